@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"trading-bot/pkg/bot"
+)
+
+// runBacktestCmd backtests the config over recent history, saves the result and
+// HTML report, and prints the summary and per-indicator attribution
+func runBacktestCmd(args []string) {
+	flags := flag.NewFlagSet("backtest", flag.ExitOnError)
+	days := flags.Int("days", 7, "Backtest window in days")
+	fee := flags.Float64("fee", 0, "Fee per fill in percent of notional (default: backtest.fee_percent)")
+	slippage := flags.Float64("slippage", 0, "Slippage per fill in basis points (default: backtest.slippage_bps)")
+	verbose := flags.Bool("verbose", false, "Show bot logs while backtesting")
+	flags.Parse(args)
+
+	// Only flags given on the command line override the config
+	var feePercent, slippageBps *float64
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "fee":
+			feePercent = fee
+		case "slippage":
+			slippageBps = slippage
+		}
+	})
+
+	configManager := bot.NewConfigManager("config.json")
+	if err := configManager.Load(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := configManager.GetConfig()
+
+	fmt.Printf("🧪 Backtesting %s over %d days\n", config.Symbol, *days)
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	result, err := bot.NewTradingBot(config).RunBacktest(*days, feePercent, slippageBps)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Backtest failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("📅 %s → %s, %d candles, fees %.3f%% + %.1f bps slippage per fill\n",
+		result.Start.Format("2006-01-02 15:04"), result.End.Format("2006-01-02 15:04"), result.Candles, result.FeePercent, result.SlippageBps)
+	fmt.Printf("💰 Balance %.2f -> %.2f (%.2f%%), fees paid %.2f\n", result.InitialBalance, result.FinalBalance, result.TotalReturnPercent, result.TotalFees)
+	fmt.Printf("📊 Trades %d, win rate %.1f%%, profit factor %.2f, Sharpe %.2f, max drawdown %.2f%%\n\n",
+		result.Performance.TotalTrades, result.WinRate, result.Performance.ProfitFactor, result.SharpeRatio, result.MaxDrawdownPercent)
+
+	fmt.Printf("%-22s %8s %9s %7s %12s\n", "INDICATOR", "SIGNALS", "ACCURACY", "TRADES", "PNL")
+	for _, stats := range result.IndicatorStats {
+		fmt.Printf("%-22s %8d %8.1f%% %7d %12.2f\n", stats.Name, stats.Signals, stats.Accuracy, stats.Trades, stats.AttributedPnL)
+	}
+	fmt.Printf("\n📄 Saved as %s (backtest_dir, HTML report alongside)\n", result.ID)
+}
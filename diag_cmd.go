@@ -0,0 +1,171 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"trading-bot/pkg/bot"
+)
+
+// logFlags collects repeated -log flags
+type logFlags []string
+
+func (l *logFlags) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *logFlags) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// runDiag dispatches the diag subcommands
+func runDiag(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: trading-bot diag bundle [flags] | trading-bot diag replay <bundle.tar.gz>")
+		os.Exit(2)
+	}
+	switch args[0] {
+	case "bundle":
+		runDiagBundle(args[1:])
+	case "replay":
+		runDiagReplay(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "❌ Unknown diag command %q (expected bundle or replay)\n", args[0])
+		os.Exit(2)
+	}
+}
+
+// runDiagBundle packages recent candles, signals, the redacted config and logs for a bug report
+func runDiagBundle(args []string) {
+	var logFiles logFlags
+	flags := flag.NewFlagSet("diag bundle", flag.ExitOnError)
+	days := flags.Int("days", 1, "Days of recent candles and signals to include")
+	out := flags.String("out", "", "Archive path (default diag-<timestamp>.tar.gz)")
+	flags.Var(&logFiles, "log", "Bot log file to include (repeatable)")
+	logLines := flags.Int("log-lines", 5000, "Keep only the last N lines of each log (0 = all)")
+	verbose := flags.Bool("verbose", false, "Show bot logs while replaying")
+	flags.Parse(args)
+
+	configManager := bot.NewConfigManager("config.json")
+	if err := configManager.Load(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := configManager.GetConfig()
+
+	logs := make(map[string]string, len(logFiles))
+	for _, file := range logFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to read log: %v\n", err)
+			os.Exit(1)
+		}
+		logs[file] = tailLines(string(data), *logLines)
+	}
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	candles, start, end, err := bot.NewTradingBot(config).LoadBacktestCandles(*days)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load history: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("🩺 Recording %s signals over %d days\n", config.Symbol, *days)
+	bundle, err := bot.BuildDiagBundle(config, candles, start, end, logs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to build bundle: %v\n", err)
+		os.Exit(1)
+	}
+
+	path := *out
+	if path == "" {
+		path = fmt.Sprintf("diag-%s.tar.gz", bundle.Manifest.CreatedAt.UTC().Format("20060102-150405"))
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to create %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	if err := bundle.Write(file); err != nil {
+		file.Close()
+		fmt.Fprintf(os.Stderr, "❌ Failed to write %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	if err := file.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to write %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("📦 Wrote %s: %d 5m candles, %d signals, %d logs (secrets redacted)\n",
+		path, bundle.Manifest.Candles[bot.FiveMinute.String()], bundle.Manifest.Signals, len(bundle.Manifest.Logs))
+	fmt.Printf("🔁 Reproduce with: trading-bot diag replay %s\n", path)
+}
+
+// runDiagReplay re-runs a bundle and reports signals that differ from the recorded ones
+func runDiagReplay(args []string) {
+	flags := flag.NewFlagSet("diag replay", flag.ExitOnError)
+	verbose := flags.Bool("verbose", false, "Show bot logs while replaying")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: trading-bot diag replay [-verbose] <bundle.tar.gz>")
+		os.Exit(2)
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to open bundle: %v\n", err)
+		os.Exit(1)
+	}
+	bundle, err := bot.ReadDiagBundle(file)
+	file.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	fmt.Printf("🔁 Replaying %s from %s to %s\n", bundle.Manifest.Symbol,
+		bundle.Manifest.Start.Format(time.RFC3339), bundle.Manifest.End.Format(time.RFC3339))
+	replay, err := bundle.Replay()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Replay failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("📊 %d signals, %d trades, return %.2f%%\n", replay.Signals, len(replay.Result.Trades), replay.Result.TotalReturnPercent)
+	if len(replay.Mismatches) == 0 {
+		fmt.Println("✅ Replay matches the recorded signals")
+		return
+	}
+	fmt.Printf("⚠️  %d signals differ from the recording:\n", len(replay.Mismatches))
+	for _, mismatch := range replay.Mismatches {
+		fmt.Printf("   %s\n", mismatch)
+	}
+	os.Exit(1)
+}
+
+// tailLines keeps the last n lines of text (n <= 0 keeps everything)
+func tailLines(text string, n int) string {
+	if n <= 0 {
+		return text
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= n {
+		return text
+	}
+	return strings.Join(lines[len(lines)-n:], "")
+}
@@ -47,32 +47,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/activity": {
             "get": {
-                "description": "Check if the trading bot API is healthy and running",
-                "consumes": [
+                "description": "Get a chronological timeline merging the signals, filter overrides (entries skipped by safe mode, maintenance or disabled trading), risk blocks, orders, fills and closed trades of one trading day. Days follow the session settings; only what the in-memory histories still hold is included.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get the activity timeline of a day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trading day as YYYY-MM-DD (default: today)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ActivityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/refresh-data": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Force a re-fetch of all timeframes, replacing the stored candles",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "admin"
                 ],
-                "summary": "Health check",
+                "summary": "Refresh market data",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal.HealthResponse"
+                            "$ref": "#/definitions/internal.AdminRefreshResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/predict": {
-            "get": {
-                "description": "Get prediction for 5-minute price direction (HIGHER/LOWER/NEUTRAL)",
+        "/admin/reload-credentials": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Rotate the Binance API keys in place. With an empty body, BINANCE_API_KEY/BINANCE_SECRET_KEY (or their *_FILE variants) are re-read, as on SIGHUP.",
                 "consumes": [
                     "application/json"
                 ],
@@ -80,18 +139,77 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "prediction"
+                    "admin"
+                ],
+                "summary": "Reload Binance API keys",
+                "parameters": [
+                    {
+                        "description": "New keys (omit to re-read the environment)",
+                        "name": "keys",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ReloadCredentialsRequest"
+                        }
+                    }
                 ],
-                "summary": "Predict price direction",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal.PredictionResponse"
+                            "$ref": "#/definitions/internal.AdminCredentialsResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reset-stats": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Reset performance statistics and the account/strategy daily loss counters; trade history is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Reset performance stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.AdminResetResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/internal.ErrorResponse"
                         }
@@ -105,9 +223,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/signals": {
+        "/alerts": {
             "get": {
-                "description": "Get the most recent trading signal generated by the bot",
+                "description": "List the user-defined price and indicator alerts with their trigger counts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "List alerts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.AlertListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Alert through the notifiers when a condition is met, e.g. \"RSI_5m \u003c 25\" or \"price crosses 65000\". Alerts are evaluated on every signal whether or not trading is enabled.",
                 "consumes": [
                     "application/json"
                 ],
@@ -115,14 +251,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "signals"
+                    "alerts"
+                ],
+                "summary": "Create an alert",
+                "parameters": [
+                    {
+                        "description": "Alert",
+                        "name": "alert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bot.AlertSpec"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/bot.Alert"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/alerts/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Get an alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get latest signals",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/bot.TradingSignal"
+                            "$ref": "#/definitions/bot.Alert"
                         }
                     },
                     "404": {
@@ -132,11 +313,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/status": {
-            "get": {
-                "description": "Get detailed status information about the trading bot",
+            },
+            "put": {
+                "description": "Replace an alert's spec. The alert is re-armed, so a one-shot alert that already fired becomes active again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -144,97 +323,5314 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "status"
+                    "alerts"
+                ],
+                "summary": "Update an alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alert",
+                        "name": "alert",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bot.AlertSpec"
+                        }
+                    }
                 ],
-                "summary": "Get bot status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/bot.SignalEngineStatus"
+                            "$ref": "#/definitions/bot.Alert"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "bot.IndicatorSignal": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "signal": {
-                    "$ref": "#/definitions/bot.SignalType"
-                },
-                "strength": {
-                    "description": "0-1 confidence",
-                    "type": "number"
-                },
-                "timeframe": {
-                    "$ref": "#/definitions/bot.Timeframe"
-                },
-                "timestamp": {
-                    "type": "string"
-                },
-                "value": {
-                    "description": "actual indicator value",
-                    "type": "number"
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "alerts"
+                ],
+                "summary": "Delete an alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.AlertDeleteResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "bot.SignalEngineStatus": {
-            "type": "object",
-            "properties": {
-                "data_summary": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
+        "/analytics/seasonality": {
+            "get": {
+                "description": "Get historical directional bias and volatility of the traded symbol by UTC hour-of-day and day-of-week",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get seasonality statistics",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Recompute instead of using cached statistics",
+                        "name": "refresh",
+                        "in": "query"
                     }
-                },
-                "last_signal": {
-                    "$ref": "#/definitions/bot.TradingSignal"
-                },
-                "last_update": {
-                    "type": "string"
-                },
-                "ready_status": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "boolean"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.SeasonalityStats"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
                     }
-                },
-                "running": {
-                    "type": "boolean"
-                },
-                "symbol": {
-                    "type": "string"
                 }
             }
         },
-        "bot.SignalType": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2
-            ],
-            "x-enum-varnames": [
-                "Hold",
-                "Buy",
-                "Sell"
-            ]
-        },
-        "bot.Timeframe": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3,
-                4
-            ],
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Page through state-changing operations (config changes, trading enable/disable, manual closes, safe mode exits, stats resets and credential reloads) with who made them, when, and the previous value",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the audit log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Entries per page (default: 50, max: 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entries to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "time; prefix - for descending (default: -time)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries at or after this RFC3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries before this RFC3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user name",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by action, e.g. config_change",
+                        "name": "action",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.AuditResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/backtest": {
+            "post": {
+                "description": "Replay the current config over the last N days of historical data; the result and HTML report are saved under the returned id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "backtest"
+                ],
+                "summary": "Run backtest",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days of history to simulate (default: 3, max: 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Fee per fill, % of notional (default: backtest.fee_percent)",
+                        "name": "fee_percent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Slippage per fill in basis points (default: backtest.slippage_bps)",
+                        "name": "slippage_bps",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.BacktestResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/backtest/{id}": {
+            "get": {
+                "description": "Get a saved backtest result including trades, equity curve and indicator stats",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "backtest"
+                ],
+                "summary": "Get backtest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backtest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.BacktestResult"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/backtest/{id}/report": {
+            "get": {
+                "description": "Download the self-contained HTML report (equity curve, drawdown chart, trade table, per-indicator stats)",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "backtest"
+                ],
+                "summary": "Download backtest report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backtest ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "HTML report",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/candles": {
+            "get": {
+                "description": "Get the candles the bot holds for a timeframe, oldest first; streamed for large requests",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signals"
+                ],
+                "summary": "Get candle history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Timeframe: 5m, 15m, 45m, 8h or 1d (default: 5m)",
+                        "name": "timeframe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Most recent candles to return (default: 500, 0 = all)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.CandleHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/config": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Get the config as saved to config.json, with API keys and tokens redacted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Get the current config",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.Config"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Merge a config.json document (or part of one) over the current config, put the signal settings (indicators, min_confidence, strategy, ...) into effect without a restart and save it to config.json. Credentials and the admin token are kept as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Update the config",
+                "parameters": [
+                    {
+                        "description": "Config document",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bot.Config"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ConfigUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/config/indicators/{name}/disable": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Turn a built-in or plugin indicator on or off, rebuild the signal aggregator and save config.json",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Enable or disable an indicator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Indicator name, e.g. rsi",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ConfigUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/config/indicators/{name}/enable": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Turn a built-in or plugin indicator on or off, rebuild the signal aggregator and save config.json",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Enable or disable an indicator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Indicator name, e.g. rsi",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ConfigUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/config/validate": {
+            "post": {
+                "description": "Run ValidateConfig plus cross-field checks (timeframe coverage, weight sums, risk sanity) on a submitted config.json document without applying it. Missing fields use defaults and older schema versions are migrated first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Validate a config",
+                "parameters": [
+                    {
+                        "description": "Config document",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bot.Config"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ConfigValidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/context": {
+            "get": {
+                "description": "Get the exact multi-timeframe snapshot the most recent signal or on-demand prediction was generated from: the candles per timeframe, how fresh each timeframe was, the current price and the configured price source",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signals"
+                ],
+                "summary": "Get the latest signal's context",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol (default: configured symbol)",
+                        "name": "symbol",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.ContextSnapshot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/errors": {
+            "get": {
+                "description": "Get recent engine errors (DATA_STALE, PROVIDER_DOWN, INDICATOR_ERROR, RISK_BLOCKED, LIQUIDATION_RISK) newest first, with counts by kind and severity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get recent errors",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Max errors to return (default: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by kind",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by severity (INFO, WARNING, CRITICAL)",
+                        "name": "severity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Check if the trading bot API is healthy and running",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/maintenance": {
+            "get": {
+                "description": "Get the current and next configured exchange maintenance window and whether new entries are paused",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get maintenance status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.MaintenanceStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/openapi.json": {
+            "get": {
+                "description": "Get the OpenAPI 3 document generated from the API's Go response types",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "info"
+                ],
+                "summary": "Get OpenAPI document",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/predict": {
+            "get": {
+                "description": "Analyzes 5-minute timeframe indicators to predict if price will be HIGHER/LOWER/NEUTRAL at specified time in future, includes current position and trading status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prediction"
+                ],
+                "summary": "Predict price direction + trading status for configurable timeframe in the future",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Prediction timeframe in seconds (default: 330 = 5.5 minutes, min: 60, max: 1800)",
+                        "name": "seconds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "One of the configured symbols (default: the traded symbol)",
+                        "name": "symbol",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.PredictionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/predictions": {
+            "get": {
+                "description": "Page through predictions served by /predict with sorting and filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prediction"
+                ],
+                "summary": "Get prediction history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Predictions per page (default: 50, max: 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Predictions to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timestamp or confidence; prefix - for descending (default: -timestamp)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only predictions at or after this RFC3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only predictions before this RFC3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HIGHER, LOWER or NEUTRAL",
+                        "name": "prediction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum confidence (0-1)",
+                        "name": "min_confidence",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.PredictionHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/predictions/accuracy": {
+            "get": {
+                "description": "Overall and rolling hit rate of resolved predictions, broken down by predicted direction, indicator and confidence bucket, with the latest outcomes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prediction"
+                ],
+                "summary": "Get prediction accuracy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of recent outcomes to return (default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.AccuracySummary"
+                        }
+                    }
+                }
+            }
+        },
+        "/predictions/accuracy/breakdown": {
+            "get": {
+                "description": "Hit rate, Brier score and log loss of resolved predictions segmented by direction, indicator, market regime, volatility tercile, session hour of day, confidence bucket and day",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prediction"
+                ],
+                "summary": "Get prediction accuracy breakdown",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.AccuracyBreakdown"
+                        }
+                    }
+                }
+            }
+        },
+        "/predictions/subscriptions": {
+            "get": {
+                "description": "List the webhooks receiving filtered predictions, with their delivery counts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prediction"
+                ],
+                "summary": "List prediction subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.PredictionSubscriptionListResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "POST served predictions to a webhook only when they meet min_confidence and/or flip direction. WebSocket clients set the same filter on their connection instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prediction"
+                ],
+                "summary": "Create a prediction subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal.PredictionSubscriptionSpec"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal.PredictionSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/predictions/subscriptions/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prediction"
+                ],
+                "summary": "Delete a prediction subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.PredictionSubscriptionDeleteResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/price/index": {
+            "get": {
+                "description": "Get the median price across configured venues (Binance, Coinbase, Kraken) with per-venue components",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "prediction"
+                ],
+                "summary": "Get index price",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol (default: configured symbol)",
+                        "name": "symbol",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.IndexPrice"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/risk": {
+            "get": {
+                "description": "Get long, short and net exposure per symbol and in total, with each symbol scaled by its rolling beta to the benchmark (BTCUSDT by default) to show the directional crypto exposure",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get risk exposure",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.RiskReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/risk/scenario": {
+            "post": {
+                "description": "Apply hypothetical instantaneous price moves to the signal position, spot holdings and hedges, and report the PnL, the stops the move gaps through and the position's margin ratio after each one. An empty body evaluates the default shocks.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Run a price shock scenario",
+                "parameters": [
+                    {
+                        "description": "Shocks",
+                        "name": "scenario",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ScenarioRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.ScenarioReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/signals": {
+            "get": {
+                "description": "Get the most recent trading signal generated by the bot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signals"
+                ],
+                "summary": "Get latest signals",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.TradingSignal"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/signals/history": {
+            "get": {
+                "description": "Page through recently generated signals with sorting and filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signals"
+                ],
+                "summary": "Get signal history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Signals per page (default: 50, max: 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Signals to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timestamp or confidence; prefix - for descending (default: -timestamp)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only signals at or after this RFC3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only signals before this RFC3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "BUY, SELL or HOLD",
+                        "name": "signal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum confidence (0-1)",
+                        "name": "min_confidence",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.SignalHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "Get detailed status information about the trading bot, with a section per configured symbol under symbols",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "status"
+                ],
+                "summary": "Get bot status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.SignalEngineStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/stream": {
+            "get": {
+                "description": "Stream signals, position updates, trades, predictions and errors as server-sent events (event name = type, data = JSON event)",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "signals"
+                ],
+                "summary": "Stream events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated event types to include (default: all)",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "One event per SSE message",
+                        "schema": {
+                            "$ref": "#/definitions/bot.Event"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/close": {
+            "post": {
+                "description": "Manually close the current open trading position",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Force close position",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.TradingControlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.TradingControlError"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/disable": {
+            "post": {
+                "description": "Disable Pine Script ATR strategy trade execution",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Disable trading",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.TradingControlResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/enable": {
+            "post": {
+                "description": "Enable Pine Script ATR strategy trade execution",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Enable trading",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.TradingControlResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/execution-quality": {
+            "get": {
+                "description": "Get average slippage (bps) against the signal price, stop level or order price, and decision-to-fill latency percentiles, with the most recent fills",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get execution quality",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of recent fills to return (default: 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.ExecutionQuality"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/hedges": {
+            "get": {
+                "description": "Get open hedge positions, the current market regime and recent hedging rule decisions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get hedges",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of audit entries to return (default: 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.HedgeStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/history": {
+            "get": {
+                "description": "Get recent trade history for Pine Script ATR strategy",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get trade history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of trades to return (default: 10, 0 = all)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trades to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "exit_time, entry_time, pnl or pnl_percent; prefix - for descending (default: -exit_time)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only trades closed at or after this RFC3339 time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only trades closed before this RFC3339 time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "LONG or SHORT",
+                        "name": "side",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Strategy name",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "e.g. ATR_STOP, TAKE_PROFIT, MANUAL",
+                        "name": "exit_reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.TradeHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/history/{id}/replay": {
+            "get": {
+                "description": "Get the 5-minute candles and signals spanning a closed trade, with entry/exit candle indexes for rendering; pending until the candles after the exit have arrived",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get trade replay",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trade ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.TradeReplay"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/position": {
+            "get": {
+                "description": "Get current open trading position for Pine Script ATR strategy",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get current position",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.PositionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/safe-mode/exit": {
+            "post": {
+                "description": "Resume new entries after an exchange outage put the bot into safe mode",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Exit safe mode",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal.SafeModeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/status": {
+            "get": {
+                "description": "Get current Pine Script ATR trading strategy status including positions and performance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get trading status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/bot.TradingStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/trading/tax-report": {
+            "get": {
+                "description": "Assemble closed-lot records with cost basis using FIFO or LIFO lot selection, exported as CSV (or JSON with format=json)",
+                "produces": [
+                    "text/csv",
+                    "application/json"
+                ],
+                "tags": [
+                    "trading"
+                ],
+                "summary": "Get tax lot report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lot selection method: FIFO or LIFO (default: FIFO)",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include lots closed in this calendar year (UTC)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Output format: csv or json (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "With format=json; CSV rows otherwise",
+                        "schema": {
+                            "$ref": "#/definitions/internal.TaxReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "description": "Upgrade to a WebSocket that pushes signals, position PnL updates, closed trades, predictions and errors as JSON events. Change topics by sending {\"action\": \"subscribe\"|\"unsubscribe\", \"topics\": [...]}; each request is acknowledged with the current topics. A subscribe request's \"filter\" ({\"min_confidence\": 0.7, \"on_flip\": true}) limits predictions to actionable ones.",
+                "tags": [
+                    "signals"
+                ],
+                "summary": "Stream events over a WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated topics to start with (default: none)",
+                        "name": "topics",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "One event per message",
+                        "schema": {
+                            "$ref": "#/definitions/bot.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "bot.APIUser": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "\"viewer\", \"trader\" or \"admin\"",
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ATRConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable ATR",
+                    "type": "boolean"
+                },
+                "multiplier": {
+                    "description": "ATR multiplier for trailing stop (default: 3.5)",
+                    "type": "number"
+                },
+                "period": {
+                    "description": "ATR calculation period (default: 5)",
+                    "type": "integer"
+                },
+                "use_shorts": {
+                    "description": "Allow short signals (default: false for spot trading)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "bot.AccountConfig": {
+            "type": "object",
+            "properties": {
+                "compounding": {
+                    "description": "Size positions from the current balance instead of InitialBalance",
+                    "type": "boolean"
+                },
+                "currency": {
+                    "description": "Currency of InitialBalance; must be the margin currency (default: the margin currency)",
+                    "type": "string"
+                },
+                "initial_balance": {
+                    "description": "Starting balance for paper trading and backtests",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.AccuracyBreakdown": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "description": "10-point confidence buckets",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "days": {
+                    "description": "Trading day the prediction was made, to follow calibration over time",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "directions": {
+                    "description": "Predicted direction: HIGHER, LOWER, NEUTRAL",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "hours": {
+                    "description": "Session-local hour the prediction was made",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "indicators": {
+                    "description": "Each indicator's own vote scored against the outcome",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "overall": {
+                    "$ref": "#/definitions/bot.AccuracySegment"
+                },
+                "pending": {
+                    "description": "Predictions whose target time hasn't been evaluated yet",
+                    "type": "integer"
+                },
+                "regimes": {
+                    "description": "TRENDING, RANGING, VOLATILE",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "rolling": {
+                    "description": "The latest resolved predictions only, to follow recent accuracy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.AccuracySegment"
+                        }
+                    ]
+                },
+                "volatility": {
+                    "description": "Terciles of the resolved predictions' volatility",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                }
+            }
+        },
+        "bot.AccuracySegment": {
+            "type": "object",
+            "properties": {
+                "brier_score": {
+                    "description": "Mean multi-class Brier score, lower is better",
+                    "type": "number"
+                },
+                "correct": {
+                    "type": "integer"
+                },
+                "hit_rate": {
+                    "description": "% correct",
+                    "type": "number"
+                },
+                "log_loss": {
+                    "description": "Mean log loss, lower is better",
+                    "type": "number"
+                },
+                "predictions": {
+                    "type": "integer"
+                },
+                "segment": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.AccuracySummary": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "directions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "indicators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AccuracySegment"
+                    }
+                },
+                "overall": {
+                    "$ref": "#/definitions/bot.AccuracySegment"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "recent": {
+                    "description": "Latest resolved predictions, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.TrackedPrediction"
+                    }
+                },
+                "rolling": {
+                    "$ref": "#/definitions/bot.AccuracySegment"
+                }
+            }
+        },
+        "bot.ActivityEvent": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "description": "The signal, skipped entry, error, order, fill or trade"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/bot.ActivityType"
+                }
+            }
+        },
+        "bot.ActivityType": {
+            "type": "string",
+            "enum": [
+                "signal",
+                "filter_override",
+                "risk_block",
+                "order",
+                "trade"
+            ],
+            "x-enum-comments": {
+                "ActivityFilterOverride": "Entry signal kept from trading by safe mode, maintenance or the enable switch"
+            },
+            "x-enum-varnames": [
+                "ActivitySignal",
+                "ActivityFilterOverride",
+                "ActivityRiskBlock",
+                "ActivityOrder",
+                "ActivityTrade"
+            ]
+        },
+        "bot.AdminConfig": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "description": "Bearer token required by admin endpoints (empty disables them; ADMIN_TOKEN overrides)",
+                    "type": "string"
+                },
+                "users": {
+                    "description": "Named tokens with a role; when set, every API route but health needs one",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.APIUser"
+                    }
+                }
+            }
+        },
+        "bot.Alert": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "False once a one-shot alert has fired",
+                    "type": "boolean"
+                },
+                "condition": {
+                    "description": "\"price\" or an indicator name, an operator (\u003c, \u003c=, \u003e, \u003e=, crosses, crosses_above, crosses_below) and a threshold",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_triggered": {
+                    "type": "string"
+                },
+                "last_value": {
+                    "description": "Latest value of the watched price or indicator",
+                    "type": "number"
+                },
+                "level": {
+                    "description": "INFO (default), WARNING or CRITICAL",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Sent instead of the generated text",
+                    "type": "string"
+                },
+                "repeat": {
+                    "description": "Fire again each time the condition is met anew; otherwise once",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "description": "Default: the traded symbol",
+                    "type": "string"
+                },
+                "trigger_count": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.AlertSpec": {
+            "type": "object",
+            "properties": {
+                "condition": {
+                    "description": "\"price\" or an indicator name, an operator (\u003c, \u003c=, \u003e, \u003e=, crosses, crosses_above, crosses_below) and a threshold",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "INFO (default), WARNING or CRITICAL",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Sent instead of the generated text",
+                    "type": "string"
+                },
+                "repeat": {
+                    "description": "Fire again each time the condition is met anew; otherwise once",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "description": "Default: the traded symbol",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "previous": {
+                    "description": "Value before the change",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "role": {
+                    "description": "The user's role",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Config key or symbol acted on",
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "user": {
+                    "description": "API user, or \"config-file\" for edits on disk",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value after the change",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "bot.BacktestConfig": {
+            "type": "object",
+            "properties": {
+                "fee_percent": {
+                    "description": "Fee per fill, % of notional",
+                    "type": "number"
+                },
+                "slippage_bps": {
+                    "description": "Adverse fill price move per fill, basis points",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.BacktestResult": {
+            "type": "object",
+            "properties": {
+                "candles": {
+                    "description": "5-minute candles simulated",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "directional_signals": {
+                    "description": "Final BUY/SELL signals scored",
+                    "type": "integer"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "equity_curve": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.EquityPoint"
+                    }
+                },
+                "fee_percent": {
+                    "description": "Simulated fee per fill, % of notional",
+                    "type": "number"
+                },
+                "final_balance": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "indicator_stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.IndicatorBacktestStats"
+                    }
+                },
+                "initial_balance": {
+                    "type": "number"
+                },
+                "max_drawdown_percent": {
+                    "type": "number"
+                },
+                "performance": {
+                    "$ref": "#/definitions/bot.PerformanceStats"
+                },
+                "sharpe_ratio": {
+                    "description": "Annualized from per-candle equity returns",
+                    "type": "number"
+                },
+                "signal_accuracy": {
+                    "description": "% of final BUY/SELL signals matching the next candle",
+                    "type": "number"
+                },
+                "slippage_bps": {
+                    "description": "Simulated slippage per fill",
+                    "type": "number"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "total_fees": {
+                    "type": "number"
+                },
+                "total_return_percent": {
+                    "type": "number"
+                },
+                "trades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Trade"
+                    }
+                },
+                "win_rate": {
+                    "description": "%",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.BinanceConfig": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "secret_key": {
+                    "type": "string"
+                },
+                "use_testnet": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "bot.BollingerBandsConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable Bollinger Bands",
+                    "type": "boolean"
+                },
+                "overbought_std": {
+                    "description": "Overbought threshold (default: 0.8)",
+                    "type": "number"
+                },
+                "oversold_std": {
+                    "description": "Oversold threshold (default: 0.2)",
+                    "type": "number"
+                },
+                "period": {
+                    "description": "Period for moving average and std dev (default: 20)",
+                    "type": "integer"
+                },
+                "standard_dev": {
+                    "description": "Standard deviation multiplier (default: 2.0)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.Candle": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "number"
+                },
+                "high": {
+                    "type": "number"
+                },
+                "low": {
+                    "type": "number"
+                },
+                "open": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "bot.CandlePatternsConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable candlestick patterns",
+                    "type": "boolean"
+                },
+                "patterns": {
+                    "description": "Patterns detected (default: all, see indicator.CandlePatternNames)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tweezer_tolerance": {
+                    "description": "Max gap between tweezer extremes as a fraction of the average range (default: 0.05)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.CandleStoreConfig": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "description": "\"sqlite\" (default) or \"memory\"",
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "path": {
+                    "description": "SQLite database file",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ChannelAnalysisConfig": {
+            "type": "object",
+            "properties": {
+                "channel_threshold": {
+                    "description": "Threshold for channel detection (default: 0.2%)",
+                    "type": "number"
+                },
+                "enabled": {
+                    "description": "Feature flag to enable/disable Channel Analysis",
+                    "type": "boolean"
+                },
+                "lookback_period": {
+                    "description": "Period for pivot point analysis (default: 20)",
+                    "type": "integer"
+                },
+                "signal_boost": {
+                    "description": "Boost factor for channel signals (default: 1.4)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.Config": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "description": "Starting balance and compounding",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.AccountConfig"
+                        }
+                    ]
+                },
+                "admin": {
+                    "description": "Operator endpoints",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.AdminConfig"
+                        }
+                    ]
+                },
+                "alerts_file": {
+                    "description": "JSON file price and indicator alerts are persisted to (empty disables)",
+                    "type": "string"
+                },
+                "atr": {
+                    "$ref": "#/definitions/bot.ATRConfig"
+                },
+                "audit_file": {
+                    "description": "JSON-lines file the audit log is appended to (empty keeps it in memory)",
+                    "type": "string"
+                },
+                "backtest": {
+                    "description": "Simulated trading costs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.BacktestConfig"
+                        }
+                    ]
+                },
+                "backtest_dir": {
+                    "description": "Directory backtest results and HTML reports are written to",
+                    "type": "string"
+                },
+                "binance": {
+                    "$ref": "#/definitions/bot.BinanceConfig"
+                },
+                "bollinger_bands": {
+                    "$ref": "#/definitions/bot.BollingerBandsConfig"
+                },
+                "bybit": {
+                    "$ref": "#/definitions/bot.ExchangeCredentials"
+                },
+                "candle_patterns": {
+                    "$ref": "#/definitions/bot.CandlePatternsConfig"
+                },
+                "candle_store": {
+                    "description": "Candle persistence; also selectable per timeframe as the \"store\" provider",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.CandleStoreConfig"
+                        }
+                    ]
+                },
+                "candle_transforms": {
+                    "description": "Candle transform applied before an indicator calculates, keyed by indicator\nname or alias, e.g. {\"trend\": \"heikin_ashi\"}; unlisted indicators use raw OHLC",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "channel_analysis": {
+                    "$ref": "#/definitions/bot.ChannelAnalysisConfig"
+                },
+                "coinbase": {
+                    "$ref": "#/definitions/bot.ExchangeCredentials"
+                },
+                "continuous": {
+                    "description": "Back-adjusted series across delivery futures rolls",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.ContinuousConfig"
+                        }
+                    ]
+                },
+                "contract": {
+                    "description": "Linear or inverse (coin-margined) settlement",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.ContractConfig"
+                        }
+                    ]
+                },
+                "correlation": {
+                    "description": "Cap on equity held in correlated symbols",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.CorrelationConfig"
+                        }
+                    ]
+                },
+                "data_dir": {
+                    "description": "Directory for the \"file\" provider",
+                    "type": "string"
+                },
+                "data_provider": {
+                    "description": "\"sample\" or an exchange: binance, coinbase, kraken, bybit",
+                    "type": "string"
+                },
+                "data_quality": {
+                    "description": "Confidence penalties for gapped or fallback candles",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.DataQualityConfig"
+                        }
+                    ]
+                },
+                "determinism": {
+                    "description": "Fixed seeds and a frozen clock for reproducible reports",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.DeterminismConfig"
+                        }
+                    ]
+                },
+                "divergence": {
+                    "description": "Price/oscillator divergence signals",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.DivergenceConfig"
+                        }
+                    ]
+                },
+                "donchian_channel": {
+                    "$ref": "#/definitions/bot.DonchianChannelConfig"
+                },
+                "elliott_wave": {
+                    "$ref": "#/definitions/bot.ElliottWaveConfig"
+                },
+                "ema": {
+                    "$ref": "#/definitions/bot.EMAConfig"
+                },
+                "event_export": {
+                    "description": "Kafka/NATS event streaming",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.EventExportConfig"
+                        }
+                    ]
+                },
+                "fees": {
+                    "description": "Exchange fee tier and BNB discount",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.FeeConfig"
+                        }
+                    ]
+                },
+                "funding": {
+                    "description": "Funding and borrow costs accrued into position PnL",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.FundingConfig"
+                        }
+                    ]
+                },
+                "heartbeat": {
+                    "description": "Dead-man's switch pings",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.HeartbeatConfig"
+                        }
+                    ]
+                },
+                "hedging": {
+                    "description": "Exposure hedging rules",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.HedgingConfig"
+                        }
+                    ]
+                },
+                "history": {
+                    "description": "Candle history loaded per timeframe",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.HistoryConfig"
+                        }
+                    ]
+                },
+                "ichimoku": {
+                    "$ref": "#/definitions/bot.IchimokuConfig"
+                },
+                "keltner_channel": {
+                    "$ref": "#/definitions/bot.KeltnerChannelConfig"
+                },
+                "kraken": {
+                    "$ref": "#/definitions/bot.ExchangeCredentials"
+                },
+                "live_trading": {
+                    "description": "Real order placement on Binance (off by default)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.LiveTradingConfig"
+                        }
+                    ]
+                },
+                "macd": {
+                    "$ref": "#/definitions/bot.MACDConfig"
+                },
+                "maintenance": {
+                    "description": "Scheduled exchange downtime",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.MaintenanceConfig"
+                        }
+                    ]
+                },
+                "margin": {
+                    "description": "Futures leverage caps and liquidation alerts",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.MarginConfig"
+                        }
+                    ]
+                },
+                "mfi": {
+                    "$ref": "#/definitions/bot.MFIConfig"
+                },
+                "min_confidence": {
+                    "type": "number"
+                },
+                "mqtt": {
+                    "description": "MQTT broker connection",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.MQTTConfig"
+                        }
+                    ]
+                },
+                "nightly_backtest": {
+                    "description": "Scheduled validation of the live config",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.NightlyBacktestConfig"
+                        }
+                    ]
+                },
+                "notifications": {
+                    "description": "Alert delivery",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.NotificationsConfig"
+                        }
+                    ]
+                },
+                "parabolic_sar": {
+                    "$ref": "#/definitions/bot.ParabolicSARConfig"
+                },
+                "pin_bar": {
+                    "$ref": "#/definitions/bot.PinBarConfig"
+                },
+                "pine": {
+                    "description": "TradingView studies run as extra indicators",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.PineConfig"
+                        }
+                    ]
+                },
+                "plugin_files": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prediction": {
+                    "description": "How served predictions are scored",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.PredictionConfig"
+                        }
+                    ]
+                },
+                "price_index": {
+                    "$ref": "#/definitions/bot.PriceIndexConfig"
+                },
+                "price_source": {
+                    "description": "Price used for predictions and PnL marking: \"last\" trade, exchange \"mark\"\nprice, order book \"mid\" or multi-venue \"index\"; PriceSources overrides it per symbol",
+                    "type": "string"
+                },
+                "price_sources": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "providers": {
+                    "description": "Per-timeframe provider overrides keyed by timeframe (\"5m\", \"1d\", ...);\ntimeframes not listed use DataProvider",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.TimeframeProviderConfig"
+                    }
+                },
+                "quote_currency": {
+                    "description": "Quote asset of Symbol (derived from Symbol when empty)",
+                    "type": "string"
+                },
+                "rebalance": {
+                    "description": "Passive allocation strategy",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.RebalanceConfig"
+                        }
+                    ]
+                },
+                "reconciliation": {
+                    "description": "Exchange order/position state polling",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.ReconciliationConfig"
+                        }
+                    ]
+                },
+                "regime_switching": {
+                    "description": "Per-regime indicator weights/thresholds",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.RegimeSwitchingConfig"
+                        }
+                    ]
+                },
+                "reporting_currency": {
+                    "description": "Currency PnL is reported in (default: USDT)",
+                    "type": "string"
+                },
+                "rsi": {
+                    "$ref": "#/definitions/bot.RSIConfig"
+                },
+                "safe_mode": {
+                    "description": "Exchange outage detection",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.SafeModeConfig"
+                        }
+                    ]
+                },
+                "scripting": {
+                    "description": "User entry/exit rules loaded from a script file",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.ScriptingConfig"
+                        }
+                    ]
+                },
+                "seasonality": {
+                    "description": "Hour-of-day / day-of-week statistics",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.SeasonalityConfig"
+                        }
+                    ]
+                },
+                "session": {
+                    "description": "Trading-day boundary for daily loss limits",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.SessionConfig"
+                        }
+                    ]
+                },
+                "stochastic": {
+                    "$ref": "#/definitions/bot.StochasticConfig"
+                },
+                "strategy": {
+                    "description": "How indicator signals are combined",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.StrategyConfig"
+                        }
+                    ]
+                },
+                "strategy_allocations": {
+                    "description": "Capital fraction and risk budget per strategy name (e.g. \"ATR_PINE_SCRIPT\", \"REBALANCE\");\nstrategies not listed share the whole account",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.StrategyAllocation"
+                    }
+                },
+                "streaming": {
+                    "description": "WebSocket klines with REST fallback",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.StreamingConfig"
+                        }
+                    ]
+                },
+                "support_resistance": {
+                    "$ref": "#/definitions/bot.SupportResistanceConfig"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "symbol_precision": {
+                    "description": "Tick/step sizes per symbol, used until (or instead of) exchange metadata,\ne.g. {\"DOGEUSDT\": {\"tick_size\": 0.00001, \"step_size\": 1}}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.SymbolPrecision"
+                    }
+                },
+                "symbol_selector": {
+                    "description": "Symbols picked from the exchange by volume, alongside Symbols",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.SymbolSelectorConfig"
+                        }
+                    ]
+                },
+                "symbols": {
+                    "description": "More markets analyzed alongside Symbol, each with its own engine",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "trade_history_file": {
+                    "description": "JSON file closed trades are persisted to (empty disables)",
+                    "type": "string"
+                },
+                "trailing_stop": {
+                    "description": "ATR or Parabolic SAR trailing stops",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.TrailingStopConfig"
+                        }
+                    ]
+                },
+                "trend": {
+                    "$ref": "#/definitions/bot.TrendConfig"
+                },
+                "version": {
+                    "description": "Schema version; older files are migrated on load",
+                    "type": "integer"
+                },
+                "volume": {
+                    "$ref": "#/definitions/bot.VolumeConfig"
+                },
+                "williams_r": {
+                    "$ref": "#/definitions/bot.WilliamsRConfig"
+                }
+            }
+        },
+        "bot.ConfigProblem": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "JSON path of the offending setting, e.g. \"atr.multiplier\"",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "severity": {
+                    "description": "\"error\" or \"warning\"",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ContextSnapshot": {
+            "type": "object",
+            "properties": {
+                "captured_at": {
+                    "type": "string"
+                },
+                "context": {
+                    "$ref": "#/definitions/bot.MultiTimeframeContext"
+                },
+                "current_price": {
+                    "description": "Latest 5m close the aggregator saw",
+                    "type": "number"
+                },
+                "data_timing": {
+                    "$ref": "#/definitions/bot.DataTiming"
+                },
+                "freshness": {
+                    "description": "Keyed by timeframe, e.g. \"5m\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.TimeframeFreshness"
+                    }
+                },
+                "price_source": {
+                    "description": "Configured price source for predictions and marking",
+                    "type": "string"
+                },
+                "signal": {
+                    "$ref": "#/definitions/bot.TradingSignal"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "trigger": {
+                    "description": "\"engine\" (scheduled) or \"prediction\" (on demand)",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ContinuousConfig": {
+            "type": "object",
+            "properties": {
+                "adjustment": {
+                    "description": "\"difference\" (default) or \"ratio\"",
+                    "type": "string"
+                },
+                "contracts": {
+                    "description": "Delivery contracts of Symbol, e.g. BTCUSDT_240329 (Binance) or BTCUSDT-29MAR24 (Bybit)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "roll_days": {
+                    "description": "Days before expiry the series moves to the next contract (default: 3)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.ContractConfig": {
+            "type": "object",
+            "properties": {
+                "contract_size": {
+                    "description": "Quote value of one inverse contract, e.g. 100 (USD) for BTCUSD perpetuals",
+                    "type": "number"
+                },
+                "type": {
+                    "description": "\"linear\" or \"inverse\"",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.CorrelationConfig": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "\"block\" the entry or \"downsize\" it to fit (default)",
+                    "type": "string"
+                },
+                "beta_benchmark": {
+                    "description": "Symbol /risk scales exposure against (default: BTCUSDT)",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "interval_minutes": {
+                    "description": "Return sampling interval (default: 5)",
+                    "type": "integer"
+                },
+                "max_concentration": {
+                    "description": "Max fraction of equity in one correlated cluster (default: 0.5)",
+                    "type": "number"
+                },
+                "threshold": {
+                    "description": "Held symbols correlated at or above this join the entry's cluster (default: 0.7)",
+                    "type": "number"
+                },
+                "window": {
+                    "description": "Returns in the rolling window (default: 96, i.e. 8 hours of 5m returns)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.DataQuality": {
+            "type": "object",
+            "properties": {
+                "fallback": {
+                    "description": "Candles last refreshed over the REST fallback",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "gaps": {
+                    "description": "Candles missing between the ones analyzed",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "stale": {
+                    "description": "Feeds the watchdog found silent",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "bot.DataQualityConfig": {
+            "type": "object",
+            "properties": {
+                "fallback_confidence": {
+                    "description": "Multiplier while candles come from the REST fallback",
+                    "type": "number"
+                },
+                "gap_confidence": {
+                    "description": "Multiplier when candles are missing between the ones analyzed",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.DataTiming": {
+            "type": "object",
+            "properties": {
+                "candle_close_time": {
+                    "description": "May be in the future for a forming candle",
+                    "type": "string"
+                },
+                "candle_open_time": {
+                    "description": "Latest candle used",
+                    "type": "string"
+                },
+                "data_timestamp": {
+                    "description": "Best estimate of when the data was valid",
+                    "type": "string"
+                },
+                "fetch_completed": {
+                    "type": "string"
+                },
+                "fetch_latency": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "fetch_started": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.DeterminismConfig": {
+            "type": "object",
+            "properties": {
+                "clock": {
+                    "description": "RFC3339 time the frozen wall clock reports (default: 2024-01-01T00:00:00Z)",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "seed": {
+                    "description": "Seed for every random source (sample data, generated test candles)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.DivergenceConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "hidden": {
+                    "description": "Also signal hidden (trend continuation) divergences",
+                    "type": "boolean"
+                },
+                "indicators": {
+                    "description": "Oscillators checked: rsi, macd, mfi, stochastic (names or aliases)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lookback": {
+                    "description": "Candles searched for the last two swings (default: 60)",
+                    "type": "integer"
+                },
+                "pivot_bars": {
+                    "description": "Candles on each side that confirm a swing high/low (default: 3)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.DonchianChannelConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable the Donchian Channel",
+                    "type": "boolean"
+                },
+                "mode": {
+                    "description": "\"breakout\" (default) or \"mean_reversion\"",
+                    "type": "string"
+                },
+                "period": {
+                    "description": "Previous candles the channel spans (default: 20)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.EMAConfig": {
+            "type": "object",
+            "properties": {
+                "crossover_boost": {
+                    "description": "Boost factor for crossover signals (default: 1.3)",
+                    "type": "number"
+                },
+                "enabled": {
+                    "description": "Feature flag to enable/disable EMA",
+                    "type": "boolean"
+                },
+                "fast_period": {
+                    "description": "Fast EMA period (default: 12)",
+                    "type": "integer"
+                },
+                "signal_period": {
+                    "description": "Signal line period (default: 9)",
+                    "type": "integer"
+                },
+                "slope_threshold": {
+                    "description": "Minimum slope for trend detection (default: 0.0001)",
+                    "type": "number"
+                },
+                "slow_period": {
+                    "description": "Slow EMA period (default: 26)",
+                    "type": "integer"
+                },
+                "trend_boost": {
+                    "description": "Boost factor for trend alignment (default: 1.2)",
+                    "type": "number"
+                },
+                "trend_period": {
+                    "description": "Trend EMA period (default: 50)",
+                    "type": "integer"
+                },
+                "volume_confirm": {
+                    "description": "Require volume confirmation (default: false)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "bot.ElliottWaveConfig": {
+            "type": "object",
+            "properties": {
+                "completion_boost": {
+                    "description": "Boost factor for wave completions (default: 1.5)",
+                    "type": "number"
+                },
+                "correction_boost": {
+                    "description": "Boost factor for correction waves (default: 1.2)",
+                    "type": "number"
+                },
+                "enabled": {
+                    "description": "Feature flag to enable/disable Elliott Wave",
+                    "type": "boolean"
+                },
+                "fibonacci_tolerance": {
+                    "description": "Fibonacci retracement tolerance (default: 0.1)",
+                    "type": "number"
+                },
+                "impulse_boost": {
+                    "description": "Boost factor for impulse waves (default: 1.4)",
+                    "type": "number"
+                },
+                "max_lookback": {
+                    "description": "Maximum lookback periods (default: 100)",
+                    "type": "integer"
+                },
+                "min_wave_length": {
+                    "description": "Minimum wave length in periods (default: 5)",
+                    "type": "integer"
+                },
+                "trend_strength": {
+                    "description": "Minimum trend strength for wave detection (default: 0.02)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.EquityPoint": {
+            "type": "object",
+            "properties": {
+                "drawdown": {
+                    "description": "% below the running peak",
+                    "type": "number"
+                },
+                "equity": {
+                    "type": "number"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ErrorKind": {
+            "type": "string",
+            "enum": [
+                "DATA_STALE",
+                "PROVIDER_DOWN",
+                "INDICATOR_ERROR",
+                "RISK_BLOCKED",
+                "LIQUIDATION_RISK"
+            ],
+            "x-enum-comments": {
+                "ErrDataStale": "Latest candles are older than expected",
+                "ErrIndicatorError": "Signal generation failed on the data we have",
+                "ErrLiquidation": "Open position is close to its liquidation price",
+                "ErrProviderDown": "Exchange/data provider requests failing",
+                "ErrRiskBlocked": "Risk management rejected a trade"
+            },
+            "x-enum-varnames": [
+                "ErrDataStale",
+                "ErrProviderDown",
+                "ErrIndicatorError",
+                "ErrRiskBlocked",
+                "ErrLiquidation"
+            ]
+        },
+        "bot.ErrorRecord": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "$ref": "#/definitions/bot.ErrorKind"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ErrorStats": {
+            "type": "object",
+            "properties": {
+                "by_kind": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "by_severity": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "last_error": {
+                    "$ref": "#/definitions/bot.ErrorRecord"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.Event": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "id": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/bot.EventType"
+                }
+            }
+        },
+        "bot.EventExportConfig": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "description": "\"kafka\" or \"nats\"",
+                    "type": "string"
+                },
+                "brokers": {
+                    "description": "Kafka host:port list or NATS URLs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "max_pending": {
+                    "description": "Undelivered events kept while the broker is down (0 = unbounded)",
+                    "type": "integer"
+                },
+                "retry_backoff_ms": {
+                    "description": "Initial delay between delivery retries (doubles up to 30s)",
+                    "type": "integer"
+                },
+                "topics": {
+                    "description": "Topic/subject per event type; unlisted events aren't exported",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "bot.EventExportStatus": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "type": "string"
+                },
+                "dropped": {
+                    "description": "Oldest events discarded because the pending queue was full",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exported": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_success": {
+                    "type": "string"
+                },
+                "pending": {
+                    "type": "integer"
+                },
+                "retries": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.EventType": {
+            "type": "string",
+            "enum": [
+                "signal",
+                "trade",
+                "position",
+                "prediction",
+                "error"
+            ],
+            "x-enum-comments": {
+                "EventError": "ErrorRecord reported by the engine or executor",
+                "EventPosition": "*Position marked to market after a signal",
+                "EventPrediction": "Prediction served by the API",
+                "EventSignal": "*TradingSignal from the engine",
+                "EventTrade": "*Trade closed by the executor"
+            },
+            "x-enum-varnames": [
+                "EventSignal",
+                "EventTrade",
+                "EventPosition",
+                "EventPrediction",
+                "EventError"
+            ]
+        },
+        "bot.ExchangeCredentials": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "passphrase": {
+                    "description": "Coinbase only",
+                    "type": "string"
+                },
+                "secret_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ExecutionQuality": {
+            "type": "object",
+            "properties": {
+                "average_latency_ms": {
+                    "type": "number"
+                },
+                "average_slippage_bps": {
+                    "type": "number"
+                },
+                "fills": {
+                    "type": "integer"
+                },
+                "max_latency_ms": {
+                    "type": "number"
+                },
+                "p50_latency_ms": {
+                    "type": "number"
+                },
+                "p95_latency_ms": {
+                    "type": "number"
+                },
+                "recent": {
+                    "description": "Newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.ExecutionRecord"
+                    }
+                },
+                "total_slippage_cost": {
+                    "type": "number"
+                },
+                "worst_slippage_bps": {
+                    "type": "number"
+                }
+            }
+        },
+        "bot.ExecutionRecord": {
+            "type": "object",
+            "properties": {
+                "decided_at": {
+                    "type": "string"
+                },
+                "fill_price": {
+                    "type": "number"
+                },
+                "filled_at": {
+                    "type": "string"
+                },
+                "intended_price": {
+                    "description": "Signal price, stop level or order limit price",
+                    "type": "number"
+                },
+                "latency_ms": {
+                    "description": "Decision to fill",
+                    "type": "number"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "reason": {
+                    "description": "\"ENTRY\", or the exit reason",
+                    "type": "string"
+                },
+                "side": {
+                    "description": "\"BUY\" or \"SELL\"",
+                    "type": "string"
+                },
+                "slippage_bps": {
+                    "description": "Adverse move from the intended price, negative when price improved",
+                    "type": "number"
+                },
+                "slippage_cost": {
+                    "description": "Quote currency lost to slippage",
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ExposureSnapshot": {
+            "type": "object",
+            "properties": {
+                "long": {
+                    "description": "Spot holdings plus long signal position",
+                    "type": "number"
+                },
+                "net": {
+                    "type": "number"
+                },
+                "short": {
+                    "description": "Hedges plus short signal position",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.FeeConfig": {
+            "type": "object",
+            "properties": {
+                "bnb_discount": {
+                    "description": "Fees are paid in BNB at a discount",
+                    "type": "boolean"
+                },
+                "bnb_discount_percent": {
+                    "description": "Discount for paying in BNB (default: 10, the futures rate; spot is 25)",
+                    "type": "number"
+                },
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "maker_percent": {
+                    "description": "Overrides the tier's maker fee, % of notional (0 keeps the tier's)",
+                    "type": "number"
+                },
+                "taker_percent": {
+                    "description": "Overrides the tier's taker fee, % of notional (0 keeps the tier's)",
+                    "type": "number"
+                },
+                "tier": {
+                    "description": "Binance USDⓈ-M futures VIP tier, VIP0-VIP9 (default: VIP0)",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.FundingConfig": {
+            "type": "object",
+            "properties": {
+                "borrow_rate": {
+                    "description": "Annual interest on the position notional for borrowed margin (default: 0)",
+                    "type": "number"
+                },
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "interval_hours": {
+                    "description": "Hours between funding settlements, counted from 00:00 UTC (default: 8)",
+                    "type": "integer"
+                },
+                "rate": {
+                    "description": "Funding rate per settlement when no live rate is known (default: 0.0001); longs pay positive rates",
+                    "type": "number"
+                },
+                "rates": {
+                    "description": "Per-symbol rate overrides",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "bot.HeartbeatConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "interval_seconds": {
+                    "description": "Time between heartbeats",
+                    "type": "integer"
+                },
+                "max_signal_age_seconds": {
+                    "description": "Withhold heartbeats when the last signal is older than this",
+                    "type": "integer"
+                },
+                "mqtt_topic": {
+                    "description": "Publish heartbeats to this topic (requires mqtt.broker)",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "GET target, e.g. https://hc-ping.com/\u003cuuid\u003e",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.HeartbeatStatus": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "healthy": {
+                    "description": "Result of the last health check",
+                    "type": "boolean"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_sent": {
+                    "type": "string"
+                },
+                "last_skip_reason": {
+                    "type": "string"
+                },
+                "last_skipped": {
+                    "type": "string"
+                },
+                "sent_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.HedgeAuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "\"TRIGGER\", \"RELEASE\", \"EXECUTED\", \"FAILED\"",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "long_exposure": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "regime": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "side": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.HedgePosition": {
+            "type": "object",
+            "properties": {
+                "entry_price": {
+                    "description": "Volume-weighted average entry",
+                    "type": "number"
+                },
+                "open_time": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.HedgeRule": {
+            "type": "object",
+            "properties": {
+                "hedge_ratio": {
+                    "description": "Fraction of long exposure to hedge (0-1)",
+                    "type": "number"
+                },
+                "hedge_symbol": {
+                    "description": "Correlated perpetual to short, e.g. \"ETHUSDT\"",
+                    "type": "string"
+                },
+                "max_long_exposure": {
+                    "description": "Aggregate long exposure (quote value) that triggers the hedge",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "regime": {
+                    "description": "Regime that must be active, e.g. \"BEARISH\" (empty = any)",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.HedgeStatus": {
+            "type": "object",
+            "properties": {
+                "audit": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.HedgeAuditEntry"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "hedges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.HedgePosition"
+                    }
+                },
+                "regime": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.HedgingConfig": {
+            "type": "object",
+            "properties": {
+                "audit_log_file": {
+                    "description": "JSON-lines audit trail of hedge decisions (empty = log only)",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "rules": {
+                    "description": "Evaluated in order; each rule manages its own hedge",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.HedgeRule"
+                    }
+                }
+            }
+        },
+        "bot.HistoryConfig": {
+            "type": "object",
+            "properties": {
+                "candles": {
+                    "description": "Candles keyed by timeframe (\"5m\", \"1d\", ...); timeframes not listed use\nthe built-in depth",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "on_shortfall": {
+                    "description": "What happens when an enabled indicator needs more candles than are configured\nor loaded: \"fail\" (default) rejects the config and stops startup, \"disable\"\nwarns and drops the indicator on that timeframe",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.IchimokuConfig": {
+            "type": "object",
+            "properties": {
+                "displacement": {
+                    "description": "Cloud displacement (default: 26)",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "description": "Feature flag to enable/disable Ichimoku Cloud",
+                    "type": "boolean"
+                },
+                "kijun_period": {
+                    "description": "Base Line period (default: 26)",
+                    "type": "integer"
+                },
+                "senkou_period": {
+                    "description": "Leading Span B period (default: 52)",
+                    "type": "integer"
+                },
+                "tenkan_period": {
+                    "description": "Conversion Line period (default: 9)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.IndexPrice": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "description": "Price per venue that responded",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "errors": {
+                    "description": "Venues that failed",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "price": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.IndicatorBacktestStats": {
+            "type": "object",
+            "properties": {
+                "accuracy": {
+                    "description": "%",
+                    "type": "number"
+                },
+                "attributed_pnl": {
+                    "description": "Share of those trades' PnL, split by signal strength",
+                    "type": "number"
+                },
+                "correct": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "signals": {
+                    "type": "integer"
+                },
+                "trades": {
+                    "description": "Trades whose entry this indicator agreed with",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.IndicatorSignal": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "signal": {
+                    "$ref": "#/definitions/bot.SignalType"
+                },
+                "strength": {
+                    "description": "0-1 confidence",
+                    "type": "number"
+                },
+                "timeframe": {
+                    "$ref": "#/definitions/bot.Timeframe"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "actual indicator value",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.IndicatorVote": {
+            "type": "object",
+            "properties": {
+                "direction": {
+                    "description": "HIGHER, LOWER or NEUTRAL",
+                    "type": "string"
+                },
+                "strength": {
+                    "description": "Scored as the vote's confidence",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.KeltnerChannelConfig": {
+            "type": "object",
+            "properties": {
+                "atr_period": {
+                    "description": "ATR period for the band width (default: 10)",
+                    "type": "integer"
+                },
+                "ema_period": {
+                    "description": "Middle line EMA period (default: 20)",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "description": "Feature flag to enable/disable the Keltner Channel",
+                    "type": "boolean"
+                },
+                "mode": {
+                    "description": "\"breakout\" or \"mean_reversion\" (default)",
+                    "type": "string"
+                },
+                "multiplier": {
+                    "description": "Band distance from the middle in ATRs (default: 2)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.LiveTradingConfig": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "Log orders instead of sending them",
+                    "type": "boolean"
+                },
+                "enabled": {
+                    "description": "Feature flag; must be set explicitly",
+                    "type": "boolean"
+                },
+                "market": {
+                    "description": "\"futures\" (USDT-M) or \"spot\"",
+                    "type": "string"
+                },
+                "order_type": {
+                    "description": "Entry order type: \"MARKET\" or \"LIMIT\" at the signal price",
+                    "type": "string"
+                },
+                "place_stops": {
+                    "description": "Keep a reduce-only STOP_MARKET at the ATR stop (futures only)",
+                    "type": "boolean"
+                },
+                "user_stream": {
+                    "description": "Apply fills from the user data stream as they happen",
+                    "type": "boolean"
+                }
+            }
+        },
+        "bot.MACDConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable MACD",
+                    "type": "boolean"
+                },
+                "fast_period": {
+                    "type": "integer"
+                },
+                "signal_period": {
+                    "type": "integer"
+                },
+                "slow_period": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.MFIConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable Reverse-MFI",
+                    "type": "boolean"
+                },
+                "overbought": {
+                    "description": "Overbought level (default: 80)",
+                    "type": "number"
+                },
+                "oversold": {
+                    "description": "Oversold level (default: 20)",
+                    "type": "number"
+                },
+                "period": {
+                    "description": "Period for MFI calculation (default: 14)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.MQTTConfig": {
+            "type": "object",
+            "properties": {
+                "broker": {
+                    "description": "e.g. tcp://localhost:1883 (empty disables MQTT)",
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "qos": {
+                    "description": "0, 1 or 2",
+                    "type": "integer"
+                },
+                "topics": {
+                    "description": "Topic per event type (\"signal\", \"trade\", \"prediction\", \"error\"); unlisted events aren't published",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.MagnitudeBucket": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string",
+                    "example": "MILD_UP"
+                },
+                "max_percent": {
+                    "description": "Inclusive upper bound (none for STRONG_UP)",
+                    "type": "number",
+                    "example": 0.3
+                },
+                "min_percent": {
+                    "description": "Exclusive lower bound (none for STRONG_DOWN)",
+                    "type": "number",
+                    "example": 0.1
+                },
+                "probability": {
+                    "type": "number",
+                    "example": 0.32
+                }
+            }
+        },
+        "bot.MaintenanceConfig": {
+            "type": "object",
+            "properties": {
+                "pause_entries_minutes": {
+                    "description": "Block new entries this long before a window starts",
+                    "type": "integer"
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.MaintenanceWindow"
+                    }
+                }
+            }
+        },
+        "bot.MaintenanceOccurrence": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.MaintenanceStatus": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "current": {
+                    "$ref": "#/definitions/bot.MaintenanceOccurrence"
+                },
+                "entries_paused": {
+                    "description": "New entries blocked (during or just before downtime)",
+                    "type": "boolean"
+                },
+                "next": {
+                    "$ref": "#/definitions/bot.MaintenanceOccurrence"
+                },
+                "pause_reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.MaintenanceWindow": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "repeat_weekly": {
+                    "description": "Recur every 7 days from Start",
+                    "type": "boolean"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.MarginConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag (futures mode)",
+                    "type": "boolean"
+                },
+                "leverage": {
+                    "description": "Max position notional as a multiple of the balance",
+                    "type": "number"
+                },
+                "liquidation_buffer_percent": {
+                    "description": "Alert when price is within this % of the liquidation price",
+                    "type": "number"
+                },
+                "maintenance_margin_rate": {
+                    "description": "Maintenance margin as a fraction of notional",
+                    "type": "number"
+                },
+                "max_margin_ratio": {
+                    "description": "Entries that would push maintenance margin / margin balance above this are blocked",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.MultiTimeframeContext": {
+            "type": "object",
+            "properties": {
+                "daily_candles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Candle"
+                    }
+                },
+                "eight_hour_candles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Candle"
+                    }
+                },
+                "fifteen_min_candles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Candle"
+                    }
+                },
+                "five_min_candles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Candle"
+                    }
+                },
+                "forty_five_min_candles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Candle"
+                    }
+                },
+                "last_update": {
+                    "type": "string"
+                },
+                "quality": {
+                    "description": "Stale, gapped and fallback timeframes (live contexts only)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.DataQuality"
+                        }
+                    ]
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.NightlyBacktestConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "hour_utc": {
+                    "description": "Hour of day (UTC) to run",
+                    "type": "integer"
+                },
+                "max_drawdown_alert": {
+                    "description": "Raise a warning above this drawdown % (0 = only on losses)",
+                    "type": "number"
+                },
+                "window_days": {
+                    "description": "Lookback windows to backtest, e.g. [7, 30]",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "bot.NotificationsConfig": {
+            "type": "object",
+            "properties": {
+                "webhook_url": {
+                    "description": "JSON POST target for alerts (alerts are always logged)",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ParabolicSARConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable the Parabolic SAR",
+                    "type": "boolean"
+                },
+                "max_step": {
+                    "description": "Acceleration factor cap (default: 0.2)",
+                    "type": "number"
+                },
+                "step": {
+                    "description": "Acceleration factor start and increment (default: 0.02)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.PerformanceStats": {
+            "type": "object",
+            "properties": {
+                "atr_trade_count": {
+                    "description": "Pine Script ATR trades",
+                    "type": "integer"
+                },
+                "average_loser_mfe_percent": {
+                    "description": "How far losers went in our favor (target tuning)",
+                    "type": "number"
+                },
+                "average_loss": {
+                    "type": "number"
+                },
+                "average_mae_percent": {
+                    "description": "Avg worst move across all trades",
+                    "type": "number"
+                },
+                "average_mfe_percent": {
+                    "description": "Excursion statistics for stop/target optimization",
+                    "type": "number"
+                },
+                "average_win": {
+                    "type": "number"
+                },
+                "average_winner_mae_percent": {
+                    "description": "How far winners went against us (stop tuning)",
+                    "type": "number"
+                },
+                "current_drawdown": {
+                    "description": "Decline from PeakEquity at the latest mark (fraction)",
+                    "type": "number"
+                },
+                "last_updated": {
+                    "type": "string"
+                },
+                "losing_trades": {
+                    "type": "integer"
+                },
+                "max_drawdown": {
+                    "description": "Largest peak-to-trough equity decline (fraction of the peak)",
+                    "type": "number"
+                },
+                "max_loss": {
+                    "type": "number"
+                },
+                "max_win": {
+                    "type": "number"
+                },
+                "peak_equity": {
+                    "description": "Equity high-water mark, unrealized PnL included",
+                    "type": "number"
+                },
+                "profit_factor": {
+                    "type": "number"
+                },
+                "sharpe_ratio": {
+                    "type": "number"
+                },
+                "total_pnl": {
+                    "type": "number"
+                },
+                "total_pnl_percent": {
+                    "type": "number"
+                },
+                "total_pnl_reporting": {
+                    "description": "Total PnL in the reporting currency",
+                    "type": "number"
+                },
+                "total_trades": {
+                    "type": "integer"
+                },
+                "win_rate": {
+                    "type": "number"
+                },
+                "winning_trades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.PinBarConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable Pin Bar detection",
+                    "type": "boolean"
+                },
+                "max_body_ratio": {
+                    "description": "Maximum body-to-range ratio (default: 0.33)",
+                    "type": "number"
+                },
+                "min_range_percent": {
+                    "description": "Minimum candle range as % of price (default: 0.001)",
+                    "type": "number"
+                },
+                "min_wick_ratio": {
+                    "description": "Minimum wick-to-body ratio (default: 2.0)",
+                    "type": "number"
+                },
+                "pattern_strength_boost": {
+                    "description": "Pattern strength multiplier (default: 1.2)",
+                    "type": "number"
+                },
+                "support_resistance": {
+                    "description": "Require S\u0026R confirmation",
+                    "type": "boolean"
+                },
+                "trend_confirmation": {
+                    "description": "Require trend context",
+                    "type": "boolean"
+                }
+            }
+        },
+        "bot.PineConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "scripts": {
+                    "description": "Study files defining buy/sell conditions (supported subset: inputs, ta.*, math.*, crossover)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "strength": {
+                    "description": "Signal strength reported when a study's buy or sell fires (default: 0.7)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.Position": {
+            "type": "object",
+            "properties": {
+                "atr_trail_stop": {
+                    "description": "Pine Script ATR trailing stop",
+                    "type": "number"
+                },
+                "base_currency": {
+                    "type": "string"
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "contract_type": {
+                    "description": "\"linear\" or \"inverse\"",
+                    "type": "string"
+                },
+                "current_price": {
+                    "type": "number"
+                },
+                "entry_price": {
+                    "type": "number"
+                },
+                "funding": {
+                    "description": "Funding payments and borrow interest in the margin currency (negative = paid), included in PnL",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "leverage": {
+                    "description": "Futures margin, updated on each mark when margin monitoring is enabled",
+                    "type": "number"
+                },
+                "liquidation_buffer_percent": {
+                    "description": "Distance from price to liquidation (%)",
+                    "type": "number"
+                },
+                "liquidation_price": {
+                    "description": "0 when the balance covers any move",
+                    "type": "number"
+                },
+                "mae": {
+                    "description": "Worst unrealized loss seen ($, positive)",
+                    "type": "number"
+                },
+                "mae_percent": {
+                    "description": "Worst unrealized move against the position (%, positive)",
+                    "type": "number"
+                },
+                "margin_currency": {
+                    "description": "Currency PnL is denominated in",
+                    "type": "string"
+                },
+                "margin_ratio": {
+                    "description": "Maintenance margin / margin balance (liquidation at 1)",
+                    "type": "number"
+                },
+                "mfe": {
+                    "description": "Maximum favorable/adverse excursion since entry (MFE/MAE), updated on each candle",
+                    "type": "number"
+                },
+                "mfe_percent": {
+                    "description": "Best unrealized move seen (%)",
+                    "type": "number"
+                },
+                "open_time": {
+                    "type": "string"
+                },
+                "pnl": {
+                    "type": "number"
+                },
+                "pnl_percent": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "quote_currency": {
+                    "description": "Currency EntryPrice, stops etc. are denominated in",
+                    "type": "string"
+                },
+                "side": {
+                    "description": "\"LONG\" or \"SHORT\"",
+                    "type": "string"
+                },
+                "stop_loss": {
+                    "type": "number"
+                },
+                "strategy": {
+                    "description": "\"ATR_PINE_SCRIPT\"",
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "take_profit": {
+                    "type": "number"
+                }
+            }
+        },
+        "bot.PositionView": {
+            "type": "object",
+            "properties": {
+                "atr_trail_stop": {
+                    "type": "number"
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "current_price": {
+                    "type": "number"
+                },
+                "entry_price": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "open_time": {
+                    "type": "string"
+                },
+                "pnl": {
+                    "type": "number"
+                },
+                "pnl_percent": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "side": {
+                    "description": "\"LONG\" or \"SHORT\"",
+                    "type": "string"
+                },
+                "stop_loss": {
+                    "type": "number"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "take_profit": {
+                    "type": "number"
+                }
+            }
+        },
+        "bot.PredictionConfig": {
+            "type": "object",
+            "properties": {
+                "history_file": {
+                    "description": "JSON file served predictions and outcomes are persisted to (empty disables)",
+                    "type": "string"
+                },
+                "mild_move_percent": {
+                    "description": "Magnitude buckets: moves within ± this are FLAT",
+                    "type": "number"
+                },
+                "neutral_atr_fraction": {
+                    "description": "Share of the 5m ATR (scaled by sqrt of the horizon in 5m candles) added to the band",
+                    "type": "number"
+                },
+                "round_trip_cost_percent": {
+                    "description": "Entry + exit fees and slippage, % of price",
+                    "type": "number"
+                },
+                "strong_move_percent": {
+                    "description": "Moves beyond ± this are STRONG_UP/STRONG_DOWN",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.PriceIndexConfig": {
+            "type": "object",
+            "properties": {
+                "min_venues": {
+                    "description": "Venues that must respond for a valid index",
+                    "type": "integer"
+                },
+                "venues": {
+                    "description": "\"binance\", \"coinbase\", \"kraken\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "bot.RSIConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable RSI",
+                    "type": "boolean"
+                },
+                "overbought": {
+                    "type": "number"
+                },
+                "oversold": {
+                    "type": "number"
+                },
+                "period": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.RebalanceConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "interval_minutes": {
+                    "description": "How often to check weights (default: 60)",
+                    "type": "integer"
+                },
+                "min_trade_value": {
+                    "description": "Skip adjustments smaller than this quote value (default: 10)",
+                    "type": "number"
+                },
+                "target_weights": {
+                    "description": "Symbol -\u003e target fraction of portfolio (remainder stays in cash)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "threshold_band": {
+                    "description": "Rebalance a symbol when |weight - target| exceeds this (default: 0.05)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.ReconciliationConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag (requires API keys)",
+                    "type": "boolean"
+                },
+                "interval_seconds": {
+                    "description": "Time between polls",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.RegimeProfile": {
+            "type": "object",
+            "properties": {
+                "min_confidence": {
+                    "description": "Signals below this confidence become HOLD (0 = no extra threshold)",
+                    "type": "number"
+                },
+                "weights": {
+                    "description": "Vote weight per indicator name, e.g. {\"RSI\": 1.5} (unlisted = 1)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "bot.RegimeStatus": {
+            "type": "object",
+            "properties": {
+                "active_from": {
+                    "description": "When the engine last switched profiles",
+                    "type": "string"
+                },
+                "efficiency_ratio": {
+                    "description": "|net move| / sum of |moves| (0 = choppy, 1 = straight line)",
+                    "type": "number"
+                },
+                "profile": {
+                    "$ref": "#/definitions/bot.RegimeProfile"
+                },
+                "regime": {
+                    "type": "string"
+                },
+                "volatility_percent": {
+                    "description": "Average true range as % of price",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.RegimeSwitchingConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "lookback": {
+                    "description": "5m candles the regime detector looks at",
+                    "type": "integer"
+                },
+                "profiles": {
+                    "description": "Keyed by \"TRENDING\", \"RANGING\" or \"VOLATILE\"",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.RegimeProfile"
+                    }
+                },
+                "trend_threshold": {
+                    "description": "Efficiency ratio (0-1) at or above which the market is TRENDING",
+                    "type": "number"
+                },
+                "volatility_threshold": {
+                    "description": "Average true range % of price above which the market is VOLATILE",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.RiskManager": {
+            "type": "object",
+            "properties": {
+                "atr_stop_multiplier": {
+                    "description": "ATR multiplier for stops",
+                    "type": "number"
+                },
+                "daily_loss_used": {
+                    "description": "Realized losses this session (fraction of balance)",
+                    "type": "number"
+                },
+                "daily_unrealized_loss": {
+                    "description": "Open position's unrealized loss at the last mark (fraction of balance)",
+                    "type": "number"
+                },
+                "last_reset_time": {
+                    "description": "Start of the current session",
+                    "type": "string"
+                },
+                "max_daily_loss": {
+                    "description": "Max daily loss %",
+                    "type": "number"
+                },
+                "max_drawdown": {
+                    "description": "Max portfolio drawdown %",
+                    "type": "number"
+                },
+                "max_position_size": {
+                    "description": "Max % of balance per trade",
+                    "type": "number"
+                },
+                "min_confidence": {
+                    "description": "Min signal confidence to trade",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.RiskReport": {
+            "type": "object",
+            "properties": {
+                "benchmark": {
+                    "type": "string"
+                },
+                "beta_exposure": {
+                    "description": "Long and short scaled by beta, in benchmark-equivalent quote value",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.ExposureSnapshot"
+                        }
+                    ]
+                },
+                "exposure": {
+                    "$ref": "#/definitions/bot.ExposureSnapshot"
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.SymbolExposure"
+                    }
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.SafeModeConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "failure_threshold": {
+                    "description": "Failures within the window that trigger safe mode",
+                    "type": "integer"
+                },
+                "flatten_positions": {
+                    "description": "Close open positions on entry (at last known price)",
+                    "type": "boolean"
+                },
+                "recovery_successes": {
+                    "description": "Consecutive successes that end safe mode (0 = manual exit only)",
+                    "type": "integer"
+                },
+                "window_seconds": {
+                    "description": "Sliding window for counting failures",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.SafeModeStatus": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "cancelled_orders": {
+                    "description": "Working orders cancelled on entry",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "entered_at": {
+                    "type": "string"
+                },
+                "exited_at": {
+                    "type": "string"
+                },
+                "flattened": {
+                    "description": "Whether positions were closed on entry",
+                    "type": "boolean"
+                },
+                "last_failure": {
+                    "type": "string"
+                },
+                "last_failure_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recent_failures": {
+                    "description": "Failures inside the detection window",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.ScenarioLine": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "description": "\"position\" (signal strategy), \"holding\" (spot) or \"hedge\"",
+                    "type": "string"
+                },
+                "pnl": {
+                    "description": "Quote-currency PnL of the move",
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "shocked_price": {
+                    "type": "number"
+                },
+                "side": {
+                    "description": "LONG or SHORT",
+                    "type": "string"
+                },
+                "stop_loss": {
+                    "description": "Signal position's stop",
+                    "type": "number"
+                },
+                "stop_triggered": {
+                    "description": "The move gaps through the stop, filling at the shocked price",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.ScenarioReport": {
+            "type": "object",
+            "properties": {
+                "benchmark": {
+                    "type": "string"
+                },
+                "beta_scaled": {
+                    "description": "Each symbol moved by shock x its beta to the benchmark",
+                    "type": "boolean"
+                },
+                "equity": {
+                    "description": "Quote-currency equity before the shocks",
+                    "type": "number"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.ScenarioResult"
+                    }
+                }
+            }
+        },
+        "bot.ScenarioResult": {
+            "type": "object",
+            "properties": {
+                "equity_after": {
+                    "type": "number"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.ScenarioLine"
+                    }
+                },
+                "liquidated": {
+                    "description": "Margin ratio reached 1",
+                    "type": "boolean"
+                },
+                "margin_ratio": {
+                    "description": "Signal position's maintenance margin / margin balance after the move",
+                    "type": "number"
+                },
+                "pnl": {
+                    "description": "Quote-currency PnL across every line",
+                    "type": "number"
+                },
+                "pnl_percent": {
+                    "type": "number"
+                },
+                "shock": {
+                    "description": "Benchmark move, e.g. -0.1 for -10%",
+                    "type": "number"
+                },
+                "stops_triggered": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "bot.ScriptingConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag",
+                    "type": "boolean"
+                },
+                "file": {
+                    "description": "Rule script; reloaded when the file changes",
+                    "type": "string"
+                },
+                "max_steps": {
+                    "description": "Evaluation step budget per run (default: 10000)",
+                    "type": "integer"
+                },
+                "position_size": {
+                    "description": "Fraction of the strategy's cash spent per entry (default: 0.1)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.SeasonalityBucket": {
+            "type": "object",
+            "properties": {
+                "avg_return_percent": {
+                    "description": "Mean candle return",
+                    "type": "number"
+                },
+                "bias": {
+                    "description": "Directional bias in [-1, 1]: 2*UpRatio - 1",
+                    "type": "number"
+                },
+                "key": {
+                    "description": "Session-local hour 0-23 or trading-day weekday 0-6 (Sunday = 0)",
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "samples": {
+                    "type": "integer"
+                },
+                "up_ratio": {
+                    "description": "Fraction of candles closing above their open",
+                    "type": "number"
+                },
+                "volatility_percent": {
+                    "description": "Standard deviation of candle returns",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.SeasonalityConfig": {
+            "type": "object",
+            "properties": {
+                "lookback_days": {
+                    "description": "History used for the statistics",
+                    "type": "integer"
+                },
+                "min_samples": {
+                    "description": "Buckets with fewer candles contribute no prior",
+                    "type": "integer"
+                },
+                "prior_enabled": {
+                    "description": "Feed the seasonal bias into the aggregator",
+                    "type": "boolean"
+                },
+                "prior_weight": {
+                    "description": "Vote weight of a full (±1) seasonal bias",
+                    "type": "number"
+                },
+                "refresh_hours": {
+                    "description": "How long computed statistics stay fresh",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.SeasonalityStats": {
+            "type": "object",
+            "properties": {
+                "candles": {
+                    "type": "integer"
+                },
+                "computed_at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "hours": {
+                    "description": "24 buckets in the session time zone",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.SeasonalityBucket"
+                    }
+                },
+                "session": {
+                    "description": "Trading day the buckets were cut by",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.SessionConfig"
+                        }
+                    ]
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timeframe": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "weekdays": {
+                    "description": "7 buckets by trading day, Sunday first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.SeasonalityBucket"
+                    }
+                }
+            }
+        },
+        "bot.SessionConfig": {
+            "type": "object",
+            "properties": {
+                "start_time": {
+                    "description": "Local \"HH:MM\" the session starts (default 00:00, Binance's day in UTC)",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA zone, e.g. \"America/New_York\" (default UTC)",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.SignalEngineStatus": {
+            "type": "object",
+            "properties": {
+                "data_summary": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "degraded_feeds": {
+                    "description": "Timeframes whose feeds went silent; their signals carry less confidence",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "last_signal": {
+                    "$ref": "#/definitions/bot.TradingSignal"
+                },
+                "last_update": {
+                    "type": "string"
+                },
+                "ready_status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "regime": {
+                    "description": "Active regime profile",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.RegimeStatus"
+                        }
+                    ]
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "stream": {
+                    "description": "Kline stream health when streaming",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.StreamStatus"
+                        }
+                    ]
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "symbols": {
+                    "description": "Every configured symbol's engine, keyed by symbol",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.SignalEngineStatus"
+                    }
+                },
+                "user_stream": {
+                    "description": "Account event stream health when trading live",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.UserStreamStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "bot.SignalType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "Hold",
+                "Buy",
+                "Sell"
+            ]
+        },
+        "bot.StochasticConfig": {
+            "type": "object",
+            "properties": {
+                "d_period": {
+                    "description": "Period for %D smoothing (default: 3)",
+                    "type": "integer"
+                },
+                "divergence_boost": {
+                    "description": "Boost for divergence signals (default: 1.3)",
+                    "type": "number"
+                },
+                "enabled": {
+                    "description": "Feature flag to enable/disable Stochastic",
+                    "type": "boolean"
+                },
+                "k_period": {
+                    "description": "Period for %K calculation (default: 14)",
+                    "type": "integer"
+                },
+                "momentum_boost": {
+                    "description": "Boost factor for momentum signals (default: 1.2)",
+                    "type": "number"
+                },
+                "overbought": {
+                    "description": "Overbought threshold (default: 80)",
+                    "type": "number"
+                },
+                "oversold": {
+                    "description": "Oversold threshold (default: 20)",
+                    "type": "number"
+                },
+                "slow_period": {
+                    "description": "Period for slow %K (default: 3)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.StrategyAllocation": {
+            "type": "object",
+            "properties": {
+                "capital_fraction": {
+                    "description": "Fraction of the account balance (0-1)",
+                    "type": "number"
+                },
+                "max_daily_loss": {
+                    "description": "Max daily loss as fraction of strategy equity (default: account limit)",
+                    "type": "number"
+                },
+                "max_position_size": {
+                    "description": "Risk fraction per signal trade (default: account limit)",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.StrategyBook": {
+            "type": "object",
+            "properties": {
+                "allocated_capital": {
+                    "description": "Initial capital assigned to the strategy",
+                    "type": "number"
+                },
+                "capital_fraction": {
+                    "description": "0 = unallocated (shares the whole account)",
+                    "type": "number"
+                },
+                "cost_basis": {
+                    "description": "Quote cost of Holdings per symbol",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "daily_loss_used": {
+                    "type": "number"
+                },
+                "holdings": {
+                    "description": "Base quantity per symbol from strategy-layer orders",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "last_reset_time": {
+                    "type": "string"
+                },
+                "max_daily_loss": {
+                    "description": "Fraction of equity the strategy may lose per day",
+                    "type": "number"
+                },
+                "max_position_size": {
+                    "description": "Risk fraction per signal trade",
+                    "type": "number"
+                },
+                "realized_pnl": {
+                    "type": "number"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "total_trades": {
+                    "type": "integer"
+                },
+                "winning_trades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.StrategyConfig": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "description": "\"5m_focus\" (default) or \"multi_timeframe\"",
+                    "type": "string"
+                },
+                "timeframe_weights": {
+                    "description": "multi_timeframe weight per timeframe (\"5m\", \"15m\", \"45m\", \"8h\", \"1d\"); 0 leaves it out",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "bot.StreamStatus": {
+            "type": "object",
+            "properties": {
+                "connected": {
+                    "type": "boolean"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_message": {
+                    "type": "string"
+                },
+                "last_price": {
+                    "description": "From the ticker stream",
+                    "type": "number"
+                },
+                "reconnects": {
+                    "type": "integer"
+                },
+                "rest_fallbacks": {
+                    "description": "REST refreshes after a disconnect",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.StreamingConfig": {
+            "type": "object",
+            "properties": {
+                "degraded_confidence": {
+                    "description": "Confidence multiplier while any feed is degraded",
+                    "type": "number"
+                },
+                "enabled": {
+                    "description": "Stream klines instead of one feed per timeframe (Binance only)",
+                    "type": "boolean"
+                },
+                "max_backoff_seconds": {
+                    "description": "Cap on the exponential reconnect delay",
+                    "type": "integer"
+                },
+                "stale_seconds": {
+                    "description": "Silence after which the stream reconnects and predictions refetch over REST",
+                    "type": "integer"
+                },
+                "watchdog_seconds": {
+                    "description": "Feed watchdog: feeds silent past their limit (stale_seconds when streamed, one\ninterval plus stale_seconds for closed-candle feeds) are flagged degraded and reconnected",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.SupportResistanceConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable Support/Resistance",
+                    "type": "boolean"
+                },
+                "period": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "bot.SymbolExposure": {
+            "type": "object",
+            "properties": {
+                "beta": {
+                    "description": "Rolling beta of the symbol's returns to the benchmark's",
+                    "type": "number"
+                },
+                "beta_adjusted": {
+                    "description": "Net scaled by beta: the benchmark exposure with the same directional risk",
+                    "type": "number"
+                },
+                "beta_estimated": {
+                    "description": "False when too few returns overlap the benchmark's and a beta of 1 is assumed",
+                    "type": "boolean"
+                },
+                "long": {
+                    "type": "number"
+                },
+                "net": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "short": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.SymbolPrecision": {
+            "type": "object",
+            "properties": {
+                "step_size": {
+                    "description": "0 keeps the default lot step",
+                    "type": "number"
+                },
+                "tick_size": {
+                    "type": "number"
+                }
+            }
+        },
+        "bot.SymbolSelectorConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "exclude": {
+                    "description": "Pairs never selected, e.g. stablecoin pairs",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "quotes": {
+                    "description": "Quote currencies, each ranked separately (default: USDT)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refresh_hours": {
+                    "description": "How often the selection is resolved again (default: 24)",
+                    "type": "integer"
+                },
+                "top": {
+                    "description": "Pairs kept per quote currency (default: 10)",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.TaxLotMethod": {
+            "type": "string",
+            "enum": [
+                "FIFO",
+                "LIFO"
+            ],
+            "x-enum-varnames": [
+                "FIFO",
+                "LIFO"
+            ]
+        },
+        "bot.TaxLotRecord": {
+            "type": "object",
+            "properties": {
+                "close_trade_id": {
+                    "type": "string"
+                },
+                "cost_basis": {
+                    "type": "number"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "date_acquired": {
+                    "type": "string"
+                },
+                "date_sold": {
+                    "type": "string"
+                },
+                "fees": {
+                    "description": "The lot's share of its trades' entry and exit fees",
+                    "type": "number"
+                },
+                "gain_loss": {
+                    "type": "number"
+                },
+                "open_trade_id": {
+                    "type": "string"
+                },
+                "proceeds": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "side": {
+                    "description": "\"LONG\" or \"SHORT\"",
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "term": {
+                    "description": "\"SHORT\" or \"LONG\" (held more than one year)",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.Timeframe": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4
+            ],
             "x-enum-varnames": [
                 "FiveMinute",
                 "FifteenMinute",
@@ -243,54 +5639,760 @@ const docTemplate = `{
                 "Daily"
             ]
         },
-        "bot.TradingSignal": {
+        "bot.TimeframeFreshness": {
+            "type": "object",
+            "properties": {
+                "age_seconds": {
+                    "description": "Capture time minus LatestClose; negative while forming",
+                    "type": "number"
+                },
+                "candles": {
+                    "type": "integer"
+                },
+                "latest": {
+                    "$ref": "#/definitions/bot.Candle"
+                },
+                "latest_close": {
+                    "description": "In the future while the candle is forming",
+                    "type": "string"
+                },
+                "stale": {
+                    "description": "Latest candle closed more than two intervals before capture",
+                    "type": "boolean"
+                }
+            }
+        },
+        "bot.TimeframeProviderConfig": {
+            "type": "object",
+            "properties": {
+                "historical": {
+                    "description": "An exchange, \"sample\", \"file\" (backfills) or \"store\" (offline)",
+                    "type": "string"
+                },
+                "realtime": {
+                    "description": "An exchange, \"binance_rest\" (polling) or \"sample\"",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.TrackedPrediction": {
+            "type": "object",
+            "properties": {
+                "actual_direction": {
+                    "type": "string"
+                },
+                "actual_price": {
+                    "type": "number"
+                },
+                "brier_score": {
+                    "description": "0 (perfect) to 2",
+                    "type": "number"
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "correct": {
+                    "type": "boolean"
+                },
+                "direction": {
+                    "description": "HIGHER, LOWER or NEUTRAL",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "indicators": {
+                    "description": "What each indicator pointed to, scored with the prediction",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.IndicatorVote"
+                    }
+                },
+                "log_loss": {
+                    "type": "number"
+                },
+                "made_at": {
+                    "type": "string"
+                },
+                "neutral_band": {
+                    "description": "Moves within ± this price change resolve NEUTRAL",
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "regime": {
+                    "description": "Market regime when the prediction was made",
+                    "type": "string"
+                },
+                "resolved": {
+                    "type": "boolean"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "target_time": {
+                    "type": "string"
+                },
+                "volatility_percent": {
+                    "description": "Average 5m true range as % of price",
+                    "type": "number"
+                }
+            }
+        },
+        "bot.Trade": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number"
+                },
+                "conversion_error": {
+                    "description": "Set when PnLReporting could not be computed",
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "entry_fee": {
+                    "description": "Fees itemized per fill (Fees is their sum)",
+                    "type": "number"
+                },
+                "entry_price": {
+                    "type": "number"
+                },
+                "entry_time": {
+                    "type": "string"
+                },
+                "exit_fee": {
+                    "type": "number"
+                },
+                "exit_price": {
+                    "type": "number"
+                },
+                "exit_reason": {
+                    "description": "\"ATR_STOP\", \"TAKE_PROFIT\", \"MANUAL\", \"SIGNAL_CHANGE\", \"SAFE_MODE\", \"FILL\", \"RECONCILED\", \"STOP_HUNT\"",
+                    "type": "string"
+                },
+                "exit_time": {
+                    "type": "string"
+                },
+                "fee_discount": {
+                    "description": "Fees waived for paying in BNB",
+                    "type": "number"
+                },
+                "fee_tier": {
+                    "description": "Fee tier charged; empty for a flat backtest fee",
+                    "type": "string"
+                },
+                "fees": {
+                    "description": "Simulated entry and exit fees, already deducted from PnL",
+                    "type": "number"
+                },
+                "funding": {
+                    "description": "Funding and borrow interest, already included in PnL",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mae": {
+                    "description": "Maximum adverse excursion ($, positive)",
+                    "type": "number"
+                },
+                "mae_percent": {
+                    "description": "Maximum adverse excursion (%, positive)",
+                    "type": "number"
+                },
+                "mfe": {
+                    "description": "Maximum favorable excursion ($)",
+                    "type": "number"
+                },
+                "mfe_percent": {
+                    "description": "Maximum favorable excursion (%)",
+                    "type": "number"
+                },
+                "pnl": {
+                    "type": "number"
+                },
+                "pnl_percent": {
+                    "type": "number"
+                },
+                "pnl_reporting": {
+                    "description": "PnL converted at exit",
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "quote_currency": {
+                    "description": "Currency PnL is denominated in",
+                    "type": "string"
+                },
+                "reporting_currency": {
+                    "description": "Currency PnLReporting is denominated in",
+                    "type": "string"
+                },
+                "side": {
+                    "type": "string"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.TradeReplay": {
+            "type": "object",
+            "properties": {
+                "candles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Candle"
+                    }
+                },
+                "captured_at": {
+                    "type": "string"
+                },
+                "entry_index": {
+                    "description": "Index into Candles of the entry candle (-1 if not found)",
+                    "type": "integer"
+                },
+                "exit_index": {
+                    "description": "Index into Candles of the exit candle (-1 if not found)",
+                    "type": "integer"
+                },
+                "pending": {
+                    "description": "Still collecting the candles after the exit",
+                    "type": "boolean"
+                },
+                "signals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.TradingSignal"
+                    }
+                },
+                "timeframe": {
+                    "type": "string"
+                },
+                "trade": {
+                    "$ref": "#/definitions/bot.Trade"
+                },
+                "trade_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.TradeView": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "entry_price": {
+                    "type": "number"
+                },
+                "entry_time": {
+                    "type": "string"
+                },
+                "exit_price": {
+                    "type": "number"
+                },
+                "exit_reason": {
+                    "type": "string"
+                },
+                "exit_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pnl": {
+                    "type": "number"
+                },
+                "pnl_percent": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "side": {
+                    "type": "string"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.TradingSignal": {
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "type": "number"
+                },
+                "indicator_signals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.IndicatorSignal"
+                    }
+                },
+                "price": {
+                    "description": "Price the signal was computed at",
+                    "type": "number"
+                },
+                "reasoning": {
+                    "type": "string"
+                },
+                "regime": {
+                    "description": "Regime profile used (when regime switching is enabled)",
+                    "type": "string"
+                },
+                "signal": {
+                    "$ref": "#/definitions/bot.SignalType"
+                },
+                "stop_loss": {
+                    "type": "number"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "target_price": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "bot.TradingStatus": {
             "type": "object",
             "properties": {
-                "confidence": {
+                "atr_config": {
+                    "$ref": "#/definitions/bot.ATRConfig"
+                },
+                "balance": {
+                    "type": "number"
+                },
+                "balances": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "base_currency": {
+                    "type": "string"
+                },
+                "current_position": {
+                    "$ref": "#/definitions/bot.Position"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "Set when the executor isn't available",
+                    "type": "string"
+                },
+                "hedges": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.HedgePosition"
+                    }
+                },
+                "holdings": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "maintenance_paused": {
+                    "type": "boolean"
+                },
+                "margin_currency": {
+                    "description": "Currency Balance and PnL are held in",
+                    "type": "string"
+                },
+                "open_orders_count": {
+                    "type": "integer"
+                },
+                "performance": {
+                    "$ref": "#/definitions/bot.PerformanceStats"
+                },
+                "quote_currency": {
+                    "type": "string"
+                },
+                "reporting_currency": {
+                    "type": "string"
+                },
+                "risk_management": {
+                    "$ref": "#/definitions/bot.RiskManager"
+                },
+                "safe_mode": {
+                    "type": "boolean"
+                },
+                "strategies": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bot.StrategyBook"
+                    }
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "total_trades": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.TrailingStopConfig": {
+            "type": "object",
+            "properties": {
+                "method": {
+                    "description": "\"atr\" (default) or \"psar\"; psar needs parabolic_sar enabled",
+                    "type": "string"
+                }
+            }
+        },
+        "bot.TrendConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable Trend analysis",
+                    "type": "boolean"
+                },
+                "long_ma": {
+                    "type": "integer"
+                },
+                "short_ma": {
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.UserStreamStatus": {
+            "type": "object",
+            "properties": {
+                "balance_updates": {
+                    "type": "integer"
+                },
+                "balances": {
+                    "description": "Latest exchange balance per asset",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "connected": {
+                    "type": "boolean"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_event": {
+                    "type": "string"
+                },
+                "last_keepalive": {
+                    "type": "string"
+                },
+                "order_updates": {
+                    "description": "Order events applied to tracked orders",
+                    "type": "integer"
+                },
+                "reconnects": {
+                    "type": "integer"
+                },
+                "renewals": {
+                    "description": "Listen keys replaced after expiring or failing keepalive",
+                    "type": "integer"
+                }
+            }
+        },
+        "bot.VolumeConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable Volume analysis",
+                    "type": "boolean"
+                },
+                "period": {
+                    "type": "integer"
+                },
+                "volume_threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "bot.WilliamsRConfig": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Feature flag to enable/disable Williams %R",
+                    "type": "boolean"
+                },
+                "fast_response": {
+                    "description": "Enhanced 5-minute response mode",
+                    "type": "boolean"
+                },
+                "momentum_boost": {
+                    "description": "Momentum signal boost factor",
+                    "type": "number"
+                },
+                "overbought": {
+                    "description": "Overbought threshold (default: -20)",
+                    "type": "number"
+                },
+                "oversold": {
+                    "description": "Oversold threshold (default: -80)",
+                    "type": "number"
+                },
+                "period": {
+                    "description": "Period for Williams %R calculation (default: 14)",
+                    "type": "integer"
+                },
+                "reversal_boost": {
+                    "description": "Reversal signal boost factor",
                     "type": "number"
+                }
+            }
+        },
+        "internal.APIInfo": {
+            "type": "object",
+            "properties": {
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Trading Bot API"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                }
+            }
+        },
+        "internal.ActivityResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "date": {
+                    "description": "Trading day in the session time zone",
+                    "type": "string",
+                    "example": "2024-01-15"
+                },
+                "events": {
+                    "description": "Oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.ActivityEvent"
+                    }
+                }
+            }
+        },
+        "internal.AdminCredentialsResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "Masked API key now in use",
+                    "type": "string",
+                    "example": "abcd…wxyz"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Binance API keys reloaded"
+                },
+                "providers": {
+                    "description": "Provider clients that picked up the new keys",
+                    "type": "integer",
+                    "example": 2
+                },
+                "source": {
+                    "description": "\"request\" or \"environment\"",
+                    "type": "string",
+                    "example": "environment"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal.AdminRefreshResponse": {
+            "type": "object",
+            "properties": {
+                "candles": {
+                    "description": "Candles stored per timeframe after the refresh",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Historical data refreshed"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal.AdminResetResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Performance stats and daily loss counters reset"
+                },
+                "performance": {
+                    "$ref": "#/definitions/bot.PerformanceStats"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal.AlertDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "alert_1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal.AlertListResponse": {
+            "type": "object",
+            "properties": {
+                "alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Alert"
+                    }
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "internal.AuditResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Items in this page",
+                    "type": "integer",
+                    "example": 10
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.AuditEntry"
+                    }
+                },
+                "limit": {
+                    "description": "0 = no limit",
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "description": "Items matching the filters",
+                    "type": "integer",
+                    "example": 125
+                }
+            }
+        },
+        "internal.CandleHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "candles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Candle"
+                    }
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "symbol": {
+                    "type": "string",
+                    "example": "BTCUSDT"
+                },
+                "timeframe": {
+                    "type": "string",
+                    "example": "5m"
+                }
+            }
+        },
+        "internal.ConfigUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Config applied and saved"
                 },
-                "indicator_signals": {
+                "reloaded": {
+                    "description": "Changed settings now in effect, by JSON key",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/bot.IndicatorSignal"
+                        "type": "string"
                     }
                 },
-                "reasoning": {
-                    "type": "string"
-                },
-                "signal": {
-                    "$ref": "#/definitions/bot.SignalType"
-                },
-                "stop_loss": {
-                    "type": "number"
-                },
-                "symbol": {
-                    "type": "string"
-                },
-                "target_price": {
-                    "type": "number"
+                "restart_required": {
+                    "description": "Changed settings saved but applied on the next start",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "timestamp": {
-                    "type": "string"
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "internal.APIInfo": {
+        "internal.ConfigValidationResponse": {
             "type": "object",
             "properties": {
-                "endpoints": {
+                "errors": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "problems": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/bot.ConfigProblem"
                     }
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Trading Bot API"
+                "valid": {
+                    "description": "False when any problem has severity \"error\"",
+                    "type": "boolean",
+                    "example": false
                 },
-                "version": {
-                    "type": "string",
-                    "example": "1.0.0"
+                "warnings": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
@@ -303,6 +6405,20 @@ const docTemplate = `{
                 }
             }
         },
+        "internal.ErrorsResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.ErrorRecord"
+                    }
+                },
+                "stats": {
+                    "$ref": "#/definitions/bot.ErrorStats"
+                }
+            }
+        },
         "internal.HealthResponse": {
             "type": "object",
             "properties": {
@@ -310,6 +6426,15 @@ const docTemplate = `{
                     "type": "boolean",
                     "example": true
                 },
+                "event_export": {
+                    "$ref": "#/definitions/bot.EventExportStatus"
+                },
+                "heartbeat": {
+                    "$ref": "#/definitions/bot.HeartbeatStatus"
+                },
+                "safe_mode": {
+                    "$ref": "#/definitions/bot.SafeModeStatus"
+                },
                 "status": {
                     "type": "string",
                     "example": "healthy"
@@ -350,45 +6475,441 @@ const docTemplate = `{
                 }
             }
         },
+        "internal.PositionResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "No open position"
+                },
+                "position": {
+                    "$ref": "#/definitions/bot.Position"
+                }
+            }
+        },
+        "internal.PredictionHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Items in this page",
+                    "type": "integer",
+                    "example": 10
+                },
+                "limit": {
+                    "description": "0 = no limit",
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "predictions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal.PredictionResponse"
+                    }
+                },
+                "total": {
+                    "description": "Items matching the filters",
+                    "type": "integer",
+                    "example": 125
+                }
+            }
+        },
         "internal.PredictionResponse": {
             "type": "object",
             "properties": {
+                "atr_trail_stop": {
+                    "description": "Current ATR trailing stop",
+                    "type": "number"
+                },
+                "candle_close_time": {
+                    "type": "string",
+                    "example": "2023-01-01T12:00:00Z"
+                },
                 "confidence": {
                     "type": "number",
                     "example": 0.75
                 },
+                "current_position": {
+                    "description": "Open position details",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.PositionView"
+                        }
+                    ]
+                },
                 "current_price": {
                     "type": "number",
                     "example": 50000.5
                 },
+                "data_freshness": {
+                    "description": "DEGRADED while the exchange is in scheduled maintenance or the bot is in safe mode",
+                    "type": "string",
+                    "example": "LIVE,DEGRADED"
+                },
+                "data_timestamp": {
+                    "description": "Latency compensation: PredictionTime is measured from DataTimestamp, not request arrival",
+                    "type": "string",
+                    "example": "2023-01-01T11:59:59Z"
+                },
+                "fetch_latency_ms": {
+                    "type": "integer",
+                    "example": 850
+                },
+                "five_minute_signal": {
+                    "type": "string",
+                    "example": "Based on 5-minute timeframe analysis"
+                },
                 "indicators": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/internal.IndicatorPrediction"
                     }
                 },
+                "magnitude_bucket": {
+                    "description": "Most likely bucket",
+                    "type": "string",
+                    "example": "MILD_UP"
+                },
+                "magnitude_buckets": {
+                    "description": "Probability of each move size (STRONG_DOWN ... STRONG_UP) at PredictionTime",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.MagnitudeBucket"
+                    }
+                },
+                "maintenance": {
+                    "$ref": "#/definitions/bot.MaintenanceStatus"
+                },
                 "prediction": {
                     "type": "string",
-                    "enum": [
-                        "HIGHER",
-                        "LOWER",
-                        "NEUTRAL"
-                    ],
-                    "example": "HIGHER"
+                    "example": "HIGHER,LOWER,NEUTRAL"
+                },
+                "prediction_stage": {
+                    "type": "string",
+                    "example": "INITIAL or FOLLOWUP"
+                },
+                "prediction_time": {
+                    "type": "string",
+                    "example": "2023-01-01T12:05:00Z"
+                },
+                "price_latency_ms": {
+                    "type": "integer",
+                    "example": 120
                 },
                 "reasoning": {
                     "type": "string",
                     "example": "Strong buy signals detected across multiple indicators"
                 },
+                "recent_trades": {
+                    "description": "Last 5 trades",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.TradeView"
+                    }
+                },
                 "symbol": {
                     "type": "string",
                     "example": "BTCUSD"
                 },
+                "time_to_target": {
+                    "type": "string",
+                    "example": "5m0s"
+                },
                 "timestamp": {
                     "type": "string",
                     "example": "2023-01-01T12:00:00Z"
+                },
+                "trading_enabled": {
+                    "description": "Whether trading is active",
+                    "type": "boolean"
+                },
+                "trading_status": {
+                    "description": "Pine Script ATR Trading Strategy Information",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.TradingStatus"
+                        }
+                    ]
+                }
+            }
+        },
+        "internal.PredictionSubscription": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deliveries": {
+                    "type": "integer"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "sub_1"
+                },
+                "last_delivered": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "min_confidence": {
+                    "description": "0-1; predictions below it are ignored, flips included",
+                    "type": "number"
+                },
+                "on_flip": {
+                    "description": "Only when the direction differs from the last prediction that met the threshold",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "description": "Default: every configured symbol",
+                    "type": "string"
+                },
+                "webhook_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/predictions"
+                }
+            }
+        },
+        "internal.PredictionSubscriptionDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "sub_1"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal.PredictionSubscriptionListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subscriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal.PredictionSubscription"
+                    }
+                }
+            }
+        },
+        "internal.PredictionSubscriptionSpec": {
+            "type": "object",
+            "properties": {
+                "min_confidence": {
+                    "description": "0-1; predictions below it are ignored, flips included",
+                    "type": "number"
+                },
+                "on_flip": {
+                    "description": "Only when the direction differs from the last prediction that met the threshold",
+                    "type": "boolean"
+                },
+                "symbol": {
+                    "description": "Default: every configured symbol",
+                    "type": "string"
+                },
+                "webhook_url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/predictions"
+                }
+            }
+        },
+        "internal.ReloadCredentialsRequest": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "type": "string"
+                },
+                "secret_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal.SafeModeResponse": {
+            "type": "object",
+            "properties": {
+                "safe_mode": {
+                    "$ref": "#/definitions/bot.SafeModeStatus"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal.ScenarioRequest": {
+            "type": "object",
+            "properties": {
+                "beta_scaled": {
+                    "description": "Move each symbol by the shock times its beta to the benchmark",
+                    "type": "boolean"
+                },
+                "shocks": {
+                    "description": "Fractional moves (default: -5%, -10%, -20%, +5%, +10%)",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "example": [
+                        -0.05,
+                        -0.1
+                    ]
+                }
+            }
+        },
+        "internal.SignalHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Items in this page",
+                    "type": "integer",
+                    "example": 10
+                },
+                "limit": {
+                    "description": "0 = no limit",
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "signals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.TradingSignal"
+                    }
+                },
+                "total": {
+                    "description": "Items matching the filters",
+                    "type": "integer",
+                    "example": 125
+                }
+            }
+        },
+        "internal.TaxReportResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "method": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bot.TaxLotMethod"
+                        }
+                    ],
+                    "example": "FIFO"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.TaxLotRecord"
+                    }
+                }
+            }
+        },
+        "internal.TradeHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Items in this page",
+                    "type": "integer",
+                    "example": 10
+                },
+                "limit": {
+                    "description": "0 = no limit",
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "description": "Items matching the filters",
+                    "type": "integer",
+                    "example": 125
+                },
+                "trades": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bot.Trade"
+                    }
                 }
             }
+        },
+        "internal.TradingControlError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "no open position to close"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "internal.TradingControlResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Position closed manually"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "time.Duration": {
+            "type": "integer",
+            "enum": [
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000
+            ],
+            "x-enum-varnames": [
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "type": "apiKey",
+            "name": "X-Admin-Token",
+            "in": "header"
         }
     }
 }`
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"trading-bot/pkg/bot"
+)
+
+// runDownload bulk-downloads klines for the configured symbols into the candle
+// store, resuming from the manifest left by an earlier run
+func runDownload(args []string) {
+	flags := flag.NewFlagSet("download", flag.ExitOnError)
+	symbols := flags.String("symbols", "", "Comma-separated symbols (default: symbol and symbols from config.json)")
+	timeframes := flags.String("timeframes", "5m,15m,45m,8h,1d", "Comma-separated timeframes")
+	from := flags.String("from", "", "First day to download, YYYY-MM-DD (default: -years back)")
+	to := flags.String("to", "", "Day to stop before, YYYY-MM-DD (default: now)")
+	years := flags.Int("years", 2, "Years of history when -from is not given")
+	chunk := flags.Int("chunk", 1000, "Candles per request")
+	rpm := flags.Int("rpm", 120, "Maximum requests per minute")
+	manifest := flags.String("manifest", "", "Resume manifest (default: candle_store.path + .download.json)")
+	flags.Parse(args)
+
+	configManager := bot.NewConfigManager("config.json")
+	if err := configManager.Load(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := configManager.GetConfig()
+
+	opts := bot.DownloadOptions{Symbols: config.AllSymbols(), ChunkCandles: *chunk, End: time.Now(), ManifestPath: *manifest}
+	if *symbols != "" {
+		opts.Symbols = nil
+		for _, symbol := range strings.Split(*symbols, ",") {
+			opts.Symbols = append(opts.Symbols, bot.NormalizeSymbol(symbol))
+		}
+	}
+	for _, name := range strings.Split(*timeframes, ",") {
+		timeframe, err := bot.ParseTimeframe(strings.TrimSpace(name))
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		opts.Timeframes = append(opts.Timeframes, timeframe)
+	}
+	opts.Start = opts.End.AddDate(-*years, 0, 0)
+	if *from != "" {
+		start, err := time.Parse("2006-01-02", *from)
+		if err != nil {
+			log.Fatalf("Invalid -from: %v", err)
+		}
+		opts.Start = start
+	}
+	if *to != "" {
+		end, err := time.Parse("2006-01-02", *to)
+		if err != nil {
+			log.Fatalf("Invalid -to: %v", err)
+		}
+		opts.End = end
+	}
+	if *rpm > 0 {
+		opts.MinInterval = time.Minute / time.Duration(*rpm)
+	}
+	if opts.ManifestPath == "" {
+		opts.ManifestPath = config.CandleStore.Path + ".download.json"
+	}
+
+	if !bot.IsExchange(config.DataProvider) {
+		log.Fatalf("data_provider %q is not an exchange to download from", config.DataProvider)
+	}
+	source, err := bot.NewExchange(config.DataProvider, config)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	store, err := bot.NewCandleStore(config.CandleStore)
+	if err != nil {
+		log.Fatalf("Failed to open candle store: %v", err)
+	}
+	defer store.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("📥 Downloading %s (%s) from %s, %s → %s\n", strings.Join(opts.Symbols, ", "), *timeframes,
+		source.Name(), opts.Start.Format("2006-01-02"), opts.End.Format("2006-01-02"))
+	started := time.Now()
+	result, err := bot.DownloadCandles(ctx, source, store, opts)
+	if result != nil {
+		fmt.Printf("✅ %d chunks downloaded, %d repaired, %d already stored; %d candles saved in %s\n",
+			result.Fetched, result.Repaired, result.Skipped, result.Candles, time.Since(started).Round(time.Second))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Download stopped, run again to resume: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiUserKey is the gin context key holding the authenticated bot.APIUser
+const apiUserKey = "api_user"
+
+// publicRoutes never need a token, so load balancers and docs keep working
+var publicRoutes = map[string]bool{
+	"GET /":                    true,
+	"GET /api/v1/health":       true,
+	"GET /api/v1/openapi.json": true,
+}
+
+// routeRoles lists the routes needing more than the viewer role; everything
+// under /api/v1/admin needs the admin role
+var routeRoles = map[string]string{
+	"POST /api/v1/trading/enable":                  bot.RoleTrader,
+	"POST /api/v1/trading/disable":                 bot.RoleTrader,
+	"POST /api/v1/trading/close":                   bot.RoleTrader,
+	"POST /api/v1/trading/safe-mode/exit":          bot.RoleTrader,
+	"POST /api/v1/alerts":                          bot.RoleTrader,
+	"PUT /api/v1/alerts/:id":                       bot.RoleTrader,
+	"DELETE /api/v1/alerts/:id":                    bot.RoleTrader,
+	"POST /api/v1/predictions/subscriptions":       bot.RoleTrader,
+	"DELETE /api/v1/predictions/subscriptions/:id": bot.RoleTrader,
+	"POST /api/v1/backtest":                        bot.RoleTrader,
+	"GET /api/v1/audit":                            bot.RoleAdmin,
+	"GET /api/v1/config":                           bot.RoleAdmin,
+	"GET /api/v1/config/history":                   bot.RoleAdmin,
+	"GET /api/v1/config/history/:version":          bot.RoleAdmin,
+	"DELETE /api/v1/config/history/:version":       bot.RoleAdmin,
+	"POST /api/v1/config/rollback/:version":        bot.RoleAdmin,
+	"PUT /api/v1/config":                           bot.RoleAdmin,
+	"POST /api/v1/config/indicators/:name/enable":  bot.RoleAdmin,
+	"POST /api/v1/config/indicators/:name/disable": bot.RoleAdmin,
+}
+
+// routeRole returns the minimum role of a route by method and Gin path, or ""
+// for routes outside the API and public ones
+func routeRole(method, path string) string {
+	key := method + " " + path
+	switch {
+	case publicRoutes[key] || !strings.HasPrefix(path, "/api/v1/"):
+		return ""
+	case strings.HasPrefix(path, "/api/v1/admin/"):
+		return bot.RoleAdmin
+	case routeRoles[key] != "":
+		return routeRoles[key]
+	}
+	return bot.RoleViewer
+}
+
+// authorize checks the caller's token against the route's role. Admin routes
+// always need a token; other routes only once admin.users is set. Calls to
+// trader and admin routes are logged with the caller's name.
+func (s *APIServer) authorize(c *gin.Context) {
+	role := routeRole(c.Request.Method, c.FullPath())
+	if role == "" {
+		c.Next()
+		return
+	}
+
+	access := s.config.Admin
+	user := bot.APIUser{Name: "anonymous", Role: bot.RoleTrader} // Without users, anyone may call the trader routes
+	if role == bot.RoleAdmin || access.AccessControl() {
+		if role == bot.RoleAdmin && access.Token == "" && !hasAdminUser(access.Users) {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{Error: "admin endpoints are disabled: set admin.token, ADMIN_TOKEN or an admin user"})
+			return
+		}
+		token := c.GetHeader("X-Admin-Token")
+		if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimPrefix(auth, "Bearer ")
+		}
+		var ok bool
+		if user, ok = access.Authenticate(token); !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Error: "invalid or missing API token"})
+			return
+		}
+		if !bot.RoleAllows(user.Role, role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{Error: fmt.Sprintf("role %s cannot call %s %s (requires %s)", user.Role, c.Request.Method, c.FullPath(), role)})
+			return
+		}
+	}
+	c.Set(apiUserKey, user)
+	c.Next()
+
+	if role != bot.RoleViewer {
+		requestLogger(c).Info("🔐 Authorized call", "user", user.Name, "role", user.Role,
+			"method", c.Request.Method, "path", c.Request.URL.Path, "status", c.Writer.Status())
+	}
+}
+
+// apiUser returns the caller set by authorize
+func (s *APIServer) apiUser(c *gin.Context) bot.APIUser {
+	if user, ok := c.Get(apiUserKey); ok {
+		return user.(bot.APIUser)
+	}
+	return bot.APIUser{Name: "anonymous"}
+}
+
+// hasAdminUser reports whether any configured user has the admin role
+func hasAdminUser(users []bot.APIUser) bool {
+	for _, user := range users {
+		if user.Role == bot.RoleAdmin {
+			return true
+		}
+	}
+	return false
+}
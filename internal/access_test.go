@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"trading-bot/pkg/bot"
+)
+
+func TestRoleBasedAccess(t *testing.T) {
+	t.Log("🔐 Testing per-route roles, open mode, the trading-control audit log and request IDs")
+
+	config := bot.DefaultConfig()
+	config.DataProvider = "sample"
+
+	call := func(server *APIServer, method, path, token string) int {
+		request := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	// Without users only the admin routes need a token
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+	if code := call(server, "GET", "/api/v1/trading/status", ""); code != http.StatusOK {
+		t.Errorf("Expected open read access without users, got %d", code)
+	}
+	if code := call(server, "GET", "/api/v1/config", ""); code != http.StatusForbidden {
+		t.Errorf("Expected config to stay disabled without an admin token, got %d", code)
+	}
+
+	config.Admin.Token = "root-token"
+	config.Admin.Users = []bot.APIUser{
+		{Name: "vera", Token: "viewer-token", Role: bot.RoleViewer},
+		{Name: "tom", Token: "trader-token", Role: bot.RoleTrader},
+	}
+	if err := bot.ValidateConfig(config); err != nil {
+		t.Fatalf("Expected valid users, got %v", err)
+	}
+	server = NewAPIServer(config, bot.NewTradingBot(config), "0")
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	cases := []struct {
+		method, path, token string
+		want                int
+	}{
+		{"GET", "/api/v1/health", "", http.StatusOK},
+		{"GET", "/api/v1/trading/status", "", http.StatusUnauthorized},
+		{"GET", "/api/v1/trading/status", "wrong", http.StatusUnauthorized},
+		{"GET", "/api/v1/trading/status", "viewer-token", http.StatusOK},
+		{"POST", "/api/v1/trading/disable", "viewer-token", http.StatusForbidden},
+		{"POST", "/api/v1/trading/disable", "trader-token", http.StatusOK},
+		{"GET", "/api/v1/config", "trader-token", http.StatusForbidden},
+		{"POST", "/api/v1/admin/reset-stats", "trader-token", http.StatusForbidden},
+		{"POST", "/api/v1/admin/reset-stats", "root-token", http.StatusOK},
+	}
+	for _, c := range cases {
+		if code := call(server, c.method, c.path, c.token); code != c.want {
+			t.Errorf("%s %s with %q: expected %d, got %d", c.method, c.path, c.token, c.want, code)
+		}
+	}
+
+	// Trader and admin calls are logged with the caller; reads and rejected calls are not
+	auditLine := func(user string) string {
+		for _, line := range strings.Split(logs.String(), "\n") {
+			if strings.Contains(line, "Authorized call") && strings.Contains(line, "user="+user+" ") {
+				return line
+			}
+		}
+		return ""
+	}
+	audited := func(user, path string) bool {
+		line := auditLine(user)
+		return strings.Contains(line, "method=POST") && strings.Contains(line, "path="+path+" ") && strings.Contains(line, "status=200")
+	}
+	if !audited("tom", "/api/v1/trading/disable") || !audited("admin", "/api/v1/admin/reset-stats") || auditLine("vera") != "" {
+		t.Errorf("Unexpected audit log:\n%s", logs.String())
+	}
+
+	// Request logs carry the caller's request ID, or a generated one, and echo it back
+	request := httptest.NewRequest("GET", "/api/v1/health", nil)
+	request.Header.Set("X-Request-ID", "req-42")
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+	if recorder.Header().Get("X-Request-ID") != "req-42" || !strings.Contains(logs.String(), "request_id=req-42") {
+		t.Errorf("Expected request ID req-42 in the response and log, got %q", recorder.Header().Get("X-Request-ID"))
+	}
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest("GET", "/api/v1/health", nil))
+	if len(recorder.Header().Get("X-Request-ID")) != 16 {
+		t.Errorf("Expected a generated request ID, got %q", recorder.Header().Get("X-Request-ID"))
+	}
+
+	// The OpenAPI document carries each route's role
+	var spec struct {
+		Paths map[string]map[string]map[string]interface{} `json:"paths"`
+	}
+	data, _ := json.Marshal(BuildOpenAPISpec())
+	json.Unmarshal(data, &spec)
+	if role := spec.Paths["/api/v1/trading/close"]["post"]["x-required-role"]; role != bot.RoleTrader {
+		t.Errorf("Expected trading/close to require the trader role, got %v", role)
+	}
+
+	// Users need distinct tokens and a known role
+	config.Admin.Users = append(config.Admin.Users, bot.APIUser{Name: "eve", Token: "trader-token", Role: "owner"})
+	if err := bot.ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "admin.users[2].token") || !strings.Contains(err.Error(), "admin.users[2].role") {
+		t.Errorf("Expected duplicate token and unknown role errors, got %v", err)
+	}
+}
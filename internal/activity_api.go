@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"net/http"
+	"time"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActivityResponse is one trading day's timeline of bot actions
+type ActivityResponse struct {
+	Date   string              `json:"date" example:"2024-01-15"` // Trading day in the session time zone
+	Count  int                 `json:"count" example:"42"`
+	Events []bot.ActivityEvent `json:"events"` // Oldest first
+}
+
+// getActivity returns a day's signals, filter overrides, risk blocks, orders and trades
+// @Summary Get the activity timeline of a day
+// @Description Get a chronological timeline merging the signals, filter overrides (entries skipped by safe mode, maintenance or disabled trading), risk blocks, orders, fills and closed trades of one trading day. Days follow the session settings; only what the in-memory histories still hold is included.
+// @Tags trading
+// @Produce json
+// @Param date query string false "Trading day as YYYY-MM-DD (default: today)"
+// @Success 200 {object} ActivityResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /activity [get]
+func (s *APIServer) getActivity(c *gin.Context) {
+	day := s.config.Session.TradingDay(time.Now())
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, s.config.Session.Location())
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid date, use YYYY-MM-DD"})
+			return
+		}
+		day = parsed
+	}
+
+	events := s.tradingBot.GetActivity(day)
+	c.JSON(http.StatusOK, ActivityResponse{Date: day.Format("2006-01-02"), Count: len(events), Events: events})
+}
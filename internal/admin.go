@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"net/http"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminRefreshResponse reports the candles reloaded per timeframe
+type AdminRefreshResponse struct {
+	Status  string         `json:"status" example:"success"`
+	Message string         `json:"message" example:"Historical data refreshed"`
+	Candles map[string]int `json:"candles"` // Candles stored per timeframe after the refresh
+}
+
+// AdminResetResponse returns the cleared performance statistics
+type AdminResetResponse struct {
+	Status      string                `json:"status" example:"success"`
+	Message     string                `json:"message" example:"Performance stats and daily loss counters reset"`
+	Performance *bot.PerformanceStats `json:"performance"`
+}
+
+// ReloadCredentialsRequest optionally carries new Binance API keys
+type ReloadCredentialsRequest struct {
+	APIKey    string `json:"api_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+// AdminCredentialsResponse reports which provider clients picked up rotated keys
+type AdminCredentialsResponse struct {
+	Status    string `json:"status" example:"success"`
+	Message   string `json:"message" example:"Binance API keys reloaded"`
+	Source    string `json:"source" example:"environment"` // "request" or "environment"
+	Providers int    `json:"providers" example:"2"`        // Provider clients that picked up the new keys
+	APIKey    string `json:"api_key" example:"abcd…wxyz"`  // Masked API key now in use
+}
+
+// refreshData re-fetches every timeframe from the data provider
+// @Summary Refresh market data
+// @Description Force a re-fetch of all timeframes, replacing the stored candles
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} AdminRefreshResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /admin/refresh-data [post]
+func (s *APIServer) refreshData(c *gin.Context) {
+	counts, err := s.tradingBot.RefreshData()
+	if err != nil {
+		c.JSON(http.StatusBadGateway, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminRefreshResponse{
+		Status:  "success",
+		Message: "Historical data refreshed",
+		Candles: counts,
+	})
+}
+
+// resetStats clears performance statistics and daily loss counters
+// @Summary Reset performance stats
+// @Description Reset performance statistics and the account/strategy daily loss counters; trade history is kept
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} AdminResetResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /admin/reset-stats [post]
+func (s *APIServer) resetStats(c *gin.Context) {
+	previous := s.tradingBot.GetTradingStatus()
+	stats, err := s.tradingBot.ResetStats()
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+		return
+	}
+	s.tradingBot.Audit(s.apiUser(c), bot.AuditStatsReset, "", map[string]interface{}{
+		"performance":     previous.Performance,
+		"daily_loss_used": previous.RiskManagement.DailyLossUsed,
+	}, stats)
+
+	c.JSON(http.StatusOK, AdminResetResponse{
+		Status:      "success",
+		Message:     "Performance stats and daily loss counters reset",
+		Performance: stats,
+	})
+}
+
+// reloadCredentials rotates the Binance API keys without restarting the engine
+// @Summary Reload Binance API keys
+// @Description Rotate the Binance API keys in place. With an empty body, BINANCE_API_KEY/BINANCE_SECRET_KEY (or their *_FILE variants) are re-read, as on SIGHUP.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param keys body ReloadCredentialsRequest false "New keys (omit to re-read the environment)"
+// @Success 200 {object} AdminCredentialsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/reload-credentials [post]
+func (s *APIServer) reloadCredentials(c *gin.Context) {
+	var request ReloadCredentialsRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+			return
+		}
+	}
+
+	reload, err := s.tradingBot.ReloadCredentials(request.APIKey, request.SecretKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	s.tradingBot.Audit(s.apiUser(c), bot.AuditCredentialsReload, "binance", nil, reload)
+
+	c.JSON(http.StatusOK, AdminCredentialsResponse{
+		Status:    "success",
+		Message:   "Binance API keys reloaded",
+		Source:    reload.Source,
+		Providers: reload.Providers,
+		APIKey:    reload.APIKey,
+	})
+}
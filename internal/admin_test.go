@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"trading-bot/pkg/bot"
+)
+
+func TestAdminEndpoints(t *testing.T) {
+	t.Log("🛡️ Testing admin refresh-data and reset-stats endpoints")
+
+	config := bot.DefaultConfig()
+	config.DataProvider = "sample"
+
+	post := func(server *APIServer, path string, headers map[string]string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("POST", path, nil)
+		for name, value := range headers {
+			request.Header.Set(name, value)
+		}
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Without a configured token the admin endpoints are disabled
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+	if recorder := post(server, "/api/v1/admin/reset-stats", nil); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 without a configured token, got %d", recorder.Code)
+	}
+
+	config.Admin.Token = "s3cret"
+	tradingBot := bot.NewTradingBot(config)
+	server = NewAPIServer(config, tradingBot, "0")
+
+	// Missing or wrong tokens are rejected
+	if recorder := post(server, "/api/v1/admin/reset-stats", nil); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", recorder.Code)
+	}
+	if recorder := post(server, "/api/v1/admin/refresh-data", map[string]string{"Authorization": "Bearer wrong"}); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with a wrong token, got %d", recorder.Code)
+	}
+
+	// Refresh replaces every timeframe with a fresh fetch
+	bearer := map[string]string{"Authorization": "Bearer s3cret"}
+	for i := 0; i < 2; i++ {
+		recorder := post(server, "/api/v1/admin/refresh-data", bearer)
+		var refreshed AdminRefreshResponse
+		if err := json.Unmarshal(recorder.Body.Bytes(), &refreshed); err != nil || recorder.Code != http.StatusOK {
+			t.Fatalf("Refresh failed with %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if refreshed.Candles["5m"] != 100 || refreshed.Candles["1d"] != 200 || len(refreshed.Candles) != 5 {
+			t.Errorf("Unexpected refreshed candle counts: %v", refreshed.Candles)
+		}
+	}
+	candles, err := tradingBot.GetCandleHistory(bot.FiveMinute, 0)
+	if err != nil || len(candles) != 100 {
+		t.Errorf("Expected refresh to replace rather than append 5m candles, got %d (err %v)", len(candles), err)
+	}
+
+	// The X-Admin-Token header is accepted as well as a Bearer token
+	recorder := post(server, "/api/v1/admin/reset-stats", map[string]string{"X-Admin-Token": "s3cret"})
+	var reset AdminResetResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &reset); err != nil || recorder.Code != http.StatusOK {
+		t.Fatalf("Reset failed with %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if reset.Performance == nil || reset.Performance.TotalTrades != 0 {
+		t.Errorf("Expected cleared performance stats, got %+v", reset.Performance)
+	}
+}
+
+func TestAdminReloadCredentials(t *testing.T) {
+	t.Log("🔑 Testing admin reload-credentials endpoint")
+
+	config := bot.DefaultConfig()
+	config.DataProvider = "sample"
+	config.Admin.Token = "s3cret"
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+
+	post := func(body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("POST", "/api/v1/admin/reload-credentials", strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer s3cret")
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := post(`{"api_key": "rotated-key-5678", "secret_key": "rotated-secret"}`)
+	var reloaded AdminCredentialsResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &reloaded); err != nil || recorder.Code != http.StatusOK {
+		t.Fatalf("Reload failed with %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if reloaded.Source != "request" || reloaded.APIKey != "rota…5678" {
+		t.Errorf("Unexpected reload response: %+v", reloaded)
+	}
+	if strings.Contains(recorder.Body.String(), "rotated-secret") {
+		t.Error("Response must not echo the secret key")
+	}
+
+	// Only one half of the key pair is rejected
+	if recorder := post(`{"api_key": "only-key"}`); recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a missing secret key, got %d", recorder.Code)
+	}
+}
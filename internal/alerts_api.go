@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"errors"
+	"net/http"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertListResponse lists the configured alerts
+type AlertListResponse struct {
+	Alerts []bot.Alert `json:"alerts"`
+	Count  int         `json:"count" example:"2"`
+}
+
+// AlertDeleteResponse confirms an alert was removed
+type AlertDeleteResponse struct {
+	Status string `json:"status" example:"success"`
+	ID     string `json:"id" example:"alert_1"`
+}
+
+// alertError maps an alert error to its status: unknown IDs are 404, bad specs 400
+func alertError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, bot.ErrAlertNotFound) {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, ErrorResponse{Error: err.Error()})
+}
+
+// listAlerts returns every price and indicator alert
+// @Summary List alerts
+// @Description List the user-defined price and indicator alerts with their trigger counts
+// @Tags alerts
+// @Produce json
+// @Success 200 {object} AlertListResponse
+// @Router /alerts [get]
+func (s *APIServer) listAlerts(c *gin.Context) {
+	alerts := s.tradingBot.GetAlerts()
+	c.JSON(http.StatusOK, AlertListResponse{Alerts: alerts, Count: len(alerts)})
+}
+
+// createAlert adds a price or indicator alert
+// @Summary Create an alert
+// @Description Alert through the notifiers when a condition is met, e.g. "RSI_5m < 25" or "price crosses 65000". Alerts are evaluated on every signal whether or not trading is enabled.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param alert body bot.AlertSpec true "Alert"
+// @Success 201 {object} bot.Alert
+// @Failure 400 {object} ErrorResponse
+// @Router /alerts [post]
+func (s *APIServer) createAlert(c *gin.Context) {
+	var spec bot.AlertSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+
+	alert, err := s.tradingBot.CreateAlert(spec)
+	if err != nil {
+		alertError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, alert)
+}
+
+// getAlert returns one alert
+// @Summary Get an alert
+// @Tags alerts
+// @Produce json
+// @Param id path string true "Alert ID"
+// @Success 200 {object} bot.Alert
+// @Failure 404 {object} ErrorResponse
+// @Router /alerts/{id} [get]
+func (s *APIServer) getAlert(c *gin.Context) {
+	alert, err := s.tradingBot.GetAlert(c.Param("id"))
+	if err != nil {
+		alertError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, alert)
+}
+
+// updateAlert replaces an alert's condition and settings
+// @Summary Update an alert
+// @Description Replace an alert's spec. The alert is re-armed, so a one-shot alert that already fired becomes active again.
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Param id path string true "Alert ID"
+// @Param alert body bot.AlertSpec true "Alert"
+// @Success 200 {object} bot.Alert
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /alerts/{id} [put]
+func (s *APIServer) updateAlert(c *gin.Context) {
+	var spec bot.AlertSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+
+	alert, err := s.tradingBot.UpdateAlert(c.Param("id"), spec)
+	if err != nil {
+		alertError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, alert)
+}
+
+// deleteAlert removes an alert
+// @Summary Delete an alert
+// @Tags alerts
+// @Produce json
+// @Param id path string true "Alert ID"
+// @Success 200 {object} AlertDeleteResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /alerts/{id} [delete]
+func (s *APIServer) deleteAlert(c *gin.Context) {
+	id := c.Param("id")
+	if err := s.tradingBot.DeleteAlert(id); err != nil {
+		alertError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, AlertDeleteResponse{Status: "success", ID: id})
+}
@@ -3,16 +3,19 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"math"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"trading-bot/pkg/bot"
 
+	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -42,12 +45,26 @@ type PredictionResponse struct {
 	FiveMinuteSignal string                `json:"five_minute_signal" example:"Based on 5-minute timeframe analysis"`
 	PredictionStage  string                `json:"prediction_stage" example:"INITIAL or FOLLOWUP"`
 
+	// Probability of each move size (STRONG_DOWN ... STRONG_UP) at PredictionTime
+	MagnitudeBuckets []bot.MagnitudeBucket `json:"magnitude_buckets"`
+	MagnitudeBucket  string                `json:"magnitude_bucket" example:"MILD_UP"` // Most likely bucket
+
+	// Latency compensation: PredictionTime is measured from DataTimestamp, not request arrival
+	DataTimestamp   string `json:"data_timestamp" example:"2023-01-01T11:59:59Z"`
+	CandleCloseTime string `json:"candle_close_time" example:"2023-01-01T12:00:00Z"`
+	FetchLatencyMs  int64  `json:"fetch_latency_ms" example:"850"`
+	PriceLatencyMs  int64  `json:"price_latency_ms" example:"120"`
+
+	// DEGRADED while the exchange is in scheduled maintenance or the bot is in safe mode
+	DataFreshness string                 `json:"data_freshness" example:"LIVE,DEGRADED"`
+	Maintenance   *bot.MaintenanceStatus `json:"maintenance,omitempty"`
+
 	// Pine Script ATR Trading Strategy Information
-	TradingStatus   interface{} `json:"trading_status,omitempty"`   // Current trading status
-	CurrentPosition interface{} `json:"current_position,omitempty"` // Open position details
-	RecentTrades    interface{} `json:"recent_trades,omitempty"`    // Last 5 trades
-	ATRTrailStop    float64     `json:"atr_trail_stop,omitempty"`   // Current ATR trailing stop
-	TradingEnabled  bool        `json:"trading_enabled"`            // Whether trading is active
+	TradingStatus   *bot.TradingStatus `json:"trading_status,omitempty"`   // Current trading status
+	CurrentPosition *bot.PositionView  `json:"current_position,omitempty"` // Open position details
+	RecentTrades    []bot.TradeView    `json:"recent_trades,omitempty"`    // Last 5 trades
+	ATRTrailStop    float64            `json:"atr_trail_stop,omitempty"`   // Current ATR trailing stop
+	TradingEnabled  bool               `json:"trading_enabled"`            // Whether trading is active
 }
 
 // IndicatorPrediction represents individual indicator prediction
@@ -69,6 +86,74 @@ type HealthResponse struct {
 	Timestamp  string `json:"timestamp" example:"2023-01-01T12:00:00Z"`
 	BotRunning bool   `json:"bot_running" example:"true"`
 	Symbol     string `json:"symbol" example:"BTCUSD"`
+
+	SafeMode    bot.SafeModeStatus    `json:"safe_mode"`
+	Heartbeat   bot.HeartbeatStatus   `json:"heartbeat"`
+	EventExport bot.EventExportStatus `json:"event_export"`
+}
+
+// ErrorsResponse lists recent classified engine errors
+type ErrorsResponse struct {
+	Errors []bot.ErrorRecord `json:"errors"`
+	Stats  bot.ErrorStats    `json:"stats"`
+}
+
+// PositionResponse holds the open position; Position is null when flat
+type PositionResponse struct {
+	Position *bot.Position `json:"position"`
+	Message  string        `json:"message,omitempty" example:"No open position"`
+}
+
+// TradeHistoryResponse is a page of closed trades
+type TradeHistoryResponse struct {
+	PageInfo
+	Trades []*bot.Trade `json:"trades"`
+}
+
+// SignalHistoryResponse is a page of generated signals
+type SignalHistoryResponse struct {
+	PageInfo
+	Signals []*bot.TradingSignal `json:"signals"`
+}
+
+// PredictionHistoryResponse is a page of served predictions
+type PredictionHistoryResponse struct {
+	PageInfo
+	Predictions []PredictionResponse `json:"predictions"`
+}
+
+// CandleHistoryResponse lists stored candles for one timeframe, oldest first
+type CandleHistoryResponse struct {
+	Symbol    string       `json:"symbol" example:"BTCUSDT"`
+	Timeframe string       `json:"timeframe" example:"5m"`
+	Count     int          `json:"count" example:"500"`
+	Candles   []bot.Candle `json:"candles"`
+}
+
+// TaxReportResponse is the JSON form of the tax lot report
+type TaxReportResponse struct {
+	Method  bot.TaxLotMethod   `json:"method" example:"FIFO"`
+	Records []bot.TaxLotRecord `json:"records"`
+	Count   int                `json:"count" example:"3"`
+}
+
+// SafeModeResponse is returned after leaving safe mode
+type SafeModeResponse struct {
+	Status   string             `json:"status" example:"success"`
+	SafeMode bot.SafeModeStatus `json:"safe_mode"`
+}
+
+// TradingControlResponse is returned by the enable/disable/close trading controls
+type TradingControlResponse struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message" example:"Position closed manually"`
+	Enabled *bool  `json:"enabled,omitempty" example:"true"`
+}
+
+// TradingControlError is returned when a trading control fails
+type TradingControlError struct {
+	Status string `json:"status" example:"error"`
+	Error  string `json:"error" example:"no open position to close"`
 }
 
 // APIInfo represents API information
@@ -78,12 +163,23 @@ type APIInfo struct {
 	Endpoints []string `json:"endpoints"`
 }
 
+// predictionHistorySize is how many served predictions /predictions keeps
+const predictionHistorySize = 2000
+
 // APIServer manages the REST API for the trading bot
 type APIServer struct {
 	router     *gin.Engine
 	tradingBot *bot.TradingBot
 	config     bot.Config
 	port       string
+
+	predictions *bot.History[PredictionResponse] // Recently served predictions
+
+	configManager *bot.ConfigManager // Nil unless set; config endpoints then return 503
+
+	subscriptions *predictionSubscriptions // Webhooks receiving filtered predictions
+
+	openAPISpec map[string]interface{} // Generated once from the response types
 }
 
 // NewAPIServer creates a new API server
@@ -94,15 +190,22 @@ func NewAPIServer(config bot.Config, tradingBot *bot.TradingBot, port string) *A
 	router := gin.New()
 
 	// Add middleware
-	router.Use(gin.Logger())
+	router.Use(logRequests) // Structured request log with a request ID
 	router.Use(gin.Recovery())
+	router.Use(gzip.Gzip(gzip.DefaultCompression, gzip.WithExcludedPaths([]string{"/api/v1/stream", "/api/v1/ws"})))
 
 	server := &APIServer{
 		router:     router,
 		tradingBot: tradingBot,
 		config:     config,
 		port:       port,
+
+		openAPISpec: BuildOpenAPISpec(),
+		predictions: bot.NewHistory[PredictionResponse](predictionHistorySize),
+
+		subscriptions: newPredictionSubscriptions(),
 	}
+	router.Use(server.authorize) // Per-route roles, see routeRoles
 
 	server.setupRoutes()
 	return server
@@ -119,18 +222,68 @@ func (s *APIServer) setupRoutes() {
 	// API v1 routes
 	v1 := s.router.Group("/api/v1")
 	{
+		v1.GET("/openapi.json", s.getOpenAPISpec)
 		v1.GET("/predict", s.predictPriceDirection)
 		v1.GET("/status", s.getStatus)
 		v1.GET("/signals", s.getLatestSignals)
+		v1.GET("/signals/history", s.getSignalHistory)
+		v1.GET("/predictions", s.getPredictionHistory)
+		v1.GET("/predictions/accuracy", s.getPredictionAccuracy)
+		v1.GET("/predictions/accuracy/breakdown", s.getPredictionAccuracyBreakdown)
+		v1.GET("/predictions/subscriptions", s.listPredictionSubscriptions)
+		v1.POST("/predictions/subscriptions", s.createPredictionSubscription)
+		v1.DELETE("/predictions/subscriptions/:id", s.deletePredictionSubscription)
+		v1.GET("/alerts", s.listAlerts)
+		v1.POST("/alerts", s.createAlert)
+		v1.GET("/alerts/:id", s.getAlert)
+		v1.PUT("/alerts/:id", s.updateAlert)
+		v1.DELETE("/alerts/:id", s.deleteAlert)
 		v1.GET("/health", s.healthCheck)
+		v1.GET("/maintenance", s.getMaintenance)
+		v1.GET("/errors", s.getErrors)
+		v1.GET("/price/index", s.getIndexPrice)
+		v1.GET("/analytics/seasonality", s.getSeasonality)
+		v1.GET("/candles", s.getCandleHistory)
+		v1.GET("/context", s.getContext)
+		v1.GET("/stream", s.streamEvents)
+		v1.GET("/ws", s.websocketEvents)
+		v1.POST("/config/validate", s.validateConfig)
+		v1.GET("/config", s.getConfig)
+		v1.PUT("/config", s.updateConfig)
+		v1.GET("/config/history", s.getConfigHistory)
+		v1.GET("/config/history/:version", s.getConfigVersion)
+		v1.DELETE("/config/history/:version", s.archiveConfigVersion)
+		v1.POST("/config/rollback/:version", s.rollbackConfig)
+		v1.POST("/config/indicators/:name/enable", s.setConfigIndicator(true))
+		v1.POST("/config/indicators/:name/disable", s.setConfigIndicator(false))
+
+		// Backtesting
+		v1.POST("/backtest", s.runBacktest)
+		v1.GET("/backtest/:id", s.getBacktest)
+		v1.GET("/backtest/:id/report", s.getBacktestReport)
 
 		// Pine Script ATR Trading Strategy Endpoints
 		v1.GET("/trading/status", s.getTradingStatus)
 		v1.GET("/trading/position", s.getCurrentPosition)
 		v1.GET("/trading/history", s.getTradeHistory)
+		v1.GET("/trading/history/:id/replay", s.getTradeReplay)
+		v1.GET("/trading/tax-report", s.getTaxReport)
+		v1.GET("/trading/hedges", s.getHedges)
+		v1.GET("/activity", s.getActivity)
+		v1.GET("/audit", s.getAudit)
+		v1.GET("/risk", s.getRisk)
+		v1.POST("/risk/scenario", s.runRiskScenario)
+		v1.GET("/trading/execution-quality", s.getExecutionQuality)
+		v1.POST("/trading/safe-mode/exit", s.exitSafeMode)
 		v1.POST("/trading/enable", s.enableTrading)
 		v1.POST("/trading/disable", s.disableTrading)
 		v1.POST("/trading/close", s.forceClosePosition)
+
+		// Operator actions (require the admin role)
+		admin := v1.Group("/admin")
+		admin.POST("/refresh-data", s.refreshData)
+		admin.POST("/reset-stats", s.resetStats)
+		admin.POST("/reload-credentials", s.reloadCredentials)
 	}
 
 	// Root route
@@ -150,16 +303,51 @@ func (s *APIServer) getAPIInfo(c *gin.Context) {
 		Message: "Trading Bot API with Pine Script ATR Strategy",
 		Version: "1.0.0",
 		Endpoints: []string{
-			"/predict - Predict price direction + trading status (default 5.5 min, use ?seconds=300 for 5 min)",
-			"/status - Get bot status",
+			"/openapi.json - OpenAPI 3 document generated from the response types",
+			"/predict - Predict price direction + trading status (default 5.5 min, use ?seconds=300 for 5 min, ?symbol=ETHUSDT for another configured symbol)",
+			"/status - Get bot status with a section per configured symbol",
 			"/signals - Get latest signals",
+			"/signals/history?limit=50&offset=0&sort=-confidence&signal=BUY - Page through recent signals",
+			"/predictions?limit=50&sort=-timestamp&prediction=HIGHER - Page through served predictions",
+			"/predictions/accuracy - Overall and rolling prediction accuracy by direction, indicator and confidence (limit)",
+			"/predictions/accuracy/breakdown - Prediction hit rate by direction, indicator, regime, volatility tercile, hour and confidence",
+			"/predictions/subscriptions (GET, POST) and /predictions/subscriptions/{id} (DELETE) - Webhooks for predictions above a confidence or flipping direction",
+			"/alerts (GET, POST) and /alerts/{id} (GET, PUT, DELETE) - Manage price and indicator alerts, e.g. \"RSI_5m < 25\"",
 			"/health - Health check",
+			"/maintenance - Current and next scheduled exchange maintenance",
+			"/errors?limit=50&kind=DATA_STALE&severity=CRITICAL - Recent engine errors and counts",
+			"/price/index?symbol=BTCUSDT - Median price across Binance/Coinbase/Kraken",
+			"/analytics/seasonality - Directional bias and volatility by hour-of-day and day-of-week",
+			"/candles?timeframe=5m&limit=500 - Stored candle history (limit=0 for all)",
+			"/context?symbol=BTCUSDT - Candles, data freshness and price source behind the most recent signal",
+			"/stream?types=signal,trade - Server-sent events for signals, trades, positions, predictions and errors",
+			"/ws?topics=signal,position - WebSocket push of the same events with per-topic subscribe/unsubscribe messages",
+			"/config/validate (POST) - Check a config.json document without applying it",
+			"/config (GET, PUT, admin role) - Read or update config.json; signal settings take effect without a restart",
+			"/config/indicators/{name}/enable|disable (POST, admin role) - Turn an indicator on or off and save config.json",
+			"/config/history?include_archived=true (admin role) and /config/history/{version} (GET, DELETE) - Applied configs with the performance under each; DELETE archives",
+			"/config/rollback/{version} (POST, admin role) - Validate and re-apply a stored config version",
+			"/backtest?days=3&fee_percent=0.04&slippage_bps=1 (POST) - Backtest the current config over recent data",
+			"/backtest/{id} - Get a backtest result",
+			"/backtest/{id}/report - Download the backtest HTML report",
 			"/trading/status - Get trading status",
 			"/trading/position - Get current position",
-			"/trading/history?limit=10 - Get trade history",
+			"/trading/history?limit=10&offset=0&sort=-pnl&side=LONG - Page through trade history (limit=0 exports all)",
+			"/trading/history/{id}/replay - Get candles and signals spanning a closed trade",
+			"/trading/tax-report?method=FIFO&year=2024 - Export closed tax lots as CSV",
+			"/trading/hedges?limit=50 - Open hedges and hedge rule audit log",
+			"/activity?date=2024-01-15 - Chronological signals, filter overrides, risk blocks, orders and trades of one trading day",
+			"/audit?user=alice&action=config_change (admin role) - Who changed config, trading state, positions or credentials, with previous values",
+			"/risk - Exposure per symbol and in total, raw and scaled by each symbol's rolling beta to BTC",
+			"/risk/scenario (POST) - PnL, stop triggers and margin of the open book under instantaneous price shocks, e.g. {\"shocks\": [-0.05, -0.1]}",
+			"/trading/execution-quality?limit=20 - Slippage and fill latency stats with recent fills",
+			"/trading/safe-mode/exit - Resume new entries after an outage (POST)",
 			"/trading/enable (POST) - Enable trading",
 			"/trading/disable (POST) - Disable trading",
 			"/trading/close (POST) - Force close position",
+			"/admin/refresh-data (POST, admin role) - Re-fetch all timeframes",
+			"/admin/reset-stats (POST, admin role) - Reset performance stats and daily loss counters",
+			"/admin/reload-credentials (POST, admin role) - Rotate Binance API keys without a restart",
 			"/swagger/index.html - API Documentation",
 		},
 	})
@@ -172,6 +360,7 @@ func (s *APIServer) getAPIInfo(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param seconds query int false "Prediction timeframe in seconds (default: 330 = 5.5 minutes, min: 60, max: 1800)"
+// @Param symbol query string false "One of the configured symbols (default: the traded symbol)"
 // @Success 200 {object} PredictionResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -190,12 +379,21 @@ func (s *APIServer) predictPriceDirection(c *gin.Context) {
 
 	predictionDuration := time.Duration(seconds) * time.Second
 
+	// Any configured symbol can be predicted; only the traded one has trading status
+	symbol := strings.ToUpper(c.DefaultQuery("symbol", s.config.Symbol))
+	if !slices.Contains(s.tradingBot.Symbols(), symbol) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("Symbol %s is not configured. Available: %s", symbol, strings.Join(s.tradingBot.Symbols(), ", ")),
+		})
+		return
+	}
+	traded := symbol == s.config.Symbol
+
 	// 🔄 LOG: Fresh prediction request
-	log.Printf("📊 NEW PREDICTION REQUEST: %s prediction in %.1f minutes - fetching fresh Binance data...",
-		s.config.Symbol, predictionDuration.Minutes())
+	requestLogger(c).Info("📊 New prediction request", "symbol", symbol, "minutes", predictionDuration.Minutes())
 
 	// Generate immediate prediction with on-demand data fetching
-	signal, err := s.tradingBot.GenerateImmediatePrediction()
+	signal, err := s.tradingBot.GenerateSymbolPrediction(symbol)
 	if err != nil {
 		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
 			Error: "Failed to generate prediction: " + err.Error(),
@@ -204,7 +402,9 @@ func (s *APIServer) predictPriceDirection(c *gin.Context) {
 	}
 
 	// Get current price from the trading bot's market data
-	currentPrice, err := s.tradingBot.GetCurrentPrice()
+	priceStarted := time.Now()
+	currentPrice, err := s.tradingBot.GetSymbolPrice(symbol)
+	priceLatency := time.Since(priceStarted)
 	if err != nil || currentPrice == 0 {
 		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
 			Error: "Current price data not available: " + err.Error(),
@@ -218,9 +418,14 @@ func (s *APIServer) predictPriceDirection(c *gin.Context) {
 	// Build indicator predictions
 	indicators := s.buildIndicatorPredictions(signal)
 
-	// Calculate prediction time (current time + configurable duration)
+	// Calculate prediction time from the data timestamp so slow fetches don't
+	// shift the evaluation window (falls back to now if no fetch was timed)
 	requestTime := time.Now().UTC()
-	predictionTime := requestTime.Add(predictionDuration)
+	timing := s.tradingBot.GetSymbolDataTiming(symbol)
+	if timing.DataTimestamp.IsZero() {
+		timing.DataTimestamp = requestTime
+	}
+	predictionTime := timing.TargetTime(predictionDuration).UTC()
 	timeToTarget := predictionTime.Sub(requestTime)
 
 	// Determine prediction stage
@@ -232,19 +437,23 @@ func (s *APIServer) predictPriceDirection(c *gin.Context) {
 		}
 	}
 
-	// Get trading information for Pine Script ATR strategy
-	tradingStatus := s.tradingBot.GetTradingStatus()
-	currentPosition := s.tradingBot.GetCurrentTradingPosition()
-	recentTrades := s.tradingBot.GetTradeHistory(5) // Last 5 trades
+	// Get trading information for Pine Script ATR strategy (the traded symbol only)
+	var tradingStatus *bot.TradingStatus
+	var currentPosition *bot.PositionView
+	var recentTrades []bot.TradeView
+	if traded {
+		status := s.tradingBot.GetTradingStatus()
+		tradingStatus = &status
+		currentPosition = s.tradingBot.GetPositionView()
+		recentTrades = s.tradingBot.GetTradeViews(5) // Last 5 trades
+	}
 
 	// Get ATR trailing stop value from current position or signals
 	var atrTrailStop float64
 	tradingEnabled := false
 
-	if statusMap, ok := tradingStatus.(map[string]interface{}); ok {
-		if enabled, exists := statusMap["enabled"]; exists {
-			tradingEnabled = enabled.(bool)
-		}
+	if tradingStatus != nil {
+		tradingEnabled = tradingStatus.Enabled
 	}
 
 	if currentPosition != nil {
@@ -259,12 +468,28 @@ func (s *APIServer) predictPriceDirection(c *gin.Context) {
 		}
 	}
 
+	// Data can't be trusted as live during exchange downtime or outages
+	freshness := "LIVE"
+	var maintenance *bot.MaintenanceStatus
+	if status := s.tradingBot.GetMaintenanceStatus(); status.Active || status.Next != nil {
+		maintenance = &status
+		if status.Active {
+			freshness = "DEGRADED"
+		}
+	}
+	if s.tradingBot.GetSafeModeStatus().Active {
+		freshness = "DEGRADED"
+	}
+
 	// 🔥 ENHANCED: Use Trading Status to Improve Predictions!
-	prediction = s.enhancePredictionWithTradingStatus(prediction, currentPosition, recentTrades, tradingStatus, currentPrice, atrTrailStop)
+	if traded {
+		prediction = s.enhancePredictionWithTradingStatus(prediction, recentTrades, *tradingStatus, currentPrice, atrTrailStop)
+	}
+	buckets := s.tradingBot.PredictSymbolMagnitude(symbol, prediction.Direction, prediction.Confidence, currentPrice, predictionDuration)
 
 	response := PredictionResponse{
 		Symbol:           signal.Symbol,
-		CurrentPrice:     currentPrice,
+		CurrentPrice:     s.tradingBot.GetSymbolFiltersFor(symbol).RoundPrice(currentPrice),
 		Prediction:       prediction.Direction,
 		Confidence:       prediction.Confidence,
 		Reasoning:        prediction.Reasoning,
@@ -274,6 +499,14 @@ func (s *APIServer) predictPriceDirection(c *gin.Context) {
 		Indicators:       indicators,
 		FiveMinuteSignal: prediction.FiveMinuteSignal,
 		PredictionStage:  stage,
+		MagnitudeBuckets: buckets,
+		MagnitudeBucket:  bot.MostLikelyBucket(buckets),
+		DataTimestamp:    timing.DataTimestamp.UTC().Format(time.RFC3339Nano),
+		CandleCloseTime:  timing.CandleCloseTime.UTC().Format(time.RFC3339),
+		FetchLatencyMs:   timing.FetchLatency.Milliseconds(),
+		PriceLatencyMs:   priceLatency.Milliseconds(),
+		DataFreshness:    freshness,
+		Maintenance:      maintenance,
 
 		// Pine Script ATR Trading Strategy Data
 		TradingStatus:   tradingStatus,
@@ -285,6 +518,10 @@ func (s *APIServer) predictPriceDirection(c *gin.Context) {
 
 	// Prediction tracker is now initialized in convertSignalToPrediction
 
+	s.predictions.Add(response)
+	s.tradingBot.TrackSymbolPrediction(symbol, response.Prediction, response.Confidence, currentPrice, predictionTime, signal.IndicatorSignals)
+	s.tradingBot.PublishEvent(bot.EventPrediction, response)
+	s.subscriptions.dispatch(response)
 	c.JSON(http.StatusOK, response)
 }
 
@@ -309,7 +546,7 @@ func (s *APIServer) convertSignalToPrediction(signal *bot.TradingSignal, current
 	}
 
 	// 🔥 NEW: Detect price momentum to prevent false signals
-	priceMomentum := s.detectPriceMomentum(currentPrice)
+	priceMomentum := s.detectPriceMomentum(signal.Symbol, currentPrice)
 
 	// Enhanced 5-minute focused analysis with trend-aware filtering
 	fiveMinBuy := 0
@@ -366,8 +603,8 @@ func (s *APIServer) convertSignalToPrediction(signal *bot.TradingSignal, current
 	if fiveMinBuy > fiveMinSell {
 		direction = "HIGHER"
 		priceTarget := currentPrice * (1 + 0.001*float64(fiveMinBuy-fiveMinSell))
-		reasoning = fmt.Sprintf("5-minute BULLISH: %d buy vs %d sell signals. Target: %.2f in %s",
-			fiveMinBuy, fiveMinSell, priceTarget, durationText)
+		reasoning = fmt.Sprintf("5-minute BULLISH: %d buy vs %d sell signals. Target: %s in %s",
+			fiveMinBuy, fiveMinSell, s.tradingBot.GetSymbolFiltersFor(signal.Symbol).FormatPrice(priceTarget), durationText)
 
 		// Add momentum info to reasoning
 		if priceMomentum == "BULLISH" {
@@ -378,8 +615,8 @@ func (s *APIServer) convertSignalToPrediction(signal *bot.TradingSignal, current
 	} else if fiveMinSell > fiveMinBuy {
 		direction = "LOWER"
 		priceTarget := currentPrice * (1 - 0.001*float64(fiveMinSell-fiveMinBuy))
-		reasoning = fmt.Sprintf("5-minute BEARISH: %d sell vs %d buy signals. Target: %.2f in %s",
-			fiveMinSell, fiveMinBuy, priceTarget, durationText)
+		reasoning = fmt.Sprintf("5-minute BEARISH: %d sell vs %d buy signals. Target: %s in %s",
+			fiveMinSell, fiveMinBuy, s.tradingBot.GetSymbolFiltersFor(signal.Symbol).FormatPrice(priceTarget), durationText)
 
 		// Add momentum info to reasoning
 		if priceMomentum == "BEARISH" {
@@ -403,11 +640,11 @@ func (s *APIServer) convertSignalToPrediction(signal *bot.TradingSignal, current
 }
 
 // 🔥 NEW: Detect price momentum to prevent false signals
-func (s *APIServer) detectPriceMomentum(currentPrice float64) string {
+func (s *APIServer) detectPriceMomentum(symbol string, currentPrice float64) string {
 	// 🚀 REAL-TIME: Fetch fresh 5-minute candles directly from Binance API
-	binanceCandles, err := s.fetchBinanceCandles("BTCUSDT", "5m", 5)
+	binanceCandles, err := s.fetchBinanceCandles(symbol, "5m", 5)
 	if err != nil {
-		log.Printf("⚠️ Failed to fetch Binance candles for momentum: %v", err)
+		apiLog.Warn("Failed to fetch Binance candles for momentum", "symbol", symbol, "error", err)
 		return "NEUTRAL" // Default if API fails
 	}
 
@@ -432,32 +669,26 @@ func (s *APIServer) detectPriceMomentum(currentPrice float64) string {
 	// Medium-term momentum (last 3 candles)
 	mediumTermChange := (recent - earlier) / earlier
 
-	log.Printf("🔍 MOMENTUM ANALYSIS:")
-	log.Printf("   Recent prices: %.2f → %.2f → %.2f", earlier, previous, recent)
-	log.Printf("   Short-term change: %.4f%% (%.2f → %.2f)", shortTermChange*100, previous, recent)
-	log.Printf("   Medium-term change: %.4f%% (%.2f → %.2f)", mediumTermChange*100, earlier, recent)
-
 	// Strong momentum thresholds
 	strongBullishThreshold := 0.003  // 0.3% up
 	strongBearishThreshold := -0.003 // 0.3% down
 
 	// Determine momentum with confidence
+	momentum, strength := "NEUTRAL", ""
 	if shortTermChange > strongBullishThreshold && mediumTermChange > 0 {
-		log.Printf("✅ BULLISH MOMENTUM detected (%.3f%% recent, %.3f%% medium)", shortTermChange*100, mediumTermChange*100)
-		return "BULLISH"
+		momentum, strength = "BULLISH", "strong"
 	} else if shortTermChange < strongBearishThreshold && mediumTermChange < 0 {
-		log.Printf("📉 BEARISH MOMENTUM detected (%.3f%% recent, %.3f%% medium)", shortTermChange*100, mediumTermChange*100)
-		return "BEARISH"
+		momentum, strength = "BEARISH", "strong"
 	} else if shortTermChange > 0.001 { // Mild upward momentum (0.1%+)
-		log.Printf("🔼 MILD BULLISH momentum (%.3f%%)", shortTermChange*100)
-		return "BULLISH"
+		momentum, strength = "BULLISH", "mild"
 	} else if shortTermChange < -0.001 { // Mild downward momentum (0.1%+)
-		log.Printf("🔽 MILD BEARISH momentum (%.3f%%)", shortTermChange*100)
-		return "BEARISH"
+		momentum, strength = "BEARISH", "mild"
 	}
 
-	log.Printf("➖ NEUTRAL momentum")
-	return "NEUTRAL"
+	apiLog.Debug("🔍 Momentum analysis", "symbol", symbol, "momentum", momentum, "strength", strength,
+		"closes", []float64{earlier, previous, recent},
+		"short_term_change_percent", shortTermChange*100, "medium_term_change_percent", mediumTermChange*100)
+	return momentum
 }
 
 // 🚀 NEW: Fetch real-time candles directly from Binance API
@@ -534,7 +765,7 @@ func (s *APIServer) applyTrendAwareFilter(signal bot.SignalType, indicatorName s
 			strings.Contains(indicatorName, "Ichimoku") ||
 			strings.Contains(indicatorName, "S&R") { // S&R often wrong during momentum
 
-			log.Printf("🛡️ FILTERED: Converted %s SELL signal to HOLD (bullish momentum)", indicatorName)
+			apiLog.Debug("🛡️ Filtered SELL signal to HOLD", "indicator", indicatorName, "momentum", momentum)
 			return bot.Hold // Convert ALL oscillator SELL signals to neutral during uptrend
 		}
 	}
@@ -547,7 +778,7 @@ func (s *APIServer) applyTrendAwareFilter(signal bot.SignalType, indicatorName s
 			strings.Contains(indicatorName, "Ichimoku") ||
 			strings.Contains(indicatorName, "S&R") { // S&R often wrong during momentum
 
-			log.Printf("🛡️ FILTERED: Converted %s BUY signal to HOLD (bearish momentum)", indicatorName)
+			apiLog.Debug("🛡️ Filtered BUY signal to HOLD", "indicator", indicatorName, "momentum", momentum)
 			return bot.Hold // Convert ALL oscillator BUY signals to neutral during downtrend
 		}
 	}
@@ -556,14 +787,14 @@ func (s *APIServer) applyTrendAwareFilter(signal bot.SignalType, indicatorName s
 }
 
 // enhancePredictionWithTradingStatus enhances the prediction based on trading status and position
-func (s *APIServer) enhancePredictionWithTradingStatus(prediction PredictionResult, currentPosition interface{}, recentTrades interface{}, tradingStatus interface{}, currentPrice float64, atrTrailStop float64) PredictionResult {
+func (s *APIServer) enhancePredictionWithTradingStatus(prediction PredictionResult, tradesSlice []bot.TradeView, tradingStatus bot.TradingStatus, currentPrice float64, atrTrailStop float64) PredictionResult {
 	// Extract recent trades information
 	var winningTrades, losingTrades int
 	var recentPnL float64
 
-	if tradesSlice, ok := recentTrades.([]*bot.Trade); ok && len(tradesSlice) > 0 {
+	if len(tradesSlice) > 0 {
 		for _, trade := range tradesSlice {
-			if trade.PnL > 0 {
+			if trade.Won() {
 				winningTrades++
 			} else {
 				losingTrades++
@@ -589,54 +820,10 @@ func (s *APIServer) enhancePredictionWithTradingStatus(prediction PredictionResu
 		}
 	}
 
-	// Extract current position information
-	if currentPosition != nil {
-		if posMap, ok := currentPosition.(map[string]interface{}); ok {
-			if side, exists := posMap["side"]; exists {
-				if sideStr, ok := side.(string); ok {
-					if pnl, exists := posMap["pnl"]; exists {
-						if pnlFloat, ok := pnl.(float64); ok {
-							// Position bias adjustment
-							if sideStr == "LONG" && pnlFloat >= 0 {
-								// Current long position is profitable - slight bullish bias
-								if prediction.Direction == "HIGHER" {
-									prediction.Confidence = math.Min(0.95, prediction.Confidence*1.08)
-									prediction.Reasoning = fmt.Sprintf("%s + Long position profitable (+$%.2f)", prediction.Reasoning, pnlFloat)
-								}
-							} else if sideStr == "LONG" && pnlFloat < 0 {
-								// Current long position is losing - slight caution
-								prediction.Confidence = math.Max(0.5, prediction.Confidence*0.95)
-								prediction.Reasoning = fmt.Sprintf("%s - Long position at loss (-$%.2f)", prediction.Reasoning, math.Abs(pnlFloat))
-							}
-						}
-					}
-				}
-			}
-		}
-	}
-
 	// Extract trading status information
-	if statusMap, ok := tradingStatus.(map[string]interface{}); ok {
-		if enabled, exists := statusMap["enabled"]; exists {
-			if enabledBool, ok := enabled.(bool); ok && enabledBool {
-				// Trading is enabled - slight confidence boost
-				prediction.Confidence = math.Min(0.95, prediction.Confidence*1.05)
-			}
-		}
-
-		// Check risk management status
-		if riskMgmt, exists := statusMap["risk_management"]; exists {
-			if riskMap, ok := riskMgmt.(map[string]interface{}); ok {
-				if dailyLoss, exists := riskMap["daily_loss_used"]; exists {
-					if dailyLossFloat, ok := dailyLoss.(float64); ok {
-						if dailyLossFloat > 0.03 { // If daily loss > 3%
-							prediction.Confidence = math.Max(0.4, prediction.Confidence*0.9) // Reduce confidence
-							prediction.Reasoning = fmt.Sprintf("%s - Risk caution: %.1f%% daily loss used", prediction.Reasoning, dailyLossFloat*100)
-						}
-					}
-				}
-			}
-		}
+	if tradingStatus.Enabled {
+		// Trading is enabled - slight confidence boost
+		prediction.Confidence = math.Min(0.95, prediction.Confidence*1.05)
 	}
 
 	// ATR trailing stop confidence adjustment
@@ -781,7 +968,7 @@ func (s *APIServer) buildIndicatorPredictions(signal *bot.TradingSignal) []Indic
 
 // getStatus returns the current bot status
 // @Summary Get bot status
-// @Description Get detailed status information about the trading bot
+// @Description Get detailed status information about the trading bot, with a section per configured symbol under symbols
 // @Tags status
 // @Accept json
 // @Produce json
@@ -829,26 +1016,303 @@ func (s *APIServer) healthCheck(c *gin.Context) {
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 		BotRunning: status.Running,
 		Symbol:     status.Symbol,
+		SafeMode:   s.tradingBot.GetSafeModeStatus(),
+		Heartbeat:  s.tradingBot.GetHeartbeatStatus(),
+
+		EventExport: s.tradingBot.GetEventExportStatus(),
 	}
 
 	if !status.Running {
 		health.Status = "unhealthy"
+	} else if health.SafeMode.Active {
+		health.Status = "degraded"
 	}
 
 	c.JSON(http.StatusOK, health)
 }
 
+// getErrors returns recent classified engine errors
+// @Summary Get recent errors
+// @Description Get recent engine errors (DATA_STALE, PROVIDER_DOWN, INDICATOR_ERROR, RISK_BLOCKED, LIQUIDATION_RISK) newest first, with counts by kind and severity
+// @Tags health
+// @Accept json
+// @Produce json
+// @Param limit query int false "Max errors to return (default: 50)"
+// @Param kind query string false "Filter by kind"
+// @Param severity query string false "Filter by severity (INFO, WARNING, CRITICAL)"
+// @Success 200 {object} ErrorsResponse
+// @Router /errors [get]
+func (s *APIServer) getErrors(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	kind := bot.ErrorKind(strings.ToUpper(c.Query("kind")))
+	severity := strings.ToUpper(c.Query("severity"))
+
+	c.JSON(http.StatusOK, ErrorsResponse{
+		Errors: s.tradingBot.GetRecentErrors(limit, kind, severity),
+		Stats:  s.tradingBot.GetErrorStats(),
+	})
+}
+
+// getMaintenance returns scheduled exchange maintenance
+// @Summary Get maintenance status
+// @Description Get the current and next configured exchange maintenance window and whether new entries are paused
+// @Tags health
+// @Accept json
+// @Produce json
+// @Success 200 {object} bot.MaintenanceStatus
+// @Router /maintenance [get]
+func (s *APIServer) getMaintenance(c *gin.Context) {
+	c.JSON(http.StatusOK, s.tradingBot.GetMaintenanceStatus())
+}
+
+// getIndexPrice returns the multi-venue median index price
+// @Summary Get index price
+// @Description Get the median price across configured venues (Binance, Coinbase, Kraken) with per-venue components
+// @Tags prediction
+// @Accept json
+// @Produce json
+// @Param symbol query string false "Symbol (default: configured symbol)"
+// @Success 200 {object} bot.IndexPrice
+// @Failure 503 {object} ErrorResponse
+// @Router /price/index [get]
+func (s *APIServer) getIndexPrice(c *gin.Context) {
+	symbol := strings.ToUpper(c.DefaultQuery("symbol", s.config.Symbol))
+
+	index, err := s.tradingBot.GetIndexPrice(symbol)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, index)
+}
+
+// getSeasonality returns hour-of-day and day-of-week statistics
+// @Summary Get seasonality statistics
+// @Description Get historical directional bias and volatility of the traded symbol by UTC hour-of-day and day-of-week
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param refresh query bool false "Recompute instead of using cached statistics"
+// @Success 200 {object} bot.SeasonalityStats
+// @Failure 503 {object} ErrorResponse
+// @Router /analytics/seasonality [get]
+func (s *APIServer) getSeasonality(c *gin.Context) {
+	var stats *bot.SeasonalityStats
+	var err error
+	if c.Query("refresh") == "true" {
+		stats, err = s.tradingBot.RefreshSeasonality()
+	} else {
+		stats, err = s.tradingBot.GetSeasonality()
+	}
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// streamEvents pushes bus events to the client as server-sent events
+// @Summary Stream events
+// @Description Stream signals, position updates, trades, predictions and errors as server-sent events (event name = type, data = JSON event)
+// @Tags signals
+// @Produce text/event-stream
+// @Param types query string false "Comma-separated event types to include (default: all)"
+// @Success 200 {object} bot.Event "One event per SSE message"
+// @Router /stream [get]
+func (s *APIServer) streamEvents(c *gin.Context) {
+	include := make(map[bot.EventType]bool)
+	for _, name := range strings.Split(c.Query("types"), ",") {
+		if name = strings.TrimSpace(strings.ToLower(name)); name != "" {
+			include[bot.EventType(name)] = true
+		}
+	}
+
+	events := s.tradingBot.SubscribeEvents("sse", 100)
+	defer s.tradingBot.UnsubscribeEvents(events)
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			return true
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			if len(include) == 0 || include[event.Type] {
+				c.SSEvent(string(event.Type), event)
+			}
+			return true
+		}
+	})
+}
+
+// getCandleHistory returns stored candles for a timeframe
+// @Summary Get candle history
+// @Description Get the candles the bot holds for a timeframe, oldest first; streamed for large requests
+// @Tags signals
+// @Produce json
+// @Param timeframe query string false "Timeframe: 5m, 15m, 45m, 8h or 1d (default: 5m)"
+// @Param limit query int false "Most recent candles to return (default: 500, 0 = all)"
+// @Success 200 {object} CandleHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /candles [get]
+func (s *APIServer) getCandleHistory(c *gin.Context) {
+	timeframe, err := bot.ParseTimeframe(c.DefaultQuery("timeframe", "5m"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "500"))
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "limit must be a non-negative integer"})
+		return
+	}
+
+	candles, err := s.tradingBot.GetCandleHistory(timeframe, limit)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	streamJSONList(c, map[string]interface{}{
+		"symbol":    s.config.Symbol,
+		"timeframe": timeframe.String(),
+	}, "candles", candles)
+}
+
+// getContext returns the multi-timeframe context behind the most recent signal
+// @Summary Get the latest signal's context
+// @Description Get the exact multi-timeframe snapshot the most recent signal or on-demand prediction was generated from: the candles per timeframe, how fresh each timeframe was, the current price and the configured price source
+// @Tags signals
+// @Produce json
+// @Param symbol query string false "Symbol (default: configured symbol)"
+// @Success 200 {object} bot.ContextSnapshot
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /context [get]
+func (s *APIServer) getContext(c *gin.Context) {
+	snapshot, err := s.tradingBot.GetContextSnapshot(strings.ToUpper(c.Query("symbol")))
+	switch {
+	case errors.Is(err, bot.ErrNoContextSnapshot):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+	case err != nil:
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusOK, snapshot)
+	}
+}
+
+// runBacktest backtests the current config
+// @Summary Run backtest
+// @Description Replay the current config over the last N days of historical data; the result and HTML report are saved under the returned id
+// @Tags backtest
+// @Accept json
+// @Produce json
+// @Param days query int false "Days of history to simulate (default: 3, max: 30)"
+// @Param fee_percent query number false "Fee per fill, % of notional (default: backtest.fee_percent)"
+// @Param slippage_bps query number false "Slippage per fill in basis points (default: backtest.slippage_bps)"
+// @Success 200 {object} bot.BacktestResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /backtest [post]
+func (s *APIServer) runBacktest(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "3"))
+	if err != nil || days < 1 || days > 30 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "days must be an integer between 1 and 30"})
+		return
+	}
+
+	costs := make(map[string]*float64)
+	for _, name := range []string{"fee_percent", "slippage_bps"} {
+		raw, ok := c.GetQuery(name)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: name + " must be a non-negative number"})
+			return
+		}
+		costs[name] = &value
+	}
+
+	result, err := s.tradingBot.RunBacktest(days, costs["fee_percent"], costs["slippage_bps"])
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Backtest failed: " + err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getBacktest returns a saved backtest result
+// @Summary Get backtest
+// @Description Get a saved backtest result including trades, equity curve and indicator stats
+// @Tags backtest
+// @Accept json
+// @Produce json
+// @Param id path string true "Backtest ID"
+// @Success 200 {object} bot.BacktestResult
+// @Failure 404 {object} ErrorResponse
+// @Router /backtest/{id} [get]
+func (s *APIServer) getBacktest(c *gin.Context) {
+	result, err := s.tradingBot.GetBacktest(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getBacktestReport downloads the HTML report of a backtest
+// @Summary Download backtest report
+// @Description Download the self-contained HTML report (equity curve, drawdown chart, trade table, per-indicator stats)
+// @Tags backtest
+// @Produce html
+// @Param id path string true "Backtest ID"
+// @Success 200 {file} file "HTML report"
+// @Failure 404 {object} ErrorResponse
+// @Router /backtest/{id}/report [get]
+func (s *APIServer) getBacktestReport(c *gin.Context) {
+	id := c.Param("id")
+	path, err := s.tradingBot.GetBacktestReportPath(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.FileAttachment(path, id+".html")
+}
+
 // Start starts the API server
 func (s *APIServer) Start() error {
-	fmt.Printf("🌐 Starting API server on port %s\n", s.port)
-	fmt.Printf("📡 Prediction endpoint: http://localhost:%s/api/v1/predict\n", s.port)
-	fmt.Printf("📊 Status endpoint: http://localhost:%s/api/v1/status\n", s.port)
-	fmt.Printf("📚 Swagger docs: http://localhost:%s/swagger/index.html\n", s.port)
-	fmt.Printf("🔍 All endpoints: http://localhost:%s/\n", s.port)
+	s.logEndpoints("🌐 Starting API server")
 
 	return s.router.Run(":" + s.port)
 }
 
+// logEndpoints logs the server's port and its main URLs
+func (s *APIServer) logEndpoints(msg string) {
+	base := "http://localhost:" + s.port
+	apiLog.Info(msg, "port", s.port, "predict", base+"/api/v1/predict", "status", base+"/api/v1/status",
+		"trading", base+"/api/v1/trading/*", "swagger", base+"/swagger/index.html")
+}
+
 // StartWithContext starts the API server with context for graceful shutdown
 func (s *APIServer) StartWithContext(ctx context.Context) error {
 	srv := &http.Server{
@@ -859,14 +1323,11 @@ func (s *APIServer) StartWithContext(ctx context.Context) error {
 	// Start server in a goroutine
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			fmt.Printf("API server error: %v\n", err)
+			apiLog.Error("API server error", "error", err)
 		}
 	}()
 
-	fmt.Printf("🌐 API server started on port %s\n", s.port)
-	fmt.Printf("📡 Prediction endpoint: http://localhost:%s/api/v1/predict\n", s.port)
-	fmt.Printf("📚 Trading endpoints: http://localhost:%s/api/v1/trading/*\n", s.port)
-	fmt.Printf("📚 Swagger docs: http://localhost:%s/swagger/index.html\n", s.port)
+	s.logEndpoints("🌐 API server started")
 
 	// Wait for context cancellation
 	<-ctx.Done()
@@ -882,7 +1343,7 @@ func (s *APIServer) StartWithContext(ctx context.Context) error {
 // @Tags trading
 // @Accept json
 // @Produce json
-// @Success 200 {object} interface{} "Trading status"
+// @Success 200 {object} bot.TradingStatus
 // @Router /trading/status [get]
 func (s *APIServer) getTradingStatus(c *gin.Context) {
 	status := s.tradingBot.GetTradingStatus()
@@ -895,20 +1356,15 @@ func (s *APIServer) getTradingStatus(c *gin.Context) {
 // @Tags trading
 // @Accept json
 // @Produce json
-// @Success 200 {object} interface{} "Current position"
+// @Success 200 {object} PositionResponse
 // @Router /trading/position [get]
 func (s *APIServer) getCurrentPosition(c *gin.Context) {
 	position := s.tradingBot.GetCurrentTradingPosition()
 	if position == nil {
-		c.JSON(http.StatusOK, map[string]interface{}{
-			"position": nil,
-			"message":  "No open position",
-		})
+		c.JSON(http.StatusOK, PositionResponse{Message: "No open position"})
 		return
 	}
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"position": position,
-	})
+	c.JSON(http.StatusOK, PositionResponse{Position: position})
 }
 
 // getTradeHistory returns recent trade history
@@ -917,21 +1373,272 @@ func (s *APIServer) getCurrentPosition(c *gin.Context) {
 // @Tags trading
 // @Accept json
 // @Produce json
-// @Param limit query int false "Number of trades to return (default: 10)"
-// @Success 200 {object} interface{} "Trade history"
+// @Param limit query int false "Number of trades to return (default: 10, 0 = all)"
+// @Param offset query int false "Trades to skip (default: 0)"
+// @Param sort query string false "exit_time, entry_time, pnl or pnl_percent; prefix - for descending (default: -exit_time)"
+// @Param from query string false "Only trades closed at or after this RFC3339 time"
+// @Param to query string false "Only trades closed before this RFC3339 time"
+// @Param side query string false "LONG or SHORT"
+// @Param strategy query string false "Strategy name"
+// @Param exit_reason query string false "e.g. ATR_STOP, TAKE_PROFIT, MANUAL"
+// @Success 200 {object} TradeHistoryResponse
+// @Failure 400 {object} ErrorResponse
 // @Router /trading/history [get]
 func (s *APIServer) getTradeHistory(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
+	query, err := parseListQuery(c, 10, 0, "-exit_time", tradeSortKeys.fields())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	side := strings.ToUpper(c.Query("side"))
+	strategy := c.Query("strategy")
+	exitReason := strings.ToUpper(c.Query("exit_reason"))
+
+	trades := make([]*bot.Trade, 0)
+	for _, trade := range s.tradingBot.GetTradeHistory(0) {
+		if (side != "" && trade.Side != side) || (strategy != "" && trade.Strategy != strategy) ||
+			(exitReason != "" && trade.ExitReason != exitReason) || !query.inRange(trade.ExitTime) {
+			continue
+		}
+		trades = append(trades, trade)
+	}
+
+	// Streamed: a full export can hold thousands of trades
+	page, info := paginate(trades, query, tradeSortKeys)
+	setPageHeaders(c, info)
+	streamJSONList(c, pageFields(info), "trades", page)
+}
+
+// getSignalHistory returns a page of recent signals
+// @Summary Get signal history
+// @Description Page through recently generated signals with sorting and filters
+// @Tags signals
+// @Produce json
+// @Param limit query int false "Signals per page (default: 50, max: 500)"
+// @Param offset query int false "Signals to skip (default: 0)"
+// @Param sort query string false "timestamp or confidence; prefix - for descending (default: -timestamp)"
+// @Param from query string false "Only signals at or after this RFC3339 time"
+// @Param to query string false "Only signals before this RFC3339 time"
+// @Param signal query string false "BUY, SELL or HOLD"
+// @Param min_confidence query number false "Minimum confidence (0-1)"
+// @Success 200 {object} SignalHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /signals/history [get]
+func (s *APIServer) getSignalHistory(c *gin.Context) {
+	query, err := parseListQuery(c, 50, 500, "-timestamp", signalSortKeys.fields())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	minConfidence, err := parseMinConfidence(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	signalType := strings.ToUpper(c.Query("signal"))
+
+	signals := make([]*bot.TradingSignal, 0)
+	for _, signal := range s.tradingBot.GetSignalHistory() {
+		if (signalType != "" && signal.Signal.String() != signalType) || signal.Confidence < minConfidence || !query.inRange(signal.Timestamp) {
+			continue
+		}
+		signals = append(signals, signal)
+	}
+
+	page, info := paginate(signals, query, signalSortKeys)
+	setPageHeaders(c, info)
+	c.JSON(http.StatusOK, SignalHistoryResponse{PageInfo: info, Signals: page})
+}
+
+// getPredictionHistory returns a page of served predictions
+// @Summary Get prediction history
+// @Description Page through predictions served by /predict with sorting and filters
+// @Tags prediction
+// @Produce json
+// @Param limit query int false "Predictions per page (default: 50, max: 500)"
+// @Param offset query int false "Predictions to skip (default: 0)"
+// @Param sort query string false "timestamp or confidence; prefix - for descending (default: -timestamp)"
+// @Param from query string false "Only predictions at or after this RFC3339 time"
+// @Param to query string false "Only predictions before this RFC3339 time"
+// @Param prediction query string false "HIGHER, LOWER or NEUTRAL"
+// @Param min_confidence query number false "Minimum confidence (0-1)"
+// @Success 200 {object} PredictionHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /predictions [get]
+func (s *APIServer) getPredictionHistory(c *gin.Context) {
+	query, err := parseListQuery(c, 50, 500, "-timestamp", predictionSortKeys.fields())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	minConfidence, err := parseMinConfidence(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	direction := strings.ToUpper(c.Query("prediction"))
+
+	predictions := make([]PredictionResponse, 0)
+	for _, prediction := range s.predictions.All() {
+		if (direction != "" && prediction.Prediction != direction) || prediction.Confidence < minConfidence || !query.inRange(predictionTime(prediction)) {
+			continue
+		}
+		predictions = append(predictions, prediction)
+	}
+
+	page, info := paginate(predictions, query, predictionSortKeys)
+	setPageHeaders(c, info)
+	c.JSON(http.StatusOK, PredictionHistoryResponse{PageInfo: info, Predictions: page})
+}
+
+// getPredictionAccuracy reports how served predictions turned out
+// @Summary Get prediction accuracy
+// @Description Overall and rolling hit rate of resolved predictions, broken down by predicted direction, indicator and confidence bucket, with the latest outcomes
+// @Tags prediction
+// @Produce json
+// @Param limit query int false "Number of recent outcomes to return (default: 20)"
+// @Success 200 {object} bot.AccuracySummary
+// @Router /predictions/accuracy [get]
+func (s *APIServer) getPredictionAccuracy(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 0 {
+		limit = 20
+	}
+
+	c.JSON(http.StatusOK, s.tradingBot.GetPredictionAccuracy(limit))
+}
+
+// getPredictionAccuracyBreakdown reports when served predictions turned out right
+// @Summary Get prediction accuracy breakdown
+// @Description Hit rate, Brier score and log loss of resolved predictions segmented by direction, indicator, market regime, volatility tercile, session hour of day, confidence bucket and day
+// @Tags prediction
+// @Produce json
+// @Success 200 {object} bot.AccuracyBreakdown
+// @Router /predictions/accuracy/breakdown [get]
+func (s *APIServer) getPredictionAccuracyBreakdown(c *gin.Context) {
+	c.JSON(http.StatusOK, s.tradingBot.GetPredictionAccuracyBreakdown())
+}
+
+// getTradeReplay returns the market data the bot saw during a closed trade
+// @Summary Get trade replay
+// @Description Get the 5-minute candles and signals spanning a closed trade, with entry/exit candle indexes for rendering; pending until the candles after the exit have arrived
+// @Tags trading
+// @Accept json
+// @Produce json
+// @Param id path string true "Trade ID"
+// @Success 200 {object} bot.TradeReplay
+// @Failure 404 {object} ErrorResponse
+// @Router /trading/history/{id}/replay [get]
+func (s *APIServer) getTradeReplay(c *gin.Context) {
+	replay, err := s.tradingBot.GetTradeReplay(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, replay)
+}
+
+// getTaxReport exports closed tax lots built from the trade history
+// @Summary Get tax lot report
+// @Description Assemble closed-lot records with cost basis using FIFO or LIFO lot selection, exported as CSV (or JSON with format=json)
+// @Tags trading
+// @Produce text/csv
+// @Produce json
+// @Param method query string false "Lot selection method: FIFO or LIFO (default: FIFO)"
+// @Param year query int false "Only include lots closed in this calendar year (UTC)"
+// @Param format query string false "Output format: csv or json (default: csv)"
+// @Success 200 {object} TaxReportResponse "With format=json; CSV rows otherwise"
+// @Failure 400 {object} ErrorResponse
+// @Router /trading/tax-report [get]
+func (s *APIServer) getTaxReport(c *gin.Context) {
+	method, err := bot.ParseTaxLotMethod(c.DefaultQuery("method", "FIFO"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var from, to time.Time
+	if yearStr := c.Query("year"); yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil || year < 1970 || year > 9999 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "year must be a valid calendar year"})
+			return
+		}
+		from = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(1, 0, 0)
+	}
+
+	records := s.tradingBot.GenerateTaxReport(method, from, to)
+
+	if c.DefaultQuery("format", "csv") == "json" {
+		c.JSON(http.StatusOK, TaxReportResponse{Method: method, Records: records, Count: len(records)})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=tax_report_%s.csv", strings.ToLower(string(method))))
+	if err := bot.WriteTaxLotsCSV(c.Writer, records); err != nil {
+		requestLogger(c).Error("Failed to write tax report", "error", err)
+	}
+}
+
+// getHedges returns open hedges and the hedging audit trail
+// @Summary Get hedges
+// @Description Get open hedge positions, the current market regime and recent hedging rule decisions
+// @Tags trading
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of audit entries to return (default: 50)"
+// @Success 200 {object} bot.HedgeStatus
+// @Router /trading/hedges [get]
+func (s *APIServer) getHedges(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
 	if err != nil || limit <= 0 {
-		limit = 10
+		limit = 50
 	}
 
-	trades := s.tradingBot.GetTradeHistory(limit)
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"trades": trades,
-		"count":  len(trades),
-	})
+	c.JSON(http.StatusOK, s.tradingBot.GetHedgeStatus(limit))
+}
+
+// getExecutionQuality returns slippage and fill latency statistics
+// @Summary Get execution quality
+// @Description Get average slippage (bps) against the signal price, stop level or order price, and decision-to-fill latency percentiles, with the most recent fills
+// @Tags trading
+// @Accept json
+// @Produce json
+// @Param limit query int false "Number of recent fills to return (default: 20)"
+// @Success 200 {object} bot.ExecutionQuality
+// @Router /trading/execution-quality [get]
+func (s *APIServer) getExecutionQuality(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 0 {
+		limit = 20
+	}
+
+	c.JSON(http.StatusOK, s.tradingBot.GetExecutionQuality(limit))
+}
+
+// exitSafeMode manually leaves outage safe mode
+// @Summary Exit safe mode
+// @Description Resume new entries after an exchange outage put the bot into safe mode
+// @Tags trading
+// @Accept json
+// @Produce json
+// @Success 200 {object} SafeModeResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /trading/safe-mode/exit [post]
+func (s *APIServer) exitSafeMode(c *gin.Context) {
+	previous := s.tradingBot.GetSafeModeStatus()
+	if err := s.tradingBot.ExitSafeMode(); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	s.tradingBot.Audit(s.apiUser(c), bot.AuditSafeModeExit, "", previous, s.tradingBot.GetSafeModeStatus())
+
+	c.JSON(http.StatusOK, SafeModeResponse{Status: "success", SafeMode: s.tradingBot.GetSafeModeStatus()})
 }
 
 // enableTrading enables trade execution
@@ -940,14 +1647,17 @@ func (s *APIServer) getTradeHistory(c *gin.Context) {
 // @Tags trading
 // @Accept json
 // @Produce json
-// @Success 200 {object} interface{} "Trading enabled"
+// @Success 200 {object} TradingControlResponse
 // @Router /trading/enable [post]
 func (s *APIServer) enableTrading(c *gin.Context) {
+	previous := s.tradingBot.GetTradingStatus().Enabled
 	s.tradingBot.EnableTrading()
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"status":  "success",
-		"message": "Pine Script ATR trading strategy enabled",
-		"enabled": true,
+	enabled := true
+	s.tradingBot.Audit(s.apiUser(c), bot.AuditTradingEnable, "", previous, enabled)
+	c.JSON(http.StatusOK, TradingControlResponse{
+		Status:  "success",
+		Message: "Pine Script ATR trading strategy enabled",
+		Enabled: &enabled,
 	})
 }
 
@@ -957,14 +1667,17 @@ func (s *APIServer) enableTrading(c *gin.Context) {
 // @Tags trading
 // @Accept json
 // @Produce json
-// @Success 200 {object} interface{} "Trading disabled"
+// @Success 200 {object} TradingControlResponse
 // @Router /trading/disable [post]
 func (s *APIServer) disableTrading(c *gin.Context) {
+	previous := s.tradingBot.GetTradingStatus().Enabled
 	s.tradingBot.DisableTrading()
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"status":  "success",
-		"message": "Pine Script ATR trading strategy disabled",
-		"enabled": false,
+	enabled := false
+	s.tradingBot.Audit(s.apiUser(c), bot.AuditTradingDisable, "", previous, enabled)
+	c.JSON(http.StatusOK, TradingControlResponse{
+		Status:  "success",
+		Message: "Pine Script ATR trading strategy disabled",
+		Enabled: &enabled,
 	})
 }
 
@@ -974,20 +1687,21 @@ func (s *APIServer) disableTrading(c *gin.Context) {
 // @Tags trading
 // @Accept json
 // @Produce json
-// @Success 200 {object} interface{} "Position closed"
+// @Success 200 {object} TradingControlResponse
+// @Failure 400 {object} TradingControlError
 // @Router /trading/close [post]
 func (s *APIServer) forceClosePosition(c *gin.Context) {
+	position := s.tradingBot.GetTradingStatus().CurrentPosition
 	err := s.tradingBot.ForceClosePosition()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		c.JSON(http.StatusBadRequest, TradingControlError{Status: "error", Error: err.Error()})
 		return
 	}
+	var closed *bot.Trade
+	if trades := s.tradingBot.GetTradeHistory(1); len(trades) > 0 {
+		closed = trades[0]
+	}
+	s.tradingBot.Audit(s.apiUser(c), bot.AuditPositionClose, s.config.Symbol, position, closed)
 
-	c.JSON(http.StatusOK, map[string]interface{}{
-		"status":  "success",
-		"message": "Position closed manually",
-	})
+	c.JSON(http.StatusOK, TradingControlResponse{Status: "success", Message: "Position closed manually"})
 }
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"trading-bot/pkg/bot"
+)
+
+func TestPredictionTradingStatusAdjustments(t *testing.T) {
+	t.Log("🎯 Testing /predict adjustments from the trading status")
+
+	config := bot.DefaultConfig()
+	config.DataProvider = "sample"
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+
+	// Only enabled trading adjusts confidence; daily loss usage doesn't
+	prediction := PredictionResult{Direction: "HIGHER", Confidence: 0.7, Reasoning: "RSI oversold"}
+	status := bot.TradingStatus{Enabled: true, RiskManagement: bot.RiskManager{DailyLossUsed: 0.05}}
+	enhanced := server.enhancePredictionWithTradingStatus(prediction, nil, status, 0, 0)
+	if math.Abs(enhanced.Confidence-0.735) > 1e-9 {
+		t.Errorf("Expected confidence 0.735, got %.4f", enhanced.Confidence)
+	}
+	if enhanced.Reasoning != prediction.Reasoning {
+		t.Errorf("Expected reasoning unchanged, got %q", enhanced.Reasoning)
+	}
+}
+
+func TestForceCloseErrorBody(t *testing.T) {
+	t.Log("🎯 Testing /trading/close error body")
+
+	config := bot.DefaultConfig()
+	config.DataProvider = "sample"
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+
+	recorder := httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, httptest.NewRequest("POST", "/api/v1/trading/close", nil))
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 without an open position, got %d", recorder.Code)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if body["status"] != "error" || body["error"] == "" {
+		t.Errorf("Expected status error with a message, got %v", body)
+	}
+}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"net/http"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditResponse is a page of audit log entries
+type AuditResponse struct {
+	PageInfo
+	Entries []bot.AuditEntry `json:"entries"`
+}
+
+// auditSortKeys are the sortable fields of the audit log
+var auditSortKeys = sortKeys[bot.AuditEntry]{
+	"time": func(a, b bot.AuditEntry) bool { return a.Time.Before(b.Time) },
+}
+
+// getAudit returns a page of the audit log
+// @Summary Get the audit log
+// @Description Page through state-changing operations (config changes, trading enable/disable, manual closes, safe mode exits, stats resets and credential reloads) with who made them, when, and the previous value
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param limit query int false "Entries per page (default: 50, max: 500)"
+// @Param offset query int false "Entries to skip (default: 0)"
+// @Param sort query string false "time; prefix - for descending (default: -time)"
+// @Param from query string false "Only entries at or after this RFC3339 time"
+// @Param to query string false "Only entries before this RFC3339 time"
+// @Param user query string false "Filter by user name"
+// @Param action query string false "Filter by action, e.g. config_change"
+// @Success 200 {object} AuditResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /audit [get]
+func (s *APIServer) getAudit(c *gin.Context) {
+	query, err := parseListQuery(c, 50, 500, "-time", auditSortKeys.fields())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	user, action := c.Query("user"), c.Query("action")
+
+	entries := make([]bot.AuditEntry, 0)
+	for _, entry := range s.tradingBot.GetAuditLog() {
+		if (user != "" && entry.User != user) || (action != "" && entry.Action != action) || !query.inRange(entry.Time) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	page, info := paginate(entries, query, auditSortKeys)
+	setPageHeaders(c, info)
+	c.JSON(http.StatusOK, AuditResponse{PageInfo: info, Entries: page})
+}
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"trading-bot/pkg/bot"
+)
+
+func TestAuditLog(t *testing.T) {
+	t.Log("📒 Testing the persisted audit log of config and trading changes")
+
+	dir := t.TempDir()
+	config := bot.DefaultConfig()
+	config.DataProvider = "sample"
+	config.AuditFile = filepath.Join(dir, "audit.jsonl")
+	config.Admin.Token = "root-token"
+	config.Admin.Users = []bot.APIUser{{Name: "tom", Token: "trader-token", Role: bot.RoleTrader}}
+	filename := filepath.Join(dir, "config.json")
+	if err := bot.SaveConfig(config, filename); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	configManager := bot.NewConfigManager(filename)
+	if err := configManager.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+	server.SetConfigManager(configManager)
+
+	send := func(method, path, token, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+	send("POST", "/api/v1/trading/disable", "trader-token", "")
+	send("PUT", "/api/v1/config", "root-token", `{"min_confidence": 0.7}`)
+
+	// Entries carry the caller and the value they replaced, newest first
+	if recorder := send("GET", "/api/v1/audit", "trader-token", ""); recorder.Code != http.StatusForbidden {
+		t.Errorf("Expected the audit log to need the admin role, got %d", recorder.Code)
+	}
+	recorder := send("GET", "/api/v1/audit", "root-token", "")
+	var response AuditResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || recorder.Code != http.StatusOK {
+		t.Fatalf("Audit request failed with %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if response.Total != 2 || response.Entries[0].Action != bot.AuditConfigChange || response.Entries[1].Action != bot.AuditTradingDisable {
+		t.Fatalf("Unexpected audit entries: %+v", response.Entries)
+	}
+	change, disable := response.Entries[0], response.Entries[1]
+	if change.User != "admin" || change.Target != "min_confidence" || string(change.Previous) != "0.6" || string(change.Value) != "0.7" {
+		t.Errorf("Unexpected config entry: %+v", change)
+	}
+	if disable.User != "tom" || disable.Role != bot.RoleTrader || string(disable.Previous) != "true" || string(disable.Value) != "false" {
+		t.Errorf("Unexpected trading entry: %+v", disable)
+	}
+
+	// Filters narrow the page
+	recorder = send("GET", "/api/v1/audit?user=tom", "root-token", "")
+	json.Unmarshal(recorder.Body.Bytes(), &response)
+	if response.Total != 1 || response.Entries[0].Action != bot.AuditTradingDisable {
+		t.Errorf("Expected only tom's entry, got %+v", response.Entries)
+	}
+
+	// The file survives a restart
+	restored, err := bot.NewAuditLog(config.AuditFile)
+	if err != nil || len(restored.Entries()) != 2 || restored.Entries()[1].Target != "min_confidence" {
+		t.Errorf("Expected both entries restored from disk, got %+v (err %v)", restored.Entries(), err)
+	}
+}
@@ -0,0 +1,211 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxConfigBodyBytes caps submitted config documents
+const maxConfigBodyBytes = 1 << 20
+
+// ConfigValidationResponse lists the problems found in a submitted config
+type ConfigValidationResponse struct {
+	Valid    bool                `json:"valid" example:"false"` // False when any problem has severity "error"
+	Errors   int                 `json:"errors" example:"1"`
+	Warnings int                 `json:"warnings" example:"2"`
+	Problems []bot.ConfigProblem `json:"problems"`
+}
+
+// validateConfig checks a submitted config without applying it
+// @Summary Validate a config
+// @Description Run ValidateConfig plus cross-field checks (timeframe coverage, weight sums, risk sanity) on a submitted config.json document without applying it. Missing fields use defaults and older schema versions are migrated first.
+// @Tags config
+// @Accept json
+// @Produce json
+// @Param config body bot.Config true "Config document"
+// @Success 200 {object} ConfigValidationResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /config/validate [post]
+func (s *APIServer) validateConfig(c *gin.Context) {
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxConfigBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "failed to read request body: " + err.Error()})
+		return
+	}
+	data, _, err = bot.MigrateConfigData(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	config := bot.DefaultConfig()
+	if err := json.Unmarshal(data, &config); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid config JSON: " + err.Error()})
+		return
+	}
+
+	// Unknown keys are ignored on load; report them so typos don't go unnoticed
+	problems := append(make([]bot.ConfigProblem, 0), bot.CheckConfig(config)...)
+	unknown, _ := bot.UnknownConfigFields(data)
+	for _, field := range unknown {
+		problems = append(problems, bot.ConfigProblem{Field: field, Message: fmt.Sprintf("unknown field %q is ignored", field), Severity: bot.ProblemWarning})
+	}
+
+	response := ConfigValidationResponse{Problems: problems}
+	for _, problem := range problems {
+		if problem.Severity == bot.ProblemError {
+			response.Errors++
+		} else {
+			response.Warnings++
+		}
+	}
+	response.Valid = response.Errors == 0
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ConfigUpdateResponse reports which changed settings took effect
+type ConfigUpdateResponse struct {
+	Status          string   `json:"status" example:"success"`
+	Message         string   `json:"message" example:"Config applied and saved"`
+	Reloaded        []string `json:"reloaded"`         // Changed settings now in effect, by JSON key
+	RestartRequired []string `json:"restart_required"` // Changed settings saved but applied on the next start
+}
+
+// SetConfigManager lets the config endpoints read and persist config.json
+func (s *APIServer) SetConfigManager(configManager *bot.ConfigManager) {
+	s.configManager = configManager
+}
+
+// getConfig returns the current config with credentials redacted
+// @Summary Get the current config
+// @Description Get the config as saved to config.json, with API keys and tokens redacted
+// @Tags config
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} bot.Config
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /config [get]
+func (s *APIServer) getConfig(c *gin.Context) {
+	if s.configManager == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "config is not managed by this server"})
+		return
+	}
+	redacted, _, err := bot.RedactConfig(s.configManager.GetConfig())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, redacted)
+}
+
+// updateConfig applies and saves a config document
+// @Summary Update the config
+// @Description Merge a config.json document (or part of one) over the current config, put the signal settings (indicators, min_confidence, strategy, ...) into effect without a restart and save it to config.json. Credentials and the admin token are kept as they are.
+// @Tags config
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param config body bot.Config true "Config document"
+// @Success 200 {object} ConfigUpdateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /config [put]
+func (s *APIServer) updateConfig(c *gin.Context) {
+	if s.configManager == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "config is not managed by this server"})
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxConfigBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "failed to read request body: " + err.Error()})
+		return
+	}
+	data, _, err = bot.MigrateConfigData(data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	current := s.configManager.GetConfig()
+	config := current
+	if err := json.Unmarshal(data, &config); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid config JSON: " + err.Error()})
+		return
+	}
+	bot.KeepCredentials(&config, current)
+	s.applyConfig(c, config, "Config applied and saved")
+}
+
+// setConfigIndicator enables or disables one indicator in the saved config
+// @Summary Enable or disable an indicator
+// @Description Turn a built-in or plugin indicator on or off, rebuild the signal aggregator and save config.json
+// @Tags config
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Indicator name, e.g. rsi"
+// @Success 200 {object} ConfigUpdateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /config/indicators/{name}/enable [post]
+// @Router /config/indicators/{name}/disable [post]
+func (s *APIServer) setConfigIndicator(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.configManager == nil {
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "config is not managed by this server"})
+			return
+		}
+		info, ok := bot.LookupIndicator(c.Param("name"))
+		if !ok {
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: fmt.Sprintf("unknown indicator %s", c.Param("name"))})
+			return
+		}
+		config := s.configManager.GetConfig()
+		info.SetEnabled(&config, enabled)
+		s.applyConfig(c, config, "Config applied and saved")
+	}
+}
+
+// applyConfig puts config into effect, then records and saves it, reporting
+// whether it succeeded
+func (s *APIServer) applyConfig(c *gin.Context, config bot.Config, message string) bool {
+	previous := s.configManager.GetConfig()
+	reload, err := s.tradingBot.ApplyConfig(config)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return false
+	}
+	if err := s.configManager.UpdateConfig(config); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return false
+	}
+	s.tradingBot.AuditConfig(s.apiUser(c), previous, config)
+	s.tradingBot.RecordConfigVersion(config, s.apiUser(c))
+	if err := s.configManager.Save(); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "config applied but not saved: " + err.Error()})
+		return false
+	}
+
+	c.JSON(http.StatusOK, ConfigUpdateResponse{
+		Status:          "success",
+		Message:         message,
+		Reloaded:        reload.Reloaded,
+		RestartRequired: reload.RestartRequired,
+	})
+	return true
+}
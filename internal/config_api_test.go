@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"trading-bot/pkg/bot"
+)
+
+func TestConfigValidateEndpoint(t *testing.T) {
+	t.Log("🩺 Testing dry config validation endpoint")
+
+	config := bot.DefaultConfig()
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+	validate := func(body string) (int, ConfigValidationResponse) {
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, httptest.NewRequest("POST", "/api/v1/config/validate", strings.NewReader(body)))
+		var response ConfigValidationResponse
+		json.Unmarshal(recorder.Body.Bytes(), &response)
+		return recorder.Code, response
+	}
+
+	// Partial documents are checked on top of the defaults
+	if code, response := validate(`{"symbol": "ETHUSDT"}`); code != http.StatusOK || !response.Valid || len(response.Problems) != 0 {
+		t.Errorf("Expected clean partial config, got %d %+v", code, response)
+	}
+
+	code, response := validate(`{"atr": {"enabled": true, "period": 5, "multiplier": 0}, "min_confidence": 0.4, "rsi": {"perod": 14}}`)
+	if code != http.StatusOK || response.Valid || response.Errors != 1 || response.Warnings != 2 {
+		t.Fatalf("Expected 1 error and 2 warnings, got %d %+v", code, response)
+	}
+	if response.Problems[0].Field != "atr.multiplier" || !strings.Contains(response.Problems[2].Message, "perod") {
+		t.Errorf("Unexpected problems: %+v", response.Problems)
+	}
+
+	// The running config is untouched and malformed JSON is rejected
+	if server.config.ATR.Multiplier != config.ATR.Multiplier {
+		t.Errorf("Validation must not apply the submitted config")
+	}
+	if code, _ := validate(`{"rsi": `); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed JSON, got %d", code)
+	}
+}
+
+func TestConfigUpdateEndpoints(t *testing.T) {
+	t.Log("🔥 Testing runtime config update and indicator toggle endpoints")
+
+	config := bot.DefaultConfig()
+	config.DataProvider = "sample"
+	config.Admin.Token = "s3cret"
+	filename := filepath.Join(t.TempDir(), "config.json")
+	if err := bot.SaveConfig(config, filename); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	configManager := bot.NewConfigManager(filename)
+	if err := configManager.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+	send := func(method, path, body string) (int, ConfigUpdateResponse) {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("X-Admin-Token", "s3cret")
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		var response ConfigUpdateResponse
+		json.Unmarshal(recorder.Body.Bytes(), &response)
+		return recorder.Code, response
+	}
+
+	if code, _ := send("PUT", "/api/v1/config", `{"min_confidence": 0.7}`); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without a config manager, got %d", code)
+	}
+	server.SetConfigManager(configManager)
+
+	// Partial documents merge over the current config and are saved
+	code, response := send("PUT", "/api/v1/config", `{"min_confidence": 0.7, "streaming": {"stale_seconds": 60}}`)
+	if code != http.StatusOK || strings.Join(response.Reloaded, ",") != "min_confidence" || strings.Join(response.RestartRequired, ",") != "streaming" {
+		t.Fatalf("Unexpected update response %d: %+v", code, response)
+	}
+	if saved, err := bot.LoadConfig(filename); err != nil || saved.MinConfidence != 0.7 || saved.Streaming.StaleSeconds != 60 || saved.RSI.Enabled != config.RSI.Enabled {
+		t.Errorf("Expected the merged config on disk, got err %v", err)
+	}
+
+	code, response = send("POST", "/api/v1/config/indicators/rsi/disable", "")
+	if code != http.StatusOK || strings.Join(response.Reloaded, ",") != "indicators" || configManager.GetConfig().RSI.Enabled {
+		t.Errorf("Expected RSI to be disabled, got %d %+v", code, response)
+	}
+	if code, _ := send("POST", "/api/v1/config/indicators/nope/enable", ""); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown indicator, got %d", code)
+	}
+	if code, _ := send("PUT", "/api/v1/config", `{"min_confidence": 2}`); code != http.StatusBadRequest || configManager.GetConfig().MinConfidence != 0.7 {
+		t.Errorf("Expected an invalid config to be rejected, got %d", code)
+	}
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConfigHistoryResponse lists applied config versions
+type ConfigHistoryResponse struct {
+	Count    int                 `json:"count" example:"3"`
+	Versions []bot.ConfigVersion `json:"versions"` // Newest first
+}
+
+// getConfigHistory returns the applied configs with the performance under each
+// @Summary Get the config history
+// @Description List every applied config (API updates, hand edits and startups) newest first, with when it was active and the trades closed under it. Archived versions are left out unless include_archived is set.
+// @Tags config
+// @Produce json
+// @Security AdminToken
+// @Param include_archived query bool false "Include archived versions"
+// @Success 200 {object} ConfigHistoryResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /config/history [get]
+func (s *APIServer) getConfigHistory(c *gin.Context) {
+	includeArchived, _ := strconv.ParseBool(c.Query("include_archived"))
+	versions := s.tradingBot.GetConfigHistory(includeArchived)
+	c.JSON(http.StatusOK, ConfigHistoryResponse{Count: len(versions), Versions: versions})
+}
+
+// getConfigVersion returns one applied config
+// @Summary Get a config version
+// @Description Get one applied config, archived or not, with the trades closed under it
+// @Tags config
+// @Produce json
+// @Security AdminToken
+// @Param version path int true "Config version"
+// @Success 200 {object} bot.ConfigVersion
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /config/history/{version} [get]
+func (s *APIServer) getConfigVersion(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "version must be an integer"})
+		return
+	}
+	entry, ok := s.tradingBot.GetConfigVersion(version)
+	if !ok {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "config version not found"})
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// archiveConfigVersion soft-deletes a config version
+// @Summary Archive a config version
+// @Description Hide a config version from the history. It stays on disk and can still be fetched by version. The active version can't be archived.
+// @Tags config
+// @Produce json
+// @Security AdminToken
+// @Param version path int true "Config version"
+// @Success 200 {object} bot.ConfigVersion
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /config/history/{version} [delete]
+func (s *APIServer) archiveConfigVersion(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "version must be an integer"})
+		return
+	}
+	if _, ok := s.tradingBot.GetConfigVersion(version); !ok {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "config version not found"})
+		return
+	}
+	entry, err := s.tradingBot.ArchiveConfigVersion(version)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	s.tradingBot.Audit(s.apiUser(c), bot.AuditConfigArchive, strconv.Itoa(version), nil, entry.ArchivedAt)
+	c.JSON(http.StatusOK, entry)
+}
+
+// rollbackConfig re-applies a stored config version
+// @Summary Roll back to a config version
+// @Description Validate and re-apply a stored config version (archived ones included) like PUT /config, keeping the current credentials. The rollback is recorded in the audit log and as a new version.
+// @Tags config
+// @Produce json
+// @Security AdminToken
+// @Param version path int true "Config version"
+// @Success 200 {object} ConfigUpdateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /config/rollback/{version} [post]
+func (s *APIServer) rollbackConfig(c *gin.Context) {
+	if s.configManager == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "config is not managed by this server"})
+		return
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "version must be an integer"})
+		return
+	}
+	entry, ok := s.tradingBot.GetConfigVersion(version)
+	if !ok {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: "config version not found"})
+		return
+	}
+
+	var from int
+	if versions := s.tradingBot.GetConfigHistory(true); len(versions) > 0 {
+		from = versions[0].Version
+	}
+	config := entry.Config
+	bot.KeepCredentials(&config, s.configManager.GetConfig())
+	if s.applyConfig(c, config, fmt.Sprintf("Rolled back to config version %d", version)) {
+		s.tradingBot.Audit(s.apiUser(c), bot.AuditConfigRollback, strconv.Itoa(version), from, version)
+	}
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"trading-bot/pkg/bot"
+)
+
+func TestConfigRollback(t *testing.T) {
+	t.Log("⏪ Testing config rollback to a stored version")
+
+	config := bot.DefaultConfig()
+	config.DataProvider = "sample"
+	config.Admin.Token = "s3cret"
+	filename := filepath.Join(t.TempDir(), "config.json")
+	if err := bot.SaveConfig(config, filename); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	configManager := bot.NewConfigManager(filename)
+	if err := configManager.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	tradingBot := bot.NewTradingBot(config)
+	tradingBot.RecordConfigVersion(config, bot.APIUser{Name: "startup"})
+	server := NewAPIServer(config, tradingBot, "0")
+	server.SetConfigManager(configManager)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("X-Admin-Token", "s3cret")
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+	if recorder := send("PUT", "/api/v1/config", `{"min_confidence": 0.7}`); recorder.Code != http.StatusOK {
+		t.Fatalf("Update failed with %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	// Rolling back re-applies and saves version 1, recorded as version 3
+	recorder := send("POST", "/api/v1/config/rollback/1", "")
+	var response ConfigUpdateResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil || recorder.Code != http.StatusOK {
+		t.Fatalf("Rollback failed with %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if response.Message != "Rolled back to config version 1" || strings.Join(response.Reloaded, ",") != "min_confidence" {
+		t.Errorf("Unexpected rollback response: %+v", response)
+	}
+	if saved, err := bot.LoadConfig(filename); err != nil || saved.MinConfidence != config.MinConfidence || saved.Admin.Token != "s3cret" {
+		t.Errorf("Expected the saved config back at %.2f with the admin token kept, got %.2f (err %v)", config.MinConfidence, saved.MinConfidence, err)
+	}
+	if versions := tradingBot.GetConfigHistory(false); len(versions) != 3 || versions[0].Config.MinConfidence != config.MinConfidence {
+		t.Errorf("Expected the rollback recorded as version 3, got %d versions", len(versions))
+	}
+
+	// The audit log names the versions rolled from and to
+	var rollback bot.AuditEntry
+	for _, entry := range tradingBot.GetAuditLog() {
+		if entry.Action == bot.AuditConfigRollback {
+			rollback = entry
+		}
+	}
+	if rollback.User != "admin" || string(rollback.Previous) != "2" || string(rollback.Value) != "1" {
+		t.Errorf("Unexpected rollback audit entry: %+v", rollback)
+	}
+
+	// Unknown versions and snapshots failing validation are rejected
+	if recorder := send("POST", "/api/v1/config/rollback/9", ""); recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown version, got %d", recorder.Code)
+	}
+	invalid := config
+	invalid.MinConfidence = 2
+	tradingBot.RecordConfigVersion(invalid, bot.APIUser{Name: "config-file"})
+	if recorder := send("POST", "/api/v1/config/rollback/4", ""); recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid snapshot, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestIDHeader carries the caller's request ID, or the generated one back
+const requestIDHeader = "X-Request-ID"
+
+// apiLog logs API work outside a request
+var apiLog = bot.Logger("api")
+
+// loggerKey is the gin context key holding the request's *slog.Logger
+const loggerKey = "logger"
+
+// logRequests tags each request with an ID, gives handlers a logger carrying
+// it and logs the outcome with the caller's name once the request is done
+func logRequests(c *gin.Context) {
+	start := time.Now()
+	id := c.GetHeader(requestIDHeader)
+	if id == "" {
+		id = newRequestID()
+	}
+	c.Header(requestIDHeader, id)
+	logger := apiLog.With("request_id", id)
+	c.Set(loggerKey, logger)
+
+	c.Next()
+
+	status := c.Writer.Status()
+	level := slog.LevelInfo
+	switch {
+	case status >= 500:
+		level = slog.LevelError
+	case status >= 400:
+		level = slog.LevelWarn
+	}
+	attrs := []any{
+		"method", c.Request.Method,
+		"path", c.Request.URL.Path,
+		"status", status,
+		"latency_ms", time.Since(start).Milliseconds(),
+		"bytes", c.Writer.Size(),
+		"client_ip", c.ClientIP(),
+	}
+	if user, ok := c.Get(apiUserKey); ok {
+		attrs = append(attrs, "user", user.(bot.APIUser).Name)
+	}
+	logger.Log(c.Request.Context(), level, "request", attrs...)
+}
+
+// requestLogger returns the logger logRequests set up for the request
+func requestLogger(c *gin.Context) *slog.Logger {
+	if logger, ok := c.Get(loggerKey); ok {
+		return logger.(*slog.Logger)
+	}
+	return apiLog
+}
+
+// newRequestID returns a random 16-character hex ID
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,420 @@
+package internal
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiParam documents a path or query parameter
+type apiParam struct {
+	Name        string
+	In          string // "query" or "path"
+	Type        string // OpenAPI primitive type
+	Description string
+}
+
+// apiRoute documents an endpoint; Response is a zero value of its 200 JSON body
+type apiRoute struct {
+	Method      string
+	Path        string // Gin-style path, e.g. /api/v1/backtest/:id
+	Tag         string
+	Summary     string
+	Params      []apiParam
+	Request     interface{} // Zero value of the JSON request body, if any
+	Response    interface{}
+	Status      int         // Success status when not 200, e.g. 201 for creation
+	ContentType string      // Non-JSON 200 content type, e.g. text/html
+	Errors      []int       // Status codes returning ErrorResponse
+	ErrorBody   interface{} // Zero value of the error body when it isn't ErrorResponse
+}
+
+// apiRoutes lists every documented endpoint with its real response type
+func apiRoutes() []apiRoute {
+	limit := func(def string) apiParam {
+		return apiParam{Name: "limit", In: "query", Type: "integer", Description: "Maximum items to return (default: " + def + ")"}
+	}
+	page := func(limitHelp, sortHelp string, filters ...apiParam) []apiParam {
+		return append([]apiParam{
+			{Name: "limit", In: "query", Type: "integer", Description: limitHelp},
+			{Name: "offset", In: "query", Type: "integer", Description: "Items to skip (default: 0)"},
+			{Name: "sort", In: "query", Type: "string", Description: sortHelp + "; prefix - for descending"},
+			{Name: "from", In: "query", Type: "string", Description: "Only items at or after this RFC3339 time"},
+			{Name: "to", In: "query", Type: "string", Description: "Only items before this RFC3339 time"},
+		}, filters...)
+	}
+	minConfidence := apiParam{Name: "min_confidence", In: "query", Type: "number", Description: "Minimum confidence (0-1)"}
+	configVersion := apiParam{Name: "version", In: "path", Type: "integer", Description: "Config version"}
+	id := func(what string) apiParam {
+		return apiParam{Name: "id", In: "path", Type: "string", Description: what + " ID"}
+	}
+
+	return []apiRoute{
+		{Method: "GET", Path: "/", Tag: "info", Summary: "Get API information", Response: APIInfo{}},
+		{Method: "GET", Path: "/api/v1/openapi.json", Tag: "info", Summary: "Get the OpenAPI document", Response: map[string]interface{}{}},
+		{Method: "GET", Path: "/api/v1/predict", Tag: "prediction", Summary: "Predict price direction + trading status",
+			Params: []apiParam{
+				{Name: "seconds", In: "query", Type: "integer", Description: "Prediction timeframe in seconds (default: 330, min: 60, max: 1800)"},
+				{Name: "symbol", In: "query", Type: "string", Description: "One of the configured symbols (default: the traded symbol)"},
+			},
+			Response: PredictionResponse{}, Errors: []int{400, 500, 503}},
+		{Method: "GET", Path: "/api/v1/status", Tag: "status", Summary: "Get bot status", Response: bot.SignalEngineStatus{}},
+		{Method: "GET", Path: "/api/v1/signals", Tag: "signals", Summary: "Get latest signal", Response: bot.TradingSignal{}, Errors: []int{404}},
+		{Method: "GET", Path: "/api/v1/signals/history", Tag: "signals", Summary: "Get signal history",
+			Params: page("Signals per page (default: 50, max: 500)", "timestamp or confidence (default: -timestamp)",
+				apiParam{Name: "signal", In: "query", Type: "string", Description: "BUY, SELL or HOLD"}, minConfidence),
+			Response: SignalHistoryResponse{}, Errors: []int{400}},
+		{Method: "GET", Path: "/api/v1/predictions", Tag: "prediction", Summary: "Get prediction history",
+			Params: page("Predictions per page (default: 50, max: 500)", "timestamp or confidence (default: -timestamp)",
+				apiParam{Name: "prediction", In: "query", Type: "string", Description: "HIGHER, LOWER or NEUTRAL"}, minConfidence),
+			Response: PredictionHistoryResponse{}, Errors: []int{400}},
+		{Method: "GET", Path: "/api/v1/predictions/accuracy", Tag: "prediction", Summary: "Get prediction accuracy",
+			Params: []apiParam{limit("20")}, Response: bot.AccuracySummary{}},
+		{Method: "GET", Path: "/api/v1/predictions/accuracy/breakdown", Tag: "prediction", Summary: "Get prediction accuracy breakdown",
+			Response: bot.AccuracyBreakdown{}},
+		{Method: "GET", Path: "/api/v1/predictions/subscriptions", Tag: "prediction", Summary: "List prediction subscriptions", Response: PredictionSubscriptionListResponse{}},
+		{Method: "POST", Path: "/api/v1/predictions/subscriptions", Tag: "prediction", Summary: "Create a prediction subscription",
+			Request: PredictionSubscriptionSpec{}, Response: PredictionSubscription{}, Status: http.StatusCreated, Errors: []int{400}},
+		{Method: "DELETE", Path: "/api/v1/predictions/subscriptions/:id", Tag: "prediction", Summary: "Delete a prediction subscription",
+			Params: []apiParam{id("Subscription")}, Response: PredictionSubscriptionDeleteResponse{}, Errors: []int{404}},
+		{Method: "GET", Path: "/api/v1/alerts", Tag: "alerts", Summary: "List alerts", Response: AlertListResponse{}},
+		{Method: "POST", Path: "/api/v1/alerts", Tag: "alerts", Summary: "Create an alert", Request: bot.AlertSpec{}, Response: bot.Alert{}, Status: http.StatusCreated, Errors: []int{400}},
+		{Method: "GET", Path: "/api/v1/alerts/:id", Tag: "alerts", Summary: "Get an alert", Params: []apiParam{id("Alert")}, Response: bot.Alert{}, Errors: []int{404}},
+		{Method: "PUT", Path: "/api/v1/alerts/:id", Tag: "alerts", Summary: "Update an alert", Params: []apiParam{id("Alert")}, Request: bot.AlertSpec{}, Response: bot.Alert{}, Errors: []int{400, 404}},
+		{Method: "DELETE", Path: "/api/v1/alerts/:id", Tag: "alerts", Summary: "Delete an alert", Params: []apiParam{id("Alert")}, Response: AlertDeleteResponse{}, Errors: []int{404}},
+		{Method: "GET", Path: "/api/v1/health", Tag: "health", Summary: "Health check", Response: HealthResponse{}},
+		{Method: "GET", Path: "/api/v1/maintenance", Tag: "status", Summary: "Get exchange maintenance status", Response: bot.MaintenanceStatus{}},
+		{Method: "GET", Path: "/api/v1/errors", Tag: "status", Summary: "Get recent engine errors",
+			Params: []apiParam{
+				limit("50"),
+				{Name: "kind", In: "query", Type: "string", Description: "Filter by kind"},
+				{Name: "severity", In: "query", Type: "string", Description: "Filter by severity (INFO, WARNING, CRITICAL)"},
+			},
+			Response: ErrorsResponse{}},
+		{Method: "GET", Path: "/api/v1/price/index", Tag: "prediction", Summary: "Get index price",
+			Params:   []apiParam{{Name: "symbol", In: "query", Type: "string", Description: "Symbol (default: configured symbol)"}},
+			Response: bot.IndexPrice{}, Errors: []int{503}},
+		{Method: "GET", Path: "/api/v1/analytics/seasonality", Tag: "analytics", Summary: "Get seasonality statistics",
+			Params:   []apiParam{{Name: "refresh", In: "query", Type: "boolean", Description: "Recompute instead of using cached statistics"}},
+			Response: bot.SeasonalityStats{}, Errors: []int{503}},
+		{Method: "GET", Path: "/api/v1/candles", Tag: "signals", Summary: "Get candle history",
+			Params: []apiParam{
+				{Name: "timeframe", In: "query", Type: "string", Description: "Timeframe: 5m, 15m, 45m, 8h or 1d (default: 5m)"},
+				{Name: "limit", In: "query", Type: "integer", Description: "Most recent candles to return (default: 500, 0 = all)"},
+			},
+			Response: CandleHistoryResponse{}, Errors: []int{400, 404}},
+		{Method: "GET", Path: "/api/v1/context", Tag: "signals", Summary: "Get the multi-timeframe context behind the latest signal",
+			Params:   []apiParam{{Name: "symbol", In: "query", Type: "string", Description: "Symbol (default: configured symbol)"}},
+			Response: bot.ContextSnapshot{}, Errors: []int{400, 404}},
+		{Method: "GET", Path: "/api/v1/stream", Tag: "signals", Summary: "Stream events as server-sent events",
+			Params:      []apiParam{{Name: "types", In: "query", Type: "string", Description: "Comma-separated event types to include (default: all)"}},
+			ContentType: "text/event-stream"},
+		{Method: "GET", Path: "/api/v1/ws", Tag: "signals", Summary: "Stream events over a WebSocket",
+			Params:   []apiParam{{Name: "topics", In: "query", Type: "string", Description: "Comma-separated topics to start with: signal, trade, position, prediction or error (default: none)"}},
+			Response: bot.Event{}, Status: http.StatusSwitchingProtocols, Errors: []int{400}},
+		{Method: "POST", Path: "/api/v1/config/validate", Tag: "config", Summary: "Validate a config without applying it",
+			Request: bot.Config{}, Response: ConfigValidationResponse{}, Errors: []int{400}},
+		{Method: "GET", Path: "/api/v1/config", Tag: "config", Summary: "Get the current config with credentials redacted",
+			Response: bot.Config{}, Errors: []int{401, 403, 503}},
+		{Method: "PUT", Path: "/api/v1/config", Tag: "config", Summary: "Apply and save a config, hot-reloading signal settings",
+			Request: bot.Config{}, Response: ConfigUpdateResponse{}, Errors: []int{400, 401, 403, 500, 503}},
+		{Method: "POST", Path: "/api/v1/config/indicators/:name/enable", Tag: "config", Summary: "Enable an indicator and save the config",
+			Params:   []apiParam{{Name: "name", In: "path", Type: "string", Description: "Indicator name, e.g. rsi"}},
+			Response: ConfigUpdateResponse{}, Errors: []int{400, 401, 403, 404, 500, 503}},
+		{Method: "POST", Path: "/api/v1/config/indicators/:name/disable", Tag: "config", Summary: "Disable an indicator and save the config",
+			Params:   []apiParam{{Name: "name", In: "path", Type: "string", Description: "Indicator name, e.g. rsi"}},
+			Response: ConfigUpdateResponse{}, Errors: []int{400, 401, 403, 404, 500, 503}},
+		{Method: "GET", Path: "/api/v1/config/history", Tag: "config", Summary: "Get the config history",
+			Params:   []apiParam{{Name: "include_archived", In: "query", Type: "boolean", Description: "Include archived versions"}},
+			Response: ConfigHistoryResponse{}, Errors: []int{401, 403}},
+		{Method: "GET", Path: "/api/v1/config/history/:version", Tag: "config", Summary: "Get a config version",
+			Params: []apiParam{configVersion}, Response: bot.ConfigVersion{}, Errors: []int{400, 401, 403, 404}},
+		{Method: "DELETE", Path: "/api/v1/config/history/:version", Tag: "config", Summary: "Archive a config version",
+			Params: []apiParam{configVersion}, Response: bot.ConfigVersion{}, Errors: []int{400, 401, 403, 404}},
+		{Method: "POST", Path: "/api/v1/config/rollback/:version", Tag: "config", Summary: "Roll back to a config version",
+			Params: []apiParam{configVersion}, Response: ConfigUpdateResponse{}, Errors: []int{400, 401, 403, 404, 500, 503}},
+		{Method: "POST", Path: "/api/v1/backtest", Tag: "backtest", Summary: "Run a backtest",
+			Params: []apiParam{
+				{Name: "days", In: "query", Type: "integer", Description: "Days of history to simulate (default: 3, max: 30)"},
+				{Name: "fee_percent", In: "query", Type: "number", Description: "Fee per fill, % of notional (default: backtest.fee_percent)"},
+				{Name: "slippage_bps", In: "query", Type: "number", Description: "Slippage per fill in basis points (default: backtest.slippage_bps)"},
+			},
+			Response: bot.BacktestResult{}, Errors: []int{400, 500}},
+		{Method: "GET", Path: "/api/v1/backtest/:id", Tag: "backtest", Summary: "Get a backtest result",
+			Params: []apiParam{id("Backtest")}, Response: bot.BacktestResult{}, Errors: []int{404}},
+		{Method: "GET", Path: "/api/v1/backtest/:id/report", Tag: "backtest", Summary: "Download backtest report",
+			Params: []apiParam{id("Backtest")}, ContentType: "text/html", Errors: []int{404}},
+		{Method: "GET", Path: "/api/v1/trading/status", Tag: "trading", Summary: "Get trading status", Response: bot.TradingStatus{}},
+		{Method: "GET", Path: "/api/v1/trading/position", Tag: "trading", Summary: "Get current position", Response: PositionResponse{}},
+		{Method: "GET", Path: "/api/v1/trading/history", Tag: "trading", Summary: "Get trade history",
+			Params: page("Trades per page (default: 10, 0 = all)", "exit_time, entry_time, pnl or pnl_percent (default: -exit_time)",
+				apiParam{Name: "side", In: "query", Type: "string", Description: "LONG or SHORT"},
+				apiParam{Name: "strategy", In: "query", Type: "string", Description: "Strategy name"},
+				apiParam{Name: "exit_reason", In: "query", Type: "string", Description: "e.g. ATR_STOP, TAKE_PROFIT, MANUAL"}),
+			Response: TradeHistoryResponse{}, Errors: []int{400}},
+		{Method: "GET", Path: "/api/v1/trading/history/:id/replay", Tag: "trading", Summary: "Get trade replay",
+			Params: []apiParam{id("Trade")}, Response: bot.TradeReplay{}, Errors: []int{404}},
+		{Method: "GET", Path: "/api/v1/trading/tax-report", Tag: "trading", Summary: "Get tax lot report (JSON with format=json, CSV otherwise)",
+			Params: []apiParam{
+				{Name: "method", In: "query", Type: "string", Description: "Lot selection method: FIFO or LIFO (default: FIFO)"},
+				{Name: "year", In: "query", Type: "integer", Description: "Only include lots closed in this calendar year (UTC)"},
+				{Name: "format", In: "query", Type: "string", Description: "Output format: csv or json (default: csv)"},
+			},
+			Response: TaxReportResponse{}, Errors: []int{400}},
+		{Method: "GET", Path: "/api/v1/trading/hedges", Tag: "trading", Summary: "Get hedges",
+			Params: []apiParam{limit("50")}, Response: bot.HedgeStatus{}},
+		{Method: "GET", Path: "/api/v1/activity", Tag: "trading", Summary: "Get the activity timeline of a trading day",
+			Params:   []apiParam{{Name: "date", In: "query", Type: "string", Description: "Trading day as YYYY-MM-DD (default: today)"}},
+			Response: ActivityResponse{}, Errors: []int{400}},
+		{Method: "GET", Path: "/api/v1/audit", Tag: "admin", Summary: "Get the audit log",
+			Params: page("Entries per page (default: 50, max: 500)", "time (default: -time)",
+				apiParam{Name: "user", In: "query", Type: "string", Description: "Filter by user name"},
+				apiParam{Name: "action", In: "query", Type: "string", Description: "Filter by action, e.g. config_change"}),
+			Response: AuditResponse{}, Errors: []int{400, 401, 403}},
+		{Method: "GET", Path: "/api/v1/risk", Tag: "trading", Summary: "Get raw and beta-adjusted exposure", Response: bot.RiskReport{}},
+		{Method: "POST", Path: "/api/v1/risk/scenario", Tag: "trading", Summary: "Run a price shock scenario on the open book", Request: ScenarioRequest{}, Response: bot.ScenarioReport{}, Errors: []int{400}},
+		{Method: "GET", Path: "/api/v1/trading/execution-quality", Tag: "trading", Summary: "Get execution quality",
+			Params: []apiParam{limit("20")}, Response: bot.ExecutionQuality{}},
+		{Method: "POST", Path: "/api/v1/trading/safe-mode/exit", Tag: "trading", Summary: "Exit safe mode", Response: SafeModeResponse{}, Errors: []int{400}},
+		{Method: "POST", Path: "/api/v1/trading/enable", Tag: "trading", Summary: "Enable trading", Response: TradingControlResponse{}},
+		{Method: "POST", Path: "/api/v1/trading/disable", Tag: "trading", Summary: "Disable trading", Response: TradingControlResponse{}},
+		{Method: "POST", Path: "/api/v1/trading/close", Tag: "trading", Summary: "Force close position", Response: TradingControlResponse{}, Errors: []int{400}, ErrorBody: TradingControlError{}},
+		{Method: "POST", Path: "/api/v1/admin/refresh-data", Tag: "admin", Summary: "Re-fetch all timeframes", Response: AdminRefreshResponse{}, Errors: []int{401, 403, 502}},
+		{Method: "POST", Path: "/api/v1/admin/reset-stats", Tag: "admin", Summary: "Reset performance stats and daily loss counters", Response: AdminResetResponse{}, Errors: []int{401, 403, 503}},
+		{Method: "POST", Path: "/api/v1/admin/reload-credentials", Tag: "admin", Summary: "Rotate Binance API keys without a restart", Request: ReloadCredentialsRequest{}, Response: AdminCredentialsResponse{}, Errors: []int{400, 401, 403}},
+	}
+}
+
+// BuildOpenAPISpec generates the OpenAPI 3 document from the route table and response types
+func BuildOpenAPISpec() map[string]interface{} {
+	schemas := make(map[string]interface{})
+	paths := make(map[string]interface{})
+
+	errorSchema := schemaFor(reflect.TypeOf(ErrorResponse{}), schemas)
+	for _, route := range apiRoutes() {
+		ok := map[string]interface{}{"description": "OK"}
+		if route.ContentType != "" {
+			ok["content"] = map[string]interface{}{route.ContentType: map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}}
+		} else {
+			ok["content"] = map[string]interface{}{"application/json": map[string]interface{}{"schema": schemaFor(reflect.TypeOf(route.Response), schemas)}}
+		}
+		success := "200"
+		if route.Status != 0 {
+			ok["description"] = http.StatusText(route.Status)
+			success = strconv.Itoa(route.Status)
+		}
+		responses := map[string]interface{}{success: ok}
+		routeErrorSchema := errorSchema
+		if route.ErrorBody != nil {
+			routeErrorSchema = schemaFor(reflect.TypeOf(route.ErrorBody), schemas)
+		}
+		for _, code := range route.Errors {
+			responses[strconv.Itoa(code)] = map[string]interface{}{
+				"description": http.StatusText(code),
+				"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": routeErrorSchema}},
+			}
+		}
+
+		params := make([]interface{}, 0, len(route.Params))
+		for _, param := range route.Params {
+			params = append(params, map[string]interface{}{
+				"name":        param.Name,
+				"in":          param.In,
+				"required":    param.In == "path",
+				"description": param.Description,
+				"schema":      map[string]interface{}{"type": param.Type},
+			})
+		}
+
+		operation := map[string]interface{}{
+			"summary":   route.Summary,
+			"tags":      []string{route.Tag},
+			"responses": responses,
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+		if route.Request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  map[string]interface{}{"application/json": map[string]interface{}{"schema": schemaFor(reflect.TypeOf(route.Request), schemas)}},
+			}
+		}
+		// Admin routes always need a token; the rest only once admin.users is set
+		if role := routeRole(route.Method, route.Path); role != "" {
+			security := []interface{}{map[string]interface{}{"bearerAuth": []string{}}, map[string]interface{}{"adminToken": []string{}}}
+			if role != bot.RoleAdmin {
+				security = append(security, map[string]interface{}{})
+			}
+			operation["security"] = security
+			operation["x-required-role"] = role
+		}
+
+		path := openAPIPath(route.Path)
+		item, _ := paths[path].(map[string]interface{})
+		if item == nil {
+			item = make(map[string]interface{})
+			paths[path] = item
+		}
+		item[strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Trading Bot API",
+			"version":     "1.0.0",
+			"description": "Generated from the API's Go response types",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{"type": "http", "scheme": "bearer"},
+				"adminToken": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-Admin-Token"},
+			},
+		},
+	}
+}
+
+// openAPIPath converts a Gin path (/backtest/:id) to OpenAPI form (/backtest/{id})
+func openAPIPath(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") {
+			parts[i] = "{" + part[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// schemaFor returns the schema for t, registering named structs under components/schemas
+func schemaFor(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := schemaFor(t.Elem(), schemas)
+		return nullable(schema)
+	case reflect.Interface:
+		return map[string]interface{}{} // Any value
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		schema := map[string]interface{}{"type": "array", "items": schemaFor(t.Elem(), schemas)}
+		if t.Kind() == reflect.Slice {
+			schema["nullable"] = true // nil slices encode as null
+		}
+		return schema
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"nullable":             true,
+			"additionalProperties": schemaFor(t.Elem(), schemas),
+		}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			return map[string]interface{}{"type": "string", "format": "date-time"}
+		}
+		if t.Name() == "" {
+			return structSchema(t, schemas)
+		}
+		name := schemaName(t)
+		ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+		if _, exists := schemas[name]; !exists {
+			schemas[name] = map[string]interface{}{} // Placeholder breaks recursive types
+			schemas[name] = structSchema(t, schemas)
+		}
+		return ref
+	}
+	return map[string]interface{}{}
+}
+
+// structSchema describes a struct's JSON fields; fields without omitempty are required
+func structSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, options, _ := strings.Cut(tag, ",")
+
+			// Untagged embedded structs are flattened like encoding/json does
+			if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+				collect(field.Type)
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+
+			properties[name] = schemaFor(field.Type, schemas)
+			if !strings.Contains(options, "omitempty") {
+				required = append(required, name)
+			}
+		}
+	}
+	collect(t)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// schemaName names a component after its package and type, e.g. bot.Trade
+func schemaName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if slash := strings.LastIndex(pkg, "/"); slash >= 0 {
+		pkg = pkg[slash+1:]
+	}
+	return pkg + "." + t.Name()
+}
+
+// nullable marks a schema as accepting null; $ref schemas are wrapped since siblings of $ref are ignored
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if _, isRef := schema["$ref"]; isRef {
+		return map[string]interface{}{"allOf": []interface{}{schema}, "nullable": true}
+	}
+	schema["nullable"] = true
+	return schema
+}
+
+// getOpenAPISpec serves the generated OpenAPI document
+// @Summary Get OpenAPI document
+// @Description Get the OpenAPI 3 document generated from the API's Go response types
+// @Tags info
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /openapi.json [get]
+func (s *APIServer) getOpenAPISpec(c *gin.Context) {
+	c.JSON(http.StatusOK, s.openAPISpec)
+}
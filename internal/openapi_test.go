@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/bot"
+)
+
+// validateSchema checks a decoded JSON value against an OpenAPI schema, resolving $refs in spec
+func validateSchema(spec, schema map[string]interface{}, value interface{}, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		resolved, ok := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})[name].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: unresolved $ref %s", path, ref)
+		}
+		return validateSchema(spec, resolved, value, path)
+	}
+	if value == nil {
+		if schema["nullable"] == true {
+			return nil
+		}
+		return fmt.Errorf("%s: null is not allowed", path)
+	}
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			if err := validateSchema(spec, sub.(map[string]interface{}), value, path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, value)
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %s", path, name)
+			}
+		}
+		for name, field := range object {
+			if property, ok := properties[name]; ok {
+				if err := validateSchema(spec, property.(map[string]interface{}), field, path+"."+name); err != nil {
+					return err
+				}
+			} else if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+				if err := validateSchema(spec, additional, field, path+"."+name); err != nil {
+					return err
+				}
+			} else {
+				return fmt.Errorf("%s: undocumented property %s", path, name)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, value)
+		}
+		for i, item := range items {
+			if err := validateSchema(spec, schema["items"].(map[string]interface{}), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected number, got %T", path, value)
+		}
+	case "integer":
+		if n, ok := value.(float64); !ok || n != math.Trunc(n) {
+			return fmt.Errorf("%s: expected integer, got %v", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, value)
+		}
+	}
+	return nil
+}
+
+// responseSchema returns the JSON schema documented for a status code
+func responseSchema(t *testing.T, spec map[string]interface{}, method, path string, status int) map[string]interface{} {
+	operation, ok := spec["paths"].(map[string]interface{})[path].(map[string]interface{})[strings.ToLower(method)].(map[string]interface{})
+	if !ok {
+		t.Fatalf("%s %s is not documented", method, path)
+	}
+	response, ok := operation["responses"].(map[string]interface{})[fmt.Sprint(status)].(map[string]interface{})
+	if !ok {
+		t.Fatalf("%s %s does not document status %d", method, path, status)
+	}
+	return response["content"].(map[string]interface{})["application/json"].(map[string]interface{})["schema"].(map[string]interface{})
+}
+
+func TestOpenAPISpecMatchesResponses(t *testing.T) {
+	t.Log("📘 Testing generated OpenAPI document against live responses")
+
+	config := bot.DefaultConfig()
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+
+	request := func(method, target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+		return recorder
+	}
+
+	served := request("GET", "/api/v1/openapi.json")
+	if served.Code != http.StatusOK {
+		t.Fatalf("Expected openapi.json to be served, got %d", served.Code)
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(served.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("Spec is not valid JSON: %v", err)
+	}
+
+	// Every API route is documented
+	paths := spec["paths"].(map[string]interface{})
+	for _, route := range server.router.Routes() {
+		if route.Path != "/" && !strings.HasPrefix(route.Path, "/api/") {
+			continue
+		}
+		item, ok := paths[openAPIPath(route.Path)].(map[string]interface{})
+		if !ok || item[strings.ToLower(route.Method)] == nil {
+			t.Errorf("Route %s %s missing from OpenAPI document", route.Method, route.Path)
+		}
+	}
+
+	// Live responses conform to their documented schemas
+	checks := []struct {
+		method, target, path string
+		status               int
+	}{
+		{"GET", "/", "/", 200},
+		{"GET", "/api/v1/status", "/api/v1/status", 200},
+		{"GET", "/api/v1/signals", "/api/v1/signals", 404},
+		{"GET", "/api/v1/signals/history?limit=5", "/api/v1/signals/history", 200},
+		{"GET", "/api/v1/predictions?sort=-confidence", "/api/v1/predictions", 200},
+		{"GET", "/api/v1/predictions?sort=price", "/api/v1/predictions", 400},
+		{"GET", "/api/v1/predictions/accuracy?limit=5", "/api/v1/predictions/accuracy", 200},
+		{"GET", "/api/v1/predictions/accuracy/breakdown", "/api/v1/predictions/accuracy/breakdown", 200},
+		{"GET", "/api/v1/predictions/subscriptions", "/api/v1/predictions/subscriptions", 200},
+		{"POST", "/api/v1/predictions/subscriptions", "/api/v1/predictions/subscriptions", 400},
+		{"DELETE", "/api/v1/predictions/subscriptions/missing", "/api/v1/predictions/subscriptions/{id}", 404},
+		{"GET", "/api/v1/alerts", "/api/v1/alerts", 200},
+		{"POST", "/api/v1/alerts", "/api/v1/alerts", 400},
+		{"GET", "/api/v1/alerts/missing", "/api/v1/alerts/{id}", 404},
+		{"DELETE", "/api/v1/alerts/missing", "/api/v1/alerts/{id}", 404},
+		{"GET", "/api/v1/ws?topics=signal,prices", "/api/v1/ws", 400},
+		{"GET", "/api/v1/health", "/api/v1/health", 200},
+		{"GET", "/api/v1/maintenance", "/api/v1/maintenance", 200},
+		{"GET", "/api/v1/errors?limit=5", "/api/v1/errors", 200},
+		{"GET", "/api/v1/context", "/api/v1/context", 404},
+		{"GET", "/api/v1/context?symbol=DOGEUSDT", "/api/v1/context", 400},
+		{"GET", "/api/v1/backtest/missing", "/api/v1/backtest/{id}", 404},
+		{"GET", "/api/v1/trading/status", "/api/v1/trading/status", 200},
+		{"GET", "/api/v1/trading/position", "/api/v1/trading/position", 200},
+		{"GET", "/api/v1/trading/history", "/api/v1/trading/history", 200},
+		{"GET", "/api/v1/trading/tax-report?format=json", "/api/v1/trading/tax-report", 200},
+		{"GET", "/api/v1/trading/hedges", "/api/v1/trading/hedges", 200},
+		{"GET", "/api/v1/risk", "/api/v1/risk", 200},
+		{"POST", "/api/v1/risk/scenario", "/api/v1/risk/scenario", 200},
+		{"GET", "/api/v1/trading/execution-quality?limit=5", "/api/v1/trading/execution-quality", 200},
+		{"POST", "/api/v1/trading/disable", "/api/v1/trading/disable", 200},
+		{"POST", "/api/v1/trading/enable", "/api/v1/trading/enable", 200},
+		{"POST", "/api/v1/trading/close", "/api/v1/trading/close", 400},
+	}
+	for _, check := range checks {
+		recorder := request(check.method, check.target)
+		if recorder.Code != check.status {
+			t.Errorf("%s %s: expected %d, got %d (%s)", check.method, check.target, check.status, recorder.Code, recorder.Body.String())
+			continue
+		}
+		var body interface{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+			t.Errorf("%s %s: invalid JSON: %v", check.method, check.target, err)
+			continue
+		}
+		if err := validateSchema(spec, responseSchema(t, spec, check.method, check.path, check.status), body, "$"); err != nil {
+			t.Errorf("%s %s does not match schema: %v", check.method, check.target, err)
+		}
+	}
+
+	// A fully populated prediction exercises the nested trading types
+	executor := bot.NewTradeExecutor(config, 10000.0)
+	signal := &bot.TradingSignal{Symbol: config.Symbol, Signal: bot.Buy, Confidence: 0.9, Timestamp: time.Now()}
+	if err := executor.ExecuteSignal(signal, 100.0, 99.0); err != nil {
+		t.Fatalf("Entry failed: %v", err)
+	}
+	status := executor.GetStatus()
+	if err := executor.ForceClosePosition(101.0); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	prediction := PredictionResponse{
+		Symbol:          config.Symbol,
+		Prediction:      "HIGHER",
+		Indicators:      []IndicatorPrediction{{Name: "RSI_5m", Signal: "BUY", Strength: 0.8, Timeframe: "5m"}},
+		Maintenance:     &bot.MaintenanceStatus{},
+		TradingStatus:   &status,
+		CurrentPosition: bot.NewPositionView(status.CurrentPosition),
+		RecentTrades:    bot.NewTradeViews(executor.GetTradeHistory(5)),
+	}
+	encoded, _ := json.Marshal(prediction)
+	var decoded interface{}
+	json.Unmarshal(encoded, &decoded)
+	if err := validateSchema(spec, responseSchema(t, spec, "GET", "/api/v1/predict", 200), decoded, "$"); err != nil {
+		t.Errorf("Populated prediction does not match schema: %v", err)
+	}
+}
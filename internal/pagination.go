@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PageInfo describes the slice of a list returned by a paginated endpoint
+type PageInfo struct {
+	Count  int `json:"count" example:"10"`  // Items in this page
+	Total  int `json:"total" example:"125"` // Items matching the filters
+	Limit  int `json:"limit" example:"10"`  // 0 = no limit
+	Offset int `json:"offset" example:"0"`
+}
+
+// listQuery holds the standard list parameters: limit, offset, sort and a from/to time range
+type listQuery struct {
+	Limit  int
+	Offset int
+	Sort   string // Field name
+	Desc   bool   // Requested as sort=-field
+	From   time.Time
+	To     time.Time
+}
+
+// sortKeys maps sort field names to "less" functions
+type sortKeys[T any] map[string]func(a, b T) bool
+
+// fields returns the sortable field names
+func (k sortKeys[T]) fields() []string {
+	names := make([]string, 0, len(k))
+	for name := range k {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseListQuery reads limit, offset, sort (field or -field) and from/to (RFC3339).
+// limit=0 returns everything and is only accepted when maxLimit is 0.
+func parseListQuery(c *gin.Context, defaultLimit, maxLimit int, defaultSort string, fields []string) (listQuery, error) {
+	query := listQuery{Limit: defaultLimit}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 || (maxLimit > 0 && (limit == 0 || limit > maxLimit)) {
+			if maxLimit > 0 {
+				return query, fmt.Errorf("limit must be between 1 and %d", maxLimit)
+			}
+			return query, fmt.Errorf("limit must be a non-negative integer")
+		}
+		query.Limit = limit
+	}
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return query, fmt.Errorf("offset must be a non-negative integer")
+		}
+		query.Offset = offset
+	}
+
+	sortParam := c.DefaultQuery("sort", defaultSort)
+	query.Desc = strings.HasPrefix(sortParam, "-")
+	query.Sort = strings.TrimPrefix(sortParam, "-")
+	valid := false
+	for _, field := range fields {
+		if field == query.Sort {
+			valid = true
+		}
+	}
+	if !valid {
+		return query, fmt.Errorf("sort must be one of %s (prefix with - for descending)", strings.Join(fields, ", "))
+	}
+
+	for name, target := range map[string]*time.Time{"from": &query.From, "to": &query.To} {
+		if value := c.Query(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return query, fmt.Errorf("%s must be an RFC3339 time", name)
+			}
+			*target = parsed
+		}
+	}
+	return query, nil
+}
+
+// inRange reports whether t falls within [From, To)
+func (q listQuery) inRange(t time.Time) bool {
+	return (q.From.IsZero() || !t.Before(q.From)) && (q.To.IsZero() || t.Before(q.To))
+}
+
+// paginate sorts the filtered items and returns the requested page plus its PageInfo
+func paginate[T any](items []T, query listQuery, keys sortKeys[T]) ([]T, PageInfo) {
+	sorted := append([]T(nil), items...)
+	if less, ok := keys[query.Sort]; ok {
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if query.Desc {
+				return less(sorted[j], sorted[i])
+			}
+			return less(sorted[i], sorted[j])
+		})
+	}
+
+	info := PageInfo{Total: len(sorted), Limit: query.Limit, Offset: query.Offset}
+	start := query.Offset
+	if start > len(sorted) {
+		start = len(sorted)
+	}
+	end := len(sorted)
+	if query.Limit > 0 && start+query.Limit < end {
+		end = start + query.Limit
+	}
+
+	page := sorted[start:end]
+	info.Count = len(page)
+	return page, info
+}
+
+// setPageHeaders sets X-Total-Count and RFC 8288 Link headers for first/prev/next/last pages
+func setPageHeaders(c *gin.Context, info PageInfo) {
+	c.Header("X-Total-Count", strconv.Itoa(info.Total))
+	if info.Limit == 0 {
+		return
+	}
+
+	link := func(offset int, rel string) string {
+		values := url.Values{}
+		for key, value := range c.Request.URL.Query() {
+			values[key] = value
+		}
+		values.Set("limit", strconv.Itoa(info.Limit))
+		values.Set("offset", strconv.Itoa(offset))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, c.Request.URL.Path, values.Encode(), rel)
+	}
+
+	lastOffset := 0
+	if info.Total > 0 {
+		lastOffset = (info.Total - 1) / info.Limit * info.Limit
+	}
+	links := []string{link(0, "first")}
+	if info.Offset > 0 {
+		prev := info.Offset - info.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, link(prev, "prev"))
+	}
+	if info.Offset+info.Limit < info.Total {
+		links = append(links, link(info.Offset+info.Limit, "next"))
+	}
+	links = append(links, link(lastOffset, "last"))
+	c.Header("Link", strings.Join(links, ", "))
+}
+
+// Sortable fields of each list endpoint
+var (
+	tradeSortKeys = sortKeys[*bot.Trade]{
+		"exit_time":   func(a, b *bot.Trade) bool { return a.ExitTime.Before(b.ExitTime) },
+		"entry_time":  func(a, b *bot.Trade) bool { return a.EntryTime.Before(b.EntryTime) },
+		"pnl":         func(a, b *bot.Trade) bool { return a.PnL < b.PnL },
+		"pnl_percent": func(a, b *bot.Trade) bool { return a.PnLPercent < b.PnLPercent },
+	}
+	signalSortKeys = sortKeys[*bot.TradingSignal]{
+		"timestamp":  func(a, b *bot.TradingSignal) bool { return a.Timestamp.Before(b.Timestamp) },
+		"confidence": func(a, b *bot.TradingSignal) bool { return a.Confidence < b.Confidence },
+	}
+	predictionSortKeys = sortKeys[PredictionResponse]{
+		"timestamp":  func(a, b PredictionResponse) bool { return predictionTime(a).Before(predictionTime(b)) },
+		"confidence": func(a, b PredictionResponse) bool { return a.Confidence < b.Confidence },
+	}
+)
+
+// predictionTime parses the time a prediction was served
+func predictionTime(prediction PredictionResponse) time.Time {
+	t, _ := time.Parse(time.RFC3339, prediction.Timestamp)
+	return t
+}
+
+// parseMinConfidence reads the optional min_confidence filter
+func parseMinConfidence(c *gin.Context) (float64, error) {
+	value := c.Query("min_confidence")
+	if value == "" {
+		return 0, nil
+	}
+	confidence, err := strconv.ParseFloat(value, 64)
+	if err != nil || confidence < 0 || confidence > 1 {
+		return 0, fmt.Errorf("min_confidence must be between 0 and 1")
+	}
+	return confidence, nil
+}
+
+// pageFields returns the PageInfo fields written alongside a streamed list
+func pageFields(info PageInfo) map[string]interface{} {
+	return map[string]interface{}{"total": info.Total, "limit": info.Limit, "offset": info.Offset}
+}
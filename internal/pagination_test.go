@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/bot"
+)
+
+func TestListPagination(t *testing.T) {
+	t.Log("📄 Testing pagination, sorting, filters and Link headers")
+
+	config := bot.DefaultConfig()
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, direction := range []string{"HIGHER", "LOWER", "HIGHER", "HIGHER", "NEUTRAL", "HIGHER", "HIGHER"} {
+		server.predictions.Add(PredictionResponse{
+			Prediction: direction,
+			Confidence: 0.5 + float64(i)*0.05,
+			Timestamp:  start.Add(time.Duration(i) * time.Minute).Format(time.RFC3339),
+		})
+	}
+
+	get := func(target string) (*httptest.ResponseRecorder, PredictionHistoryResponse) {
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, httptest.NewRequest("GET", target, nil))
+		var page PredictionHistoryResponse
+		json.Unmarshal(recorder.Body.Bytes(), &page)
+		return recorder, page
+	}
+
+	// 5 HIGHER predictions, highest confidence first, second page of 2
+	recorder, page := get("/api/v1/predictions?prediction=higher&sort=-confidence&limit=2&offset=2")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if page.Total != 5 || page.Count != 2 || page.Offset != 2 || page.Limit != 2 {
+		t.Errorf("Unexpected page info: %+v", page.PageInfo)
+	}
+	if len(page.Predictions) != 2 || page.Predictions[0].Confidence != 0.65 || page.Predictions[1].Confidence != 0.6 {
+		t.Errorf("Expected confidences 0.65, 0.60 on page 2, got %+v", page.Predictions)
+	}
+	if recorder.Header().Get("X-Total-Count") != "5" {
+		t.Errorf("Expected X-Total-Count 5, got %q", recorder.Header().Get("X-Total-Count"))
+	}
+	links := make(map[string]string)
+	for _, part := range strings.Split(recorder.Header().Get("Link"), ", ") {
+		target, rel, _ := strings.Cut(part, "; rel=")
+		links[strings.Trim(rel, `"`)] = strings.Trim(target, "<>")
+	}
+	for rel, offset := range map[string]string{"first": "offset=0", "prev": "offset=0", "next": "offset=4", "last": "offset=4"} {
+		if !strings.Contains(links[rel], offset) || !strings.Contains(links[rel], "prediction=higher") {
+			t.Errorf("Expected %s link with %s and the original filters, got %q", rel, offset, links[rel])
+		}
+	}
+
+	// Time range filter is [from, to)
+	_, page = get("/api/v1/predictions?from=2024-01-01T00:02:00Z&to=2024-01-01T00:05:00Z&sort=timestamp")
+	if page.Total != 3 || page.Predictions[0].Timestamp != "2024-01-01T00:02:00Z" {
+		t.Errorf("Expected 3 predictions from 00:02 in ascending order, got %+v", page.Predictions)
+	}
+
+	// Invalid parameters are rejected rather than silently ignored
+	for _, target := range []string{
+		"/api/v1/predictions?limit=501",
+		"/api/v1/predictions?offset=-1",
+		"/api/v1/predictions?from=yesterday",
+		"/api/v1/predictions?min_confidence=2",
+		"/api/v1/trading/history?sort=-size",
+	} {
+		if recorder, _ := get(target); recorder.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, recorder.Code)
+		}
+	}
+}
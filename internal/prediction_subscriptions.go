@@ -0,0 +1,275 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errSubscriptionNotFound is returned for an unknown subscription ID
+var errSubscriptionNotFound = errors.New("subscription not found")
+
+// PredictionFilter passes only actionable predictions. With both filters set, a
+// prediction must meet the threshold and flip direction.
+type PredictionFilter struct {
+	Symbol        string  `json:"symbol,omitempty"`         // Default: every configured symbol
+	MinConfidence float64 `json:"min_confidence,omitempty"` // 0-1; predictions below it are ignored, flips included
+	OnFlip        bool    `json:"on_flip,omitempty"`        // Only when the direction differs from the last prediction that met the threshold
+}
+
+// validate checks the filter against the configured symbols
+func (f *PredictionFilter) validate(symbols []string) error {
+	f.Symbol = strings.ToUpper(f.Symbol)
+	if f.Symbol != "" && !slices.Contains(symbols, f.Symbol) {
+		return fmt.Errorf("symbol %s is not configured (available: %s)", f.Symbol, strings.Join(symbols, ", "))
+	}
+	if f.MinConfidence < 0 || f.MinConfidence > 1 {
+		return fmt.Errorf("min_confidence must be between 0 and 1, got %v", f.MinConfidence)
+	}
+	return nil
+}
+
+// predictionGate applies a filter, remembering each symbol's last direction that met the threshold
+type predictionGate struct {
+	filter PredictionFilter
+	last   map[string]string
+}
+
+// newPredictionGate creates a gate for a validated filter
+func newPredictionGate(filter PredictionFilter) *predictionGate {
+	return &predictionGate{filter: filter, last: make(map[string]string)}
+}
+
+// pass reports whether the prediction gets through, and the symbol's previous direction
+func (g *predictionGate) pass(prediction PredictionResponse) (bool, string) {
+	if g.filter.Symbol != "" && prediction.Symbol != g.filter.Symbol {
+		return false, ""
+	}
+	if prediction.Confidence < g.filter.MinConfidence {
+		return false, ""
+	}
+	previous := g.last[prediction.Symbol]
+	g.last[prediction.Symbol] = prediction.Prediction
+	if g.filter.OnFlip && previous == prediction.Prediction {
+		return false, previous
+	}
+	return true, previous
+}
+
+// PredictionSubscriptionSpec is a webhook receiving the predictions that pass its filter
+type PredictionSubscriptionSpec struct {
+	PredictionFilter
+	WebhookURL string `json:"webhook_url" example:"https://example.com/hooks/predictions"`
+}
+
+// PredictionSubscription is a registered webhook with its delivery record
+type PredictionSubscription struct {
+	ID string `json:"id" example:"sub_1"`
+	PredictionSubscriptionSpec
+	CreatedAt     time.Time `json:"created_at"`
+	Deliveries    int       `json:"deliveries"`
+	Failures      int       `json:"failures"`
+	LastDelivered time.Time `json:"last_delivered,omitempty"`
+	LastError     string    `json:"last_error,omitempty"`
+
+	gate *predictionGate
+}
+
+// PredictionWebhook is the JSON body POSTed to a subscription's webhook
+type PredictionWebhook struct {
+	SubscriptionID    string             `json:"subscription_id" example:"sub_1"`
+	PreviousDirection string             `json:"previous_direction,omitempty" example:"LOWER"` // Set when the direction flipped
+	Prediction        PredictionResponse `json:"prediction"`
+}
+
+// PredictionSubscriptionListResponse lists the prediction subscriptions
+type PredictionSubscriptionListResponse struct {
+	Subscriptions []PredictionSubscription `json:"subscriptions"`
+	Count         int                      `json:"count" example:"1"`
+}
+
+// PredictionSubscriptionDeleteResponse confirms a subscription was removed
+type PredictionSubscriptionDeleteResponse struct {
+	Status string `json:"status" example:"success"`
+	ID     string `json:"id" example:"sub_1"`
+}
+
+// predictionSubscriptions delivers served predictions to the webhooks whose filters they pass
+type predictionSubscriptions struct {
+	mutex         sync.Mutex
+	subscriptions []*PredictionSubscription // Creation order
+	nextID        int
+	httpClient    *http.Client
+}
+
+// newPredictionSubscriptions creates an empty subscription registry
+func newPredictionSubscriptions() *predictionSubscriptions {
+	return &predictionSubscriptions{
+		subscriptions: make([]*PredictionSubscription, 0),
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// create registers a webhook subscription
+func (ps *predictionSubscriptions) create(spec PredictionSubscriptionSpec, symbols []string) (PredictionSubscription, error) {
+	if err := spec.validate(symbols); err != nil {
+		return PredictionSubscription{}, err
+	}
+	target, err := url.Parse(spec.WebhookURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return PredictionSubscription{}, fmt.Errorf("webhook_url must be an http(s) URL, got %q", spec.WebhookURL)
+	}
+
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	ps.nextID++
+	subscription := &PredictionSubscription{
+		ID:                         fmt.Sprintf("sub_%d", ps.nextID),
+		PredictionSubscriptionSpec: spec,
+		CreatedAt:                  time.Now(),
+		gate:                       newPredictionGate(spec.PredictionFilter),
+	}
+	ps.subscriptions = append(ps.subscriptions, subscription)
+	return *subscription, nil
+}
+
+// delete removes a subscription
+func (ps *predictionSubscriptions) delete(id string) error {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	for i, subscription := range ps.subscriptions {
+		if subscription.ID == id {
+			ps.subscriptions = append(ps.subscriptions[:i], ps.subscriptions[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", errSubscriptionNotFound, id)
+}
+
+// list returns every subscription in creation order
+func (ps *predictionSubscriptions) list() []PredictionSubscription {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	subscriptions := make([]PredictionSubscription, len(ps.subscriptions))
+	for i, subscription := range ps.subscriptions {
+		subscriptions[i] = *subscription
+	}
+	return subscriptions
+}
+
+// dispatch posts the prediction to every subscription it passes, without waiting for the webhooks
+func (ps *predictionSubscriptions) dispatch(prediction PredictionResponse) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	for _, subscription := range ps.subscriptions {
+		ok, previous := subscription.gate.pass(prediction)
+		if !ok {
+			continue
+		}
+		payload := PredictionWebhook{SubscriptionID: subscription.ID, Prediction: prediction}
+		if previous != "" && previous != prediction.Prediction {
+			payload.PreviousDirection = previous
+		}
+		go ps.deliver(subscription.ID, subscription.WebhookURL, payload)
+	}
+}
+
+// deliver POSTs one webhook and records the outcome on its subscription
+func (ps *predictionSubscriptions) deliver(id, target string, payload PredictionWebhook) {
+	err := func() error {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal prediction: %w", err)
+		}
+		response, err := ps.httpClient.Post(target, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to send prediction: %w", err)
+		}
+		defer response.Body.Close()
+		if response.StatusCode >= 300 {
+			return fmt.Errorf("webhook returned status %d", response.StatusCode)
+		}
+		return nil
+	}()
+	if err != nil {
+		apiLog.Warn("Prediction subscription delivery failed", "subscription_id", id, "error", err)
+	}
+
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	for _, subscription := range ps.subscriptions {
+		if subscription.ID != id {
+			continue
+		}
+		if err != nil {
+			subscription.Failures++
+			subscription.LastError = err.Error()
+		} else {
+			subscription.Deliveries++
+			subscription.LastDelivered = time.Now()
+		}
+	}
+}
+
+// listPredictionSubscriptions returns the registered prediction webhooks
+// @Summary List prediction subscriptions
+// @Description List the webhooks receiving filtered predictions, with their delivery counts
+// @Tags prediction
+// @Produce json
+// @Success 200 {object} PredictionSubscriptionListResponse
+// @Router /predictions/subscriptions [get]
+func (s *APIServer) listPredictionSubscriptions(c *gin.Context) {
+	subscriptions := s.subscriptions.list()
+	c.JSON(http.StatusOK, PredictionSubscriptionListResponse{Subscriptions: subscriptions, Count: len(subscriptions)})
+}
+
+// createPredictionSubscription registers a prediction webhook
+// @Summary Create a prediction subscription
+// @Description POST served predictions to a webhook only when they meet min_confidence and/or flip direction. WebSocket clients set the same filter on their connection instead.
+// @Tags prediction
+// @Accept json
+// @Produce json
+// @Param subscription body PredictionSubscriptionSpec true "Subscription"
+// @Success 201 {object} PredictionSubscription
+// @Failure 400 {object} ErrorResponse
+// @Router /predictions/subscriptions [post]
+func (s *APIServer) createPredictionSubscription(c *gin.Context) {
+	var spec PredictionSubscriptionSpec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+
+	subscription, err := s.subscriptions.create(spec, s.tradingBot.Symbols())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// deletePredictionSubscription removes a prediction webhook
+// @Summary Delete a prediction subscription
+// @Tags prediction
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} PredictionSubscriptionDeleteResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /predictions/subscriptions/{id} [delete]
+func (s *APIServer) deletePredictionSubscription(c *gin.Context) {
+	id := c.Param("id")
+	if err := s.subscriptions.delete(id); err != nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, PredictionSubscriptionDeleteResponse{Status: "success", ID: id})
+}
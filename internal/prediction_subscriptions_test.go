@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestPredictionSubscriptions(t *testing.T) {
+	t.Log("🎯 Testing prediction webhooks and WebSocket filters on confidence and direction flips")
+
+	config := bot.DefaultConfig()
+	tradingBot := bot.NewTradingBot(config)
+	server := NewAPIServer(config, tradingBot, "0")
+	prediction := func(direction string, confidence float64) PredictionResponse {
+		return PredictionResponse{Symbol: config.Symbol, Prediction: direction, Confidence: confidence}
+	}
+
+	// The threshold applies first; flips are judged against the last prediction that met it
+	gate := newPredictionGate(PredictionFilter{MinConfidence: 0.7, OnFlip: true})
+	var passed []string
+	for _, p := range []PredictionResponse{
+		prediction("HIGHER", 0.8), prediction("HIGHER", 0.9), prediction("LOWER", 0.6),
+		prediction("LOWER", 0.75), {Symbol: "ETHUSDT", Prediction: "LOWER", Confidence: 0.9}, prediction("LOWER", 0.95),
+	} {
+		if ok, previous := gate.pass(p); ok {
+			passed = append(passed, p.Symbol+":"+previous+">"+p.Prediction)
+		}
+	}
+	if strings.Join(passed, ",") != config.Symbol+":>HIGHER,"+config.Symbol+":HIGHER>LOWER,ETHUSDT:>LOWER" {
+		t.Errorf("Unexpected predictions passed: %v", passed)
+	}
+
+	webhooks := make(chan PredictionWebhook, 10)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload PredictionWebhook
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Invalid webhook body: %v", err)
+		}
+		webhooks <- payload
+	}))
+	defer hook.Close()
+
+	request := func(method, target string, body interface{}) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		recorder := httptest.NewRecorder()
+		server.router.ServeHTTP(recorder, httptest.NewRequest(method, target, bytes.NewReader(data)))
+		return recorder
+	}
+	for _, invalid := range []PredictionSubscriptionSpec{
+		{WebhookURL: "ftp://example.com"},
+		{WebhookURL: hook.URL, PredictionFilter: PredictionFilter{MinConfidence: 1.5}},
+		{WebhookURL: hook.URL, PredictionFilter: PredictionFilter{Symbol: "DOGEUSDT"}},
+	} {
+		if recorder := request("POST", "/api/v1/predictions/subscriptions", invalid); recorder.Code != http.StatusBadRequest {
+			t.Errorf("Expected %+v to be rejected, got %d", invalid, recorder.Code)
+		}
+	}
+	recorder := request("POST", "/api/v1/predictions/subscriptions", PredictionSubscriptionSpec{
+		WebhookURL: hook.URL, PredictionFilter: PredictionFilter{Symbol: strings.ToLower(config.Symbol), MinConfidence: 0.7, OnFlip: true},
+	})
+	var subscription PredictionSubscription
+	if err := json.Unmarshal(recorder.Body.Bytes(), &subscription); recorder.Code != http.StatusCreated || err != nil || subscription.Symbol != config.Symbol {
+		t.Fatalf("Expected the subscription to be created, got %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	// Only actionable predictions reach the webhook
+	for _, p := range []PredictionResponse{prediction("HIGHER", 0.8), prediction("HIGHER", 0.9), prediction("LOWER", 0.5), prediction("LOWER", 0.85)} {
+		server.subscriptions.dispatch(p)
+	}
+	received := make([]PredictionWebhook, 0, 2)
+	for len(received) < 2 {
+		select {
+		case payload := <-webhooks:
+			received = append(received, payload)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected 2 webhooks, got %+v", received)
+		}
+	}
+	directions := map[string]string{}
+	for _, payload := range received {
+		directions[payload.Prediction.Prediction] = payload.PreviousDirection
+	}
+	if previous, ok := directions["LOWER"]; !ok || previous != "HIGHER" || directions["HIGHER"] != "" || received[0].SubscriptionID != subscription.ID {
+		t.Errorf("Expected the first HIGHER then the flip to LOWER, got %+v", received)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for server.subscriptions.list()[0].Deliveries < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if listed := server.subscriptions.list(); listed[0].Deliveries != 2 || listed[0].Failures != 0 {
+		t.Errorf("Expected 2 recorded deliveries, got %+v", listed[0])
+	}
+
+	if recorder := request("DELETE", "/api/v1/predictions/subscriptions/"+subscription.ID, nil); recorder.Code != http.StatusOK {
+		t.Errorf("Expected the subscription to be deleted, got %d", recorder.Code)
+	}
+	if recorder := request("DELETE", "/api/v1/predictions/subscriptions/"+subscription.ID, nil); recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected a second delete to be 404, got %d", recorder.Code)
+	}
+
+	// WebSocket clients filter predictions on their own connection
+	httpServer := httptest.NewServer(server.router)
+	defer httpServer.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http")+"/api/v1/ws", nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var reply WebSocketReply
+	conn.ReadJSON(&reply)
+
+	conn.WriteJSON(WebSocketRequest{Action: "subscribe", Topics: []string{"prediction"}, Filter: &PredictionFilter{MinConfidence: 2}})
+	if conn.ReadJSON(&reply); !strings.Contains(reply.Error, "min_confidence") || len(reply.Topics) != 0 {
+		t.Errorf("Expected an invalid filter to be rejected, got %+v", reply)
+	}
+	conn.WriteJSON(WebSocketRequest{Action: "subscribe", Topics: []string{"prediction"}, Filter: &PredictionFilter{MinConfidence: 0.7, OnFlip: true}})
+	reply = WebSocketReply{}
+	if conn.ReadJSON(&reply); reply.Error != "" || reply.Filter == nil || !reply.Filter.OnFlip {
+		t.Fatalf("Expected the filter to be acknowledged, got %+v", reply)
+	}
+	for _, p := range []PredictionResponse{prediction("HIGHER", 0.6), prediction("LOWER", 0.8), prediction("LOWER", 0.9), prediction("HIGHER", 0.7)} {
+		tradingBot.PublishEvent(bot.EventPrediction, p)
+	}
+	for _, want := range []string{"LOWER", "HIGHER"} {
+		var event struct {
+			Data PredictionResponse `json:"data"`
+		}
+		if err := conn.ReadJSON(&event); err != nil || event.Data.Prediction != want {
+			t.Fatalf("Expected a %s prediction, got %+v (err %v)", want, event.Data, err)
+		}
+	}
+}
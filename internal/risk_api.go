@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScenarioRequest lists the price shocks to evaluate
+type ScenarioRequest struct {
+	Shocks     []float64 `json:"shocks" example:"-0.05,-0.1"` // Fractional moves (default: -5%, -10%, -20%, +5%, +10%)
+	BetaScaled bool      `json:"beta_scaled"`                 // Move each symbol by the shock times its beta to the benchmark
+}
+
+// getRisk returns raw and beta-adjusted exposure
+// @Summary Get risk exposure
+// @Description Get long, short and net exposure per symbol and in total, with each symbol scaled by its rolling beta to the benchmark (BTCUSDT by default) to show the directional crypto exposure
+// @Tags trading
+// @Produce json
+// @Success 200 {object} bot.RiskReport
+// @Router /risk [get]
+func (s *APIServer) getRisk(c *gin.Context) {
+	c.JSON(http.StatusOK, s.tradingBot.GetRiskReport())
+}
+
+// runRiskScenario reports what instantaneous price shocks would do to the open book
+// @Summary Run a price shock scenario
+// @Description Apply hypothetical instantaneous price moves to the signal position, spot holdings and hedges, and report the PnL, the stops the move gaps through and the position's margin ratio after each one. An empty body evaluates the default shocks.
+// @Tags trading
+// @Accept json
+// @Produce json
+// @Param scenario body ScenarioRequest false "Shocks"
+// @Success 200 {object} bot.ScenarioReport
+// @Failure 400 {object} ErrorResponse
+// @Router /risk/scenario [post]
+func (s *APIServer) runRiskScenario(c *gin.Context) {
+	var request ScenarioRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request body: " + err.Error()})
+			return
+		}
+	}
+
+	report, err := s.tradingBot.RunScenarios(request.Shocks, request.BetaScaled)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamFlushEvery is how many list items are encoded between flushes
+const streamFlushEvery = 500
+
+// streamJSONList writes {<fields...>, "count": n, "<listField>": [...]} encoding one
+// item at a time, so large lists never sit in memory as a single marshaled buffer
+func streamJSONList[T any](c *gin.Context, fields map[string]interface{}, listField string, items []T) {
+	c.Header("Content-Type", "application/json; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	writer := bufio.NewWriterSize(c.Writer, 32*1024)
+	encoder := json.NewEncoder(writer)
+	err := func() error {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		writer.WriteString("{")
+		for _, name := range names {
+			if err := writeJSONKey(writer, name); err != nil {
+				return err
+			}
+			if err := encoder.Encode(fields[name]); err != nil {
+				return err
+			}
+			writer.WriteString(",")
+		}
+		if err := writeJSONKey(writer, "count"); err != nil {
+			return err
+		}
+		fmt.Fprintf(writer, "%d,", len(items))
+		if err := writeJSONKey(writer, listField); err != nil {
+			return err
+		}
+
+		writer.WriteString("[")
+		for i, item := range items {
+			if i > 0 {
+				writer.WriteString(",")
+			}
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+			if i%streamFlushEvery == streamFlushEvery-1 {
+				if err := writer.Flush(); err != nil {
+					return err
+				}
+				c.Writer.Flush()
+			}
+		}
+		writer.WriteString("]}")
+		return writer.Flush()
+	}()
+	if err != nil {
+		// Headers are already sent; the client sees a truncated body
+		requestLogger(c).Warn("Failed to stream list", "field", listField, "error", err)
+	}
+}
+
+// writeJSONKey writes a quoted object key followed by a colon
+func writeJSONKey(writer *bufio.Writer, name string) error {
+	key, err := json.Marshal(name)
+	if err != nil {
+		return err
+	}
+	writer.Write(key)
+	_, err = writer.WriteString(":")
+	return err
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStreamedJSONAndCompression(t *testing.T) {
+	t.Log("🗜️ Testing streamed list encoding and gzip responses")
+
+	// Streamed lists decode to the same shape as the typed response
+	candles := make([]bot.Candle, 1200) // Spans several flushes
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range candles {
+		candles[i] = bot.Candle{Timestamp: start.Add(time.Duration(i) * 5 * time.Minute), Close: float64(100 + i)}
+	}
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	streamJSONList(c, map[string]interface{}{"symbol": "BTCUSDT", "timeframe": "5m"}, "candles", candles)
+
+	var decoded CandleHistoryResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("Streamed body is not valid JSON: %v", err)
+	}
+	if decoded.Count != 1200 || len(decoded.Candles) != 1200 || decoded.Symbol != "BTCUSDT" || decoded.Candles[1199].Close != 1299 {
+		t.Errorf("Unexpected streamed response: count %d, %d candles, symbol %s", decoded.Count, len(decoded.Candles), decoded.Symbol)
+	}
+
+	// Clients that accept gzip get compressed responses
+	config := bot.DefaultConfig()
+	server := NewAPIServer(config, bot.NewTradingBot(config), "0")
+	request := httptest.NewRequest("GET", "/api/v1/trading/history?limit=0", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	recorder = httptest.NewRecorder()
+	server.router.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected gzip 200 response, got %d with encoding %q", recorder.Code, recorder.Header().Get("Content-Encoding"))
+	}
+	reader, err := gzip.NewReader(recorder.Body)
+	if err != nil {
+		t.Fatalf("Invalid gzip body: %v", err)
+	}
+	body, _ := io.ReadAll(reader)
+	var history TradeHistoryResponse
+	if err := json.Unmarshal(body, &history); err != nil || history.Count != 0 {
+		t.Errorf("Unexpected trade history %s (err %v)", body, err)
+	}
+}
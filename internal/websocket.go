@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// websocketPing is how often idle clients are pinged; a client that misses two pongs is dropped
+const websocketPing = 30 * time.Second
+
+var websocketUpgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 4096}
+
+// WebSocketRequest changes a connection's topics: {"action": "subscribe", "topics": ["signal", "position"]}
+type WebSocketRequest struct {
+	Action string            `json:"action"`           // subscribe or unsubscribe
+	Topics []string          `json:"topics"`           // Event types: signal, trade, position, prediction or error
+	Filter *PredictionFilter `json:"filter,omitempty"` // On subscribe: only push predictions passing it
+}
+
+// WebSocketReply acknowledges a request with the connection's topics, or reports why it failed
+type WebSocketReply struct {
+	Type   string            `json:"type"` // Always "subscription", to tell replies from events
+	Topics []string          `json:"topics"`
+	Filter *PredictionFilter `json:"filter,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// parseTopics validates event type names, ignoring blanks
+func parseTopics(names []string) ([]bot.EventType, error) {
+	topics := make([]bot.EventType, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		if !bot.ValidEventType(name) {
+			return nil, fmt.Errorf("unknown topic %s (use signal, trade, position, prediction or error)", name)
+		}
+		topics = append(topics, bot.EventType(name))
+	}
+	return topics, nil
+}
+
+// topicNames returns the subscribed topics in sorted order
+func topicNames(topics map[bot.EventType]bool) []string {
+	names := make([]string, 0, len(topics))
+	for topic := range topics {
+		names = append(names, string(topic))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// websocketEvents pushes bus events to the client over a WebSocket, filtered by the
+// topics the client subscribed to
+// @Summary Stream events over a WebSocket
+// @Description Upgrade to a WebSocket that pushes signals, position PnL updates, closed trades, predictions and errors as JSON events. Change topics by sending {"action": "subscribe"|"unsubscribe", "topics": [...]}; each request is acknowledged with the current topics. A subscribe request's "filter" ({"min_confidence": 0.7, "on_flip": true}) limits predictions to actionable ones.
+// @Tags signals
+// @Param topics query string false "Comma-separated topics to start with (default: none)"
+// @Success 101 {object} bot.Event "One event per message"
+// @Failure 400 {object} ErrorResponse
+// @Router /ws [get]
+func (s *APIServer) websocketEvents(c *gin.Context) {
+	initial, err := parseTopics(strings.Split(c.Query("topics"), ","))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	conn, err := websocketUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return // The upgrader has already replied
+	}
+	defer conn.Close()
+
+	topics := make(map[bot.EventType]bool)
+	for _, topic := range initial {
+		topics[topic] = true
+	}
+	var predictions *predictionGate // Nil pushes every prediction
+	events := s.tradingBot.SubscribeEvents("websocket", 100)
+	defer s.tradingBot.UnsubscribeEvents(events)
+
+	// Only this goroutine writes; the reader hands messages over until the client goes away
+	messages := make(chan []byte)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(messages)
+		conn.SetReadDeadline(time.Now().Add(2 * websocketPing))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * websocketPing))
+		})
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			select {
+			case messages <- message:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(websocketPing)
+	defer ping.Stop()
+	write := func(message interface{}) bool {
+		conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		return conn.WriteJSON(message) == nil
+	}
+	if !write(WebSocketReply{Type: "subscription", Topics: topicNames(topics)}) {
+		return
+	}
+
+	for {
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			var request WebSocketRequest
+			reply := WebSocketReply{Type: "subscription"}
+			err := json.Unmarshal(message, &request)
+			requested, topicErr := parseTopics(request.Topics)
+			switch {
+			case err != nil:
+				reply.Error = "invalid request: " + err.Error()
+			case topicErr != nil:
+				reply.Error = topicErr.Error()
+			case request.Filter != nil && request.Action == "subscribe":
+				if err := request.Filter.validate(s.tradingBot.Symbols()); err != nil {
+					reply.Error = err.Error()
+					break
+				}
+				predictions = newPredictionGate(*request.Filter)
+				fallthrough
+			case request.Action == "subscribe" || request.Action == "unsubscribe":
+				for _, topic := range requested {
+					if request.Action == "subscribe" {
+						topics[topic] = true
+					} else {
+						delete(topics, topic)
+					}
+				}
+				if !topics[bot.EventPrediction] {
+					predictions = nil
+				}
+			default:
+				reply.Error = fmt.Sprintf("unknown action %q (use subscribe or unsubscribe)", request.Action)
+			}
+			reply.Topics = topicNames(topics)
+			if predictions != nil {
+				reply.Filter = &predictions.filter
+			}
+			if !write(reply) {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if !topics[event.Type] {
+				continue
+			}
+			if prediction, ok := event.Data.(PredictionResponse); ok && predictions != nil {
+				if pass, _ := predictions.pass(prediction); !pass {
+					continue
+				}
+			}
+			if !write(event) {
+				requestLogger(c).Info("WebSocket client went away", "client_ip", c.ClientIP())
+				return
+			}
+		case <-ping.C:
+			if conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)) != nil {
+				return
+			}
+		}
+	}
+}
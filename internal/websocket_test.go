@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWebSocketEvents(t *testing.T) {
+	t.Log("🛰️ Testing WebSocket event push with per-topic subscriptions")
+
+	config := bot.DefaultConfig()
+	tradingBot := bot.NewTradingBot(config)
+	server := httptest.NewServer(NewAPIServer(config, tradingBot, "0").router)
+	defer server.Close()
+	target := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/ws"
+
+	if _, response, err := websocket.DefaultDialer.Dial(target+"?topics=prices", nil); err == nil || response.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected an unknown topic to be rejected before the upgrade, got %v", err)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(target+"?topics=signal", nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	var reply WebSocketReply
+	read := func(value interface{}) {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if err := conn.ReadJSON(value); err != nil {
+			t.Fatalf("Failed to read message: %v", err)
+		}
+	}
+	read(&reply)
+	if reply.Type != "subscription" || strings.Join(reply.Topics, ",") != "signal" {
+		t.Fatalf("Expected the initial topics to be acknowledged, got %+v", reply)
+	}
+
+	// Subscribing is acknowledged, and the subscription is active once acknowledged
+	request := func(message WebSocketRequest) WebSocketReply {
+		t.Helper()
+		if err := conn.WriteJSON(message); err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		var reply WebSocketReply
+		read(&reply)
+		return reply
+	}
+	if reply := request(WebSocketRequest{Action: "subscribe", Topics: []string{"position", "trade"}}); reply.Error != "" || strings.Join(reply.Topics, ",") != "position,signal,trade" {
+		t.Fatalf("Unexpected subscribe reply %+v", reply)
+	}
+	if reply := request(WebSocketRequest{Action: "unsubscribe", Topics: []string{"signal"}}); strings.Join(reply.Topics, ",") != "position,trade" {
+		t.Fatalf("Unexpected unsubscribe reply %+v", reply)
+	}
+	if reply := request(WebSocketRequest{Action: "subscribe", Topics: []string{"candles"}}); !strings.Contains(reply.Error, "unknown topic candles") || len(reply.Topics) != 2 {
+		t.Errorf("Expected an unknown topic error keeping the topics, got %+v", reply)
+	}
+	if reply := request(WebSocketRequest{Action: "mute"}); !strings.Contains(reply.Error, "unknown action") {
+		t.Errorf("Expected an unknown action error, got %+v", reply)
+	}
+
+	// Only subscribed topics are pushed
+	tradingBot.PublishEvent(bot.EventSignal, &bot.TradingSignal{Symbol: config.Symbol, Signal: bot.Buy})
+	tradingBot.PublishEvent(bot.EventPosition, &bot.Position{Symbol: config.Symbol, Side: "LONG", PnL: 12.5})
+	tradingBot.PublishEvent(bot.EventTrade, &bot.Trade{Symbol: config.Symbol, PnL: 20})
+
+	var position, trade struct {
+		Type bot.EventType `json:"type"`
+		Data struct {
+			PnL float64 `json:"pnl"`
+		} `json:"data"`
+	}
+	read(&position)
+	read(&trade)
+	if position.Type != bot.EventPosition || position.Data.PnL != 12.5 || trade.Type != bot.EventTrade || trade.Data.PnL != 20 {
+		t.Errorf("Expected the position then the trade event, got %+v and %+v", position, trade)
+	}
+}
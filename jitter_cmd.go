@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"trading-bot/pkg/bot"
+)
+
+// runJitter backtests with randomized entry timing and slippage and prints how much return survives
+func runJitter(args []string) {
+	flags := flag.NewFlagSet("jitter", flag.ExitOnError)
+	days := flags.Int("days", 7, "Backtest window in days")
+	runs := flags.Int("runs", 20, "Number of jittered backtests")
+	slippage := flags.Float64("slippage", 10, "Maximum adverse entry slippage in basis points")
+	verbose := flags.Bool("verbose", false, "Show bot logs while backtesting")
+	flags.Parse(args)
+
+	configManager := bot.NewConfigManager("config.json")
+	if err := configManager.Load(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := configManager.GetConfig()
+
+	fmt.Printf("🎲 Entry timing robustness for %s: %d runs, ±1 candle, up to %.1f bps slippage over %d days\n", config.Symbol, *runs, *slippage, *days)
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	candles, start, end, err := bot.NewTradingBot(config).LoadBacktestCandles(*days)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load history: %v\n", err)
+		os.Exit(1)
+	}
+
+	report, err := bot.RunEntryJitterTest(config, config.Account.InitialBalance, candles, start, end, *runs, *slippage)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Entry jitter test failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("📊 Perfect entries: return %.2f%%\n", report.BaselineReturn)
+	fmt.Printf("🎲 Jittered: mean %.2f%%, median %.2f%%, worst %.2f%%, best %.2f%%, std dev %.2f%%\n",
+		report.MeanReturn, report.MedianReturn, report.WorstReturn, report.BestReturn, report.StdDevReturn)
+	fmt.Printf("✅ %d/%d runs profitable", report.ProfitableRuns, report.Runs)
+	if report.BaselineReturn > 0 {
+		fmt.Printf(", %.0f%% of the edge retained", report.EdgeRetained)
+	}
+	fmt.Println()
+}
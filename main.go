@@ -14,14 +14,19 @@
 // @BasePath /api/v1
 // @schemes http
 
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name X-Admin-Token
+
 package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"syscall"
 	"time"
 
@@ -30,12 +35,46 @@ import (
 	"trading-bot/pkg/bot"
 )
 
+// mainLog logs the bot process lifecycle
+var mainLog = bot.Logger("main")
+
 func main() {
 	// Check for test command
 	// TestCommand()
 
-	fmt.Println("🚀 Multi-Timeframe Trading Bot with API")
-	fmt.Println("==========================================")
+	// Offline tools
+	if len(os.Args) > 1 && os.Args[1] == "backtest" {
+		runBacktestCmd(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "sensitivity" {
+		runSensitivity(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "optimize" {
+		runOptimize(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "walkforward" {
+		runWalkForward(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "stophunt" {
+		runStopHunt(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "jitter" {
+		runJitter(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "diag" {
+		runDiag(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "download" {
+		runDownload(os.Args[2:])
+		return
+	}
 
 	// Load configuration
 	configManager := bot.NewConfigManager("config.json")
@@ -45,8 +84,18 @@ func main() {
 
 	config := configManager.GetConfig()
 
-	// Display configuration summary
-	fmt.Print(configManager.GetSummary())
+	// Route log output through the structured logger
+	bot.SetupLogging(config.Logging, os.Stderr)
+	mainLog.Info("🚀 Multi-Timeframe Trading Bot with API", "config", "config.json")
+
+	// Log the configuration summary
+	indicators := make([]string, 0)
+	for _, info := range bot.Indicators() {
+		if info.IsEnabled(config) {
+			indicators = append(indicators, info.Name)
+		}
+	}
+	mainLog.Info("📊 Configuration loaded", "symbol", config.Symbol, "data_provider", config.DataProvider, "indicators", strings.Join(indicators, ","))
 
 	// Create trading bot
 	bot := bot.NewTradingBot(config)
@@ -56,13 +105,14 @@ func main() {
 	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
 
 	// Start the bot
-	fmt.Printf("🎯 Starting trading bot for %s...\n", config.Symbol)
+	mainLog.Info("🎯 Starting trading bot", "symbol", config.Symbol)
 	if err := bot.Start(); err != nil {
 		log.Fatalf("Failed to start trading bot: %v", err)
 	}
 
 	// Create and start API server
 	apiServer := internal.NewAPIServer(config, bot, "8080")
+	apiServer.SetConfigManager(configManager)
 
 	// Start API server in a goroutine
 	ctx, cancel := context.WithCancel(context.Background())
@@ -70,7 +120,26 @@ func main() {
 
 	go func() {
 		if err := apiServer.StartWithContext(ctx); err != nil {
-			log.Printf("API server error: %v", err)
+			mainLog.Error("API server error", "error", err)
+		}
+	}()
+
+	// Apply edits of config.json without a restart
+	go bot.WatchConfig(ctx, configManager, 5*time.Second)
+
+	// Re-read Binance API keys from the environment / secret files on SIGHUP
+	reloadChan := make(chan os.Signal, 1)
+	signal.Notify(reloadChan, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-reloadChan:
+				if _, err := bot.ReloadCredentials("", ""); err != nil {
+					mainLog.Warn("Failed to reload Binance API keys", "error", err)
+				}
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 
@@ -83,25 +152,14 @@ func main() {
 			select {
 			case <-ticker.C:
 				status := bot.GetStatus()
-				fmt.Printf("\n📈 Status Update:\n")
-				fmt.Printf("   Running: %t\n", status.Running)
-				fmt.Printf("   Symbol: %s\n", status.Symbol)
-				fmt.Printf("   Data Available:\n")
-				for tf, count := range status.DataSummary {
-					ready := "❌"
-					if status.ReadyStatus[tf] {
-						ready = "✅"
-					}
-					fmt.Printf("     %s: %d candles %s\n", tf.String(), count, ready)
+				attrs := []any{"running", status.Running, "symbol", status.Symbol, "last_update", status.LastUpdate.Format(time.RFC3339)}
+				for _, tf := range sortedTimeframes(status.DataSummary) {
+					attrs = append(attrs, "candles_"+tf.String(), status.DataSummary[tf], "ready_"+tf.String(), status.ReadyStatus[tf])
 				}
 				if status.LastSignal != nil {
-					fmt.Printf("   Last Signal: %s (%.1f%% confidence)\n",
-						status.LastSignal.Signal.String(),
-						status.LastSignal.Confidence*100)
+					attrs = append(attrs, "last_signal", status.LastSignal.Signal.String(), "confidence", status.LastSignal.Confidence)
 				}
-				fmt.Printf("   Last Update: %s\n", status.LastUpdate.Format(time.RFC3339))
-				fmt.Printf("   🌐 API: http://localhost:8080/api/v1/predict\n")
-				fmt.Println()
+				mainLog.Info("📈 Status update", attrs...)
 			case <-ctx.Done():
 				return
 			}
@@ -109,24 +167,29 @@ func main() {
 	}()
 
 	// Wait for shutdown signal
-	fmt.Println("✅ Trading bot and API server are running.")
-	fmt.Println("📡 Prediction API: http://localhost:8080/api/v1/predict")
-	fmt.Println("📊 Status API: http://localhost:8080/api/v1/status")
-	fmt.Println("📚 Swagger docs: http://localhost:8080/swagger/index.html")
-	fmt.Println("🔍 All endpoints: http://localhost:8080/")
-	fmt.Println("Press Ctrl+C to stop.")
+	mainLog.Info("✅ Trading bot and API server are running, press Ctrl+C to stop", "url", "http://localhost:8080/")
 	<-signalChan
 
 	// Graceful shutdown
-	fmt.Println("\n🛑 Shutting down trading bot and API server...")
+	mainLog.Info("🛑 Shutting down trading bot and API server")
 
 	// Cancel context to stop API server
 	cancel()
 
 	// Stop trading bot
 	if err := bot.Stop(); err != nil {
-		log.Printf("Error during bot shutdown: %v", err)
+		mainLog.Error("Error during bot shutdown", "error", err)
 	}
 
-	fmt.Println("👋 Trading bot and API server stopped. Goodbye!")
+	mainLog.Info("👋 Trading bot and API server stopped")
+}
+
+// sortedTimeframes returns the timeframes of a status data summary, shortest first
+func sortedTimeframes(summary map[bot.Timeframe]int) []bot.Timeframe {
+	timeframes := make([]bot.Timeframe, 0, len(summary))
+	for tf := range summary {
+		timeframes = append(timeframes, tf)
+	}
+	sort.Slice(timeframes, func(i, j int) bool { return timeframes[i].Duration() < timeframes[j].Duration() })
+	return timeframes
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"trading-bot/pkg/bot"
+)
+
+// sweepFlags collects repeated -param flags
+type sweepFlags []bot.SweepParameter
+
+func (s *sweepFlags) String() string {
+	return fmt.Sprintf("%d parameters", len(*s))
+}
+
+// Set parses "section.param=v1,v2,v3" or "section.param=from:to:step"
+func (s *sweepFlags) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return fmt.Errorf("expected section.param=values, got %q", value)
+	}
+	param := bot.SweepParameter{Parameter: parts[0]}
+
+	if bounds := strings.Split(parts[1], ":"); len(bounds) == 3 {
+		var numbers [3]float64
+		for i, bound := range bounds {
+			number, err := strconv.ParseFloat(bound, 64)
+			if err != nil {
+				return fmt.Errorf("invalid range %q: %w", parts[1], err)
+			}
+			numbers[i] = number
+		}
+		from, to, step := numbers[0], numbers[1], numbers[2]
+		if step <= 0 || to < from {
+			return fmt.Errorf("invalid range %q: need from <= to and step > 0", parts[1])
+		}
+		for v := from; v <= to+step/1e6; v += step {
+			param.Values = append(param.Values, v)
+		}
+	} else {
+		for _, field := range strings.Split(parts[1], ",") {
+			number, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				return fmt.Errorf("invalid value %q: %w", field, err)
+			}
+			param.Values = append(param.Values, number)
+		}
+	}
+
+	*s = append(*s, param)
+	return nil
+}
+
+// runOptimize backtests a grid of indicator parameters in parallel and prints the best combinations
+func runOptimize(args []string) {
+	var params sweepFlags
+	flags := flag.NewFlagSet("optimize", flag.ExitOnError)
+	flags.Var(&params, "param", "Parameter to sweep, e.g. rsi.period=10,14,20 or rsi.period=10:20:2 (repeatable)")
+	days := flags.Int("days", 7, "Backtest window in days")
+	workers := flags.Int("workers", 0, "Concurrent backtests (0 = one per CPU)")
+	top := flags.Int("top", 10, "Number of combinations to print")
+	verbose := flags.Bool("verbose", false, "Show bot logs while backtesting")
+	flags.Parse(args)
+
+	if len(params) == 0 {
+		fmt.Fprintln(os.Stderr, "❌ Nothing to sweep: pass at least one -param section.param=values")
+		os.Exit(2)
+	}
+
+	configManager := bot.NewConfigManager("config.json")
+	if err := configManager.Load(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := configManager.GetConfig()
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	candles, start, end, err := bot.NewTradingBot(config).LoadBacktestCandles(*days)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load history: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Ctrl-C stops the sweep and prints what finished
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	optimizer := bot.NewParameterOptimizer(config, config.Account.InitialBalance, *workers)
+	optimizer.OnProgress(func(p bot.SweepProgress) {
+		best := "-"
+		if p.Best != nil {
+			best = fmt.Sprintf("%.2f%%", p.Best.TotalReturnPercent)
+		}
+		fmt.Fprintf(os.Stderr, "\r⏳ %d/%d combinations, best return %s, ~%s left   ",
+			p.Completed, p.Total, best, p.Remaining.Round(time.Second))
+	})
+
+	fmt.Printf("🧮 Optimizing %s over %d days\n", config.Symbol, *days)
+	report, err := optimizer.Run(ctx, params, candles, start, end)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Optimization failed: %v\n", err)
+		os.Exit(1)
+	}
+	if report.Cancelled {
+		fmt.Printf("⚠️  Cancelled after %d of %d combinations\n", report.Completed, report.Combinations)
+	}
+
+	names := make([]string, 0, len(params))
+	for _, param := range params {
+		names = append(names, param.Parameter)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("📊 %d combinations on %d workers in %s\n\n", report.Completed, report.Workers, report.Duration.Round(time.Second))
+	for i, result := range report.Results {
+		if i >= *top {
+			break
+		}
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			values = append(values, fmt.Sprintf("%s=%g", name, result.Parameters[name]))
+		}
+		if result.Error != "" {
+			fmt.Printf("%3d. %s  ❌ %s\n", i+1, strings.Join(values, " "), result.Error)
+			continue
+		}
+		fmt.Printf("%3d. %s  return %.2f%%  drawdown %.2f%%  accuracy %.1f%%  trades %d\n",
+			i+1, strings.Join(values, " "), result.TotalReturnPercent, result.MaxDrawdownPercent, result.SignalAccuracy, result.Trades)
+	}
+}
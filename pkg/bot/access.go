@@ -0,0 +1,41 @@
+package bot
+
+import "crypto/subtle"
+
+// API roles, each allowed everything the previous one is
+const (
+	RoleViewer = "viewer" // Read-only endpoints
+	RoleTrader = "trader" // Trading control, alerts, subscriptions and backtests
+	RoleAdmin  = "admin"  // Operator endpoints and config changes
+)
+
+// roleRanks orders the roles by privilege
+var roleRanks = map[string]int{RoleViewer: 1, RoleTrader: 2, RoleAdmin: 3}
+
+// RoleAllows reports whether role may call an endpoint requiring required
+func RoleAllows(role, required string) bool {
+	return roleRanks[role] > 0 && roleRanks[role] >= roleRanks[required]
+}
+
+// AccessControl reports whether named users are configured, so that every
+// route needs a token rather than just the admin ones
+func (a AdminConfig) AccessControl() bool {
+	return len(a.Users) > 0
+}
+
+// Authenticate returns the user a bearer token belongs to; the admin token
+// authenticates as "admin" with the admin role
+func (a AdminConfig) Authenticate(token string) (APIUser, bool) {
+	if token == "" {
+		return APIUser{}, false
+	}
+	if a.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.Token)) == 1 {
+		return APIUser{Name: "admin", Role: RoleAdmin}, true
+	}
+	for _, user := range a.Users {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(user.Token)) == 1 {
+			return APIUser{Name: user.Name, Role: user.Role}, true
+		}
+	}
+	return APIUser{}, false
+}
@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccountCompounding(t *testing.T) {
+	t.Log("💰 Testing configurable starting balance and compounding position sizing")
+
+	// Win 10% on a first trade, then compare the size of the second entry
+	secondEntrySize := func(compounding bool) (float64, float64) {
+		config := DefaultConfig()
+		config.Account = AccountConfig{InitialBalance: 5000, Currency: "USDT", Compounding: compounding}
+		executor := NewTradeExecutor(config, config.Account.InitialBalance)
+		buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Timestamp: time.Now()}
+		sell := &TradingSignal{Symbol: config.Symbol, Signal: Sell, Confidence: 0.9, Timestamp: time.Now()}
+
+		if err := executor.ExecuteSignal(buy, 100, 99); err != nil {
+			t.Fatalf("Failed to open position: %v", err)
+		}
+		first := executor.GetCurrentPosition().Quantity
+		if err := executor.ExecuteSignal(sell, 110, 109); err != nil {
+			t.Fatalf("Failed to close position: %v", err)
+		}
+		if err := executor.ExecuteSignal(buy, 110, 109); err != nil {
+			t.Fatalf("Failed to reopen position: %v", err)
+		}
+		return first, executor.GetCurrentPosition().Quantity
+	}
+
+	// 2% of 5000 at 1 risk per unit
+	fixedFirst, fixedSecond := secondEntrySize(false)
+	if math.Abs(fixedFirst-100) > 1e-6 || math.Abs(fixedSecond-100) > 1e-6 {
+		t.Errorf("Expected fixed sizing of 100 units twice, got %.4f then %.4f", fixedFirst, fixedSecond)
+	}
+	compoundFirst, compoundSecond := secondEntrySize(true)
+	if math.Abs(compoundFirst-100) > 1e-6 || compoundSecond <= compoundFirst {
+		t.Errorf("Expected compounding to grow the second entry past %.4f, got %.4f", compoundFirst, compoundSecond)
+	}
+
+	// The balance currency must match the margin currency
+	config := DefaultConfig()
+	config.Account.Currency = "USDC"
+	if err := ValidateConfig(config); err != nil {
+		t.Errorf("Expected a USD stablecoin balance to be accepted: %v", err)
+	}
+	config.Contract = ContractConfig{Type: ContractInverse, ContractSize: 100}
+	config.Account.Currency = "USDT"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "account.currency") {
+		t.Errorf("Expected a USDT balance to be rejected for an inverse contract, got %v", err)
+	}
+	config.Account = AccountConfig{InitialBalance: 0}
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "account.initial_balance") {
+		t.Errorf("Expected a zero initial balance to be rejected, got %v", err)
+	}
+}
@@ -53,12 +53,7 @@ func TestAccuracyAnalysis(t *testing.T) {
 		futurePrice := histData.GetActualPriceAt(testTime.Add(5 * time.Minute))
 		priceChange := futurePrice - currentPrice
 
-		actualDirection := "NEUTRAL"
-		if priceChange > 3 {
-			actualDirection = "HIGHER"
-		} else if priceChange < -3 {
-			actualDirection = "LOWER"
-		}
+		actualDirection := actualDirectionFor(histData.GetCandles(FiveMinute, testTime, 100), priceChange)
 
 		// Generate prediction
 		fiveMinCandles := histData.GetCandles(FiveMinute, testTime, 100)
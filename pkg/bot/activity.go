@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// skippedEntryHistorySize is how many skipped entries are kept for the activity timeline
+const skippedEntryHistorySize = 1000
+
+// ActivityType classifies an entry of the activity timeline
+type ActivityType string
+
+const (
+	ActivitySignal         ActivityType = "signal"
+	ActivityFilterOverride ActivityType = "filter_override" // Entry signal kept from trading by safe mode, maintenance or the enable switch
+	ActivityRiskBlock      ActivityType = "risk_block"
+	ActivityOrder          ActivityType = "order"
+	ActivityTrade          ActivityType = "trade"
+)
+
+// ActivityEvent is one bot action on the activity timeline
+type ActivityEvent struct {
+	Time    time.Time    `json:"time"`
+	Type    ActivityType `json:"type"`
+	Symbol  string       `json:"symbol,omitempty"`
+	Summary string       `json:"summary"`
+	Details interface{}  `json:"details,omitempty"` // The signal, skipped entry, error, order, fill or trade
+}
+
+// SkippedEntry is an entry signal the executor did not act on
+type SkippedEntry struct {
+	Time       time.Time  `json:"time"`
+	Symbol     string     `json:"symbol"`
+	Signal     SignalType `json:"signal"`
+	Confidence float64    `json:"confidence"`
+	Reason     string     `json:"reason"`
+}
+
+// recordSkippedEntry notes an entry signal that was not acted on (assumes lock is held)
+func (te *TradeExecutor) recordSkippedEntry(signal *TradingSignal, reason string) {
+	te.skippedEntries.Add(SkippedEntry{Time: te.now(), Symbol: te.config.Symbol, Signal: signal.Signal, Confidence: signal.Confidence, Reason: reason})
+}
+
+// GetActivity returns the signals, filter overrides, risk blocks, orders, fills
+// and closed trades of one trading day (see SessionConfig.TradingDay), oldest first
+func (tb *TradingBot) GetActivity(day time.Time) []ActivityEvent {
+	date := day.Format("2006-01-02")
+	events := make([]ActivityEvent, 0)
+	add := func(at time.Time, kind ActivityType, symbol, summary string, details interface{}) {
+		if !at.IsZero() && tb.config.Session.TradingDay(at).Format("2006-01-02") == date {
+			events = append(events, ActivityEvent{Time: at, Type: kind, Symbol: symbol, Summary: summary, Details: details})
+		}
+	}
+
+	for _, signal := range tb.signalHistory.All() {
+		add(signal.Timestamp, ActivitySignal, signal.Symbol,
+			fmt.Sprintf("%s at %.1f%% confidence", signal.Signal.String(), signal.Confidence*100), signal)
+	}
+	for _, record := range tb.errorLog.Recent(0, ErrRiskBlocked, "") {
+		add(record.Time, ActivityRiskBlock, "", record.Message, record)
+	}
+	if te := tb.tradeExecutor; te != nil {
+		for _, skipped := range te.skippedEntries.All() {
+			add(skipped.Time, ActivityFilterOverride, skipped.Symbol,
+				fmt.Sprintf("%s entry skipped: %s", skipped.Signal.String(), skipped.Reason), skipped)
+		}
+		for _, fill := range te.executions.All() {
+			add(fill.FilledAt, ActivityOrder, fill.Symbol,
+				fmt.Sprintf("%s %.8g filled at %.8g (%s)", fill.Side, fill.Quantity, fill.FillPrice, fill.Reason), fill)
+		}
+		for _, order := range te.GetOrderHistory(0) {
+			add(order.CreatedTime, ActivityOrder, order.Symbol,
+				fmt.Sprintf("%s %s %.8g at %.8g (%s, %s)", order.Type, order.Side, order.Quantity, order.Price, order.Strategy, order.Status), order)
+		}
+		for _, trade := range te.GetTradeHistory(0) {
+			add(trade.ExitTime, ActivityTrade, trade.Symbol,
+				fmt.Sprintf("%s closed by %s, PnL %.2f (%.2f%%)", trade.Side, trade.ExitReason, trade.PnL, trade.PnLPercent), trade)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events
+}
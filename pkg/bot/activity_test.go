@@ -0,0 +1,45 @@
+package bot
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestActivityTimeline(t *testing.T) {
+	t.Log("🗓️ Testing the merged daily activity timeline")
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	tb := NewTradingBot(config)
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tb.signalHistory.Add(&TradingSignal{Symbol: "BTCUSDT", Signal: Buy, Confidence: 0.8, Timestamp: day.Add(10 * time.Hour)})
+	tb.signalHistory.Add(&TradingSignal{Symbol: "BTCUSDT", Signal: Sell, Confidence: 0.7, Timestamp: day.Add(-time.Hour)})
+	blocked := NewEngineError(ErrRiskBlocked, SeverityWarning, "risk", errors.New("daily loss limit reached"))
+	blocked.Time = day.Add(9 * time.Hour)
+	tb.errorLog.Record(blocked)
+	tb.tradeExecutor.tradeHistory = append(tb.tradeExecutor.tradeHistory, &Trade{Symbol: "BTCUSDT", Side: "LONG", ExitReason: "ATR_STOP", PnL: -12.5, ExitTime: day.Add(12 * time.Hour)})
+
+	// Entries the executor skips are recorded as filter overrides
+	tb.tradeExecutor.SetClock(ClockFunc(func() time.Time { return day.Add(11 * time.Hour) }))
+	tb.tradeExecutor.Disable()
+	tb.tradeExecutor.ExecuteSignal(&TradingSignal{Signal: Buy, Confidence: 0.8}, 100, 0)
+	tb.tradeExecutor.ExecuteSignal(&TradingSignal{Signal: Hold}, 100, 0)
+
+	events := tb.GetActivity(day)
+	var types []string
+	for _, event := range events {
+		types = append(types, string(event.Type))
+	}
+	if strings.Join(types, ",") != "risk_block,signal,filter_override,trade" {
+		t.Fatalf("Unexpected timeline: %v", types)
+	}
+	if events[2].Summary != "BUY entry skipped: Trade execution disabled" || events[3].Summary != "LONG closed by ATR_STOP, PnL -12.50 (0.00%)" {
+		t.Errorf("Unexpected summaries: %q, %q", events[2].Summary, events[3].Summary)
+	}
+	if previous := tb.GetActivity(day.AddDate(0, 0, -1)); len(previous) != 1 || previous[0].Type != ActivitySignal {
+		t.Errorf("Expected only the previous day's signal, got %+v", previous)
+	}
+}
@@ -0,0 +1,336 @@
+package bot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAlertNotFound is returned for an unknown alert ID
+var ErrAlertNotFound = errors.New("alert not found")
+
+// alertCondition matches "<subject> <operator> <threshold>", e.g. "RSI_5m < 25" or "price crosses 65000"
+var alertCondition = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s*(<=|>=|<|>|\s(?:crosses_above|crosses_below|crosses)\s)\s*(-?\d+(?:\.\d+)?)\s*$`)
+
+// AlertSpec is the user-defined part of an alert
+type AlertSpec struct {
+	Symbol    string `json:"symbol,omitempty"`  // Default: the traded symbol
+	Condition string `json:"condition"`         // "price" or an indicator name, an operator (<, <=, >, >=, crosses, crosses_above, crosses_below) and a threshold
+	Message   string `json:"message,omitempty"` // Sent instead of the generated text
+	Level     string `json:"level,omitempty"`   // INFO (default), WARNING or CRITICAL
+	Repeat    bool   `json:"repeat"`            // Fire again each time the condition is met anew; otherwise once
+}
+
+// Alert is a price or indicator condition delivered through the notifiers when it is met
+type Alert struct {
+	ID string `json:"id"`
+	AlertSpec
+	Active        bool      `json:"active"` // False once a one-shot alert has fired
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	TriggerCount  int       `json:"trigger_count"`
+	LastTriggered time.Time `json:"last_triggered,omitempty"`
+	LastValue     float64   `json:"last_value,omitempty"` // Latest value of the watched price or indicator
+
+	subject   string
+	operator  string
+	threshold float64
+	armed     bool // Threshold conditions fire when they become true, not while they stay true
+	hasLast   bool
+}
+
+// parse validates the spec and fills in the parsed condition
+func (a *Alert) parse() error {
+	match := alertCondition.FindStringSubmatch(a.Condition)
+	if match == nil {
+		return fmt.Errorf("invalid condition %q: expected e.g. \"RSI_5m < 25\" or \"price crosses 65000\"", a.Condition)
+	}
+	threshold, err := strconv.ParseFloat(match[3], 64)
+	if err != nil {
+		return fmt.Errorf("invalid threshold %q: %w", match[3], err)
+	}
+	a.Level = strings.ToUpper(valueOrDefault(a.Level, SeverityInfo))
+	if a.Level != SeverityInfo && a.Level != SeverityWarning && a.Level != SeverityCritical {
+		return fmt.Errorf("level must be %s, %s or %s, got %q", SeverityInfo, SeverityWarning, SeverityCritical, a.Level)
+	}
+	a.subject, a.operator, a.threshold = match[1], strings.TrimSpace(match[2]), threshold
+	a.armed, a.hasLast = true, false
+	return nil
+}
+
+// watchedValue returns the alert's price or indicator value
+func (a *Alert) watchedValue(price float64, indicators []IndicatorSignal) (float64, bool) {
+	if strings.EqualFold(a.subject, "price") {
+		return price, price > 0
+	}
+	for _, indicator := range indicators {
+		if strings.EqualFold(indicator.Name, a.subject) {
+			return indicator.Value, true
+		}
+	}
+	return 0, false
+}
+
+// check reports whether the alert fires at value, updating its crossing and arming state
+func (a *Alert) check(value float64) bool {
+	last, hasLast := a.LastValue, a.hasLast
+	a.LastValue, a.hasLast = value, true
+
+	above := hasLast && last < a.threshold && value >= a.threshold
+	below := hasLast && last > a.threshold && value <= a.threshold
+	switch a.operator {
+	case "crosses_above":
+		return above
+	case "crosses_below":
+		return below
+	case "crosses":
+		return above || below
+	}
+
+	met := false
+	switch a.operator {
+	case "<":
+		met = value < a.threshold
+	case "<=":
+		met = value <= a.threshold
+	case ">":
+		met = value > a.threshold
+	case ">=":
+		met = value >= a.threshold
+	}
+	if !met {
+		a.armed = true
+		return false
+	}
+	fire := a.armed
+	a.armed = false
+	return fire
+}
+
+// AlertManager holds the user-defined alerts and evaluates them independently of trading
+type AlertManager struct {
+	mutex     sync.Mutex
+	alerts    []*Alert // Creation order
+	nextID    int
+	notifiers []Notifier
+	filename  string // JSON file alerts are persisted to; empty disables
+}
+
+// NewAlertManager creates an alert manager delivering through notifiers
+func NewAlertManager(notifiers []Notifier) *AlertManager {
+	return &AlertManager{alerts: make([]*Alert, 0), notifiers: notifiers}
+}
+
+// SetFile persists alerts to filename, first restoring those saved there
+func (am *AlertManager) SetFile(filename string) error {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	am.filename = filename
+
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read alerts: %w", err)
+	}
+	var alerts []*Alert
+	if err := json.Unmarshal(data, &alerts); err != nil {
+		return fmt.Errorf("failed to parse alerts: %w", err)
+	}
+	for _, alert := range alerts {
+		if err := alert.parse(); err != nil {
+			notifyLog.Warn("Skipping saved alert", "alert_id", alert.ID, "error", err)
+			continue
+		}
+		var id int
+		if _, err := fmt.Sscanf(alert.ID, "alert_%d", &id); err == nil && id > am.nextID {
+			am.nextID = id
+		}
+		am.alerts = append(am.alerts, alert)
+	}
+	return nil
+}
+
+// save writes the alerts to the alerts file (assumes lock is held)
+func (am *AlertManager) save() {
+	if am.filename == "" {
+		return
+	}
+	if err := writeJSONFile(am.filename, am.alerts); err != nil {
+		notifyLog.Warn("Failed to save alerts", "error", err)
+	}
+}
+
+// find returns the alert with id (assumes lock is held)
+func (am *AlertManager) find(id string) (int, *Alert) {
+	for i, alert := range am.alerts {
+		if alert.ID == id {
+			return i, alert
+		}
+	}
+	return -1, nil
+}
+
+// Create adds an active alert
+func (am *AlertManager) Create(spec AlertSpec) (Alert, error) {
+	alert := &Alert{AlertSpec: spec, Active: true}
+	if err := alert.parse(); err != nil {
+		return Alert{}, err
+	}
+
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	am.nextID++
+	alert.ID = fmt.Sprintf("alert_%d", am.nextID)
+	alert.CreatedAt = time.Now()
+	alert.UpdatedAt = alert.CreatedAt
+	am.alerts = append(am.alerts, alert)
+	am.save()
+	return *alert, nil
+}
+
+// Update replaces an alert's spec and re-arms it
+func (am *AlertManager) Update(id string, spec AlertSpec) (Alert, error) {
+	updated := Alert{AlertSpec: spec}
+	if err := updated.parse(); err != nil {
+		return Alert{}, err
+	}
+
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	_, alert := am.find(id)
+	if alert == nil {
+		return Alert{}, fmt.Errorf("%w: %s", ErrAlertNotFound, id)
+	}
+	updated.ID, updated.CreatedAt, updated.TriggerCount, updated.LastTriggered = alert.ID, alert.CreatedAt, alert.TriggerCount, alert.LastTriggered
+	updated.Active = true
+	updated.UpdatedAt = time.Now()
+	*alert = updated
+	am.save()
+	return *alert, nil
+}
+
+// Delete removes an alert
+func (am *AlertManager) Delete(id string) error {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	i, alert := am.find(id)
+	if alert == nil {
+		return fmt.Errorf("%w: %s", ErrAlertNotFound, id)
+	}
+	am.alerts = append(am.alerts[:i], am.alerts[i+1:]...)
+	am.save()
+	return nil
+}
+
+// Get returns one alert
+func (am *AlertManager) Get(id string) (Alert, error) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	if _, alert := am.find(id); alert != nil {
+		return *alert, nil
+	}
+	return Alert{}, fmt.Errorf("%w: %s", ErrAlertNotFound, id)
+}
+
+// List returns every alert in creation order
+func (am *AlertManager) List() []Alert {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+	alerts := make([]Alert, len(am.alerts))
+	for i, alert := range am.alerts {
+		alerts[i] = *alert
+	}
+	return alerts
+}
+
+// Evaluate checks symbol's active alerts against its price and indicator values,
+// notifying for each one that fires, and returns the fired alerts
+func (am *AlertManager) Evaluate(symbol string, price float64, indicators []IndicatorSignal) []Alert {
+	am.mutex.Lock()
+	fired := make([]Alert, 0)
+	for _, alert := range am.alerts {
+		if !alert.Active || alert.Symbol != symbol {
+			continue
+		}
+		value, ok := alert.watchedValue(price, indicators)
+		if !ok || !alert.check(value) {
+			continue
+		}
+		alert.TriggerCount++
+		alert.LastTriggered = time.Now()
+		alert.Active = alert.Repeat
+		fired = append(fired, *alert)
+	}
+	if len(fired) > 0 {
+		am.save()
+	}
+	am.mutex.Unlock()
+
+	for _, alert := range fired {
+		message := alert.Message
+		if message == "" {
+			message = fmt.Sprintf("%s: %s (now %s)", alert.Symbol, alert.Condition, strconv.FormatFloat(alert.LastValue, 'f', -1, 64))
+		}
+		notifyAll(am.notifiers, alert.Level, "Alert "+alert.ID, message)
+	}
+	return fired
+}
+
+// CreateAlert adds an alert for one of the configured symbols
+func (tb *TradingBot) CreateAlert(spec AlertSpec) (Alert, error) {
+	if err := tb.resolveAlertSymbol(&spec); err != nil {
+		return Alert{}, err
+	}
+	return tb.alerts.Create(spec)
+}
+
+// UpdateAlert replaces an alert's spec and re-arms it
+func (tb *TradingBot) UpdateAlert(id string, spec AlertSpec) (Alert, error) {
+	if err := tb.resolveAlertSymbol(&spec); err != nil {
+		return Alert{}, err
+	}
+	return tb.alerts.Update(id, spec)
+}
+
+// resolveAlertSymbol defaults the symbol to the traded one and rejects unconfigured symbols
+func (tb *TradingBot) resolveAlertSymbol(spec *AlertSpec) error {
+	spec.Symbol = strings.ToUpper(valueOrDefault(spec.Symbol, tb.config.Symbol))
+	if _, ok := tb.lookupEngine(spec.Symbol); !ok {
+		return fmt.Errorf("symbol %s is not configured (available: %s)", spec.Symbol, strings.Join(tb.Symbols(), ", "))
+	}
+	return nil
+}
+
+// DeleteAlert removes an alert
+func (tb *TradingBot) DeleteAlert(id string) error {
+	return tb.alerts.Delete(id)
+}
+
+// GetAlert returns one alert
+func (tb *TradingBot) GetAlert(id string) (Alert, error) {
+	return tb.alerts.Get(id)
+}
+
+// GetAlerts returns every alert in creation order
+func (tb *TradingBot) GetAlerts() []Alert {
+	return tb.alerts.List()
+}
+
+// checkAlerts evaluates the signal's symbol's alerts at the signal's price and indicator values
+func (tb *TradingBot) checkAlerts(signal *TradingSignal) {
+	price := signal.Price
+	if price <= 0 {
+		if current, err := tb.GetSymbolPrice(signal.Symbol); err == nil {
+			price = current
+		}
+	}
+	tb.alerts.Evaluate(signal.Symbol, price, signal.IndicatorSignals)
+}
@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPriceAlerts(t *testing.T) {
+	t.Log("🔔 Testing price and indicator alerts, their firing rules and persistence")
+
+	notifier := &recordingNotifier{}
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	alerts := NewAlertManager([]Notifier{notifier})
+	if err := alerts.SetFile(path); err != nil {
+		t.Fatalf("Failed to set alerts file: %v", err)
+	}
+
+	for _, condition := range []string{"RSI_5m", "price crosses", "RSI_5m => 25", "25 < RSI_5m"} {
+		if _, err := alerts.Create(AlertSpec{Symbol: "BTCUSDT", Condition: condition}); err == nil {
+			t.Errorf("Expected condition %q to be rejected", condition)
+		}
+	}
+	if _, err := alerts.Create(AlertSpec{Symbol: "BTCUSDT", Condition: "price > 1", Level: "LOUD"}); err == nil {
+		t.Error("Expected an unknown level to be rejected")
+	}
+
+	rsi, _ := alerts.Create(AlertSpec{Symbol: "BTCUSDT", Condition: "RSI_5m < 25", Level: "warning"})
+	cross, _ := alerts.Create(AlertSpec{Symbol: "BTCUSDT", Condition: "price crosses 65000", Repeat: true})
+	above, _ := alerts.Create(AlertSpec{Symbol: "BTCUSDT", Condition: "price crosses_above 66000", Message: "BTC broke out"})
+	repeat, _ := alerts.Create(AlertSpec{Symbol: "ETHUSDT", Condition: "price >= 3000", Repeat: true})
+	if rsi.Level != SeverityWarning || !rsi.Active || rsi.ID != "alert_1" {
+		t.Fatalf("Expected an active WARNING alert_1, got %+v", rsi)
+	}
+
+	indicators := func(value float64) []IndicatorSignal {
+		return []IndicatorSignal{{Name: "RSI_5m", Value: value}}
+	}
+	fired := func(symbol string, price, rsiValue float64) []string {
+		ids := make([]string, 0)
+		for _, alert := range alerts.Evaluate(symbol, price, indicators(rsiValue)) {
+			ids = append(ids, alert.ID)
+		}
+		return ids
+	}
+
+	// The first observation only seeds crossings; RSI fires when it drops below 25
+	if ids := fired("BTCUSDT", 64000, 30); len(ids) != 0 {
+		t.Errorf("Expected nothing to fire on the first observation, got %v", ids)
+	}
+	if ids := fired("BTCUSDT", 64500, 24); strings.Join(ids, ",") != rsi.ID {
+		t.Errorf("Expected only the RSI alert to fire, got %v", ids)
+	}
+
+	// Crossing up fires the repeating cross alert; the breakout needs 66000
+	if ids := fired("BTCUSDT", 65500, 20); strings.Join(ids, ",") != cross.ID {
+		t.Errorf("Expected the cross alert to fire, got %v", ids)
+	}
+	if ids := fired("BTCUSDT", 66100, 20); strings.Join(ids, ",") != above.ID {
+		t.Errorf("Expected the breakout alert to fire, got %v", ids)
+	}
+	if ids := fired("BTCUSDT", 64900, 20); strings.Join(ids, ",") != cross.ID {
+		t.Errorf("Expected crossing back down to fire the cross alert again, got %v", ids)
+	}
+
+	// Repeating threshold alerts fire when the condition is met anew, not while it holds
+	for i, want := range []int{1, 0, 0, 1} {
+		price := []float64{3100, 3200, 2900, 3050}[i]
+		if ids := fired("ETHUSDT", price, 0); len(ids) != want {
+			t.Errorf("ETH at %.0f: expected %d firing, got %v", price, want, ids)
+		}
+	}
+
+	got, _ := alerts.Get(rsi.ID)
+	if got.Active || got.TriggerCount != 1 || got.LastValue != 24 {
+		t.Errorf("Expected the one-shot RSI alert to fire once and deactivate, got %+v", got)
+	}
+	if got, _ := alerts.Get(cross.ID); !got.Active || got.TriggerCount != 2 {
+		t.Errorf("Expected the repeating cross alert to stay active after 2 firings, got %+v", got)
+	}
+	if len(notifier.messages) != 6 || notifier.levels[0] != SeverityWarning || !strings.Contains(notifier.messages[0], "RSI_5m < 25") || notifier.messages[2] != "BTC broke out" {
+		t.Errorf("Unexpected notifications %v %q", notifier.levels, notifier.messages)
+	}
+
+	// Updating re-arms a fired alert; deleting removes it
+	if updated, err := alerts.Update(rsi.ID, AlertSpec{Symbol: "BTCUSDT", Condition: "RSI_5m > 70"}); err != nil || !updated.Active || updated.TriggerCount != 1 || updated.Level != SeverityInfo {
+		t.Errorf("Expected the update to re-arm the alert, got %+v (err %v)", updated, err)
+	}
+	if err := alerts.Delete(repeat.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := alerts.Update("alert_99", AlertSpec{Condition: "price > 1"}); !errors.Is(err, ErrAlertNotFound) {
+		t.Errorf("Expected ErrAlertNotFound, got %v", err)
+	}
+
+	// Alerts and their state survive a restart
+	restored := NewAlertManager(nil)
+	if err := restored.SetFile(path); err != nil {
+		t.Fatalf("Failed to restore alerts: %v", err)
+	}
+	list := restored.List()
+	if len(list) != 3 || list[0].Condition != "RSI_5m > 70" || list[2].Active || list[2].TriggerCount != 1 {
+		t.Fatalf("Expected 3 restored alerts with their state, got %+v", list)
+	}
+	if ids := restored.Evaluate("BTCUSDT", 64000, indicators(75)); len(ids) != 1 || ids[0].ID != rsi.ID {
+		t.Errorf("Expected the restored alert to fire, got %+v", ids)
+	}
+	if created, _ := restored.Create(AlertSpec{Symbol: "BTCUSDT", Condition: "price < 1"}); created.ID != "alert_4" {
+		t.Errorf("Expected IDs to continue after the restored ones, got %s", created.ID)
+	}
+
+	// The bot defaults the symbol and only accepts configured ones
+	tb := NewTradingBot(DefaultConfig())
+	if alert, err := tb.CreateAlert(AlertSpec{Condition: "price crosses 65000"}); err != nil || alert.Symbol != tb.config.Symbol {
+		t.Errorf("Expected the traded symbol by default, got %+v (err %v)", alert, err)
+	}
+	if _, err := tb.CreateAlert(AlertSpec{Symbol: "DOGEUSDT", Condition: "price > 1"}); err == nil {
+		t.Error("Expected an unconfigured symbol to be rejected")
+	}
+}
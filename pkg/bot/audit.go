@@ -0,0 +1,178 @@
+package bot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// auditHistorySize bounds the audit entries kept in memory; the file keeps all
+const auditHistorySize = 5000
+
+// Audited operations
+const (
+	AuditConfigChange      = "config_change"
+	AuditConfigArchive     = "config_archive"
+	AuditConfigRollback    = "config_rollback"
+	AuditTradingEnable     = "trading_enable"
+	AuditTradingDisable    = "trading_disable"
+	AuditPositionClose     = "position_close"
+	AuditSafeModeExit      = "safe_mode_exit"
+	AuditStatsReset        = "stats_reset"
+	AuditCredentialsReload = "credentials_reload"
+)
+
+// AuditEntry records who changed what, when, and the value it replaced
+type AuditEntry struct {
+	Time     time.Time       `json:"time"`
+	User     string          `json:"user"`           // API user, or "config-file" for edits on disk
+	Role     string          `json:"role,omitempty"` // The user's role
+	Action   string          `json:"action"`
+	Target   string          `json:"target,omitempty"`   // Config key or symbol acted on
+	Previous json.RawMessage `json:"previous,omitempty"` // Value before the change
+	Value    json.RawMessage `json:"value,omitempty"`    // Value after the change
+}
+
+// AuditLog is an append-only log of state-changing operations, mirrored to a
+// JSON-lines file when one is configured
+type AuditLog struct {
+	path    string
+	entries *History[AuditEntry]
+	mutex   sync.Mutex // Serializes file appends
+}
+
+// NewAuditLog creates an audit log backed by path (empty = memory only),
+// loading the entries already in the file
+func NewAuditLog(path string) (*AuditLog, error) {
+	audit := &AuditLog{path: path, entries: NewHistory[AuditEntry](auditHistorySize)}
+	if path == "" {
+		return audit, nil
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return audit, nil
+	}
+	if err != nil {
+		return audit, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return audit, fmt.Errorf("failed to parse audit log line %d: %w", line, err)
+		}
+		audit.entries.Add(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return audit, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return audit, nil
+}
+
+// Record appends entry to the file, then to memory
+func (a *AuditLog) Record(entry AuditEntry) error {
+	if a.path != "" {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit entry: %w", err)
+		}
+
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+		file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log: %w", err)
+		}
+		defer file.Close()
+		if _, err := file.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("failed to append audit entry: %w", err)
+		}
+	}
+	a.entries.Add(entry)
+	return nil
+}
+
+// Entries returns the retained entries, oldest first
+func (a *AuditLog) Entries() []AuditEntry {
+	return a.entries.All()
+}
+
+// Audit records an operation by user; previous and value are stored as JSON
+func (tb *TradingBot) Audit(user APIUser, action, target string, previous, value interface{}) {
+	entry := AuditEntry{Time: time.Now().UTC(), User: user.Name, Role: user.Role, Action: action, Target: target}
+	for _, field := range []struct {
+		value interface{}
+		into  *json.RawMessage
+	}{{previous, &entry.Previous}, {value, &entry.Value}} {
+		if field.value == nil {
+			continue
+		}
+		data, err := json.Marshal(field.value)
+		if err != nil {
+			configLog.Warn("Failed to encode audit value", "action", action, "error", err)
+			continue
+		}
+		*field.into = data
+	}
+
+	configLog.Info("📒 Audit", "user", user.Name, "role", user.Role, "action", action, "target", target)
+	if err := tb.auditLog.Record(entry); err != nil {
+		configLog.Warn("Failed to write audit log", "error", err)
+	}
+}
+
+// AuditConfig records one config_change entry per top-level key that differs
+// between previous and config, with credentials redacted
+func (tb *TradingBot) AuditConfig(user APIUser, previous, config Config) {
+	var values [2]map[string]json.RawMessage
+	for i, c := range []Config{previous, config} {
+		redacted, _, err := RedactConfig(c)
+		if err != nil {
+			configLog.Warn("Failed to audit config change", "error", err)
+			return
+		}
+		data, err := json.Marshal(redacted)
+		if err == nil {
+			err = json.Unmarshal(data, &values[i])
+		}
+		if err != nil {
+			configLog.Warn("Failed to audit config change", "error", err)
+			return
+		}
+	}
+
+	keys := make(map[string]bool)
+	for _, tree := range values {
+		for key := range tree {
+			keys[key] = true
+		}
+	}
+	for _, key := range sortedKeys(keys) {
+		before, after := values[0][key], values[1][key]
+		if bytes.Equal(before, after) {
+			continue
+		}
+		tb.Audit(user, AuditConfigChange, key, rawOrNil(before), rawOrNil(after))
+	}
+}
+
+// rawOrNil returns nil for a missing value so it is left out of the entry
+func rawOrNil(value json.RawMessage) interface{} {
+	if value == nil {
+		return nil
+	}
+	return value
+}
+
+// GetAuditLog returns the retained audit entries, oldest first
+func (tb *TradingBot) GetAuditLog() []AuditEntry {
+	return tb.auditLog.Entries()
+}
@@ -0,0 +1,349 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// backtestLookbacks mirrors the candle counts the live engine analyzes per timeframe
+var backtestLookbacks = map[Timeframe]int{
+	Daily:           30,
+	EightHour:       50,
+	FortyFiveMinute: 60,
+	FifteenMinute:   80,
+	FiveMinute:      100,
+}
+
+// BacktestLookback is how many candles of a timeframe a backtest needs before its window starts
+func BacktestLookback(timeframe Timeframe) int {
+	return backtestLookbacks[timeframe]
+}
+
+// EquityPoint is the account equity after a simulated 5-minute candle
+type EquityPoint struct {
+	Time     time.Time `json:"time"`
+	Equity   float64   `json:"equity"`
+	Drawdown float64   `json:"drawdown"` // % below the running peak
+}
+
+// IndicatorBacktestStats scores an indicator's BUY/SELL calls against the next
+// candle and attributes trade PnL to the indicators that called each entry
+type IndicatorBacktestStats struct {
+	Name          string  `json:"name"`
+	Signals       int     `json:"signals"`
+	Correct       int     `json:"correct"`
+	Accuracy      float64 `json:"accuracy"`       // %
+	Trades        int     `json:"trades"`         // Trades whose entry this indicator agreed with
+	AttributedPnL float64 `json:"attributed_pnl"` // Share of those trades' PnL, split by signal strength
+}
+
+// BacktestResult is the outcome of replaying the ATR strategy over historical candles
+type BacktestResult struct {
+	ID                 string                   `json:"id"`
+	Symbol             string                   `json:"symbol"`
+	Start              time.Time                `json:"start"`
+	End                time.Time                `json:"end"`
+	CreatedAt          time.Time                `json:"created_at"`
+	Candles            int                      `json:"candles"` // 5-minute candles simulated
+	InitialBalance     float64                  `json:"initial_balance"`
+	FinalBalance       float64                  `json:"final_balance"`
+	TotalReturnPercent float64                  `json:"total_return_percent"`
+	MaxDrawdownPercent float64                  `json:"max_drawdown_percent"`
+	SharpeRatio        float64                  `json:"sharpe_ratio"` // Annualized from per-candle equity returns
+	WinRate            float64                  `json:"win_rate"`     // %
+	FeePercent         float64                  `json:"fee_percent"`  // Simulated fee per fill, % of notional
+	SlippageBps        float64                  `json:"slippage_bps"` // Simulated slippage per fill
+	TotalFees          float64                  `json:"total_fees"`
+	DirectionalSignals int                      `json:"directional_signals"` // Final BUY/SELL signals scored
+	SignalAccuracy     float64                  `json:"signal_accuracy"`     // % of final BUY/SELL signals matching the next candle
+	Performance        PerformanceStats         `json:"performance"`
+	Trades             []*Trade                 `json:"trades"`
+	EquityCurve        []EquityPoint            `json:"equity_curve"`
+	IndicatorStats     []IndicatorBacktestStats `json:"indicator_stats"`
+}
+
+// Backtester replays historical candles through the signal aggregator and a
+// TradeExecutor running on simulated candle time
+type Backtester struct {
+	config         Config
+	initialBalance float64
+	vectorized     bool
+	onSignal       func(*TradingSignal)
+	stopHunt       bool    // Trigger stops inside candles (see SetStopHunt)
+	stopHuntWick   float64 // Extra adverse wick, % of close
+
+	entryJitter      *LockedRand // Randomizes entry timing and slippage (see SetEntryJitter)
+	entrySlippageBps float64
+}
+
+// NewBacktester creates a new backtester
+func NewBacktester(config Config, initialBalance float64) *Backtester {
+	config.TradeHistoryFile = "" // Never persist simulated trades
+	return &Backtester{config: config, initialBalance: initialBalance}
+}
+
+// SetVectorized runs Ichimoku, Bollinger Bands and Elliott Wave on their
+// vectorized path. Elliott Wave is then recomputed from each candle window
+// instead of accumulating state across candles.
+func (bt *Backtester) SetVectorized(enabled bool) {
+	bt.vectorized = enabled
+}
+
+// OnSignal registers a callback invoked with the signal generated at every simulated candle
+func (bt *Backtester) OnSignal(callback func(*TradingSignal)) {
+	bt.onSignal = callback
+}
+
+// Run simulates every 5-minute candle closing in [start, end). Higher timeframes
+// only expose candles that had closed by then, so there is no lookahead.
+func (bt *Backtester) Run(candles map[Timeframe][]Candle, start, end time.Time) (*BacktestResult, error) {
+	fiveMin := candles[FiveMinute]
+	if len(fiveMin) == 0 {
+		return nil, fmt.Errorf("no 5-minute candles to backtest")
+	}
+	for tf := range candles {
+		sort.Slice(candles[tf], func(i, j int) bool { return candles[tf][i].Timestamp.Before(candles[tf][j].Timestamp) })
+	}
+
+	clock := NewSimClock(start)
+	executor := NewTradeExecutor(bt.config, bt.initialBalance)
+	executor.SetClock(clock)
+	executor.SetSimulatedCosts(bt.config.Backtest.FeePercent, bt.config.Backtest.SlippageBps)
+	aggregator := NewSignalAggregator(bt.config)
+	aggregator.SetClock(clock)
+	aggregator.SetVectorized(bt.vectorized)
+
+	// Determinism mode derives the ID from the inputs and stamps the frozen clock
+	now := bt.config.Determinism.WallClock()
+	id := fmt.Sprintf("bt_%d", now().UnixNano())
+	if bt.config.Determinism.Enabled {
+		id = deterministicID("bt", bt.config, start, end, len(fiveMin))
+	}
+
+	result := &BacktestResult{
+		ID:             id,
+		Symbol:         bt.config.Symbol,
+		Start:          start,
+		End:            end,
+		CreatedAt:      now(),
+		InitialBalance: bt.initialBalance,
+		FeePercent:     executor.fees.EffectiveTakerPercent(),
+		SlippageBps:    bt.config.Backtest.SlippageBps,
+		EquityCurve:    make([]EquityPoint, 0),
+	}
+
+	indicatorStats := make(map[string]*IndicatorBacktestStats)
+	statsFor := func(name string) *IndicatorBacktestStats {
+		stats, exists := indicatorStats[name]
+		if !exists {
+			stats = &IndicatorBacktestStats{Name: name}
+			indicatorStats[name] = stats
+		}
+		return stats
+	}
+	entrySignals := make(map[time.Time]*TradingSignal) // Signal behind each entry, by entry time
+	correctSignals := 0
+	peak := bt.initialBalance
+	var last Candle
+
+	for i, candle := range fiveMin {
+		closeTime := candle.Timestamp.Add(FiveMinute.Duration())
+		if closeTime.Before(start) || !closeTime.Before(end) {
+			continue
+		}
+		clock.Set(closeTime)
+		last = candle
+		result.Candles++
+
+		if bt.stopHunt {
+			if _, err := executor.HuntStop(candle, bt.stopHuntWick); err != nil {
+				backtestLog.Warn("Stop hunt failed", "time", closeTime, "error", err)
+			}
+		}
+		executor.UpdateExcursion(candle)
+
+		ctx := bt.contextAt(candles, closeTime)
+		signal, err := aggregator.GenerateSignal(ctx)
+		if err == nil {
+			signal.Timestamp = closeTime
+			if bt.onSignal != nil {
+				bt.onSignal(signal)
+			}
+			price := candle.Close
+			if bt.entryJitter != nil && signal.Signal != Hold && executor.GetCurrentPosition() == nil {
+				price = bt.jitteredEntryPrice(fiveMin, i, signal.Signal)
+			}
+			if err := executor.ExecuteSignal(signal, price, atrTrailStopFor(signal, price, bt.config.ATR.Multiplier)); err != nil {
+				backtestLog.Warn("Backtest execution failed", "time", closeTime, "error", err)
+			}
+			if position := executor.GetCurrentPosition(); position != nil && position.OpenTime.Equal(closeTime) {
+				entrySignals[closeTime] = signal
+			}
+
+			// Score directional calls against the next candle's close
+			if i+1 < len(fiveMin) {
+				move := fiveMin[i+1].Close - candle.Close
+				if signal.Signal != Hold {
+					result.DirectionalSignals++
+					if (signal.Signal == Buy && move > 0) || (signal.Signal == Sell && move < 0) {
+						correctSignals++
+					}
+				}
+				for _, indSig := range signal.IndicatorSignals {
+					if indSig.Signal == Hold {
+						continue
+					}
+					stats := statsFor(indSig.Name)
+					stats.Signals++
+					if (indSig.Signal == Buy && move > 0) || (indSig.Signal == Sell && move < 0) {
+						stats.Correct++
+					}
+				}
+			}
+		}
+
+		equity := bt.equity(executor, candle.Close)
+		peak = math.Max(peak, equity)
+		drawdown := 0.0
+		if peak > 0 {
+			drawdown = (peak - equity) / peak * 100
+		}
+		result.MaxDrawdownPercent = math.Max(result.MaxDrawdownPercent, drawdown)
+		result.EquityCurve = append(result.EquityCurve, EquityPoint{Time: closeTime, Equity: equity, Drawdown: drawdown})
+	}
+
+	if result.Candles == 0 {
+		return nil, fmt.Errorf("no 5-minute candles close between %s and %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	// Mark-to-market: close anything still open at the last simulated price
+	if executor.GetCurrentPosition() != nil {
+		if err := executor.ForceClosePosition(last.Close); err != nil {
+			return nil, fmt.Errorf("failed to close final position: %w", err)
+		}
+	}
+
+	result.Trades = executor.GetTradeHistory(0)
+	result.FinalBalance = executor.GetBalances()[executor.MarginCurrency()]
+	result.TotalReturnPercent = (result.FinalBalance - bt.initialBalance) / bt.initialBalance * 100
+	result.SharpeRatio = sharpeRatio(bt.initialBalance, result.EquityCurve)
+	executor.mutex.RLock()
+	result.Performance = *executor.performanceStats
+	executor.mutex.RUnlock()
+	result.Performance.SharpeRatio = result.SharpeRatio
+	result.WinRate = result.Performance.WinRate
+	for _, trade := range result.Trades {
+		result.TotalFees += trade.Fees
+		attributeTrade(trade, entrySignals[trade.EntryTime], statsFor)
+	}
+
+	if result.DirectionalSignals > 0 {
+		result.SignalAccuracy = float64(correctSignals) / float64(result.DirectionalSignals) * 100
+	}
+	for _, stats := range indicatorStats {
+		if stats.Signals > 0 {
+			stats.Accuracy = float64(stats.Correct) / float64(stats.Signals) * 100
+		}
+		result.IndicatorStats = append(result.IndicatorStats, *stats)
+	}
+	sort.Slice(result.IndicatorStats, func(i, j int) bool { return result.IndicatorStats[i].Name < result.IndicatorStats[j].Name })
+
+	backtestLog.Info("🧪 Backtest finished", "id", result.ID, "candles", result.Candles, "trades", len(result.Trades),
+		"return_percent", result.TotalReturnPercent, "max_drawdown_percent", result.MaxDrawdownPercent, "sharpe", result.SharpeRatio)
+	return result, nil
+}
+
+// attributeTrade splits a trade's PnL across the indicators whose signal at
+// entry pointed the trade's way, in proportion to their strength
+func attributeTrade(trade *Trade, entry *TradingSignal, statsFor func(string) *IndicatorBacktestStats) {
+	if entry == nil {
+		return
+	}
+	direction := Buy
+	if trade.Side == "SHORT" {
+		direction = Sell
+	}
+
+	agreeing := make([]IndicatorSignal, 0)
+	totalStrength := 0.0
+	for _, indSig := range entry.IndicatorSignals {
+		if indSig.Signal == direction {
+			agreeing = append(agreeing, indSig)
+			totalStrength += indSig.Strength
+		}
+	}
+	for _, indSig := range agreeing {
+		share := 1 / float64(len(agreeing))
+		if totalStrength > 0 {
+			share = indSig.Strength / totalStrength
+		}
+		stats := statsFor(indSig.Name)
+		stats.Trades++
+		stats.AttributedPnL += trade.PnL * share
+	}
+}
+
+// sharpeRatio annualizes the mean over the standard deviation of per-candle
+// equity returns (5-minute candles, trading around the clock)
+func sharpeRatio(initialBalance float64, curve []EquityPoint) float64 {
+	if len(curve) < 2 {
+		return 0
+	}
+	returns := make([]float64, len(curve))
+	previous := initialBalance
+	mean := 0.0
+	for i, point := range curve {
+		if previous > 0 {
+			returns[i] = point.Equity/previous - 1
+		}
+		previous = point.Equity
+		mean += returns[i]
+	}
+	mean /= float64(len(returns))
+
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(returns)-1))
+	if stdDev == 0 {
+		return 0
+	}
+	return mean / stdDev * math.Sqrt(float64(365*24*time.Hour/FiveMinute.Duration()))
+}
+
+// contextAt builds the multi-timeframe context visible at a point in time
+func (bt *Backtester) contextAt(candles map[Timeframe][]Candle, at time.Time) *MultiTimeframeContext {
+	return &MultiTimeframeContext{
+		Symbol:              bt.config.Symbol,
+		DailyCandles:        closedCandles(candles[Daily], Daily, at, backtestLookbacks[Daily]),
+		EightHourCandles:    closedCandles(candles[EightHour], EightHour, at, backtestLookbacks[EightHour]),
+		FortyFiveMinCandles: closedCandles(candles[FortyFiveMinute], FortyFiveMinute, at, backtestLookbacks[FortyFiveMinute]),
+		FifteenMinCandles:   closedCandles(candles[FifteenMinute], FifteenMinute, at, backtestLookbacks[FifteenMinute]),
+		FiveMinCandles:      closedCandles(candles[FiveMinute], FiveMinute, at, backtestLookbacks[FiveMinute]),
+		LastUpdate:          at,
+	}
+}
+
+// equity returns margin balance plus the open position's unrealized PnL at price
+func (bt *Backtester) equity(executor *TradeExecutor, price float64) float64 {
+	equity := executor.GetBalances()[executor.MarginCurrency()]
+	if position := executor.GetCurrentPosition(); position != nil {
+		equity += bt.config.Contract.PnL(position.Side, position.EntryPrice, price, position.Quantity)
+	}
+	return equity
+}
+
+// closedCandles returns up to limit candles (sorted ascending) that had closed by at
+func closedCandles(candles []Candle, timeframe Timeframe, at time.Time, limit int) []Candle {
+	n := sort.Search(len(candles), func(i int) bool {
+		return candles[i].Timestamp.Add(timeframe.Duration()).After(at)
+	})
+	start := n - limit
+	if start < 0 {
+		start = 0
+	}
+	return candles[start:n]
+}
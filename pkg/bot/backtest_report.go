@@ -0,0 +1,213 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// backtestIDPattern guards report lookups against path traversal
+var backtestIDPattern = regexp.MustCompile(`^bt_[0-9]+$`)
+
+// svgWidth/svgHeight size the inline report charts
+const (
+	svgWidth  = 900
+	svgHeight = 240
+)
+
+var backtestReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"money":   func(v float64) string { return fmt.Sprintf("%.2f", v) },
+	"percent": func(v float64) string { return fmt.Sprintf("%.2f%%", v) },
+	"time":    func(v interface{ Format(string) string }) string { return v.Format("2006-01-02 15:04") },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Backtest {{.Result.ID}} - {{.Result.Symbol}}</title>
+<style>
+body { font-family: -apple-system, Helvetica, Arial, sans-serif; margin: 24px; color: #222; }
+h1 { margin-bottom: 4px; }
+.summary { display: flex; flex-wrap: wrap; gap: 12px; margin: 16px 0; }
+.card { border: 1px solid #ddd; border-radius: 6px; padding: 10px 14px; min-width: 140px; }
+.card .label { color: #777; font-size: 12px; }
+.card .value { font-size: 18px; font-weight: 600; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; margin-bottom: 24px; }
+th, td { border-bottom: 1px solid #eee; padding: 4px 8px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+.win { color: #1a7f37; } .loss { color: #cf222e; }
+svg { border: 1px solid #eee; }
+</style>
+</head>
+<body>
+<h1>Backtest {{.Result.Symbol}}</h1>
+<div>{{time .Result.Start}} → {{time .Result.End}} · {{.Result.Candles}} candles · report {{.Result.ID}}</div>
+
+<div class="summary">
+<div class="card"><div class="label">Initial balance</div><div class="value">{{money .Result.InitialBalance}}</div></div>
+<div class="card"><div class="label">Final balance</div><div class="value">{{money .Result.FinalBalance}}</div></div>
+<div class="card"><div class="label">Return</div><div class="value">{{percent .Result.TotalReturnPercent}}</div></div>
+<div class="card"><div class="label">Max drawdown</div><div class="value">{{percent .Result.MaxDrawdownPercent}}</div></div>
+<div class="card"><div class="label">Trades</div><div class="value">{{.Result.Performance.TotalTrades}}</div></div>
+<div class="card"><div class="label">Win rate</div><div class="value">{{percent .Result.Performance.WinRate}}</div></div>
+<div class="card"><div class="label">Profit factor</div><div class="value">{{money .Result.Performance.ProfitFactor}}</div></div>
+<div class="card"><div class="label">Sharpe</div><div class="value">{{money .Result.SharpeRatio}}</div></div>
+<div class="card"><div class="label">Fees ({{.Result.FeePercent}}% + {{.Result.SlippageBps}} bps)</div><div class="value">{{money .Result.TotalFees}}</div></div>
+</div>
+
+<h2>Equity curve</h2>
+<svg width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+<polyline fill="none" stroke="#0969da" stroke-width="1.5" points="{{.EquityPoints}}"/>
+</svg>
+
+<h2>Drawdown</h2>
+<svg width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+<polyline fill="none" stroke="#cf222e" stroke-width="1.5" points="{{.DrawdownPoints}}"/>
+</svg>
+
+<h2>Trades</h2>
+<table>
+<tr><th>Entry</th><th>Exit</th><th>Side</th><th>Entry price</th><th>Exit price</th><th>Quantity</th><th>PnL</th><th>PnL %</th><th>MFE %</th><th>MAE %</th><th>Reason</th></tr>
+{{range .Result.Trades}}<tr>
+<td>{{time .EntryTime}}</td><td>{{time .ExitTime}}</td><td>{{.Side}}</td>
+<td>{{money .EntryPrice}}</td><td>{{money .ExitPrice}}</td><td>{{printf "%.6f" .Quantity}}</td>
+<td class="{{if gt .PnL 0.0}}win{{else}}loss{{end}}">{{money .PnL}}</td><td>{{percent .PnLPercent}}</td>
+<td>{{percent .MFEPercent}}</td><td>{{percent .MAEPercent}}</td><td>{{.ExitReason}}</td>
+</tr>{{else}}<tr><td colspan="11">No trades</td></tr>{{end}}
+</table>
+
+<h2>Indicator accuracy (next-candle direction) and PnL attribution</h2>
+<table>
+<tr><th>Indicator</th><th>Signals</th><th>Correct</th><th>Accuracy</th><th>Trades</th><th>Attributed PnL</th></tr>
+{{range .Result.IndicatorStats}}<tr><td>{{.Name}}</td><td>{{.Signals}}</td><td>{{.Correct}}</td><td>{{percent .Accuracy}}</td>
+<td>{{.Trades}}</td><td class="{{if gt .AttributedPnL 0.0}}win{{else}}loss{{end}}">{{money .AttributedPnL}}</td></tr>
+{{else}}<tr><td colspan="6">No directional indicator signals</td></tr>{{end}}
+</table>
+</body>
+</html>
+`))
+
+// WriteBacktestHTMLReport renders a self-contained HTML report with inline SVG charts
+func WriteBacktestHTMLReport(w io.Writer, result *BacktestResult) error {
+	equity := make([]float64, len(result.EquityCurve))
+	drawdown := make([]float64, len(result.EquityCurve))
+	for i, point := range result.EquityCurve {
+		equity[i] = point.Equity
+		drawdown[i] = -point.Drawdown // Plot drawdown downward from zero
+	}
+
+	return backtestReportTemplate.Execute(w, map[string]interface{}{
+		"Result":         result,
+		"Width":          svgWidth,
+		"Height":         svgHeight,
+		"EquityPoints":   svgPolyline(equity, svgWidth, svgHeight),
+		"DrawdownPoints": svgPolyline(drawdown, svgWidth, svgHeight),
+	})
+}
+
+// svgPolyline scales values into polyline points filling a width×height box
+func svgPolyline(values []float64, width, height int) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	span := hi - lo
+	if span == 0 {
+		span = 1
+	}
+
+	const padding = 4.0
+	points := make([]string, len(values))
+	for i, v := range values {
+		x := padding
+		if len(values) > 1 {
+			x += float64(i) / float64(len(values)-1) * (float64(width) - 2*padding)
+		}
+		y := padding + (1-(v-lo)/span)*(float64(height)-2*padding)
+		points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+	return strings.Join(points, " ")
+}
+
+// BacktestStore saves backtest results and reports to a directory
+type BacktestStore struct {
+	dir string
+}
+
+// NewBacktestStore creates a new backtest store
+func NewBacktestStore(dir string) *BacktestStore {
+	return &BacktestStore{dir: dir}
+}
+
+// Save writes <id>.json and <id>.html for a result
+func (bs *BacktestStore) Save(result *BacktestResult) error {
+	if err := os.MkdirAll(bs.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create backtest directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal backtest result: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(bs.dir, result.ID+".json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write backtest result: %w", err)
+	}
+
+	file, err := os.Create(filepath.Join(bs.dir, result.ID+".html"))
+	if err != nil {
+		return fmt.Errorf("failed to create backtest report: %w", err)
+	}
+	defer file.Close()
+
+	if err := WriteBacktestHTMLReport(file, result); err != nil {
+		return fmt.Errorf("failed to render backtest report: %w", err)
+	}
+	return nil
+}
+
+// Load reads a saved backtest result
+func (bs *BacktestStore) Load(id string) (*BacktestResult, error) {
+	if !backtestIDPattern.MatchString(id) {
+		return nil, fmt.Errorf("invalid backtest id: %s", id)
+	}
+
+	data, err := os.ReadFile(filepath.Join(bs.dir, id+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("backtest %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to read backtest result: %w", err)
+	}
+
+	var result BacktestResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse backtest result: %w", err)
+	}
+	return &result, nil
+}
+
+// ReportPath returns the path of a saved HTML report
+func (bs *BacktestStore) ReportPath(id string) (string, error) {
+	if !backtestIDPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid backtest id: %s", id)
+	}
+
+	path := filepath.Join(bs.dir, id+".html")
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("backtest report %s not found", id)
+	}
+	return path, nil
+}
@@ -0,0 +1,170 @@
+package bot
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// syntheticCandles builds candles for a timeframe from a smooth price path
+func syntheticCandles(timeframe Timeframe, start time.Time, count int) []Candle {
+	price := func(t time.Time) float64 {
+		hours := t.Sub(start).Hours()
+		return 50000 + 1500*math.Sin(hours/6) + 20*hours
+	}
+
+	candles := make([]Candle, count)
+	for i := range candles {
+		open := start.Add(time.Duration(i) * timeframe.Duration())
+		o, c := price(open), price(open.Add(timeframe.Duration()))
+		candles[i] = Candle{
+			Timestamp: open,
+			Open:      o,
+			High:      math.Max(o, c) * 1.001,
+			Low:       math.Min(o, c) * 0.999,
+			Close:     c,
+			Volume:    1000 + float64(i%7)*100,
+		}
+	}
+	return candles
+}
+
+func TestBacktestReport(t *testing.T) {
+	t.Log("🧪 Testing backtest simulation and HTML report")
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := map[Timeframe][]Candle{
+		Daily:           syntheticCandles(Daily, origin.AddDate(0, 0, -40), 42),
+		EightHour:       syntheticCandles(EightHour, origin.AddDate(0, 0, -20), 66),
+		FortyFiveMinute: syntheticCandles(FortyFiveMinute, origin.AddDate(0, 0, -3), 200),
+		FifteenMinute:   syntheticCandles(FifteenMinute, origin.AddDate(0, 0, -2), 400),
+		FiveMinute:      syntheticCandles(FiveMinute, origin.AddDate(0, 0, -1), 288*3),
+	}
+
+	// Higher timeframes never expose a candle that hadn't closed yet
+	at := origin.Add(10 * time.Hour)
+	visible := closedCandles(candles[EightHour], EightHour, at, 50)
+	if last := visible[len(visible)-1]; last.Timestamp.Add(8 * time.Hour).After(at) {
+		t.Fatalf("Lookahead: 8H candle opening %v visible at %v", last.Timestamp, at)
+	}
+
+	start, end := origin, origin.Add(24*time.Hour)
+	result, err := NewBacktester(DefaultConfig(), 10000.0).Run(candles, start, end)
+	if err != nil {
+		t.Fatalf("Backtest failed: %v", err)
+	}
+	if result.Candles != 288 || len(result.EquityCurve) != 288 {
+		t.Errorf("Expected 288 simulated candles, got %d (equity points %d)", result.Candles, len(result.EquityCurve))
+	}
+	for _, trade := range result.Trades {
+		if trade.EntryTime.Before(start) || trade.ExitTime.After(end) {
+			t.Errorf("Trade %s uses wall-clock time instead of candle time: %v -> %v", trade.ID, trade.EntryTime, trade.ExitTime)
+		}
+	}
+	t.Logf("   %d trades, return %.2f%%, max drawdown %.2f%%", len(result.Trades), result.TotalReturnPercent, result.MaxDrawdownPercent)
+
+	var html bytes.Buffer
+	if err := WriteBacktestHTMLReport(&html, result); err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	for _, want := range []string{"<svg", "<polyline", "Indicator accuracy", result.ID} {
+		if !strings.Contains(html.String(), want) {
+			t.Errorf("Report missing %q", want)
+		}
+	}
+
+	store := NewBacktestStore(t.TempDir())
+	if err := store.Save(result); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if loaded, err := store.Load(result.ID); err != nil || loaded.Candles != result.Candles {
+		t.Errorf("Failed to load saved backtest: %v", err)
+	}
+	if _, err := store.ReportPath(result.ID); err != nil {
+		t.Errorf("Report not saved: %v", err)
+	}
+	if _, err := store.ReportPath("../config"); err == nil {
+		t.Errorf("Expected invalid id to be rejected")
+	}
+}
+
+func TestBacktestCostsAndAttribution(t *testing.T) {
+	t.Log("💸 Testing backtest fees, slippage, Sharpe ratio and indicator PnL attribution")
+
+	// Fills move against the trade and both sides pay the fee
+	executor := NewTradeExecutor(DefaultConfig(), 10000)
+	executor.SetSimulatedCosts(0.1, 10)
+	buy := &TradingSignal{Symbol: "BTCUSDT", Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(buy, 100, 99); err != nil {
+		t.Fatalf("Failed to open position: %v", err)
+	}
+	position := executor.GetCurrentPosition()
+	if position == nil || math.Abs(position.EntryPrice-100.1) > 1e-9 {
+		t.Fatalf("Expected a 100.1 entry after 10 bps slippage, got %+v", position)
+	}
+	if err := executor.ForceClosePosition(110); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+	trade := executor.GetTradeHistory(0)[0]
+	exit := 110 * 0.999
+	fees := 0.001 * position.Quantity * (100.1 + exit)
+	if math.Abs(trade.ExitPrice-exit) > 1e-9 || math.Abs(trade.Fees-fees) > 1e-9 || math.Abs(trade.PnL-(position.Quantity*(exit-100.1)-fees)) > 1e-6 {
+		t.Errorf("Expected exit %.3f, fees %.4f and net PnL, got %+v", exit, fees, trade)
+	}
+
+	// Costs only make a backtest worse
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := map[Timeframe][]Candle{
+		Daily:           syntheticCandles(Daily, origin.AddDate(0, 0, -40), 42),
+		EightHour:       syntheticCandles(EightHour, origin.AddDate(0, 0, -20), 66),
+		FortyFiveMinute: syntheticCandles(FortyFiveMinute, origin.AddDate(0, 0, -3), 200),
+		FifteenMinute:   syntheticCandles(FifteenMinute, origin.AddDate(0, 0, -2), 400),
+		FiveMinute:      syntheticCandles(FiveMinute, origin.AddDate(0, 0, -1), 288*3),
+	}
+	config := DefaultConfig()
+	config.Backtest = BacktestConfig{}
+	free, err := NewBacktester(config, 10000).Run(candles, origin, origin.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Cost-free backtest failed: %v", err)
+	}
+	config.Backtest = BacktestConfig{FeePercent: 0.05, SlippageBps: 5}
+	costly, err := NewBacktester(config, 10000).Run(candles, origin, origin.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Backtest with costs failed: %v", err)
+	}
+	if len(costly.Trades) == 0 {
+		t.Fatal("Expected the synthetic trend to produce trades")
+	}
+	if free.TotalFees != 0 || costly.TotalFees <= 0 || costly.FinalBalance >= free.FinalBalance {
+		t.Errorf("Expected costs to lower the final balance: free %.2f (fees %.2f), costly %.2f (fees %.2f)",
+			free.FinalBalance, free.TotalFees, costly.FinalBalance, costly.TotalFees)
+	}
+	if math.IsNaN(costly.SharpeRatio) || costly.Performance.SharpeRatio != costly.SharpeRatio || costly.WinRate != costly.Performance.WinRate {
+		t.Errorf("Expected Sharpe and win rate in the result, got %.2f / %.2f", costly.SharpeRatio, costly.WinRate)
+	}
+
+	// Attribution hands out each attributed trade's PnL exactly once
+	attributed, tradesAttributed := 0.0, 0
+	for _, stats := range costly.IndicatorStats {
+		attributed += stats.AttributedPnL
+		tradesAttributed += stats.Trades
+	}
+	total := 0.0
+	for _, trade := range costly.Trades {
+		total += trade.PnL
+	}
+	if tradesAttributed == 0 || math.Abs(attributed-total) > 1e-6 {
+		t.Errorf("Expected attributed PnL %.4f to match trade PnL %.4f (%d attributions)", attributed, total, tradesAttributed)
+	}
+
+	// Sharpe: steady growth with a little noise is strongly positive, a flat curve is zero
+	curve := []EquityPoint{{Equity: 10010}, {Equity: 10030}, {Equity: 10040}, {Equity: 10060}}
+	if sharpe := sharpeRatio(10000, curve); sharpe <= 10 {
+		t.Errorf("Expected a high Sharpe for steady gains, got %.2f", sharpe)
+	}
+	if sharpe := sharpeRatio(10000, []EquityPoint{{Equity: 10000}, {Equity: 10000}}); sharpe != 0 {
+		t.Errorf("Expected zero Sharpe for a flat curve, got %.2f", sharpe)
+	}
+}
@@ -0,0 +1,221 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// maxBayesianCandidates bounds the grid the bayesian search scores each step
+const maxBayesianCandidates = 100000
+
+// Gaussian-process settings over parameter coordinates scaled to [0, 1]
+const (
+	kernelLength = 0.25 // RBF length scale
+	kernelNoise  = 1e-3 // Observation noise, keeps the kernel matrix invertible
+	exploration  = 2.0  // Standard deviations added to the mean (upper confidence bound)
+)
+
+// bayesianSearch backtests a random start sample of the grid, then repeatedly
+// fits a Gaussian process to the scores and backtests the candidate with the
+// highest upper confidence bound, up to Iterations combinations in total
+func (wf *WalkForwardOptimizer) bayesianSearch(ctx context.Context, candles map[Timeframe][]Candle, start, end time.Time, fold int64) (*SweepResult, int, error) {
+	params := wf.options.Params
+	total := 1
+	for _, param := range params {
+		if len(param.Values) == 0 {
+			return nil, 0, fmt.Errorf("parameter %s has no values", param.Parameter)
+		}
+		total *= len(param.Values)
+		if total > maxBayesianCandidates {
+			return nil, 0, fmt.Errorf("bayesian search supports at most %d combinations", maxBayesianCandidates)
+		}
+	}
+	budget := wf.options.Iterations
+	if budget > total {
+		budget = total
+	}
+	initial := budget / 4
+	if initial < 3 {
+		initial = 3
+	}
+
+	rng := rand.New(rand.NewSource(wf.options.Seed + fold))
+	evaluated := make(map[int]bool)
+	var points [][]float64
+	var scores []float64
+	var best *SweepResult
+	bestScore := math.Inf(-1)
+
+	for len(evaluated) < budget && ctx.Err() == nil {
+		var index int
+		if len(evaluated) < initial || len(points) < 2 {
+			index = rng.Intn(total)
+			for evaluated[index] {
+				index = rng.Intn(total)
+			}
+		} else {
+			index = nextCandidate(params, total, evaluated, points, scores)
+		}
+		evaluated[index] = true
+
+		values := decodeCombination(params, index)
+		result, err := wf.backtest(ctx, values, candles, start, end)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return nil, 0, err
+		}
+		if result.Error != "" {
+			continue // Invalid combinations (e.g. failing validation) teach the model nothing
+		}
+		score := wf.score(result)
+		points = append(points, coordinates(params, index))
+		scores = append(scores, score)
+		if score > bestScore {
+			r := result
+			best, bestScore = &r, score
+		}
+	}
+	return best, len(evaluated), nil
+}
+
+// decodeCombination maps a grid index to parameter values, the last parameter varying fastest
+func decodeCombination(params []SweepParameter, index int) map[string]float64 {
+	values := make(map[string]float64, len(params))
+	for i := len(params) - 1; i >= 0; i-- {
+		count := len(params[i].Values)
+		values[params[i].Parameter] = params[i].Values[index%count]
+		index /= count
+	}
+	return values
+}
+
+// coordinates places a grid index in the unit cube, one axis per parameter
+func coordinates(params []SweepParameter, index int) []float64 {
+	point := make([]float64, len(params))
+	for i := len(params) - 1; i >= 0; i-- {
+		count := len(params[i].Values)
+		if count > 1 {
+			point[i] = float64(index%count) / float64(count-1)
+		}
+		index /= count
+	}
+	return point
+}
+
+// nextCandidate fits a Gaussian process to the scores so far and returns the
+// unevaluated grid index with the highest upper confidence bound
+func nextCandidate(params []SweepParameter, total int, evaluated map[int]bool, points [][]float64, scores []float64) int {
+	// Standardize scores so the unit-variance prior fits any objective's scale
+	mean, spread := 0.0, 0.0
+	for _, score := range scores {
+		mean += score
+	}
+	mean /= float64(len(scores))
+	for _, score := range scores {
+		spread += (score - mean) * (score - mean)
+	}
+	spread = math.Sqrt(spread / float64(len(scores)))
+	if spread == 0 {
+		spread = 1
+	}
+	targets := make([]float64, len(scores))
+	for i, score := range scores {
+		targets[i] = (score - mean) / spread
+	}
+
+	n := len(points)
+	kernelMatrix := make([][]float64, n)
+	for i := range kernelMatrix {
+		kernelMatrix[i] = make([]float64, n)
+		for j := range kernelMatrix[i] {
+			kernelMatrix[i][j] = kernel(points[i], points[j])
+		}
+		kernelMatrix[i][i] += kernelNoise
+	}
+	lower := cholesky(kernelMatrix)
+	alpha := solveUpper(lower, solveLower(lower, targets))
+
+	bestIndex, bestBound := -1, math.Inf(-1)
+	covariance := make([]float64, n)
+	for index := 0; index < total; index++ {
+		if evaluated[index] {
+			continue
+		}
+		point := coordinates(params, index)
+		predicted := 0.0
+		for i := range points {
+			covariance[i] = kernel(point, points[i])
+			predicted += covariance[i] * alpha[i]
+		}
+		v := solveLower(lower, covariance)
+		variance := 1.0
+		for _, x := range v {
+			variance -= x * x
+		}
+		if bound := predicted + exploration*math.Sqrt(math.Max(variance, 0)); bound > bestBound {
+			bestIndex, bestBound = index, bound
+		}
+	}
+	return bestIndex
+}
+
+// kernel is the squared-exponential covariance of two points
+func kernel(a, b []float64) float64 {
+	distance := 0.0
+	for i := range a {
+		distance += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Exp(-distance / (2 * kernelLength * kernelLength))
+}
+
+// cholesky returns the lower-triangular L with L·Lᵀ = m (m must be positive definite)
+func cholesky(m [][]float64) [][]float64 {
+	n := len(m)
+	lower := make([][]float64, n)
+	for i := range lower {
+		lower[i] = make([]float64, n)
+		for j := 0; j <= i; j++ {
+			sum := m[i][j]
+			for k := 0; k < j; k++ {
+				sum -= lower[i][k] * lower[j][k]
+			}
+			if i == j {
+				lower[i][i] = math.Sqrt(math.Max(sum, 1e-12))
+			} else {
+				lower[i][j] = sum / lower[j][j]
+			}
+		}
+	}
+	return lower
+}
+
+// solveLower solves L·x = b by forward substitution
+func solveLower(lower [][]float64, b []float64) []float64 {
+	x := make([]float64, len(b))
+	for i := range b {
+		sum := b[i]
+		for k := 0; k < i; k++ {
+			sum -= lower[i][k] * x[k]
+		}
+		x[i] = sum / lower[i][i]
+	}
+	return x
+}
+
+// solveUpper solves Lᵀ·x = b by back substitution
+func solveUpper(lower [][]float64, b []float64) []float64 {
+	x := make([]float64, len(b))
+	for i := len(b) - 1; i >= 0; i-- {
+		sum := b[i]
+		for k := i + 1; k < len(b); k++ {
+			sum -= lower[k][i] * x[k]
+		}
+		x[i] = sum / lower[i][i]
+	}
+	return x
+}
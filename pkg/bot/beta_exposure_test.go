@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBetaExposure(t *testing.T) {
+	t.Log("🧮 Testing exposure scaled by each symbol's rolling beta to BTC")
+
+	executor := NewTradeExecutor(DefaultConfig(), 10000)
+
+	// ETH moves 1.5x BTC; SOL has too little history for a beta
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := map[string]float64{"BTCUSDT": 100, "ETHUSDT": 100, "SOLUSDT": 100}
+	for i := 0; i < 30; i++ {
+		at := start.Add(time.Duration(i) * 5 * time.Minute)
+		move := 0.01 * math.Sin(float64(i))
+		prices["BTCUSDT"] *= 1 + move
+		prices["ETHUSDT"] *= 1 + 1.5*move
+		executor.RecordPrice("BTCUSDT", at, prices["BTCUSDT"])
+		executor.RecordPrice("ETHUSDT", at, prices["ETHUSDT"])
+	}
+	executor.RecordPrice("SOLUSDT", start, prices["SOLUSDT"])
+
+	for _, intent := range []OrderIntent{
+		{Symbol: "ETHUSDT", Side: "BUY", Quantity: 2000 / prices["ETHUSDT"]},
+		{Symbol: "BTCUSDT", Side: "BUY", Quantity: 1000 / prices["BTCUSDT"]},
+		{Symbol: "SOLUSDT", Side: "BUY", Quantity: 10},
+		{Symbol: "ETHUSDT", Side: "SHORT", Quantity: 500 / prices["ETHUSDT"]},
+	} {
+		intent.Strategy, intent.Price = "TEST", prices[intent.Symbol]
+		if err := executor.ExecuteIntent(intent); err != nil {
+			t.Fatalf("%s %s failed: %v", intent.Side, intent.Symbol, err)
+		}
+	}
+
+	report := executor.GetRiskReport(map[string]float64{"BTCUSDT": prices["BTCUSDT"], "ETHUSDT": prices["ETHUSDT"]}, "BTCUSDT")
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.5 }
+	if report.Benchmark != "BTCUSDT" || len(report.Symbols) != 3 || report.Symbols[0].Symbol != "BTCUSDT" {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	btc, eth, sol := report.Symbols[0], report.Symbols[1], report.Symbols[2]
+	if btc.Beta != 1 || !btc.BetaEstimated || !near(btc.BetaAdjusted, 1000) {
+		t.Errorf("Expected BTC to be its own benchmark, got %+v", btc)
+	}
+	if math.Abs(eth.Beta-1.5) > 1e-6 || !eth.BetaEstimated || !near(eth.Net, 1500) || !near(eth.BetaAdjusted, 2250) {
+		t.Errorf("Expected ETH net 1500 at beta 1.5, got %+v", eth)
+	}
+	if sol.Beta != 1 || sol.BetaEstimated || !near(sol.Long, 1000) {
+		t.Errorf("Expected SOL to assume a beta of 1 at its sampled price, got %+v", sol)
+	}
+	if !near(report.Exposure.Long, 4000) || !near(report.Exposure.Short, 500) || !near(report.Exposure.Net, 3500) {
+		t.Errorf("Unexpected raw exposure %+v", report.Exposure)
+	}
+	if !near(report.BetaExposure.Long, 5000) || !near(report.BetaExposure.Short, 750) || !near(report.BetaExposure.Net, 4250) {
+		t.Errorf("Unexpected beta exposure %+v", report.BetaExposure)
+	}
+}
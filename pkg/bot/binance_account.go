@@ -0,0 +1,172 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// signedGetJSON performs an HMAC-signed GET against an account endpoint
+func (b *BinanceFuturesDataProvider) signedGetJSON(path string, params url.Values, out interface{}) error {
+	return b.signedRequestJSON(http.MethodGet, b.baseURL, path, params, out)
+}
+
+// signedRequestJSON performs an HMAC-signed request; the signed parameters go
+// in the query string for every method
+func (b *BinanceFuturesDataProvider) signedRequestJSON(method, baseURL, path string, params url.Values, out interface{}) error {
+	apiKey, secretKey := b.Credentials()
+	if apiKey == "" || secretKey == "" {
+		return fmt.Errorf("API keys required for %s", path)
+	}
+
+	params.Set("timestamp", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(params.Encode()))
+	query := params.Encode() + "&signature=" + hex.EncodeToString(mac.Sum(nil))
+
+	return b.apiKeyRequestJSON(method, baseURL+path+"?"+query, apiKey, out)
+}
+
+// keyedRequestJSON performs a request carrying the API key without a
+// signature, as the user data stream endpoints expect
+func (b *BinanceFuturesDataProvider) keyedRequestJSON(method, baseURL, path string, params url.Values, out interface{}) error {
+	apiKey, _ := b.Credentials()
+	if apiKey == "" {
+		return fmt.Errorf("API key required for %s", path)
+	}
+	endpoint := baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	return b.apiKeyRequestJSON(method, endpoint, apiKey, out)
+}
+
+// apiKeyRequestJSON sends a request with the API key header and decodes the JSON response
+func (b *BinanceFuturesDataProvider) apiKeyRequestJSON(method, endpoint, apiKey string, out interface{}) error {
+	req, err := http.NewRequest(method, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-MBX-APIKEY", apiKey)
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// GetOrderState fetches an order's status and cumulative fills
+func (b *BinanceFuturesDataProvider) GetOrderState(symbol, orderID string) (*ExchangeOrderState, error) {
+	params := url.Values{}
+	params.Set("symbol", b.convertSymbol(symbol))
+	params.Set("orderId", orderID)
+
+	var order struct {
+		OrderID     int64  `json:"orderId"`
+		Symbol      string `json:"symbol"`
+		Side        string `json:"side"`
+		Status      string `json:"status"`
+		OrigQty     string `json:"origQty"`
+		ExecutedQty string `json:"executedQty"`
+		AvgPrice    string `json:"avgPrice"`
+		UpdateTime  int64  `json:"updateTime"`
+	}
+	if err := b.signedGetJSON("/fapi/v1/order", params, &order); err != nil {
+		return nil, err
+	}
+
+	state := &ExchangeOrderState{
+		OrderID:    strconv.FormatInt(order.OrderID, 10),
+		Symbol:     order.Symbol,
+		Side:       order.Side,
+		Status:     order.Status,
+		UpdateTime: time.UnixMilli(order.UpdateTime),
+	}
+	var err error
+	if state.Quantity, err = strconv.ParseFloat(order.OrigQty, 64); err != nil {
+		return nil, fmt.Errorf("failed to parse order quantity: %w", err)
+	}
+	if state.ExecutedQuantity, err = strconv.ParseFloat(order.ExecutedQty, 64); err != nil {
+		return nil, fmt.Errorf("failed to parse executed quantity: %w", err)
+	}
+	if state.AveragePrice, err = strconv.ParseFloat(order.AvgPrice, 64); err != nil {
+		return nil, fmt.Errorf("failed to parse average price: %w", err)
+	}
+	return state, nil
+}
+
+// GetPositionState fetches the net position in a symbol (one-way mode)
+func (b *BinanceFuturesDataProvider) GetPositionState(symbol string) (*ExchangePositionState, error) {
+	binanceSymbol := b.convertSymbol(symbol)
+	params := url.Values{}
+	params.Set("symbol", binanceSymbol)
+
+	var positions []struct {
+		Symbol      string `json:"symbol"`
+		PositionAmt string `json:"positionAmt"`
+		EntryPrice  string `json:"entryPrice"`
+	}
+	if err := b.signedGetJSON("/fapi/v2/positionRisk", params, &positions); err != nil {
+		return nil, err
+	}
+
+	state := &ExchangePositionState{Symbol: binanceSymbol}
+	for _, position := range positions {
+		if position.Symbol != binanceSymbol {
+			continue
+		}
+		quantity, err := strconv.ParseFloat(position.PositionAmt, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse position amount: %w", err)
+		}
+		entry, err := strconv.ParseFloat(position.EntryPrice, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse entry price: %w", err)
+		}
+		state.Quantity += quantity
+		if quantity != 0 {
+			state.EntryPrice = entry
+		}
+	}
+	return state, nil
+}
+
+// GetBalances fetches the futures wallet balance per asset
+func (b *BinanceFuturesDataProvider) GetBalances() (map[string]float64, error) {
+	var assets []struct {
+		Asset   string `json:"asset"`
+		Balance string `json:"balance"`
+	}
+	if err := b.signedGetJSON("/fapi/v2/balance", url.Values{}, &assets); err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]float64)
+	for _, asset := range assets {
+		balance, err := strconv.ParseFloat(asset.Balance, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s balance: %w", asset.Asset, err)
+		}
+		if balance != 0 {
+			balances[asset.Asset] = balance
+		}
+	}
+	return balances, nil
+}
@@ -0,0 +1,189 @@
+package bot
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Binance markets live orders can be routed to
+const (
+	MarketFutures = "futures" // USDT-margined perpetuals
+	MarketSpot    = "spot"
+)
+
+// BinanceOrderClient places, cancels and polls orders on Binance USDT-M
+// futures or spot, signing with the data provider's API keys
+type BinanceOrderClient struct {
+	provider *BinanceFuturesDataProvider
+	market   string
+	baseURL  string
+	wsURL    string // User data streams connect to wsURL/<listen key>
+}
+
+// NewBinanceOrderClient creates an order client for the futures or spot market
+func NewBinanceOrderClient(provider *BinanceFuturesDataProvider, market string) *BinanceOrderClient {
+	baseURL, wsURL := provider.baseURL, provider.wsURL
+	if market == MarketSpot {
+		baseURL, wsURL = "https://api.binance.com", "wss://stream.binance.com:9443/ws"
+	}
+	return &BinanceOrderClient{provider: provider, market: market, baseURL: baseURL, wsURL: wsURL}
+}
+
+// orderPath is the market's order endpoint
+func (c *BinanceOrderClient) orderPath() string {
+	if c.market == MarketSpot {
+		return "/api/v3/order"
+	}
+	return "/fapi/v1/order"
+}
+
+// binanceOrderResponse covers the order fields futures and spot responses share
+type binanceOrderResponse struct {
+	OrderID             int64  `json:"orderId"`
+	Symbol              string `json:"symbol"`
+	Side                string `json:"side"`
+	Status              string `json:"status"`
+	OrigQty             string `json:"origQty"`
+	ExecutedQty         string `json:"executedQty"`
+	AvgPrice            string `json:"avgPrice"`            // Futures only
+	CummulativeQuoteQty string `json:"cummulativeQuoteQty"` // Spot only (sic)
+	UpdateTime          int64  `json:"updateTime"`
+	TransactTime        int64  `json:"transactTime"` // Spot placement responses
+}
+
+// state converts a response into an ExchangeOrderState
+func (r binanceOrderResponse) state() (*ExchangeOrderState, error) {
+	state := &ExchangeOrderState{
+		OrderID:    strconv.FormatInt(r.OrderID, 10),
+		Symbol:     r.Symbol,
+		Side:       r.Side,
+		Status:     r.Status,
+		UpdateTime: time.UnixMilli(r.UpdateTime),
+	}
+	if r.UpdateTime == 0 {
+		state.UpdateTime = time.UnixMilli(r.TransactTime)
+	}
+	values, err := parseFloats([]string{r.OrigQty, r.ExecutedQty}, "quantity", "executed quantity")
+	if err != nil {
+		return nil, err
+	}
+	state.Quantity, state.ExecutedQuantity = values[0], values[1]
+
+	// Spot reports the executed quote amount instead of an average price
+	switch {
+	case r.AvgPrice != "":
+		if state.AveragePrice, err = strconv.ParseFloat(r.AvgPrice, 64); err != nil {
+			return nil, fmt.Errorf("failed to parse average price: %w", err)
+		}
+	case r.CummulativeQuoteQty != "" && state.ExecutedQuantity > 0:
+		quote, err := strconv.ParseFloat(r.CummulativeQuoteQty, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse executed quote quantity: %w", err)
+		}
+		state.AveragePrice = quote / state.ExecutedQuantity
+	}
+	return state, nil
+}
+
+// PlaceOrder sends a MARKET, LIMIT (GTC) or STOP_MARKET order and returns its
+// state right after matching
+func (c *BinanceOrderClient) PlaceOrder(req OrderRequest) (*ExchangeOrderState, error) {
+	params := url.Values{}
+	params.Set("symbol", c.provider.convertSymbol(req.Symbol))
+	params.Set("side", req.Side)
+	params.Set("type", req.Type)
+	params.Set("quantity", strconv.FormatFloat(req.Quantity, 'f', -1, 64))
+	params.Set("newOrderRespType", "RESULT") // Include fills of immediately matched orders
+	if req.ClientID != "" {
+		params.Set("newClientOrderId", req.ClientID)
+	}
+
+	switch req.Type {
+	case "MARKET":
+	case "LIMIT":
+		params.Set("price", strconv.FormatFloat(req.Price, 'f', -1, 64))
+		params.Set("timeInForce", "GTC")
+	case "STOP_MARKET":
+		if c.market == MarketSpot {
+			return nil, fmt.Errorf("STOP_MARKET orders are only supported on futures")
+		}
+		params.Set("stopPrice", strconv.FormatFloat(req.StopPrice, 'f', -1, 64))
+	default:
+		return nil, fmt.Errorf("unsupported order type %s", req.Type)
+	}
+	if req.ReduceOnly && c.market == MarketFutures {
+		params.Set("reduceOnly", "true")
+	}
+
+	var response binanceOrderResponse
+	if err := c.provider.signedRequestJSON(http.MethodPost, c.baseURL, c.orderPath(), params, &response); err != nil {
+		return nil, err
+	}
+	return response.state()
+}
+
+// CancelOrder cancels a working order
+func (c *BinanceOrderClient) CancelOrder(symbol, orderID string) error {
+	params := url.Values{}
+	params.Set("symbol", c.provider.convertSymbol(symbol))
+	params.Set("orderId", orderID)
+
+	var response binanceOrderResponse
+	return c.provider.signedRequestJSON(http.MethodDelete, c.baseURL, c.orderPath(), params, &response)
+}
+
+// GetOrderState fetches an order's status and cumulative fills
+func (c *BinanceOrderClient) GetOrderState(symbol, orderID string) (*ExchangeOrderState, error) {
+	if c.market == MarketFutures {
+		return c.provider.GetOrderState(symbol, orderID)
+	}
+
+	params := url.Values{}
+	params.Set("symbol", c.provider.convertSymbol(symbol))
+	params.Set("orderId", orderID)
+	var response binanceOrderResponse
+	if err := c.provider.signedRequestJSON(http.MethodGet, c.baseURL, c.orderPath(), params, &response); err != nil {
+		return nil, err
+	}
+	return response.state()
+}
+
+// GetPositionState returns the futures position, or on spot the base asset
+// held (free and locked) as a long position without an entry price
+func (c *BinanceOrderClient) GetPositionState(symbol string) (*ExchangePositionState, error) {
+	if c.market == MarketFutures {
+		return c.provider.GetPositionState(symbol)
+	}
+
+	base, _, err := SplitSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+	var account struct {
+		Balances []struct {
+			Asset  string `json:"asset"`
+			Free   string `json:"free"`
+			Locked string `json:"locked"`
+		} `json:"balances"`
+	}
+	if err := c.provider.signedRequestJSON(http.MethodGet, c.baseURL, "/api/v3/account", url.Values{}, &account); err != nil {
+		return nil, err
+	}
+
+	state := &ExchangePositionState{Symbol: c.provider.convertSymbol(symbol)}
+	for _, balance := range account.Balances {
+		if !strings.EqualFold(balance.Asset, base) {
+			continue
+		}
+		amounts, err := parseFloats([]string{balance.Free, balance.Locked}, "free", "locked")
+		if err != nil {
+			return nil, err
+		}
+		state.Quantity = amounts[0] + amounts[1]
+	}
+	return state, nil
+}
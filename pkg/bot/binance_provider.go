@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -18,6 +19,7 @@ type BinanceFuturesDataProvider struct {
 	baseURL    string
 	apiKey     string
 	secretKey  string
+	keyMutex   sync.RWMutex
 	httpClient *http.Client
 	wsConn     *websocket.Conn
 	wsURL      string
@@ -69,8 +71,55 @@ func NewBinanceFuturesDataProvider(apiKey, secretKey string) *BinanceFuturesData
 	}
 }
 
+// SetCredentials swaps the API keys and drops pooled connections so the next
+// request starts a fresh session; streams and stored data are left running
+func (b *BinanceFuturesDataProvider) SetCredentials(apiKey, secretKey string) {
+	b.keyMutex.Lock()
+	b.apiKey = apiKey
+	b.secretKey = secretKey
+	b.keyMutex.Unlock()
+	b.httpClient.CloseIdleConnections()
+}
+
+// Credentials returns the API keys currently in use
+func (b *BinanceFuturesDataProvider) Credentials() (apiKey, secretKey string) {
+	b.keyMutex.RLock()
+	defer b.keyMutex.RUnlock()
+	return b.apiKey, b.secretKey
+}
+
 // GetHistoricalData fetches historical kline data from Binance Futures API
 func (b *BinanceFuturesDataProvider) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	params := url.Values{}
+	params.Add("limit", strconv.Itoa(count))
+	return b.fetchKlines(symbol, timeframe, params)
+}
+
+// GetHistoricalRange fetches all klines opening in [start, end), paging past the 1500-kline limit
+func (b *BinanceFuturesDataProvider) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	candles := make([]Candle, 0)
+	cursor := start
+	for cursor.Before(end) {
+		params := url.Values{}
+		params.Add("startTime", strconv.FormatInt(cursor.UnixMilli(), 10))
+		params.Add("endTime", strconv.FormatInt(end.UnixMilli()-1, 10))
+		params.Add("limit", "1500")
+
+		page, err := b.fetchKlines(symbol, timeframe, params)
+		if err != nil {
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		candles = append(candles, page...)
+		cursor = page[len(page)-1].Timestamp.Add(timeframe.Duration())
+	}
+	return candles, nil
+}
+
+// fetchKlines requests /fapi/v1/klines with the given extra parameters
+func (b *BinanceFuturesDataProvider) fetchKlines(symbol string, timeframe Timeframe, params url.Values) ([]Candle, error) {
 	// Convert symbol to Binance format (e.g., BTCUSD -> BTCUSDT)
 	binanceSymbol := b.convertSymbol(symbol)
 
@@ -79,10 +128,8 @@ func (b *BinanceFuturesDataProvider) GetHistoricalData(symbol string, timeframe
 
 	// Build URL
 	endpoint := fmt.Sprintf("%s/fapi/v1/klines", b.baseURL)
-	params := url.Values{}
 	params.Add("symbol", binanceSymbol)
 	params.Add("interval", interval)
-	params.Add("limit", strconv.Itoa(count))
 
 	// Make HTTP request
 	req, err := http.NewRequest("GET", endpoint+"?"+params.Encode(), nil)
@@ -148,7 +195,7 @@ func (b *BinanceFuturesDataProvider) GetRealTimeData(symbol string, timeframe Ti
 		wsURL := fmt.Sprintf("%s/%s", b.wsURL, streamName)
 		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
 		if err != nil {
-			fmt.Printf("WebSocket connection failed: %v\n", err)
+			providerLog.Warn("WebSocket connection failed", "error", err)
 			return
 		}
 		defer conn.Close()
@@ -164,14 +211,14 @@ func (b *BinanceFuturesDataProvider) GetRealTimeData(symbol string, timeframe Ti
 				// Read message
 				_, message, err := conn.ReadMessage()
 				if err != nil {
-					fmt.Printf("WebSocket read error: %v\n", err)
+					providerLog.Warn("WebSocket read error", "error", err)
 					return
 				}
 
 				// Parse message
 				var wsMsg BinanceWSMessage
 				if err := json.Unmarshal(message, &wsMsg); err != nil {
-					fmt.Printf("Failed to parse WebSocket message: %v\n", err)
+					providerLog.Warn("Failed to parse WebSocket message", "error", err)
 					continue
 				}
 
@@ -183,7 +230,7 @@ func (b *BinanceFuturesDataProvider) GetRealTimeData(symbol string, timeframe Ti
 				// Convert to Candle
 				candle, err := b.convertWSKlineToCandle(wsMsg.Data.Kline, binanceSymbol)
 				if err != nil {
-					fmt.Printf("Failed to convert WebSocket kline: %v\n", err)
+					providerLog.Warn("Failed to convert WebSocket kline", "error", err)
 					continue
 				}
 
@@ -251,6 +298,197 @@ func (b *BinanceFuturesDataProvider) GetCurrentPrice(symbol string) (float64, er
 	return price, nil
 }
 
+// GetMarkPrice fetches the exchange mark price, which is smoothed across venues
+// and resistant to single prints on a thin book
+func (b *BinanceFuturesDataProvider) GetMarkPrice(symbol string) (float64, error) {
+	params := url.Values{}
+	params.Add("symbol", b.convertSymbol(symbol))
+
+	var premiumResp struct {
+		MarkPrice string `json:"markPrice"`
+	}
+	if err := b.getJSON("/fapi/v1/premiumIndex", params, &premiumResp); err != nil {
+		return 0, err
+	}
+
+	price, err := strconv.ParseFloat(premiumResp.MarkPrice, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse mark price: %w", err)
+	}
+	return price, nil
+}
+
+// GetMidPrice fetches the midpoint of the best bid and ask
+func (b *BinanceFuturesDataProvider) GetMidPrice(symbol string) (float64, error) {
+	params := url.Values{}
+	params.Add("symbol", b.convertSymbol(symbol))
+
+	var bookResp struct {
+		BidPrice string `json:"bidPrice"`
+		AskPrice string `json:"askPrice"`
+	}
+	if err := b.getJSON("/fapi/v1/ticker/bookTicker", params, &bookResp); err != nil {
+		return 0, err
+	}
+
+	bid, err := strconv.ParseFloat(bookResp.BidPrice, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+	ask, err := strconv.ParseFloat(bookResp.AskPrice, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+	if bid <= 0 || ask <= 0 {
+		return 0, fmt.Errorf("empty order book for %s", symbol)
+	}
+	return (bid + ask) / 2, nil
+}
+
+// GetPrice fetches the price for a symbol using the given source ("last", "mark" or "mid")
+func (b *BinanceFuturesDataProvider) GetPrice(symbol, source string) (float64, error) {
+	switch source {
+	case PriceSourceMark:
+		return b.GetMarkPrice(symbol)
+	case PriceSourceMid:
+		return b.GetMidPrice(symbol)
+	default:
+		return b.GetCurrentPrice(symbol)
+	}
+}
+
+// getJSON performs a GET request against the REST API and decodes the response
+func (b *BinanceFuturesDataProvider) getJSON(path string, params url.Values, out interface{}) error {
+	resp, err := b.httpClient.Get(b.baseURL + path + "?" + params.Encode())
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// GetSymbolFilters fetches lot size, tick size and min notional rules from exchangeInfo
+func (b *BinanceFuturesDataProvider) GetSymbolFilters(symbol string) (*SymbolFilters, error) {
+	binanceSymbol := b.convertSymbol(symbol)
+
+	var exchangeInfo struct {
+		Symbols []struct {
+			Symbol  string                   `json:"symbol"`
+			Filters []map[string]interface{} `json:"filters"`
+		} `json:"symbols"`
+	}
+	if err := b.getExchangeInfo(&exchangeInfo); err != nil {
+		return nil, err
+	}
+
+	for _, info := range exchangeInfo.Symbols {
+		if info.Symbol != binanceSymbol {
+			continue
+		}
+
+		filters := &SymbolFilters{Symbol: binanceSymbol}
+		for _, filter := range info.Filters {
+			switch filter["filterType"] {
+			case "PRICE_FILTER":
+				filters.TickSize = parseFilterValue(filter, "tickSize")
+			case "LOT_SIZE":
+				filters.StepSize = parseFilterValue(filter, "stepSize")
+				filters.MinQty = parseFilterValue(filter, "minQty")
+				filters.MaxQty = parseFilterValue(filter, "maxQty")
+			case "MIN_NOTIONAL":
+				// Futures use "notional", spot uses "minNotional"
+				filters.MinNotional = parseFilterValue(filter, "notional")
+				if filters.MinNotional == 0 {
+					filters.MinNotional = parseFilterValue(filter, "minNotional")
+				}
+			}
+		}
+		return filters, nil
+	}
+
+	return nil, fmt.Errorf("symbol %s not found in exchange info", binanceSymbol)
+}
+
+// ListSymbols returns the futures symbols currently trading
+func (b *BinanceFuturesDataProvider) ListSymbols() ([]string, error) {
+	var exchangeInfo struct {
+		Symbols []struct {
+			Symbol string `json:"symbol"`
+			Status string `json:"status"`
+		} `json:"symbols"`
+	}
+	if err := b.getExchangeInfo(&exchangeInfo); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(exchangeInfo.Symbols))
+	for _, info := range exchangeInfo.Symbols {
+		if info.Status == "" || info.Status == "TRADING" {
+			symbols = append(symbols, info.Symbol)
+		}
+	}
+	return symbols, nil
+}
+
+// QuoteVolumes returns each futures symbol's 24h volume in its quote currency
+func (b *BinanceFuturesDataProvider) QuoteVolumes() (map[string]float64, error) {
+	var stats []struct {
+		Symbol      string `json:"symbol"`
+		QuoteVolume string `json:"quoteVolume"`
+	}
+	if err := b.getJSON("/fapi/v1/ticker/24hr", url.Values{}, &stats); err != nil {
+		return nil, err
+	}
+
+	volumes := make(map[string]float64, len(stats))
+	for _, stat := range stats {
+		if volume, err := strconv.ParseFloat(stat.QuoteVolume, 64); err == nil {
+			volumes[stat.Symbol] = volume
+		}
+	}
+	return volumes, nil
+}
+
+// getExchangeInfo fetches and decodes /fapi/v1/exchangeInfo
+func (b *BinanceFuturesDataProvider) getExchangeInfo(out interface{}) error {
+	resp, err := b.httpClient.Get(fmt.Sprintf("%s/fapi/v1/exchangeInfo", b.baseURL))
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// parseFilterValue reads a numeric string field from an exchangeInfo filter
+func parseFilterValue(filter map[string]interface{}, key string) float64 {
+	str, ok := filter[key].(string)
+	if !ok {
+		return 0
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
 // Close closes the data provider connection
 func (b *BinanceFuturesDataProvider) Close() error {
 	if b.running {
@@ -390,3 +628,140 @@ func (b *BinanceFuturesDataProvider) convertWSKlineToCandle(kline struct {
 		Volume:    volume,
 	}, nil
 }
+
+// BinanceRESTPollingProvider serves real-time candles by polling the klines REST
+// endpoint instead of holding a WebSocket open. Useful for slow timeframes (8h, 1d)
+// where a long-lived stream buys little.
+type BinanceRESTPollingProvider struct {
+	*BinanceFuturesDataProvider
+	pollInterval time.Duration
+	stopChan     chan struct{}
+	stopped      bool
+}
+
+// NewBinanceRESTPollingProvider creates a REST polling provider on top of the futures API
+func NewBinanceRESTPollingProvider(apiKey, secretKey string, pollInterval time.Duration) *BinanceRESTPollingProvider {
+	return &BinanceRESTPollingProvider{
+		BinanceFuturesDataProvider: NewBinanceFuturesDataProvider(apiKey, secretKey),
+		pollInterval:               pollInterval,
+		stopChan:                   make(chan struct{}),
+	}
+}
+
+// GetRealTimeData polls the latest completed kline and emits it when it changes
+func (p *BinanceRESTPollingProvider) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	candleChan := make(chan Candle, 10)
+
+	go func() {
+		defer close(candleChan)
+
+		ticker := time.NewTicker(p.pollInterval)
+		defer ticker.Stop()
+
+		var lastEmitted time.Time
+		for {
+			select {
+			case <-p.stopChan:
+				return
+			case <-ticker.C:
+				// Fetch the last two klines: the final one is still forming
+				candles, err := p.GetHistoricalData(symbol, timeframe, 2)
+				if err != nil || len(candles) < 2 {
+					providerLog.Warn("REST poll failed", "symbol", symbol, "timeframe", timeframe.String(), "error", err)
+					continue
+				}
+
+				completed := candles[len(candles)-2]
+				if !completed.Timestamp.After(lastEmitted) {
+					continue
+				}
+				lastEmitted = completed.Timestamp
+
+				select {
+				case candleChan <- completed:
+				case <-p.stopChan:
+					return
+				}
+			}
+		}
+	}()
+
+	return candleChan, nil
+}
+
+// Close stops all polling loops
+func (p *BinanceRESTPollingProvider) Close() error {
+	if !p.stopped {
+		close(p.stopChan)
+		p.stopped = true
+	}
+	return p.BinanceFuturesDataProvider.Close()
+}
+
+// Name returns the venue name
+func (b *BinanceFuturesDataProvider) Name() string {
+	return "binance"
+}
+
+// GetTicker fetches the last price, 24h volume and top of book
+func (b *BinanceFuturesDataProvider) GetTicker(symbol string) (*Ticker, error) {
+	params := url.Values{}
+	params.Add("symbol", b.convertSymbol(symbol))
+
+	var statsResp struct {
+		LastPrice string `json:"lastPrice"`
+		Volume    string `json:"volume"`
+	}
+	if err := b.getJSON("/fapi/v1/ticker/24hr", params, &statsResp); err != nil {
+		return nil, err
+	}
+	var bookResp struct {
+		BidPrice string `json:"bidPrice"`
+		AskPrice string `json:"askPrice"`
+	}
+	if err := b.getJSON("/fapi/v1/ticker/bookTicker", params, &bookResp); err != nil {
+		return nil, err
+	}
+
+	values, err := parseFloats([]string{statsResp.LastPrice, bookResp.BidPrice, bookResp.AskPrice, statsResp.Volume}, "price", "bid", "ask", "volume")
+	if err != nil {
+		return nil, err
+	}
+	return &Ticker{Symbol: symbol, Last: values[0], Bid: values[1], Ask: values[2], Volume: values[3], Timestamp: time.Now()}, nil
+}
+
+// binanceDepthLimits are the order book sizes /fapi/v1/depth accepts
+var binanceDepthLimits = []int{5, 10, 20, 50, 100, 500, 1000}
+
+// GetOrderBook fetches depth levels per side of the order book
+func (b *BinanceFuturesDataProvider) GetOrderBook(symbol string, depth int) (*OrderBook, error) {
+	// Request the smallest accepted size covering depth
+	limit := binanceDepthLimits[len(binanceDepthLimits)-1]
+	for _, accepted := range binanceDepthLimits {
+		if accepted >= depth {
+			limit = accepted
+			break
+		}
+	}
+	params := url.Values{}
+	params.Add("symbol", b.convertSymbol(symbol))
+	params.Add("limit", strconv.Itoa(limit))
+
+	var depthResp struct {
+		Bids [][]interface{} `json:"bids"` // [price, quantity]
+		Asks [][]interface{} `json:"asks"`
+	}
+	if err := b.getJSON("/fapi/v1/depth", params, &depthResp); err != nil {
+		return nil, err
+	}
+
+	var err error
+	book := &OrderBook{Symbol: symbol, Timestamp: time.Now()}
+	if book.Bids, err = parseBookLevels(depthResp.Bids, depth); err != nil {
+		return nil, err
+	}
+	if book.Asks, err = parseBookLevels(depthResp.Asks, depth); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
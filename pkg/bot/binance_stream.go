@@ -0,0 +1,304 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// StreamStatus reports the health of the kline stream
+type StreamStatus struct {
+	Connected     bool      `json:"connected"`
+	LastMessage   time.Time `json:"last_message"`
+	LastPrice     float64   `json:"last_price"` // From the ticker stream
+	Reconnects    int       `json:"reconnects"`
+	RESTFallbacks int       `json:"rest_fallbacks"` // REST refreshes after a disconnect
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+// BinanceKlineStream keeps a TimeframeManager current from one combined Binance
+// WebSocket carrying every timeframe's klines (forming candles included) and the
+// symbol's ticker. When the connection drops or goes quiet the latest candles are
+// refreshed over REST and the stream reconnects with exponential backoff.
+type BinanceKlineStream struct {
+	provider   *BinanceFuturesDataProvider
+	symbol     string
+	intervals  map[string]Timeframe // Binance interval -> timeframe it feeds
+	tm         *TimeframeManager
+	staleAfter time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	builders   map[Timeframe]*CandleBuilder // Aligned to the streamed open times
+
+	mutex    sync.RWMutex
+	conn     *websocket.Conn
+	status   StreamStatus
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewBinanceKlineStream creates a stream feeding timeframes of symbol into tm
+func NewBinanceKlineStream(provider *BinanceFuturesDataProvider, symbol string, timeframes []Timeframe, tm *TimeframeManager, config StreamingConfig) *BinanceKlineStream {
+	intervals := make(map[string]Timeframe, len(timeframes))
+	builders := make(map[Timeframe]*CandleBuilder, len(timeframes))
+	for _, timeframe := range timeframes {
+		intervals[provider.convertTimeframe(timeframe)] = timeframe
+		builders[timeframe] = NewCandleBuilder(timeframe, tm.clock)
+	}
+	return &BinanceKlineStream{
+		provider:   provider,
+		symbol:     symbol,
+		intervals:  intervals,
+		tm:         tm,
+		staleAfter: time.Duration(config.StaleSeconds) * time.Second,
+		minBackoff: time.Second,
+		maxBackoff: time.Duration(config.MaxBackoffSeconds) * time.Second,
+		builders:   builders,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start connects in the background; it keeps reconnecting until Stop
+func (s *BinanceKlineStream) Start() {
+	go s.run()
+}
+
+// Stop closes the connection and ends reconnection
+func (s *BinanceKlineStream) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+		s.mutex.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.mutex.Unlock()
+	})
+}
+
+// Reconnect drops the current connection; the stream refreshes over REST and redials
+func (s *BinanceKlineStream) Reconnect() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+// Status returns a snapshot of the stream's health
+func (s *BinanceKlineStream) Status() StreamStatus {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.status
+}
+
+// Covers reports whether timeframe is fed by the stream
+func (s *BinanceKlineStream) Covers(timeframe Timeframe) bool {
+	streamed, ok := s.intervals[s.provider.convertTimeframe(timeframe)]
+	return ok && streamed == timeframe
+}
+
+// Healthy reports whether the stream is connected and has pushed data within the stale timeout
+func (s *BinanceKlineStream) Healthy() bool {
+	status := s.Status()
+	return status.Connected && time.Since(status.LastMessage) < s.staleAfter
+}
+
+// LastPrice returns the streamed last trade price while the stream is healthy
+func (s *BinanceKlineStream) LastPrice() (float64, bool) {
+	status := s.Status()
+	return status.LastPrice, s.Healthy() && status.LastPrice > 0
+}
+
+// streamURL is the combined stream of every interval's klines and the ticker
+func (s *BinanceKlineStream) streamURL() string {
+	base := strings.ToLower(s.provider.convertSymbol(s.symbol))
+	streams := make([]string, 0, len(s.intervals)+1)
+	for _, interval := range sortedKeys(s.intervals) {
+		streams = append(streams, base+"@kline_"+interval)
+	}
+	streams = append(streams, base+"@ticker")
+	return strings.TrimSuffix(s.provider.wsURL, "/ws") + "/stream?streams=" + strings.Join(streams, "/")
+}
+
+// run holds the connection open, falling back to REST and backing off between attempts
+func (s *BinanceKlineStream) run() {
+	backoff := s.minBackoff
+	for {
+		received, err := s.connectAndRead()
+		select {
+		case <-s.stopChan:
+			return
+		default:
+		}
+
+		s.mutex.Lock()
+		s.status.Connected = false
+		s.status.LastError = err.Error()
+		s.mutex.Unlock()
+		providerLog.Warn("Kline stream disconnected, refreshing over REST", "symbol", s.symbol, "error", err)
+		s.refreshOverREST()
+
+		// A connection that delivered data starts the backoff over
+		if received {
+			backoff = s.minBackoff
+		}
+		select {
+		case <-s.stopChan:
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+
+		s.mutex.Lock()
+		s.status.Reconnects++
+		s.mutex.Unlock()
+	}
+}
+
+// connectAndRead reads messages until the connection fails or stays silent past
+// the stale timeout, reporting whether any message arrived
+func (s *BinanceKlineStream) connectAndRead() (bool, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(s.streamURL(), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	s.mutex.Lock()
+	s.conn = conn
+	s.status.Connected = true
+	s.mutex.Unlock()
+	select {
+	case <-s.stopChan:
+		return false, fmt.Errorf("stream stopped")
+	default:
+	}
+	providerLog.Info("📡 Kline stream connected", "symbol", s.symbol)
+
+	done := make(chan struct{})
+	defer close(done)
+	go s.heartbeat(conn, done)
+
+	received := false
+	for {
+		conn.SetReadDeadline(time.Now().Add(s.staleAfter))
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return received, fmt.Errorf("read failed: %w", err)
+		}
+		received = true
+		if err := s.handleMessage(message); err != nil {
+			providerLog.Warn("Skipping kline stream message", "symbol", s.symbol, "error", err)
+		}
+	}
+}
+
+// heartbeat pings the server a few times per stale timeout; a failed ping closes
+// the connection so a dead socket is noticed without waiting for the read deadline
+func (s *BinanceKlineStream) heartbeat(conn *websocket.Conn, done <-chan struct{}) {
+	if s.staleAfter <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.staleAfter / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+				providerLog.Warn("Kline stream heartbeat failed", "symbol", s.symbol, "error", err)
+				conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// handleMessage applies a kline to its timeframe or records the ticker price
+func (s *BinanceKlineStream) handleMessage(message []byte) error {
+	var msg BinanceWSMessage
+	if err := json.Unmarshal(message, &msg); err != nil {
+		return fmt.Errorf("failed to parse message: %w", err)
+	}
+
+	var price float64
+	if strings.HasSuffix(msg.Stream, "@ticker") {
+		var ticker struct {
+			Data struct {
+				LastPrice string `json:"c"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(message, &ticker); err != nil {
+			return fmt.Errorf("failed to parse ticker: %w", err)
+		}
+		parsed, err := strconv.ParseFloat(ticker.Data.LastPrice, 64)
+		if err != nil {
+			return fmt.Errorf("invalid ticker price: %w", err)
+		}
+		price = parsed
+	} else if timeframe, ok := s.intervals[msg.Data.Kline.Interval]; ok {
+		candle, err := s.provider.convertWSKlineToCandle(msg.Data.Kline, s.symbol)
+		if err != nil {
+			return err
+		}
+		s.applyKline(timeframe, candle)
+		s.tm.SetFallback(timeframe, false)
+	} else {
+		return fmt.Errorf("unexpected stream %q", msg.Stream)
+	}
+
+	s.mutex.Lock()
+	s.status.LastMessage = time.Now()
+	if price > 0 {
+		s.status.LastPrice = price
+	}
+	s.mutex.Unlock()
+	return nil
+}
+
+// refreshOverREST fetches the latest candles of every streamed timeframe so
+// nothing is missed while disconnected
+func (s *BinanceKlineStream) refreshOverREST() {
+	for _, interval := range sortedKeys(s.intervals) {
+		timeframe := s.intervals[interval]
+		candles, err := s.provider.GetHistoricalData(s.symbol, timeframe, 3)
+		if err != nil {
+			providerLog.Warn("REST fallback failed", "symbol", s.symbol, "timeframe", timeframe.String(), "error", err)
+			continue
+		}
+		for _, candle := range candles {
+			s.applyKline(timeframe, candle)
+		}
+		s.tm.SetFallback(timeframe, true)
+	}
+
+	s.mutex.Lock()
+	s.status.RESTFallbacks++
+	s.mutex.Unlock()
+}
+
+// applyKline passes a streamed or REST kline through the timeframe's candle
+// builder, then stores the candles it closed followed by the kline itself.
+// REST klines go through it too, so a stale streamed candle never overwrites
+// one refreshed while disconnected.
+func (s *BinanceKlineStream) applyKline(timeframe Timeframe, kline Candle) {
+	builder := s.builders[timeframe]
+	builder.AddKline(kline)
+	forming := true
+	for completed := builder.GetCompletedCandle(); completed != nil; completed = builder.GetCompletedCandle() {
+		s.tm.AddCandle(timeframe, *completed)
+		forming = forming && !completed.Timestamp.Equal(kline.Timestamp)
+	}
+	if forming {
+		s.tm.AddCandle(timeframe, kline)
+	}
+}
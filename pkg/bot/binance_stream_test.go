@@ -0,0 +1,163 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestBinanceKlineStream(t *testing.T) {
+	t.Log("📡 Testing the kline WebSocket stream with REST fallback and reconnect")
+
+	open := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	klineMessage := func(openTime time.Time, close string, closed bool) map[string]interface{} {
+		return map[string]interface{}{
+			"stream": "btcusdt@kline_5m",
+			"data": map[string]interface{}{"e": "kline", "k": map[string]interface{}{
+				"t": openTime.UnixMilli(), "i": "5m", "o": "100", "h": "105", "l": "99", "c": close, "v": "10", "x": closed,
+			}},
+		}
+	}
+
+	var connections, restCalls int32
+	upgrader := websocket.Upgrader{}
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stream":
+			if streams := r.URL.Query().Get("streams"); !strings.Contains(streams, "btcusdt@kline_5m") || !strings.Contains(streams, "btcusdt@ticker") {
+				t.Errorf("Unexpected streams %q", streams)
+			}
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			if atomic.AddInt32(&connections, 1) == 1 {
+				// Forming candle, its update, then the ticker; the connection then drops
+				conn.WriteJSON(klineMessage(open, "101", false))
+				conn.WriteJSON(klineMessage(open, "103", false))
+				conn.WriteJSON(map[string]interface{}{"stream": "btcusdt@ticker", "data": map[string]string{"e": "24hrTicker", "c": "103.5"}})
+				<-release
+				return
+			}
+			conn.WriteJSON(klineMessage(open.Add(10*time.Minute), "107", false))
+			conn.ReadMessage() // Hold the connection until the client closes it
+		case "/fapi/v1/klines":
+			// The REST fallback fills the candle that closed while disconnected
+			atomic.AddInt32(&restCalls, 1)
+			rows := make([][]interface{}, 0)
+			for _, ts := range []time.Time{open, open.Add(5 * time.Minute)} {
+				rows = append(rows, []interface{}{ts.UnixMilli(), "100", "106", "99", "104", "12", ts.Add(5*time.Minute).UnixMilli() - 1, "0", 1, "0", "0", "0"})
+			}
+			json.NewEncoder(w).Encode(rows)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewBinanceFuturesDataProvider("", "")
+	provider.baseURL = server.URL
+	provider.wsURL = "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+	tm := NewTimeframeManager("BTCUSDT")
+	stream := NewBinanceKlineStream(provider, "BTCUSDT", []Timeframe{FiveMinute}, tm, StreamingConfig{Enabled: true, StaleSeconds: 5, MaxBackoffSeconds: 1})
+	stream.minBackoff = 10 * time.Millisecond
+	stream.Start()
+	defer stream.Stop()
+
+	waitFor := func(description string, done func() bool) {
+		deadline := time.Now().Add(5 * time.Second)
+		for !done() {
+			if time.Now().After(deadline) {
+				t.Fatalf("Timed out waiting for %s (status %+v)", description, stream.Status())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	latest := func() Candle {
+		candles, _ := tm.GetCandles(FiveMinute)
+		if len(candles) == 0 {
+			return Candle{}
+		}
+		return candles[len(candles)-1]
+	}
+
+	// Forming candles update in place and the ticker supplies the price
+	waitFor("the ticker", func() bool { _, ok := stream.LastPrice(); return ok })
+	if candles, _ := tm.GetCandles(FiveMinute); len(candles) != 1 || candles[0].Close != 103 {
+		t.Errorf("Expected one forming candle closing at 103, got %+v", candles)
+	}
+	if price, _ := stream.LastPrice(); price != 103.5 || !stream.Healthy() {
+		t.Errorf("Expected a healthy stream at 103.5, got %v (status %+v)", price, stream.Status())
+	}
+
+	// A drop falls back to REST, then the stream reconnects and keeps appending
+	close(release)
+	waitFor("the reconnected candle", func() bool { return latest().Timestamp.Equal(open.Add(10 * time.Minute)) })
+	candles, _ := tm.GetCandles(FiveMinute)
+	if len(candles) != 3 || candles[1].Close != 104 || candles[2].Close != 107 {
+		t.Errorf("Expected REST to fill the gap before the streamed candle, got %+v", candles)
+	}
+	status := stream.Status()
+	if status.Reconnects != 1 || status.RESTFallbacks != 1 || atomic.LoadInt32(&restCalls) != 1 || !status.Connected {
+		t.Errorf("Expected one reconnect after one REST fallback, got %+v (%d REST calls)", status, restCalls)
+	}
+
+	// Stopping closes the connection for good
+	stream.Stop()
+	time.Sleep(50 * time.Millisecond)
+	if n := atomic.LoadInt32(&connections); n != 2 {
+		t.Errorf("Expected no reconnect after Stop, got %d connections", n)
+	}
+
+	// Streaming settings are validated
+	config := DefaultConfig()
+	config.Streaming.StaleSeconds = 1
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "streaming.stale_seconds") {
+		t.Errorf("Expected a too-short stale timeout to be rejected, got %v", err)
+	}
+}
+
+func TestBinanceKlineStreamBuilders(t *testing.T) {
+	t.Log("📡 Testing that streamed and REST klines go through the candle builders")
+
+	open := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	klineMessage := func(openTime time.Time, close string) []byte {
+		message, _ := json.Marshal(map[string]interface{}{
+			"stream": "btcusdt@kline_5m",
+			"data": map[string]interface{}{"e": "kline", "k": map[string]interface{}{
+				"t": openTime.UnixMilli(), "i": "5m", "o": "100", "h": "105", "l": "99", "c": close, "v": "10", "x": false,
+			}},
+		})
+		return message
+	}
+
+	tm := NewTimeframeManager("BTCUSDT")
+	tm.SetClock(NewSimClock(open.Add(time.Minute)))
+	stream := NewBinanceKlineStream(NewBinanceFuturesDataProvider("", ""), "BTCUSDT", []Timeframe{FiveMinute}, tm, DefaultConfig().Streaming)
+	if err := stream.handleMessage(klineMessage(open, "101")); err != nil {
+		t.Fatalf("handleMessage failed: %v", err)
+	}
+	if !stream.builders[FiveMinute].anchor.Equal(open) {
+		t.Errorf("Expected the builder anchored at the kline open, got %v", stream.builders[FiveMinute].anchor)
+	}
+
+	// A candle refreshed over REST while disconnected isn't overwritten by the
+	// stale streamed one when the next candle arrives
+	stream.applyKline(FiveMinute, Candle{Timestamp: open, Open: 100, High: 106, Low: 99, Close: 104, Volume: 12})
+	if err := stream.handleMessage(klineMessage(open.Add(5*time.Minute), "105")); err != nil {
+		t.Fatalf("handleMessage failed: %v", err)
+	}
+	candles, _ := tm.GetCandles(FiveMinute)
+	if len(candles) != 2 || candles[0].Close != 104 || candles[1].Close != 105 {
+		t.Errorf("Expected the refreshed 12:00 candle and the streamed 12:05 one, got %+v", candles)
+	}
+}
@@ -0,0 +1,473 @@
+package bot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// errListenKeyExpired ends a user data stream whose listen key Binance expired
+var errListenKeyExpired = errors.New("listen key expired")
+
+// Binance expires a listen key 60 minutes after its last keepalive
+const (
+	listenKeyKeepalive = 30 * time.Minute
+	userStreamPing     = time.Minute // WebSocket pings; a connection silent for two intervals is dropped
+)
+
+// UserStreamStatus reports the health of the user data stream
+type UserStreamStatus struct {
+	Connected      bool               `json:"connected"`
+	LastEvent      time.Time          `json:"last_event"`
+	OrderUpdates   int                `json:"order_updates"` // Order events applied to tracked orders
+	BalanceUpdates int                `json:"balance_updates"`
+	Balances       map[string]float64 `json:"balances,omitempty"` // Latest exchange balance per asset
+	Reconnects     int                `json:"reconnects"`
+	Renewals       int                `json:"renewals"` // Listen keys replaced after expiring or failing keepalive
+	LastKeepalive  time.Time          `json:"last_keepalive"`
+	LastError      string             `json:"last_error,omitempty"`
+}
+
+// BinanceUserStream applies the account's order and balance events from the
+// Binance user data stream as they happen, so fills update the position
+// without waiting for the next reconciliation poll. It keeps its listen key
+// alive, replaces it when Binance expires it, and reconnects with exponential
+// backoff, resyncing through the onReconnect hook whatever was missed.
+type BinanceUserStream struct {
+	client      *BinanceOrderClient
+	executor    *TradeExecutor
+	onReconnect func() // Called after reconnecting; nil to skip
+	keepalive   time.Duration
+	ping        time.Duration
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+
+	mutex     sync.RWMutex
+	conn      *websocket.Conn
+	listenKey string // Empty when a new one has to be created
+	status    UserStreamStatus
+	stopChan  chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewBinanceUserStream creates a stream feeding the client's account events into executor
+func NewBinanceUserStream(client *BinanceOrderClient, executor *TradeExecutor) *BinanceUserStream {
+	return &BinanceUserStream{
+		client:     client,
+		executor:   executor,
+		keepalive:  listenKeyKeepalive,
+		ping:       userStreamPing,
+		minBackoff: time.Second,
+		maxBackoff: time.Minute,
+		status:     UserStreamStatus{Balances: make(map[string]float64)},
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// OnReconnect sets a hook run after every reconnect, e.g. a reconciliation
+// pass to pick up fills pushed while disconnected. Call before Start.
+func (s *BinanceUserStream) OnReconnect(fn func()) {
+	s.onReconnect = fn
+}
+
+// Start connects in the background; it keeps reconnecting until Stop
+func (s *BinanceUserStream) Start() {
+	go s.run()
+}
+
+// Stop closes the connection and its listen key
+func (s *BinanceUserStream) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+		s.mutex.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		listenKey := s.listenKey
+		s.listenKey = ""
+		s.mutex.Unlock()
+
+		if listenKey != "" {
+			if err := s.client.CloseListenKey(listenKey); err != nil {
+				executorLog.Warn("Failed to close listen key", "error", err)
+			}
+		}
+	})
+}
+
+// Status returns a snapshot of the stream's health
+func (s *BinanceUserStream) Status() UserStreamStatus {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	status := s.status
+	status.Balances = make(map[string]float64, len(s.status.Balances))
+	for asset, balance := range s.status.Balances {
+		status.Balances[asset] = balance
+	}
+	return status
+}
+
+// listenKeyPath is the market's user data stream endpoint
+func (c *BinanceOrderClient) listenKeyPath() string {
+	if c.market == MarketSpot {
+		return "/api/v3/userDataStream"
+	}
+	return "/fapi/v1/listenKey"
+}
+
+// CreateListenKey opens a user data stream and returns its listen key
+func (c *BinanceOrderClient) CreateListenKey() (string, error) {
+	var response struct {
+		ListenKey string `json:"listenKey"`
+	}
+	if err := c.provider.keyedRequestJSON(http.MethodPost, c.baseURL, c.listenKeyPath(), nil, &response); err != nil {
+		return "", fmt.Errorf("failed to create listen key: %w", err)
+	}
+	if response.ListenKey == "" {
+		return "", fmt.Errorf("empty listen key")
+	}
+	return response.ListenKey, nil
+}
+
+// KeepAliveListenKey extends a listen key's validity by 60 minutes
+func (c *BinanceOrderClient) KeepAliveListenKey(listenKey string) error {
+	if err := c.provider.keyedRequestJSON(http.MethodPut, c.baseURL, c.listenKeyPath(), url.Values{"listenKey": {listenKey}}, &struct{}{}); err != nil {
+		return fmt.Errorf("failed to keep listen key alive: %w", err)
+	}
+	return nil
+}
+
+// CloseListenKey ends a user data stream
+func (c *BinanceOrderClient) CloseListenKey(listenKey string) error {
+	return c.provider.keyedRequestJSON(http.MethodDelete, c.baseURL, c.listenKeyPath(), url.Values{"listenKey": {listenKey}}, &struct{}{})
+}
+
+// run holds the connection open, backing off between attempts
+func (s *BinanceUserStream) run() {
+	backoff := s.minBackoff
+	for {
+		received, err := s.connectAndRead()
+		select {
+		case <-s.stopChan:
+			return
+		default:
+		}
+
+		s.mutex.Lock()
+		s.status.Connected = false
+		s.status.LastError = err.Error()
+		s.mutex.Unlock()
+		executorLog.Warn("User data stream disconnected, reconnecting", "backoff", backoff, "error", err)
+
+		// A connection that delivered events starts the backoff over
+		if received {
+			backoff = s.minBackoff
+		}
+		select {
+		case <-s.stopChan:
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+
+		s.mutex.Lock()
+		s.status.Reconnects++
+		s.mutex.Unlock()
+	}
+}
+
+// ensureListenKey returns the current listen key, creating one if there is none
+func (s *BinanceUserStream) ensureListenKey() (string, error) {
+	s.mutex.RLock()
+	listenKey := s.listenKey
+	s.mutex.RUnlock()
+	if listenKey != "" {
+		return listenKey, nil
+	}
+
+	listenKey, err := s.client.CreateListenKey()
+	if err != nil {
+		return "", err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.status.Reconnects > 0 {
+		s.status.Renewals++
+	}
+	s.listenKey = listenKey
+	s.status.LastKeepalive = time.Now()
+	return listenKey, nil
+}
+
+// expireListenKey drops the listen key so the next connection creates a new one
+func (s *BinanceUserStream) expireListenKey() {
+	s.mutex.Lock()
+	s.listenKey = ""
+	s.mutex.Unlock()
+}
+
+// connectAndRead applies events until the connection fails, its listen key
+// expires or it misses pongs, reporting whether any event arrived
+func (s *BinanceUserStream) connectAndRead() (bool, error) {
+	listenKey, err := s.ensureListenKey()
+	if err != nil {
+		return false, err
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(s.client.wsURL+"/"+listenKey, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	s.mutex.Lock()
+	s.conn = conn
+	s.status.Connected = true
+	reconnected := s.status.Reconnects > 0
+	s.mutex.Unlock()
+	select {
+	case <-s.stopChan:
+		return false, fmt.Errorf("stream stopped")
+	default:
+	}
+	executorLog.Info("📡 User data stream connected", "market", s.client.market)
+	if reconnected && s.onReconnect != nil {
+		s.onReconnect()
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go s.keepAlive(conn, listenKey, done)
+
+	// Events only arrive with account activity, so liveness comes from pongs
+	conn.SetReadDeadline(time.Now().Add(2 * s.ping))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * s.ping))
+	})
+	received := false
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return received, fmt.Errorf("read failed: %w", err)
+		}
+		received = true
+		conn.SetReadDeadline(time.Now().Add(2 * s.ping))
+		if err := s.handleMessage(message); errors.Is(err, errListenKeyExpired) {
+			s.expireListenKey()
+			return received, err
+		} else if err != nil {
+			executorLog.Warn("Skipping user data stream message", "error", err)
+		}
+	}
+}
+
+// keepAlive pings the connection and extends the listen key until done. A failed
+// keepalive drops the key and closes the connection so a new key is created.
+func (s *BinanceUserStream) keepAlive(conn *websocket.Conn, listenKey string, done <-chan struct{}) {
+	pings := time.NewTicker(s.ping)
+	defer pings.Stop()
+	keepalives := time.NewTicker(s.keepalive)
+	defer keepalives.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-pings.C:
+			conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+		case <-keepalives.C:
+			if err := s.client.KeepAliveListenKey(listenKey); err != nil {
+				executorLog.Warn("Listen key keepalive failed, renewing the user data stream", "error", err)
+				s.expireListenKey()
+				conn.Close()
+				return
+			}
+			s.mutex.Lock()
+			s.status.LastKeepalive = time.Now()
+			s.mutex.Unlock()
+		}
+	}
+}
+
+// Binance event keys can differ only in case ("x" and "X", "i" and "I", ...).
+// Each pair is declared so encoding/json's case-insensitive matching can't
+// fill one field from the other.
+
+// binanceOrderEvent holds the order fields of a futures ORDER_TRADE_UPDATE
+// ("o") or a spot executionReport (top level)
+type binanceOrderEvent struct {
+	Symbol        string `json:"s"`
+	ClientID      string `json:"c"`
+	OrigClientID  string `json:"C"` // Spot cancels carry the order's client ID here
+	Side          string `json:"S"`
+	ExecutionType string `json:"x"`
+	Status        string `json:"X"`
+	OrderID       int64  `json:"i"`
+	Ignore        int64  `json:"I"`
+	Quantity      string `json:"q"`
+	QuoteQuantity string `json:"Q"`
+	Executed      string `json:"z"`
+	ExecutedQuote string `json:"Z"`  // Spot only
+	AvgPrice      string `json:"ap"` // Futures only
+	ActivatePrice string `json:"AP"`
+	TransactTime  int64  `json:"T"`
+	TradeID       int64  `json:"t"`
+}
+
+// state converts an order event into an ExchangeOrderState
+func (e binanceOrderEvent) state() (*ExchangeOrderState, error) {
+	values, err := parseFloats([]string{e.Quantity, e.Executed}, "quantity", "executed quantity")
+	if err != nil {
+		return nil, err
+	}
+	state := &ExchangeOrderState{
+		OrderID:          strconv.FormatInt(e.OrderID, 10),
+		Symbol:           e.Symbol,
+		Side:             e.Side,
+		Status:           e.Status,
+		Quantity:         values[0],
+		ExecutedQuantity: values[1],
+		UpdateTime:       time.UnixMilli(e.TransactTime),
+	}
+
+	// Spot reports the executed quote amount instead of an average price
+	switch {
+	case e.AvgPrice != "":
+		if state.AveragePrice, err = strconv.ParseFloat(e.AvgPrice, 64); err != nil {
+			return nil, fmt.Errorf("failed to parse average price: %w", err)
+		}
+	case e.ExecutedQuote != "" && state.ExecutedQuantity > 0:
+		quote, err := strconv.ParseFloat(e.ExecutedQuote, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse executed quote quantity: %w", err)
+		}
+		state.AveragePrice = quote / state.ExecutedQuantity
+	}
+	return state, nil
+}
+
+// binanceBalanceEvent holds the balances of a futures ACCOUNT_UPDATE ("a.B")
+// or a spot outboundAccountPosition ("B")
+type binanceBalanceEvent struct {
+	Asset         string `json:"a"`
+	WalletBalance string `json:"wb"` // Futures
+	CrossWallet   string `json:"cw"`
+	Free          string `json:"f"` // Spot
+	Locked        string `json:"l"`
+}
+
+// balance returns the asset's total balance
+func (e binanceBalanceEvent) balance() (float64, error) {
+	if e.WalletBalance != "" {
+		return strconv.ParseFloat(e.WalletBalance, 64)
+	}
+	values, err := parseFloats([]string{e.Free, e.Locked}, "free", "locked")
+	if err != nil {
+		return 0, err
+	}
+	return values[0] + values[1], nil
+}
+
+// handleMessage applies an order update to its tracked order or records balances
+func (s *BinanceUserStream) handleMessage(message []byte) error {
+	var header struct {
+		Type      string `json:"e"`
+		EventTime int64  `json:"E"`
+	}
+	if err := json.Unmarshal(message, &header); err != nil {
+		return fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	switch header.Type {
+	case "ORDER_TRADE_UPDATE": // Futures
+		var event struct {
+			Order binanceOrderEvent `json:"o"`
+		}
+		if err := json.Unmarshal(message, &event); err != nil {
+			return fmt.Errorf("failed to parse order update: %w", err)
+		}
+		return s.applyOrder(event.Order)
+	case "executionReport": // Spot
+		var order binanceOrderEvent
+		if err := json.Unmarshal(message, &order); err != nil {
+			return fmt.Errorf("failed to parse execution report: %w", err)
+		}
+		return s.applyOrder(order)
+	case "ACCOUNT_UPDATE": // Futures
+		var event struct {
+			Account struct {
+				Balances []binanceBalanceEvent `json:"B"`
+			} `json:"a"`
+		}
+		if err := json.Unmarshal(message, &event); err != nil {
+			return fmt.Errorf("failed to parse account update: %w", err)
+		}
+		return s.applyBalances(event.Account.Balances)
+	case "outboundAccountPosition": // Spot
+		var event struct {
+			Balances []binanceBalanceEvent `json:"B"`
+		}
+		if err := json.Unmarshal(message, &event); err != nil {
+			return fmt.Errorf("failed to parse account position: %w", err)
+		}
+		return s.applyBalances(event.Balances)
+	case "listenKeyExpired":
+		return errListenKeyExpired
+	}
+	return nil
+}
+
+// applyOrder feeds an order update to the executor; orders it didn't place are ignored
+func (s *BinanceUserStream) applyOrder(event binanceOrderEvent) error {
+	state, err := event.state()
+	if err != nil {
+		return err
+	}
+	id := event.ClientID
+	if event.Status == "CANCELED" && event.OrigClientID != "" {
+		id = event.OrigClientID
+	}
+
+	filled, err := s.executor.ApplyOrderState(id, *state)
+	if err != nil {
+		return nil // Not one of ours, or already settled from the placement response
+	}
+	s.mutex.Lock()
+	s.status.LastEvent = time.Now()
+	s.status.OrderUpdates++
+	s.mutex.Unlock()
+	if filled {
+		executorLog.Info("⚡ Order update from the user data stream", "order_id", id, "status", state.Status,
+			"filled", state.ExecutedQuantity, "average_price", state.AveragePrice)
+	}
+	return nil
+}
+
+// applyBalances records the exchange balances pushed with an account update
+func (s *BinanceUserStream) applyBalances(balances []binanceBalanceEvent) error {
+	parsed := make(map[string]float64, len(balances))
+	for _, balance := range balances {
+		amount, err := balance.balance()
+		if err != nil {
+			return fmt.Errorf("failed to parse %s balance: %w", balance.Asset, err)
+		}
+		parsed[balance.Asset] = amount
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for asset, amount := range parsed {
+		s.status.Balances[asset] = amount
+	}
+	s.status.LastEvent = time.Now()
+	s.status.BalanceUpdates++
+	return nil
+}
@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestBinanceUserStream(t *testing.T) {
+	t.Log("⚡ Testing order fills, balances and listen key renewal on the Binance user data stream")
+
+	upgrader := websocket.Upgrader{}
+	connected := make(chan *websocket.Conn, 1)
+	var created, keepalives, closed int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/fapi/v1/listenKey":
+			if r.Header.Get("X-MBX-APIKEY") != "key" {
+				t.Errorf("Unexpected unkeyed listen key request %s %s", r.Method, r.URL)
+			}
+			switch r.Method {
+			case http.MethodPost:
+				json.NewEncoder(w).Encode(map[string]string{"listenKey": fmt.Sprintf("key%d", atomic.AddInt32(&created, 1))})
+				return
+			case http.MethodPut:
+				atomic.AddInt32(&keepalives, 1)
+			case http.MethodDelete:
+				atomic.AddInt32(&closed, 1)
+			}
+			w.Write([]byte("{}"))
+		case r.URL.Path == "/fapi/v1/order":
+			// Limit entries rest until the stream reports their fills
+			query := r.URL.Query()
+			json.NewEncoder(w).Encode(map[string]interface{}{"orderId": 7, "symbol": query.Get("symbol"), "side": query.Get("side"),
+				"status": "NEW", "origQty": query.Get("quantity"), "executedQty": "0", "avgPrice": "0", "updateTime": 1})
+		case strings.HasPrefix(r.URL.Path, "/ws/key"):
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				return
+			}
+			connected <- conn
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewBinanceFuturesDataProvider("key", "secret")
+	provider.baseURL = server.URL
+	provider.wsURL = "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+	client := NewBinanceOrderClient(provider, MarketFutures)
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000)
+	executor.SetOrderPlacer(client, LiveTradingConfig{Enabled: true, Market: MarketFutures, OrderType: "LIMIT"})
+
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}, 100, 99.5); err != nil {
+		t.Fatalf("Live entry failed: %v", err)
+	}
+	orders := executor.GetOpenOrders()
+	if len(orders) != 1 || executor.GetCurrentPosition() != nil {
+		t.Fatalf("Expected one resting limit entry, got %+v", orders)
+	}
+	entry := orders[0]
+	quantity := strconv.FormatFloat(entry.Quantity, 'f', -1, 64)
+
+	var resyncs int32
+	stream := NewBinanceUserStream(client, executor)
+	stream.keepalive, stream.minBackoff = 20*time.Millisecond, 10*time.Millisecond
+	stream.OnReconnect(func() { atomic.AddInt32(&resyncs, 1) })
+	stream.Start()
+	defer stream.Stop()
+	var conn *websocket.Conn
+	select {
+	case conn = <-connected:
+		defer conn.Close()
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the user data stream to connect")
+	}
+
+	orderUpdate := func(status, executed string) map[string]interface{} {
+		return map[string]interface{}{"e": "ORDER_TRADE_UPDATE", "E": 2, "T": 2, "o": map[string]interface{}{
+			"s": config.Symbol, "c": entry.ID, "S": "BUY", "o": "LIMIT", "x": "TRADE", "X": status, "i": 7,
+			"q": quantity, "z": executed, "ap": "99.98", "T": 2, "t": 11,
+		}}
+	}
+	messages := []interface{}{
+		map[string]interface{}{"e": "ORDER_TRADE_UPDATE", "o": map[string]interface{}{"s": config.Symbol, "c": "someone-else", "X": "FILLED", "q": "1", "z": "1", "ap": "50"}},
+		orderUpdate("FILLED", quantity),
+		map[string]interface{}{"e": "ACCOUNT_UPDATE", "a": map[string]interface{}{"m": "ORDER", "B": []map[string]string{{"a": "USDT", "wb": "9990.5", "cw": "9990.5", "bc": "0"}}}},
+	}
+	for _, message := range messages {
+		if err := conn.WriteJSON(message); err != nil {
+			t.Fatalf("Failed to push event: %v", err)
+		}
+	}
+
+	// The fill opens the position without a reconciliation pass
+	deadline := time.Now().Add(5 * time.Second)
+	for stream.Status().BalanceUpdates == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	position := executor.GetCurrentPosition()
+	if position == nil || position.EntryPrice != 99.98 || len(executor.GetOpenOrders()) != 0 {
+		t.Fatalf("Expected the streamed fill to open a long at 99.98, got %+v", position)
+	}
+	status := stream.Status()
+	if !status.Connected || status.OrderUpdates != 1 || status.Balances["USDT"] != 9990.5 {
+		t.Errorf("Expected one applied order update and the USDT balance, got %+v", status)
+	}
+
+	// An expired listen key is replaced and the reconnect resyncs
+	for atomic.LoadInt32(&keepalives) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := conn.WriteJSON(map[string]interface{}{"e": "listenKeyExpired", "E": 4}); err != nil {
+		t.Fatalf("Failed to push expiry: %v", err)
+	}
+	select {
+	case renewed := <-connected:
+		defer renewed.Close()
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the stream to reconnect after the listen key expired")
+	}
+	for atomic.LoadInt32(&resyncs) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	status = stream.Status()
+	if atomic.LoadInt32(&created) != 2 || status.Renewals != 1 || status.Reconnects != 1 || atomic.LoadInt32(&resyncs) != 1 || status.LastKeepalive.IsZero() {
+		t.Errorf("Expected one renewal, reconnect and resync with keepalives sent, got %d keys %d resyncs %+v", created, resyncs, status)
+	}
+	stream.Stop()
+	if atomic.LoadInt32(&closed) != 1 {
+		t.Errorf("Expected Stop to close the listen key, got %d closes", closed)
+	}
+
+	// Spot execution reports carry the executed quote instead of an average price
+	spot, err := json.Marshal(map[string]interface{}{"e": "executionReport", "E": 3, "s": config.Symbol, "c": "x", "C": "", "S": "SELL",
+		"x": "TRADE", "X": "FILLED", "i": 8, "I": 9, "q": "2", "Q": "0", "z": "2", "Z": "201", "T": 3, "t": 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event binanceOrderEvent
+	if err := json.Unmarshal(spot, &event); err != nil {
+		t.Fatalf("Failed to parse execution report: %v", err)
+	}
+	if state, err := event.state(); err != nil || state.AveragePrice != 100.5 || state.Side != "SELL" || state.OrderID != "8" {
+		t.Errorf("Expected a 100.5 average SELL for order 8, got %+v (err %v)", state, err)
+	}
+
+	// The feature is on by default for live trading
+	if !DefaultConfig().LiveTrading.UserStream {
+		t.Error("Expected live_trading.user_stream to default on")
+	}
+}
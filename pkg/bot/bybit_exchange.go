@@ -0,0 +1,302 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bybitMaxCandles is the most candles Bybit returns per request
+const bybitMaxCandles = 1000
+
+// bybitRecvWindow is how long a signed request stays valid, in milliseconds
+const bybitRecvWindow = "5000"
+
+// BybitExchange implements Exchange for Bybit USDT perpetuals (v5 API).
+// Real-time candles are polled; 8h candles are aggregated from 4h ones.
+type BybitExchange struct {
+	*candlePoller
+	baseURL     string
+	credentials ExchangeCredentials
+	httpClient  *http.Client
+}
+
+// NewBybitExchange creates a Bybit client
+func NewBybitExchange(credentials ExchangeCredentials) *BybitExchange {
+	return &BybitExchange{
+		candlePoller: newCandlePoller(30 * time.Second),
+		baseURL:      "https://api.bybit.com",
+		credentials:  credentials,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns the venue name
+func (b *BybitExchange) Name() string {
+	return "bybit"
+}
+
+// convertSymbol maps a symbol to a linear perpetual (BTCUSD -> BTCUSDT)
+func (b *BybitExchange) convertSymbol(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+	if strings.HasSuffix(symbol, "USD") {
+		return symbol + "T"
+	}
+	return symbol
+}
+
+// interval maps a timeframe to a Bybit kline interval
+func (b *BybitExchange) interval(timeframe Timeframe) venueInterval {
+	switch timeframe {
+	case FifteenMinute:
+		return venueInterval{param: "15", length: 15 * time.Minute}
+	case FortyFiveMinute:
+		return venueInterval{param: "60", length: time.Hour} // No 45m, use 1h as closest
+	case EightHour:
+		return venueInterval{param: "240", length: 4 * time.Hour, aggregate: true}
+	case Daily:
+		return venueInterval{param: "D", length: 24 * time.Hour}
+	default:
+		return venueInterval{param: "5", length: 5 * time.Minute}
+	}
+}
+
+// getResult performs a GET request, signed when signed is set, and unwraps
+// Bybit's {retCode, retMsg, result} envelope
+func (b *BybitExchange) getResult(path string, params url.Values, signed bool, out interface{}) error {
+	query := params.Encode()
+	req, err := http.NewRequest(http.MethodGet, b.baseURL+path+"?"+query, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if signed {
+		if b.credentials.APIKey == "" || b.credentials.SecretKey == "" {
+			return fmt.Errorf("API keys required for %s", path)
+		}
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		mac := hmac.New(sha256.New, []byte(b.credentials.SecretKey))
+		mac.Write([]byte(timestamp + b.credentials.APIKey + bybitRecvWindow + query))
+		req.Header.Set("X-BAPI-API-KEY", b.credentials.APIKey)
+		req.Header.Set("X-BAPI-TIMESTAMP", timestamp)
+		req.Header.Set("X-BAPI-RECV-WINDOW", bybitRecvWindow)
+		req.Header.Set("X-BAPI-SIGN", hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	var envelope struct {
+		RetCode int             `json:"retCode"`
+		RetMsg  string          `json:"retMsg"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := doExchangeRequest(b.httpClient, req, &envelope); err != nil {
+		return err
+	}
+	if envelope.RetCode != 0 {
+		return fmt.Errorf("bybit error %d: %s", envelope.RetCode, envelope.RetMsg)
+	}
+	if err := json.Unmarshal(envelope.Result, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// GetHistoricalData fetches the latest count candles
+func (b *BybitExchange) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	return latestVenueCandles(b, b.clock, symbol, timeframe, b.interval(timeframe), count)
+}
+
+// GetHistoricalRange fetches all candles opening in [start, end), paging past the 1000-candle limit
+func (b *BybitExchange) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	bybitSymbol := b.convertSymbol(symbol)
+	interval := b.interval(timeframe)
+
+	return fetchVenueRange(b.clock, interval, timeframe, start, end, bybitMaxCandles, func(from, to time.Time) ([]Candle, error) {
+		params := url.Values{}
+		params.Add("category", "linear")
+		params.Add("symbol", bybitSymbol)
+		params.Add("interval", interval.param)
+		params.Add("start", strconv.FormatInt(from.UnixMilli(), 10))
+		params.Add("end", strconv.FormatInt(to.UnixMilli()-1, 10))
+		params.Add("limit", strconv.Itoa(bybitMaxCandles))
+
+		// Rows are [startTime, open, high, low, close, volume, turnover], newest first
+		var result struct {
+			List [][]string `json:"list"`
+		}
+		if err := b.getResult("/v5/market/kline", params, false, &result); err != nil {
+			return nil, err
+		}
+		candles := make([]Candle, 0, len(result.List))
+		for i, row := range result.List {
+			values, err := parseFloats(row, "start time", "open", "high", "low", "close", "volume")
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert kline %d: %w", i, err)
+			}
+			candles = append(candles, Candle{
+				Timestamp: time.UnixMilli(int64(values[0])),
+				Open:      values[1],
+				High:      values[2],
+				Low:       values[3],
+				Close:     values[4],
+				Volume:    values[5],
+			})
+		}
+		return candles, nil
+	})
+}
+
+// GetRealTimeData polls for completed candles
+func (b *BybitExchange) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	return b.poll(symbol, timeframe, b.GetHistoricalData), nil
+}
+
+// GetTicker fetches the last trade and top of book
+func (b *BybitExchange) GetTicker(symbol string) (*Ticker, error) {
+	params := url.Values{}
+	params.Add("category", "linear")
+	params.Add("symbol", b.convertSymbol(symbol))
+
+	var result struct {
+		List []struct {
+			LastPrice string `json:"lastPrice"`
+			Bid1Price string `json:"bid1Price"`
+			Ask1Price string `json:"ask1Price"`
+			Volume24h string `json:"volume24h"`
+		} `json:"list"`
+	}
+	if err := b.getResult("/v5/market/tickers", params, false, &result); err != nil {
+		return nil, err
+	}
+	if len(result.List) == 0 {
+		return nil, fmt.Errorf("bybit returned no ticker for %s", b.convertSymbol(symbol))
+	}
+	ticker := result.List[0]
+	values, err := parseFloats([]string{ticker.LastPrice, ticker.Bid1Price, ticker.Ask1Price, ticker.Volume24h}, "price", "bid", "ask", "volume")
+	if err != nil {
+		return nil, err
+	}
+	return &Ticker{Symbol: symbol, Last: values[0], Bid: values[1], Ask: values[2], Volume: values[3], Timestamp: time.Now()}, nil
+}
+
+// ListSymbols returns the linear perpetuals currently trading, following Bybit's page cursor
+func (b *BybitExchange) ListSymbols() ([]string, error) {
+	var symbols []string
+	cursor := ""
+	for {
+		params := url.Values{}
+		params.Add("category", "linear")
+		params.Add("limit", "1000")
+		if cursor != "" {
+			params.Add("cursor", cursor)
+		}
+
+		var result struct {
+			List []struct {
+				Symbol string `json:"symbol"`
+				Status string `json:"status"`
+			} `json:"list"`
+			NextPageCursor string `json:"nextPageCursor"`
+		}
+		if err := b.getResult("/v5/market/instruments-info", params, false, &result); err != nil {
+			return nil, err
+		}
+		for _, instrument := range result.List {
+			if instrument.Status == "" || instrument.Status == "Trading" {
+				symbols = append(symbols, instrument.Symbol)
+			}
+		}
+		if result.NextPageCursor == "" || len(result.List) == 0 {
+			return symbols, nil
+		}
+		cursor = result.NextPageCursor
+	}
+}
+
+// QuoteVolumes returns each linear perpetual's 24h turnover in its quote currency
+func (b *BybitExchange) QuoteVolumes() (map[string]float64, error) {
+	params := url.Values{}
+	params.Add("category", "linear")
+
+	var result struct {
+		List []struct {
+			Symbol     string `json:"symbol"`
+			Turnover24 string `json:"turnover24h"`
+		} `json:"list"`
+	}
+	if err := b.getResult("/v5/market/tickers", params, false, &result); err != nil {
+		return nil, err
+	}
+
+	volumes := make(map[string]float64, len(result.List))
+	for _, ticker := range result.List {
+		if volume, err := strconv.ParseFloat(ticker.Turnover24, 64); err == nil {
+			volumes[ticker.Symbol] = volume
+		}
+	}
+	return volumes, nil
+}
+
+// GetOrderBook fetches depth levels per side of the order book (at most 500)
+func (b *BybitExchange) GetOrderBook(symbol string, depth int) (*OrderBook, error) {
+	params := url.Values{}
+	params.Add("category", "linear")
+	params.Add("symbol", b.convertSymbol(symbol))
+	if depth > 0 {
+		params.Add("limit", strconv.Itoa(min(depth, 500)))
+	}
+
+	var result struct {
+		Bids [][]interface{} `json:"b"` // [price, size]
+		Asks [][]interface{} `json:"a"`
+	}
+	if err := b.getResult("/v5/market/orderbook", params, false, &result); err != nil {
+		return nil, err
+	}
+
+	var err error
+	book := &OrderBook{Symbol: symbol, Timestamp: time.Now()}
+	if book.Bids, err = parseBookLevels(result.Bids, depth); err != nil {
+		return nil, err
+	}
+	if book.Asks, err = parseBookLevels(result.Asks, depth); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// GetBalances fetches the unified trading account's wallet balance per coin
+func (b *BybitExchange) GetBalances() (map[string]float64, error) {
+	params := url.Values{}
+	params.Add("accountType", "UNIFIED")
+
+	var result struct {
+		List []struct {
+			Coin []struct {
+				Coin          string `json:"coin"`
+				WalletBalance string `json:"walletBalance"`
+			} `json:"coin"`
+		} `json:"list"`
+	}
+	if err := b.getResult("/v5/account/wallet-balance", params, true, &result); err != nil {
+		return nil, err
+	}
+	balances := make(map[string]float64)
+	for _, account := range result.List {
+		for _, coin := range account.Coin {
+			balance, err := strconv.ParseFloat(coin.WalletBalance, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s balance: %w", coin.Coin, err)
+			}
+			if balance != 0 {
+				balances[coin.Coin] += balance
+			}
+		}
+	}
+	return balances, nil
+}
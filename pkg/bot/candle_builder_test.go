@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCandleBuilderAlignment(t *testing.T) {
+	t.Log("🧱 Testing candle builder alignment to exchange open times")
+
+	// A builder started mid-candle still opens candles on the exchange's boundaries
+	start := time.Date(2024, 1, 1, 0, 2, 30, 0, time.UTC)
+	clock := NewSimClock(start)
+	builder := NewCandleBuilder(FiveMinute, clock)
+	builder.AddTick(100, 1)
+	builder.AddTickAt(start.Add(149*time.Second), 105, 1) // 00:04:59
+	if builder.GetCompletedCandle() != nil {
+		t.Fatal("Candle completed before its close")
+	}
+
+	// The first tick past the boundary closes the candle, even before the clock is checked
+	builder.AddTickAt(start.Add(151*time.Second), 103, 2) // 00:05:01
+	builder.AddTickAt(start.Add(100*time.Second), 999, 9) // Late tick for the closed 00:00 candle
+	candle := builder.GetCompletedCandle()
+	if candle == nil || !candle.Timestamp.Equal(start.Add(-150*time.Second)) || candle.Close != 105 || candle.High != 105 || candle.Volume != 2 {
+		t.Fatalf("Unexpected 00:00 candle: %+v", candle)
+	}
+
+	// Without later ticks the clock closes the candle
+	clock.Set(time.Date(2024, 1, 1, 0, 10, 0, 0, time.UTC))
+	if candle := builder.GetCompletedCandle(); candle == nil || !candle.Timestamp.Equal(time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC)) || candle.Open != 103 {
+		t.Fatalf("Unexpected 00:05 candle: %+v", candle)
+	}
+
+	// Higher timeframes follow the exchange's UTC boundaries
+	eightHour := NewCandleBuilder(EightHour, clock)
+	eightHour.AddTickAt(time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC), 100, 1)
+	eightHour.AddTickAt(time.Date(2024, 1, 1, 16, 0, 0, 0, time.UTC), 101, 1)
+	if candle := eightHour.GetCompletedCandle(); candle == nil || !candle.Timestamp.Equal(time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the 08:00 candle, got %+v", candle)
+	}
+
+	// Kline payloads re-anchor the buckets and override the locally built values
+	daily := NewCandleBuilder(Daily, clock)
+	daily.AddTickAt(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), 100, 1)
+	daily.AddKline(Candle{Timestamp: time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC), Open: 98, High: 102, Low: 97, Close: 100, Volume: 50})
+	daily.AddTickAt(time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC), 104, 1)
+	daily.AddTickAt(time.Date(2024, 1, 2, 17, 0, 0, 0, time.UTC), 103, 1)
+	candle = daily.GetCompletedCandle()
+	if candle == nil || !candle.Timestamp.Equal(time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC)) || candle.Open != 98 || candle.High != 104 || candle.Close != 104 || candle.Volume != 51 {
+		t.Errorf("Unexpected kline-aligned candle: %+v", candle)
+	}
+
+	// Sample history ends with the last closed candle on the same boundaries
+	provider := NewSampleDataProvider([]string{"BTCUSDT"}, 50000)
+	provider.SetDeterminism(DeterminismConfig{Enabled: true, Seed: 1, Clock: "2024-01-01T00:07:00Z"})
+	history, err := provider.GetHistoricalData("BTCUSDT", FiveMinute, 3)
+	if err != nil || len(history) != 3 || !history[0].Timestamp.Equal(time.Date(2023, 12, 31, 23, 50, 0, 0, time.UTC)) || !history[2].Timestamp.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected sample history: %+v (err %v)", history, err)
+	}
+}
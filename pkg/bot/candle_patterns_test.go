@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/indicator"
+)
+
+func TestCandlePatterns(t *testing.T) {
+	t.Log("🧩 Testing multi-candle patterns and their strength scores")
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ohlc := func(bars ...[4]float64) []indicator.Candle {
+		candles := make([]indicator.Candle, len(bars))
+		for i, bar := range bars {
+			candles[i] = indicator.Candle{Timestamp: origin.Add(time.Duration(i) * 5 * time.Minute), Open: bar[0], High: bar[1], Low: bar[2], Close: bar[3]}
+		}
+		return candles
+	}
+	all := indicator.NewCandlePatterns(indicator.CandlePatternsConfig{Enabled: true, TweezerTolerance: 0.05}, indicator.FiveMinute)
+
+	tests := []struct {
+		name     string
+		candles  []indicator.Candle
+		pattern  string
+		signal   indicator.SignalType
+		strength float64
+	}{
+		// The third candle wins back 70% of the first body; the star is 5% of it
+		{"morning star", ohlc([4]float64{100, 101, 89, 90}, [4]float64{89, 89.5, 88, 88.5}, [4]float64{89, 97.5, 88.8, 97}),
+			"morning_star", indicator.Buy, 0.7 * 0.95},
+		{"evening star", ohlc([4]float64{90, 101, 89, 100}, [4]float64{101, 102, 100.5, 101.5}, [4]float64{101, 101.2, 92.5, 93}),
+			"evening_star", indicator.Sell, 0.7 * 0.95},
+		// Bodies fill 80%, 83% and 83% of their ranges
+		{"three white soldiers", ohlc([4]float64{100, 104.5, 99.5, 104}, [4]float64{102, 107.5, 101.5, 107}, [4]float64{105, 110.5, 104.5, 110}),
+			"three_white_soldiers", indicator.Buy, (0.8 + 5.0/6 + 5.0/6) / 3},
+		{"three black crows", ohlc([4]float64{110, 110.5, 105.5, 106}, [4]float64{107, 107.5, 101.5, 102}, [4]float64{104, 104.5, 98.5, 99}),
+			"three_black_crows", indicator.Sell, (0.8 + 5.0/6 + 5.0/6) / 3},
+		// Lows 0.02 apart out of an allowed 0.2995, beating the weaker harami
+		{"tweezer bottom", ohlc([4]float64{100, 101, 99, 100}, [4]float64{105, 105.5, 99, 100}, [4]float64{100.5, 104.5, 99.02, 104}),
+			"tweezers", indicator.Buy, 0.5 + 0.5*(1-0.02/0.2995)},
+		// Half the mother bar's range, continuing its bullish direction
+		{"inside bar", ohlc([4]float64{100, 101, 99, 100}, [4]float64{100, 105, 99, 104}, [4]float64{102, 104, 101, 103}),
+			"inside_bar", indicator.Buy, 0.25},
+		{"outside bar", ohlc([4]float64{100, 101, 99, 100}, [4]float64{100, 102, 99, 101}, [4]float64{99.5, 104, 98.5, 103.5}),
+			"outside_bar", indicator.Buy, 4 / 5.5 * (5.5/3 - 1)},
+		// A small bearish body inside a long bullish one outscores the inside bar it also forms
+		{"bearish harami", ohlc([4]float64{100, 101, 99, 100}, [4]float64{100, 106.5, 99.5, 106}, [4]float64{104, 104.5, 102.5, 103}),
+			"harami", indicator.Sell, 0.8 * (1 - 1.0/6)},
+	}
+	for _, tc := range tests {
+		matches := all.Detect(tc.candles)
+		if len(matches) == 0 || matches[len(matches)-1].Index != len(tc.candles)-1 {
+			t.Errorf("%s: expected a pattern on the last candle, got %+v", tc.name, matches)
+			continue
+		}
+		match := matches[len(matches)-1]
+		if match.Pattern != tc.pattern || match.Signal != tc.signal || math.Abs(match.Strength-tc.strength) > 1e-9 {
+			t.Errorf("%s: expected %s %v %.4f, got %+v", tc.name, tc.pattern, tc.signal, tc.strength, match)
+		}
+
+		values := all.Calculate(tc.candles)
+		signal := all.GetSignal(values, tc.candles[len(tc.candles)-1].Close)
+		if signal.Signal != tc.signal || math.Abs(signal.Strength-tc.strength) > 1e-9 {
+			t.Errorf("%s: expected the signal to follow the pattern, got %+v", tc.name, signal)
+		}
+	}
+
+	// Only listed patterns are detected
+	harami := tests[len(tests)-1].candles
+	insideOnly := indicator.NewCandlePatterns(indicator.CandlePatternsConfig{Enabled: true, Patterns: []string{"inside_bar"}}, indicator.FiveMinute)
+	if matches := insideOnly.Detect(harami); len(matches) == 0 || matches[len(matches)-1].Pattern != "inside_bar" || matches[len(matches)-1].Signal != indicator.Buy {
+		t.Errorf("Expected only the inside bar with harami disabled, got %+v", matches)
+	}
+
+	// Wired in as a registered indicator
+	config := DefaultConfig()
+	for _, info := range Indicators() {
+		info.SetEnabled(&config, false)
+	}
+	config.CandlePatterns.Enabled = true
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected the default patterns to be valid: %v", err)
+	}
+	var candles []Candle
+	for _, c := range tests[0].candles {
+		candles = append(candles, Candle{Timestamp: c.Timestamp, Open: c.Open, High: c.High, Low: c.Low, Close: c.Close})
+	}
+	signals := NewSignalAggregator(config).getTimeframeSignals(candles, FiveMinute, 97)
+	if len(signals) != 1 || signals[0].Name != "CandlePatterns_5m" || signals[0].Signal != Buy {
+		t.Errorf("Expected a morning star BUY from the aggregator, got %+v", signals)
+	}
+
+	config.CandlePatterns.Patterns = []string{"harami", "abandoned_baby"}
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), `candle_patterns.patterns[1]: unknown candle pattern "abandoned_baby"`) {
+		t.Errorf("Expected an unknown pattern to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package bot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CandleStore persists candles per symbol and timeframe so they survive restarts
+// and backtests can run offline
+type CandleStore interface {
+	SaveCandles(symbol string, timeframe Timeframe, candles []Candle) error                 // Inserts or replaces by open time
+	LoadCandles(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) // Opening in [start, end), oldest first
+	LatestCandles(symbol string, timeframe Timeframe, count int) ([]Candle, error)          // The last count, oldest first
+	Close() error
+}
+
+// candleStoreDrivers create a CandleStore from its configured path
+var candleStoreDrivers = map[string]func(path string) (CandleStore, error){
+	"sqlite": func(path string) (CandleStore, error) { return NewSQLiteCandleStore(path) },
+	"memory": func(string) (CandleStore, error) { return NewMemoryCandleStore(), nil },
+}
+
+// RegisterCandleStore adds a backend selectable as candle_store.driver
+func RegisterCandleStore(driver string, open func(path string) (CandleStore, error)) {
+	candleStoreDrivers[driver] = open
+}
+
+// NewCandleStore opens the configured backend
+func NewCandleStore(config CandleStoreConfig) (CandleStore, error) {
+	open, ok := candleStoreDrivers[valueOrDefault(config.Driver, "sqlite")]
+	if !ok {
+		return nil, fmt.Errorf("unknown candle store driver: %s", config.Driver)
+	}
+	return open(config.Path)
+}
+
+// MemoryCandleStore keeps candles in memory; useful for tests and as a reference backend
+type MemoryCandleStore struct {
+	mutex   sync.RWMutex
+	candles map[string][]Candle // symbol|timeframe -> candles sorted by open time
+}
+
+// NewMemoryCandleStore creates an empty in-memory store
+func NewMemoryCandleStore() *MemoryCandleStore {
+	return &MemoryCandleStore{candles: make(map[string][]Candle)}
+}
+
+// SaveCandles inserts or replaces candles by open time
+func (m *MemoryCandleStore) SaveCandles(symbol string, timeframe Timeframe, candles []Candle) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := symbol + "|" + timeframe.String()
+	byTime := make(map[int64]Candle, len(m.candles[key])+len(candles))
+	for _, candle := range append(append([]Candle(nil), m.candles[key]...), candles...) {
+		byTime[candle.Timestamp.UnixMilli()] = candle
+	}
+	merged := make([]Candle, 0, len(byTime))
+	for _, candle := range byTime {
+		merged = append(merged, candle)
+	}
+	m.candles[key] = sortCandles(merged)
+	return nil
+}
+
+// LoadCandles returns the candles opening in [start, end)
+func (m *MemoryCandleStore) LoadCandles(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	inRange := make([]Candle, 0)
+	for _, candle := range m.candles[symbol+"|"+timeframe.String()] {
+		if !candle.Timestamp.Before(start) && candle.Timestamp.Before(end) {
+			inRange = append(inRange, candle)
+		}
+	}
+	return inRange, nil
+}
+
+// LatestCandles returns the last count candles
+func (m *MemoryCandleStore) LatestCandles(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	candles := m.candles[symbol+"|"+timeframe.String()]
+	if len(candles) > count {
+		candles = candles[len(candles)-count:]
+	}
+	return append([]Candle(nil), candles...), nil
+}
+
+// Close is a no-op for the memory store
+func (m *MemoryCandleStore) Close() error {
+	return nil
+}
+
+// CandleStoreProvider serves stored candles as a historical data provider, so
+// timeframes can be routed to the store for offline runs
+type CandleStoreProvider struct {
+	store CandleStore
+}
+
+// NewCandleStoreProvider wraps a store as a data provider
+func NewCandleStoreProvider(store CandleStore) *CandleStoreProvider {
+	return &CandleStoreProvider{store: store}
+}
+
+// GetHistoricalData returns the latest count stored candles
+func (p *CandleStoreProvider) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	return p.store.LatestCandles(symbol, timeframe, count)
+}
+
+// GetHistoricalRange returns the stored candles opening in [start, end)
+func (p *CandleStoreProvider) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	return p.store.LoadCandles(symbol, timeframe, start, end)
+}
+
+// GetRealTimeData is not supported; the store only serves history
+func (p *CandleStoreProvider) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	return nil, fmt.Errorf("candle store does not support real-time data")
+}
+
+// Close leaves the store open; its owner closes it
+func (p *CandleStoreProvider) Close() error {
+	return nil
+}
+
+// storedPrefix returns the leading run of stored candles without gaps, minus its
+// final candle, which may have been saved while still forming. Whatever follows
+// has to be fetched again.
+func storedPrefix(stored []Candle) []Candle {
+	if len(stored) < 2 {
+		return nil
+	}
+
+	// Candles are spaced by their native interval (45m is served as 1h by some venues)
+	step := time.Duration(0)
+	for i := 1; i < len(stored); i++ {
+		if gap := stored[i].Timestamp.Sub(stored[i-1].Timestamp); gap > 0 && (step == 0 || gap < step) {
+			step = gap
+		}
+	}
+	for i := 1; i < len(stored); i++ {
+		if stored[i].Timestamp.Sub(stored[i-1].Timestamp) > step {
+			return stored[:i-1]
+		}
+	}
+	return stored[:len(stored)-1]
+}
+
+// loadRangeThroughStore returns candles opening in [start, end), reading what the
+// store already holds and fetching (and saving) only the rest
+func loadRangeThroughStore(store CandleStore, symbol string, timeframe Timeframe, start, end time.Time, fetch func(start, end time.Time) ([]Candle, error)) ([]Candle, error) {
+	stored, err := store.LoadCandles(symbol, timeframe, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stored %s candles: %w", timeframe.String(), err)
+	}
+
+	// Stored candles only help when they start at the beginning of the range
+	prefix := storedPrefix(stored)
+	if len(prefix) > 0 && prefix[0].Timestamp.Sub(start) >= 2*timeframe.Duration() {
+		prefix = nil
+	}
+	from := start
+	if len(prefix) > 0 {
+		from = prefix[len(prefix)-1].Timestamp.Add(time.Second)
+	}
+
+	fetched, err := fetch(from, end)
+	if err != nil {
+		if len(stored) == 0 {
+			return nil, err
+		}
+		providerLog.Warn("Failed to fetch candles, using stored ones", "timeframe", timeframe.String(), "stored", len(stored), "error", err)
+		return stored, nil
+	}
+	if err := store.SaveCandles(symbol, timeframe, fetched); err != nil {
+		return nil, fmt.Errorf("failed to store %s candles: %w", timeframe.String(), err)
+	}
+	return appendNewerCandles(prefix, fetched), nil
+}
+
+// appendNewerCandles appends the candles opening after the last of candles
+// (venues differ in whether a range start is inclusive)
+func appendNewerCandles(candles, newer []Candle) []Candle {
+	merged := append([]Candle(nil), candles...)
+	for _, candle := range newer {
+		if n := len(merged); n == 0 || candle.Timestamp.After(merged[n-1].Timestamp) {
+			merged = append(merged, candle)
+		}
+	}
+	return merged
+}
+
+// LoadStoredCandles reads [start, end) of every backtested timeframe from a
+// candle store, plus the lookback each timeframe needs before start
+func LoadStoredCandles(store CandleStore, symbol string, start, end time.Time) (map[Timeframe][]Candle, error) {
+	candles := make(map[Timeframe][]Candle)
+	for _, timeframe := range []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute} {
+		from := start.Add(-time.Duration(BacktestLookback(timeframe)) * timeframe.Duration())
+		series, err := store.LoadCandles(symbol, timeframe, from, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load stored %s candles: %w", timeframe.String(), err)
+		}
+		if len(series) == 0 {
+			return nil, fmt.Errorf("no stored %s %s candles between %s and %s", symbol, timeframe.String(), from.Format("2006-01-02"), end.Format("2006-01-02"))
+		}
+		candles[timeframe] = series
+	}
+	return candles, nil
+}
@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCandleStore(t *testing.T) {
+	t.Log("🗄️ Testing the SQLite candle store, write-through and gap backfills")
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	candleAt := func(i int, close float64) Candle {
+		return Candle{Timestamp: start.Add(time.Duration(i) * 5 * time.Minute), Open: 100, High: 110, Low: 90, Close: close, Volume: 1}
+	}
+
+	path := filepath.Join(t.TempDir(), "data", "candles.db")
+	store, err := NewCandleStore(CandleStoreConfig{Enabled: true, Driver: "sqlite", Path: path})
+	if err != nil {
+		t.Fatalf("Failed to open candle store: %v", err)
+	}
+
+	// The timeframe manager writes through, replacing a forming candle in place
+	tm := NewTimeframeManager("BTCUSDT")
+	tm.SetStore(store)
+	for i := 0; i < 10; i++ {
+		tm.AddCandle(FiveMinute, candleAt(i, 100))
+	}
+	tm.AddCandle(FiveMinute, candleAt(9, 105))
+	if err := store.Close(); err != nil {
+		t.Fatalf("Failed to close candle store: %v", err)
+	}
+
+	// Candles survive reopening
+	store, err = NewCandleStore(CandleStoreConfig{Driver: "sqlite", Path: path})
+	if err != nil {
+		t.Fatalf("Failed to reopen candle store: %v", err)
+	}
+	defer store.Close()
+	latest, err := store.LatestCandles("BTCUSDT", FiveMinute, 3)
+	if err != nil || len(latest) != 3 || !latest[2].Timestamp.Equal(candleAt(9, 0).Timestamp) || latest[2].Close != 105 {
+		t.Fatalf("Expected the last 3 candles ending with the updated one, got %+v (err %v)", latest, err)
+	}
+	if ranged, _ := store.LoadCandles("BTCUSDT", FiveMinute, candleAt(2, 0).Timestamp, candleAt(5, 0).Timestamp); len(ranged) != 3 {
+		t.Errorf("Expected 3 candles in [2, 5), got %d", len(ranged))
+	}
+
+	// Punch a gap at 5-6: only the stored run up to it is reused, the rest is fetched
+	sqlite := store.(*SQLiteCandleStore)
+	if _, err := sqlite.db.Exec("DELETE FROM candles WHERE open_time IN (?, ?)", candleAt(5, 0).Timestamp.UnixMilli(), candleAt(6, 0).Timestamp.UnixMilli()); err != nil {
+		t.Fatalf("Failed to remove candles: %v", err)
+	}
+	var fetchedFrom time.Time
+	fetch := func(from, end time.Time) ([]Candle, error) {
+		fetchedFrom = from
+		candles := make([]Candle, 0)
+		for i := 0; i < 12; i++ {
+			if candle := candleAt(i, 200); !candle.Timestamp.Before(from) && candle.Timestamp.Before(end) {
+				candles = append(candles, candle)
+			}
+		}
+		return candles, nil
+	}
+	candles, err := loadRangeThroughStore(store, "BTCUSDT", FiveMinute, start, candleAt(12, 0).Timestamp, fetch)
+	if err != nil || len(candles) != 12 {
+		t.Fatalf("Expected 12 contiguous candles, got %d (err %v)", len(candles), err)
+	}
+	if !fetchedFrom.After(candleAt(3, 0).Timestamp) || fetchedFrom.After(candleAt(4, 0).Timestamp) || candles[3].Close != 100 || candles[4].Close != 200 {
+		t.Errorf("Expected stored candles through 3 and a fetch from 4, fetched from %v: %+v", fetchedFrom, candles)
+	}
+	if stored, _ := store.LoadCandles("BTCUSDT", FiveMinute, start, candleAt(12, 0).Timestamp); len(stored) != 12 {
+		t.Errorf("Expected the fetched candles to be stored, got %d", len(stored))
+	}
+
+	// Offline: a failed fetch falls back to what's stored
+	offline := func(from, end time.Time) ([]Candle, error) { return nil, fmt.Errorf("network unreachable") }
+	if candles, err := loadRangeThroughStore(store, "BTCUSDT", FiveMinute, start, candleAt(12, 0).Timestamp, offline); err != nil || len(candles) != 12 {
+		t.Errorf("Expected stored candles while offline, got %d (err %v)", len(candles), err)
+	}
+
+	// The store is selectable per timeframe for historical data only
+	config := DefaultConfig()
+	config.Providers = map[string]TimeframeProviderConfig{"5m": {Historical: "store"}}
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "candle_store.enabled") {
+		t.Errorf("Expected the store provider to require the candle store, got %v", err)
+	}
+	config.CandleStore.Enabled = true
+	if err := ValidateConfig(config); err != nil {
+		t.Errorf("Expected the store provider to validate: %v", err)
+	}
+	config.CandleStore.Driver = "postgres"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "candle_store.driver") {
+		t.Errorf("Expected an unknown driver to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the time source of the engine, executor, risk manager and candle
+// builders. SystemClock follows the wall clock; SimClock lets backtests and
+// tests advance virtual time deterministically.
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) ClockTicker
+	After(d time.Duration) <-chan time.Time
+}
+
+// ClockTicker delivers ticks every period until stopped
+type ClockTicker interface {
+	Chan() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the wall clock
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (systemClock) NewTicker(d time.Duration) ClockTicker  { return systemTicker{time.NewTicker(d)} }
+
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (t systemTicker) Chan() <-chan time.Time { return t.ticker.C }
+func (t systemTicker) Stop()                  { t.ticker.Stop() }
+
+// ClockFunc reads the time from a function, e.g. a frozen clock. Its tickers
+// and timers run on the wall clock.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time                         { return f() }
+func (f ClockFunc) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (f ClockFunc) NewTicker(d time.Duration) ClockTicker  { return SystemClock.NewTicker(d) }
+
+// SimClock is a virtual clock that only moves when advanced. Tickers and
+// timers fire at their due times as it passes them; like time.Ticker, a tick
+// is dropped when the previous one hasn't been received yet.
+type SimClock struct {
+	now     time.Time
+	waiters []*simWaiter
+	mutex   sync.Mutex
+}
+
+// simWaiter is a pending SimClock ticker (period > 0) or timer
+type simWaiter struct {
+	clock  *SimClock
+	due    time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+// NewSimClock creates a virtual clock starting at start
+func NewSimClock(start time.Time) *SimClock {
+	return &SimClock{now: start}
+}
+
+// Now returns the virtual time
+func (c *SimClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+// NewTicker creates a ticker firing every d of virtual time
+func (c *SimClock) NewTicker(d time.Duration) ClockTicker {
+	if d <= 0 {
+		panic("non-positive interval for SimClock.NewTicker")
+	}
+	return c.wait(d, d)
+}
+
+// After returns a channel receiving the virtual time once d has passed
+func (c *SimClock) After(d time.Duration) <-chan time.Time {
+	return c.wait(d, 0).ch
+}
+
+func (c *SimClock) wait(d, period time.Duration) *simWaiter {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	waiter := &simWaiter{clock: c, due: c.now.Add(d), period: period, ch: make(chan time.Time, 1)}
+	c.waiters = append(c.waiters, waiter)
+	return waiter
+}
+
+// Advance moves the clock forward by d, firing everything due on the way in order
+func (c *SimClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the clock to t, firing everything due by then. The clock never
+// moves backwards: an earlier t is ignored.
+func (c *SimClock) Set(t time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for {
+		next := -1
+		for i, waiter := range c.waiters {
+			if !waiter.due.After(t) && (next < 0 || waiter.due.Before(c.waiters[next].due)) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		waiter := c.waiters[next]
+		if waiter.due.After(c.now) {
+			c.now = waiter.due
+		}
+		select {
+		case waiter.ch <- c.now:
+		default:
+		}
+		if waiter.period > 0 {
+			waiter.due = waiter.due.Add(waiter.period)
+		} else {
+			c.waiters = append(c.waiters[:next], c.waiters[next+1:]...)
+		}
+	}
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+func (w *simWaiter) Chan() <-chan time.Time { return w.ch }
+
+// Stop removes the ticker from its clock
+func (w *simWaiter) Stop() {
+	c := w.clock
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i, waiter := range c.waiters {
+		if waiter == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return
+		}
+	}
+}
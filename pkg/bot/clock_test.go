@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimClock(t *testing.T) {
+	t.Log("⏱️ Testing the simulated clock, candle builders and session resets on virtual time")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewSimClock(start)
+
+	// Tickers and timers fire as virtual time passes them, never on their own
+	ticker := clock.NewTicker(time.Minute)
+	timer := clock.After(90 * time.Second)
+	clock.Advance(59 * time.Second)
+	select {
+	case <-ticker.Chan():
+		t.Fatal("Ticker fired before its interval")
+	case <-timer:
+		t.Fatal("Timer fired early")
+	default:
+	}
+	clock.Advance(time.Minute)
+	if tick := <-ticker.Chan(); !tick.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected the tick at its due time, got %v", tick)
+	}
+	if fired := <-timer; !fired.Equal(start.Add(90 * time.Second)) {
+		t.Errorf("Expected the timer at its due time, got %v", fired)
+	}
+	ticker.Stop()
+	clock.Advance(time.Hour)
+	select {
+	case <-ticker.Chan():
+		t.Error("Stopped ticker fired")
+	default:
+	}
+	clock.Set(start)
+	if !clock.Now().Equal(start.Add(time.Hour + 119*time.Second)) {
+		t.Errorf("Clock moved backwards to %v", clock.Now())
+	}
+
+	// The sample feed's candle builder closes candles on the simulated clock
+	clock = NewSimClock(start)
+	provider := NewSampleDataProvider([]string{"BTCUSDT"}, 50000)
+	provider.SetClock(clock)
+	defer provider.Close()
+	candles, err := provider.GetRealTimeData("BTCUSDT", FiveMinute)
+	if err != nil {
+		t.Fatalf("GetRealTimeData failed: %v", err)
+	}
+	var candle *Candle
+	for step := 0; step < 60 && candle == nil; step++ {
+		clock.Advance(5 * time.Second)
+		select {
+		case c := <-candles:
+			candle = &c
+		case <-time.After(2 * time.Millisecond):
+		}
+	}
+	if candle == nil || !candle.Timestamp.Equal(start) || candle.Volume == 0 {
+		t.Fatalf("Expected the 00:00 candle within 5 virtual minutes, got %+v", candle)
+	}
+
+	// The risk manager resets the daily loss when the virtual session rolls over
+	executor := NewTradeExecutor(DefaultConfig(), 10000)
+	executor.SetClock(clock)
+	executor.riskManager.DailyLossUsed = 0.03
+	clock.Advance(12 * time.Hour)
+	if executor.rollSession(); executor.riskManager.DailyLossUsed != 0.03 {
+		t.Error("Daily loss reset within the session")
+	}
+	clock.Advance(12 * time.Hour)
+	if session := executor.rollSession(); executor.riskManager.DailyLossUsed != 0 || !session.Equal(start.AddDate(0, 0, 1)) {
+		t.Errorf("Expected the loss reset at the next session, got %.2f from %v", executor.riskManager.DailyLossUsed, session)
+	}
+}
+
+func TestClockInjection(t *testing.T) {
+	t.Log("⏱️ Testing signals, feed updates, the watchdog and venue requests on an injected clock")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewSimClock(start)
+
+	// Signals are stamped with the aggregator's clock
+	sa := NewSignalAggregator(DefaultConfig())
+	sa.SetClock(clock)
+	candles := generateTestCandles(200, 50000)
+	signal, err := sa.GenerateSignal(&MultiTimeframeContext{Symbol: "BTCUSDT", FiveMinCandles: candles, FifteenMinCandles: candles,
+		FortyFiveMinCandles: candles, EightHourCandles: candles, DailyCandles: candles})
+	if err != nil || !signal.Timestamp.Equal(start) {
+		t.Fatalf("Expected the signal stamped at %v, got %+v (err %v)", start, signal, err)
+	}
+
+	// Feed updates are stamped with the manager's clock, and the watchdog runs on it
+	tm := NewTimeframeManager("BTCUSDT")
+	tm.SetClock(clock)
+	tm.AddCandle(FiveMinute, Candle{Timestamp: start, Close: 100})
+	if !tm.LastUpdate(FiveMinute).Equal(start) {
+		t.Errorf("Expected the update stamped at %v, got %v", start, tm.LastUpdate(FiveMinute))
+	}
+	reconnects := make(chan Timeframe, 10)
+	stop := make(chan struct{})
+	defer close(stop)
+	go tm.RunWatchdog(stop, time.Second, map[Timeframe]time.Duration{FiveMinute: 30 * time.Second}, func(tf Timeframe) error {
+		reconnects <- tf
+		return nil
+	})
+	var reconnected bool
+	for step := 0; step < 100 && !reconnected; step++ {
+		clock.Advance(time.Second)
+		select {
+		case <-reconnects:
+			reconnected = true
+		case <-time.After(2 * time.Millisecond):
+		}
+	}
+	if !reconnected || clock.Now().Sub(start) < 30*time.Second {
+		t.Errorf("Expected a reconnect once the feed was silent for 30 virtual seconds, got one after %v", clock.Now().Sub(start))
+	}
+
+	// Venue requests stop at the clock's current time
+	clock = NewSimClock(start.Add(time.Hour))
+	var pages int
+	interval := venueInterval{param: "300", length: 5 * time.Minute}
+	_, err = fetchVenueRange(clock, interval, FiveMinute, start, start.AddDate(0, 0, 1), 12, func(from, to time.Time) ([]Candle, error) {
+		pages++
+		return nil, nil
+	})
+	if err != nil || pages != 1 {
+		t.Errorf("Expected one page up to the virtual now, got %d (err %v)", pages, err)
+	}
+
+	// IDs stay unique while the clock stands still
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000)
+	executor.SetClock(clock)
+	executor.SetOrderPlacer(NewDryRunOrderPlacer(), LiveTradingConfig{Enabled: true, DryRun: true, OrderType: "MARKET"})
+	for _, signal := range []SignalType{Buy, Sell, Buy, Sell} {
+		if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: signal, Confidence: 0.9}, 100, 99.5); err != nil {
+			t.Fatalf("Frozen-clock %s failed: %v", signal, err)
+		}
+	}
+	trades := executor.GetTradeHistory(0)
+	if len(trades) < 2 || trades[0].ID == trades[1].ID || !trades[0].EntryTime.Equal(clock.Now()) {
+		t.Errorf("Expected distinct trade IDs at the frozen time %v, got %+v", clock.Now(), trades)
+	}
+}
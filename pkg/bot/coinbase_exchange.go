@@ -0,0 +1,218 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// coinbaseMaxCandles is the most candles Coinbase returns per request
+const coinbaseMaxCandles = 300
+
+// CoinbaseExchange implements Exchange for the Coinbase Exchange (spot) API.
+// Real-time candles are polled; 8h candles are aggregated from hourly ones.
+type CoinbaseExchange struct {
+	*candlePoller
+	baseURL     string
+	credentials ExchangeCredentials
+	httpClient  *http.Client
+}
+
+// NewCoinbaseExchange creates a Coinbase Exchange client
+func NewCoinbaseExchange(credentials ExchangeCredentials) *CoinbaseExchange {
+	return &CoinbaseExchange{
+		candlePoller: newCandlePoller(30 * time.Second),
+		baseURL:      "https://api.exchange.coinbase.com",
+		credentials:  credentials,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns the venue name
+func (c *CoinbaseExchange) Name() string {
+	return "coinbase"
+}
+
+// productID maps a symbol to a Coinbase product (BTCUSDT -> BTC-USD)
+func (c *CoinbaseExchange) productID(symbol string) (string, error) {
+	base, quote, err := venuePair(symbol)
+	if err != nil {
+		return "", err
+	}
+	return base + "-" + quote, nil
+}
+
+// interval maps a timeframe to a Coinbase candle granularity in seconds
+func (c *CoinbaseExchange) interval(timeframe Timeframe) venueInterval {
+	switch timeframe {
+	case FifteenMinute:
+		return venueInterval{param: "900", length: 15 * time.Minute}
+	case FortyFiveMinute:
+		return venueInterval{param: "3600", length: time.Hour} // No 45m, use 1h as closest
+	case EightHour:
+		return venueInterval{param: "3600", length: time.Hour, aggregate: true}
+	case Daily:
+		return venueInterval{param: "86400", length: 24 * time.Hour}
+	default:
+		return venueInterval{param: "300", length: 5 * time.Minute}
+	}
+}
+
+// getJSON performs a public GET request
+func (c *CoinbaseExchange) getJSON(path string, params url.Values, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	return doExchangeRequest(c.httpClient, req, out)
+}
+
+// GetHistoricalData fetches the latest count candles
+func (c *CoinbaseExchange) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	return latestVenueCandles(c, c.clock, symbol, timeframe, c.interval(timeframe), count)
+}
+
+// GetHistoricalRange fetches all candles opening in [start, end), paging past the 300-candle limit
+func (c *CoinbaseExchange) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	product, err := c.productID(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval := c.interval(timeframe)
+
+	return fetchVenueRange(c.clock, interval, timeframe, start, end, coinbaseMaxCandles, func(from, to time.Time) ([]Candle, error) {
+		params := url.Values{}
+		params.Add("granularity", interval.param)
+		params.Add("start", from.UTC().Format(time.RFC3339))
+		params.Add("end", to.Add(-time.Second).UTC().Format(time.RFC3339))
+
+		// Rows are [time, low, high, open, close, volume], newest first
+		var rows [][]interface{}
+		if err := c.getJSON("/products/"+product+"/candles", params, &rows); err != nil {
+			return nil, err
+		}
+		candles := make([]Candle, 0, len(rows))
+		for i, row := range rows {
+			values, err := parseFloats(jsonStrings(row), "time", "low", "high", "open", "close", "volume")
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert candle %d: %w", i, err)
+			}
+			candles = append(candles, Candle{
+				Timestamp: time.Unix(int64(values[0]), 0),
+				Low:       values[1],
+				High:      values[2],
+				Open:      values[3],
+				Close:     values[4],
+				Volume:    values[5],
+			})
+		}
+		return candles, nil
+	})
+}
+
+// GetRealTimeData polls for completed candles
+func (c *CoinbaseExchange) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	return c.poll(symbol, timeframe, c.GetHistoricalData), nil
+}
+
+// GetTicker fetches the last trade and top of book
+func (c *CoinbaseExchange) GetTicker(symbol string) (*Ticker, error) {
+	product, err := c.productID(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickerResp struct {
+		Price  string `json:"price"`
+		Bid    string `json:"bid"`
+		Ask    string `json:"ask"`
+		Volume string `json:"volume"`
+	}
+	if err := c.getJSON("/products/"+product+"/ticker", nil, &tickerResp); err != nil {
+		return nil, err
+	}
+	values, err := parseFloats([]string{tickerResp.Price, tickerResp.Bid, tickerResp.Ask, tickerResp.Volume}, "price", "bid", "ask", "volume")
+	if err != nil {
+		return nil, err
+	}
+	return &Ticker{Symbol: symbol, Last: values[0], Bid: values[1], Ask: values[2], Volume: values[3], Timestamp: time.Now()}, nil
+}
+
+// GetOrderBook fetches the aggregated order book, trimmed to depth levels per side
+func (c *CoinbaseExchange) GetOrderBook(symbol string, depth int) (*OrderBook, error) {
+	product, err := c.productID(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("level", "2")
+	var bookResp struct {
+		Bids [][]interface{} `json:"bids"` // [price, size, num-orders]
+		Asks [][]interface{} `json:"asks"`
+	}
+	if err := c.getJSON("/products/"+product+"/book", params, &bookResp); err != nil {
+		return nil, err
+	}
+
+	book := &OrderBook{Symbol: symbol, Timestamp: time.Now()}
+	if book.Bids, err = parseBookLevels(bookResp.Bids, depth); err != nil {
+		return nil, err
+	}
+	if book.Asks, err = parseBookLevels(bookResp.Asks, depth); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// GetBalances fetches the total balance of every account
+func (c *CoinbaseExchange) GetBalances() (map[string]float64, error) {
+	if c.credentials.APIKey == "" || c.credentials.SecretKey == "" || c.credentials.Passphrase == "" {
+		return nil, fmt.Errorf("API key, secret and passphrase required for /accounts")
+	}
+	secret, err := base64.StdEncoding.DecodeString(c.credentials.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Coinbase secret: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + http.MethodGet + "/accounts"))
+
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+"/accounts", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("CB-ACCESS-KEY", c.credentials.APIKey)
+	req.Header.Set("CB-ACCESS-SIGN", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("CB-ACCESS-TIMESTAMP", timestamp)
+	req.Header.Set("CB-ACCESS-PASSPHRASE", c.credentials.Passphrase)
+
+	var accounts []struct {
+		Currency string `json:"currency"`
+		Balance  string `json:"balance"`
+	}
+	if err := doExchangeRequest(c.httpClient, req, &accounts); err != nil {
+		return nil, err
+	}
+	balances := make(map[string]float64)
+	for _, account := range accounts {
+		balance, err := strconv.ParseFloat(account.Balance, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s balance: %w", account.Currency, err)
+		}
+		if balance != 0 {
+			balances[account.Currency] = balance
+		}
+	}
+	return balances, nil
+}
@@ -4,13 +4,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"strings"
+	"sync"
+	"time"
+
+	"trading-bot/pkg/indicator"
 )
 
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() Config {
 	return Config{
+		Version: CurrentConfigVersion,
 		RSI: RSIConfig{
 			Enabled:    true, // RSI enabled by default
 			Period:     14,
@@ -110,12 +116,44 @@ func DefaultConfig() Config {
 			ChannelThreshold: 0.2,  // 0.2% threshold for sensitive channel detection
 			SignalBoost:      1.4,  // 1.4x boost for confirmed channel signals
 		},
+		KeltnerChannel: KeltnerChannelConfig{
+			Enabled:    false,
+			EMAPeriod:  20,
+			ATRPeriod:  10,
+			Multiplier: 2.0,
+			Mode:       ChannelModeMeanReversion, // Fade stretches to the bands
+		},
+		DonchianChannel: DonchianChannelConfig{
+			Enabled: false,
+			Period:  20,
+			Mode:    ChannelModeBreakout, // Turtle-style new highs and lows
+		},
+		ParabolicSAR: ParabolicSARConfig{
+			Enabled: false,
+			Step:    0.02, // Wilder's acceleration factor
+			MaxStep: 0.2,
+		},
+		CandlePatterns: CandlePatternsConfig{
+			Enabled:          false,
+			Patterns:         indicator.CandlePatternNames(),
+			TweezerTolerance: 0.05,
+		},
 		ATR: ATRConfig{
 			Enabled:    true,  // ATR enabled by default
 			Period:     7,     // Pine Script: Length 7 for ATR calculation
 			Multiplier: 1.0,   // Pine Script: ATR Multiplier 1 for trailing stop distance
 			UseShorts:  false, // Disable shorts for spot trading
 		},
+		Strategy: StrategyConfig{
+			Mode: StrategyModeFiveMinuteFocus,
+			TimeframeWeights: map[string]float64{
+				Daily.String():           0.25,
+				EightHour.String():       0.20,
+				FortyFiveMinute.String(): 0.20,
+				FifteenMinute.String():   0.20,
+				FiveMinute.String():      0.15,
+			},
+		},
 		MinConfidence: 0.6, // 60% minimum confidence
 		Symbol:        "BTCUSDT",
 		Binance: BinanceConfig{
@@ -124,6 +162,209 @@ func DefaultConfig() Config {
 			UseTestnet: false,
 		},
 		DataProvider: "binance", // FIXED: Use live Binance futures data instead of sample
+		Streaming: StreamingConfig{
+			Enabled:           true,
+			StaleSeconds:      30, // Binance pushes kline updates every ~250ms
+			MaxBackoffSeconds: 60,
+
+			WatchdogSeconds:    10,
+			DegradedConfidence: 0.7, // Silent feeds cut confidence by 30%
+		},
+		DataQuality: DataQualityConfig{
+			GapConfidence:      0.85,
+			FallbackConfidence: 0.9,
+		},
+		SymbolSelector: SymbolSelectorConfig{
+			Enabled:      false,
+			Quotes:       []string{"USDT"},
+			Top:          10,
+			Exclude:      []string{"USDCUSDT", "FDUSDUSDT"},
+			RefreshHours: 24,
+		},
+		CandleStore: CandleStoreConfig{
+			Enabled: false,
+			Driver:  "sqlite",
+			Path:    "data/candles.db",
+		},
+		Divergence: DivergenceConfig{
+			Enabled:    false,
+			Indicators: []string{"rsi", "macd", "mfi", "stochastic"},
+			PivotBars:  3,
+			Lookback:   60,
+			Hidden:     true,
+		},
+		History: HistoryConfig{
+			Candles:     map[string]int{"1d": 200, "8h": 80, "45m": 60, "15m": 80, "5m": 100},
+			OnShortfall: HistoryShortfallFail,
+		},
+		Account: AccountConfig{
+			InitialBalance: 10000,
+			Compounding:    false, // Fixed-fractional sizing from the initial capital
+		},
+		Backtest: BacktestConfig{
+			FeePercent:  0.04, // Binance futures taker fee
+			SlippageBps: 1,
+		},
+		Session: SessionConfig{
+			Timezone:  "UTC",
+			StartTime: "00:00",
+		},
+		Contract: ContractConfig{
+			Type:         ContractLinear,
+			ContractSize: 100, // Binance COIN-M BTCUSD perpetual; most other coins use 10
+		},
+		Continuous: ContinuousConfig{
+			Enabled:    false,
+			RollDays:   3,
+			Adjustment: AdjustDifference,
+		},
+		Margin: MarginConfig{
+			Enabled:                  false, // Spot-style accounting unless trading futures
+			Leverage:                 5,
+			MaintenanceMarginRate:    0.004, // Binance BTCUSDT first tier
+			MaxMarginRatio:           0.5,   // Keep maintenance margin under half the margin balance
+			LiquidationBufferPercent: 5,     // Alert when liquidation is within 5% of price
+		},
+		Funding: FundingConfig{
+			Enabled:       false, // Spot positions pay no funding
+			IntervalHours: 8,     // Binance settles at 00:00, 08:00 and 16:00 UTC
+			Rate:          0.0001,
+			Rates:         map[string]float64{},
+		},
+		Fees: FeeConfig{
+			Enabled:            false, // Backtests charge backtest.fee_percent instead
+			Tier:               "VIP0",
+			BNBDiscountPercent: 10,
+		},
+		Reconciliation: ReconciliationConfig{
+			Enabled:         false, // Needs API keys with read access
+			IntervalSeconds: 30,
+		},
+		LiveTrading: LiveTradingConfig{
+			Enabled:    false, // Orders are simulated unless explicitly enabled
+			DryRun:     true,
+			Market:     MarketFutures,
+			OrderType:  "MARKET",
+			PlaceStops: true,
+			UserStream: true,
+		},
+		TrailingStop: TrailingStopConfig{
+			Method: TrailingStopATR,
+		},
+		BacktestDir: "backtests",
+		NightlyBacktest: NightlyBacktestConfig{
+			Enabled:          false, // Opt-in: downloads 30 days of candles nightly
+			HourUTC:          2,
+			WindowDays:       []int{7, 30},
+			MaxDrawdownAlert: 10, // Warn when a window draws down more than 10%
+		},
+		Rebalance: RebalanceConfig{
+			Enabled:         false, // Passive allocation is opt-in
+			TargetWeights:   map[string]float64{},
+			ThresholdBand:   0.05, // Rebalance when 5% away from target weight
+			IntervalMinutes: 60,   // Check hourly
+			MinTradeValue:   10,   // Ignore adjustments under 10 quote units
+		},
+		Hedging: HedgingConfig{
+			Enabled: false, // Hedging is opt-in
+			Rules:   []HedgeRule{},
+		},
+		Correlation: CorrelationConfig{
+			Enabled:          false, // Correlation limits are opt-in
+			IntervalMinutes:  5,
+			Window:           96, // 8 hours of 5-minute returns
+			Threshold:        0.7,
+			MaxConcentration: 0.5,
+			Action:           CorrelationActionDownsize,
+			BetaBenchmark:    "BTCUSDT",
+		},
+		Scripting: ScriptingConfig{
+			Enabled:      false, // Scripted rules are opt-in
+			File:         "strategy.rules",
+			PositionSize: 0.1,   // Spend 10% of the strategy's cash per entry
+			MaxSteps:     10000, // Far above what any sane rule set needs
+		},
+		Pine: PineConfig{
+			Enabled:  false, // Studies are opt-in
+			Scripts:  []string{},
+			Strength: 0.7,
+		},
+		Determinism: DeterminismConfig{
+			Enabled: false, // Wall clock and random seeds by default
+			Seed:    42,
+			Clock:   "2024-01-01T00:00:00Z",
+		},
+		Prediction: PredictionConfig{
+			RoundTripCostPercent: 0.08, // Two 0.04% futures taker fills
+			NeutralATRFraction:   0.25,
+			MildMovePercent:      0.1,
+			StrongMovePercent:    0.3,
+		},
+		PriceSource: PriceSourceLast, // Last trade price; "mark", "mid" or "index" resist thin-book prints
+		PriceIndex: PriceIndexConfig{
+			Venues:    []string{"binance", "coinbase", "kraken"},
+			MinVenues: 2, // Median of at least two venues
+		},
+		RegimeSwitching: RegimeSwitchingConfig{
+			Enabled:             false, // Single weight set unless opted in
+			Lookback:            50,    // ~4 hours of 5m candles
+			TrendThreshold:      0.3,   // Net move is 30% of the distance travelled
+			VolatilityThreshold: 0.5,   // Average 5m range above 0.5% of price
+			Profiles: map[string]RegimeProfile{
+				RegimeTrending: {Weights: map[string]float64{"Trend": 1.5, "EMA": 1.5, "MACD": 1.5, "RSI": 0.75, "Stochastic": 0.75, "Williams": 0.75}},
+				RegimeRanging:  {Weights: map[string]float64{"RSI": 1.5, "BollingerBands": 1.5, "Stochastic": 1.25, "Williams": 1.25, "Trend": 0.75, "EMA": 0.75}},
+				RegimeVolatile: {Weights: map[string]float64{"ATR": 1.5, "Volume": 1.25}, MinConfidence: 0.85},
+			},
+		},
+		Seasonality: SeasonalityConfig{
+			LookbackDays: 90,    // ~3 months of 15m candles
+			RefreshHours: 24,    // Recompute daily
+			PriorEnabled: false, // Statistics only unless opted in
+			PriorWeight:  0.5,   // A full bias counts as half an indicator vote
+			MinSamples:   30,
+		},
+		SafeMode: SafeModeConfig{
+			Enabled:           true,  // Stop new entries when the exchange looks down
+			FailureThreshold:  5,     // 5 failures...
+			WindowSeconds:     300,   // ...within 5 minutes
+			FlattenPositions:  false, // Keep positions; trailing stops still manage exits
+			RecoverySuccesses: 3,     // Resume after 3 consecutive successful price fetches
+		},
+		Heartbeat: HeartbeatConfig{
+			Enabled:             false, // Needs a URL or MQTT topic
+			IntervalSeconds:     60,
+			MaxSignalAgeSeconds: 180, // Signals are generated every minute
+		},
+		MQTT: MQTTConfig{
+			ClientID: "nexus-bot",
+			QoS:      1, // At least once
+			Topics: map[string]string{
+				string(EventSignal):     "nexus-bot/signals",
+				string(EventTrade):      "nexus-bot/trades",
+				string(EventPrediction): "nexus-bot/predictions",
+			},
+		},
+		EventExport: EventExportConfig{
+			Enabled: false, // Needs a Kafka or NATS broker
+			Backend: ExportBackendKafka,
+			Brokers: []string{"localhost:9092"},
+			Topics: map[string]string{
+				string(EventSignal):     "nexus-bot.signals",
+				string(EventTrade):      "nexus-bot.trades",
+				string(EventPrediction): "nexus-bot.predictions",
+				string(EventError):      "nexus-bot.errors",
+			},
+			MaxPending:     10000,
+			RetryBackoffMs: 500,
+		},
+		Maintenance: MaintenanceConfig{
+			Windows:             []MaintenanceWindow{},
+			PauseEntriesMinutes: 15, // Don't open positions that can't be managed during downtime
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: LogFormatConsole,
+		},
 	}
 }
 
@@ -147,14 +388,31 @@ func LoadConfig(filename string) (Config, error) {
 		return config, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	// Upgrade older schemas before parsing
+	data, _, err = MigrateConfigData(data)
+	if err != nil {
+		return config, err
+	}
+
 	// Parse JSON
 	if err := json.Unmarshal(data, &config); err != nil {
 		return config, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	if unknown, err := UnknownConfigFields(data); err == nil {
+		for _, field := range unknown {
+			configLog.Warn("Ignoring unknown config field", "field", field)
+		}
+	}
+	config = normalizeConfigSymbols(config)
 
 	// Load API keys from environment variables if not set in config
 	config = loadAPIKeysFromEnv(config)
 
+	// Plugins register their indicators before validation looks them up
+	if err := LoadIndicatorPlugins(config.PluginFiles); err != nil {
+		return config, err
+	}
+
 	// Validate configuration
 	if err := ValidateConfig(config); err != nil {
 		return config, fmt.Errorf("invalid configuration: %w", err)
@@ -163,23 +421,64 @@ func LoadConfig(filename string) (Config, error) {
 	return config, nil
 }
 
+// normalizeConfigSymbols normalizes the configured symbols (btc-usdt -> BTCUSDT)
+func normalizeConfigSymbols(config Config) Config {
+	config.Symbol = NormalizeSymbol(config.Symbol)
+	if len(config.Symbols) > 0 {
+		symbols := make([]string, len(config.Symbols))
+		for i, symbol := range config.Symbols {
+			symbols[i] = NormalizeSymbol(symbol)
+		}
+		config.Symbols = symbols
+	}
+	return config
+}
+
 // loadAPIKeysFromEnv loads API keys from environment variables if not set in config
 func loadAPIKeysFromEnv(config Config) Config {
 	// Load Binance API keys from environment variables if not set
+	envAPIKey, envSecretKey, err := BinanceCredentialsFromEnv()
+	if err != nil {
+		configLog.Warn("Ignoring Binance credentials from the environment", "error", err)
+	}
+
 	if config.Binance.APIKey == "" || strings.Contains(config.Binance.APIKey, "YOUR_") {
-		if envAPIKey := os.Getenv("BINANCE_API_KEY"); envAPIKey != "" {
+		if envAPIKey != "" {
 			config.Binance.APIKey = envAPIKey
-			fmt.Println("📊 Loaded Binance API Key from environment variable")
+			configLog.Info("📊 Loaded Binance API key from environment variable")
 		}
 	}
 
 	if config.Binance.SecretKey == "" || strings.Contains(config.Binance.SecretKey, "YOUR_") {
-		if envSecretKey := os.Getenv("BINANCE_SECRET_KEY"); envSecretKey != "" {
+		if envSecretKey != "" {
 			config.Binance.SecretKey = envSecretKey
-			fmt.Println("🔐 Loaded Binance Secret Key from environment variable")
+			configLog.Info("🔐 Loaded Binance secret key from environment variable")
 		}
 	}
 
+	// Keys of the other venues fill in whatever the config leaves empty
+	for venue, credentials := range map[string]*ExchangeCredentials{"coinbase": &config.Coinbase, "kraken": &config.Kraken, "bybit": &config.Bybit} {
+		env, err := ExchangeCredentialsFromEnv(venue)
+		if err != nil {
+			configLog.Warn("Ignoring venue credentials from the environment", "venue", venue, "error", err)
+			continue
+		}
+		if credentials.APIKey == "" && credentials.SecretKey == "" && env.APIKey != "" {
+			*credentials = env
+			configLog.Info("📊 Loaded venue API keys from environment variables", "venue", venue)
+		}
+	}
+
+	if envAdminToken := os.Getenv("ADMIN_TOKEN"); envAdminToken != "" {
+		config.Admin.Token = envAdminToken
+		configLog.Info("🛡️ Loaded admin token from environment variable")
+	}
+
+	if envTelegramToken := os.Getenv("TELEGRAM_BOT_TOKEN"); envTelegramToken != "" {
+		config.Notifications.Telegram.Token = envTelegramToken
+		configLog.Info("💬 Loaded Telegram bot token from environment variable")
+	}
+
 	return config
 }
 
@@ -199,103 +498,272 @@ func SaveConfig(config Config, filename string) error {
 	return nil
 }
 
-// ValidateConfig validates the configuration parameters
+// FieldError is one invalid config setting
+type FieldError struct {
+	Field   string `json:"field"` // JSON path of the setting, e.g. "ichimoku.tenkan_period"
+	Message string `json:"message"`
+}
+
+// ValidationErrors lists every problem ValidateConfig found
+type ValidationErrors []FieldError
+
+// Error joins all problems so callers that only print the error still see each one
+func (e ValidationErrors) Error() string {
+	parts := make([]string, len(e))
+	for i, fieldErr := range e {
+		parts[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return fmt.Sprintf("%d config problems: %s", len(parts), strings.Join(parts, "; "))
+}
+
+// add records a problem with a field
+func (e *ValidationErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns nil when nothing was recorded
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidateConfig validates the configuration parameters, returning every problem
+// found as ValidationErrors rather than stopping at the first
 func ValidateConfig(config Config) error {
+	var errs ValidationErrors
+
 	// Validate RSI
 	if config.RSI.Period < 1 || config.RSI.Period > 100 {
-		return fmt.Errorf("RSI period must be between 1 and 100")
+		errs.add("rsi.period", "RSI period must be between 1 and 100")
 	}
 	if config.RSI.Overbought <= config.RSI.Oversold {
-		return fmt.Errorf("RSI overbought level must be greater than oversold level")
+		errs.add("rsi.overbought", "RSI overbought level must be greater than oversold level")
 	}
 	if config.RSI.Overbought < 50 || config.RSI.Overbought > 100 {
-		return fmt.Errorf("RSI overbought level must be between 50 and 100")
+		errs.add("rsi.overbought", "RSI overbought level must be between 50 and 100")
 	}
 	if config.RSI.Oversold < 0 || config.RSI.Oversold > 50 {
-		return fmt.Errorf("RSI oversold level must be between 0 and 50")
+		errs.add("rsi.oversold", "RSI oversold level must be between 0 and 50")
 	}
 
 	// Validate MACD
 	if config.MACD.FastPeriod < 1 || config.MACD.FastPeriod > 50 {
-		return fmt.Errorf("MACD fast period must be between 1 and 50")
+		errs.add("macd.fast_period", "MACD fast period must be between 1 and 50")
 	}
 	if config.MACD.SlowPeriod < 1 || config.MACD.SlowPeriod > 100 {
-		return fmt.Errorf("MACD slow period must be between 1 and 100")
+		errs.add("macd.slow_period", "MACD slow period must be between 1 and 100")
 	}
 	if config.MACD.SignalPeriod < 1 || config.MACD.SignalPeriod > 50 {
-		return fmt.Errorf("MACD signal period must be between 1 and 50")
+		errs.add("macd.signal_period", "MACD signal period must be between 1 and 50")
 	}
 	if config.MACD.FastPeriod >= config.MACD.SlowPeriod {
-		return fmt.Errorf("MACD fast period must be less than slow period")
+		errs.add("macd.fast_period", "MACD fast period must be less than slow period")
 	}
 
 	// Validate Volume
 	if config.Volume.Period < 1 || config.Volume.Period > 100 {
-		return fmt.Errorf("Volume period must be between 1 and 100")
+		errs.add("volume.period", "Volume period must be between 1 and 100")
 	}
 	if config.Volume.VolumeThreshold < 0 {
-		return fmt.Errorf("Volume threshold must be positive")
+		errs.add("volume.volume_threshold", "Volume threshold must be positive")
 	}
 
 	// Validate Trend
 	if config.Trend.ShortMA < 1 || config.Trend.ShortMA > 100 {
-		return fmt.Errorf("Trend short MA must be between 1 and 100")
+		errs.add("trend.short_ma", "Trend short MA must be between 1 and 100")
 	}
 	if config.Trend.LongMA < 1 || config.Trend.LongMA > 200 {
-		return fmt.Errorf("Trend long MA must be between 1 and 200")
+		errs.add("trend.long_ma", "Trend long MA must be between 1 and 200")
 	}
 	if config.Trend.ShortMA >= config.Trend.LongMA {
-		return fmt.Errorf("Trend short MA must be less than long MA")
+		errs.add("trend.short_ma", "Trend short MA must be less than long MA")
 	}
 
 	// Validate Support/Resistance
 	if config.SupportResistance.Period < 1 || config.SupportResistance.Period > 100 {
-		return fmt.Errorf("Support/Resistance period must be between 1 and 100")
+		errs.add("support_resistance.period", "Support/Resistance period must be between 1 and 100")
 	}
 	if config.SupportResistance.Threshold < 0 || config.SupportResistance.Threshold > 1 {
-		return fmt.Errorf("Support/Resistance threshold must be between 0 and 1")
+		errs.add("support_resistance.threshold", "Support/Resistance threshold must be between 0 and 1")
 	}
 
 	// Validate Ichimoku
 	if config.Ichimoku.TenkanPeriod < 1 || config.Ichimoku.TenkanPeriod > 50 {
-		return fmt.Errorf("Ichimoku Tenkan period must be between 1 and 50")
+		errs.add("ichimoku.tenkan_period", "Ichimoku Tenkan period must be between 1 and 50")
 	}
 	if config.Ichimoku.KijunPeriod < 1 || config.Ichimoku.KijunPeriod > 100 {
-		return fmt.Errorf("Ichimoku Kijun period must be between 1 and 100")
+		errs.add("ichimoku.kijun_period", "Ichimoku Kijun period must be between 1 and 100")
 	}
 	if config.Ichimoku.SenkouPeriod < 1 || config.Ichimoku.SenkouPeriod > 200 {
-		return fmt.Errorf("Ichimoku Senkou period must be between 1 and 200")
+		errs.add("ichimoku.senkou_period", "Ichimoku Senkou period must be between 1 and 200")
 	}
 	if config.Ichimoku.Displacement < 1 || config.Ichimoku.Displacement > 100 {
-		return fmt.Errorf("Ichimoku displacement must be between 1 and 100")
+		errs.add("ichimoku.displacement", "Ichimoku displacement must be between 1 and 100")
 	}
 	if config.Ichimoku.TenkanPeriod >= config.Ichimoku.KijunPeriod {
-		return fmt.Errorf("Ichimoku Tenkan period must be less than Kijun period")
+		errs.add("ichimoku.tenkan_period", "Ichimoku Tenkan period must be less than Kijun period")
 	}
 	if config.Ichimoku.KijunPeriod >= config.Ichimoku.SenkouPeriod {
-		return fmt.Errorf("Ichimoku Kijun period must be less than Senkou period")
+		errs.add("ichimoku.kijun_period", "Ichimoku Kijun period must be less than Senkou period")
 	}
 
 	// Validate MFI
 	if config.MFI.Period < 1 || config.MFI.Period > 100 {
-		return fmt.Errorf("MFI period must be between 1 and 100")
+		errs.add("mfi.period", "MFI period must be between 1 and 100")
 	}
 	if config.MFI.Overbought <= config.MFI.Oversold {
-		return fmt.Errorf("MFI overbought level must be greater than oversold level")
+		errs.add("mfi.overbought", "MFI overbought level must be greater than oversold level")
 	}
 	if config.MFI.Overbought < 50 || config.MFI.Overbought > 100 {
-		return fmt.Errorf("MFI overbought level must be between 50 and 100")
+		errs.add("mfi.overbought", "MFI overbought level must be between 50 and 100")
 	}
 	if config.MFI.Oversold < 0 || config.MFI.Oversold > 50 {
-		return fmt.Errorf("MFI oversold level must be between 0 and 50")
+		errs.add("mfi.oversold", "MFI oversold level must be between 0 and 50")
+	}
+
+	// Validate Keltner and Donchian channels
+	channelModes := map[string]bool{ChannelModeBreakout: true, ChannelModeMeanReversion: true}
+	if config.KeltnerChannel.Enabled {
+		if config.KeltnerChannel.EMAPeriod < 1 || config.KeltnerChannel.EMAPeriod > 200 {
+			errs.add("keltner_channel.ema_period", "Keltner Channel EMA period must be between 1 and 200")
+		}
+		if config.KeltnerChannel.ATRPeriod < 1 || config.KeltnerChannel.ATRPeriod > 200 {
+			errs.add("keltner_channel.atr_period", "Keltner Channel ATR period must be between 1 and 200")
+		}
+		if config.KeltnerChannel.Multiplier <= 0 || config.KeltnerChannel.Multiplier > 10 {
+			errs.add("keltner_channel.multiplier", "Keltner Channel multiplier must be between 0 and 10")
+		}
+		if !channelModes[config.KeltnerChannel.Mode] {
+			errs.add("keltner_channel.mode", "unknown Keltner Channel mode %q (use breakout or mean_reversion)", config.KeltnerChannel.Mode)
+		}
+	}
+	if config.DonchianChannel.Enabled {
+		if config.DonchianChannel.Period < 1 || config.DonchianChannel.Period > 200 {
+			errs.add("donchian_channel.period", "Donchian Channel period must be between 1 and 200")
+		}
+		if !channelModes[config.DonchianChannel.Mode] {
+			errs.add("donchian_channel.mode", "unknown Donchian Channel mode %q (use breakout or mean_reversion)", config.DonchianChannel.Mode)
+		}
+	}
+
+	// Validate Parabolic SAR and the trailing stop it can drive
+	if config.ParabolicSAR.Enabled {
+		if config.ParabolicSAR.Step <= 0 || config.ParabolicSAR.Step > 1 {
+			errs.add("parabolic_sar.step", "Parabolic SAR step must be between 0 and 1")
+		}
+		if config.ParabolicSAR.MaxStep < config.ParabolicSAR.Step || config.ParabolicSAR.MaxStep > 1 {
+			errs.add("parabolic_sar.max_step", "Parabolic SAR max step must be between step and 1")
+		}
+	}
+	// Validate candlestick patterns
+	if config.CandlePatterns.Enabled {
+		for i, pattern := range config.CandlePatterns.Patterns {
+			if !indicator.IsCandlePattern(pattern) {
+				errs.add(fmt.Sprintf("candle_patterns.patterns[%d]", i), "unknown candle pattern %q (use %s)", pattern, strings.Join(indicator.CandlePatternNames(), ", "))
+			}
+		}
+		if config.CandlePatterns.TweezerTolerance <= 0 || config.CandlePatterns.TweezerTolerance > 1 {
+			errs.add("candle_patterns.tweezer_tolerance", "tweezer tolerance must be between 0 and 1")
+		}
+	}
+	switch config.TrailingStop.Method {
+	case "", TrailingStopATR:
+	case TrailingStopPSAR:
+		if !config.ParabolicSAR.Enabled {
+			errs.add("trailing_stop.method", "psar trailing stops require parabolic_sar to be enabled")
+		}
+	default:
+		errs.add("trailing_stop.method", "unknown trailing stop method %q (use atr or psar)", config.TrailingStop.Method)
+	}
+
+	// Validate per-indicator candle transforms
+	if config.Divergence.Enabled {
+		if config.Divergence.PivotBars < 1 || config.Divergence.PivotBars > 20 {
+			errs.add("divergence.pivot_bars", "divergence pivot bars must be between 1 and 20")
+		}
+		if config.Divergence.Lookback < 2*config.Divergence.PivotBars+2 {
+			errs.add("divergence.lookback", "divergence lookback must cover two swings (at least %d candles)", 2*config.Divergence.PivotBars+2)
+		}
+		if len(config.Divergence.Indicators) == 0 {
+			errs.add("divergence.indicators", "divergence needs at least one oscillator")
+		}
+		for i, name := range config.Divergence.Indicators {
+			if info, ok := LookupIndicator(name); !ok || !divergenceIndicators[info.Name] {
+				errs.add(fmt.Sprintf("divergence.indicators[%d]", i), "%s is not a divergence oscillator (use rsi, macd, mfi or stochastic)", name)
+			}
+		}
+	}
+	for _, name := range sortedKeys(config.CandleTransforms) {
+		if _, ok := LookupIndicator(name); !ok {
+			errs.add("candle_transforms."+name, "unknown indicator %s", name)
+		}
+		if _, ok := indicator.LookupCandleTransform(config.CandleTransforms[name]); !ok {
+			errs.add("candle_transforms."+name, "unknown candle transform %q (use %s)",
+				config.CandleTransforms[name], strings.Join(indicator.CandleTransformNames(), ", "))
+		}
+	}
+
+	// Validate the aggregation mode
+	switch config.Strategy.Mode {
+	case StrategyModeFiveMinuteFocus, StrategyModeMultiTimeframe:
+	default:
+		errs.add("strategy.mode", "strategy mode must be %s or %s, got %q", StrategyModeFiveMinuteFocus, StrategyModeMultiTimeframe, config.Strategy.Mode)
+	}
+	totalWeight := 0.0
+	for _, name := range sortedKeys(config.Strategy.TimeframeWeights) {
+		weight := config.Strategy.TimeframeWeights[name]
+		if _, err := ParseTimeframe(name); err != nil {
+			errs.add("strategy.timeframe_weights."+name, "unknown timeframe %s (use 5m, 15m, 45m, 8h or 1d)", name)
+		}
+		if weight < 0 {
+			errs.add("strategy.timeframe_weights."+name, "timeframe weight cannot be negative")
+		}
+		totalWeight += weight
+	}
+	if config.Strategy.Mode == StrategyModeMultiTimeframe && totalWeight <= 0 {
+		errs.add("strategy.timeframe_weights", "multi_timeframe mode needs at least one positive timeframe weight")
 	}
 
 	// Validate general settings
 	if config.MinConfidence < 0 || config.MinConfidence > 1 {
-		return fmt.Errorf("Minimum confidence must be between 0 and 1")
+		errs.add("min_confidence", "Minimum confidence must be between 0 and 1")
 	}
 	if config.Symbol == "" {
-		return fmt.Errorf("Symbol cannot be empty")
+		errs.add("symbol", "Symbol cannot be empty")
+	} else if !validSymbolFormat(NormalizeSymbol(config.Symbol)) {
+		errs.add("symbol", "symbol %q is not a valid trading pair", config.Symbol)
+	}
+	seenSymbols := map[string]bool{config.Symbol: true}
+	for i, symbol := range config.Symbols {
+		if symbol == "" {
+			errs.add(fmt.Sprintf("symbols[%d]", i), "symbol cannot be empty")
+		} else if !validSymbolFormat(NormalizeSymbol(symbol)) {
+			errs.add(fmt.Sprintf("symbols[%d]", i), "symbol %q is not a valid trading pair", symbol)
+		} else if seenSymbols[symbol] {
+			errs.add(fmt.Sprintf("symbols[%d]", i), "symbol %s is listed twice", symbol)
+		}
+		seenSymbols[symbol] = true
+	}
+	if config.SymbolSelector.Enabled {
+		if config.SymbolSelector.Top < 1 || config.SymbolSelector.Top > 50 {
+			errs.add("symbol_selector.top", "symbol selector top must be between 1 and 50")
+		}
+		if config.SymbolSelector.RefreshHours < 1 {
+			errs.add("symbol_selector.refresh_hours", "symbol selector refresh must be at least 1 hour")
+		}
+		if len(config.SymbolSelector.Quotes) == 0 {
+			errs.add("symbol_selector.quotes", "symbol selector needs at least one quote currency")
+		}
+		for i, quote := range config.SymbolSelector.Quotes {
+			if !isKnownQuote(quote) {
+				errs.add(fmt.Sprintf("symbol_selector.quotes[%d]", i), "unknown quote currency %q (use one of %s)", quote, strings.Join(knownQuoteCurrencies, ", "))
+			}
+		}
 	}
 
 	// Validate Binance settings if using Binance data provider
@@ -303,11 +771,601 @@ func ValidateConfig(config Config) error {
 		// API keys are optional for public data (klines)
 		// Only warn if they're not set
 		if config.Binance.APIKey == "" || strings.Contains(config.Binance.APIKey, "YOUR_") {
-			fmt.Println("⚠️  Note: Using Binance public API (no API key). For advanced features, set BINANCE_API_KEY environment variable.")
+			configLog.Info("Using Binance public API (no API key). For advanced features, set BINANCE_API_KEY environment variable.")
+		}
+	}
+	if config.DataProvider != "" && config.DataProvider != "sample" && !IsExchange(config.DataProvider) {
+		errs.add("data_provider", "unknown data provider %s (use sample, %s)", config.DataProvider, strings.Join(exchangeNames, ", "))
+	}
+	if config.DataProvider == "coinbase" && config.Coinbase.APIKey != "" && config.Coinbase.Passphrase == "" {
+		errs.add("coinbase.passphrase", "Coinbase API keys require a passphrase")
+	}
+	if config.CandleStore.Enabled {
+		if _, ok := candleStoreDrivers[valueOrDefault(config.CandleStore.Driver, "sqlite")]; !ok {
+			errs.add("candle_store.driver", "unknown candle store driver %s", config.CandleStore.Driver)
+		}
+		if valueOrDefault(config.CandleStore.Driver, "sqlite") == "sqlite" && config.CandleStore.Path == "" {
+			errs.add("candle_store.path", "SQLite candle store requires a path")
+		}
+	}
+	for _, name := range sortedKeys(config.History.Candles) {
+		if _, err := ParseTimeframe(name); err != nil {
+			errs.add("history.candles."+name, "unknown timeframe %s (use 5m, 15m, 45m, 8h or 1d)", name)
+		}
+		if count := config.History.Candles[name]; count < 1 || count > 1500 {
+			errs.add("history.candles."+name, "history depth must be between 1 and 1500 candles, got %d", count)
+		}
+	}
+	switch config.History.OnShortfall {
+	case "", HistoryShortfallFail:
+		for _, shortfall := range historyShortfalls(config, nil) {
+			errs.add("history.candles."+shortfall.Timeframe.String(), "%s needs %d candles but only %d are loaded",
+				shortfall.Indicator, shortfall.Required, shortfall.Loaded)
+		}
+	case HistoryShortfallDisable:
+	default:
+		errs.add("history.on_shortfall", "unknown shortfall policy %q (use fail or disable)", config.History.OnShortfall)
+	}
+	if config.Streaming.Enabled {
+		if config.Streaming.StaleSeconds < 5 {
+			errs.add("streaming.stale_seconds", "stale timeout must be at least 5 seconds")
+		}
+		if config.Streaming.MaxBackoffSeconds < 1 {
+			errs.add("streaming.max_backoff_seconds", "max reconnect backoff must be at least 1 second")
+		}
+	}
+	if config.Streaming.WatchdogSeconds < 0 {
+		errs.add("streaming.watchdog_seconds", "watchdog interval cannot be negative")
+	}
+	if config.Streaming.DegradedConfidence < 0 || config.Streaming.DegradedConfidence > 1 {
+		errs.add("streaming.degraded_confidence", "degraded confidence multiplier must be between 0 and 1")
+	}
+	if config.DataQuality.GapConfidence < 0 || config.DataQuality.GapConfidence > 1 {
+		errs.add("data_quality.gap_confidence", "gap confidence multiplier must be between 0 and 1")
+	}
+	if config.DataQuality.FallbackConfidence < 0 || config.DataQuality.FallbackConfidence > 1 {
+		errs.add("data_quality.fallback_confidence", "fallback confidence multiplier must be between 0 and 1")
+	}
+
+	// Validate quote currency matches the symbol
+	if config.QuoteCurrency != "" && !strings.HasSuffix(strings.ToUpper(config.Symbol), strings.ToUpper(config.QuoteCurrency)) {
+		errs.add("quote_currency", "quote currency %s does not match symbol %s", config.QuoteCurrency, config.Symbol)
+	}
+
+	// Validate rebalancing targets
+	if config.Rebalance.Enabled {
+		totalWeight := 0.0
+		_, executorQuote, _ := SplitSymbol(config.Symbol)
+		if config.QuoteCurrency != "" {
+			executorQuote = strings.ToUpper(config.QuoteCurrency)
+		}
+		for _, symbol := range sortedKeys(config.Rebalance.TargetWeights) {
+			weight := config.Rebalance.TargetWeights[symbol]
+			if weight < 0 || weight > 1 {
+				errs.add("rebalance.target_weights."+symbol, "rebalance weight for %s must be between 0 and 1", symbol)
+			}
+			if _, quote, err := SplitSymbol(symbol); err != nil || quote != executorQuote {
+				errs.add("rebalance.target_weights."+symbol, "rebalance symbol %s must be quoted in %s", symbol, executorQuote)
+			}
+			totalWeight += weight
+		}
+		if totalWeight > 1.0+1e-9 {
+			errs.add("rebalance.target_weights", "rebalance target weights sum to %.2f, must not exceed 1", totalWeight)
+		}
+		if config.Rebalance.ThresholdBand <= 0 || config.Rebalance.ThresholdBand >= 1 {
+			errs.add("rebalance.threshold_band", "rebalance threshold band must be between 0 and 1")
+		}
+		if config.Rebalance.IntervalMinutes < 1 {
+			errs.add("rebalance.interval_minutes", "rebalance interval must be at least 1 minute")
 		}
 	}
 
-	return nil
+	// Validate per-strategy capital allocations
+	totalFraction := 0.0
+	for _, name := range sortedKeys(config.StrategyAllocations) {
+		allocation := config.StrategyAllocations[name]
+		if allocation.CapitalFraction < 0 || allocation.CapitalFraction > 1 {
+			errs.add("strategy_allocations."+name+".capital_fraction", "strategy %s capital fraction must be between 0 and 1", name)
+		}
+		if allocation.MaxDailyLoss < 0 || allocation.MaxDailyLoss > 1 {
+			errs.add("strategy_allocations."+name+".max_daily_loss", "strategy %s max daily loss must be between 0 and 1", name)
+		}
+		if allocation.MaxPositionSize < 0 || allocation.MaxPositionSize > 1 {
+			errs.add("strategy_allocations."+name+".max_position_size", "strategy %s max position size must be between 0 and 1", name)
+		}
+		totalFraction += allocation.CapitalFraction
+	}
+	if totalFraction > 1.0+1e-9 {
+		errs.add("strategy_allocations", "strategy capital fractions sum to %.2f, must not exceed 1", totalFraction)
+	}
+
+	// Validate hedging rules
+	if config.Hedging.Enabled {
+		hedgeSymbols := make(map[string]string)
+		for i, rule := range config.Hedging.Rules {
+			if rule.Name == "" {
+				errs.add(fmt.Sprintf("hedging.rules[%d].name", i), "hedging rule %d must have a name", i)
+			}
+			if rule.HedgeSymbol == "" {
+				errs.add(fmt.Sprintf("hedging.rules[%d].hedge_symbol", i), "hedging rule %s must set hedge_symbol", rule.Name)
+			}
+			if other, exists := hedgeSymbols[rule.HedgeSymbol]; exists {
+				errs.add(fmt.Sprintf("hedging.rules[%d].hedge_symbol", i), "hedging rules %s and %s both hedge %s", other, rule.Name, rule.HedgeSymbol)
+			}
+			hedgeSymbols[rule.HedgeSymbol] = rule.Name
+			if rule.HedgeRatio <= 0 || rule.HedgeRatio > 1 {
+				errs.add(fmt.Sprintf("hedging.rules[%d].hedge_ratio", i), "hedging rule %s hedge ratio must be between 0 and 1", rule.Name)
+			}
+			if rule.MaxLongExposure < 0 {
+				errs.add(fmt.Sprintf("hedging.rules[%d].max_long_exposure", i), "hedging rule %s max long exposure cannot be negative", rule.Name)
+			}
+		}
+	}
+
+	// Validate the correlation limit
+	if config.Correlation.BetaBenchmark == "" {
+		errs.add("correlation.beta_benchmark", "beta benchmark symbol is required")
+	}
+	if config.Correlation.Enabled {
+		if config.Correlation.IntervalMinutes <= 0 {
+			errs.add("correlation.interval_minutes", "correlation interval must be positive")
+		}
+		if config.Correlation.Window < minCorrelationSamples {
+			errs.add("correlation.window", "correlation window must be at least %d returns", minCorrelationSamples)
+		}
+		if config.Correlation.Threshold <= 0 || config.Correlation.Threshold > 1 {
+			errs.add("correlation.threshold", "correlation threshold must be between 0 and 1")
+		}
+		if config.Correlation.MaxConcentration <= 0 || config.Correlation.MaxConcentration > 1 {
+			errs.add("correlation.max_concentration", "correlation max concentration must be between 0 and 1")
+		}
+		if config.Correlation.Action != CorrelationActionBlock && config.Correlation.Action != CorrelationActionDownsize {
+			errs.add("correlation.action", "correlation action must be %s or %s, got %q", CorrelationActionBlock, CorrelationActionDownsize, config.Correlation.Action)
+		}
+	}
+
+	// Validate Pine studies
+	if config.Pine.Enabled {
+		if len(config.Pine.Scripts) == 0 {
+			errs.add("pine.scripts", "Pine studies are enabled without any scripts")
+		}
+		if config.Pine.Strength <= 0 || config.Pine.Strength > 1 {
+			errs.add("pine.strength", "Pine signal strength must be between 0 and 1")
+		}
+	}
+
+	// Validate determinism mode
+	if config.Determinism.Enabled {
+		if _, err := time.Parse(time.RFC3339, config.Determinism.Clock); err != nil {
+			errs.add("determinism.clock", "frozen clock must be an RFC3339 time, got %q", config.Determinism.Clock)
+		}
+	}
+
+	// Validate symbol precision overrides
+	for _, symbol := range sortedKeys(config.SymbolPrecision) {
+		precision := config.SymbolPrecision[symbol]
+		if precision.TickSize <= 0 {
+			errs.add("symbol_precision."+symbol+".tick_size", "tick size for %s must be positive", symbol)
+		}
+		if precision.StepSize < 0 {
+			errs.add("symbol_precision."+symbol+".step_size", "step size for %s cannot be negative", symbol)
+		}
+	}
+
+	// Validate contract settlement
+	switch config.Contract.Type {
+	case "", ContractLinear:
+	case ContractInverse:
+		if config.Contract.ContractSize <= 0 {
+			errs.add("contract.contract_size", "inverse contract size must be positive")
+		}
+	default:
+		errs.add("contract.type", "contract type must be %q or %q, got %q", ContractLinear, ContractInverse, config.Contract.Type)
+	}
+
+	// Validate indicator plugins
+	for _, name := range sortedKeys(config.Plugins) {
+		info, ok := LookupIndicator(name)
+		if !ok || info.plugin == nil || info.Name != name {
+			errs.add("indicators."+name, "unknown indicator %s", name)
+			continue
+		}
+		if _, err := info.plugin.newIndicator(config.Plugins[name], indicator.FiveMinute); err != nil {
+			errs.add("indicators."+name, "%v", err)
+		}
+	}
+
+	// Validate continuous contracts
+	if config.Continuous.Enabled {
+		if len(config.Continuous.Contracts) == 0 {
+			errs.add("continuous.contracts", "continuous series needs at least one delivery contract")
+		}
+		var previous time.Time
+		for i, contract := range config.Continuous.Contracts {
+			pair, expiry, err := ParseDeliveryContract(contract)
+			switch {
+			case err != nil:
+				errs.add(fmt.Sprintf("continuous.contracts[%d]", i), "%v", err)
+			case pair != config.Symbol:
+				errs.add(fmt.Sprintf("continuous.contracts[%d]", i), "contract %s is not a %s contract", contract, config.Symbol)
+			case !expiry.After(previous):
+				errs.add(fmt.Sprintf("continuous.contracts[%d]", i), "contracts must be listed oldest expiry first")
+			default:
+				previous = expiry
+			}
+		}
+		if config.Continuous.RollDays < 0 || config.Continuous.RollDays > 30 {
+			errs.add("continuous.roll_days", "roll days must be between 0 and 30")
+		}
+		switch config.Continuous.Adjustment {
+		case "", AdjustDifference, AdjustRatio:
+		default:
+			errs.add("continuous.adjustment", "adjustment must be %q or %q, got %q", AdjustDifference, AdjustRatio, config.Continuous.Adjustment)
+		}
+	}
+
+	// Validate simulated trading costs
+	if config.Backtest.FeePercent < 0 || config.Backtest.FeePercent >= 100 {
+		errs.add("backtest.fee_percent", "backtest fee must be between 0 and 100%%")
+	}
+	if config.Backtest.SlippageBps < 0 || config.Backtest.SlippageBps >= 10000 {
+		errs.add("backtest.slippage_bps", "backtest slippage must be between 0 and 10000 bps")
+	}
+
+	// Validate the session boundary
+	if _, err := time.LoadLocation(valueOrDefault(config.Session.Timezone, "UTC")); err != nil {
+		errs.add("session.timezone", "unknown session time zone %q", config.Session.Timezone)
+	}
+	if _, _, err := config.Session.startClock(); err != nil {
+		errs.add("session.start_time", "%v", err)
+	}
+
+	// Validate the starting balance
+	if config.Account.InitialBalance <= 0 {
+		errs.add("account.initial_balance", "initial balance must be positive")
+	}
+	if currency := strings.ToUpper(config.Account.Currency); currency != "" {
+		base, quote := resolveCurrencies(config)
+		margin := config.Contract.MarginCurrency(base, quote)
+		if currency != margin && !(usdStablecoins[currency] && usdStablecoins[margin]) {
+			errs.add("account.currency", "initial balance currency %s must be the margin currency %s", currency, margin)
+		}
+	}
+
+	// Validate futures margin limits
+	if config.Margin.Enabled {
+		if config.Margin.Leverage < 1 || config.Margin.Leverage > 125 {
+			errs.add("margin.leverage", "leverage must be between 1 and 125")
+		}
+		if config.Margin.MaintenanceMarginRate <= 0 || config.Margin.MaintenanceMarginRate >= 1 {
+			errs.add("margin.maintenance_margin_rate", "maintenance margin rate must be between 0 and 1")
+		}
+		if config.Margin.MaxMarginRatio <= 0 || config.Margin.MaxMarginRatio > 1 {
+			errs.add("margin.max_margin_ratio", "max margin ratio must be between 0 and 1")
+		}
+		if config.Margin.LiquidationBufferPercent < 0 {
+			errs.add("margin.liquidation_buffer_percent", "liquidation buffer cannot be negative")
+		}
+	}
+
+	// Validate funding accrual
+	if config.Funding.Enabled {
+		if config.Funding.IntervalHours <= 0 || 24%config.Funding.IntervalHours != 0 {
+			errs.add("funding.interval_hours", "funding interval must divide 24 hours, got %d", config.Funding.IntervalHours)
+		}
+		for _, symbol := range sortedKeys(config.Funding.Rates) {
+			if rate := config.Funding.Rates[symbol]; math.Abs(rate) > 0.05 {
+				errs.add("funding.rates."+symbol, "funding rate %v for %s is beyond ±5%% per settlement", rate, symbol)
+			}
+		}
+		if math.Abs(config.Funding.Rate) > 0.05 {
+			errs.add("funding.rate", "funding rate %v is beyond ±5%% per settlement", config.Funding.Rate)
+		}
+		if config.Funding.BorrowRate < 0 || config.Funding.BorrowRate > 1 {
+			errs.add("funding.borrow_rate", "borrow rate must be between 0 and 1 per year")
+		}
+	}
+
+	// Validate the fee tier
+	if config.Fees.Enabled {
+		if _, ok := BinanceFuturesFeeTiers[config.Fees.Tier]; !ok {
+			errs.add("fees.tier", "unknown fee tier %q (available: %s)", config.Fees.Tier, strings.Join(sortedKeys(BinanceFuturesFeeTiers), ", "))
+		}
+		if config.Fees.MakerPercent < 0 || config.Fees.MakerPercent >= 1 {
+			errs.add("fees.maker_percent", "maker fee must be between 0 and 1%% of notional")
+		}
+		if config.Fees.TakerPercent < 0 || config.Fees.TakerPercent >= 1 {
+			errs.add("fees.taker_percent", "taker fee must be between 0 and 1%% of notional")
+		}
+		if config.Fees.BNBDiscount && (config.Fees.BNBDiscountPercent <= 0 || config.Fees.BNBDiscountPercent >= 100) {
+			errs.add("fees.bnb_discount_percent", "BNB discount must be between 0 and 100%%")
+		}
+	}
+
+	// Validate reconciliation polling
+	live := config.LiveTrading
+	if (config.Reconciliation.Enabled || (live.Enabled && !live.DryRun)) && config.Reconciliation.IntervalSeconds < 5 {
+		errs.add("reconciliation.interval_seconds", "reconciliation interval must be at least 5 seconds")
+	}
+
+	// Validate live order routing
+	if live.Enabled {
+		if live.Market != MarketFutures && live.Market != MarketSpot {
+			errs.add("live_trading.market", "market must be %q or %q, got %q", MarketFutures, MarketSpot, live.Market)
+		}
+		if live.OrderType != "MARKET" && live.OrderType != "LIMIT" {
+			errs.add("live_trading.order_type", "order type must be MARKET or LIMIT, got %q", live.OrderType)
+		}
+		if live.Market == MarketSpot && live.PlaceStops {
+			errs.add("live_trading.place_stops", "exchange stop orders are only supported on futures")
+		}
+		if live.Market == MarketSpot && config.ATR.UseShorts {
+			errs.add("live_trading.market", "spot trading cannot short; disable atr.use_shorts")
+		}
+		if !live.DryRun && config.DataProvider != "binance" {
+			errs.add("live_trading.enabled", "live trading requires the binance data provider (or dry_run)")
+		}
+	}
+
+	// Validate prediction scoring
+	if config.Prediction.RoundTripCostPercent < 0 {
+		errs.add("prediction.round_trip_cost_percent", "round-trip cost cannot be negative")
+	}
+	if config.Prediction.NeutralATRFraction < 0 {
+		errs.add("prediction.neutral_atr_fraction", "neutral ATR fraction cannot be negative")
+	}
+	if config.Prediction.MildMovePercent <= 0 {
+		errs.add("prediction.mild_move_percent", "mild move threshold must be positive")
+	} else if config.Prediction.StrongMovePercent <= config.Prediction.MildMovePercent {
+		errs.add("prediction.strong_move_percent", "strong move threshold must be above the mild move threshold")
+	}
+
+	// Validate scripted strategy
+	if config.Scripting.Enabled {
+		if config.Scripting.File == "" {
+			errs.add("scripting.file", "scripted strategy requires a rule file")
+		}
+		if config.Scripting.PositionSize <= 0 || config.Scripting.PositionSize > 1 {
+			errs.add("scripting.position_size", "scripted position size must be between 0 and 1")
+		}
+		if config.Scripting.MaxSteps < 1 {
+			errs.add("scripting.max_steps", "scripted step budget must be at least 1")
+		}
+	}
+
+	// Validate price sources
+	sources := map[string]string{"price_source": config.PriceSource}
+	for symbol, source := range config.PriceSources {
+		sources["price_sources."+symbol] = source
+	}
+	for _, field := range sortedKeys(sources) {
+		source := sources[field]
+		switch source {
+		case "", PriceSourceLast, PriceSourceMark, PriceSourceMid, PriceSourceIndex:
+		default:
+			errs.add(field, "unknown price source %s (use last, mark, mid or index)", source)
+		}
+	}
+
+	// Validate price index venues
+	for _, venue := range config.PriceIndex.Venues {
+		switch venue {
+		case "binance", "coinbase", "kraken":
+		default:
+			errs.add("price_index.venues", "unknown price index venue %s", venue)
+		}
+	}
+	if config.PriceIndex.MinVenues > len(config.PriceIndex.Venues) {
+		errs.add("price_index.min_venues", "price index min venues %d exceeds %d configured venues", config.PriceIndex.MinVenues, len(config.PriceIndex.Venues))
+	}
+
+	// Validate nightly backtest
+	if config.NightlyBacktest.Enabled {
+		if config.NightlyBacktest.HourUTC < 0 || config.NightlyBacktest.HourUTC > 23 {
+			errs.add("nightly_backtest.hour_utc", "nightly backtest hour must be between 0 and 23")
+		}
+		if len(config.NightlyBacktest.WindowDays) == 0 {
+			errs.add("nightly_backtest.window_days", "nightly backtest needs at least one window")
+		}
+		for _, days := range config.NightlyBacktest.WindowDays {
+			if days <= 0 || days > 90 {
+				errs.add("nightly_backtest.window_days", "nightly backtest window must be between 1 and 90 days, got %d", days)
+			}
+		}
+	}
+
+	// Validate regime switching
+	if config.RegimeSwitching.Enabled {
+		if config.RegimeSwitching.Lookback < 2 {
+			errs.add("regime_switching.lookback", "regime switching lookback must be at least 2 candles")
+		}
+		if config.RegimeSwitching.TrendThreshold <= 0 || config.RegimeSwitching.TrendThreshold > 1 {
+			errs.add("regime_switching.trend_threshold", "regime switching trend threshold must be between 0 and 1")
+		}
+		if config.RegimeSwitching.VolatilityThreshold <= 0 {
+			errs.add("regime_switching.volatility_threshold", "regime switching volatility threshold must be positive")
+		}
+		for _, regime := range sortedKeys(config.RegimeSwitching.Profiles) {
+			profile := config.RegimeSwitching.Profiles[regime]
+			switch regime {
+			case RegimeTrending, RegimeRanging, RegimeVolatile:
+			default:
+				errs.add("regime_switching.profiles."+regime, "unknown regime %s (use TRENDING, RANGING or VOLATILE)", regime)
+			}
+			if profile.MinConfidence < 0 || profile.MinConfidence > 1 {
+				errs.add("regime_switching.profiles."+regime+".min_confidence", "regime %s min confidence must be between 0 and 1", regime)
+			}
+			for _, name := range sortedKeys(profile.Weights) {
+				weight := profile.Weights[name]
+				if weight < 0 {
+					errs.add("regime_switching.profiles."+regime+".weights."+name, "regime %s weight for %s cannot be negative", regime, name)
+				}
+			}
+		}
+	}
+
+	// Validate seasonality
+	if config.Seasonality.LookbackDays <= 0 || config.Seasonality.LookbackDays > 365 {
+		errs.add("seasonality.lookback_days", "seasonality lookback must be between 1 and 365 days")
+	}
+	if config.Seasonality.RefreshHours <= 0 {
+		errs.add("seasonality.refresh_hours", "seasonality refresh hours must be positive")
+	}
+	if config.Seasonality.PriorWeight < 0 {
+		errs.add("seasonality.prior_weight", "seasonality prior weight cannot be negative")
+	}
+
+	// Validate safe mode
+	if config.SafeMode.Enabled {
+		if config.SafeMode.FailureThreshold <= 0 {
+			errs.add("safe_mode.failure_threshold", "safe mode failure threshold must be positive")
+		}
+		if config.SafeMode.WindowSeconds <= 0 {
+			errs.add("safe_mode.window_seconds", "safe mode window must be positive")
+		}
+		if config.SafeMode.RecoverySuccesses < 0 {
+			errs.add("safe_mode.recovery_successes", "safe mode recovery successes cannot be negative")
+		}
+	}
+
+	// Validate heartbeat and MQTT
+	if config.MQTT.QoS < 0 || config.MQTT.QoS > 2 {
+		errs.add("mqtt.qos", "mqtt qos must be 0, 1 or 2")
+	}
+	for _, eventType := range sortedKeys(config.MQTT.Topics) {
+		if !ValidEventType(eventType) {
+			errs.add("mqtt.topics."+eventType, "unknown mqtt event type %s (use signal, trade, position, prediction or error)", eventType)
+		}
+	}
+	if config.Heartbeat.Enabled {
+		if config.Heartbeat.URL == "" && config.Heartbeat.MQTTTopic == "" {
+			errs.add("heartbeat.url", "heartbeat needs a url or mqtt_topic")
+		}
+		if config.Heartbeat.MQTTTopic != "" && config.MQTT.Broker == "" {
+			errs.add("heartbeat.mqtt_topic", "heartbeat mqtt_topic requires mqtt.broker")
+		}
+		if config.Heartbeat.IntervalSeconds <= 0 {
+			errs.add("heartbeat.interval_seconds", "heartbeat interval must be positive")
+		}
+		if config.Heartbeat.MaxSignalAgeSeconds <= 0 {
+			errs.add("heartbeat.max_signal_age_seconds", "heartbeat max signal age must be positive")
+		}
+	}
+
+	// Validate Telegram
+	if telegram := config.Notifications.Telegram; telegram.Enabled {
+		if telegram.Token == "" {
+			errs.add("notifications.telegram.token", "telegram needs a bot token")
+		}
+		if telegram.ChatID == 0 {
+			errs.add("notifications.telegram.chat_id", "telegram needs a chat id")
+		}
+	}
+
+	// Validate event export
+	if config.EventExport.Enabled {
+		if config.EventExport.Backend != ExportBackendKafka && config.EventExport.Backend != ExportBackendNATS {
+			errs.add("event_export.backend", "event export backend must be kafka or nats")
+		}
+		if len(config.EventExport.Brokers) == 0 {
+			errs.add("event_export.brokers", "event export needs at least one broker")
+		}
+		if len(config.EventExport.Topics) == 0 {
+			errs.add("event_export.topics", "event export needs at least one topic")
+		}
+		for _, eventType := range sortedKeys(config.EventExport.Topics) {
+			topic := config.EventExport.Topics[eventType]
+			if !ValidEventType(eventType) {
+				errs.add("event_export.topics."+eventType, "unknown event export event type %s (use signal, trade, position, prediction or error)", eventType)
+			}
+			if topic == "" {
+				errs.add("event_export.topics."+eventType, "event export topic for %s cannot be empty", eventType)
+			}
+		}
+		if config.EventExport.MaxPending < 0 {
+			errs.add("event_export.max_pending", "event export max pending cannot be negative")
+		}
+		if config.EventExport.RetryBackoffMs <= 0 {
+			errs.add("event_export.retry_backoff_ms", "event export retry backoff must be positive")
+		}
+	}
+
+	// Validate maintenance windows
+	if config.Maintenance.PauseEntriesMinutes < 0 {
+		errs.add("maintenance.pause_entries_minutes", "maintenance pause entries minutes cannot be negative")
+	}
+	for i, window := range config.Maintenance.Windows {
+		if window.Name == "" {
+			errs.add(fmt.Sprintf("maintenance.windows[%d].name", i), "maintenance window %d must have a name", i)
+		}
+		if !window.End.After(window.Start) {
+			errs.add(fmt.Sprintf("maintenance.windows[%d].end", i), "maintenance window %s must end after it starts", window.Name)
+		}
+		if window.RepeatWeekly && window.End.Sub(window.Start) >= 7*24*time.Hour {
+			errs.add(fmt.Sprintf("maintenance.windows[%d].end", i), "weekly maintenance window %s must be shorter than a week", window.Name)
+		}
+	}
+
+	// Validate API users
+	userNames, userTokens := make(map[string]bool), make(map[string]bool)
+	for i, user := range config.Admin.Users {
+		switch {
+		case user.Name == "":
+			errs.add(fmt.Sprintf("admin.users[%d].name", i), "API user %d must have a name", i)
+		case userNames[user.Name]:
+			errs.add(fmt.Sprintf("admin.users[%d].name", i), "duplicate API user %s", user.Name)
+		}
+		switch {
+		case user.Token == "":
+			errs.add(fmt.Sprintf("admin.users[%d].token", i), "API user %s must have a token", user.Name)
+		case userTokens[user.Token] || user.Token == config.Admin.Token:
+			errs.add(fmt.Sprintf("admin.users[%d].token", i), "API user %s shares its token with another user", user.Name)
+		}
+		if !RoleAllows(user.Role, RoleViewer) {
+			errs.add(fmt.Sprintf("admin.users[%d].role", i), "unknown role %q for API user %s (want viewer, trader or admin)", user.Role, user.Name)
+		}
+		userNames[user.Name], userTokens[user.Token] = true, true
+	}
+
+	// Validate logging
+	if _, ok := logLevels[config.Logging.Level]; !ok {
+		errs.add("logging.level", "unknown log level %q (want debug, info, warn or error)", config.Logging.Level)
+	}
+	if config.Logging.Format != LogFormatConsole && config.Logging.Format != LogFormatJSON {
+		errs.add("logging.format", "unknown log format %q (want console or json)", config.Logging.Format)
+	}
+
+	// Validate per-timeframe provider overrides
+	for _, tfName := range sortedKeys(config.Providers) {
+		route := config.Providers[tfName]
+		if _, err := ParseTimeframe(tfName); err != nil {
+			errs.add("providers."+tfName, "%v", err)
+		}
+		for _, name := range []string{route.Historical, route.RealTime} {
+			switch name {
+			case "", "sample", "binance_rest", "file", "store":
+			default:
+				if IsExchange(name) {
+					continue
+				}
+				errs.add("providers."+tfName, "unknown data provider %s", name)
+			}
+		}
+		if route.RealTime == "file" {
+			errs.add("providers."+tfName+".realtime", "file provider only supports historical data")
+		}
+		if (route.Historical == "file" || route.RealTime == "file") && config.DataDir == "" {
+			errs.add("data_dir", "file provider for %s requires data_dir", tfName)
+		}
+		if route.RealTime == "store" {
+			errs.add("providers."+tfName+".realtime", "store provider only supports historical data")
+		}
+		if route.Historical == "store" && !config.CandleStore.Enabled {
+			errs.add("candle_store.enabled", "store provider for %s requires the candle store", tfName)
+		}
+	}
+
+	return errs.err()
 }
 
 // GetConfigSummary returns a human-readable summary of the configuration
@@ -435,8 +1493,49 @@ func GetConfigSummary(config Config) string {
 		summary += fmt.Sprintf("  ❌ Channel Analysis: DISABLED\n")
 	}
 
+	if config.KeltnerChannel.Enabled {
+		summary += fmt.Sprintf("  ✅ Keltner Channel: EMA %d, ATR %d x %.1f, %s\n",
+			config.KeltnerChannel.EMAPeriod, config.KeltnerChannel.ATRPeriod, config.KeltnerChannel.Multiplier, config.KeltnerChannel.Mode)
+		enabledCount++
+	} else {
+		summary += fmt.Sprintf("  ❌ Keltner Channel: DISABLED\n")
+	}
+
+	if config.DonchianChannel.Enabled {
+		summary += fmt.Sprintf("  ✅ Donchian Channel: Period %d, %s\n", config.DonchianChannel.Period, config.DonchianChannel.Mode)
+		enabledCount++
+	} else {
+		summary += fmt.Sprintf("  ❌ Donchian Channel: DISABLED\n")
+	}
+
+	if config.ParabolicSAR.Enabled {
+		summary += fmt.Sprintf("  ✅ Parabolic SAR: Step %.2f, Max %.2f\n", config.ParabolicSAR.Step, config.ParabolicSAR.MaxStep)
+		enabledCount++
+	} else {
+		summary += fmt.Sprintf("  ❌ Parabolic SAR: DISABLED\n")
+	}
+
+	if config.CandlePatterns.Enabled {
+		summary += fmt.Sprintf("  ✅ Candle Patterns: %d patterns\n", len(config.CandlePatterns.Patterns))
+		enabledCount++
+	} else {
+		summary += fmt.Sprintf("  ❌ Candle Patterns: DISABLED\n")
+	}
+
+	for _, info := range Indicators() {
+		if info.plugin == nil {
+			continue
+		}
+		if info.IsEnabled(config) {
+			summary += fmt.Sprintf("  ✅ %s: plugin\n", info.DisplayName)
+			enabledCount++
+		} else {
+			summary += fmt.Sprintf("  ❌ %s: DISABLED\n", info.DisplayName)
+		}
+	}
+
 	summary += fmt.Sprintf("══════════════════════════════════════\n")
-	summary += fmt.Sprintf("🎯 Active Indicators: %d/14\n", enabledCount)
+	summary += fmt.Sprintf("🎯 Active Indicators: %d/%d\n", enabledCount, len(Indicators()))
 	summary += fmt.Sprintf("📊 Min Confidence: %.1f%%\n", config.MinConfidence*100)
 	summary += fmt.Sprintf("══════════════════════════════════════\n")
 
@@ -447,6 +1546,8 @@ func GetConfigSummary(config Config) string {
 type ConfigManager struct {
 	filename string
 	config   Config
+	data     []byte // File contents last loaded or saved, so Watch skips our own writes
+	mutex    sync.RWMutex
 }
 
 // NewConfigManager creates a new configuration manager
@@ -457,40 +1558,78 @@ func NewConfigManager(filename string) *ConfigManager {
 	}
 }
 
-// Load loads the configuration from file
+// Load loads the configuration from file, upgrading older schema versions in place
 func (cm *ConfigManager) Load() error {
+	if _, err := UpgradeConfigFile(cm.filename); err != nil {
+		return err
+	}
 	config, err := LoadConfig(cm.filename)
 	if err != nil {
 		return err
 	}
+	data, _ := ioutil.ReadFile(cm.filename)
+
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
 	cm.config = config
+	cm.data = data
 	return nil
 }
 
-// Save saves the current configuration to file
+// Save saves the current configuration to file. Credentials and the admin token
+// are written as the file has them, so keys loaded from the environment stay off disk.
 func (cm *ConfigManager) Save() error {
-	return SaveConfig(cm.config, cm.filename)
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	config := cm.config
+	if data, err := ioutil.ReadFile(cm.filename); err == nil {
+		if migrated, _, err := MigrateConfigData(data); err == nil {
+			stored := DefaultConfig()
+			if err := json.Unmarshal(migrated, &stored); err == nil {
+				KeepCredentials(&config, stored)
+			}
+		}
+	}
+
+	if err := SaveConfig(config, cm.filename); err != nil {
+		return err
+	}
+	cm.data, _ = ioutil.ReadFile(cm.filename)
+	return nil
 }
 
 // GetConfig returns the current configuration
 func (cm *ConfigManager) GetConfig() Config {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
 	return cm.config
 }
 
 // UpdateConfig updates the configuration
 func (cm *ConfigManager) UpdateConfig(config Config) error {
+	config = normalizeConfigSymbols(config)
 	if err := ValidateConfig(config); err != nil {
 		return err
 	}
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
 	cm.config = config
 	return nil
 }
 
-// UpdateSymbol updates the trading symbol
+// UpdateSymbol updates the trading symbol, normalized (btc-usdt -> BTCUSDT).
+// Check it against the exchange with TradingBot.ValidateSymbol first.
 func (cm *ConfigManager) UpdateSymbol(symbol string) error {
+	symbol = NormalizeSymbol(symbol)
 	if symbol == "" {
 		return fmt.Errorf("symbol cannot be empty")
 	}
+	if !validSymbolFormat(symbol) {
+		return fmt.Errorf("symbol %q is not a valid trading pair", symbol)
+	}
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
 	cm.config.Symbol = symbol
 	return nil
 }
@@ -500,134 +1639,67 @@ func (cm *ConfigManager) UpdateMinConfidence(confidence float64) error {
 	if confidence < 0 || confidence > 1 {
 		return fmt.Errorf("confidence must be between 0 and 1")
 	}
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
 	cm.config.MinConfidence = confidence
 	return nil
 }
 
 // GetSummary returns a configuration summary
 func (cm *ConfigManager) GetSummary() string {
-	return GetConfigSummary(cm.config)
+	return GetConfigSummary(cm.GetConfig())
 }
 
-// EnableIndicator enables a specific indicator by name
+// EnableIndicator enables a specific indicator by name ("all" enables every indicator)
 func (cm *ConfigManager) EnableIndicator(indicatorName string) error {
-	switch indicatorName {
-	case "rsi":
-		cm.config.RSI.Enabled = true
-	case "macd":
-		cm.config.MACD.Enabled = true
-	case "volume":
-		cm.config.Volume.Enabled = true
-	case "trend":
-		cm.config.Trend.Enabled = true
-	case "support_resistance", "sr":
-		cm.config.SupportResistance.Enabled = true
-	case "ichimoku":
-		cm.config.Ichimoku.Enabled = true
-	case "mfi", "reverse_mfi":
-		cm.config.MFI.Enabled = true
-	case "bollinger_bands", "bb":
-		cm.config.BollingerBands.Enabled = true
-	case "all":
-		cm.config.RSI.Enabled = true
-		cm.config.MACD.Enabled = true
-		cm.config.Volume.Enabled = true
-		cm.config.Trend.Enabled = true
-		cm.config.SupportResistance.Enabled = true
-		cm.config.Ichimoku.Enabled = true
-		cm.config.MFI.Enabled = true
-		cm.config.BollingerBands.Enabled = true
-	default:
-		return fmt.Errorf("unknown indicator: %s. Available: rsi, macd, volume, trend, support_resistance, ichimoku, mfi, bollinger_bands, all", indicatorName)
-	}
-	return nil
+	return cm.setIndicator(indicatorName, true)
 }
 
-// DisableIndicator disables a specific indicator by name
+// DisableIndicator disables a specific indicator by name ("all" disables every indicator)
 func (cm *ConfigManager) DisableIndicator(indicatorName string) error {
-	switch indicatorName {
-	case "rsi":
-		cm.config.RSI.Enabled = false
-	case "macd":
-		cm.config.MACD.Enabled = false
-	case "volume":
-		cm.config.Volume.Enabled = false
-	case "trend":
-		cm.config.Trend.Enabled = false
-	case "support_resistance", "sr":
-		cm.config.SupportResistance.Enabled = false
-	case "ichimoku":
-		cm.config.Ichimoku.Enabled = false
-	case "mfi", "reverse_mfi":
-		cm.config.MFI.Enabled = false
-	case "bollinger_bands", "bb":
-		cm.config.BollingerBands.Enabled = false
-	case "all":
-		cm.config.RSI.Enabled = false
-		cm.config.MACD.Enabled = false
-		cm.config.Volume.Enabled = false
-		cm.config.Trend.Enabled = false
-		cm.config.SupportResistance.Enabled = false
-		cm.config.Ichimoku.Enabled = false
-		cm.config.MFI.Enabled = false
-		cm.config.BollingerBands.Enabled = false
-	default:
-		return fmt.Errorf("unknown indicator: %s. Available: rsi, macd, volume, trend, support_resistance, ichimoku, mfi, bollinger_bands, all", indicatorName)
+	return cm.setIndicator(indicatorName, false)
+}
+
+// setIndicator sets one registered indicator, or all of them, on or off
+func (cm *ConfigManager) setIndicator(indicatorName string, enabled bool) error {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if strings.EqualFold(indicatorName, "all") {
+		for _, info := range Indicators() {
+			info.SetEnabled(&cm.config, enabled)
+		}
+		return nil
 	}
+
+	info, ok := LookupIndicator(indicatorName)
+	if !ok {
+		return fmt.Errorf("unknown indicator: %s. Available: %s, all", indicatorName, strings.Join(IndicatorNames(), ", "))
+	}
+	info.SetEnabled(&cm.config, enabled)
 	return nil
 }
 
 // ToggleIndicator toggles a specific indicator on/off
 func (cm *ConfigManager) ToggleIndicator(indicatorName string) error {
-	switch indicatorName {
-	case "rsi":
-		cm.config.RSI.Enabled = !cm.config.RSI.Enabled
-	case "macd":
-		cm.config.MACD.Enabled = !cm.config.MACD.Enabled
-	case "volume":
-		cm.config.Volume.Enabled = !cm.config.Volume.Enabled
-	case "trend":
-		cm.config.Trend.Enabled = !cm.config.Trend.Enabled
-	case "support_resistance", "sr":
-		cm.config.SupportResistance.Enabled = !cm.config.SupportResistance.Enabled
-	case "ichimoku":
-		cm.config.Ichimoku.Enabled = !cm.config.Ichimoku.Enabled
-	case "mfi", "reverse_mfi":
-		cm.config.MFI.Enabled = !cm.config.MFI.Enabled
-	case "bollinger_bands", "bb":
-		cm.config.BollingerBands.Enabled = !cm.config.BollingerBands.Enabled
-	default:
-		return fmt.Errorf("unknown indicator: %s. Available: rsi, macd, volume, trend, support_resistance, ichimoku, mfi, bollinger_bands", indicatorName)
+	info, ok := LookupIndicator(indicatorName)
+	if !ok {
+		return fmt.Errorf("unknown indicator: %s. Available: %s", indicatorName, strings.Join(IndicatorNames(), ", "))
 	}
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	info.SetEnabled(&cm.config, !info.IsEnabled(cm.config))
 	return nil
 }
 
 // GetEnabledIndicators returns a list of currently enabled indicators
 func (cm *ConfigManager) GetEnabledIndicators() []string {
+	config := cm.GetConfig()
 	var enabled []string
-	if cm.config.RSI.Enabled {
-		enabled = append(enabled, "RSI")
-	}
-	if cm.config.MACD.Enabled {
-		enabled = append(enabled, "MACD")
-	}
-	if cm.config.Volume.Enabled {
-		enabled = append(enabled, "Volume")
-	}
-	if cm.config.Trend.Enabled {
-		enabled = append(enabled, "Trend")
-	}
-	if cm.config.SupportResistance.Enabled {
-		enabled = append(enabled, "Support/Resistance")
-	}
-	if cm.config.Ichimoku.Enabled {
-		enabled = append(enabled, "Ichimoku")
-	}
-	if cm.config.MFI.Enabled {
-		enabled = append(enabled, "Reverse-MFI")
-	}
-	if cm.config.BollingerBands.Enabled {
-		enabled = append(enabled, "Bollinger Bands")
+	for _, info := range Indicators() {
+		if info.IsEnabled(config) {
+			enabled = append(enabled, info.DisplayName)
+		}
 	}
 	return enabled
 }
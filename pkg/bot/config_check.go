@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Config problem severities
+const (
+	ProblemError   = "error"   // The config would be rejected or cannot trade
+	ProblemWarning = "warning" // The config is accepted but likely not what was intended
+)
+
+// ConfigProblem is one issue found while checking a config
+type ConfigProblem struct {
+	Field    string `json:"field,omitempty"` // JSON path of the offending setting, e.g. "atr.multiplier"
+	Message  string `json:"message"`
+	Severity string `json:"severity"` // "error" or "warning"
+}
+
+// CheckConfig runs ValidateConfig plus cross-field checks (timeframe coverage, weight
+// sums, risk sanity) without applying the config; errors are listed before warnings
+func CheckConfig(config Config) []ConfigProblem {
+	problems := make([]ConfigProblem, 0)
+	add := func(severity, field, format string, args ...interface{}) {
+		problems = append(problems, ConfigProblem{Field: field, Message: fmt.Sprintf(format, args...), Severity: severity})
+	}
+
+	var validationErrs ValidationErrors
+	if err := ValidateConfig(config); errors.As(err, &validationErrs) {
+		for _, fieldErr := range validationErrs {
+			add(ProblemError, fieldErr.Field, "%s", fieldErr.Message)
+		}
+	} else if err != nil {
+		add(ProblemError, "", "%v", err)
+	}
+
+	// Timeframe coverage: something must vote, and every timeframe needs real data
+	var enabled []IndicatorInfo
+	for _, info := range Indicators() {
+		if info.IsEnabled(config) {
+			enabled = append(enabled, info)
+		}
+	}
+	if len(enabled) == 0 && !(config.Pine.Enabled && len(config.Pine.Scripts) > 0) {
+		add(ProblemError, "", "no indicators are enabled, so no signals can be generated")
+	}
+	for _, timeframe := range []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute} {
+		route := config.Providers[timeframe.String()]
+		if config.DataProvider == "binance" && (route.Historical == "sample" || route.RealTime == "sample") {
+			add(ProblemWarning, "providers."+timeframe.String(), "%s uses synthetic sample data while data_provider is binance", timeframe)
+		}
+	}
+
+	// Weight sums
+	if config.Rebalance.Enabled && len(config.Rebalance.TargetWeights) == 0 {
+		add(ProblemError, "rebalance.target_weights", "rebalancing is enabled without target weights")
+	}
+	if config.RegimeSwitching.Enabled {
+		for _, regime := range sortedKeys(config.RegimeSwitching.Profiles) {
+			profile := config.RegimeSwitching.Profiles[regime]
+			field := "regime_switching.profiles." + regime + ".weights"
+			for _, name := range sortedKeys(profile.Weights) {
+				if !knownIndicatorWeight(name) {
+					add(ProblemWarning, field+"."+name, "weight does not match any indicator")
+				}
+			}
+			totalWeight := 0.0
+			for _, info := range enabled {
+				totalWeight += profile.weightFor(info.DisplayName)
+			}
+			if len(enabled) > 0 && totalWeight <= 0 {
+				add(ProblemError, field, "regime %s gives every enabled indicator zero weight", regime)
+			}
+		}
+	}
+	if config.Seasonality.PriorEnabled && config.Seasonality.PriorWeight == 0 {
+		add(ProblemWarning, "seasonality.prior_weight", "seasonal prior is enabled with zero weight")
+	}
+
+	// Risk sanity
+	if config.ATR.Period < 1 {
+		add(ProblemError, "atr.period", "ATR period must be at least 1")
+	}
+	if config.ATR.Multiplier <= 0 {
+		add(ProblemError, "atr.multiplier", "ATR multiplier must be positive or trailing stops sit at the entry price")
+	}
+	if config.MinConfidence < 0.5 {
+		add(ProblemWarning, "min_confidence", "min confidence %.2f trades on signals no better than a coin flip", config.MinConfidence)
+	}
+	for _, name := range sortedKeys(config.StrategyAllocations) {
+		allocation := config.StrategyAllocations[name]
+		if allocation.MaxDailyLoss > 0.2 {
+			add(ProblemWarning, "strategy_allocations."+name+".max_daily_loss", "daily loss budget of %.0f%% is unusually high", allocation.MaxDailyLoss*100)
+		}
+		if allocation.MaxPositionSize > 0.1 {
+			add(ProblemWarning, "strategy_allocations."+name+".max_position_size", "risking %.0f%% per trade is unusually high", allocation.MaxPositionSize*100)
+		}
+	}
+	if config.ATR.UseShorts && config.Hedging.Enabled {
+		add(ProblemWarning, "atr.use_shorts", "short signals and exposure hedging are both enabled; hedges may offset ATR shorts")
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Severity == ProblemError && problems[j].Severity != ProblemError
+	})
+	return problems
+}
+
+// knownIndicatorWeight reports whether a regime weight key matches a registered indicator
+func knownIndicatorWeight(name string) bool {
+	for _, info := range Indicators() {
+		if strings.Contains(info.DisplayName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns a map's keys in order so problems are reported deterministically
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
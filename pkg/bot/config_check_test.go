@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"testing"
+)
+
+func TestCheckConfig(t *testing.T) {
+	t.Log("🩺 Testing cross-field config checks")
+
+	if problems := CheckConfig(DefaultConfig()); len(problems) != 0 {
+		t.Fatalf("Expected default config to be clean, got %+v", problems)
+	}
+
+	config := DefaultConfig()
+	config.ATR.Multiplier = 0
+	config.MinConfidence = 0.4
+	config.Providers = map[string]TimeframeProviderConfig{"1d": {Historical: "sample"}}
+	config.RegimeSwitching.Enabled = true
+	config.RegimeSwitching.Profiles = map[string]RegimeProfile{
+		RegimeRanging: {Weights: map[string]float64{"RSI": 0, "MACD": 0, "Volume": 0, "Trend": 0, "Support": 0, "Ichimoku": 0, "MFI": 0,
+			"Bollinger": 0, "Stochastic": 0, "Williams": 0, "Pin": 0, "EMA": 0, "Elliott": 0, "Channel": 0, "ATR": 0, "VWAP": 2}},
+	}
+
+	byField := make(map[string]ConfigProblem)
+	for _, problem := range CheckConfig(config) {
+		byField[problem.Field] = problem
+	}
+	expected := map[string]string{
+		"atr.multiplier": ProblemError,
+		"regime_switching.profiles.RANGING.weights":      ProblemError,
+		"regime_switching.profiles.RANGING.weights.VWAP": ProblemWarning,
+		"min_confidence": ProblemWarning,
+		"providers.1d":   ProblemWarning,
+	}
+	for field, severity := range expected {
+		if problem, ok := byField[field]; !ok || problem.Severity != severity {
+			t.Errorf("Expected %s %s, got %+v", severity, field, problem)
+		}
+	}
+
+	// All indicators off is an error and errors sort before warnings
+	cm := NewConfigManager("")
+	cm.config = config
+	cm.DisableIndicator("all")
+	problems := CheckConfig(cm.GetConfig())
+	if problems[0].Severity != ProblemError || problems[len(problems)-1].Severity != ProblemWarning {
+		t.Errorf("Expected errors before warnings, got %+v", problems)
+	}
+}
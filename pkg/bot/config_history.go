@@ -0,0 +1,198 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ConfigPerformance summarizes the trades closed while a config was active
+type ConfigPerformance struct {
+	Trades   int     `json:"trades"`
+	Wins     int     `json:"wins"`
+	WinRate  float64 `json:"win_rate"` // Fraction of trades with positive PnL
+	TotalPnL float64 `json:"total_pnl"`
+}
+
+// ConfigVersion is a config as it was applied, without credentials
+type ConfigVersion struct {
+	Version     int                `json:"version"`
+	AppliedAt   time.Time          `json:"applied_at"`
+	AppliedBy   string             `json:"applied_by"`             // API user, "config-file" or "startup"
+	ActiveUntil *time.Time         `json:"active_until,omitempty"` // When the next version replaced it; nil while active
+	ArchivedAt  *time.Time         `json:"archived_at,omitempty"`  // Soft-deleted: hidden from the history by default
+	Performance *ConfigPerformance `json:"performance,omitempty"`  // Filled in when the history is read
+	Config      Config             `json:"config"`
+}
+
+// UnmarshalJSON decodes the config over the defaults, so versions saved before
+// a setting existed get its default when rolled back to
+func (v *ConfigVersion) UnmarshalJSON(data []byte) error {
+	type plain ConfigVersion
+	decoded := plain{Config: DefaultConfig()}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*v = ConfigVersion(decoded)
+	return nil
+}
+
+// ConfigHistory keeps every applied config, persisted to a JSON file when one is configured
+type ConfigHistory struct {
+	filename string
+	versions []ConfigVersion
+	mutex    sync.RWMutex
+}
+
+// NewConfigHistory creates a history backed by filename (empty = memory only),
+// loading the versions already saved there
+func NewConfigHistory(filename string) (*ConfigHistory, error) {
+	history := &ConfigHistory{filename: filename}
+	if filename == "" {
+		return history, nil
+	}
+
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return history, fmt.Errorf("failed to read config history: %w", err)
+	}
+	if err := json.Unmarshal(data, &history.versions); err != nil {
+		return history, fmt.Errorf("failed to parse config history: %w", err)
+	}
+	return history, nil
+}
+
+// Record appends config as a new version unless it matches the latest one.
+// Credentials are stripped; the previous version's ActiveUntil is set.
+func (h *ConfigHistory) Record(config Config, by string, at time.Time) (ConfigVersion, bool) {
+	KeepCredentials(&config, Config{})
+	data, err := json.Marshal(config)
+	if err != nil {
+		configLog.Warn("Failed to record config version", "error", err)
+		return ConfigVersion{}, false
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if n := len(h.versions); n > 0 {
+		latest := &h.versions[n-1]
+		if previous, err := json.Marshal(latest.Config); err == nil && bytes.Equal(previous, data) {
+			return *latest, false
+		}
+		latest.ActiveUntil = &at
+	}
+	version := ConfigVersion{Version: len(h.versions) + 1, AppliedAt: at, AppliedBy: by, Config: config}
+	h.versions = append(h.versions, version)
+	h.save()
+	return version, true
+}
+
+// Archive soft-deletes a version; the active one can't be archived
+func (h *ConfigHistory) Archive(version int, at time.Time) (ConfigVersion, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if version < 1 || version > len(h.versions) {
+		return ConfigVersion{}, fmt.Errorf("config version %d not found", version)
+	}
+	if version == len(h.versions) {
+		return ConfigVersion{}, fmt.Errorf("config version %d is active and cannot be archived", version)
+	}
+	entry := &h.versions[version-1]
+	if entry.ArchivedAt == nil {
+		entry.ArchivedAt = &at
+		h.save()
+	}
+	return *entry, nil
+}
+
+// Get returns one version, archived ones included
+func (h *ConfigHistory) Get(version int) (ConfigVersion, bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	if version < 1 || version > len(h.versions) {
+		return ConfigVersion{}, false
+	}
+	return h.versions[version-1], true
+}
+
+// Versions returns the versions oldest first, optionally with archived ones
+func (h *ConfigHistory) Versions(includeArchived bool) []ConfigVersion {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	versions := make([]ConfigVersion, 0, len(h.versions))
+	for _, version := range h.versions {
+		if includeArchived || version.ArchivedAt == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// save writes the history to its file (assumes lock is held)
+func (h *ConfigHistory) save() {
+	if h.filename == "" {
+		return
+	}
+	if err := writeJSONFile(h.filename, h.versions); err != nil {
+		configLog.Warn("Failed to save config history", "error", err)
+	}
+}
+
+// configPerformance sums the trades that closed in [from, until); a nil until means now
+func configPerformance(trades []*Trade, from time.Time, until *time.Time) *ConfigPerformance {
+	performance := &ConfigPerformance{}
+	for _, trade := range trades {
+		if trade.ExitTime.Before(from) || (until != nil && !trade.ExitTime.Before(*until)) {
+			continue
+		}
+		performance.Trades++
+		performance.TotalPnL += trade.PnL
+		if trade.PnL > 0 {
+			performance.Wins++
+		}
+	}
+	if performance.Trades > 0 {
+		performance.WinRate = float64(performance.Wins) / float64(performance.Trades)
+	}
+	return performance
+}
+
+// RecordConfigVersion adds a config applied by user to the config history
+func (tb *TradingBot) RecordConfigVersion(config Config, user APIUser) {
+	if version, added := tb.configHistory.Record(config, user.Name, tb.signalEngine.clock.Now().UTC()); added {
+		configLog.Info("🗄️  Config version recorded", "version", version.Version, "user", user.Name)
+	}
+}
+
+// GetConfigHistory returns the config versions newest first with the
+// performance of the trades closed under each
+func (tb *TradingBot) GetConfigHistory(includeArchived bool) []ConfigVersion {
+	trades := tb.GetTradeHistory(0)
+	versions := tb.configHistory.Versions(includeArchived)
+	for i := range versions {
+		versions[i].Performance = configPerformance(trades, versions[i].AppliedAt, versions[i].ActiveUntil)
+	}
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Version > versions[j].Version })
+	return versions
+}
+
+// GetConfigVersion returns one config version with its performance
+func (tb *TradingBot) GetConfigVersion(version int) (ConfigVersion, bool) {
+	entry, ok := tb.configHistory.Get(version)
+	if ok {
+		entry.Performance = configPerformance(tb.GetTradeHistory(0), entry.AppliedAt, entry.ActiveUntil)
+	}
+	return entry, ok
+}
+
+// ArchiveConfigVersion soft-deletes a config version from the history
+func (tb *TradingBot) ArchiveConfigVersion(version int) (ConfigVersion, error) {
+	return tb.configHistory.Archive(version, tb.signalEngine.clock.Now().UTC())
+}
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigHistory(t *testing.T) {
+	t.Log("🗄️ Testing config versions, per-version performance and archiving")
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	config.ConfigHistoryFile = filepath.Join(t.TempDir(), "config_history.json")
+	config.Binance.APIKey = "secret"
+	tb := NewTradingBot(config)
+
+	// Unchanged configs aren't recorded twice, and credentials never are
+	tb.RecordConfigVersion(config, APIUser{Name: "startup"})
+	tb.RecordConfigVersion(config, APIUser{Name: "startup"})
+	updated := config
+	updated.MinConfidence = 0.7
+	tb.RecordConfigVersion(updated, APIUser{Name: "alice"})
+
+	versions := tb.GetConfigHistory(false)
+	if len(versions) != 2 || versions[0].Version != 2 || versions[0].AppliedBy != "alice" || versions[0].ActiveUntil != nil {
+		t.Fatalf("Unexpected versions: %+v", versions)
+	}
+	if versions[1].ActiveUntil == nil || !versions[1].ActiveUntil.Equal(versions[0].AppliedAt) || versions[1].Config.Binance.APIKey != "" {
+		t.Errorf("Expected version 1 to end when version 2 was applied, without credentials: %+v", versions[1])
+	}
+
+	// Trades count toward the version active when they closed
+	closed := versions[0].AppliedAt.Add(time.Millisecond)
+	tb.tradeExecutor.tradeHistory = append(tb.tradeExecutor.tradeHistory,
+		&Trade{PnL: 10, ExitTime: closed}, &Trade{PnL: -4, ExitTime: closed}, &Trade{PnL: 3, ExitTime: versions[1].AppliedAt})
+	versions = tb.GetConfigHistory(false)
+	if current := versions[0].Performance; current.Trades != 2 || current.Wins != 1 || current.WinRate != 0.5 || current.TotalPnL != 6 {
+		t.Errorf("Unexpected performance of version 2: %+v", current)
+	}
+	if first := versions[1].Performance; first.Trades != 1 || first.TotalPnL != 3 {
+		t.Errorf("Unexpected performance of version 1: %+v", first)
+	}
+
+	// Archiving hides a version without deleting it; the active one stays
+	if _, err := tb.ArchiveConfigVersion(2); err == nil {
+		t.Error("Expected the active version to be protected from archiving")
+	}
+	if _, err := tb.ArchiveConfigVersion(1); err != nil {
+		t.Fatalf("Archive failed: %v", err)
+	}
+	if len(tb.GetConfigHistory(false)) != 1 || len(tb.GetConfigHistory(true)) != 2 {
+		t.Error("Expected the archived version to be listed only on request")
+	}
+
+	// The history survives a restart
+	restored, err := NewConfigHistory(config.ConfigHistoryFile)
+	if err != nil || len(restored.Versions(true)) != 2 || restored.Versions(true)[0].ArchivedAt == nil || restored.Versions(true)[1].Config.MinConfidence != 0.7 {
+		t.Errorf("Expected both versions restored from disk, got %+v (err %v)", restored.Versions(true), err)
+	}
+}
@@ -0,0 +1,297 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Indicator settings are stored in config.json as one map keyed by indicator name:
+//
+//	"indicators": {"rsi": {"enabled": true, "period": 14, ...}, "momentum": {"enabled": true, "period": 10}}
+//
+// Built-in entries are decoded into their typed section of Config (so code keeps
+// using config.RSI.Period); any other entry is an indicator plugin.
+
+// configAlias has Config's fields without its JSON methods
+type configAlias Config
+
+// jsonMember is one key/value pair of a JSON object, kept in document order
+type jsonMember struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// builtinIndicatorField returns the Config field holding a built-in indicator's settings
+func builtinIndicatorField(name string) (reflect.StructField, bool) {
+	info, ok := LookupIndicator(name)
+	if !ok || info.plugin != nil || info.Name != name {
+		return reflect.StructField{}, false
+	}
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		if field := configType.Field(i); jsonName(field) == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// MarshalJSON writes the built-in indicator sections and plugins as one
+// "indicators" map, placed where the first indicator section would be
+func (c Config) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(configAlias(c))
+	if err != nil {
+		return nil, err
+	}
+	members, err := decodeObjectMembers(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var indicators, result []jsonMember
+	position := -1
+	for _, member := range members {
+		if _, ok := builtinIndicatorField(member.Key); ok {
+			indicators = append(indicators, member)
+			if position < 0 {
+				position = len(result)
+			}
+			continue
+		}
+		result = append(result, member)
+	}
+	for _, name := range sortedKeys(c.Plugins) {
+		entry, err := encodePluginEntry(c.Plugins[name])
+		if err != nil {
+			return nil, fmt.Errorf("indicators.%s: %w", name, err)
+		}
+		indicators = append(indicators, jsonMember{Key: name, Value: entry})
+	}
+	if len(indicators) == 0 {
+		return data, nil
+	}
+
+	object, err := encodeObjectMembers(indicators)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 {
+		position = len(result)
+	}
+	result = append(result[:position], append([]jsonMember{{Key: "indicators", Value: object}}, result[position:]...)...)
+	return encodeObjectMembers(result)
+}
+
+// UnmarshalJSON reads the "indicators" map on top of the other settings; entries
+// that aren't built-in indicators become plugin settings
+func (c *Config) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Indicators map[string]json.RawMessage `json:"indicators"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*configAlias)(c)); err != nil {
+		return err
+	}
+
+	plugins := make(map[string]PluginConfig, len(c.Plugins))
+	for name, plugin := range c.Plugins {
+		plugins[name] = plugin
+	}
+	for _, name := range sortedKeys(raw.Indicators) {
+		if field, ok := builtinIndicatorField(name); ok {
+			section := reflect.ValueOf(c).Elem().FieldByIndex(field.Index).Addr().Interface()
+			if err := json.Unmarshal(raw.Indicators[name], section); err != nil {
+				return fmt.Errorf("indicators.%s: %w", name, err)
+			}
+			continue
+		}
+		plugin, err := decodePluginEntry(raw.Indicators[name])
+		if err != nil {
+			return fmt.Errorf("indicators.%s: %w", name, err)
+		}
+		plugins[name] = plugin
+	}
+	if len(plugins) > 0 {
+		c.Plugins = plugins
+	}
+	return nil
+}
+
+// encodePluginEntry flattens a plugin's params next to its enabled flag
+func encodePluginEntry(plugin PluginConfig) (json.RawMessage, error) {
+	enabled, _ := json.Marshal(plugin.Enabled)
+	members := []jsonMember{{Key: "enabled", Value: enabled}}
+	if len(bytes.TrimSpace(plugin.Params)) > 0 && string(bytes.TrimSpace(plugin.Params)) != "null" {
+		params, err := decodeObjectMembers(plugin.Params)
+		if err != nil {
+			return nil, fmt.Errorf("params must be a JSON object: %w", err)
+		}
+		for _, param := range params {
+			if param.Key != "enabled" {
+				members = append(members, param)
+			}
+		}
+	}
+	return encodeObjectMembers(members)
+}
+
+// decodePluginEntry splits a plugin entry into its enabled flag and params
+func decodePluginEntry(entry json.RawMessage) (PluginConfig, error) {
+	var plugin PluginConfig
+	members, err := decodeObjectMembers(entry)
+	if err != nil {
+		return plugin, err
+	}
+	var params []jsonMember
+	for _, member := range members {
+		if member.Key != "enabled" {
+			params = append(params, member)
+			continue
+		}
+		if err := json.Unmarshal(member.Value, &plugin.Enabled); err != nil {
+			return plugin, fmt.Errorf("enabled: %w", err)
+		}
+	}
+	if len(params) > 0 {
+		if plugin.Params, err = encodeObjectMembers(params); err != nil {
+			return plugin, err
+		}
+	}
+	return plugin, nil
+}
+
+// decodeObjectMembers splits a JSON object into its members in document order
+func decodeObjectMembers(data []byte) ([]jsonMember, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	var members []jsonMember
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		members = append(members, jsonMember{Key: token.(string), Value: value})
+	}
+	return members, nil
+}
+
+// encodeObjectMembers joins members back into a JSON object
+func encodeObjectMembers(members []jsonMember) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, member := range members {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, err := json.Marshal(member.Key)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(member.Value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// UnknownConfigFields lists the keys of raw config JSON that no setting reads, as
+// sorted dotted paths (e.g. "indicators.rsi.perod"). Plugin entries are checked
+// by ValidateConfig once the plugins have registered.
+func UnknownConfigFields(data []byte) ([]string, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var unknown []string
+	if indicators, ok := doc["indicators"].(map[string]interface{}); ok {
+		for name, entry := range indicators {
+			if field, ok := builtinIndicatorField(name); ok {
+				collectUnknownFields(entry, field.Type, "indicators."+name, &unknown)
+			}
+		}
+		delete(doc, "indicators")
+	}
+	collectUnknownFields(doc, reflect.TypeOf(Config{}), "", &unknown)
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// collectUnknownFields walks a decoded JSON value against the Go type it decodes into
+func collectUnknownFields(value interface{}, t reflect.Type, path string, unknown *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, child := range object {
+			field, ok := jsonField(t, key)
+			if !ok {
+				*unknown = append(*unknown, join(key))
+				continue
+			}
+			collectUnknownFields(child, field.Type, join(key), unknown)
+		}
+	case reflect.Map:
+		if object, ok := value.(map[string]interface{}); ok {
+			for key, child := range object {
+				collectUnknownFields(child, t.Elem(), join(key), unknown)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if list, ok := value.([]interface{}); ok {
+			for i, child := range list {
+				collectUnknownFields(child, t.Elem(), fmt.Sprintf("%s[%d]", path, i), unknown)
+			}
+		}
+	}
+}
+
+// jsonField finds the exported field a JSON key decodes into, matching case-insensitively like encoding/json
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var match reflect.StructField
+	found := false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Tag.Get("json") == "-" {
+			continue
+		}
+		name := jsonName(field)
+		if name == key {
+			return field, true
+		}
+		if !found && strings.EqualFold(name, key) {
+			match, found = field, true
+		}
+	}
+	return match, found
+}
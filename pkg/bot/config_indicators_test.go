@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIndicatorConfigMap(t *testing.T) {
+	t.Log("🗂️ Testing the indicators config map, its schema checks and migration")
+
+	for _, info := range Indicators() {
+		if _, ok := builtinIndicatorField(info.Name); info.plugin == nil && !ok {
+			t.Errorf("Built-in indicator %s has no config section", info.Name)
+		}
+	}
+
+	// Indicator sections and plugins are written under "indicators" only
+	config := DefaultConfig()
+	config.Plugins = map[string]PluginConfig{"momentum": {Enabled: true, Params: json.RawMessage(`{"period": 5}`)}}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var doc map[string]json.RawMessage
+	json.Unmarshal(data, &doc)
+	if doc["rsi"] != nil || doc["plugins"] != nil || doc["indicators"] == nil {
+		t.Fatalf("Expected indicator settings under indicators only, got %s", data)
+	}
+	var indicators map[string]map[string]interface{}
+	json.Unmarshal(doc["indicators"], &indicators)
+	if indicators["rsi"]["period"] != 14.0 || indicators["momentum"]["enabled"] != true || indicators["momentum"]["period"] != 5.0 {
+		t.Errorf("Unexpected indicators map: %v", indicators)
+	}
+
+	// The map round-trips, and partial entries keep the other defaults
+	var decoded Config
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(decoded.RSI, config.RSI) || !decoded.Plugins["momentum"].Enabled {
+		t.Errorf("Round trip lost settings: %+v (err %v)", decoded.Plugins, err)
+	}
+	if again, _ := json.Marshal(decoded); string(again) != string(data) {
+		t.Errorf("Expected a stable encoding:\n%s\n%s", data, again)
+	}
+	partial := DefaultConfig()
+	if err := json.Unmarshal([]byte(`{"indicators": {"rsi": {"period": 9}}}`), &partial); err != nil || partial.RSI.Period != 9 || partial.RSI.Overbought != 70 {
+		t.Errorf("Expected period 9 over the RSI defaults, got %+v (err %v)", partial.RSI, err)
+	}
+
+	// Wrong types and unknown keys are reported with their path
+	if err := json.Unmarshal([]byte(`{"indicators": {"rsi": {"period": "fast"}}}`), &partial); err == nil || !strings.HasPrefix(err.Error(), "indicators.rsi: ") {
+		t.Errorf("Expected a typed error for indicators.rsi, got %v", err)
+	}
+	unknown, err := UnknownConfigFields([]byte(`{"indicators": {"rsi": {"perod": 14}, "momentum": {"period": 3}}, "symbl": "BTCUSDT", "strategy": {"mode": "5m_focus", "weights": {}}}`))
+	if err != nil || strings.Join(unknown, ",") != "indicators.rsi.perod,strategy.weights,symbl" {
+		t.Errorf("Unexpected unknown fields %v (err %v)", unknown, err)
+	}
+
+	// Version 1 files with top-level blocks and a plugins map are migrated
+	migrated, from, err := MigrateConfigData([]byte(`{"version": 1, "rsi": {"period": 9}, "plugins": {"momentum": {"enabled": true, "params": {"period": 4}}}}`))
+	if err != nil || from != 1 {
+		t.Fatalf("Migration failed from version %d: %v", from, err)
+	}
+	upgraded := DefaultConfig()
+	if err := json.Unmarshal(migrated, &upgraded); err != nil {
+		t.Fatalf("Migrated config is invalid: %v", err)
+	}
+	if upgraded.RSI.Period != 9 || !upgraded.Plugins["momentum"].Enabled || string(upgraded.Plugins["momentum"].Params) != `{"period":4}` {
+		t.Errorf("Expected the v1 settings in the indicators map, got %s", migrated)
+	}
+}
@@ -0,0 +1,205 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// CurrentConfigVersion is the config schema version written by this build
+const CurrentConfigVersion = 2
+
+// configMigration upgrades a raw config document from version From to From+1
+type configMigration struct {
+	From        int
+	Description string
+	Apply       func(doc map[string]interface{})
+}
+
+// configMigrations run in order; add one (and bump CurrentConfigVersion) whenever
+// a field is renamed or moved so older config.json files keep their settings
+var configMigrations = []configMigration{
+	{From: 0, Description: "flatten trading.indicators and rename legacy indicator keys", Apply: migrateLegacyLayout},
+	{From: 1, Description: "move indicator blocks and plugins into the indicators map", Apply: migrateIndicatorMap},
+}
+
+// legacyIndicatorKeys maps old block names to current ones
+var legacyIndicatorKeys = map[string]string{
+	"reverse_mfi": "mfi",
+}
+
+// legacyFieldKeys maps old field names to current ones per indicator block
+var legacyFieldKeys = map[string]map[string]string{
+	"mfi":                {"oversold_threshold": "oversold", "overbought_threshold": "overbought"},
+	"ichimoku":           {"senkou_span_b_period": "senkou_period"},
+	"trend":              {"short_period": "short_ma", "long_period": "long_ma"},
+	"volume":             {"threshold": "volume_threshold"},
+	"support_resistance": {"lookback_period": "period"},
+	"ema":                {"short_period": "fast_period", "long_period": "slow_period"},
+	"bollinger_bands":    {"std_dev": "standard_dev"},
+}
+
+// migrateLegacyLayout moves the nested {"trading": {"symbol", "indicators": {...}}}
+// layout to top-level blocks and renames keys the current schema no longer reads
+func migrateLegacyLayout(doc map[string]interface{}) {
+	if trading, ok := doc["trading"].(map[string]interface{}); ok {
+		if indicators, ok := trading["indicators"].(map[string]interface{}); ok {
+			for name, block := range indicators {
+				if _, exists := doc[name]; !exists {
+					doc[name] = block
+				}
+			}
+			delete(trading, "indicators")
+		}
+		for key, value := range trading {
+			if _, exists := doc[key]; !exists {
+				doc[key] = value
+			}
+		}
+		delete(doc, "trading")
+	}
+
+	for oldKey, newKey := range legacyIndicatorKeys {
+		if block, ok := doc[oldKey]; ok {
+			if _, exists := doc[newKey]; !exists {
+				doc[newKey] = block
+			}
+			delete(doc, oldKey)
+		}
+	}
+
+	for name, renames := range legacyFieldKeys {
+		block, ok := doc[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for oldKey, newKey := range renames {
+			if value, ok := block[oldKey]; ok {
+				if _, exists := block[newKey]; !exists {
+					block[newKey] = value
+				}
+				delete(block, oldKey)
+			}
+		}
+	}
+}
+
+// migrateIndicatorMap moves the top-level indicator blocks and the "plugins" map
+// ({"enabled", "params": {...}}) into one "indicators" map with params flattened
+func migrateIndicatorMap(doc map[string]interface{}) {
+	indicators, ok := doc["indicators"].(map[string]interface{})
+	if !ok {
+		indicators = make(map[string]interface{})
+	}
+
+	for _, info := range Indicators() {
+		block, ok := doc[info.Name]
+		if !ok || info.plugin != nil {
+			continue
+		}
+		if _, exists := indicators[info.Name]; !exists {
+			indicators[info.Name] = block
+		}
+		delete(doc, info.Name)
+	}
+
+	if plugins, ok := doc["plugins"].(map[string]interface{}); ok {
+		for name, value := range plugins {
+			plugin, ok := value.(map[string]interface{})
+			if _, exists := indicators[name]; !ok || exists {
+				continue
+			}
+			entry := make(map[string]interface{})
+			if params, ok := plugin["params"].(map[string]interface{}); ok {
+				for key, param := range params {
+					entry[key] = param
+				}
+			}
+			if enabled, ok := plugin["enabled"]; ok {
+				entry["enabled"] = enabled
+			}
+			indicators[name] = entry
+		}
+		delete(doc, "plugins")
+	}
+
+	if len(indicators) > 0 {
+		doc["indicators"] = indicators
+	}
+}
+
+// configVersion reads the version field of a raw config document (0 when absent)
+func configVersion(doc map[string]interface{}) int {
+	version, _ := doc["version"].(float64)
+	return int(version)
+}
+
+// MigrateConfigData upgrades raw config JSON to CurrentConfigVersion, returning the
+// migrated document and the version it started from
+func MigrateConfigData(data []byte) ([]byte, int, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	from := configVersion(doc)
+	if from > CurrentConfigVersion {
+		return nil, from, fmt.Errorf("config version %d is newer than supported version %d", from, CurrentConfigVersion)
+	}
+	if from == CurrentConfigVersion {
+		return data, from, nil
+	}
+
+	for _, migration := range configMigrations {
+		if migration.From >= from {
+			migration.Apply(doc)
+		}
+	}
+	doc["version"] = CurrentConfigVersion
+
+	migrated, err := json.Marshal(doc)
+	if err != nil {
+		return nil, from, fmt.Errorf("failed to marshal migrated config: %w", err)
+	}
+	return migrated, from, nil
+}
+
+// UpgradeConfigFile rewrites an older config file at CurrentConfigVersion after
+// saving the original to <filename>.v<version>.bak. Returns whether it was upgraded.
+func UpgradeConfigFile(filename string) (bool, error) {
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	migrated, from, err := MigrateConfigData(data)
+	if err != nil {
+		return false, err
+	}
+	if from == CurrentConfigVersion {
+		return false, nil
+	}
+
+	config := DefaultConfig()
+	if err := json.Unmarshal(migrated, &config); err != nil {
+		return false, fmt.Errorf("failed to parse migrated config: %w", err)
+	}
+	if err := ValidateConfig(config); err != nil {
+		return false, fmt.Errorf("migrated config is invalid, leaving %s unchanged: %w", filename, err)
+	}
+
+	backup := fmt.Sprintf("%s.v%d.bak", filename, from)
+	if err := ioutil.WriteFile(backup, data, 0644); err != nil {
+		return false, fmt.Errorf("failed to write config backup: %w", err)
+	}
+	if err := SaveConfig(config, filename); err != nil {
+		return false, err
+	}
+
+	configLog.Info("🔧 Config upgraded", "file", filename, "from_version", from, "to_version", CurrentConfigVersion, "backup", backup)
+	return true, nil
+}
@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigMigration(t *testing.T) {
+	t.Log("🔧 Testing config schema migration and in-place upgrade")
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.json")
+	legacy := `{
+  "trading": {
+    "symbol": "ETHUSDT",
+    "indicators": {
+      "trend": {"enabled": true, "short_period": 10, "long_period": 25},
+      "ichimoku": {"enabled": false, "tenkan_period": 9, "kijun_period": 26, "senkou_span_b_period": 60},
+      "reverse_mfi": {"enabled": false, "period": 10, "oversold_threshold": 15, "overbought_threshold": 85}
+    }
+  },
+  "api": {"port": 8080}
+}`
+	if err := ioutil.WriteFile(filename, []byte(legacy), 0644); err != nil {
+		t.Fatalf("Failed to write legacy config: %v", err)
+	}
+
+	cm := NewConfigManager(filename)
+	if err := cm.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	config := cm.GetConfig()
+	if config.Version != CurrentConfigVersion || config.Symbol != "ETHUSDT" {
+		t.Errorf("Expected version %d for ETHUSDT, got version %d for %s", CurrentConfigVersion, config.Version, config.Symbol)
+	}
+	if config.Trend.ShortMA != 10 || config.Trend.LongMA != 25 || config.Ichimoku.SenkouPeriod != 60 || config.Ichimoku.Enabled {
+		t.Errorf("Legacy trend/ichimoku settings not migrated: %+v %+v", config.Trend, config.Ichimoku)
+	}
+	if config.MFI.Enabled || config.MFI.Period != 10 || config.MFI.Oversold != 15 || config.MFI.Overbought != 85 {
+		t.Errorf("reverse_mfi block not migrated to mfi: %+v", config.MFI)
+	}
+	if config.Stochastic.KPeriod != DefaultConfig().Stochastic.KPeriod {
+		t.Errorf("Blocks missing from the old file should use defaults")
+	}
+
+	// The original is backed up and the file is rewritten at the current version
+	backup, err := ioutil.ReadFile(filename + ".v0.bak")
+	if err != nil || string(backup) != legacy {
+		t.Fatalf("Expected original config in backup, got err %v", err)
+	}
+	var rewritten map[string]interface{}
+	data, _ := ioutil.ReadFile(filename)
+	if err := json.Unmarshal(data, &rewritten); err != nil {
+		t.Fatalf("Rewritten config is invalid JSON: %v", err)
+	}
+	indicators, _ := rewritten["indicators"].(map[string]interface{})
+	if configVersion(rewritten) != CurrentConfigVersion || rewritten["trading"] != nil || rewritten["mfi"] != nil || indicators["mfi"] == nil {
+		t.Errorf("Expected rewritten file in the current layout, got keys %v", rewritten)
+	}
+
+	// Current files are left untouched
+	if upgraded, err := UpgradeConfigFile(filename); upgraded || err != nil {
+		t.Errorf("Expected no upgrade for a current file, got %v (err %v)", upgraded, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("Expected only config and one backup, got %d files", len(entries))
+	}
+
+	// Files from a newer build are rejected rather than silently downgraded
+	if err := ioutil.WriteFile(filename, []byte(`{"version": 99}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := LoadConfig(filename); err == nil || !strings.Contains(err.Error(), "newer") {
+		t.Errorf("Expected newer-version error, got %v", err)
+	}
+}
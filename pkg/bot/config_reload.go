@@ -0,0 +1,244 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"time"
+)
+
+// ConfigReload reports which changed settings took effect and which wait for a restart
+type ConfigReload struct {
+	Reloaded        []string `json:"reloaded"`         // Changed settings now in effect, by JSON key
+	RestartRequired []string `json:"restart_required"` // Changed settings applied on the next start
+}
+
+// hotReloadKeys are the settings the signal aggregator reads; changing them
+// swaps in a freshly built aggregator instead of restarting the engines
+var hotReloadKeys = map[string]bool{
+	"indicators":        true,
+	"min_confidence":    true,
+	"strategy":          true,
+	"candle_transforms": true,
+	"divergence":        true,
+	"regime_switching":  true,
+	"pine":              true,
+	"data_quality":      true,
+}
+
+// isHotReloadField reports whether a Config field belongs to a hot-reloadable setting
+func isHotReloadField(field reflect.StructField) bool {
+	if field.Name == "Plugins" {
+		return true
+	}
+	name := jsonName(field)
+	if _, ok := builtinIndicatorField(name); ok {
+		return true
+	}
+	return hotReloadKeys[name]
+}
+
+// copyHotSettings copies the hot-reloadable settings of src into dst
+func copyHotSettings(dst *Config, src Config) {
+	dstValue, srcValue := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src)
+	for i := 0; i < dstValue.NumField(); i++ {
+		if isHotReloadField(dstValue.Type().Field(i)) {
+			dstValue.Field(i).Set(srcValue.Field(i))
+		}
+	}
+}
+
+// changedConfigKeys lists the top-level JSON keys whose values differ
+func changedConfigKeys(old, new Config) ([]string, error) {
+	var before, after map[string]json.RawMessage
+	for _, pair := range []struct {
+		config Config
+		into   *map[string]json.RawMessage
+	}{{old, &before}, {new, &after}} {
+		data, err := json.Marshal(pair.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode config: %w", err)
+		}
+		if err := json.Unmarshal(data, pair.into); err != nil {
+			return nil, fmt.Errorf("failed to decode config: %w", err)
+		}
+	}
+
+	changed := make(map[string]bool)
+	for key, value := range after {
+		if !bytes.Equal(value, before[key]) {
+			changed[key] = true
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changed[key] = true
+		}
+	}
+	return sortedKeys(changed), nil
+}
+
+// KeepCredentials copies the exchange API keys, admin token, API users and Telegram bot token of from into config
+func KeepCredentials(config *Config, from Config) {
+	config.Binance.APIKey, config.Binance.SecretKey = from.Binance.APIKey, from.Binance.SecretKey
+	config.Coinbase, config.Kraken, config.Bybit = from.Coinbase, from.Kraken, from.Bybit
+	config.Admin = from.Admin
+	config.Notifications.Telegram.Token = from.Notifications.Telegram.Token
+}
+
+// ApplyConfig validates config and puts its signal settings (indicators,
+// min_confidence, strategy, ...) into effect on every engine by rebuilding their
+// signal aggregators. Other changed settings are reported as needing a restart.
+func (tb *TradingBot) ApplyConfig(config Config) (*ConfigReload, error) {
+	config = normalizeConfigSymbols(config)
+	if err := ValidateConfig(config); err != nil {
+		return nil, err
+	}
+
+	tb.reloadMutex.Lock()
+	defer tb.reloadMutex.Unlock()
+
+	current := tb.config
+	if tb.hotConfig != nil {
+		current = *tb.hotConfig
+	}
+	KeepCredentials(&config, current) // Rotated through ReloadCredentials, not here
+	changed, err := changedConfigKeys(current, config)
+	if err != nil {
+		return nil, err
+	}
+	reload := &ConfigReload{Reloaded: []string{}, RestartRequired: []string{}}
+	for _, key := range changed {
+		if hotReloadKeys[key] {
+			reload.Reloaded = append(reload.Reloaded, key)
+		} else if key != "version" {
+			reload.RestartRequired = append(reload.RestartRequired, key)
+		}
+	}
+	if len(reload.Reloaded) == 0 {
+		return reload, nil
+	}
+
+	// Check every engine before swapping any aggregator
+	engines := tb.symbolEngines()
+	for _, engine := range engines {
+		if err := engine.checkReloadHistory(config); err != nil {
+			return nil, fmt.Errorf("%s: %w", engine.config.Symbol, err)
+		}
+	}
+	for _, engine := range engines {
+		engine.reloadSignalSettings(config)
+	}
+	if tb.tradeExecutor != nil {
+		tb.tradeExecutor.SetMinConfidence(config.MinConfidence)
+	}
+
+	applied := current
+	copyHotSettings(&applied, config)
+	tb.hotConfig = &applied
+	configLog.Info("🔄 Config reloaded", "reloaded", reload.Reloaded, "restart_required", reload.RestartRequired)
+	return reload, nil
+}
+
+// checkReloadHistory rejects signal settings whose indicators can't be computed
+// from the loaded candles, unless history.on_shortfall drops them. Engines not
+// yet started are checked when they start.
+func (se *SignalEngine) checkReloadHistory(config Config) error {
+	se.mutex.RLock()
+	engineConfig, running := se.config, se.running
+	se.mutex.RUnlock()
+	copyHotSettings(&engineConfig, config)
+	if !running || engineConfig.History.OnShortfall == HistoryShortfallDisable {
+		return nil
+	}
+	if shortfalls := historyShortfalls(engineConfig, se.timeframeManager.GetDataSummary()); len(shortfalls) > 0 {
+		return fmt.Errorf("indicators cannot be computed: %s", shortfalls[0])
+	}
+	return nil
+}
+
+// reloadSignalSettings swaps in an aggregator built from config's signal
+// settings, carrying over the exchange filters and seasonality of the old one
+func (se *SignalEngine) reloadSignalSettings(config Config) {
+	se.mutex.Lock()
+	defer se.mutex.Unlock()
+
+	copyHotSettings(&se.config, config)
+	previous := se.signalAggregator
+	aggregator := NewSignalAggregator(se.config)
+	aggregator.SetClock(se.clock)
+	aggregator.SetSymbolFilters(previous.SymbolFilters())
+	previous.seasonalityMutex.RLock()
+	aggregator.SetSeasonality(previous.seasonality)
+	previous.seasonalityMutex.RUnlock()
+	if se.running && se.config.History.OnShortfall == HistoryShortfallDisable {
+		aggregator.removeIndicators(historyShortfalls(se.config, se.timeframeManager.GetDataSummary()))
+	}
+	se.signalAggregator = aggregator
+}
+
+// aggregator returns the engine's current signal aggregator
+func (se *SignalEngine) aggregator() *SignalAggregator {
+	se.mutex.RLock()
+	defer se.mutex.RUnlock()
+	return se.signalAggregator
+}
+
+// Watch polls the config file every interval until ctx is done. When its
+// contents change on disk it is reloaded and passed to onChange with the config
+// it replaces; invalid edits are logged and ignored, and writes made through
+// Save are not reported.
+func (cm *ConfigManager) Watch(ctx context.Context, interval time.Duration, onChange func(previous, config Config)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			data, err := ioutil.ReadFile(cm.filename)
+			if err != nil {
+				continue
+			}
+			cm.mutex.RLock()
+			unchanged := bytes.Equal(data, cm.data)
+			cm.mutex.RUnlock()
+			if unchanged {
+				continue
+			}
+
+			config, err := LoadConfig(cm.filename)
+			cm.mutex.Lock()
+			previous := cm.config
+			cm.data = data
+			if err == nil {
+				cm.config = config
+			}
+			cm.mutex.Unlock()
+			if err != nil {
+				configLog.Warn("Ignoring invalid config change", "file", cm.filename, "error", err)
+				continue
+			}
+			configLog.Info("📝 Config changed on disk, reloading", "file", cm.filename)
+			onChange(previous, config)
+		}
+	}
+}
+
+// WatchConfig applies external edits of the config manager's file until ctx
+// is done, auditing them as made by "config-file"
+func (tb *TradingBot) WatchConfig(ctx context.Context, cm *ConfigManager, interval time.Duration) {
+	cm.Watch(ctx, interval, func(previous, config Config) {
+		if _, err := tb.ApplyConfig(config); err != nil {
+			configLog.Warn("Failed to apply config change", "file", cm.filename, "error", err)
+			return
+		}
+		user := APIUser{Name: "config-file"}
+		tb.AuditConfig(user, previous, config)
+		tb.RecordConfigVersion(config, user)
+	})
+}
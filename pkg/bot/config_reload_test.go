@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigHotReload(t *testing.T) {
+	t.Log("🔥 Testing hot config reload, credential-safe saves and config file watching")
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	tb := NewTradingBot(config)
+	previous := tb.signalEngine.aggregator()
+
+	// Signal settings swap in a new aggregator; other changes wait for a restart
+	updated := config
+	updated.RSI.Enabled = false
+	updated.MinConfidence = 0.7
+	updated.Streaming.StaleSeconds = 60
+	reload, err := tb.ApplyConfig(updated)
+	if err != nil {
+		t.Fatalf("ApplyConfig failed: %v", err)
+	}
+	if strings.Join(reload.Reloaded, ",") != "indicators,min_confidence" || strings.Join(reload.RestartRequired, ",") != "streaming" {
+		t.Errorf("Unexpected reload report: %+v", reload)
+	}
+	aggregator := tb.signalEngine.aggregator()
+	if aggregator == previous || aggregator.config.RSI.Enabled || aggregator.config.MinConfidence != 0.7 {
+		t.Errorf("Expected a rebuilt aggregator without RSI at min confidence 0.7")
+	}
+	if tb.signalEngine.config.Streaming.StaleSeconds != config.Streaming.StaleSeconds {
+		t.Errorf("Restart-only settings must not change the running engine")
+	}
+
+	// Unchanged and invalid configs leave the aggregator alone
+	if reload, err := tb.ApplyConfig(updated); err != nil || len(reload.Reloaded) != 0 {
+		t.Errorf("Expected nothing to reload, got %+v (err %v)", reload, err)
+	}
+	updated.MinConfidence = 2
+	if _, err := tb.ApplyConfig(updated); err == nil || tb.signalEngine.aggregator() != aggregator {
+		t.Errorf("Expected an invalid config to be rejected, got err %v", err)
+	}
+
+	// Saving keeps the file's credentials, so keys from the environment stay off disk
+	filename := filepath.Join(t.TempDir(), "config.json")
+	stored := DefaultConfig()
+	stored.Binance.APIKey = "file-key"
+	if err := SaveConfig(stored, filename); err != nil {
+		t.Fatalf("SaveConfig failed: %v", err)
+	}
+	cm := NewConfigManager(filename)
+	if err := cm.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	live := cm.GetConfig()
+	live.Binance.APIKey = "env-key"
+	live.MinConfidence = 0.65
+	if err := cm.UpdateConfig(live); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	if err := cm.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if saved, err := LoadConfig(filename); err != nil || saved.Binance.APIKey != "file-key" || saved.MinConfidence != 0.65 {
+		t.Errorf("Expected the file's API key with the new min confidence, got %q at %.2f (err %v)", saved.Binance.APIKey, saved.MinConfidence, err)
+	}
+
+	// External edits are picked up; invalid ones are ignored
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan Config, 4)
+	go cm.Watch(ctx, 5*time.Millisecond, func(_, config Config) { changes <- config })
+
+	data, _ := ioutil.ReadFile(filename)
+	if err := ioutil.WriteFile(filename, []byte(strings.Replace(string(data), `"min_confidence": 0.65`, `"min_confidence": 2`, 1)), 0644); err != nil {
+		t.Fatalf("Failed to edit config: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if err := ioutil.WriteFile(filename, []byte(strings.Replace(string(data), `"min_confidence": 0.65`, `"min_confidence": 0.8`, 1)), 0644); err != nil {
+		t.Fatalf("Failed to edit config: %v", err)
+	}
+	select {
+	case changed := <-changes:
+		if changed.MinConfidence != 0.8 || cm.GetConfig().MinConfidence != 0.8 {
+			t.Errorf("Expected min confidence 0.8 from disk, got %.2f", changed.MinConfidence)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the edited config file to be reported")
+	}
+}
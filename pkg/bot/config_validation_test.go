@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigAggregatesErrors(t *testing.T) {
+	t.Log("📋 Testing that config validation reports every problem with field paths")
+
+	config := DefaultConfig()
+	config.RSI.Period = 0
+	config.Ichimoku.TenkanPeriod = 30 // Above Kijun (26)
+	config.MinConfidence = 1.5
+	config.StrategyAllocations = map[string]StrategyAllocation{"REBALANCE": {CapitalFraction: 2}}
+	config.Providers = map[string]TimeframeProviderConfig{"5m": {RealTime: "carrier_pigeon"}}
+
+	err := ValidateConfig(config)
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %T: %v", err, err)
+	}
+
+	fields := make(map[string]int)
+	for _, fieldErr := range validationErrs {
+		fields[fieldErr.Field]++
+	}
+	for _, field := range []string{"rsi.period", "ichimoku.tenkan_period", "min_confidence",
+		"strategy_allocations.REBALANCE.capital_fraction", "strategy_allocations", "providers.5m"} {
+		if fields[field] == 0 {
+			t.Errorf("Expected a problem for %s, got %v", field, validationErrs)
+		}
+	}
+	if !strings.HasPrefix(err.Error(), "6 config problems: rsi.period: ") {
+		t.Errorf("Unexpected error text: %s", err.Error())
+	}
+
+	// A single problem reads like the old first-error message, prefixed with its field
+	config = DefaultConfig()
+	config.MACD.FastPeriod = 30
+	if err := ValidateConfig(config); err == nil || err.Error() != "macd.fast_period: MACD fast period must be less than slow period" {
+		t.Errorf("Unexpected single error: %v", err)
+	}
+	if err := ValidateConfig(DefaultConfig()); err != nil {
+		t.Errorf("Default config should be valid: %v", err)
+	}
+
+	// LoadConfig wraps the aggregated errors so callers can still inspect them
+	filename := filepath.Join(t.TempDir(), "config.json")
+	if err := ioutil.WriteFile(filename, []byte(`{"version": 1, "rsi": {"period": 0}, "mfi": {"period": 0}}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	_, err = LoadConfig(filename)
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Errorf("Expected 2 wrapped validation errors, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoContextSnapshot is returned before an engine has generated its first signal
+var ErrNoContextSnapshot = errors.New("no signal has been generated yet")
+
+// TimeframeFreshness is how current one timeframe's candles were when a signal used them
+type TimeframeFreshness struct {
+	Candles     int       `json:"candles"`
+	Latest      *Candle   `json:"latest,omitempty"`
+	LatestClose time.Time `json:"latest_close,omitempty"` // In the future while the candle is forming
+	AgeSeconds  float64   `json:"age_seconds"`            // Capture time minus LatestClose; negative while forming
+	Stale       bool      `json:"stale"`                  // Latest candle closed more than two intervals before capture
+}
+
+// ContextSnapshot is the multi-timeframe context the most recent signal was generated from
+type ContextSnapshot struct {
+	Symbol       string                        `json:"symbol"`
+	CapturedAt   time.Time                     `json:"captured_at"`
+	Trigger      string                        `json:"trigger"` // "engine" (scheduled) or "prediction" (on demand)
+	Signal       *TradingSignal                `json:"signal"`
+	CurrentPrice float64                       `json:"current_price"` // Latest 5m close the aggregator saw
+	PriceSource  string                        `json:"price_source"`  // Configured price source for predictions and marking
+	Freshness    map[string]TimeframeFreshness `json:"freshness"`     // Keyed by timeframe, e.g. "5m"
+	DataTiming   *DataTiming                   `json:"data_timing,omitempty"`
+	Context      *MultiTimeframeContext        `json:"context"`
+}
+
+// contextCandles returns the context's candles per timeframe
+func contextCandles(ctx *MultiTimeframeContext) map[Timeframe][]Candle {
+	return map[Timeframe][]Candle{
+		Daily:           ctx.DailyCandles,
+		EightHour:       ctx.EightHourCandles,
+		FortyFiveMinute: ctx.FortyFiveMinCandles,
+		FifteenMinute:   ctx.FifteenMinCandles,
+		FiveMinute:      ctx.FiveMinCandles,
+	}
+}
+
+// recordContext keeps the context a signal was generated from for /context
+func (se *SignalEngine) recordContext(ctx *MultiTimeframeContext, signal *TradingSignal, trigger string) {
+	snapshot := &ContextSnapshot{
+		Symbol:       ctx.Symbol,
+		CapturedAt:   ctx.LastUpdate,
+		Trigger:      trigger,
+		Signal:       signal,
+		CurrentPrice: ctx.GetCurrentPrice(),
+		Freshness:    make(map[string]TimeframeFreshness),
+		Context:      ctx,
+	}
+	for tf, candles := range contextCandles(ctx) {
+		freshness := TimeframeFreshness{Candles: len(candles)}
+		if len(candles) > 0 {
+			latest := candles[len(candles)-1]
+			freshness.Latest = &latest
+			freshness.LatestClose = latest.Timestamp.Add(tf.Duration())
+			age := ctx.LastUpdate.Sub(freshness.LatestClose)
+			freshness.AgeSeconds = age.Seconds()
+			freshness.Stale = age > 2*tf.Duration()
+		}
+		snapshot.Freshness[tf.String()] = freshness
+	}
+
+	se.mutex.Lock()
+	defer se.mutex.Unlock()
+	se.lastContext = snapshot
+}
+
+// GetContextSnapshot returns the context behind a symbol's most recent signal
+// (the traded symbol when empty), with its price source and data timing
+func (tb *TradingBot) GetContextSnapshot(symbol string) (*ContextSnapshot, error) {
+	engine, err := tb.engineFor(symbol)
+	if err != nil {
+		return nil, err
+	}
+	engine.mutex.RLock()
+	recorded := engine.lastContext
+	engine.mutex.RUnlock()
+	if recorded == nil {
+		return nil, fmt.Errorf("%w for %s", ErrNoContextSnapshot, engine.config.Symbol)
+	}
+
+	snapshot := *recorded
+	snapshot.PriceSource = tb.config.PriceSourceFor(snapshot.Symbol)
+	if timing := tb.GetSymbolDataTiming(snapshot.Symbol); !timing.FetchCompleted.IsZero() {
+		snapshot.DataTiming = &timing
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,58 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextSnapshot(t *testing.T) {
+	t.Log("🔍 Testing the multi-timeframe context snapshot behind the latest signal")
+
+	config := DefaultConfig()
+	tb := NewTradingBot(config)
+	if _, err := tb.GetContextSnapshot(""); !errors.Is(err, ErrNoContextSnapshot) {
+		t.Fatalf("Expected no snapshot before the first signal, got %v", err)
+	}
+	if _, err := tb.GetContextSnapshot("DOGEUSDT"); err == nil || errors.Is(err, ErrNoContextSnapshot) {
+		t.Fatalf("Expected an unconfigured symbol to be rejected, got %v", err)
+	}
+
+	// Current 5m and 15m candles, a daily series that stopped 10 days ago
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ctx := &MultiTimeframeContext{
+		Symbol:            config.Symbol,
+		DailyCandles:      syntheticCandles(Daily, now.AddDate(0, 0, -40), 30),
+		FifteenMinCandles: syntheticCandles(FifteenMinute, now.Add(-80*FifteenMinute.Duration()), 80),
+		FiveMinCandles:    syntheticCandles(FiveMinute, now.Add(-99*FiveMinute.Duration()), 100),
+		LastUpdate:        now,
+	}
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.8, Timestamp: now}
+	tb.signalEngine.recordContext(ctx, signal, "engine")
+
+	snapshot, err := tb.GetContextSnapshot("")
+	if err != nil {
+		t.Fatalf("Expected a snapshot after a signal: %v", err)
+	}
+	if snapshot.Signal != signal || snapshot.Context != ctx || snapshot.Trigger != "engine" || snapshot.PriceSource != PriceSourceLast {
+		t.Errorf("Expected the signal, its context and the price source, got %+v", snapshot)
+	}
+	if snapshot.CurrentPrice != ctx.GetCurrentPrice() || !snapshot.CapturedAt.Equal(now) {
+		t.Errorf("Expected the 5m close at capture time, got %.2f at %s", snapshot.CurrentPrice, snapshot.CapturedAt)
+	}
+
+	// The forming 5m candle closes in the future; the daily series is stale
+	five := snapshot.Freshness["5m"]
+	if five.Candles != 100 || five.Latest == nil || five.AgeSeconds != -300 || five.Stale {
+		t.Errorf("Expected a forming 5m candle 5 minutes from closing, got %+v", five)
+	}
+	if fifteen := snapshot.Freshness["15m"]; fifteen.AgeSeconds != 0 || fifteen.Stale {
+		t.Errorf("Expected a 15m candle that just closed, got %+v", fifteen)
+	}
+	if daily := snapshot.Freshness["1d"]; !daily.Stale || daily.AgeSeconds != (10*24*time.Hour).Seconds() {
+		t.Errorf("Expected a stale daily series, got %+v", daily)
+	}
+	if eight := snapshot.Freshness["8h"]; eight.Candles != 0 || eight.Latest != nil {
+		t.Errorf("Expected an empty 8h timeframe, got %+v", eight)
+	}
+}
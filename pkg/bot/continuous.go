@@ -0,0 +1,236 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// deliveryExpiryHour is the UTC hour Binance and Bybit settle delivery futures
+const deliveryExpiryHour = 8
+
+// ParseDeliveryContract splits a delivery futures symbol into its pair and expiry:
+// BTCUSDT_240329 (Binance) or BTCUSDT-29MAR24 (Bybit)
+func ParseDeliveryContract(contract string) (string, time.Time, error) {
+	var pair, layout, date string
+	if i := strings.LastIndex(contract, "_"); i > 0 {
+		pair, layout, date = contract[:i], "060102", contract[i+1:]
+	} else if i := strings.LastIndex(contract, "-"); i > 0 {
+		pair, layout, date = contract[:i], "02Jan06", contract[i+1:] // Month names parse case-insensitively
+	} else {
+		return "", time.Time{}, fmt.Errorf("%s is not a delivery contract (expected PAIR_YYMMDD or PAIR-DDMMMYY)", contract)
+	}
+
+	day, err := time.Parse(layout, date)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s has an invalid expiry date: %w", contract, err)
+	}
+	return NormalizeSymbol(pair), day.Add(deliveryExpiryHour * time.Hour), nil
+}
+
+// deliveryContract is a contract of a continuous series and the time the series
+// rolls off it
+type deliveryContract struct {
+	symbol string
+	roll   time.Time
+}
+
+// ContinuousContractProvider serves Symbol as one back-adjusted series across its
+// delivery contracts. Each contract covers the candles up to its roll, RollDays
+// before expiry. At every roll the older contracts are shifted (or scaled) by the
+// gap between the two contracts' closes, so indicators see no jump and the live
+// contract keeps its real prices. Other symbols pass through to the source.
+type ContinuousContractProvider struct {
+	source     DataProvider
+	symbol     string
+	contracts  []deliveryContract // Oldest first
+	adjustment string
+	now        func() time.Time
+
+	mutex sync.Mutex
+	gaps  map[string]float64 // Measured rolls keyed by timeframe/contract; past rolls don't change
+}
+
+// NewContinuousContractProvider wraps source, which must fetch time ranges, to serve
+// symbol as a continuous series of the configured contracts
+func NewContinuousContractProvider(source DataProvider, symbol string, config ContinuousConfig) (*ContinuousContractProvider, error) {
+	if _, ok := source.(RangeDataProvider); !ok {
+		return nil, fmt.Errorf("continuous contracts need a provider that fetches time ranges")
+	}
+	p := &ContinuousContractProvider{
+		source:     source,
+		symbol:     symbol,
+		adjustment: valueOrDefault(config.Adjustment, AdjustDifference),
+		now:        time.Now,
+		gaps:       make(map[string]float64),
+	}
+	for _, contract := range config.Contracts {
+		_, expiry, err := ParseDeliveryContract(contract)
+		if err != nil {
+			return nil, err
+		}
+		p.contracts = append(p.contracts, deliveryContract{symbol: contract, roll: expiry.AddDate(0, 0, -config.RollDays)})
+	}
+	if len(p.contracts) == 0 {
+		return nil, fmt.Errorf("continuous series for %s has no contracts", symbol)
+	}
+	return p, nil
+}
+
+// contractAt returns the index of the contract the series follows at t
+func (p *ContinuousContractProvider) contractAt(t time.Time) int {
+	for i, contract := range p.contracts {
+		if t.Before(contract.roll) {
+			return i
+		}
+	}
+	return len(p.contracts) - 1
+}
+
+// GetHistoricalRange returns the back-adjusted candles opening in [start, end)
+func (p *ContinuousContractProvider) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	ranged := p.source.(RangeDataProvider)
+	if symbol != p.symbol {
+		return ranged.GetHistoricalRange(symbol, timeframe, start, end)
+	}
+
+	first, current := p.contractAt(start), p.contractAt(p.now())
+	last := min(p.contractAt(end.Add(-time.Nanosecond)), current)
+
+	// Each contract is adjusted by every roll after it, newest first
+	adjustments := make([]float64, current+1)
+	adjustments[current] = p.identity()
+	for i := current - 1; i >= first; i-- {
+		gap, err := p.rollGap(timeframe, i)
+		if err != nil {
+			return nil, err
+		}
+		adjustments[i] = p.combine(adjustments[i+1], gap)
+	}
+
+	var series []Candle
+	for i := first; i <= last; i++ {
+		from, to := start, end
+		if i > 0 && p.contracts[i-1].roll.After(from) {
+			from = p.contracts[i-1].roll
+		}
+		if i < current && p.contracts[i].roll.Before(to) {
+			to = p.contracts[i].roll
+		}
+		if !from.Before(to) {
+			continue
+		}
+		candles, err := ranged.GetHistoricalRange(p.contracts[i].symbol, timeframe, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", p.contracts[i].symbol, err)
+		}
+		for _, candle := range appendNewerCandles(nil, filterCandleRange(candles, from, to)) {
+			if n := len(series); n == 0 || candle.Timestamp.After(series[n-1].Timestamp) {
+				series = append(series, p.adjust(candle, adjustments[i]))
+			}
+		}
+	}
+	return series, nil
+}
+
+// GetHistoricalData returns the latest count back-adjusted candles
+func (p *ContinuousContractProvider) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	if symbol != p.symbol {
+		return p.source.GetHistoricalData(symbol, timeframe, count)
+	}
+	end := p.now()
+	candles, err := p.GetHistoricalRange(symbol, timeframe, end.Add(-time.Duration(count)*timeframe.Duration()), end)
+	if err != nil {
+		return nil, err
+	}
+	return candles[max(len(candles)-count, 0):], nil
+}
+
+// GetRealTimeData streams the contract the series follows now. Its prices are
+// unadjusted, like the newest historical candles; after a roll the history only
+// re-adjusts once it is reloaded.
+func (p *ContinuousContractProvider) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	if symbol != p.symbol {
+		return p.source.GetRealTimeData(symbol, timeframe)
+	}
+	contract := p.contracts[p.contractAt(p.now())]
+	providerLog.Info("🔗 Continuous feed follows contract", "symbol", symbol, "timeframe", timeframe.String(), "contract", contract.symbol, "until", contract.roll)
+	return p.source.GetRealTimeData(contract.symbol, timeframe)
+}
+
+// Close leaves the source open; it is closed as a provider of its own
+func (p *ContinuousContractProvider) Close() error {
+	return nil
+}
+
+// rollGap measures the roll off contract i as the difference (or ratio) between
+// the next contract's close and its own at their last shared candle before the roll
+func (p *ContinuousContractProvider) rollGap(timeframe Timeframe, i int) (float64, error) {
+	key := timeframe.String() + "/" + p.contracts[i].symbol
+	p.mutex.Lock()
+	gap, ok := p.gaps[key]
+	p.mutex.Unlock()
+	if ok {
+		return gap, nil
+	}
+
+	ranged := p.source.(RangeDataProvider)
+	roll := p.contracts[i].roll
+	from := roll.Add(-3 * timeframe.Duration())
+	before, err := ranged.GetHistoricalRange(p.contracts[i].symbol, timeframe, from, roll)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s at its roll: %w", p.contracts[i].symbol, err)
+	}
+	after, err := ranged.GetHistoricalRange(p.contracts[i+1].symbol, timeframe, from, roll)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch %s at the %s roll: %w", p.contracts[i+1].symbol, p.contracts[i].symbol, err)
+	}
+
+	closes := make(map[int64]float64, len(after))
+	for _, candle := range after {
+		closes[candle.Timestamp.UnixMilli()] = candle.Close
+	}
+	for j := len(before) - 1; j >= 0; j-- {
+		next, ok := closes[before[j].Timestamp.UnixMilli()]
+		if !ok || !before[j].Timestamp.Before(roll) || before[j].Close <= 0 {
+			continue
+		}
+		gap = next - before[j].Close
+		if p.adjustment == AdjustRatio {
+			gap = next / before[j].Close
+		}
+		p.mutex.Lock()
+		p.gaps[key] = gap
+		p.mutex.Unlock()
+		return gap, nil
+	}
+	return 0, fmt.Errorf("no %s candles shared by %s and %s before the roll at %s",
+		timeframe.String(), p.contracts[i].symbol, p.contracts[i+1].symbol, roll.Format(time.RFC3339))
+}
+
+// identity is the adjustment of the current contract
+func (p *ContinuousContractProvider) identity() float64 {
+	if p.adjustment == AdjustRatio {
+		return 1
+	}
+	return 0
+}
+
+// combine adds a roll's gap to the adjustment of the contracts after it
+func (p *ContinuousContractProvider) combine(adjustment, gap float64) float64 {
+	if p.adjustment == AdjustRatio {
+		return adjustment * gap
+	}
+	return adjustment + gap
+}
+
+// adjust applies an adjustment to a candle's prices; volume is left as traded
+func (p *ContinuousContractProvider) adjust(candle Candle, adjustment float64) Candle {
+	if p.adjustment == AdjustRatio {
+		candle.Open, candle.High, candle.Low, candle.Close = candle.Open*adjustment, candle.High*adjustment, candle.Low*adjustment, candle.Close*adjustment
+	} else {
+		candle.Open, candle.High, candle.Low, candle.Close = candle.Open+adjustment, candle.High+adjustment, candle.Low+adjustment, candle.Close+adjustment
+	}
+	return candle
+}
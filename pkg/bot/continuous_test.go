@@ -0,0 +1,121 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// contractPriceSource prices each contract at its base plus one per hour from origin
+type contractPriceSource struct {
+	origin time.Time
+	bases  map[string]float64
+}
+
+func (s *contractPriceSource) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	var candles []Candle
+	for t := start.Truncate(timeframe.Duration()); t.Before(end); t = t.Add(timeframe.Duration()) {
+		if t.Before(start) {
+			continue
+		}
+		price := s.bases[symbol] + t.Sub(s.origin).Hours()
+		candles = append(candles, Candle{Timestamp: t, Open: price, High: price + 0.5, Low: price - 0.5, Close: price, Volume: 1})
+	}
+	return candles, nil
+}
+
+func (s *contractPriceSource) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	return nil, nil
+}
+
+func (s *contractPriceSource) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	return nil, nil
+}
+
+func (s *contractPriceSource) Close() error { return nil }
+
+func TestContinuousContracts(t *testing.T) {
+	t.Log("🔗 Testing back-adjusted continuous series across delivery futures rolls")
+
+	for contract, want := range map[string]string{
+		"BTCUSDT_240329":  "BTCUSDT 2024-03-29 08:00",
+		"BTCUSDT-28JUN24": "BTCUSDT 2024-06-28 08:00",
+	} {
+		pair, expiry, err := ParseDeliveryContract(contract)
+		if got := pair + " " + expiry.Format("2006-01-02 15:04"); err != nil || got != want {
+			t.Errorf("ParseDeliveryContract(%s): expected %s, got %s (err %v)", contract, want, got, err)
+		}
+	}
+	if _, _, err := ParseDeliveryContract("BTCUSDT"); err == nil {
+		t.Errorf("Expected a perpetual to be rejected")
+	}
+
+	origin := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)
+	source := &contractPriceSource{origin: origin, bases: map[string]float64{"BTCUSDT_240329": 100, "BTCUSDT_240628": 110, "ETHUSDT": 50}}
+	config := ContinuousConfig{Enabled: true, Contracts: []string{"BTCUSDT_240329", "BTCUSDT_240628"}, RollDays: 3, Adjustment: AdjustDifference}
+	provider, err := NewContinuousContractProvider(source, "BTCUSDT", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.now = func() time.Time { return time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC) }
+
+	// Rolls on March 26 08:00; the older contract is lifted by the 10 gap so the series runs smoothly
+	roll := time.Date(2024, 3, 26, 8, 0, 0, 0, time.UTC)
+	series, err := provider.GetHistoricalRange("BTCUSDT", EightHour, roll.Add(-24*time.Hour), roll.Add(24*time.Hour))
+	if err != nil || len(series) != 6 {
+		t.Fatalf("Expected 6 8h candles around the roll, got %d (err %v)", len(series), err)
+	}
+	for _, candle := range series {
+		if want := 110 + candle.Timestamp.Sub(origin).Hours(); math.Abs(candle.Close-want) > 1e-9 {
+			t.Errorf("Expected %.1f at %s, got %.1f", want, candle.Timestamp.Format(time.RFC3339), candle.Close)
+		}
+	}
+
+	// Ratio adjustment scales the older contract by the closes' ratio at the last shared candle
+	config.Adjustment = AdjustRatio
+	ratio, _ := NewContinuousContractProvider(source, "BTCUSDT", config)
+	ratio.now = provider.now
+	series, _ = ratio.GetHistoricalRange("BTCUSDT", EightHour, roll.Add(-24*time.Hour), roll)
+	last := roll.Add(-8 * time.Hour).Sub(origin).Hours()
+	if want := (100 + last) * (110 + last) / (100 + last); len(series) != 3 || math.Abs(series[2].Close-want) > 1e-9 {
+		t.Errorf("Expected the last pre-roll close scaled to %.2f, got %+v", want, series)
+	}
+
+	// Before the roll the nearer contract is current and unadjusted; other symbols pass through
+	provider.now = func() time.Time { return roll.Add(-time.Hour) }
+	provider.gaps = make(map[string]float64)
+	if series, _ := provider.GetHistoricalRange("BTCUSDT", EightHour, roll.Add(-24*time.Hour), roll); series[0].Close != 100+series[0].Timestamp.Sub(origin).Hours() {
+		t.Errorf("Expected unadjusted prices before the roll, got %+v", series[0])
+	}
+	if candles, _ := provider.GetHistoricalRange("ETHUSDT", EightHour, roll, roll.Add(8*time.Hour)); len(candles) != 1 || candles[0].Close != 50+roll.Sub(origin).Hours() {
+		t.Errorf("Expected ETHUSDT to pass through, got %+v", candles)
+	}
+
+	// The engine routes every timeframe through the series
+	botConfig := DefaultConfig()
+	botConfig.DataProvider = "sample"
+	botConfig.Continuous = ContinuousConfig{Enabled: true, Contracts: config.Contracts, RollDays: 3}
+	if err := ValidateConfig(botConfig); err != nil {
+		t.Fatalf("Expected the continuous config to be valid: %v", err)
+	}
+	engine := newSignalEngine(botConfig)
+	if err := engine.initializeDataProvider(); err != nil {
+		t.Fatal(err)
+	}
+	if engine.dataProvider.historicalRoutes[FiveMinute] != "continuous" || engine.dataProvider.realTimeRoutes[Daily] != "continuous" {
+		t.Errorf("Expected continuous routes, got %v / %v", engine.dataProvider.historicalRoutes, engine.dataProvider.realTimeRoutes)
+	}
+	if other := engine.forSymbol("ETHUSDT"); other.config.Continuous.Enabled {
+		t.Errorf("Expected other symbols' engines to skip the traded symbol's contracts")
+	}
+
+	botConfig.Continuous.Contracts = []string{"BTCUSDT_240628", "ETHUSDT_240329", "BTCUSDT_240329"}
+	botConfig.Continuous.Adjustment = "spread"
+	err = ValidateConfig(botConfig)
+	for _, want := range []string{"continuous.contracts[1]: contract ETHUSDT_240329 is not a BTCUSDT contract", "continuous.contracts[2]: contracts must be listed oldest expiry first", "continuous.adjustment"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+}
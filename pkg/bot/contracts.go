@@ -0,0 +1,81 @@
+package bot
+
+import "math"
+
+// Contract settlement types
+const (
+	ContractLinear  = "linear"  // USDT-margined: quantity in base units, PnL in quote
+	ContractInverse = "inverse" // Coin-margined: quantity in contracts, PnL in base coin
+)
+
+// Inverse reports whether the contract is coin-margined
+func (c ContractConfig) Inverse() bool {
+	return c.Type == ContractInverse
+}
+
+// MarginCurrency returns the currency balances, margin and PnL are held in
+func (c ContractConfig) MarginCurrency(base, quote string) string {
+	if c.Inverse() {
+		return base
+	}
+	return quote
+}
+
+// Notional returns the value of quantity at price in the margin currency
+func (c ContractConfig) Notional(quantity, price float64) float64 {
+	if c.Inverse() {
+		if price <= 0 {
+			return 0
+		}
+		return quantity * c.ContractSize / price
+	}
+	return quantity * price
+}
+
+// PnL returns the profit of a LONG or SHORT position in the margin currency
+func (c ContractConfig) PnL(side string, entryPrice, exitPrice, quantity float64) float64 {
+	pnl := c.Notional(quantity, entryPrice) - c.Notional(quantity, exitPrice)
+	if !c.Inverse() {
+		pnl = -pnl
+	}
+	if side == "SHORT" {
+		pnl = -pnl
+	}
+	return pnl
+}
+
+// PnLPercent returns PnL as a % of the entry notional in the margin currency
+func (c ContractConfig) PnLPercent(side string, entryPrice, exitPrice float64) float64 {
+	entryNotional := c.Notional(1, entryPrice)
+	if entryNotional == 0 {
+		return 0
+	}
+	return c.PnL(side, entryPrice, exitPrice, 1) / entryNotional * 100
+}
+
+// AverageEntry combines two fills into one entry price: quantity-weighted for
+// linear contracts, harmonic for inverse ones so PnL is unchanged
+func (c ContractConfig) AverageEntry(quantity1, price1, quantity2, price2 float64) float64 {
+	total := quantity1 + quantity2
+	if total <= 0 {
+		return price2
+	}
+	if c.Inverse() {
+		return total / (quantity1/price1 + quantity2/price2)
+	}
+	return (quantity1*price1 + quantity2*price2) / total
+}
+
+// RiskPerUnit is the margin-currency loss per unit of quantity if the price
+// moves from entry to stop
+func (c ContractConfig) RiskPerUnit(entryPrice, stopLoss float64) float64 {
+	return math.Abs(c.Notional(1, entryPrice) - c.Notional(1, stopLoss))
+}
+
+// OrderValue returns the quote-currency value checked against exchange notional minimums
+func (c ContractConfig) OrderValue(quantity, price float64) float64 {
+	if c.Inverse() {
+		return quantity * c.ContractSize
+	}
+	return quantity * price
+}
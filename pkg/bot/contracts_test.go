@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInverseContractPnL(t *testing.T) {
+	t.Log("🪙 Testing coin-margined contracts size and settle PnL in the base coin")
+
+	config := DefaultConfig()
+	config.Symbol = "BTCUSD"
+	config.QuoteCurrency = "USD"
+	config.Contract = ContractConfig{Type: ContractInverse, ContractSize: 100}
+	config.SymbolPrecision = map[string]SymbolPrecision{"BTCUSD": {TickSize: 0.1, StepSize: 1}}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected inverse config to validate: %v", err)
+	}
+	executor := NewTradeExecutor(config, 1.0) // 1 BTC of margin
+
+	buySignal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(buySignal, 50000, 49000); err != nil {
+		t.Fatalf("Failed to open long position: %v", err)
+	}
+	position := executor.GetCurrentPosition()
+	if position == nil {
+		t.Fatalf("Expected an open long position")
+	}
+
+	// 2% of 1 BTC at risk, each $100 contract loses 100/49000 - 100/50000 BTC at the stop
+	if position.Quantity != 490 {
+		t.Errorf("Expected 490 contracts, got %.0f", position.Quantity)
+	}
+	if position.ContractType != ContractInverse || position.MarginCurrency != "BTC" {
+		t.Errorf("Expected an inverse BTC-margined position, got %s/%s", position.ContractType, position.MarginCurrency)
+	}
+
+	if err := executor.ForceClosePosition(55000); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+	trade := executor.GetTradeHistory(1)[0]
+	wantPnL := 490 * 100 * (1.0/50000 - 1.0/55000)
+	if math.Abs(trade.PnL-wantPnL) > 1e-12 || trade.QuoteCurrency != "BTC" {
+		t.Errorf("Expected PnL %.8f BTC, got %.8f %s", wantPnL, trade.PnL, trade.QuoteCurrency)
+	}
+	if want := (1 - 50000.0/55000) * 100; math.Abs(trade.PnLPercent-want) > 1e-9 {
+		t.Errorf("Expected PnL %.4f%%, got %.4f%%", want, trade.PnLPercent)
+	}
+	if balance := executor.GetBalances()["BTC"]; math.Abs(balance-(1+wantPnL)) > 1e-12 {
+		t.Errorf("Expected BTC balance %.8f, got %.8f", 1+wantPnL, balance)
+	}
+
+	// Shorts profit from a falling price; linear math is unchanged
+	inverse := config.Contract
+	if pnl := inverse.PnL("SHORT", 50000, 40000, 10); math.Abs(pnl-10*100*(1.0/40000-1.0/50000)) > 1e-12 {
+		t.Errorf("Unexpected inverse short PnL %.8f", pnl)
+	}
+	linear := ContractConfig{Type: ContractLinear}
+	if pnl := linear.PnL("SHORT", 100, 90, 2); pnl != 20 {
+		t.Errorf("Expected linear short PnL 20, got %.2f", pnl)
+	}
+
+	config.Contract.Type = "quanto"
+	if err := ValidateConfig(config); err == nil {
+		t.Error("Expected an unknown contract type to be rejected")
+	}
+}
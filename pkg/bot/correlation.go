@@ -0,0 +1,213 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minCorrelationSamples is the fewest overlapping returns a correlation is computed from
+const minCorrelationSamples = 10
+
+// priceSample is the last price seen in a sampling interval
+type priceSample struct {
+	at    time.Time // Interval start
+	price float64
+}
+
+// CorrelationTracker keeps a rolling window of sampled prices per symbol and
+// correlates their interval returns. Prices may come from several sources; the
+// latest price in each interval wins.
+type CorrelationTracker struct {
+	interval time.Duration
+	window   int // Returns kept per symbol
+	samples  map[string][]priceSample
+	mutex    sync.RWMutex
+}
+
+// NewCorrelationTracker creates a tracker sampling prices every interval
+func NewCorrelationTracker(interval time.Duration, window int) *CorrelationTracker {
+	return &CorrelationTracker{
+		interval: interval,
+		window:   window,
+		samples:  make(map[string][]priceSample),
+	}
+}
+
+// Record adds a price observation; observations older than the symbol's latest interval are ignored
+func (ct *CorrelationTracker) Record(symbol string, at time.Time, price float64) {
+	if price <= 0 {
+		return
+	}
+	bucket := at.Truncate(ct.interval)
+
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+	samples := ct.samples[symbol]
+	if n := len(samples); n > 0 {
+		switch last := samples[n-1].at; {
+		case bucket.Equal(last):
+			samples[n-1].price = price
+			return
+		case bucket.Before(last):
+			return
+		}
+	}
+	samples = append(samples, priceSample{at: bucket, price: price})
+	if len(samples) > ct.window+1 {
+		samples = samples[len(samples)-ct.window-1:]
+	}
+	ct.samples[symbol] = samples
+}
+
+// Price returns the symbol's latest sampled price
+func (ct *CorrelationTracker) Price(symbol string) (float64, bool) {
+	ct.mutex.RLock()
+	defer ct.mutex.RUnlock()
+	samples := ct.samples[symbol]
+	if len(samples) == 0 {
+		return 0, false
+	}
+	return samples[len(samples)-1].price, true
+}
+
+// returns maps each interval to the return into it from the adjacent interval (assumes lock is held)
+func (ct *CorrelationTracker) returns(symbol string) map[time.Time]float64 {
+	samples := ct.samples[symbol]
+	returns := make(map[time.Time]float64, len(samples))
+	for i := 1; i < len(samples); i++ {
+		if samples[i].at.Sub(samples[i-1].at) == ct.interval {
+			returns[samples[i].at] = samples[i].price/samples[i-1].price - 1
+		}
+	}
+	return returns
+}
+
+// alignedReturns pairs two symbols' returns over the intervals both have
+func (ct *CorrelationTracker) alignedReturns(a, b string) ([]float64, []float64) {
+	ct.mutex.RLock()
+	returnsA, returnsB := ct.returns(a), ct.returns(b)
+	ct.mutex.RUnlock()
+
+	var xs, ys []float64
+	for at, x := range returnsA {
+		if y, ok := returnsB[at]; ok {
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+	}
+	return xs, ys
+}
+
+// covariance returns the covariance of the pairs and the variance of each side
+func covariance(xs, ys []float64) (float64, float64, float64) {
+	n := float64(len(xs))
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i] / n
+		meanY += ys[i] / n
+	}
+	var covariance, varianceX, varianceY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	return covariance / n, varianceX / n, varianceY / n
+}
+
+// Correlation returns the Pearson correlation of two symbols' returns over the
+// intervals both have, or false when they overlap too little
+func (ct *CorrelationTracker) Correlation(a, b string) (float64, bool) {
+	xs, ys := ct.alignedReturns(a, b)
+	if len(xs) < minCorrelationSamples {
+		return 0, false
+	}
+	cov, varianceX, varianceY := covariance(xs, ys)
+	if varianceX == 0 || varianceY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varianceX*varianceY), true
+}
+
+// Beta returns the slope of a symbol's returns on the benchmark's over the
+// intervals both have, or false when they overlap too little
+func (ct *CorrelationTracker) Beta(symbol, benchmark string) (float64, bool) {
+	if symbol == benchmark {
+		return 1, true
+	}
+	xs, ys := ct.alignedReturns(symbol, benchmark)
+	if len(xs) < minCorrelationSamples {
+		return 0, false
+	}
+	cov, _, benchmarkVariance := covariance(xs, ys)
+	if benchmarkVariance == 0 {
+		return 0, false
+	}
+	return cov / benchmarkVariance, true
+}
+
+// RecordPrice feeds a price observation into the rolling correlations and betas
+func (te *TradeExecutor) RecordPrice(symbol string, at time.Time, price float64) {
+	te.correlations.Record(symbol, at, price)
+}
+
+// longExposureBySymbol values the spot holdings and a long signal position per
+// symbol, and returns them with account equity (assumes lock is held)
+func (te *TradeExecutor) longExposureBySymbol() (map[string]float64, float64) {
+	exposure := make(map[string]float64)
+	equity := te.balances[te.quoteCurrency]
+	for symbol, quantity := range te.holdings {
+		if price, ok := te.correlations.Price(symbol); ok {
+			exposure[symbol] += quantity * price
+			equity += quantity * price
+		}
+	}
+	if position := te.currentPosition; position != nil && position.Side == "LONG" {
+		exposure[position.Symbol] += te.config.Contract.OrderValue(position.Quantity, position.CurrentPrice)
+	}
+	return exposure, equity
+}
+
+// limitCorrelation caps a long entry so that the held symbols correlated with it,
+// the entry included, stay within max_concentration of equity. It returns the
+// allowed quantity, or an error when the entry is blocked (assumes lock is held).
+func (te *TradeExecutor) limitCorrelation(symbol string, quantity, price float64) (float64, error) {
+	limit := te.config.Correlation
+	if !limit.Enabled {
+		return quantity, nil
+	}
+
+	exposure, equity := te.longExposureBySymbol()
+	cluster := exposure[symbol]
+	var correlated []string
+	for held, value := range exposure {
+		if held == symbol {
+			continue
+		}
+		if correlation, ok := te.correlations.Correlation(symbol, held); ok && correlation >= limit.Threshold {
+			correlated = append(correlated, fmt.Sprintf("%s %.2f", held, correlation))
+			cluster += value
+		}
+	}
+	if len(correlated) == 0 {
+		return quantity, nil
+	}
+	sort.Strings(correlated)
+
+	notional := quantity * price
+	allowed := limit.MaxConcentration*equity - cluster
+	if notional <= allowed {
+		return quantity, nil
+	}
+	if limit.Action == CorrelationActionBlock || allowed <= 0 {
+		return 0, fmt.Errorf("correlation limit: %s entry would put %.1f%% of equity in symbols correlated with it (%s), max %.1f%%",
+			symbol, (cluster+notional)/equity*100, strings.Join(correlated, ", "), limit.MaxConcentration*100)
+	}
+	executorLog.Info("🔗 Downsizing correlated entry", "symbol", symbol, "quantity", quantity, "allowed", allowed/price, "correlated", strings.Join(correlated, ", "))
+	return allowed / price, nil
+}
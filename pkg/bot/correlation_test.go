@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCorrelationLimit(t *testing.T) {
+	t.Log("🔗 Testing rolling correlations and the portfolio correlation concentration limit")
+
+	config := DefaultConfig()
+	config.Correlation.Enabled = true
+	executor := NewTradeExecutor(config, 10000)
+
+	// BTC and ETH move together, SOL on its own
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prices := map[string]float64{"BTCUSDT": 100, "ETHUSDT": 100, "SOLUSDT": 100}
+	for i := 0; i < 40; i++ {
+		at := start.Add(time.Duration(i) * 5 * time.Minute)
+		move := 0.01 * math.Sin(float64(i))
+		prices["BTCUSDT"] *= 1 + move
+		prices["ETHUSDT"] *= 1 + 1.5*move + 0.001*math.Cos(float64(i)*7)
+		prices["SOLUSDT"] *= 1 + 0.01*math.Cos(float64(i)*2.3)
+		for symbol, price := range prices {
+			executor.RecordPrice(symbol, at.Add(time.Minute), price*0.99) // Overwritten later in the interval
+			executor.RecordPrice(symbol, at.Add(3*time.Minute), price)
+			executor.RecordPrice(symbol, at.Add(-time.Hour), price*2) // Stale, ignored
+		}
+	}
+	tracker := executor.correlations
+	if correlation, ok := tracker.Correlation("BTCUSDT", "ETHUSDT"); !ok || correlation < 0.9 {
+		t.Errorf("Expected BTC and ETH to be highly correlated, got %.3f (%v)", correlation, ok)
+	}
+	if correlation, ok := tracker.Correlation("BTCUSDT", "SOLUSDT"); !ok || math.Abs(correlation) > 0.5 {
+		t.Errorf("Expected BTC and SOL to be uncorrelated, got %.3f (%v)", correlation, ok)
+	}
+	if _, ok := tracker.Correlation("BTCUSDT", "DOGEUSDT"); ok {
+		t.Error("Expected no correlation without overlapping returns")
+	}
+	if price, _ := tracker.Price("BTCUSDT"); price != prices["BTCUSDT"] {
+		t.Errorf("Expected the latest price in the interval, got %.4f", price)
+	}
+
+	buy := func(symbol string, notional float64) error {
+		return executor.ExecuteIntent(OrderIntent{Strategy: "TEST", Symbol: symbol, Side: "BUY", Quantity: notional / prices[symbol], Price: prices[symbol]})
+	}
+	value := func(symbol string) float64 {
+		return executor.GetHoldings()[symbol] * prices[symbol]
+	}
+
+	// Nothing correlated is held yet, so the first entry is not limited
+	if err := buy("ETHUSDT", 4000); err != nil || math.Abs(value("ETHUSDT")-4000) > 1 {
+		t.Fatalf("Expected the ETH entry to fill, got %.2f (%v)", value("ETHUSDT"), err)
+	}
+	// BTC joins ETH's cluster: 50% of 10000 equity leaves room for 1000
+	if err := buy("BTCUSDT", 4000); err != nil || math.Abs(value("BTCUSDT")-1000) > 1 {
+		t.Errorf("Expected the BTC entry to be downsized to 1000, got %.2f (%v)", value("BTCUSDT"), err)
+	}
+	// Uncorrelated entries are untouched
+	if err := buy("SOLUSDT", 3000); err != nil || math.Abs(value("SOLUSDT")-3000) > 1 {
+		t.Errorf("Expected the SOL entry to fill, got %.2f (%v)", value("SOLUSDT"), err)
+	}
+	// The cluster is full, leaving nothing above the minimum lot
+	if err := buy("BTCUSDT", 500); err == nil || math.Abs(value("BTCUSDT")-1000) > 1 {
+		t.Errorf("Expected a full cluster to block the entry, got %.2f (%v)", value("BTCUSDT"), err)
+	}
+
+	executor.config.Correlation.Action = CorrelationActionBlock
+	executor.config.Correlation.MaxConcentration = 0.6
+	if err := buy("ETHUSDT", 2000); err == nil || !strings.Contains(err.Error(), "correlation limit") {
+		t.Errorf("Expected block mode to reject an oversized entry, got %v", err)
+	}
+	if err := buy("ETHUSDT", 900); err != nil {
+		t.Errorf("Expected an entry within the limit to fill, got %v", err)
+	}
+
+	config.Correlation = CorrelationConfig{Enabled: true, Window: 5, Threshold: 1.5, Action: "hedge"}
+	err := ValidateConfig(config)
+	for _, field := range []string{"interval_minutes", "window", "threshold", "max_concentration", "action"} {
+		if err == nil || !strings.Contains(err.Error(), "correlation."+field) {
+			t.Errorf("Expected correlation.%s to be rejected, got %v", field, err)
+		}
+	}
+}
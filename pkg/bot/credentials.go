@@ -0,0 +1,114 @@
+package bot
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// CredentialSetter is implemented by providers whose API keys can be rotated in place
+type CredentialSetter interface {
+	SetCredentials(apiKey, secretKey string)
+}
+
+// CredentialReload reports the outcome of an API key rotation
+type CredentialReload struct {
+	Source    string `json:"source" example:"environment"` // "request" or "environment"
+	Providers int    `json:"providers" example:"2"`        // Provider clients that picked up the new keys
+	APIKey    string `json:"api_key" example:"abcd…wxyz"`  // Masked API key now in use
+}
+
+// BinanceCredentialsFromEnv reads BINANCE_API_KEY/BINANCE_SECRET_KEY, preferring the
+// *_FILE variants (e.g. mounted secrets) when set
+func BinanceCredentialsFromEnv() (apiKey, secretKey string, err error) {
+	if apiKey, err = envOrFile("BINANCE_API_KEY"); err != nil {
+		return "", "", err
+	}
+	if secretKey, err = envOrFile("BINANCE_SECRET_KEY"); err != nil {
+		return "", "", err
+	}
+	return apiKey, secretKey, nil
+}
+
+// ExchangeCredentialsFromEnv reads <VENUE>_API_KEY, <VENUE>_SECRET_KEY and
+// <VENUE>_PASSPHRASE (or their *_FILE variants) for a non-Binance venue
+func ExchangeCredentialsFromEnv(venue string) (ExchangeCredentials, error) {
+	var credentials ExchangeCredentials
+	prefix := strings.ToUpper(venue) + "_"
+	var err error
+	if credentials.APIKey, err = envOrFile(prefix + "API_KEY"); err != nil {
+		return credentials, err
+	}
+	if credentials.SecretKey, err = envOrFile(prefix + "SECRET_KEY"); err != nil {
+		return credentials, err
+	}
+	if credentials.Passphrase, err = envOrFile(prefix + "PASSPHRASE"); err != nil {
+		return credentials, err
+	}
+	return credentials, nil
+}
+
+// envOrFile returns the contents of the file named by <name>_FILE, or the <name> variable
+func envOrFile(name string) (string, error) {
+	if path := os.Getenv(name + "_FILE"); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s_FILE: %w", name, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return os.Getenv(name), nil
+}
+
+// MaskSecret keeps the first and last four characters of a key for display
+func MaskSecret(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + "…" + secret[len(secret)-4:]
+}
+
+// UpdateCredentials rotates the keys of every registered provider that supports it
+func (dpm *DataProviderManager) UpdateCredentials(apiKey, secretKey string) int {
+	updated := 0
+	for _, provider := range dpm.providers {
+		if setter, ok := provider.(CredentialSetter); ok {
+			setter.SetCredentials(apiKey, secretKey)
+			updated++
+		}
+	}
+	return updated
+}
+
+// ReloadCredentials rotates the Binance API keys without restarting the engine. Empty
+// keys re-read BINANCE_API_KEY/BINANCE_SECRET_KEY (or their *_FILE variants).
+func (tb *TradingBot) ReloadCredentials(apiKey, secretKey string) (*CredentialReload, error) {
+	if tb.signalEngine == nil {
+		return nil, fmt.Errorf("signal engine not initialized")
+	}
+
+	source := "request"
+	if apiKey == "" && secretKey == "" {
+		var err error
+		if apiKey, secretKey, err = BinanceCredentialsFromEnv(); err != nil {
+			return nil, err
+		}
+		source = "environment"
+	}
+	if apiKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("both an API key and a secret key are required")
+	}
+
+	updated := 0
+	for _, se := range tb.symbolEngines() {
+		se.mutex.Lock()
+		se.config.Binance.APIKey = apiKey
+		se.config.Binance.SecretKey = secretKey
+		updated += se.dataProvider.UpdateCredentials(apiKey, secretKey)
+		se.mutex.Unlock()
+	}
+
+	providerLog.Info("🔑 Binance API keys reloaded", "source", source, "clients", updated)
+	return &CredentialReload{Source: source, Providers: updated, APIKey: MaskSecret(apiKey)}, nil
+}
@@ -0,0 +1,67 @@
+package bot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReloadCredentials(t *testing.T) {
+	t.Log("🔑 Testing live Binance API key rotation")
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	tb := NewTradingBot(config)
+
+	provider := NewBinanceFuturesDataProvider("old-key", "old-secret")
+	polling := NewBinanceRESTPollingProvider("old-key", "old-secret", 0)
+	tb.signalEngine.dataProvider.AddProvider("binance", provider)
+	tb.signalEngine.dataProvider.AddProvider("binance_rest", polling)
+	tb.signalEngine.dataProvider.AddProvider("sample", NewSampleDataProvider([]string{"BTCUSDT"}, 50000))
+
+	// Keys passed explicitly reach every Binance client
+	reload, err := tb.ReloadCredentials("new-api-key-1234", "new-secret")
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if reload.Source != "request" || reload.Providers != 2 || reload.APIKey != "new-…1234" {
+		t.Errorf("Unexpected reload result: %+v", reload)
+	}
+	for _, p := range []*BinanceFuturesDataProvider{provider, polling.BinanceFuturesDataProvider} {
+		if apiKey, secretKey := p.Credentials(); apiKey != "new-api-key-1234" || secretKey != "new-secret" {
+			t.Errorf("Provider kept stale keys: %s/%s", apiKey, secretKey)
+		}
+	}
+	if tb.signalEngine.config.Binance.APIKey != "new-api-key-1234" {
+		t.Errorf("Expected providers created later to use the new key")
+	}
+
+	// Empty keys re-read the environment, preferring *_FILE secrets
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("file-secret\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	t.Setenv("BINANCE_API_KEY", "env-key")
+	t.Setenv("BINANCE_SECRET_KEY", "env-secret")
+	t.Setenv("BINANCE_SECRET_KEY_FILE", secretFile)
+
+	reload, err = tb.ReloadCredentials("", "")
+	if err != nil {
+		t.Fatalf("Reload from environment failed: %v", err)
+	}
+	if reload.Source != "environment" || reload.APIKey != "*******" {
+		t.Errorf("Unexpected reload result: %+v", reload)
+	}
+	if apiKey, secretKey := provider.Credentials(); apiKey != "env-key" || secretKey != "file-secret" {
+		t.Errorf("Expected env key and file secret, got %s/%s", apiKey, secretKey)
+	}
+
+	// A missing secret file is an error and leaves the current keys alone
+	t.Setenv("BINANCE_SECRET_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+	if _, err := tb.ReloadCredentials("", ""); err == nil {
+		t.Error("Expected an error for a missing secret file")
+	}
+	if _, secretKey := provider.Credentials(); secretKey != "file-secret" {
+		t.Errorf("Failed reload changed the secret to %s", secretKey)
+	}
+}
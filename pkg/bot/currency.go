@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateRefreshInterval is how often the cached reporting currency rate is fetched
+const rateRefreshInterval = 15 * time.Minute
+
+// knownQuoteCurrencies lists quote assets in match order (longest/most specific first)
+var knownQuoteCurrencies = []string{"FDUSD", "USDT", "USDC", "BUSD", "TUSD", "USD", "EUR", "GBP", "TRY", "BTC", "ETH", "BNB"}
+
+// usdStablecoins are treated as 1:1 with each other for conversion
+var usdStablecoins = map[string]bool{"USD": true, "USDT": true, "USDC": true, "BUSD": true, "FDUSD": true, "TUSD": true}
+
+// SplitSymbol splits a trading pair into base and quote currencies (BTCUSDT -> BTC, USDT)
+func SplitSymbol(symbol string) (base, quote string, err error) {
+	symbol = strings.ToUpper(symbol)
+	for _, q := range knownQuoteCurrencies {
+		if strings.HasSuffix(symbol, q) && len(symbol) > len(q) {
+			return strings.TrimSuffix(symbol, q), q, nil
+		}
+	}
+	return "", "", fmt.Errorf("unable to determine quote currency for %s", symbol)
+}
+
+// RateProvider returns how many units of `to` one unit of `from` is worth
+type RateProvider func(from, to string) (float64, error)
+
+// CurrencyConverter converts amounts between currencies using stablecoin parity
+// and, for anything else, a pluggable rate provider (e.g. exchange tickers)
+type CurrencyConverter struct {
+	rates  RateProvider
+	cached map[string]float64 // Last rate fetched by Refresh, keyed by "FROM/TO"
+	mutex  sync.RWMutex
+}
+
+// NewCurrencyConverter creates a converter; rates may be nil for stablecoin-only conversion
+func NewCurrencyConverter(rates RateProvider) *CurrencyConverter {
+	return &CurrencyConverter{rates: rates, cached: make(map[string]float64)}
+}
+
+// Convert converts amount from one currency to another
+func (cc *CurrencyConverter) Convert(amount float64, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to || (usdStablecoins[from] && usdStablecoins[to]) {
+		return amount, nil
+	}
+	if cc.rates == nil {
+		return 0, fmt.Errorf("no rate provider configured for %s -> %s", from, to)
+	}
+
+	rate, err := cc.rates(from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get %s/%s rate: %w", from, to, err)
+	}
+	return amount * rate, nil
+}
+
+// Refresh fetches the from -> to rate and caches it for ConvertCached. A
+// failed fetch keeps the previously cached rate.
+func (cc *CurrencyConverter) Refresh(from, to string) error {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to || (usdStablecoins[from] && usdStablecoins[to]) {
+		return nil
+	}
+	if cc.rates == nil {
+		return fmt.Errorf("no rate provider configured for %s -> %s", from, to)
+	}
+
+	rate, err := cc.rates(from, to)
+	if err != nil {
+		return fmt.Errorf("failed to get %s/%s rate: %w", from, to, err)
+	}
+
+	cc.mutex.Lock()
+	defer cc.mutex.Unlock()
+	cc.cached[from+"/"+to] = rate
+	return nil
+}
+
+// ConvertCached converts amount with the rate cached by Refresh. It never calls
+// the rate provider, so it is safe to use while holding other locks.
+func (cc *CurrencyConverter) ConvertCached(amount float64, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to || (usdStablecoins[from] && usdStablecoins[to]) {
+		return amount, nil
+	}
+
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+	rate, ok := cc.cached[from+"/"+to]
+	if !ok {
+		return 0, fmt.Errorf("no %s/%s rate fetched yet", from, to)
+	}
+	return amount * rate, nil
+}
+
+// BinanceRateProvider builds a RateProvider from exchange ticker prices, trying the
+// direct pair (FROMTO) then the inverse pair (TOFROM)
+func BinanceRateProvider(provider *BinanceFuturesDataProvider) RateProvider {
+	return func(from, to string) (float64, error) {
+		if price, err := provider.GetCurrentPrice(from + to); err == nil && price > 0 {
+			return price, nil
+		}
+		price, err := provider.GetCurrentPrice(to + from)
+		if err != nil {
+			return 0, err
+		}
+		if price <= 0 {
+			return 0, fmt.Errorf("invalid price for %s%s", to, from)
+		}
+		return 1 / price, nil
+	}
+}
+
+// FormatCurrencyAmount formats an amount with precision suited to the currency
+func FormatCurrencyAmount(amount float64, currency string) string {
+	switch strings.ToUpper(currency) {
+	case "BTC", "ETH", "BNB":
+		return fmt.Sprintf("%.8f %s", amount, currency)
+	default:
+		return fmt.Sprintf("%.2f %s", amount, currency)
+	}
+}
+
+// startRateRefresh keeps the executor's cached reporting currency rate current
+func (tb *TradingBot) startRateRefresh(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(rateRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				tb.tradeExecutor.refreshRates()
+			}
+		}
+	}()
+}
@@ -0,0 +1,138 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSplitSymbol(t *testing.T) {
+	tests := []struct {
+		symbol, base, quote string
+	}{
+		{"BTCUSDT", "BTC", "USDT"},
+		{"ETHBTC", "ETH", "BTC"},
+		{"BTCEUR", "BTC", "EUR"},
+		{"BTCFDUSD", "BTC", "FDUSD"},
+		{"btcusd", "BTC", "USD"},
+	}
+
+	for _, tt := range tests {
+		base, quote, err := SplitSymbol(tt.symbol)
+		if err != nil || base != tt.base || quote != tt.quote {
+			t.Errorf("SplitSymbol(%s) = %s, %s, %v; want %s, %s", tt.symbol, base, quote, err, tt.base, tt.quote)
+		}
+	}
+
+	if _, _, err := SplitSymbol("XYZ"); err == nil {
+		t.Errorf("Expected error for symbol without known quote")
+	}
+}
+
+func TestBTCQuotedPairAccounting(t *testing.T) {
+	t.Log("💱 Testing BTC-quoted pair PnL accounting")
+
+	config := DefaultConfig()
+	config.Symbol = "ETHBTC"
+	config.ReportingCurrency = "EUR"
+	executor := NewTradeExecutor(config, 1.0) // 1 BTC balance
+	executor.SetSymbolFilters(&SymbolFilters{Symbol: "ETHBTC", TickSize: 0.00001, StepSize: 0.001, MinQty: 0.001})
+	fetches := 0
+	executor.SetRateProvider(func(from, to string) (float64, error) {
+		fetches++
+		if from == "BTC" && to == "EUR" {
+			return 40000, nil
+		}
+		return 0, fmt.Errorf("no rate for %s%s", from, to)
+	})
+
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(signal, 0.05, 0.049); err != nil {
+		t.Fatalf("Failed to open position: %v", err)
+	}
+
+	position := executor.GetCurrentPosition()
+	if position.BaseCurrency != "ETH" || position.QuoteCurrency != "BTC" {
+		t.Errorf("Expected ETH/BTC position, got %s/%s", position.BaseCurrency, position.QuoteCurrency)
+	}
+
+	if err := executor.ForceClosePosition(0.051); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+
+	trade := executor.GetTradeHistory(1)[0]
+	expectedPnL := (0.051 - 0.05) * position.Quantity
+	if math.Abs(trade.PnL-expectedPnL) > 1e-12 {
+		t.Errorf("Expected PnL %.8f BTC, got %.8f", expectedPnL, trade.PnL)
+	}
+	if math.Abs(trade.PnLReporting-expectedPnL*40000) > 1e-6 || trade.ReportingCurrency != "EUR" {
+		t.Errorf("Expected reporting PnL %.2f EUR, got %.2f %s", expectedPnL*40000, trade.PnLReporting, trade.ReportingCurrency)
+	}
+
+	balances := executor.GetBalances()
+	if math.Abs(balances["BTC"]-(1.0+expectedPnL)) > 1e-12 {
+		t.Errorf("Expected BTC balance %.8f, got %.8f", 1.0+expectedPnL, balances["BTC"])
+	}
+
+	// The rate is cached when the provider is set and refreshed on a timer, not per signal
+	if fetches != 1 {
+		t.Errorf("Expected a single rate fetch, got %d", fetches)
+	}
+}
+
+func TestPnLConversionOutsideExecutorLock(t *testing.T) {
+	t.Log("💱 Testing PnL conversion uses rates fetched outside the executor lock")
+
+	config := DefaultConfig()
+	config.Symbol = "ETHBTC"
+	config.ReportingCurrency = "EUR"
+	executor := NewTradeExecutor(config, 1.0)
+	executor.SetSymbolFilters(&SymbolFilters{Symbol: "ETHBTC", TickSize: 0.00001, StepSize: 0.001, MinQty: 0.001})
+
+	available := true
+	executor.SetRateProvider(func(from, to string) (float64, error) {
+		if !executor.mutex.TryRLock() {
+			t.Errorf("Rate for %s -> %s fetched while the executor was locked", from, to)
+		} else {
+			executor.mutex.RUnlock()
+		}
+		if !available {
+			return 0, fmt.Errorf("exchange unreachable")
+		}
+		return 40000, nil
+	})
+
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(signal, 0.05, 0.049); err != nil {
+		t.Fatalf("Failed to open position: %v", err)
+	}
+
+	// A failed refresh keeps the last fetched rate for the close
+	available = false
+	executor.refreshRates()
+	if err := executor.ForceClosePosition(0.051); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+	if executor.GetCurrentPosition() != nil {
+		t.Fatalf("Expected position to be closed")
+	}
+	trade := executor.GetTradeHistory(1)[0]
+	if trade.ConversionError != "" || math.Abs(trade.PnLReporting-trade.PnL*40000) > 1e-6 {
+		t.Errorf("Expected PnL converted at cached rate, got %.2f EUR (%s)", trade.PnLReporting, trade.ConversionError)
+	}
+
+	// Without any fetched rate the trade still closes and records the error
+	executor.SetRateProvider(nil)
+	if err := executor.ExecuteSignal(signal, 0.05, 0.049); err != nil {
+		t.Fatalf("Failed to reopen position: %v", err)
+	}
+	if err := executor.ForceClosePosition(0.051); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+	if executor.GetCurrentPosition() != nil {
+		t.Fatalf("Expected position to be closed without a rate")
+	}
+	if trade := executor.GetTradeHistory(1)[0]; trade.ConversionError == "" {
+		t.Errorf("Expected conversion error without a rate")
+	}
+}
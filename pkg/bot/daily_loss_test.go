@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDailyLossSession(t *testing.T) {
+	t.Log("📆 Testing the daily loss limit with unrealized losses and session resets")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000)
+	executor.riskManager.MaxDailyLoss = 0.01
+	now := time.Date(2024, 3, 1, 22, 0, 0, 0, time.UTC)
+	executor.SetClock(ClockFunc(func() time.Time { return now }))
+
+	// 400 units long at 100; marking at 99.7 is a $120 unrealized loss, over the 1% limit
+	buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	hold := &TradingSignal{Symbol: config.Symbol, Signal: Hold, Confidence: 0.9}
+	if err := executor.ExecuteSignal(buy, 100, 99.5); err != nil {
+		t.Fatalf("Failed to open long position: %v", err)
+	}
+	if err := executor.ExecuteSignal(hold, 99.7, 99.5); err != nil {
+		t.Fatalf("Failed to mark position: %v", err)
+	}
+	if loss := executor.GetStatus().RiskManagement.DailyUnrealizedLoss; loss < 0.0119 || loss > 0.0121 {
+		t.Errorf("Expected a 1.2%% unrealized daily loss, got %.4f", loss)
+	}
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: "TEST", Symbol: "ETHUSDT", Side: "BUY", Quantity: 1, Price: 100}); err == nil || !strings.Contains(err.Error(), "daily loss limit") {
+		t.Errorf("Expected the unrealized loss to block new entries, got %v", err)
+	}
+
+	// Holds still manage the position: the stop fires and the loss becomes realized
+	if err := executor.ExecuteSignal(hold, 99.4, 99.5); err != nil || executor.GetCurrentPosition() != nil {
+		t.Fatalf("Expected the stop to close the position (err %v)", err)
+	}
+	risk := executor.GetStatus().RiskManagement
+	if risk.DailyUnrealizedLoss != 0 || risk.DailyLossUsed < 0.0239 || risk.DailyLossUsed > 0.0241 {
+		t.Errorf("Expected a 2.4%% realized loss and no unrealized loss, got %+v", risk)
+	}
+	if err := executor.ExecuteSignal(buy, 100, 99.5); err != nil || executor.GetCurrentPosition() != nil {
+		t.Errorf("Expected the realized loss to block the entry (err %v)", err)
+	}
+
+	// The limit resets at the session boundary, not 24h after start
+	now = time.Date(2024, 3, 2, 0, 1, 0, 0, time.UTC)
+	if err := executor.ExecuteSignal(buy, 100, 99.5); err != nil || executor.GetCurrentPosition() == nil {
+		t.Errorf("Expected entries to resume in the new session (err %v)", err)
+	}
+	if risk := executor.GetStatus().RiskManagement; !risk.LastResetTime.Equal(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)) || risk.DailyLossUsed != 0 {
+		t.Errorf("Expected the session to start at midnight UTC, got %+v", risk)
+	}
+
+	// Sessions can start at any UTC time
+	if start := (SessionConfig{StartTime: "08:30"}).SessionStart(now); !start.Equal(time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected a 08:30 session to have started the same day, got %v", start)
+	}
+
+	// A 17:00 New York session follows DST: 22:00 UTC before March 10, 21:00 after,
+	// and is named for the day it mostly falls on
+	newYork := SessionConfig{Timezone: "America/New_York", StartTime: "17:00"}
+	if start := newYork.SessionStart(now); !start.Equal(time.Date(2024, 3, 1, 22, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the session to start at 22:00 UTC in winter, got %v", start.UTC())
+	}
+	if day := newYork.TradingDay(now); day.Format("2006-01-02") != "2024-03-02" {
+		t.Errorf("Expected a Friday 17:00 session to count toward Saturday, got %v", day)
+	}
+	if start := newYork.SessionStart(time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)); !start.Equal(time.Date(2024, 3, 10, 21, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the session to start at 21:00 UTC after the DST change, got %v", start.UTC())
+	}
+
+	config.Session = SessionConfig{Timezone: "Mars/Olympus", StartTime: "25:00"}
+	err := ValidateConfig(config)
+	if err == nil || !strings.Contains(err.Error(), "session.timezone") || !strings.Contains(err.Error(), "session.start_time") {
+		t.Errorf("Expected an unknown zone and start time to be rejected, got %v", err)
+	}
+}
+
+func TestLossLimitAllowsClosingSignals(t *testing.T) {
+	t.Log("🚪 Testing that loss limits let signals close the open side without reversing")
+
+	config := DefaultConfig()
+	config.ATR.UseShorts = true
+	executor := NewTradeExecutor(config, 10000)
+	executor.riskManager.MaxDailyLoss = 0.01
+
+	// A short marked 0.3 against it is over the 1% daily limit
+	sell := &TradingSignal{Symbol: config.Symbol, Signal: Sell, Confidence: 0.9}
+	hold := &TradingSignal{Symbol: config.Symbol, Signal: Hold, Confidence: 0.9}
+	buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(sell, 100, 100.5); err != nil || executor.GetCurrentPosition() == nil {
+		t.Fatalf("Failed to open short position (err %v)", err)
+	}
+	if err := executor.ExecuteSignal(hold, 100.3, 100.5); err != nil {
+		t.Fatalf("Failed to mark position: %v", err)
+	}
+	if loss := executor.GetStatus().RiskManagement.DailyUnrealizedLoss; loss < 0.01 {
+		t.Fatalf("Expected the unrealized loss over the limit, got %.4f", loss)
+	}
+
+	// Buy while short closes the short, but the limit blocks the long it would reverse into
+	if err := executor.ExecuteSignal(buy, 100.3, 100.5); err != nil {
+		t.Fatalf("Closing signal failed: %v", err)
+	}
+	trades := executor.GetTradeHistory(0)
+	if position := executor.GetCurrentPosition(); position != nil {
+		t.Errorf("Expected the short closed without reversing, got a %s position", position.Side)
+	}
+	if len(trades) != 1 || trades[0].Side != "SHORT" || trades[0].ExitReason != "SIGNAL_CHANGE" {
+		t.Errorf("Expected the short closed on the signal change, got %+v", trades)
+	}
+}
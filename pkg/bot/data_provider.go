@@ -3,7 +3,6 @@ package bot
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"sync"
 	"time"
 )
@@ -51,32 +50,65 @@ var DefaultRealTimeConfigs = map[Timeframe]RealTimeConfig{
 	},
 }
 
-// CandleBuilder aggregates ticks into candles
+// CandleBuilder aggregates ticks into candles whose open times line up with the
+// exchange's: buckets are counted from an anchor open time (the Unix epoch by
+// default, which is how Binance aligns its klines), not from when the builder
+// started, and each tick is bucketed by its own timestamp
 type CandleBuilder struct {
 	timeframe     Timeframe
 	currentCandle *Candle
-	startTime     time.Time
+	completed     []Candle  // Candles closed by a later tick, not yet collected
+	anchor        time.Time // Any exchange-reported open time of this timeframe
+	clock         Clock
 	mutex         sync.RWMutex
 }
 
-// NewCandleBuilder creates a new candle builder
-func NewCandleBuilder(timeframe Timeframe) *CandleBuilder {
-	now := time.Now()
+// NewCandleBuilder creates a new candle builder that stamps ticks and closes candles by clock
+func NewCandleBuilder(timeframe Timeframe, clock Clock) *CandleBuilder {
 	return &CandleBuilder{
 		timeframe: timeframe,
-		startTime: now.Truncate(timeframe.Duration()),
+		anchor:    klineEpoch,
+		clock:     clock,
 	}
 }
 
-// AddTick adds a price tick to the current candle
+// Align anchors the buckets to an open time reported by the exchange, e.g. from
+// a kline payload. The forming candle is moved to its aligned bucket.
+func (cb *CandleBuilder) Align(openTime time.Time) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.anchor = openTime
+	if cb.currentCandle != nil {
+		cb.currentCandle.Timestamp = cb.openTime(cb.currentCandle.Timestamp)
+	}
+}
+
+// AddTick adds a price tick at the clock's current time
 func (cb *CandleBuilder) AddTick(price, volume float64) {
+	cb.AddTickAt(cb.clock.Now(), price, volume)
+}
+
+// AddTickAt adds a price tick made at timestamp. A tick past the forming
+// candle's bucket closes that candle; ticks for an already closed bucket are dropped.
+func (cb *CandleBuilder) AddTickAt(timestamp time.Time, price, volume float64) {
 	cb.mutex.Lock()
 	defer cb.mutex.Unlock()
 
+	openTime := cb.openTime(timestamp)
+	if cb.currentCandle != nil {
+		if openTime.Before(cb.currentCandle.Timestamp) {
+			return
+		}
+		if openTime.After(cb.currentCandle.Timestamp) {
+			cb.completed = append(cb.completed, *cb.currentCandle)
+			cb.currentCandle = nil
+		}
+	}
+
 	if cb.currentCandle == nil {
 		// Start new candle
 		cb.currentCandle = &Candle{
-			Timestamp: cb.startTime,
+			Timestamp: openTime,
 			Open:      price,
 			High:      price,
 			Low:       price,
@@ -92,21 +124,62 @@ func (cb *CandleBuilder) AddTick(price, volume float64) {
 	}
 }
 
-// GetCompletedCandle returns completed candle if timeframe elapsed
+// AddKline applies an exchange kline of this timeframe, forming or closed. Its
+// open time aligns the builder and its values replace the locally built candle.
+func (cb *CandleBuilder) AddKline(kline Candle) {
+	cb.Align(kline.Timestamp)
+
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if cb.currentCandle != nil {
+		if kline.Timestamp.Before(cb.currentCandle.Timestamp) {
+			return
+		}
+		if kline.Timestamp.After(cb.currentCandle.Timestamp) {
+			cb.completed = append(cb.completed, *cb.currentCandle)
+		}
+	}
+	cb.currentCandle = &kline
+}
+
+// GetCompletedCandle returns the oldest candle closed by a later tick, or the
+// forming candle once the clock has passed its close
 func (cb *CandleBuilder) GetCompletedCandle() *Candle {
 	cb.mutex.Lock()
 	defer cb.mutex.Unlock()
 
-	now := time.Now()
-	if now.Sub(cb.startTime) >= cb.timeframe.Duration() && cb.currentCandle != nil {
+	if len(cb.completed) > 0 {
+		completed := cb.completed[0]
+		cb.completed = cb.completed[1:]
+		return &completed
+	}
+	if cb.currentCandle != nil && !cb.clock.Now().Before(cb.currentCandle.Timestamp.Add(cb.timeframe.Duration())) {
 		completed := *cb.currentCandle
 		cb.currentCandle = nil
-		cb.startTime = now.Truncate(cb.timeframe.Duration())
 		return &completed
 	}
 	return nil
 }
 
+// openTime returns the open time of the bucket holding t (assumes lock is held)
+func (cb *CandleBuilder) openTime(t time.Time) time.Time {
+	return candleOpenTime(t, cb.timeframe, cb.anchor)
+}
+
+// klineEpoch is the origin exchange klines are aligned to
+var klineEpoch = time.Unix(0, 0).UTC()
+
+// candleOpenTime returns the open time of the timeframe candle holding t, with
+// candles opening at anchor and every timeframe duration before and after it
+func candleOpenTime(t time.Time, timeframe Timeframe, anchor time.Time) time.Time {
+	duration := timeframe.Duration()
+	offset := t.Sub(anchor) % duration
+	if offset < 0 {
+		offset += duration
+	}
+	return t.Add(-offset)
+}
+
 // SampleDataProvider generates sample market data for testing
 type SampleDataProvider struct {
 	symbols        []string
@@ -118,12 +191,19 @@ type SampleDataProvider struct {
 	stopChan       chan struct{}
 	config         RealTimeConfig
 	candleBuilders map[Timeframe]*CandleBuilder
+	rng            *LockedRand
+	clock          func() time.Time // Ends the generated history
 	mutex          sync.RWMutex
+
+	feedClock Clock // Drives the real-time ticks and candle builders
 }
 
 // NewSampleDataProvider creates a new sample data provider
 func NewSampleDataProvider(symbols []string, basePrice float64) *SampleDataProvider {
 	return &SampleDataProvider{
+		rng:            DeterminismConfig{}.Rand(),
+		clock:          time.Now,
+		feedClock:      SystemClock,
 		symbols:        symbols,
 		basePrice:      basePrice,
 		currentPrice:   basePrice,
@@ -144,6 +224,21 @@ func NewDemoDataProvider(symbols []string, basePrice float64) *SampleDataProvide
 	return provider
 }
 
+// SetDeterminism seeds the generator and freezes its clock when determinism is enabled
+func (sdp *SampleDataProvider) SetDeterminism(config DeterminismConfig) {
+	sdp.mutex.Lock()
+	defer sdp.mutex.Unlock()
+	sdp.rng = config.Rand()
+	sdp.clock = config.WallClock()
+}
+
+// SetClock replaces the time source of the real-time feeds started afterwards
+func (sdp *SampleDataProvider) SetClock(clock Clock) {
+	sdp.mutex.Lock()
+	defer sdp.mutex.Unlock()
+	sdp.feedClock = clock
+}
+
 // SetRealTimeConfig configures real-time data behavior
 func (sdp *SampleDataProvider) SetRealTimeConfig(timeframe Timeframe, config RealTimeConfig) {
 	sdp.mutex.Lock()
@@ -154,10 +249,10 @@ func (sdp *SampleDataProvider) SetRealTimeConfig(timeframe Timeframe, config Rea
 // generatePriceTick generates a single price tick
 func (sdp *SampleDataProvider) generatePriceTick() float64 {
 	// Generate realistic price movement
-	changePercent := (rand.Float64() - 0.5) * sdp.volatility * 0.1 // Smaller movements for ticks
+	changePercent := (sdp.rng.Float64() - 0.5) * sdp.volatility * 0.1 // Smaller movements for ticks
 
 	// Add some trend
-	trend := sdp.trendStrength * 0.1 * (rand.Float64() - 0.3) // Smaller trend for ticks
+	trend := sdp.trendStrength * 0.1 * (sdp.rng.Float64() - 0.3) // Smaller trend for ticks
 	changePercent += trend
 
 	// Calculate new price
@@ -168,7 +263,7 @@ func (sdp *SampleDataProvider) generatePriceTick() float64 {
 // generateVolume generates a volume amount for a tick
 func (sdp *SampleDataProvider) generateVolume() float64 {
 	baseVolume := 100.0 // Smaller base volume for ticks
-	volumeMultiplier := 0.5 + rand.Float64()
+	volumeMultiplier := 0.5 + sdp.rng.Float64()
 	return baseVolume * volumeMultiplier
 }
 
@@ -176,8 +271,8 @@ func (sdp *SampleDataProvider) generateVolume() float64 {
 func (sdp *SampleDataProvider) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
 	candles := make([]Candle, count)
 
-	// Start from some time in the past
-	startTime := time.Now().Add(-time.Duration(count) * timeframe.Duration())
+	// End with the last closed candle, on the same boundaries as the exchange and the live candle builders
+	startTime := candleOpenTime(sdp.clock(), timeframe, klineEpoch).Add(-time.Duration(count) * timeframe.Duration())
 	price := sdp.basePrice
 
 	for i := 0; i < count; i++ {
@@ -194,6 +289,23 @@ func (sdp *SampleDataProvider) GetHistoricalData(symbol string, timeframe Timefr
 	return candles, nil
 }
 
+// GetHistoricalRange generates candles aligned to the timeframe and opening in [start, end)
+func (sdp *SampleDataProvider) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	first := start.Truncate(timeframe.Duration())
+	if first.Before(start) {
+		first = first.Add(timeframe.Duration())
+	}
+
+	candles := make([]Candle, 0)
+	price := sdp.basePrice
+	for timestamp := first; timestamp.Before(end); timestamp = timestamp.Add(timeframe.Duration()) {
+		candle := sdp.generateCandle(timestamp, price, timeframe)
+		candles = append(candles, candle)
+		price = candle.Close
+	}
+	return candles, nil
+}
+
 // GetRealTimeData provides real-time market data simulation with proper candle aggregation
 func (sdp *SampleDataProvider) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
 	candleChan := make(chan Candle, 10)
@@ -210,31 +322,31 @@ func (sdp *SampleDataProvider) GetRealTimeData(symbol string, timeframe Timefram
 
 	// Create candle builder for this timeframe
 	sdp.mutex.Lock()
-	candleBuilder := NewCandleBuilder(timeframe)
+	clock := sdp.feedClock
+	candleBuilder := NewCandleBuilder(timeframe, clock)
 	sdp.candleBuilders[timeframe] = candleBuilder
+	sdp.running = true
 	sdp.mutex.Unlock()
 
+	// Price tick timer
+	tickTicker := clock.NewTicker(config.TickInterval)
+
+	// Candle completion check timer
+	candleTicker := clock.NewTicker(time.Second * 10) // Check every 10 seconds
+
 	go func() {
 		defer close(candleChan)
-
-		// Price tick timer
-		tickTicker := time.NewTicker(config.TickInterval)
 		defer tickTicker.Stop()
-
-		// Candle completion check timer
-		candleTicker := time.NewTicker(time.Second * 10) // Check every 10 seconds
 		defer candleTicker.Stop()
 
-		sdp.running = true
-
 		if config.EnableDebugLogs {
-			fmt.Printf("Starting %s real-time data: ticks every %v, candles every %v\n",
-				timeframe.String(), config.TickInterval, config.CandleInterval)
+			providerLog.Debug("Starting real-time sample data", "timeframe", timeframe.String(),
+				"tick_interval", config.TickInterval.String(), "candle_interval", config.CandleInterval.String())
 		}
 
 		for {
 			select {
-			case <-tickTicker.C:
+			case <-tickTicker.Chan():
 				// Generate price tick
 				newPrice := sdp.generatePriceTick()
 				volume := sdp.generateVolume()
@@ -244,16 +356,16 @@ func (sdp *SampleDataProvider) GetRealTimeData(symbol string, timeframe Timefram
 				sdp.currentPrice = newPrice
 
 				if config.EnableDebugLogs {
-					fmt.Printf("%s tick: $%.2f\n", timeframe.String(), newPrice)
+					providerLog.Debug("Sample tick", "timeframe", timeframe.String(), "price", newPrice)
 				}
 
-			case <-candleTicker.C:
+			case <-candleTicker.Chan():
 				// Check for completed candle
 				if completedCandle := candleBuilder.GetCompletedCandle(); completedCandle != nil {
 					if config.EnableDebugLogs {
-						fmt.Printf("%s candle completed: O:%.2f H:%.2f L:%.2f C:%.2f V:%.0f\n",
-							timeframe.String(), completedCandle.Open, completedCandle.High,
-							completedCandle.Low, completedCandle.Close, completedCandle.Volume)
+						providerLog.Debug("Sample candle completed", "timeframe", timeframe.String(), "open", completedCandle.Open,
+							"high", completedCandle.High, "low", completedCandle.Low, "close", completedCandle.Close,
+							"volume", completedCandle.Volume)
 					}
 
 					select {
@@ -275,10 +387,10 @@ func (sdp *SampleDataProvider) GetRealTimeData(symbol string, timeframe Timefram
 // generateCandle creates a realistic candle with OHLCV data
 func (sdp *SampleDataProvider) generateCandle(timestamp time.Time, startPrice float64, timeframe Timeframe) Candle {
 	// Generate realistic price movement
-	changePercent := (rand.Float64() - 0.5) * sdp.volatility
+	changePercent := (sdp.rng.Float64() - 0.5) * sdp.volatility
 
 	// Add some trend
-	trend := sdp.trendStrength * (rand.Float64() - 0.3) // Slight upward bias
+	trend := sdp.trendStrength * (sdp.rng.Float64() - 0.3) // Slight upward bias
 	changePercent += trend
 
 	// Calculate price levels
@@ -286,8 +398,8 @@ func (sdp *SampleDataProvider) generateCandle(timestamp time.Time, startPrice fl
 	closePrice := open * (1 + changePercent)
 
 	// Generate high and low
-	highChange := rand.Float64() * sdp.volatility * 0.5
-	lowChange := -rand.Float64() * sdp.volatility * 0.5
+	highChange := sdp.rng.Float64() * sdp.volatility * 0.5
+	lowChange := -sdp.rng.Float64() * sdp.volatility * 0.5
 
 	high := math.Max(open, closePrice) * (1 + highChange)
 	low := math.Min(open, closePrice) * (1 + lowChange)
@@ -303,7 +415,7 @@ func (sdp *SampleDataProvider) generateCandle(timestamp time.Time, startPrice fl
 	// Generate volume (higher volume on bigger moves)
 	volumeBase := 10000.0
 	volumeMultiplier := 1.0 + math.Abs(changePercent)*10
-	volume := volumeBase * volumeMultiplier * (0.5 + rand.Float64())
+	volume := volumeBase * volumeMultiplier * (0.5 + sdp.rng.Float64())
 
 	return Candle{
 		Timestamp: timestamp,
@@ -325,14 +437,15 @@ func (sdp *SampleDataProvider) EnableDebugLogs(timeframe Timeframe, enabled bool
 
 // Close stops the data provider
 func (sdp *SampleDataProvider) Close() error {
+	sdp.mutex.Lock()
+	defer sdp.mutex.Unlock()
+
 	if sdp.running {
 		close(sdp.stopChan)
 		sdp.running = false
 
 		// Clean up candle builders
-		sdp.mutex.Lock()
 		sdp.candleBuilders = make(map[Timeframe]*CandleBuilder)
-		sdp.mutex.Unlock()
 	}
 	return nil
 }
@@ -412,12 +525,27 @@ func (adp *APIDataProvider) Close() error {
 type DataProviderManager struct {
 	providers map[string]DataProvider
 	primary   DataProvider
+
+	// Per-timeframe routing (provider names); timeframes without a route use primary
+	historicalRoutes map[Timeframe]string
+	realTimeRoutes   map[Timeframe]string
+
+	stream *BinanceKlineStream // Feeds the timeframes it covers instead of per-timeframe feeds
+
+	historyDepths map[Timeframe]int // Candles loaded per timeframe; unset ones use historicalCandleCounts
+
+	feedMutex sync.Mutex
+	feeds     map[Timeframe]chan struct{} // Closed when a per-timeframe feed is replaced
 }
 
 // NewDataProviderManager creates a new data provider manager
 func NewDataProviderManager() *DataProviderManager {
 	return &DataProviderManager{
-		providers: make(map[string]DataProvider),
+		providers:        make(map[string]DataProvider),
+		historicalRoutes: make(map[Timeframe]string),
+		realTimeRoutes:   make(map[Timeframe]string),
+		historyDepths:    make(map[Timeframe]int),
+		feeds:            make(map[Timeframe]chan struct{}),
 	}
 }
 
@@ -429,6 +557,12 @@ func (dpm *DataProviderManager) AddProvider(name string, provider DataProvider)
 	}
 }
 
+// HasProvider reports whether a provider with the given name is registered
+func (dpm *DataProviderManager) HasProvider(name string) bool {
+	_, exists := dpm.providers[name]
+	return exists
+}
+
 // SetPrimary sets the primary data provider
 func (dpm *DataProviderManager) SetPrimary(name string) error {
 	provider, exists := dpm.providers[name]
@@ -439,24 +573,88 @@ func (dpm *DataProviderManager) SetPrimary(name string) error {
 	return nil
 }
 
-// GetHistoricalData gets historical data from primary provider
-func (dpm *DataProviderManager) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+// SetTimeframeProviders routes a timeframe's historical and real-time data to named
+// providers. An empty name leaves that side on the primary provider.
+func (dpm *DataProviderManager) SetTimeframeProviders(timeframe Timeframe, historical, realTime string) error {
+	if historical != "" {
+		if !dpm.HasProvider(historical) {
+			return fmt.Errorf("provider %s not found for %s historical data", historical, timeframe.String())
+		}
+		dpm.historicalRoutes[timeframe] = historical
+	}
+	if realTime != "" {
+		if !dpm.HasProvider(realTime) {
+			return fmt.Errorf("provider %s not found for %s real-time data", realTime, timeframe.String())
+		}
+		dpm.realTimeRoutes[timeframe] = realTime
+	}
+	return nil
+}
+
+// providerFor resolves the provider for a timeframe from a routing table
+func (dpm *DataProviderManager) providerFor(routes map[Timeframe]string, timeframe Timeframe) (DataProvider, error) {
+	if name, ok := routes[timeframe]; ok {
+		return dpm.providers[name], nil
+	}
 	if dpm.primary == nil {
 		return nil, fmt.Errorf("no primary provider set")
 	}
-	return dpm.primary.GetHistoricalData(symbol, timeframe, count)
+	return dpm.primary, nil
+}
+
+// GetHistoricalData gets historical data from the timeframe's provider
+func (dpm *DataProviderManager) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	provider, err := dpm.providerFor(dpm.historicalRoutes, timeframe)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetHistoricalData(symbol, timeframe, count)
+}
+
+// RangeDataProvider is implemented by providers that can fetch an arbitrary time range
+type RangeDataProvider interface {
+	GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error)
+}
+
+// GetHistoricalRange gets candles opening in [start, end) from the timeframe's provider,
+// falling back to the latest candles when the provider can't page by time
+func (dpm *DataProviderManager) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	provider, err := dpm.providerFor(dpm.historicalRoutes, timeframe)
+	if err != nil {
+		return nil, err
+	}
+	if ranged, ok := provider.(RangeDataProvider); ok {
+		return ranged.GetHistoricalRange(symbol, timeframe, start, end)
+	}
+
+	count := int(time.Since(start)/timeframe.Duration()) + 1
+	candles, err := provider.GetHistoricalData(symbol, timeframe, count)
+	if err != nil {
+		return nil, err
+	}
+	inRange := make([]Candle, 0, len(candles))
+	for _, candle := range candles {
+		if !candle.Timestamp.Before(start) && candle.Timestamp.Before(end) {
+			inRange = append(inRange, candle)
+		}
+	}
+	return inRange, nil
 }
 
-// GetRealTimeData gets real-time data from primary provider
+// GetRealTimeData gets real-time data from the timeframe's provider
 func (dpm *DataProviderManager) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
-	if dpm.primary == nil {
-		return nil, fmt.Errorf("no primary provider set")
+	provider, err := dpm.providerFor(dpm.realTimeRoutes, timeframe)
+	if err != nil {
+		return nil, err
 	}
-	return dpm.primary.GetRealTimeData(symbol, timeframe)
+	return provider.GetRealTimeData(symbol, timeframe)
 }
 
 // Close closes all providers
 func (dpm *DataProviderManager) Close() error {
+	if dpm.stream != nil {
+		dpm.stream.Stop()
+	}
 	for _, provider := range dpm.providers {
 		if err := provider.Close(); err != nil {
 			return err
@@ -465,26 +663,36 @@ func (dpm *DataProviderManager) Close() error {
 	return nil
 }
 
+// historicalCandleCounts is how many candles are loaded per timeframe unless
+// history.candles overrides it; enough for the default indicators in
+// multi_timeframe mode (the daily Trend uses a 200-candle MA)
+var historicalCandleCounts = map[Timeframe]int{
+	Daily:           200,
+	EightHour:       80,
+	FortyFiveMinute: 60,
+	FifteenMinute:   80,
+	FiveMinute:      100,
+}
+
+// SetHistoryDepth sets how many candles are loaded for a timeframe
+func (dpm *DataProviderManager) SetHistoryDepth(timeframe Timeframe, count int) {
+	dpm.historyDepths[timeframe] = count
+}
+
+// historyDepth returns how many candles are loaded for a timeframe
+func (dpm *DataProviderManager) historyDepth(timeframe Timeframe) int {
+	if count, ok := dpm.historyDepths[timeframe]; ok {
+		return count
+	}
+	return historicalCandleCounts[timeframe]
+}
+
 // LoadHistoricalDataForAllTimeframes loads data for all required timeframes
 func (dpm *DataProviderManager) LoadHistoricalDataForAllTimeframes(symbol string, tm *TimeframeManager) error {
 	timeframes := []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute}
 
 	for _, timeframe := range timeframes {
-		var count int
-		switch timeframe {
-		case Daily:
-			count = 30
-		case EightHour:
-			count = 50
-		case FortyFiveMinute:
-			count = 60
-		case FifteenMinute:
-			count = 80
-		case FiveMinute:
-			count = 100
-		}
-
-		candles, err := dpm.GetHistoricalData(symbol, timeframe, count)
+		candles, err := dpm.GetHistoricalData(symbol, timeframe, dpm.historyDepth(timeframe))
 		if err != nil {
 			return fmt.Errorf("failed to load %s data: %w", timeframe.String(), err)
 		}
@@ -498,23 +706,152 @@ func (dpm *DataProviderManager) LoadHistoricalDataForAllTimeframes(symbol string
 	return nil
 }
 
-// StartRealTimeDataFeeds starts real-time data feeds for all timeframes
-func (dpm *DataProviderManager) StartRealTimeDataFeeds(symbol string, tm *TimeframeManager) error {
+// LoadHistoricalDataThroughStore seeds tm from the candle store and fetches only
+// the candles missing since the last stored one. Timeframes with too little or
+// too old stored data are loaded in full; if fetching fails, stored candles are
+// used alone so the bot can start offline.
+func (dpm *DataProviderManager) LoadHistoricalDataThroughStore(symbol string, tm *TimeframeManager, store CandleStore) error {
 	timeframes := []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute}
 
 	for _, timeframe := range timeframes {
-		candleChan, err := dpm.GetRealTimeData(symbol, timeframe)
+		count := dpm.historyDepth(timeframe)
+		stored, err := store.LatestCandles(symbol, timeframe, count)
 		if err != nil {
-			return fmt.Errorf("failed to start %s real-time feed: %w", timeframe.String(), err)
+			return fmt.Errorf("failed to read stored %s data: %w", timeframe.String(), err)
 		}
 
-		// Start goroutine to handle incoming candles
-		go func(tf Timeframe, ch <-chan Candle) {
-			for candle := range ch {
-				tm.AddCandle(tf, candle)
+		var candles []Candle
+		prefix := storedPrefix(stored)
+		if len(prefix) < count/2 || time.Since(prefix[len(prefix)-1].Timestamp) > time.Duration(count)*timeframe.Duration() {
+			candles, err = dpm.GetHistoricalData(symbol, timeframe, count)
+		} else {
+			var fetched []Candle
+			from := prefix[len(prefix)-1].Timestamp.Add(time.Second)
+			fetched, err = dpm.GetHistoricalRange(symbol, timeframe, from, time.Now().Add(timeframe.Duration()))
+			candles = appendNewerCandles(prefix, fetched)
+			providerLog.Info("📦 Loaded stored candles", "timeframe", timeframe.String(), "stored", len(prefix), "fetched", len(fetched))
+		}
+		if err != nil {
+			if len(stored) == 0 {
+				return fmt.Errorf("failed to load %s data: %w", timeframe.String(), err)
 			}
-		}(timeframe, candleChan)
+			providerLog.Warn("Failed to fetch candles, using stored ones", "timeframe", timeframe.String(), "stored", len(stored), "error", err)
+			candles = stored
+		}
+
+		if len(candles) > count {
+			candles = candles[len(candles)-count:]
+		}
+		tm.ReplaceCandles(timeframe, candles)
 	}
 
 	return nil
 }
+
+// RefreshHistoricalDataForAllTimeframes re-fetches every timeframe and replaces the stored
+// candles, so corrected or backfilled bars overwrite what was loaded before. Nothing is
+// replaced unless all timeframes fetch successfully.
+func (dpm *DataProviderManager) RefreshHistoricalDataForAllTimeframes(symbol string, tm *TimeframeManager) (map[Timeframe]int, error) {
+	timeframes := []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute}
+
+	fetched := make(map[Timeframe][]Candle, len(timeframes))
+	for _, timeframe := range timeframes {
+		candles, err := dpm.GetHistoricalData(symbol, timeframe, dpm.historyDepth(timeframe))
+		if err != nil {
+			return nil, fmt.Errorf("failed to refresh %s data: %w", timeframe.String(), err)
+		}
+		fetched[timeframe] = candles
+	}
+
+	counts := make(map[Timeframe]int, len(fetched))
+	for timeframe, candles := range fetched {
+		tm.ReplaceCandles(timeframe, candles)
+		counts[timeframe] = len(candles)
+	}
+	return counts, nil
+}
+
+// StartKlineStream streams every timeframe without a real-time route over one
+// Binance WebSocket; the primary provider must be Binance. Call it before
+// StartRealTimeDataFeeds, which then only starts feeds for the other timeframes.
+func (dpm *DataProviderManager) StartKlineStream(symbol string, tm *TimeframeManager, config StreamingConfig) error {
+	binanceProvider, ok := dpm.primary.(*BinanceFuturesDataProvider)
+	if !ok {
+		return fmt.Errorf("kline streaming requires the Binance data provider")
+	}
+
+	timeframes := make([]Timeframe, 0)
+	for _, timeframe := range []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute} {
+		if _, routed := dpm.realTimeRoutes[timeframe]; !routed {
+			timeframes = append(timeframes, timeframe)
+		}
+	}
+	if len(timeframes) == 0 {
+		return fmt.Errorf("every timeframe has a real-time provider override")
+	}
+
+	dpm.stream = NewBinanceKlineStream(binanceProvider, symbol, timeframes, tm, config)
+	dpm.stream.Start()
+	return nil
+}
+
+// Stream returns the kline stream, or nil when timeframes use per-timeframe feeds
+func (dpm *DataProviderManager) Stream() *BinanceKlineStream {
+	return dpm.stream
+}
+
+// StartRealTimeDataFeeds starts real-time data feeds for all timeframes not covered by the kline stream
+func (dpm *DataProviderManager) StartRealTimeDataFeeds(symbol string, tm *TimeframeManager) error {
+	timeframes := []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute}
+
+	for _, timeframe := range timeframes {
+		if dpm.stream != nil && dpm.stream.Covers(timeframe) {
+			continue
+		}
+		if err := dpm.startFeed(symbol, timeframe, tm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startFeed starts a timeframe's real-time feed, retiring the one it replaces
+func (dpm *DataProviderManager) startFeed(symbol string, timeframe Timeframe, tm *TimeframeManager) error {
+	candleChan, err := dpm.GetRealTimeData(symbol, timeframe)
+	if err != nil {
+		return fmt.Errorf("failed to start %s real-time feed: %w", timeframe.String(), err)
+	}
+
+	retired := make(chan struct{})
+	dpm.feedMutex.Lock()
+	if previous, ok := dpm.feeds[timeframe]; ok {
+		close(previous)
+	}
+	dpm.feeds[timeframe] = retired
+	dpm.feedMutex.Unlock()
+
+	// Start goroutine to handle incoming candles; a replaced feed is drained
+	// without applying its candles
+	go func(tf Timeframe, ch <-chan Candle) {
+		for candle := range ch {
+			select {
+			case <-retired:
+				continue
+			default:
+			}
+			tm.AddCandle(tf, candle)
+		}
+	}(timeframe, candleChan)
+	return nil
+}
+
+// ReconnectFeed restarts a silent timeframe's feed: the kline stream is redialled
+// and a per-timeframe feed is replaced by a new one
+func (dpm *DataProviderManager) ReconnectFeed(symbol string, timeframe Timeframe, tm *TimeframeManager) error {
+	if dpm.stream != nil && dpm.stream.Covers(timeframe) {
+		dpm.stream.Reconnect()
+		return nil
+	}
+	return dpm.startFeed(symbol, timeframe, tm)
+}
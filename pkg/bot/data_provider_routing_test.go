@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPerTimeframeProviderRouting(t *testing.T) {
+	t.Log("🔀 Testing per-timeframe data provider routing")
+
+	dir := t.TempDir()
+	csvData := "timestamp,open,high,low,close,volume\n" +
+		"1704067200000,100,110,95,105,1000\n" +
+		"2024-01-02T00:00:00Z,105,112,101,108,1200\n"
+	if err := os.WriteFile(filepath.Join(dir, "BTCUSDT_1d.csv"), []byte(csvData), 0644); err != nil {
+		t.Fatalf("Failed to write candle file: %v", err)
+	}
+
+	manager := NewDataProviderManager()
+	manager.AddProvider("sample", NewSampleDataProvider([]string{"BTCUSDT"}, 50000))
+	manager.AddProvider("file", NewFileDataProvider(dir))
+
+	if err := manager.SetTimeframeProviders(Daily, "file", ""); err != nil {
+		t.Fatalf("Failed to route daily data: %v", err)
+	}
+	if err := manager.SetTimeframeProviders(FiveMinute, "missing", ""); err == nil {
+		t.Errorf("Expected error routing to an unregistered provider")
+	}
+
+	daily, err := manager.GetHistoricalData("BTCUSDT", Daily, 10)
+	if err != nil {
+		t.Fatalf("Failed to load daily candles from file: %v", err)
+	}
+	if len(daily) != 2 || daily[1].Close != 108 {
+		t.Errorf("Expected 2 file candles ending at 108, got %d candles", len(daily))
+	}
+	if len(daily) > 0 && (daily[0].Timestamp.Location() != time.UTC || daily[0].Timestamp.Hour() != 0) {
+		t.Errorf("Expected millisecond timestamps in UTC, got %v", daily[0].Timestamp)
+	}
+
+	// Unrouted timeframes fall back to the primary (sample) provider
+	fiveMin, err := manager.GetHistoricalData("BTCUSDT", FiveMinute, 25)
+	if err != nil || len(fiveMin) != 25 {
+		t.Errorf("Expected 25 sample candles for 5m, got %d (err: %v)", len(fiveMin), err)
+	}
+
+	if _, err := manager.GetRealTimeData("BTCUSDT", Daily); err != nil {
+		t.Errorf("Daily real-time data should stay on the primary provider: %v", err)
+	}
+	manager.Close()
+}
+
+func TestProvidersConfigValidation(t *testing.T) {
+	config := DefaultConfig()
+	config.Providers = map[string]TimeframeProviderConfig{
+		"1d": {Historical: "file", RealTime: "binance_rest"},
+	}
+	if err := ValidateConfig(config); err == nil {
+		t.Errorf("Expected error when file provider is used without data_dir")
+	}
+
+	config.DataDir = "data"
+	if err := ValidateConfig(config); err != nil {
+		t.Errorf("Expected valid providers config, got: %v", err)
+	}
+
+	config.Providers["2h"] = TimeframeProviderConfig{Historical: "sample"}
+	if err := ValidateConfig(config); err == nil {
+		t.Errorf("Expected error for unknown timeframe key")
+	}
+}
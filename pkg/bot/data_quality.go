@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DataQuality lists problems with the candles a live signal is computed from,
+// keyed by timeframe name
+type DataQuality struct {
+	Stale    []string       `json:"stale,omitempty"`    // Feeds the watchdog found silent
+	Gaps     map[string]int `json:"gaps,omitempty"`     // Candles missing between the ones analyzed
+	Fallback []string       `json:"fallback,omitempty"` // Candles last refreshed over the REST fallback
+}
+
+// countMissingCandles counts the intervals skipped between consecutive candles
+func countMissingCandles(candles []Candle, timeframe Timeframe) int {
+	step := timeframe.Duration()
+	missing := 0
+	for i := 1; i < len(candles); i++ {
+		if gap := candles[i].Timestamp.Sub(candles[i-1].Timestamp); gap >= 2*step {
+			missing += int(gap/step) - 1
+		}
+	}
+	return missing
+}
+
+// dataQuality summarizes stale, gapped and fallback timeframes (assumes lock is held)
+func (tm *TimeframeManager) dataQuality(candles map[Timeframe][]Candle) *DataQuality {
+	quality := &DataQuality{}
+	for _, timeframe := range multiTimeframes {
+		if tm.degraded[timeframe] {
+			quality.Stale = append(quality.Stale, timeframe.String())
+		}
+		if tm.fallback[timeframe] {
+			quality.Fallback = append(quality.Fallback, timeframe.String())
+		}
+		if missing := countMissingCandles(candles[timeframe], timeframe); missing > 0 {
+			if quality.Gaps == nil {
+				quality.Gaps = make(map[string]int)
+			}
+			quality.Gaps[timeframe.String()] = missing
+		}
+	}
+	return quality
+}
+
+// SetFallback records whether a timeframe's latest candles came from the REST
+// fallback rather than its live feed
+func (tm *TimeframeManager) SetFallback(timeframe Timeframe, fallback bool) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	if fallback {
+		tm.fallback[timeframe] = true
+	} else {
+		delete(tm.fallback, timeframe)
+	}
+}
+
+// applyDataQuality lowers a signal's confidence for stale, gapped or fallback
+// candles on the timeframes the strategy analyzes, naming each problem in the
+// reasoning; a signal pushed below min_confidence becomes a HOLD
+func (sa *SignalAggregator) applyDataQuality(signal *TradingSignal, quality *DataQuality) {
+	if quality == nil {
+		return
+	}
+	used := make(map[string]bool)
+	for _, timeframe := range sa.timeframes() {
+		if sa.config.Strategy.Mode != StrategyModeMultiTimeframe || sa.timeframeWeight(timeframe) > 0 {
+			used[timeframe.String()] = true
+		}
+	}
+	filter := func(names []string) []string {
+		var kept []string
+		for _, name := range names {
+			if used[name] {
+				kept = append(kept, name)
+			}
+		}
+		return kept
+	}
+
+	factor := 1.0
+	var problems []string
+	if stale := filter(quality.Stale); len(stale) > 0 {
+		factor *= sa.config.Streaming.DegradedConfidence
+		problems = append(problems, "stale "+strings.Join(stale, ", "))
+	}
+	var gaps []string
+	for _, name := range sortedKeys(quality.Gaps) {
+		if used[name] {
+			gaps = append(gaps, fmt.Sprintf("%s missing %d", name, quality.Gaps[name]))
+		}
+	}
+	if len(gaps) > 0 {
+		factor *= sa.config.DataQuality.GapConfidence
+		problems = append(problems, strings.Join(gaps, ", "))
+	}
+	if fallback := filter(quality.Fallback); len(fallback) > 0 {
+		factor *= sa.config.DataQuality.FallbackConfidence
+		problems = append(problems, "REST fallback for "+strings.Join(fallback, ", "))
+	}
+	if factor >= 1 {
+		return
+	}
+
+	signal.Confidence *= factor
+	signal.Reasoning += fmt.Sprintf(" - Data quality: %s (confidence x%.2f)", strings.Join(problems, "; "), factor)
+	if signal.Signal != Hold && signal.Confidence < sa.config.MinConfidence {
+		signal.Signal = Hold
+		signal.Reasoning += " - Below minimum confidence threshold"
+	}
+}
@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDataQualityPenalty(t *testing.T) {
+	t.Log("🩹 Testing confidence penalties for stale, gapped and fallback candles")
+
+	// Live contexts report gaps, fallback refreshes and silent feeds per timeframe
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tm := NewTimeframeManager("BTCUSDT")
+	for _, tf := range multiTimeframes {
+		candles := syntheticCandles(tf, start, 120)
+		if tf == FiveMinute {
+			candles = append(candles[:50], candles[52:]...)
+		}
+		tm.ReplaceCandles(tf, candles)
+	}
+	tm.SetFallback(FifteenMinute, true)
+	tm.CheckFeeds(map[Timeframe]time.Duration{FiveMinute: time.Minute}, time.Now().Add(time.Hour))
+	ctx, err := tm.GetMultiTimeframeContext()
+	if err != nil {
+		t.Fatalf("Failed to build context: %v", err)
+	}
+	if q := ctx.Quality; q == nil || q.Gaps["5m"] != 2 || len(q.Gaps) != 1 || len(q.Fallback) != 1 || q.Fallback[0] != "15m" || len(q.Stale) != 1 || q.Stale[0] != "5m" {
+		t.Fatalf("Unexpected data quality: %+v", ctx.Quality)
+	}
+
+	// Only the timeframes the strategy analyzes count; a BUY pushed below
+	// min_confidence turns into a HOLD with the reason spelled out
+	sa := NewSignalAggregator(DefaultConfig())
+	signal := &TradingSignal{Signal: Buy, Confidence: 0.8, Reasoning: "BULLISH"}
+	sa.applyDataQuality(signal, ctx.Quality)
+	if signal.Signal != Hold || signal.Confidence < 0.475 || signal.Confidence > 0.477 {
+		t.Errorf("Expected a HOLD at 0.8 x 0.7 x 0.85, got %s at %.3f", signal.Signal, signal.Confidence)
+	}
+	if want := "BULLISH - Data quality: stale 5m; 5m missing 2 (confidence x0.59) - Below minimum confidence threshold"; signal.Reasoning != want {
+		t.Errorf("Unexpected reasoning:\n%s\nwant:\n%s", signal.Reasoning, want)
+	}
+
+	signal = &TradingSignal{Signal: Buy, Confidence: 0.8}
+	sa.applyDataQuality(signal, &DataQuality{Fallback: []string{"5m"}, Gaps: map[string]int{"1d": 3}})
+	if signal.Signal != Buy || signal.Confidence < 0.719 || signal.Confidence > 0.721 || !strings.Contains(signal.Reasoning, "REST fallback for 5m") {
+		t.Errorf("Expected a BUY at 0.8 x 0.9, got %s at %.3f (%s)", signal.Signal, signal.Confidence, signal.Reasoning)
+	}
+	signal = &TradingSignal{Signal: Buy, Confidence: 0.8}
+	sa.applyDataQuality(signal, nil)
+	if signal.Confidence != 0.8 || signal.Reasoning != "" {
+		t.Errorf("Backtest contexts carry no quality and keep their confidence, got %+v", signal)
+	}
+}
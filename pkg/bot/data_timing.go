@@ -0,0 +1,38 @@
+package bot
+
+import "time"
+
+// DataTiming records when market data was fetched and which candle it reflects,
+// so prediction targets are anchored to the data rather than request arrival
+type DataTiming struct {
+	FetchStarted    time.Time     `json:"fetch_started"`
+	FetchCompleted  time.Time     `json:"fetch_completed"`
+	FetchLatency    time.Duration `json:"fetch_latency"`
+	CandleOpenTime  time.Time     `json:"candle_open_time"`  // Latest candle used
+	CandleCloseTime time.Time     `json:"candle_close_time"` // May be in the future for a forming candle
+	DataTimestamp   time.Time     `json:"data_timestamp"`    // Best estimate of when the data was valid
+}
+
+// NewDataTiming estimates the data timestamp from a fetch window and the latest candle.
+// The exchange snapshot is assumed to be taken halfway through the round trip; a
+// closed candle can't reflect anything after its close.
+func NewDataTiming(fetchStarted, fetchCompleted time.Time, latest Candle, timeframe Timeframe) DataTiming {
+	latency := fetchCompleted.Sub(fetchStarted)
+	timing := DataTiming{
+		FetchStarted:    fetchStarted,
+		FetchCompleted:  fetchCompleted,
+		FetchLatency:    latency,
+		CandleOpenTime:  latest.Timestamp,
+		CandleCloseTime: latest.Timestamp.Add(timeframe.Duration()),
+		DataTimestamp:   fetchStarted.Add(latency / 2),
+	}
+	if !latest.Timestamp.IsZero() && timing.CandleCloseTime.Before(timing.DataTimestamp) {
+		timing.DataTimestamp = timing.CandleCloseTime
+	}
+	return timing
+}
+
+// TargetTime returns the prediction target for a horizon measured from the data timestamp
+func (dt DataTiming) TargetTime(horizon time.Duration) time.Time {
+	return dt.DataTimestamp.Add(horizon)
+}
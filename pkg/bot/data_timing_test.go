@@ -0,0 +1,33 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDataTiming(t *testing.T) {
+	t.Log("⏱️ Testing latency-compensated prediction timestamps")
+
+	// Forming candle: data is as of the middle of a slow 2s fetch
+	fetchStarted := time.Date(2024, 1, 1, 12, 2, 0, 0, time.UTC)
+	fetchCompleted := fetchStarted.Add(2 * time.Second)
+	forming := Candle{Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	timing := NewDataTiming(fetchStarted, fetchCompleted, forming, FiveMinute)
+	if timing.FetchLatency != 2*time.Second {
+		t.Errorf("Expected 2s fetch latency, got %v", timing.FetchLatency)
+	}
+	if want := fetchStarted.Add(time.Second); !timing.DataTimestamp.Equal(want) {
+		t.Errorf("Expected data timestamp %v, got %v", want, timing.DataTimestamp)
+	}
+	if want := fetchStarted.Add(time.Second + 5*time.Minute); !timing.TargetTime(5 * time.Minute).Equal(want) {
+		t.Errorf("Expected target %v, got %v", want, timing.TargetTime(5*time.Minute))
+	}
+
+	// Closed candle (no newer data): the data can't be newer than the close
+	closed := Candle{Timestamp: time.Date(2024, 1, 1, 11, 50, 0, 0, time.UTC)}
+	timing = NewDataTiming(fetchStarted, fetchCompleted, closed, FiveMinute)
+	if want := time.Date(2024, 1, 1, 11, 55, 0, 0, time.UTC); !timing.DataTimestamp.Equal(want) {
+		t.Errorf("Expected data timestamp capped at candle close %v, got %v", want, timing.DataTimestamp)
+	}
+}
@@ -0,0 +1,71 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultFrozenClock is reported when determinism is enabled without a clock
+var defaultFrozenClock = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// WallClock returns time.Now, or a clock frozen at Clock when determinism is enabled
+func (d DeterminismConfig) WallClock() func() time.Time {
+	if !d.Enabled {
+		return time.Now
+	}
+	frozen, err := time.Parse(time.RFC3339, d.Clock)
+	if err != nil {
+		frozen = defaultFrozenClock
+	}
+	return func() time.Time { return frozen }
+}
+
+// Rand returns a random source seeded from Seed when determinism is enabled
+// and from the current time otherwise. The source is safe for concurrent use.
+func (d DeterminismConfig) Rand() *LockedRand {
+	seed := time.Now().UnixNano()
+	if d.Enabled {
+		seed = d.Seed
+	}
+	return &LockedRand{rng: rand.New(rand.NewSource(seed))}
+}
+
+// DeterminismFromEnv enables determinism with the seed in TRADING_BOT_SEED (used
+// by test data generators so a suite run can be reproduced)
+func DeterminismFromEnv() DeterminismConfig {
+	config := DefaultConfig().Determinism
+	if seed, err := strconv.ParseInt(os.Getenv("TRADING_BOT_SEED"), 10, 64); err == nil {
+		config.Enabled = true
+		config.Seed = seed
+	}
+	return config
+}
+
+// LockedRand is a mutex-guarded *rand.Rand
+type LockedRand struct {
+	rng   *rand.Rand
+	mutex sync.Mutex
+}
+
+// Float64 returns a pseudo-random number in [0, 1)
+func (r *LockedRand) Float64() float64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.rng.Float64()
+}
+
+// deterministicID derives a stable ID from the JSON encoding of parts
+func deterministicID(prefix string, parts ...interface{}) string {
+	hash := fnv.New64a()
+	for _, part := range parts {
+		data, _ := json.Marshal(part)
+		hash.Write(data)
+	}
+	return fmt.Sprintf("%s_%016x", prefix, hash.Sum64())
+}
@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeterministicBacktest(t *testing.T) {
+	t.Log("🔁 Testing determinism mode produces byte-identical backtest and sweep reports")
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	config.Determinism.Enabled = true
+	config.Determinism.Seed = 7
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected determinism config to validate: %v", err)
+	}
+
+	run := func() ([]byte, map[Timeframe][]Candle, time.Time, time.Time) {
+		candles, start, end, err := NewTradingBot(config).LoadBacktestCandles(1)
+		if err != nil {
+			t.Fatalf("Failed to load candles: %v", err)
+		}
+		if want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+			t.Fatalf("Expected the window to end at the frozen clock, got %v", end)
+		}
+		result, err := NewBacktester(config, 10000.0).Run(copyCandles(candles), start, end)
+		if err != nil {
+			t.Fatalf("Backtest failed: %v", err)
+		}
+		data, _ := json.Marshal(result)
+		return data, candles, start, end
+	}
+
+	first, candles, start, end := run()
+	second, _, _, _ := run()
+	if !bytes.Equal(first, second) {
+		t.Fatalf("Expected byte-identical backtest reports (%d vs %d bytes)", len(first), len(second))
+	}
+	if !strings.Contains(string(first), `"id":"bt_`) || !strings.Contains(string(first), `"created_at":"2024-01-01T00:00:00Z"`) {
+		t.Errorf("Expected a derived ID and the frozen creation time, got %.120s", first)
+	}
+
+	// A different seed generates different sample data
+	config.Determinism.Seed = 8
+	other, _, _, _ := run()
+	if bytes.Equal(first, other) {
+		t.Error("Expected a different seed to change the report")
+	}
+	config.Determinism.Seed = 7
+
+	// Sweeps on several workers come back in the same order every time
+	params := []SweepParameter{
+		{Parameter: "rsi.period", Values: []float64{7, 14}},
+		{Parameter: "ema.fast_period", Values: []float64{5, 9}},
+	}
+	sweep := func() []byte {
+		report, err := NewParameterOptimizer(config, 10000.0, 3).Run(context.Background(), params, candles, start.Add(18*time.Hour), end)
+		if err != nil {
+			t.Fatalf("Sweep failed: %v", err)
+		}
+		if report.Duration != 0 {
+			t.Errorf("Expected the frozen clock to report zero duration, got %v", report.Duration)
+		}
+		data, _ := json.Marshal(report)
+		return data
+	}
+	if a, b := sweep(), sweep(); !bytes.Equal(a, b) {
+		t.Errorf("Expected byte-identical sweep reports:\n%s\n%s", a, b)
+	}
+
+	config.Determinism.Clock = "yesterday"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "determinism.clock") {
+		t.Errorf("Expected determinism.clock validation error, got %v", err)
+	}
+}
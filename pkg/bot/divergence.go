@@ -0,0 +1,39 @@
+package bot
+
+import "trading-bot/pkg/indicator"
+
+// divergenceIndicators are the registered oscillators divergence detection runs on
+var divergenceIndicators = map[string]bool{"rsi": true, "macd": true, "mfi": true, "stochastic": true}
+
+// divergenceSignal checks an oscillator's values for divergence from price when
+// divergence.indicators lists it
+func (sa *SignalAggregator) divergenceSignal(ind indicator.TechnicalIndicator, timeframe Timeframe, candles []indicator.Candle, values []float64) (indicator.IndicatorSignal, bool) {
+	if !sa.config.Divergence.Enabled {
+		return indicator.IndicatorSignal{}, false
+	}
+
+	var name string
+	switch ind.(type) {
+	case *indicator.RSI:
+		name = "rsi"
+	case *indicator.MACD:
+		name = "macd"
+	case *indicator.ReverseMFI:
+		name = "mfi"
+	case *indicator.Stochastic:
+		name = "stochastic"
+	default:
+		return indicator.IndicatorSignal{}, false
+	}
+	for _, key := range sa.config.Divergence.Indicators {
+		if info, ok := LookupIndicator(key); ok && info.Name == name {
+			config := indicator.DivergenceConfig{
+				PivotBars: sa.config.Divergence.PivotBars,
+				Lookback:  sa.config.Divergence.Lookback,
+				Hidden:    sa.config.Divergence.Hidden,
+			}
+			return indicator.DivergenceSignal(ind, convertTimeframe(timeframe), candles, values, config)
+		}
+	}
+	return indicator.IndicatorSignal{}, false
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/indicator"
+)
+
+func TestDivergenceDetection(t *testing.T) {
+	t.Log("↔️ Testing regular and hidden divergences between price swings and oscillators")
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candlesFrom := func(prices []float64) []indicator.Candle {
+		candles := make([]indicator.Candle, len(prices))
+		for i, price := range prices {
+			candles[i] = indicator.Candle{Timestamp: origin.Add(time.Duration(i) * 5 * time.Minute), Open: price, High: price + 1, Low: price - 1, Close: price}
+		}
+		return candles
+	}
+	config := indicator.DivergenceConfig{PivotBars: 2, Lookback: 60, Hidden: true}
+
+	// Swing lows at 3 (low 6) and 9 (low 5) while the oscillator rises from 20 to 30
+	prices := []float64{10, 9, 8, 7, 8, 9, 10, 9, 8, 6, 8, 9, 10, 11}
+	oscillator := []float64{50, 40, 30, 20, 30, 40, 60, 45, 35, 30, 40, 50, 55, 58}
+	divergence, ok := indicator.DetectDivergence(candlesFrom(prices), oscillator, config)
+	if !ok || divergence.Kind != indicator.RegularBullish || divergence.Signal != indicator.Buy || divergence.From != 3 || divergence.To != 9 {
+		t.Fatalf("Expected a regular bullish divergence between candles 3 and 9, got %+v (ok %v)", divergence, ok)
+	}
+	// Price fell 1 of its 7 range, the oscillator rose 10 of its 40
+	if want := (1.0/7 + 10.0/40) / 2; math.Abs(divergence.Strength-want) > 1e-9 || divergence.Level != 30 {
+		t.Errorf("Expected strength %.4f at level 30, got %+v", want, divergence)
+	}
+	// A shorter oscillator series is aligned to the last candles
+	if shifted, ok := indicator.DetectDivergence(candlesFrom(prices), oscillator[2:], config); !ok || shifted != divergence {
+		t.Errorf("Expected the same divergence from a right-aligned series, got %+v", shifted)
+	}
+
+	// A higher low with a lower oscillator low is a hidden bullish divergence
+	prices[9], oscillator[9] = 7.5, 15
+	if divergence, ok := indicator.DetectDivergence(candlesFrom(prices), oscillator, config); !ok || divergence.Kind != indicator.HiddenBullish {
+		t.Errorf("Expected a hidden bullish divergence, got %+v (ok %v)", divergence, ok)
+	}
+	if _, ok := indicator.DetectDivergence(candlesFrom(prices), oscillator, indicator.DivergenceConfig{PivotBars: 2, Lookback: 60}); ok {
+		t.Errorf("Expected hidden divergences to be off unless enabled")
+	}
+
+	// Mirrored: a higher high with a lower oscillator high is regular bearish
+	for i := range prices {
+		prices[i], oscillator[i] = 20-prices[i], 100-oscillator[i]
+	}
+	prices[9], oscillator[9] = 14, 70
+	if divergence, ok := indicator.DetectDivergence(candlesFrom(prices), oscillator, config); !ok || divergence.Kind != indicator.RegularBearish || divergence.Signal != indicator.Sell {
+		t.Errorf("Expected a regular bearish divergence, got %+v (ok %v)", divergence, ok)
+	}
+
+	// Agreeing swings aren't a divergence
+	oscillator[9] = 90
+	if divergence, ok := indicator.DetectDivergence(candlesFrom(prices), oscillator, config); ok {
+		t.Errorf("Expected no divergence when the oscillator confirms price, got %+v", divergence)
+	}
+
+	// A slow grind to a lower low after a crash leaves RSI with a higher low
+	var closes []float64
+	for i := 0; i < 20; i++ {
+		closes = append(closes, 100+float64(i%2))
+	}
+	closes = append(closes, 95, 88, 80, 84, 88, 90, 89, 88, 87, 86, 85, 84, 83, 82, 81, 80, 79, 78, 81, 84, 86)
+	var candles []Candle
+	for i, close := range closes {
+		candles = append(candles, Candle{Timestamp: origin.Add(time.Duration(i) * 5 * time.Minute), Open: close, High: close + 0.5, Low: close - 0.5, Close: close})
+	}
+
+	botConfig := DefaultConfig()
+	for _, info := range Indicators() {
+		info.SetEnabled(&botConfig, false)
+	}
+	botConfig.RSI.Enabled = true
+	botConfig.Divergence.Enabled = true
+	botConfig.Divergence.Indicators = []string{"rsi"}
+	if err := ValidateConfig(botConfig); err != nil {
+		t.Fatalf("Expected the divergence config to be valid: %v", err)
+	}
+	signals := NewSignalAggregator(botConfig).getTimeframeSignals(candles, FiveMinute, closes[len(closes)-1])
+	if len(signals) != 2 || signals[1].Name != "RSIDivergence_5m" || signals[1].Signal != Buy || signals[1].Strength <= 0 {
+		t.Fatalf("Expected RSI followed by a bullish RSI divergence, got %+v", signals)
+	}
+
+	// Oscillators not listed, or with divergence disabled, add nothing
+	botConfig.Divergence.Indicators = []string{"macd"}
+	if signals := NewSignalAggregator(botConfig).getTimeframeSignals(candles, FiveMinute, closes[len(closes)-1]); len(signals) != 1 {
+		t.Errorf("Expected only the RSI signal, got %+v", signals)
+	}
+
+	botConfig.Divergence.Indicators = []string{"reverse_mfi", "ema"}
+	botConfig.Divergence.Lookback = 5
+	err := ValidateConfig(botConfig)
+	for _, want := range []string{"divergence.indicators[1]: ema is not a divergence oscillator", "divergence.lookback"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+	if err != nil && strings.Contains(err.Error(), "divergence.indicators[0]") {
+		t.Errorf("Expected the reverse_mfi alias to be accepted: %v", err)
+	}
+}
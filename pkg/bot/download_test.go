@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingRangeSource serves synthetic candles and counts the requests made
+type countingRangeSource struct {
+	requests int
+	failAt   int // Request number that fails, 0 for none
+}
+
+func (s *countingRangeSource) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	s.requests++
+	if s.requests == s.failAt {
+		return nil, context.DeadlineExceeded
+	}
+	count := int(end.Sub(start) / timeframe.Duration())
+	return syntheticCandles(timeframe, start, count), nil
+}
+
+func TestDownloadCandles(t *testing.T) {
+	t.Log("📥 Testing chunked candle downloads with checksums and resume")
+
+	span := 10 * FiveMinute.Duration()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(span)
+	opts := DownloadOptions{
+		Symbols:      []string{"BTCUSDT", "ETHUSDT"},
+		Timeframes:   []Timeframe{FiveMinute},
+		Start:        start,
+		End:          start.Add(4 * span),
+		ChunkCandles: 10,
+		ManifestPath: filepath.Join(t.TempDir(), "candles.download.json"),
+	}
+	store := NewMemoryCandleStore()
+
+	// Interrupted on the sixth request: the first symbol's chunks and one of the second's are kept
+	source := &countingRangeSource{failAt: 6}
+	result, err := DownloadCandles(context.Background(), source, store, opts)
+	if err == nil || result.Fetched != 5 || result.Candles != 50 {
+		t.Fatalf("Expected 5 chunks before the failure, got %+v (err %v)", result, err)
+	}
+
+	// Resuming fetches only the missing chunks
+	source = &countingRangeSource{}
+	result, err = DownloadCandles(context.Background(), source, store, opts)
+	if err != nil || result.Skipped != 5 || result.Fetched != 3 || source.requests != 3 {
+		t.Fatalf("Expected 3 chunks fetched and 5 skipped, got %+v after %d requests (err %v)", result, source.requests, err)
+	}
+	if stored, _ := store.LoadCandles("ETHUSDT", FiveMinute, opts.Start, opts.End); len(stored) != 40 {
+		t.Errorf("Expected 40 ETHUSDT candles, got %d", len(stored))
+	}
+
+	// A stored candle changed since the download fails verification and is fetched again
+	tampered, _ := store.LoadCandles("BTCUSDT", FiveMinute, start.Add(span), start.Add(2*span))
+	tampered[3].Close *= 1.01
+	store.SaveCandles("BTCUSDT", FiveMinute, tampered[3:4])
+	source = &countingRangeSource{}
+	result, err = DownloadCandles(context.Background(), source, store, opts)
+	if err != nil || result.Repaired != 1 || result.Skipped != 7 || source.requests != 1 {
+		t.Errorf("Expected only the tampered chunk downloaded again, got %+v (err %v)", result, err)
+	}
+
+	// Requests are spaced by MinInterval
+	opts.Symbols, opts.ManifestPath, opts.MinInterval = []string{"SOLUSDT"}, filepath.Join(t.TempDir(), "m.json"), 20*time.Millisecond
+	began := time.Now()
+	if _, err := DownloadCandles(context.Background(), &countingRangeSource{}, store, opts); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(began); elapsed < 60*time.Millisecond {
+		t.Errorf("Expected 4 requests to take at least 3 intervals, took %s", elapsed)
+	}
+
+	opts.End = opts.Start
+	if _, err := DownloadCandles(context.Background(), &countingRangeSource{}, store, opts); err == nil || !strings.Contains(err.Error(), "is not before end") {
+		t.Errorf("Expected an empty range to be rejected, got %v", err)
+	}
+}
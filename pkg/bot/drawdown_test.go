@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDrawdownHighWaterMark(t *testing.T) {
+	t.Log("📉 Testing peak-to-trough drawdown tracking and the drawdown risk gate")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000)
+	executor.riskManager.MaxDrawdown = 0.05
+	var blocked []*EngineError
+	executor.SetErrorReporter(func(err *EngineError) { blocked = append(blocked, err) })
+
+	// 400 units long at 100: marks at 101 then 99.6 set the peak and the trough
+	buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	hold := &TradingSignal{Symbol: config.Symbol, Signal: Hold, Confidence: 0.9}
+	if err := executor.ExecuteSignal(buy, 100, 99.5); err != nil {
+		t.Fatalf("Failed to open long position: %v", err)
+	}
+	for _, price := range []float64{101, 99.6} {
+		if err := executor.ExecuteSignal(hold, price, 99.5); err != nil {
+			t.Fatalf("Failed to mark position at %.1f: %v", price, err)
+		}
+	}
+	stats := executor.GetStatus().Performance
+	if stats.PeakEquity != 10400 || math.Abs(stats.CurrentDrawdown-560.0/10400) > 1e-9 || stats.MaxDrawdown != stats.CurrentDrawdown {
+		t.Errorf("Expected a 10400 peak and a %.4f drawdown, got %+v", 560.0/10400, stats)
+	}
+
+	// Holds still manage the position in drawdown: the stop fires at 99.4
+	if err := executor.ExecuteSignal(hold, 99.4, 99.5); err != nil {
+		t.Fatalf("Failed to mark position at 99.4: %v", err)
+	}
+	if executor.GetCurrentPosition() != nil {
+		t.Fatal("Expected the stop to close the position despite the drawdown")
+	}
+	stats = executor.GetStatus().Performance
+	if want := 640.0 / 10400; math.Abs(stats.MaxDrawdown-want) > 1e-9 || math.Abs(stats.CurrentDrawdown-want) > 1e-9 {
+		t.Errorf("Expected a realized %.4f drawdown, got max %.4f current %.4f", want, stats.MaxDrawdown, stats.CurrentDrawdown)
+	}
+
+	// Past the 5% limit, new entries are refused
+	if err := executor.ExecuteSignal(buy, 100, 99.5); err != nil {
+		t.Fatalf("Unexpected error from a blocked entry: %v", err)
+	}
+	if executor.GetCurrentPosition() != nil || len(blocked) == 0 {
+		t.Errorf("Expected the drawdown limit to block the entry (reported %d)", len(blocked))
+	}
+
+	// Resetting stats restarts the high-water mark from current equity
+	if reset := executor.ResetStats(); reset.PeakEquity != 9760 || reset.MaxDrawdown != 0 {
+		t.Errorf("Expected the high-water mark to restart at 9760, got %+v", reset)
+	}
+}
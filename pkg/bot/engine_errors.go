@@ -0,0 +1,167 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrorKind classifies engine errors
+type ErrorKind string
+
+// Engine error kinds
+const (
+	ErrDataStale      ErrorKind = "DATA_STALE"       // Latest candles are older than expected
+	ErrProviderDown   ErrorKind = "PROVIDER_DOWN"    // Exchange/data provider requests failing
+	ErrIndicatorError ErrorKind = "INDICATOR_ERROR"  // Signal generation failed on the data we have
+	ErrRiskBlocked    ErrorKind = "RISK_BLOCKED"     // Risk management rejected a trade
+	ErrLiquidation    ErrorKind = "LIQUIDATION_RISK" // Open position is close to its liquidation price
+)
+
+// Error severities (match notifier levels)
+const (
+	SeverityInfo     = "INFO"
+	SeverityWarning  = "WARNING"
+	SeverityCritical = "CRITICAL"
+)
+
+// EngineError is a classified error from the signal engine or trading pipeline
+type EngineError struct {
+	Kind     ErrorKind
+	Severity string
+	Source   string // Component that raised it, e.g. "timeframes", "aggregator", "risk"
+	Err      error
+	Time     time.Time
+}
+
+// NewEngineError creates a classified error
+func NewEngineError(kind ErrorKind, severity, source string, err error) *EngineError {
+	return &EngineError{Kind: kind, Severity: severity, Source: source, Err: err, Time: time.Now()}
+}
+
+// Error implements error
+func (e *EngineError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Kind, e.Source, e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *EngineError) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError returns err as an EngineError, treating unclassified errors as provider failures
+func ClassifyError(err error) *EngineError {
+	var engineErr *EngineError
+	if errors.As(err, &engineErr) {
+		return engineErr
+	}
+	return NewEngineError(ErrProviderDown, SeverityWarning, "unknown", err)
+}
+
+// ErrorRecord is a recorded engine error as exposed by the API
+type ErrorRecord struct {
+	Kind     ErrorKind `json:"kind"`
+	Severity string    `json:"severity"`
+	Source   string    `json:"source"`
+	Message  string    `json:"message"`
+	Time     time.Time `json:"time"`
+}
+
+// ErrorStats counts errors by kind and severity since startup
+type ErrorStats struct {
+	Total      int               `json:"total"`
+	ByKind     map[ErrorKind]int `json:"by_kind"`
+	BySeverity map[string]int    `json:"by_severity"`
+	LastError  *ErrorRecord      `json:"last_error,omitempty"`
+}
+
+// ErrorLog keeps the most recent engine errors and running counts
+type ErrorLog struct {
+	records    []ErrorRecord
+	maxRecords int
+	stats      ErrorStats
+	alerted    map[ErrorKind]time.Time // Last notification per kind
+	mutex      sync.RWMutex
+}
+
+// NewErrorLog creates an error log retaining up to maxRecords errors
+func NewErrorLog(maxRecords int) *ErrorLog {
+	return &ErrorLog{
+		records:    make([]ErrorRecord, 0, maxRecords),
+		maxRecords: maxRecords,
+		stats:      ErrorStats{ByKind: make(map[ErrorKind]int), BySeverity: make(map[string]int)},
+		alerted:    make(map[ErrorKind]time.Time),
+	}
+}
+
+// Record stores a classified error
+func (el *ErrorLog) Record(err *EngineError) ErrorRecord {
+	record := ErrorRecord{
+		Kind:     err.Kind,
+		Severity: err.Severity,
+		Source:   err.Source,
+		Message:  err.Err.Error(),
+		Time:     err.Time,
+	}
+
+	el.mutex.Lock()
+	defer el.mutex.Unlock()
+
+	el.records = append(el.records, record)
+	if len(el.records) > el.maxRecords {
+		el.records = el.records[len(el.records)-el.maxRecords:]
+	}
+	el.stats.Total++
+	el.stats.ByKind[record.Kind]++
+	el.stats.BySeverity[record.Severity]++
+	el.stats.LastError = &record
+	return record
+}
+
+// ShouldAlert reports whether a notification for kind is due, allowing one per interval
+func (el *ErrorLog) ShouldAlert(kind ErrorKind, now time.Time, interval time.Duration) bool {
+	el.mutex.Lock()
+	defer el.mutex.Unlock()
+
+	if last, ok := el.alerted[kind]; ok && now.Sub(last) < interval {
+		return false
+	}
+	el.alerted[kind] = now
+	return true
+}
+
+// Recent returns up to limit errors, newest first, optionally filtered by kind and/or severity
+func (el *ErrorLog) Recent(limit int, kind ErrorKind, severity string) []ErrorRecord {
+	el.mutex.RLock()
+	defer el.mutex.RUnlock()
+
+	records := make([]ErrorRecord, 0)
+	for i := len(el.records) - 1; i >= 0 && (limit <= 0 || len(records) < limit); i-- {
+		record := el.records[i]
+		if (kind == "" || record.Kind == kind) && (severity == "" || record.Severity == severity) {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// Stats returns error counts since startup
+func (el *ErrorLog) Stats() ErrorStats {
+	el.mutex.RLock()
+	defer el.mutex.RUnlock()
+
+	stats := ErrorStats{
+		Total:      el.stats.Total,
+		ByKind:     make(map[ErrorKind]int, len(el.stats.ByKind)),
+		BySeverity: make(map[string]int, len(el.stats.BySeverity)),
+		LastError:  el.stats.LastError,
+	}
+	for kind, count := range el.stats.ByKind {
+		stats.ByKind[kind] = count
+	}
+	for severity, count := range el.stats.BySeverity {
+		stats.BySeverity[severity] = count
+	}
+	return stats
+}
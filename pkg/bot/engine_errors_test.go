@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEngineErrorTaxonomy(t *testing.T) {
+	t.Log("🧯 Testing typed engine errors, error log and alerts")
+
+	// Classified errors survive wrapping; plain errors default to provider failures
+	stale := NewEngineError(ErrDataStale, SeverityCritical, "timeframes", fmt.Errorf("latest 5m candle closed 1h ago"))
+	if got := ClassifyError(fmt.Errorf("wrapped: %w", stale)); got != stale {
+		t.Errorf("Expected wrapped EngineError to be unwrapped, got %+v", got)
+	}
+	if got := ClassifyError(errors.New("connection reset")); got.Kind != ErrProviderDown || got.Severity != SeverityWarning {
+		t.Errorf("Expected unclassified error to be PROVIDER_DOWN/WARNING, got %s/%s", got.Kind, got.Severity)
+	}
+
+	// The log keeps the newest records and filters by kind/severity
+	log := NewErrorLog(3)
+	for i := 0; i < 4; i++ {
+		log.Record(NewEngineError(ErrIndicatorError, SeverityWarning, "aggregator", fmt.Errorf("failure %d", i)))
+	}
+	log.Record(stale)
+	recent := log.Recent(10, "", "")
+	if len(recent) != 3 || recent[0].Kind != ErrDataStale || recent[2].Message != "failure 2" {
+		t.Errorf("Expected the 3 newest records newest first, got %+v", recent)
+	}
+	if filtered := log.Recent(10, ErrIndicatorError, ""); len(filtered) != 2 {
+		t.Errorf("Expected 2 INDICATOR_ERROR records, got %d", len(filtered))
+	}
+	stats := log.Stats()
+	if stats.Total != 5 || stats.ByKind[ErrIndicatorError] != 4 || stats.BySeverity[SeverityCritical] != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	// Critical errors notify once per throttle interval; provider errors feed outage detection
+	config := DefaultConfig()
+	config.SafeMode.FailureThreshold = 5
+	tb := NewTradingBot(config)
+	notifier := &recordingNotifier{}
+	tb.notifiers = []Notifier{notifier}
+
+	tb.recordEngineError(NewEngineError(ErrDataStale, SeverityCritical, "timeframes", fmt.Errorf("stale")))
+	tb.recordEngineError(NewEngineError(ErrDataStale, SeverityCritical, "timeframes", fmt.Errorf("still stale")))
+	if len(notifier.levels) != 1 || notifier.levels[0] != SeverityCritical {
+		t.Errorf("Expected exactly one CRITICAL alert, got %v", notifier.levels)
+	}
+	if failures := tb.GetSafeModeStatus().RecentFailures; failures != 2 {
+		t.Errorf("Stale data should count towards safe mode, got %d failures", failures)
+	}
+
+	tb.recordEngineError(NewEngineError(ErrIndicatorError, SeverityWarning, "aggregator", fmt.Errorf("bad candles")))
+	if got := tb.GetRecentErrors(1, "", ""); len(got) != 1 || got[0].Kind != ErrIndicatorError {
+		t.Errorf("Expected newest error to be INDICATOR_ERROR, got %+v", got)
+	}
+
+	// Risk limits report RISK_BLOCKED through the executor
+	tb.tradeExecutor.riskManager.DailyLossUsed = 1
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Timestamp: time.Now()}
+	if err := tb.tradeExecutor.ExecuteSignal(signal, 100.0, 98.0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if blocked := tb.GetRecentErrors(10, ErrRiskBlocked, ""); len(blocked) != 1 {
+		t.Errorf("Expected one RISK_BLOCKED error, got %d", len(blocked))
+	}
+}
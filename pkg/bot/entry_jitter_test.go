@@ -0,0 +1,55 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEntryJitter(t *testing.T) {
+	t.Log("🎲 Testing randomized entry timing and slippage backtests")
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	config.Determinism.Enabled = true
+	candles, start, end, err := NewTradingBot(config).LoadBacktestCandles(1)
+	if err != nil {
+		t.Fatalf("Failed to load candles: %v", err)
+	}
+	start = end.Add(-12 * time.Hour)
+
+	// Jittered entries fill within the neighbouring closes, moved against the trade
+	bt := NewBacktester(config, 10000)
+	bt.SetEntryJitter(config.Determinism.Rand(), 50)
+	five := []Candle{{Close: 100}, {Close: 101}, {Close: 102}}
+	for i := 0; i < 50; i++ {
+		if buy := bt.jitteredEntryPrice(five, 1, Buy); buy < 100 || buy > 102*1.005 {
+			t.Fatalf("Buy fill %.4f outside 100-%.4f", buy, 102*1.005)
+		}
+		if sell := bt.jitteredEntryPrice(five, 1, Sell); sell < 100*0.995 || sell > 102 {
+			t.Fatalf("Sell fill %.4f outside %.4f-102", sell, 100*0.995)
+		}
+	}
+
+	report, err := RunEntryJitterTest(config, 10000, candles, start, end, 3, 20)
+	if err != nil {
+		t.Fatalf("Entry jitter test failed: %v", err)
+	}
+	if report.Runs != 3 || len(report.Returns) != 3 {
+		t.Fatalf("Expected 3 runs, got %d with %d returns", report.Runs, len(report.Returns))
+	}
+	if report.WorstReturn > report.MedianReturn || report.MedianReturn > report.BestReturn ||
+		report.MeanReturn < report.WorstReturn || report.MeanReturn > report.BestReturn {
+		t.Errorf("Inconsistent return stats %+v", report)
+	}
+
+	// Determinism makes the runs reproducible
+	again, err := RunEntryJitterTest(config, 10000, candles, start, end, 3, 20)
+	if err != nil || !reflect.DeepEqual(again.Returns, report.Returns) {
+		t.Errorf("Expected identical runs, got %v and %v (err %v)", report.Returns, again.Returns, err)
+	}
+
+	if _, err := RunEntryJitterTest(config, 10000, candles, start, end, 0, 20); err == nil {
+		t.Error("Expected zero runs to be rejected")
+	}
+}
@@ -0,0 +1,243 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/segmentio/kafka-go"
+)
+
+// Event export backends
+const (
+	ExportBackendKafka = "kafka"
+	ExportBackendNATS  = "nats"
+)
+
+// EventSink delivers a payload to a topic and returns only once the broker has acknowledged it
+type EventSink interface {
+	Send(ctx context.Context, topic, key string, payload []byte) error
+	Close() error
+}
+
+// EventExportStatus reports exporter progress
+type EventExportStatus struct {
+	Enabled     bool      `json:"enabled"`
+	Backend     string    `json:"backend,omitempty"`
+	Exported    int64     `json:"exported"`
+	Retries     int64     `json:"retries"`
+	Dropped     int64     `json:"dropped"` // Oldest events discarded because the pending queue was full
+	Pending     int       `json:"pending"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastSuccess time.Time `json:"last_success,omitempty"`
+}
+
+// EventExporter streams bus events to Kafka or NATS with at-least-once delivery:
+// each event is retried until the broker acknowledges it, in publish order
+type EventExporter struct {
+	config  EventExportConfig
+	sink    EventSink
+	pending []Event
+	status  EventExportStatus
+	wake    chan struct{}
+	mutex   sync.Mutex
+}
+
+// NewEventExporter creates an exporter delivering to sink
+func NewEventExporter(config EventExportConfig, sink EventSink) *EventExporter {
+	return &EventExporter{
+		config:  config,
+		sink:    sink,
+		pending: make([]Event, 0),
+		status:  EventExportStatus{Enabled: true, Backend: config.Backend},
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// NewEventSink connects to the configured backend
+func NewEventSink(config EventExportConfig) (EventSink, error) {
+	switch config.Backend {
+	case ExportBackendKafka:
+		return newKafkaSink(config), nil
+	case ExportBackendNATS:
+		return newNATSSink(config)
+	default:
+		return nil, fmt.Errorf("unknown event export backend: %s", config.Backend)
+	}
+}
+
+// Start queues events from the bus and delivers them until ctx is cancelled
+func (ee *EventExporter) Start(ctx context.Context, events <-chan Event) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-events:
+				ee.enqueue(event)
+			}
+		}
+	}()
+
+	go func() {
+		defer ee.sink.Close()
+		backoff := time.Duration(ee.config.RetryBackoffMs) * time.Millisecond
+		for {
+			for ee.DeliverPending(ctx) != nil {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				if backoff < 30*time.Second {
+					backoff *= 2
+				}
+			}
+			backoff = time.Duration(ee.config.RetryBackoffMs) * time.Millisecond
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ee.wake:
+			}
+		}
+	}()
+}
+
+// enqueue adds an event to the pending queue, discarding the oldest when full
+func (ee *EventExporter) enqueue(event Event) {
+	if ee.config.Topics[string(event.Type)] == "" {
+		return
+	}
+
+	ee.mutex.Lock()
+	if ee.config.MaxPending > 0 && len(ee.pending) >= ee.config.MaxPending {
+		ee.pending = ee.pending[1:]
+		ee.status.Dropped++
+	}
+	ee.pending = append(ee.pending, event)
+	ee.mutex.Unlock()
+
+	select {
+	case ee.wake <- struct{}{}:
+	default:
+	}
+}
+
+// DeliverPending sends queued events in order, stopping at the first failure so it is retried
+func (ee *EventExporter) DeliverPending(ctx context.Context) error {
+	for {
+		ee.mutex.Lock()
+		if len(ee.pending) == 0 {
+			ee.mutex.Unlock()
+			return nil
+		}
+		event := ee.pending[0]
+		ee.mutex.Unlock()
+
+		topic, payload, err := eventMessage(ee.config.Topics, event)
+		if err != nil {
+			notifyLog.Warn("Failed to marshal event, skipping export", "event", event.Type, "error", err)
+			ee.ack(event, time.Now())
+			continue
+		}
+
+		if err := ee.sink.Send(ctx, topic, event.ID, payload); err != nil {
+			ee.mutex.Lock()
+			ee.status.Retries++
+			ee.status.LastError = err.Error()
+			ee.mutex.Unlock()
+			notifyLog.Warn("Event export failed, will retry", "topic", topic, "error", err)
+			return err
+		}
+		ee.ack(event, time.Now())
+	}
+}
+
+// ack removes a delivered event from the head of the queue
+func (ee *EventExporter) ack(event Event, now time.Time) {
+	ee.mutex.Lock()
+	defer ee.mutex.Unlock()
+
+	// The head may have been discarded by enqueue while we were sending
+	if len(ee.pending) > 0 && ee.pending[0].ID == event.ID {
+		ee.pending = ee.pending[1:]
+	}
+	ee.status.Exported++
+	ee.status.LastSuccess = now
+}
+
+// GetStatus returns exporter counters
+func (ee *EventExporter) GetStatus() EventExportStatus {
+	ee.mutex.Lock()
+	defer ee.mutex.Unlock()
+
+	status := ee.status
+	status.Pending = len(ee.pending)
+	return status
+}
+
+// kafkaSink writes to Kafka, waiting for all in-sync replicas to acknowledge
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+// newKafkaSink creates a synchronous Kafka writer; topics come from each message
+func newKafkaSink(config EventExportConfig) *kafkaSink {
+	return &kafkaSink{writer: &kafka.Writer{
+		Addr:         kafka.TCP(config.Brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		MaxAttempts:  1, // Retries are handled by the exporter
+		WriteTimeout: 10 * time.Second,
+	}}
+}
+
+// Send writes one message keyed by event ID
+func (ks *kafkaSink) Send(ctx context.Context, topic, key string, payload []byte) error {
+	if err := ks.writer.WriteMessages(ctx, kafka.Message{Topic: topic, Key: []byte(key), Value: payload}); err != nil {
+		return fmt.Errorf("kafka write to %s failed: %w", topic, err)
+	}
+	return nil
+}
+
+// Close flushes and closes the writer
+func (ks *kafkaSink) Close() error {
+	return ks.writer.Close()
+}
+
+// natsSink publishes to NATS JetStream, whose acks confirm the event was stored
+type natsSink struct {
+	conn      *nats.Conn
+	jetStream nats.JetStreamContext
+}
+
+// newNATSSink connects to NATS; subjects must be bound to a JetStream stream
+func newNATSSink(config EventExportConfig) (*natsSink, error) {
+	conn, err := nats.Connect(strings.Join(config.Brokers, ","), nats.Name("nexus-bot"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	jetStream, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open JetStream context: %w", err)
+	}
+	return &natsSink{conn: conn, jetStream: jetStream}, nil
+}
+
+// Send publishes one message, using the event ID for JetStream de-duplication
+func (ns *natsSink) Send(ctx context.Context, topic, key string, payload []byte) error {
+	if _, err := ns.jetStream.Publish(topic, payload, nats.MsgId(key), nats.Context(ctx)); err != nil {
+		return fmt.Errorf("nats publish to %s failed: %w", topic, err)
+	}
+	return nil
+}
+
+// Close drains the connection
+func (ns *natsSink) Close() error {
+	return ns.conn.Drain()
+}
@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// flakySink fails its first sends and records delivered event IDs
+type flakySink struct {
+	failures  int
+	delivered []string
+	topics    []string
+}
+
+func (fs *flakySink) Send(_ context.Context, topic, key string, _ []byte) error {
+	if fs.failures > 0 {
+		fs.failures--
+		return fmt.Errorf("broker unavailable")
+	}
+	fs.delivered = append(fs.delivered, key)
+	fs.topics = append(fs.topics, topic)
+	return nil
+}
+
+func (fs *flakySink) Close() error { return nil }
+
+func TestEventExportAtLeastOnce(t *testing.T) {
+	t.Log("📤 Testing Kafka/NATS event export retry and ordering")
+
+	config := DefaultConfig().EventExport
+	config.Enabled = true
+	config.MaxPending = 3
+	delete(config.Topics, string(EventPrediction))
+
+	sink := &flakySink{failures: 2}
+	exporter := NewEventExporter(config, sink)
+
+	events := []Event{
+		{ID: "1", Type: EventSignal},
+		{ID: "2", Type: EventPrediction}, // Not exported: no topic
+		{ID: "3", Type: EventTrade},
+		{ID: "4", Type: EventError},
+	}
+	for _, event := range events {
+		exporter.enqueue(event)
+	}
+
+	// Broker down: events stay queued in order
+	for i := 0; i < 2; i++ {
+		if err := exporter.DeliverPending(context.Background()); err == nil {
+			t.Fatalf("Expected delivery attempt %d to fail", i+1)
+		}
+	}
+	status := exporter.GetStatus()
+	if status.Pending != 3 || status.Retries != 2 || status.Exported != 0 {
+		t.Fatalf("Expected 3 pending / 2 retries / 0 exported, got %+v", status)
+	}
+
+	// Broker back: everything is delivered once, oldest first
+	if err := exporter.DeliverPending(context.Background()); err != nil {
+		t.Fatalf("Unexpected delivery error: %v", err)
+	}
+	if fmt.Sprint(sink.delivered) != "[1 3 4]" {
+		t.Errorf("Expected events 1, 3, 4 in order, got %v", sink.delivered)
+	}
+	if sink.topics[2] != "nexus-bot.errors" {
+		t.Errorf("Expected error events on nexus-bot.errors, got %s", sink.topics[2])
+	}
+	if status := exporter.GetStatus(); status.Pending != 0 || status.Exported != 3 {
+		t.Errorf("Expected empty queue after delivery, got %+v", status)
+	}
+
+	// A full queue sheds the oldest event
+	for i := 5; i <= 8; i++ {
+		exporter.enqueue(Event{ID: fmt.Sprint(i), Type: EventSignal})
+	}
+	if status := exporter.GetStatus(); status.Pending != 3 || status.Dropped != 1 {
+		t.Errorf("Expected 3 pending and 1 dropped, got %+v", status)
+	}
+
+	full := DefaultConfig()
+	full.EventExport.Enabled = true
+	full.EventExport.Backend = "rabbitmq"
+	if err := ValidateConfig(full); err == nil {
+		t.Errorf("Expected unknown backend to fail validation")
+	}
+}
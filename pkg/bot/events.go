@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EventType identifies what an Event carries
+type EventType string
+
+// Bus event types
+const (
+	EventSignal     EventType = "signal"     // *TradingSignal from the engine
+	EventTrade      EventType = "trade"      // *Trade closed by the executor
+	EventPosition   EventType = "position"   // *Position marked to market after a signal
+	EventPrediction EventType = "prediction" // Prediction served by the API
+	EventError      EventType = "error"      // ErrorRecord reported by the engine or executor
+)
+
+// ValidEventType reports whether name is a known event type
+func ValidEventType(name string) bool {
+	switch EventType(name) {
+	case EventSignal, EventTrade, EventPosition, EventPrediction, EventError:
+		return true
+	}
+	return false
+}
+
+// Event is a message on the bot's internal event bus
+type Event struct {
+	ID        string      `json:"id"`
+	Type      EventType   `json:"type"`
+	Symbol    string      `json:"symbol"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// eventSubscriber is a named consumer of bus events
+type eventSubscriber struct {
+	name    string
+	channel chan Event
+}
+
+// EventBus fans events out to subscribers without blocking publishers;
+// events are dropped for subscribers whose buffer is full
+type EventBus struct {
+	subscribers []eventSubscriber
+	sequence    uint64
+	mutex       sync.RWMutex
+}
+
+// NewEventBus creates an empty event bus
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make([]eventSubscriber, 0)}
+}
+
+// Subscribe returns a channel receiving every event published from now on
+func (eb *EventBus) Subscribe(name string, buffer int) <-chan Event {
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+
+	channel := make(chan Event, buffer)
+	eb.subscribers = append(eb.subscribers, eventSubscriber{name: name, channel: channel})
+	return channel
+}
+
+// Unsubscribe stops delivery to a channel returned by Subscribe and closes it
+func (eb *EventBus) Unsubscribe(channel <-chan Event) {
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+
+	for i, subscriber := range eb.subscribers {
+		if subscriber.channel == channel {
+			close(subscriber.channel)
+			eb.subscribers = append(eb.subscribers[:i], eb.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish sends an event to all subscribers
+func (eb *EventBus) Publish(eventType EventType, symbol string, data interface{}) {
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+
+	eb.sequence++
+	now := time.Now()
+	event := Event{
+		ID:        fmt.Sprintf("evt_%d_%d", now.UnixNano(), eb.sequence),
+		Type:      eventType,
+		Symbol:    symbol,
+		Timestamp: now,
+		Data:      data,
+	}
+
+	for _, subscriber := range eb.subscribers {
+		select {
+		case subscriber.channel <- event:
+		default:
+			notifyLog.Warn("Event bus subscriber full, dropping event", "subscriber", subscriber.name, "event", eventType)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestEventBusPublishing(t *testing.T) {
+	t.Log("📡 Testing event bus fan-out and MQTT topic mapping")
+
+	config := DefaultConfig()
+	bus := NewEventBus()
+	first := bus.Subscribe("first", 10)
+	second := bus.Subscribe("second", 1)
+
+	bus.Publish(EventSignal, config.Symbol, &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.8})
+	bus.Publish(EventPrediction, config.Symbol, map[string]string{"prediction": "HIGHER"}) // Dropped for "second"
+
+	if len(first) != 2 || len(second) != 1 {
+		t.Fatalf("Expected 2 and 1 buffered events, got %d and %d", len(first), len(second))
+	}
+	event := <-first
+	if event.Type != EventSignal || event.Symbol != config.Symbol || event.ID == "" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+
+	topic, payload, err := eventMessage(config.MQTT.Topics, event)
+	if err != nil {
+		t.Fatalf("Failed to build MQTT message: %v", err)
+	}
+	if topic != "nexus-bot/signals" {
+		t.Errorf("Expected signal topic nexus-bot/signals, got %s", topic)
+	}
+	var decoded struct {
+		Type string `json:"type"`
+		Data struct {
+			Signal SignalType `json:"signal"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded.Type != "signal" || decoded.Data.Signal != Buy {
+		t.Errorf("Unexpected payload %s (err %v)", payload, err)
+	}
+
+	delete(config.MQTT.Topics, string(EventPrediction))
+	if topic, _, _ := eventMessage(config.MQTT.Topics, Event{Type: EventPrediction}); topic != "" {
+		t.Errorf("Expected unmapped prediction events to be skipped, got topic %s", topic)
+	}
+
+	config.MQTT.Topics["candles"] = "nexus-bot/candles"
+	if err := ValidateConfig(config); err == nil {
+		t.Errorf("Expected unknown MQTT topic event type to fail validation")
+	}
+
+	// Closed trades reach the executor's observer
+	executor := NewTradeExecutor(DefaultConfig(), 10000.0)
+	var closed []*Trade
+	executor.SetTradeObserver(func(trade *Trade) { closed = append(closed, trade) })
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Timestamp: time.Now()}
+	if err := executor.ExecuteSignal(signal, 100.0, 99.0); err != nil {
+		t.Fatalf("Entry failed: %v", err)
+	}
+	if err := executor.ForceClosePosition(101.0); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if len(closed) != 1 || closed[0].PnL <= 0 {
+		t.Errorf("Expected one profitable closed trade observed, got %d", len(closed))
+	}
+}
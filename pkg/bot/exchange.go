@@ -0,0 +1,305 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// exchangeNames are the venues that can be selected with data_provider
+var exchangeNames = []string{"binance", "coinbase", "kraken", "bybit"}
+
+// Exchange is a trading venue: candles (historical, ranged and real-time),
+// ticker, order book and account balances
+type Exchange interface {
+	DataProvider
+	RangeDataProvider
+	Name() string
+	GetTicker(symbol string) (*Ticker, error)
+	GetOrderBook(symbol string, depth int) (*OrderBook, error)
+	GetBalances() (map[string]float64, error) // Total balance per asset; requires API keys
+}
+
+// Ticker is a venue's latest trade and top of book
+type Ticker struct {
+	Symbol    string    `json:"symbol"`
+	Last      float64   `json:"last"`
+	Bid       float64   `json:"bid"`
+	Ask       float64   `json:"ask"`
+	Volume    float64   `json:"volume"` // 24h base volume
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// OrderBookLevel is the resting quantity at one price
+type OrderBookLevel struct {
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+}
+
+// OrderBook holds the best bids (highest first) and asks (lowest first)
+type OrderBook struct {
+	Symbol    string           `json:"symbol"`
+	Bids      []OrderBookLevel `json:"bids"`
+	Asks      []OrderBookLevel `json:"asks"`
+	Timestamp time.Time        `json:"timestamp"`
+}
+
+// IsExchange reports whether name is a venue selectable as data_provider
+func IsExchange(name string) bool {
+	for _, exchange := range exchangeNames {
+		if exchange == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewExchange creates the named venue with its configured API keys
+func NewExchange(name string, config Config) (Exchange, error) {
+	switch name {
+	case "binance":
+		return NewBinanceFuturesDataProvider(config.Binance.APIKey, config.Binance.SecretKey), nil
+	case "coinbase":
+		return NewCoinbaseExchange(config.Coinbase), nil
+	case "kraken":
+		return NewKrakenExchange(config.Kraken), nil
+	case "bybit":
+		return NewBybitExchange(config.Bybit), nil
+	default:
+		return nil, fmt.Errorf("unknown exchange: %s", name)
+	}
+}
+
+// doExchangeRequest sends a request and decodes the JSON response
+func doExchangeRequest(httpClient *http.Client, req *http.Request, out interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// parseFloats parses numeric strings, naming the first one that fails
+func parseFloats(values []string, names ...string) ([]float64, error) {
+	if len(values) < len(names) {
+		return nil, fmt.Errorf("expected %d fields, got %d", len(names), len(values))
+	}
+	parsed := make([]float64, len(names))
+	for i, name := range names {
+		value, err := strconv.ParseFloat(values[i], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		parsed[i] = value
+	}
+	return parsed, nil
+}
+
+// parseBookLevels converts the first depth [price, quantity, ...] rows into levels
+func parseBookLevels(rows [][]interface{}, depth int) ([]OrderBookLevel, error) {
+	if depth > 0 && len(rows) > depth {
+		rows = rows[:depth]
+	}
+	levels := make([]OrderBookLevel, 0, len(rows))
+	for _, row := range rows {
+		values, err := parseFloats(jsonStrings(row), "price", "quantity")
+		if err != nil {
+			return nil, err
+		}
+		levels = append(levels, OrderBookLevel{Price: values[0], Quantity: values[1]})
+	}
+	return levels, nil
+}
+
+// jsonStrings converts a row of mixed JSON numbers and strings to strings
+func jsonStrings(row []interface{}) []string {
+	values := make([]string, len(row))
+	for i, value := range row {
+		switch v := value.(type) {
+		case string:
+			values[i] = v
+		case float64:
+			values[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return values
+}
+
+// aggregateCandles merges candles into buckets of timeframe, for venues that
+// don't offer it natively; candles must be sorted oldest first
+func aggregateCandles(candles []Candle, timeframe Timeframe) []Candle {
+	aggregated := make([]Candle, 0)
+	for _, candle := range candles {
+		bucket := candleOpenTime(candle.Timestamp, timeframe, klineEpoch)
+		if n := len(aggregated); n > 0 && aggregated[n-1].Timestamp.Equal(bucket) {
+			last := &aggregated[n-1]
+			if candle.High > last.High {
+				last.High = candle.High
+			}
+			if candle.Low < last.Low {
+				last.Low = candle.Low
+			}
+			last.Close = candle.Close
+			last.Volume += candle.Volume
+			continue
+		}
+		candle.Timestamp = bucket
+		aggregated = append(aggregated, candle)
+	}
+	return aggregated
+}
+
+// sortCandles orders candles oldest first and drops duplicate timestamps
+func sortCandles(candles []Candle) []Candle {
+	sort.Slice(candles, func(i, j int) bool { return candles[i].Timestamp.Before(candles[j].Timestamp) })
+	unique := candles[:0]
+	for _, candle := range candles {
+		if n := len(unique); n > 0 && unique[n-1].Timestamp.Equal(candle.Timestamp) {
+			continue
+		}
+		unique = append(unique, candle)
+	}
+	return unique
+}
+
+// candlePoller serves real-time candles by polling a venue's latest klines, for
+// venues whose streams the bot doesn't speak
+type candlePoller struct {
+	pollInterval time.Duration
+	stopChan     chan struct{}
+	stopped      bool
+	clock        Clock // Drives polling and decides which candles are complete
+}
+
+// newCandlePoller creates a poller checking for new candles every pollInterval
+func newCandlePoller(pollInterval time.Duration) *candlePoller {
+	return &candlePoller{pollInterval: pollInterval, stopChan: make(chan struct{}), clock: SystemClock}
+}
+
+// SetClock replaces the time source of the venue's polls and range requests;
+// call before requesting data
+func (p *candlePoller) SetClock(clock Clock) {
+	p.clock = clock
+}
+
+// poll emits the latest completed candle from fetch whenever it changes
+func (p *candlePoller) poll(symbol string, timeframe Timeframe, fetch func(symbol string, timeframe Timeframe, count int) ([]Candle, error)) <-chan Candle {
+	candleChan := make(chan Candle, 10)
+
+	go func() {
+		defer close(candleChan)
+
+		ticker := p.clock.NewTicker(p.pollInterval)
+		defer ticker.Stop()
+
+		var lastEmitted time.Time
+		for {
+			select {
+			case <-p.stopChan:
+				return
+			case <-ticker.Chan():
+				// The final candle is still forming
+				candles, err := fetch(symbol, timeframe, 2)
+				if err != nil || len(candles) < 2 {
+					providerLog.Warn("Poll failed", "symbol", symbol, "timeframe", timeframe.String(), "error", err)
+					continue
+				}
+
+				completed := candles[len(candles)-2]
+				if !completed.Timestamp.After(lastEmitted) {
+					continue
+				}
+				lastEmitted = completed.Timestamp
+
+				select {
+				case candleChan <- completed:
+				case <-p.stopChan:
+					return
+				}
+			}
+		}
+	}()
+
+	return candleChan
+}
+
+// Close stops all polling loops
+func (p *candlePoller) Close() error {
+	if !p.stopped {
+		close(p.stopChan)
+		p.stopped = true
+	}
+	return nil
+}
+
+// venueInterval is how a venue serves a timeframe: its native interval
+// parameter and length, aggregated up to the timeframe when it has none
+type venueInterval struct {
+	param     string
+	length    time.Duration
+	aggregate bool
+}
+
+// fetchVenueRange pages fetch over [start, end) in chunks of at most maxCandles
+// native candles, aggregating them into timeframe when needed. Nothing is
+// requested past clock's current time.
+func fetchVenueRange(clock Clock, interval venueInterval, timeframe Timeframe, start, end time.Time, maxCandles int, fetch func(from, to time.Time) ([]Candle, error)) ([]Candle, error) {
+	candles := make([]Candle, 0)
+	now := clock.Now()
+	for cursor := start; cursor.Before(end) && cursor.Before(now); {
+		to := cursor.Add(time.Duration(maxCandles) * interval.length)
+		if to.After(end) {
+			to = end
+		}
+		page, err := fetch(cursor, to)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, page...)
+		cursor = to
+	}
+
+	candles = sortCandles(candles)
+	if interval.aggregate {
+		candles = aggregateCandles(candles, timeframe)
+	}
+	inRange := make([]Candle, 0, len(candles))
+	for _, candle := range candles {
+		if !candle.Timestamp.Before(start) && candle.Timestamp.Before(end) {
+			inRange = append(inRange, candle)
+		}
+	}
+	return inRange, nil
+}
+
+// latestVenueCandles returns the last count candles of timeframe as of clock's
+// current time, the forming one included
+func latestVenueCandles(ranged RangeDataProvider, clock Clock, symbol string, timeframe Timeframe, interval venueInterval, count int) ([]Candle, error) {
+	length := interval.length
+	if interval.aggregate {
+		length = timeframe.Duration()
+	}
+	now := clock.Now()
+	start := now.Truncate(length).Add(-time.Duration(count-1) * length)
+	candles, err := ranged.GetHistoricalRange(symbol, timeframe, start, now.Add(length))
+	if err != nil {
+		return nil, err
+	}
+	if len(candles) > count {
+		candles = candles[len(candles)-count:]
+	}
+	return candles, nil
+}
@@ -0,0 +1,154 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExchangeAdapters(t *testing.T) {
+	t.Log("🏦 Testing Coinbase, Kraken and Bybit adapters behind the Exchange interface")
+
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var body interface{}
+		switch r.URL.Path {
+		case "/products/BTC-USD/candles":
+			// Hourly candles over the requested window, newest first
+			start, _ := time.Parse(time.RFC3339, query.Get("start"))
+			end, _ := time.Parse(time.RFC3339, query.Get("end"))
+			rows := make([][]interface{}, 0)
+			for ts := end.Truncate(time.Hour); !ts.Before(start); ts = ts.Add(-time.Hour) {
+				price := float64(100 + ts.Sub(day).Hours())
+				rows = append(rows, []interface{}{ts.Unix(), price - 1, price + 1, price, price + 0.5, 10})
+			}
+			body = rows
+		case "/products/BTC-USD/ticker":
+			body = map[string]string{"price": "101.5", "bid": "101.4", "ask": "101.6", "volume": "1234"}
+		case "/products/BTC-USD/book":
+			body = map[string]interface{}{
+				"bids": [][]interface{}{{"101.4", "2", 3}, {"101.3", "1", 1}},
+				"asks": [][]interface{}{{"101.6", "1.5", 2}},
+			}
+		case "/0/public/OHLC":
+			if query.Get("pair") != "XBTUSD" || query.Get("interval") != "5" {
+				t.Errorf("Unexpected Kraken OHLC query %v", query)
+			}
+			body = map[string]interface{}{"error": []string{}, "result": map[string]interface{}{
+				"XXBTZUSD": [][]interface{}{{day.Unix(), "100", "102", "99", "101", "100.5", "7", 12}},
+				"last":     day.Unix(),
+			}}
+		case "/0/private/Balance":
+			if r.Header.Get("API-Key") != "kraken-key" || r.Header.Get("API-Sign") == "" {
+				t.Errorf("Expected a signed Kraken request, got headers %v", r.Header)
+			}
+			body = map[string]interface{}{"error": []string{}, "result": map[string]string{"XXBT": "0.5", "ZUSD": "1000", "ETH": "0"}}
+		case "/v5/market/tickers":
+			if query.Get("symbol") != "BTCUSDT" {
+				body = map[string]interface{}{"retCode": 10001, "retMsg": "symbol invalid"}
+				break
+			}
+			body = map[string]interface{}{"retCode": 0, "result": map[string]interface{}{
+				"list": []map[string]string{{"lastPrice": "101", "bid1Price": "100.9", "ask1Price": "101.1", "volume24h": "50"}},
+			}}
+		case "/v5/account/wallet-balance":
+			if r.Header.Get("X-BAPI-API-KEY") != "bybit-key" || len(r.Header.Get("X-BAPI-SIGN")) != 64 {
+				t.Errorf("Expected a signed Bybit request, got headers %v", r.Header)
+			}
+			body = map[string]interface{}{"retCode": 0, "result": map[string]interface{}{
+				"list": []map[string]interface{}{{"coin": []map[string]string{{"coin": "USDT", "walletBalance": "2500"}}}},
+			}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.Kraken = ExchangeCredentials{APIKey: "kraken-key", SecretKey: "c2VjcmV0"}
+	config.Bybit = ExchangeCredentials{APIKey: "bybit-key", SecretKey: "secret"}
+	exchanges := make(map[string]Exchange)
+	for _, name := range []string{"coinbase", "kraken", "bybit"} {
+		exchange, err := NewExchange(name, config)
+		if err != nil || exchange.Name() != name {
+			t.Fatalf("Expected the %s exchange, got %v (err %v)", name, exchange, err)
+		}
+		exchanges[name] = exchange
+	}
+	exchanges["coinbase"].(*CoinbaseExchange).baseURL = server.URL
+	exchanges["kraken"].(*KrakenExchange).baseURL = server.URL
+	exchanges["bybit"].(*BybitExchange).baseURL = server.URL
+
+	// Coinbase: 8h candles are built from hourly ones, paged past 300 per request
+	candles, err := exchanges["coinbase"].GetHistoricalRange("BTCUSDT", EightHour, day, day.Add(15*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Coinbase range failed: %v", err)
+	}
+	if len(candles) != 45 || !candles[1].Timestamp.Equal(day.Add(8*time.Hour)) {
+		t.Fatalf("Expected 45 aligned 8h candles, got %d starting %v", len(candles), candles[0].Timestamp)
+	}
+	if first := candles[0]; first.Open != 100 || first.Close != 107.5 || first.High != 108 || first.Low != 99 || first.Volume != 80 {
+		t.Errorf("Unexpected aggregated candle %+v", first)
+	}
+	ticker, err := exchanges["coinbase"].GetTicker("BTCUSDT")
+	if err != nil || ticker.Last != 101.5 || ticker.Bid != 101.4 || ticker.Ask != 101.6 {
+		t.Errorf("Unexpected Coinbase ticker %+v (err %v)", ticker, err)
+	}
+	book, err := exchanges["coinbase"].GetOrderBook("BTCUSDT", 1)
+	if err != nil || len(book.Bids) != 1 || book.Bids[0] != (OrderBookLevel{Price: 101.4, Quantity: 2}) || len(book.Asks) != 1 {
+		t.Errorf("Expected a 1-level Coinbase book, got %+v (err %v)", book, err)
+	}
+	if _, err := exchanges["coinbase"].GetBalances(); err == nil {
+		t.Error("Expected Coinbase balances to require credentials")
+	}
+
+	// Kraken: OHLC rows and asset codes are normalized
+	krakenCandles, err := exchanges["kraken"].GetHistoricalRange("BTCUSDT", FiveMinute, day, day.Add(time.Hour))
+	if err != nil || len(krakenCandles) != 1 || krakenCandles[0].Close != 101 || krakenCandles[0].Volume != 7 {
+		t.Errorf("Unexpected Kraken candles %+v (err %v)", krakenCandles, err)
+	}
+	balances, err := exchanges["kraken"].GetBalances()
+	if err != nil || balances["BTC"] != 0.5 || balances["USD"] != 1000 || len(balances) != 2 {
+		t.Errorf("Unexpected Kraken balances %v (err %v)", balances, err)
+	}
+
+	// Bybit: symbols map to linear perpetuals and API errors surface
+	if ticker, err := exchanges["bybit"].GetTicker("BTCUSD"); err != nil || ticker.Last != 101 {
+		t.Errorf("Unexpected Bybit ticker %+v (err %v)", ticker, err)
+	}
+	if _, err := exchanges["bybit"].GetTicker("ETHUSDT"); err == nil || !strings.Contains(err.Error(), "symbol invalid") {
+		t.Errorf("Expected the Bybit error message, got %v", err)
+	}
+	if balances, err := exchanges["bybit"].GetBalances(); err != nil || balances["USDT"] != 2500 {
+		t.Errorf("Unexpected Bybit balances %v (err %v)", balances, err)
+	}
+
+	// Venues are selected with data_provider
+	config.DataProvider = "bybit"
+	if err := ValidateConfig(config); err != nil {
+		t.Errorf("Expected bybit to be a valid data provider: %v", err)
+	}
+	config.DataProvider = "ftx"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "data_provider") {
+		t.Errorf("Expected an unknown data provider to be rejected, got %v", err)
+	}
+	if _, err := NewExchange("ftx", config); err == nil {
+		t.Error("Expected NewExchange to reject an unknown venue")
+	}
+
+	// Aggregated buckets open on the same boundaries as the candle builders'
+	hourly := make([]Candle, 0)
+	for hour := time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC); hour.Before(time.Date(2024, 1, 2, 2, 0, 0, 0, time.UTC)); hour = hour.Add(time.Hour) {
+		hourly = append(hourly, Candle{Timestamp: hour, Open: 100, High: 101, Low: 99, Close: 100, Volume: 1})
+	}
+	daily := aggregateCandles(hourly, Daily)
+	if len(daily) != 2 || !daily[1].Timestamp.Equal(candleOpenTime(hourly[3].Timestamp, Daily, klineEpoch)) || daily[1].Volume != 2 {
+		t.Errorf("Expected two daily buckets split at midnight, got %+v", daily)
+	}
+}
@@ -0,0 +1,142 @@
+package bot
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// executionHistorySize is how many fills the execution-quality stats cover
+const executionHistorySize = 1000
+
+// ExecutionRecord compares a fill with the price and time the decision was made at
+type ExecutionRecord struct {
+	OrderID       string    `json:"order_id"`
+	Symbol        string    `json:"symbol"`
+	Side          string    `json:"side"`   // "BUY" or "SELL"
+	Reason        string    `json:"reason"` // "ENTRY", or the exit reason
+	Quantity      float64   `json:"quantity"`
+	IntendedPrice float64   `json:"intended_price"` // Signal price, stop level or order limit price
+	FillPrice     float64   `json:"fill_price"`
+	SlippageBps   float64   `json:"slippage_bps"`  // Adverse move from the intended price, negative when price improved
+	SlippageCost  float64   `json:"slippage_cost"` // Quote currency lost to slippage
+	DecidedAt     time.Time `json:"decided_at"`
+	FilledAt      time.Time `json:"filled_at"`
+	LatencyMs     float64   `json:"latency_ms"` // Decision to fill
+}
+
+// ExecutionQuality aggregates slippage and fill latency over recent fills
+type ExecutionQuality struct {
+	Fills              int               `json:"fills"`
+	AverageSlippageBps float64           `json:"average_slippage_bps"`
+	WorstSlippageBps   float64           `json:"worst_slippage_bps"`
+	TotalSlippageCost  float64           `json:"total_slippage_cost"`
+	AverageLatencyMs   float64           `json:"average_latency_ms"`
+	P50LatencyMs       float64           `json:"p50_latency_ms"`
+	P95LatencyMs       float64           `json:"p95_latency_ms"`
+	MaxLatencyMs       float64           `json:"max_latency_ms"`
+	Recent             []ExecutionRecord `json:"recent"` // Newest first
+}
+
+// executionDecision is the price and time a signal was acted on
+type executionDecision struct {
+	price float64
+	at    time.Time
+}
+
+// newExecutionRecord scores a fill against its intended price and decision time
+func newExecutionRecord(orderID, symbol, side, reason string, quantity, intended, fill float64, decidedAt, filledAt time.Time) ExecutionRecord {
+	record := ExecutionRecord{
+		OrderID:       orderID,
+		Symbol:        symbol,
+		Side:          side,
+		Reason:        reason,
+		Quantity:      quantity,
+		IntendedPrice: intended,
+		FillPrice:     fill,
+		DecidedAt:     decidedAt,
+		FilledAt:      filledAt,
+		LatencyMs:     math.Max(float64(filledAt.Sub(decidedAt))/float64(time.Millisecond), 0),
+	}
+	if intended > 0 {
+		adverse := fill - intended
+		if side == "SELL" {
+			adverse = -adverse
+		}
+		record.SlippageBps = adverse / intended * 10000
+		record.SlippageCost = adverse * quantity
+	}
+	return record
+}
+
+// SummarizeExecutions aggregates fills, keeping the newest recent records
+func SummarizeExecutions(records []ExecutionRecord, recent int) ExecutionQuality {
+	quality := ExecutionQuality{Fills: len(records), Recent: make([]ExecutionRecord, 0)}
+	if len(records) == 0 {
+		return quality
+	}
+
+	latencies := make([]float64, 0, len(records))
+	quality.WorstSlippageBps = math.Inf(-1)
+	for _, record := range records {
+		quality.AverageSlippageBps += record.SlippageBps
+		quality.TotalSlippageCost += record.SlippageCost
+		quality.WorstSlippageBps = math.Max(quality.WorstSlippageBps, record.SlippageBps)
+		quality.AverageLatencyMs += record.LatencyMs
+		latencies = append(latencies, record.LatencyMs)
+	}
+	quality.AverageSlippageBps /= float64(len(records))
+	quality.AverageLatencyMs /= float64(len(records))
+
+	sort.Float64s(latencies)
+	quality.P50LatencyMs = percentile(latencies, 50)
+	quality.P95LatencyMs = percentile(latencies, 95)
+	quality.MaxLatencyMs = latencies[len(latencies)-1]
+
+	for i := len(records) - 1; i >= 0 && len(quality.Recent) < recent; i-- {
+		quality.Recent = append(quality.Recent, records[i])
+	}
+	return quality
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// recordExecution stores a fill for the execution-quality stats (assumes lock is held)
+func (te *TradeExecutor) recordExecution(orderID, side, reason string, quantity, intended, fill float64, decidedAt time.Time) {
+	te.executions.Add(newExecutionRecord(orderID, te.config.Symbol, side, reason, quantity, intended, fill, decidedAt, te.now()))
+}
+
+// recordExit scores an exit against the stop it triggered on or the signal that
+// closed it (assumes lock is held)
+func (te *TradeExecutor) recordExit(position *Position, reason string, exitPrice float64) {
+	// Order fills are scored as they arrive; reconciled closes weren't our orders
+	if reason == "FILL" || reason == "RECONCILED" {
+		return
+	}
+	side := "SELL"
+	if position.Side == "SHORT" {
+		side = "BUY"
+	}
+	intended, decidedAt := exitPrice, te.now()
+	if reason == "ATR_STOP" && position.ATRTrailStop > 0 {
+		intended = position.ATRTrailStop
+	} else if te.decision != nil {
+		intended, decidedAt = te.decision.price, te.decision.at
+	}
+	te.recordExecution(position.ID, side, reason, position.Quantity, intended, exitPrice, decidedAt)
+}
+
+// GetExecutionQuality returns slippage and latency stats with the newest recent fills
+func (te *TradeExecutor) GetExecutionQuality(recent int) ExecutionQuality {
+	return SummarizeExecutions(te.executions.All(), recent)
+}
@@ -0,0 +1,61 @@
+package bot
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestExecutionQuality(t *testing.T) {
+	t.Log("⏱️ Testing slippage and decision-to-fill latency stats")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000)
+	decided := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := decided.Add(250 * time.Millisecond)
+	executor.SetClock(ClockFunc(func() time.Time { return now }))
+
+	// Signal computed at 100, filled 250ms later at 100.1: 10 bps worse
+	buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Price: 100, Timestamp: decided}
+	if err := executor.ExecuteSignal(buy, 100.1, 99.5); err != nil {
+		t.Fatalf("Failed to open position: %v", err)
+	}
+	quantity := executor.GetCurrentPosition().Quantity
+
+	// Stop at 99.5 fills at 99.3 on the next mark
+	now = now.Add(time.Minute)
+	hold := &TradingSignal{Symbol: config.Symbol, Signal: Hold, Confidence: 0.9, Price: 99.3, Timestamp: now}
+	if err := executor.ExecuteSignal(hold, 99.3, 99.5); err != nil {
+		t.Fatalf("Failed to mark position: %v", err)
+	}
+	if executor.GetCurrentPosition() != nil {
+		t.Fatal("Expected the stop to close the position")
+	}
+
+	quality := executor.GetExecutionQuality(10)
+	if quality.Fills != 2 || len(quality.Recent) != 2 {
+		t.Fatalf("Expected 2 fills, got %d (%d recent)", quality.Fills, len(quality.Recent))
+	}
+	entry, exit := quality.Recent[1], quality.Recent[0]
+	if entry.Side != "BUY" || math.Abs(entry.SlippageBps-10) > 1e-6 || entry.LatencyMs != 250 {
+		t.Errorf("Expected a 10 bps, 250ms entry, got %+v", entry)
+	}
+	wantExit := 0.2 / 99.5 * 10000
+	if exit.Side != "SELL" || exit.Reason != "ATR_STOP" || exit.IntendedPrice != 99.5 || math.Abs(exit.SlippageBps-wantExit) > 1e-6 {
+		t.Errorf("Expected a %.2f bps stop exit, got %+v", wantExit, exit)
+	}
+	if math.Abs(quality.AverageSlippageBps-(10+wantExit)/2) > 1e-6 || math.Abs(quality.WorstSlippageBps-wantExit) > 1e-6 {
+		t.Errorf("Unexpected slippage stats: avg %.4f, worst %.4f", quality.AverageSlippageBps, quality.WorstSlippageBps)
+	}
+	if math.Abs(quality.TotalSlippageCost-(0.1+0.2)*quantity) > 1e-6 {
+		t.Errorf("Expected slippage cost %.4f, got %.4f", 0.3*quantity, quality.TotalSlippageCost)
+	}
+	if quality.P95LatencyMs != 250 || quality.P50LatencyMs != 0 {
+		t.Errorf("Expected p50 0ms and p95 250ms, got %.0f/%.0f", quality.P50LatencyMs, quality.P95LatencyMs)
+	}
+
+	// A better-than-intended fill counts as negative slippage
+	if record := newExecutionRecord("o", "BTCUSDT", "SELL", "ENTRY", 1, 100, 100.5, decided, decided); record.SlippageBps != -50 {
+		t.Errorf("Expected -50 bps price improvement, got %.2f", record.SlippageBps)
+	}
+}
@@ -0,0 +1,187 @@
+package bot
+
+import "time"
+
+// HedgePosition is a short perpetual position opened to offset long exposure
+type HedgePosition struct {
+	Symbol     string    `json:"symbol"`
+	Strategy   string    `json:"strategy"`
+	Quantity   float64   `json:"quantity"`
+	EntryPrice float64   `json:"entry_price"` // Volume-weighted average entry
+	OpenTime   time.Time `json:"open_time"`
+}
+
+// ExposureSnapshot summarizes account-wide market exposure in quote currency
+type ExposureSnapshot struct {
+	Long  float64 `json:"long"`  // Spot holdings plus long signal position
+	Short float64 `json:"short"` // Hedges plus short signal position
+	Net   float64 `json:"net"`
+}
+
+// openHedge adds to (or opens) the short hedge on a symbol; callers must hold the mutex
+func (te *TradeExecutor) openHedge(intent OrderIntent, quantity float64) {
+	hedge, exists := te.hedges[intent.Symbol]
+	if !exists {
+		te.hedges[intent.Symbol] = &HedgePosition{
+			Symbol:     intent.Symbol,
+			Strategy:   intent.Strategy,
+			Quantity:   quantity,
+			EntryPrice: intent.Price,
+			OpenTime:   te.now(),
+		}
+		return
+	}
+
+	total := hedge.Quantity + quantity
+	hedge.EntryPrice = (hedge.EntryPrice*hedge.Quantity + intent.Price*quantity) / total
+	hedge.Quantity = total
+}
+
+// coverHedge reduces a hedge and returns the realized PnL; callers must hold the mutex
+func (te *TradeExecutor) coverHedge(symbol string, quantity, price float64) float64 {
+	hedge := te.hedges[symbol]
+	pnl := (hedge.EntryPrice - price) * quantity
+
+	hedge.Quantity -= quantity
+	if hedge.Quantity <= 1e-12 {
+		delete(te.hedges, symbol)
+	}
+	return pnl
+}
+
+// GetExposure values all holdings, hedges and the signal position at the given prices.
+// Symbols missing from prices fall back to their last known/entry price.
+func (te *TradeExecutor) GetExposure(prices map[string]float64) ExposureSnapshot {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	var exposure ExposureSnapshot
+	for symbol, quantity := range te.holdings {
+		exposure.Long += quantity * prices[symbol]
+	}
+	for symbol, hedge := range te.hedges {
+		price, ok := prices[symbol]
+		if !ok {
+			price = hedge.EntryPrice
+		}
+		exposure.Short += hedge.Quantity * price
+	}
+
+	if position := te.currentPosition; position != nil {
+		price, ok := prices[position.Symbol]
+		if !ok {
+			price = position.CurrentPrice
+		}
+		if position.Side == "LONG" {
+			exposure.Long += position.Quantity * price
+		} else {
+			exposure.Short += position.Quantity * price
+		}
+	}
+
+	exposure.Net = exposure.Long - exposure.Short
+	return exposure
+}
+
+// GetExposureSymbols returns every symbol the account currently has exposure to
+func (te *TradeExecutor) GetExposureSymbols() []string {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	symbols := make([]string, 0, len(te.holdings)+len(te.hedges)+1)
+	for symbol := range te.holdings {
+		symbols = append(symbols, symbol)
+	}
+	for symbol := range te.hedges {
+		symbols = append(symbols, symbol)
+	}
+	if te.currentPosition != nil {
+		symbols = append(symbols, te.currentPosition.Symbol)
+	}
+	return symbols
+}
+
+// GetHedgePositions returns open hedges
+func (te *TradeExecutor) GetHedgePositions() []HedgePosition {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	hedges := make([]HedgePosition, 0, len(te.hedges))
+	for _, hedge := range te.hedges {
+		hedges = append(hedges, *hedge)
+	}
+	return hedges
+}
+
+// SymbolExposure is one symbol's exposure in quote currency and in benchmark-beta terms
+type SymbolExposure struct {
+	Symbol        string  `json:"symbol"`
+	Price         float64 `json:"price"`
+	Long          float64 `json:"long"`
+	Short         float64 `json:"short"`
+	Net           float64 `json:"net"`
+	Beta          float64 `json:"beta"`           // Rolling beta of the symbol's returns to the benchmark's
+	BetaEstimated bool    `json:"beta_estimated"` // False when too few returns overlap the benchmark's and a beta of 1 is assumed
+	BetaAdjusted  float64 `json:"beta_adjusted"`  // Net scaled by beta: the benchmark exposure with the same directional risk
+}
+
+// RiskReport is the account's exposure, raw and scaled by each symbol's beta to the benchmark
+type RiskReport struct {
+	Benchmark    string           `json:"benchmark"`
+	Exposure     ExposureSnapshot `json:"exposure"`
+	BetaExposure ExposureSnapshot `json:"beta_exposure"` // Long and short scaled by beta, in benchmark-equivalent quote value
+	Symbols      []SymbolExposure `json:"symbols"`
+	Timestamp    time.Time        `json:"timestamp"`
+}
+
+// GetRiskReport values holdings, hedges and the signal position at the given
+// prices, falling back to the latest sampled, entry or marked price, and scales
+// each symbol by its rolling beta to the benchmark
+func (te *TradeExecutor) GetRiskReport(prices map[string]float64, benchmark string) RiskReport {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	symbols := make(map[string]*SymbolExposure)
+	entry := func(symbol string, fallback float64) *SymbolExposure {
+		if _, ok := symbols[symbol]; !ok {
+			symbols[symbol] = &SymbolExposure{Symbol: symbol, Price: te.priceFor(prices, symbol, fallback)}
+		}
+		return symbols[symbol]
+	}
+	for symbol, quantity := range te.holdings {
+		exposure := entry(symbol, 0)
+		exposure.Long += quantity * exposure.Price
+	}
+	for symbol, hedge := range te.hedges {
+		exposure := entry(symbol, hedge.EntryPrice)
+		exposure.Short += hedge.Quantity * exposure.Price
+	}
+	if position := te.currentPosition; position != nil {
+		exposure := entry(position.Symbol, position.CurrentPrice)
+		if position.Side == "LONG" {
+			exposure.Long += position.Quantity * exposure.Price
+		} else {
+			exposure.Short += position.Quantity * exposure.Price
+		}
+	}
+
+	report := RiskReport{Benchmark: benchmark, Symbols: make([]SymbolExposure, 0, len(symbols)), Timestamp: te.now()}
+	for _, symbol := range sortedKeys(symbols) {
+		exposure := symbols[symbol]
+		exposure.Net = exposure.Long - exposure.Short
+		exposure.Beta, exposure.BetaEstimated = te.correlations.Beta(symbol, benchmark)
+		if !exposure.BetaEstimated {
+			exposure.Beta = 1
+		}
+		exposure.BetaAdjusted = exposure.Net * exposure.Beta
+
+		report.Exposure.Long += exposure.Long
+		report.Exposure.Short += exposure.Short
+		report.BetaExposure.Long += exposure.Long * exposure.Beta
+		report.BetaExposure.Short += exposure.Short * exposure.Beta
+		report.Symbols = append(report.Symbols, *exposure)
+	}
+	report.Exposure.Net = report.Exposure.Long - report.Exposure.Short
+	report.BetaExposure.Net = report.BetaExposure.Long - report.BetaExposure.Short
+	return report
+}
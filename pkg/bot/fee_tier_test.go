@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFeeTier(t *testing.T) {
+	t.Log("🏷️ Testing fee tiers, the BNB discount and fees itemized per trade")
+
+	config := DefaultConfig()
+	config.Fees = FeeConfig{Enabled: true, Tier: "VIP1", BNBDiscount: true, BNBDiscountPercent: 10}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected the fee tier to be valid: %v", err)
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	// The tier takes precedence over a flat simulated fee
+	executor := NewTradeExecutor(config, 10000)
+	executor.SetSimulatedCosts(0.1, 0)
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}, 100, 99.5); err != nil {
+		t.Fatalf("Failed to open position: %v", err)
+	}
+	quantity := executor.GetCurrentPosition().Quantity
+	if err := executor.ForceClosePosition(110); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+	trade := executor.GetTradeHistory(0)[0]
+	entryFee, exitFee := quantity*100*0.0004*0.9, quantity*110*0.0004*0.9
+	if !near(trade.EntryFee, entryFee) || !near(trade.ExitFee, exitFee) || !near(trade.Fees, entryFee+exitFee) {
+		t.Errorf("Expected VIP1 taker fees less 10%%, %.4f + %.4f, got %+v", entryFee, exitFee, trade)
+	}
+	if trade.FeeTier != "VIP1" || !near(trade.FeeDiscount, (entryFee+exitFee)/9) || !near(trade.PnL, quantity*10-entryFee-exitFee) {
+		t.Errorf("Expected the tier, the discount saved and net PnL, got %+v", trade)
+	}
+
+	// A taker override replaces the tier's rate
+	config.Fees.TakerPercent = 0.03
+	config.Fees.BNBDiscount = false
+	if schedule := config.Fees.Schedule(); schedule.TakerPercent != 0.03 || schedule.MakerPercent != 0.016 || schedule.EffectiveTakerPercent() != 0.03 {
+		t.Errorf("Expected a 0.03%% taker override on VIP1, got %+v", schedule)
+	}
+
+	// Each tax lot carries its share of the fees
+	records := GenerateTaxLots([]*Trade{trade}, FIFO, trade.EntryTime, trade.ExitTime.Add(1))
+	if len(records) != 1 || !near(records[0].Fees, trade.Fees) {
+		t.Fatalf("Expected one lot with the trade's fees, got %+v", records)
+	}
+	var csv bytes.Buffer
+	if err := WriteTaxLotsCSV(&csv, records); err != nil || !strings.HasSuffix(strings.SplitN(csv.String(), "\n", 2)[0], ",fees") {
+		t.Errorf("Expected a fees column in the tax CSV, got %q (err %v)", csv.String(), err)
+	}
+
+	config.Fees.Tier = "VIP12"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "fees.tier") {
+		t.Errorf("Expected an unknown tier to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"sort"
+	"time"
+)
+
+// FeedLimits returns how long each timeframe's feed may stay silent: the kline
+// stream pushes forming candles continuously, while per-timeframe feeds only
+// deliver closed candles, so they get one interval on top of the stale timeout
+func FeedLimits(config StreamingConfig, streamed func(Timeframe) bool) map[Timeframe]time.Duration {
+	stale := time.Duration(config.StaleSeconds) * time.Second
+	limits := make(map[Timeframe]time.Duration)
+	for _, timeframe := range []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute} {
+		if streamed != nil && streamed(timeframe) {
+			limits[timeframe] = stale
+		} else {
+			limits[timeframe] = timeframe.Duration() + stale
+		}
+	}
+	return limits
+}
+
+// CheckFeeds marks timeframes whose last update is older than their limit as
+// degraded, returning the ones currently stale and the ones that just recovered.
+// Timeframes that never received data are skipped.
+func (tm *TimeframeManager) CheckFeeds(limits map[Timeframe]time.Duration, now time.Time) (stale, recovered []Timeframe) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+
+	for timeframe, limit := range limits {
+		last, ok := tm.lastUpdate[timeframe]
+		if !ok || last.IsZero() {
+			continue
+		}
+		if now.Sub(last) > limit {
+			tm.degraded[timeframe] = true
+			stale = append(stale, timeframe)
+		} else if tm.degraded[timeframe] {
+			delete(tm.degraded, timeframe)
+			recovered = append(recovered, timeframe)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i] < stale[j] })
+	sort.Slice(recovered, func(i, j int) bool { return recovered[i] < recovered[j] })
+	return stale, recovered
+}
+
+// DegradedTimeframes lists the timeframes whose feeds are currently silent
+func (tm *TimeframeManager) DegradedTimeframes() []Timeframe {
+	tm.mutex.RLock()
+	defer tm.mutex.RUnlock()
+
+	timeframes := make([]Timeframe, 0, len(tm.degraded))
+	for timeframe := range tm.degraded {
+		timeframes = append(timeframes, timeframe)
+	}
+	sort.Slice(timeframes, func(i, j int) bool { return timeframes[i] < timeframes[j] })
+	return timeframes
+}
+
+// LastUpdate returns when a timeframe last received data (zero if never)
+func (tm *TimeframeManager) LastUpdate(timeframe Timeframe) time.Time {
+	tm.mutex.RLock()
+	defer tm.mutex.RUnlock()
+	return tm.lastUpdate[timeframe]
+}
+
+// RunWatchdog checks the feeds every interval until stop is closed. A feed that
+// goes silent is reconnected, and again after each further limit it stays silent.
+func (tm *TimeframeManager) RunWatchdog(stop <-chan struct{}, interval time.Duration, limits map[Timeframe]time.Duration, reconnect func(Timeframe) error) {
+	tm.mutex.RLock()
+	ticker := tm.clock.NewTicker(interval)
+	tm.mutex.RUnlock()
+	defer ticker.Stop()
+
+	reconnected := make(map[Timeframe]time.Time)
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.Chan():
+			stale, recovered := tm.CheckFeeds(limits, now)
+			for _, timeframe := range recovered {
+				engineLog.Info("✅ Feed recovered", "symbol", tm.marketData.Symbol, "timeframe", timeframe.String())
+				delete(reconnected, timeframe)
+			}
+			for _, timeframe := range stale {
+				if last, ok := reconnected[timeframe]; ok && now.Sub(last) < limits[timeframe] {
+					continue
+				}
+				reconnected[timeframe] = now
+				engineLog.Warn("Feed silent, reconnecting", "symbol", tm.marketData.Symbol, "timeframe", timeframe.String(),
+					"silent_for", now.Sub(tm.LastUpdate(timeframe)).Round(time.Second))
+				if err := reconnect(timeframe); err != nil {
+					engineLog.Warn("Failed to reconnect feed", "symbol", tm.marketData.Symbol, "timeframe", timeframe.String(), "error", err)
+				}
+			}
+		}
+	}
+}
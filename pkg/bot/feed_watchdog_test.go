@@ -0,0 +1,101 @@
+package bot
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// feedSource hands out a new candle channel per real-time feed request
+type feedSource struct {
+	mutex sync.Mutex
+	feeds []chan Candle
+}
+
+func (f *feedSource) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	return nil, nil
+}
+
+func (f *feedSource) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	feed := make(chan Candle, 1)
+	f.feeds = append(f.feeds, feed)
+	return feed, nil
+}
+
+func (f *feedSource) Close() error { return nil }
+
+func TestFeedWatchdog(t *testing.T) {
+	t.Log("🐕 Testing the feed staleness watchdog, reconnects and degraded feeds")
+
+	config := DefaultConfig().Streaming
+	limits := FeedLimits(config, func(tf Timeframe) bool { return tf == FiveMinute })
+	if limits[FiveMinute] != 30*time.Second || limits[FifteenMinute] != 15*time.Minute+30*time.Second {
+		t.Errorf("Unexpected feed limits: %v", limits)
+	}
+
+	// Feeds past their limit are degraded until data arrives again
+	tm := NewTimeframeManager("BTCUSDT")
+	tm.AddCandle(FiveMinute, Candle{Timestamp: time.Now().Truncate(5 * time.Minute), Close: 100})
+	now := time.Now()
+	if stale, _ := tm.CheckFeeds(limits, now.Add(time.Second)); len(stale) != 0 {
+		t.Errorf("Expected fresh feeds, got stale %v", stale)
+	}
+	stale, _ := tm.CheckFeeds(limits, now.Add(time.Minute))
+	if len(stale) != 1 || stale[0] != FiveMinute || len(tm.DegradedTimeframes()) != 1 {
+		t.Fatalf("Expected the 5m feed to go stale, got %v", stale)
+	}
+	engine := newSignalEngine(DefaultConfig())
+	engine.timeframeManager = tm
+	if status := engine.GetStatus(); len(status.DegradedFeeds) != 1 || status.DegradedFeeds[0] != "5m" {
+		t.Errorf("Expected the degraded 5m feed in the status, got %v", status.DegradedFeeds)
+	}
+	tm.AddCandle(FiveMinute, Candle{Timestamp: time.Now().Truncate(5 * time.Minute), Close: 101})
+	if _, recovered := tm.CheckFeeds(limits, time.Now()); len(recovered) != 1 || len(tm.DegradedTimeframes()) != 0 {
+		t.Errorf("Expected the 5m feed to recover, got %v", recovered)
+	}
+
+	// A reconnect replaces the feed; the retired one no longer updates candles
+	source := &feedSource{}
+	dpm := NewDataProviderManager()
+	dpm.AddProvider("source", source)
+	feeds := NewTimeframeManager("BTCUSDT")
+	if err := dpm.startFeed("BTCUSDT", FiveMinute, feeds); err != nil {
+		t.Fatalf("Failed to start feed: %v", err)
+	}
+	if err := dpm.ReconnectFeed("BTCUSDT", FiveMinute, feeds); err != nil || len(source.feeds) != 2 {
+		t.Fatalf("Expected a second feed after reconnecting, got %d (err %v)", len(source.feeds), err)
+	}
+	start := time.Now().Truncate(5 * time.Minute)
+	source.feeds[0] <- Candle{Timestamp: start, Close: 1}
+	source.feeds[1] <- Candle{Timestamp: start.Add(5 * time.Minute), Close: 2}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if candles, _ := feeds.GetCandles(FiveMinute); len(candles) > 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if candles, _ := feeds.GetCandles(FiveMinute); len(candles) != 1 || candles[0].Close != 2 {
+		t.Errorf("Expected only the new feed's candle, got %+v", candles)
+	}
+
+	// The watchdog reconnects a silent feed once per limit
+	reconnects := make(chan Timeframe, 10)
+	stop := make(chan struct{})
+	go tm.RunWatchdog(stop, 5*time.Millisecond, map[Timeframe]time.Duration{FiveMinute: 10 * time.Millisecond, Daily: time.Hour}, func(tf Timeframe) error {
+		reconnects <- tf
+		return nil
+	})
+	select {
+	case tf := <-reconnects:
+		if tf != FiveMinute {
+			t.Errorf("Expected the 5m feed to be reconnected, got %s", tf.String())
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the watchdog to reconnect the silent feed")
+	}
+	close(stop)
+}
@@ -0,0 +1,72 @@
+package bot
+
+// FeeTier is an exchange VIP tier's fees, % of notional
+type FeeTier struct {
+	MakerPercent float64 `json:"maker_percent"`
+	TakerPercent float64 `json:"taker_percent"`
+}
+
+// BinanceFuturesFeeTiers are Binance USDⓈ-M futures fees by VIP tier
+var BinanceFuturesFeeTiers = map[string]FeeTier{
+	"VIP0": {MakerPercent: 0.02, TakerPercent: 0.05},
+	"VIP1": {MakerPercent: 0.016, TakerPercent: 0.04},
+	"VIP2": {MakerPercent: 0.014, TakerPercent: 0.035},
+	"VIP3": {MakerPercent: 0.012, TakerPercent: 0.032},
+	"VIP4": {MakerPercent: 0.01, TakerPercent: 0.03},
+	"VIP5": {MakerPercent: 0.008, TakerPercent: 0.027},
+	"VIP6": {MakerPercent: 0.006, TakerPercent: 0.025},
+	"VIP7": {MakerPercent: 0.004, TakerPercent: 0.022},
+	"VIP8": {MakerPercent: 0.002, TakerPercent: 0.02},
+	"VIP9": {MakerPercent: 0, TakerPercent: 0.017},
+}
+
+// FeeSchedule is the fee charged per fill, before any BNB discount
+type FeeSchedule struct {
+	Tier            string  `json:"tier,omitempty"` // Empty for a flat backtest.fee_percent
+	MakerPercent    float64 `json:"maker_percent"`
+	TakerPercent    float64 `json:"taker_percent"`
+	DiscountPercent float64 `json:"discount_percent"` // Share of each fee waived for paying in BNB
+}
+
+// Schedule resolves the tier and overrides into the fees charged per fill
+func (c FeeConfig) Schedule() FeeSchedule {
+	tier := BinanceFuturesFeeTiers[c.Tier]
+	schedule := FeeSchedule{Tier: c.Tier, MakerPercent: tier.MakerPercent, TakerPercent: tier.TakerPercent}
+	if c.MakerPercent > 0 {
+		schedule.MakerPercent = c.MakerPercent
+	}
+	if c.TakerPercent > 0 {
+		schedule.TakerPercent = c.TakerPercent
+	}
+	if c.BNBDiscount {
+		schedule.DiscountPercent = c.BNBDiscountPercent
+	}
+	return schedule
+}
+
+// flatFeeSchedule charges the same fee on maker and taker fills
+func flatFeeSchedule(feePercent float64) FeeSchedule {
+	return FeeSchedule{MakerPercent: feePercent, TakerPercent: feePercent}
+}
+
+// EffectiveTakerPercent is the taker fee after the BNB discount
+func (s FeeSchedule) EffectiveTakerPercent() float64 {
+	return s.TakerPercent * (1 - s.DiscountPercent/100)
+}
+
+// charge returns the fee paid on a fill's notional and the amount the BNB discount saved
+func (s FeeSchedule) charge(notional float64, maker bool) (float64, float64) {
+	rate := s.TakerPercent
+	if maker {
+		rate = s.MakerPercent
+	}
+	gross := notional * rate / 100
+	saved := gross * s.DiscountPercent / 100
+	return gross - saved, saved
+}
+
+// entryIsMaker reports whether signal entries rest on the book: live LIMIT
+// orders do; market orders and simulated fills take liquidity
+func (te *TradeExecutor) entryIsMaker() bool {
+	return te.orders != nil && te.liveConfig.OrderType == "LIMIT"
+}
@@ -0,0 +1,118 @@
+package bot
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileDataProvider serves historical candles from CSV files for backfills.
+// Files are named <SYMBOL>_<timeframe>.csv (e.g. BTCUSDT_5m.csv) with rows of
+// timestamp,open,high,low,close,volume where timestamp is unix milliseconds or RFC3339.
+type FileDataProvider struct {
+	dataDir string
+}
+
+// NewFileDataProvider creates a new file-backed data provider
+func NewFileDataProvider(dataDir string) *FileDataProvider {
+	return &FileDataProvider{dataDir: dataDir}
+}
+
+// GetHistoricalData returns the most recent count candles from the timeframe's CSV file
+func (f *FileDataProvider) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	path := filepath.Join(f.dataDir, fmt.Sprintf("%s_%s.csv", symbol, timeframe.String()))
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open candle file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	candles := make([]Candle, 0)
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		line++
+
+		candle, err := parseCSVCandle(record)
+		if err != nil {
+			// Allow a header row
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+		}
+		candles = append(candles, candle)
+	}
+
+	if count > 0 && len(candles) > count {
+		candles = candles[len(candles)-count:]
+	}
+
+	return candles, nil
+}
+
+// GetRealTimeData is not supported; files are only used for backfills
+func (f *FileDataProvider) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	return nil, fmt.Errorf("file provider does not support real-time data")
+}
+
+// Close is a no-op for the file provider
+func (f *FileDataProvider) Close() error {
+	return nil
+}
+
+// parseCSVCandle converts a CSV row into a Candle
+func parseCSVCandle(record []string) (Candle, error) {
+	if len(record) < 6 {
+		return Candle{}, fmt.Errorf("expected 6 columns, got %d", len(record))
+	}
+
+	timestamp, err := parseCSVTimestamp(strings.TrimSpace(record[0]))
+	if err != nil {
+		return Candle{}, err
+	}
+
+	values := make([]float64, 5)
+	for i := range values {
+		values[i], err = strconv.ParseFloat(strings.TrimSpace(record[i+1]), 64)
+		if err != nil {
+			return Candle{}, fmt.Errorf("invalid number %q: %w", record[i+1], err)
+		}
+	}
+
+	return Candle{
+		Timestamp: timestamp,
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+		Volume:    values[4],
+	}, nil
+}
+
+// parseCSVTimestamp accepts unix milliseconds or RFC3339 timestamps
+func parseCSVTimestamp(value string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC(), nil
+	}
+	ts, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+	}
+	return ts, nil
+}
@@ -45,12 +45,7 @@ func TestFineTuneThreshold(t *testing.T) {
 			futurePrice := histData.GetActualPriceAt(testTime.Add(5 * time.Minute))
 			priceChange := futurePrice - currentPrice
 
-			actualDirection := "NEUTRAL"
-			if priceChange > 4 {
-				actualDirection = "HIGHER"
-			} else if priceChange < -4 {
-				actualDirection = "LOWER"
-			}
+			actualDirection := actualDirectionFor(histData.GetCandles(FiveMinute, testTime, 100), priceChange)
 
 			// Generate prediction with this threshold
 			fiveMinCandles := histData.GetCandles(FiveMinute, testTime, 100)
@@ -0,0 +1,115 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// fundingRefreshInterval is how often live funding rates are fetched
+const fundingRefreshInterval = 15 * time.Minute
+
+// GetFundingRate fetches the rate charged at the next funding settlement
+func (b *BinanceFuturesDataProvider) GetFundingRate(symbol string) (float64, error) {
+	params := url.Values{}
+	params.Add("symbol", b.convertSymbol(symbol))
+
+	var premiumResp struct {
+		LastFundingRate string `json:"lastFundingRate"`
+	}
+	if err := b.getJSON("/fapi/v1/premiumIndex", params, &premiumResp); err != nil {
+		return 0, err
+	}
+
+	rate, err := strconv.ParseFloat(premiumResp.LastFundingRate, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse funding rate: %w", err)
+	}
+	return rate, nil
+}
+
+// SetFundingRate records the exchange's current funding rate for a symbol,
+// overriding the configured rate
+func (te *TradeExecutor) SetFundingRate(symbol string, rate float64) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.fundingRates[symbol] = rate
+}
+
+// fundingRate returns the live, per-symbol or default funding rate (assumes lock is held)
+func (te *TradeExecutor) fundingRate(symbol string) float64 {
+	if rate, ok := te.fundingRates[symbol]; ok {
+		return rate
+	}
+	if rate, ok := te.config.Funding.Rates[symbol]; ok {
+		return rate
+	}
+	return te.config.Funding.Rate
+}
+
+// accrueFunding books the funding settlements crossed and the borrow interest
+// accrued since the last accrual into the open position, valuing its notional at
+// price (assumes lock is held)
+func (te *TradeExecutor) accrueFunding(price float64) {
+	position := te.currentPosition
+	funding := te.config.Funding
+	if position == nil || !funding.Enabled || price <= 0 {
+		return
+	}
+	from := position.lastAccrual
+	if from.IsZero() {
+		from = position.OpenTime
+	}
+	now := te.now()
+	if !now.After(from) {
+		return
+	}
+	position.lastAccrual = now
+
+	notional := te.config.Contract.Notional(position.Quantity, price)
+	direction := 1.0
+	if position.Side == "SHORT" {
+		direction = -1
+	}
+
+	// Longs pay shorts when the rate is positive
+	interval := time.Duration(funding.IntervalHours) * time.Hour
+	for settlement := from.Truncate(interval).Add(interval); !settlement.After(now); settlement = settlement.Add(interval) {
+		rate := te.fundingRate(position.Symbol)
+		payment := -direction * rate * notional
+		position.Funding += payment
+		executorLog.Info("💸 Funding settled", "symbol", position.Symbol, "side", position.Side, "rate", rate, "payment", FormatCurrencyAmount(payment, te.marginCurrency))
+	}
+
+	if funding.BorrowRate > 0 {
+		position.Funding -= notional * funding.BorrowRate * now.Sub(from).Hours() / (365 * 24)
+	}
+}
+
+// startFundingRefresh keeps the traded symbol's live funding rate current
+func (tb *TradingBot) startFundingRefresh(ctx context.Context, provider *BinanceFuturesDataProvider) {
+	refresh := func() {
+		rate, err := provider.GetFundingRate(tb.config.Symbol)
+		if err != nil {
+			executorLog.Warn("Funding rate refresh failed, using the configured rate", "symbol", tb.config.Symbol, "error", err)
+			return
+		}
+		tb.tradeExecutor.SetFundingRate(tb.config.Symbol, rate)
+	}
+
+	go func() {
+		refresh()
+		ticker := time.NewTicker(fundingRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refresh()
+			}
+		}
+	}()
+}
@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFundingAccrual(t *testing.T) {
+	t.Log("💸 Testing funding settlements and borrow interest in position and trade PnL")
+
+	config := DefaultConfig()
+	config.Funding.Enabled = true
+	config.Funding.BorrowRate = 0.1
+	executor := NewTradeExecutor(config, 10000)
+	now := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
+	executor.SetClock(ClockFunc(func() time.Time { return now }))
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+
+	// A 400 unit long: 40000 notional
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}, 100, 99.5); err != nil {
+		t.Fatalf("Failed to open long position: %v", err)
+	}
+
+	// 07:00 -> 17:00 crosses the 08:00 and 16:00 settlements at 0.01%
+	now = now.Add(10 * time.Hour)
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Hold, Confidence: 0.9}, 100, 99.5); err != nil {
+		t.Fatalf("Hold failed: %v", err)
+	}
+	borrow := 40000 * 0.1 * 10 / (365 * 24)
+	position := executor.GetCurrentPosition()
+	if !near(position.Funding, -8-borrow) || !near(position.PnL, -8-borrow) {
+		t.Errorf("Expected -8 funding and %.4f borrow interest, got funding %.4f, PnL %.4f", borrow, position.Funding, position.PnL)
+	}
+
+	// A negative live rate pays the long at the 00:00 settlement
+	executor.SetFundingRate(config.Symbol, -0.0002)
+	now = now.Add(7*time.Hour + 30*time.Minute)
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Sell, Confidence: 0.9}, 100, 99.5); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+	trades := executor.GetTradeHistory(1)
+	borrow = 40000 * 0.1 * 17.5 / (365 * 24)
+	if len(trades) != 1 || !near(trades[0].Funding, -borrow) || !near(trades[0].PnL, -borrow) {
+		t.Errorf("Expected the trade to carry %.4f of net funding in its PnL, got %+v", -borrow, trades)
+	}
+
+	config.Funding.IntervalHours = 5
+	config.Funding.Rates = map[string]float64{"ETHUSDT": 0.2}
+	err := ValidateConfig(config)
+	for _, field := range []string{"funding.interval_hours", "funding.rates.ETHUSDT"} {
+		if err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Expected %s to be rejected, got %v", field, err)
+		}
+	}
+}
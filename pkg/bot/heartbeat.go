@@ -0,0 +1,182 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HeartbeatStatus reports the dead-man's switch state
+type HeartbeatStatus struct {
+	Enabled        bool       `json:"enabled"`
+	Healthy        bool       `json:"healthy"` // Result of the last health check
+	SentCount      int        `json:"sent_count"`
+	LastSent       *time.Time `json:"last_sent,omitempty"`
+	LastSkipped    *time.Time `json:"last_skipped,omitempty"`
+	LastSkipReason string     `json:"last_skip_reason,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+// HeartbeatPayload is published to MQTT with each heartbeat
+type HeartbeatPayload struct {
+	Symbol     string    `json:"symbol"`
+	Timestamp  time.Time `json:"timestamp"`
+	LastSignal time.Time `json:"last_signal"`
+}
+
+// HeartbeatSender delivers one heartbeat
+type HeartbeatSender func(payload HeartbeatPayload) error
+
+// HeartbeatMonitor pings external dead-man's switches only while the bot is healthy,
+// so a crashed process, a frozen feed or a stalled engine all end up alerting externally
+type HeartbeatMonitor struct {
+	config  HeartbeatConfig
+	check   func(now time.Time) (HeartbeatPayload, error) // Returns an error when the bot is unhealthy
+	senders map[string]HeartbeatSender
+	status  HeartbeatStatus
+	mutex   sync.RWMutex
+}
+
+// NewHeartbeatMonitor creates a heartbeat monitor; check decides whether the bot is healthy
+func NewHeartbeatMonitor(config HeartbeatConfig, check func(now time.Time) (HeartbeatPayload, error)) *HeartbeatMonitor {
+	return &HeartbeatMonitor{
+		config:  config,
+		check:   check,
+		senders: make(map[string]HeartbeatSender),
+		status:  HeartbeatStatus{Enabled: config.Enabled},
+	}
+}
+
+// AddSender registers a heartbeat destination
+func (hm *HeartbeatMonitor) AddSender(name string, sender HeartbeatSender) {
+	hm.mutex.Lock()
+	defer hm.mutex.Unlock()
+	hm.senders[name] = sender
+}
+
+// Start sends heartbeats every interval until ctx is cancelled
+func (hm *HeartbeatMonitor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Duration(hm.config.IntervalSeconds) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				hm.RunOnce(now)
+			}
+		}
+	}()
+}
+
+// RunOnce checks health and, if healthy, pings every sender
+func (hm *HeartbeatMonitor) RunOnce(now time.Time) {
+	payload, err := hm.check(now)
+
+	hm.mutex.Lock()
+	defer hm.mutex.Unlock()
+
+	if err != nil {
+		if hm.status.Healthy || hm.status.LastSkipped == nil {
+			engineLog.Warn("💔 Heartbeat withheld", "reason", err)
+		}
+		hm.status.Healthy = false
+		hm.status.LastSkipped = &now
+		hm.status.LastSkipReason = err.Error()
+		return
+	}
+	if !hm.status.Healthy && hm.status.LastSkipped != nil {
+		engineLog.Info("💓 Heartbeat resumed")
+	}
+	hm.status.Healthy = true
+
+	sent := false
+	hm.status.LastError = ""
+	for name, sender := range hm.senders {
+		if err := sender(payload); err != nil {
+			hm.status.LastError = fmt.Sprintf("%s: %v", name, err)
+			engineLog.Warn("Heartbeat failed", "sender", name, "error", err)
+			continue
+		}
+		sent = true
+	}
+	if sent {
+		hm.status.SentCount++
+		hm.status.LastSent = &now
+	}
+}
+
+// GetStatus returns the heartbeat state
+func (hm *HeartbeatMonitor) GetStatus() HeartbeatStatus {
+	hm.mutex.RLock()
+	defer hm.mutex.RUnlock()
+	return hm.status
+}
+
+// HTTPHeartbeatSender pings a healthchecks.io-style URL with GET
+func HTTPHeartbeatSender(url string) HeartbeatSender {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return func(HeartbeatPayload) error {
+		resp, err := client.Get(url)
+		if err != nil {
+			return fmt.Errorf("failed to ping %s: %w", url, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("heartbeat URL returned status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// MQTTHeartbeatSender publishes the heartbeat payload as JSON to a topic
+func MQTTHeartbeatSender(publisher *MQTTPublisher, topic string) HeartbeatSender {
+	return func(payload HeartbeatPayload) error {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal heartbeat: %w", err)
+		}
+		return publisher.Publish(topic, data)
+	}
+}
+
+// checkHeartbeat reports the bot healthy when the engine runs, the 5m feed is
+// fresh, signals are recent and safe mode is off
+func (tb *TradingBot) checkHeartbeat(now time.Time) (HeartbeatPayload, error) {
+	status := tb.signalEngine.GetStatus()
+	if !status.Running {
+		return HeartbeatPayload{}, fmt.Errorf("signal engine not running")
+	}
+	if tb.outage.IsActive() {
+		return HeartbeatPayload{}, fmt.Errorf("safe mode active")
+	}
+
+	latest, err := tb.signalEngine.timeframeManager.GetLatestCandles(FiveMinute, 1)
+	if err != nil || len(latest) == 0 {
+		return HeartbeatPayload{}, fmt.Errorf("no 5m candles")
+	}
+	if age := now.Sub(latest[0].Timestamp.Add(FiveMinute.Duration())); age > 2*FiveMinute.Duration() {
+		return HeartbeatPayload{}, fmt.Errorf("5m feed stale: last candle closed %s ago", age.Round(time.Second))
+	}
+
+	maxAge := time.Duration(tb.config.Heartbeat.MaxSignalAgeSeconds) * time.Second
+	if status.LastSignal == nil {
+		return HeartbeatPayload{}, fmt.Errorf("no signals generated yet")
+	}
+	if age := now.Sub(status.LastSignal.Timestamp); age > maxAge {
+		return HeartbeatPayload{}, fmt.Errorf("last signal %s ago", age.Round(time.Second))
+	}
+
+	return HeartbeatPayload{Symbol: tb.config.Symbol, Timestamp: now, LastSignal: status.LastSignal.Timestamp}, nil
+}
+
+// GetHeartbeatStatus returns the dead-man's switch state
+func (tb *TradingBot) GetHeartbeatStatus() HeartbeatStatus {
+	return tb.heartbeat.GetStatus()
+}
@@ -0,0 +1,65 @@
+package bot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat(t *testing.T) {
+	t.Log("💓 Testing dead-man's switch heartbeat")
+
+	pings := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pings++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.Heartbeat = HeartbeatConfig{Enabled: true, URL: server.URL, IntervalSeconds: 60, MaxSignalAgeSeconds: 180}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Valid heartbeat config rejected: %v", err)
+	}
+	tb := NewTradingBot(config)
+	tb.heartbeat.AddSender("http", HTTPHeartbeatSender(server.URL))
+	engine := tb.signalEngine
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Engine not running: no ping
+	tb.heartbeat.RunOnce(now)
+	if status := tb.GetHeartbeatStatus(); pings != 0 || status.Healthy || status.LastSkipReason == "" {
+		t.Fatalf("Expected heartbeat withheld while engine stopped, got %d pings / %+v", pings, status)
+	}
+
+	// Running with a fresh feed and a recent signal: ping
+	engine.running = true
+	engine.timeframeManager.AddCandle(FiveMinute, Candle{Timestamp: now.Add(-5 * time.Minute), Open: 100, High: 101, Low: 99, Close: 100})
+	engine.lastSignal = &TradingSignal{Symbol: config.Symbol, Signal: Hold, Timestamp: now.Add(-time.Minute)}
+	tb.heartbeat.RunOnce(now)
+	if status := tb.GetHeartbeatStatus(); pings != 1 || !status.Healthy || status.SentCount != 1 {
+		t.Fatalf("Expected one ping while healthy, got %d pings / %+v", pings, status)
+	}
+
+	// Stalled signal generation: no ping
+	later := now.Add(4 * time.Minute)
+	tb.heartbeat.RunOnce(later)
+	if pings != 1 {
+		t.Errorf("Expected no ping once the last signal is 5 minutes old")
+	}
+
+	// Frozen feed: no ping even with a fresh signal
+	engine.lastSignal = &TradingSignal{Symbol: config.Symbol, Signal: Hold, Timestamp: later}
+	tb.heartbeat.RunOnce(later.Add(10 * time.Minute))
+	if status := tb.GetHeartbeatStatus(); pings != 1 || status.Healthy {
+		t.Errorf("Expected no ping with a stale 5m feed, got %d pings / %+v", pings, status)
+	}
+
+	config.Heartbeat.URL = ""
+	config.Heartbeat.MQTTTopic = "nexus/heartbeat"
+	if err := ValidateConfig(config); err == nil {
+		t.Errorf("Expected MQTT heartbeat without a broker to be rejected")
+	}
+}
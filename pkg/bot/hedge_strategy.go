@@ -0,0 +1,197 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// HedgeAuditEntry records a single hedging decision or execution result
+type HedgeAuditEntry struct {
+	Time         time.Time `json:"time"`
+	Rule         string    `json:"rule"`
+	Action       string    `json:"action"` // "TRIGGER", "RELEASE", "EXECUTED", "FAILED"
+	Symbol       string    `json:"symbol"`
+	Side         string    `json:"side,omitempty"`
+	Quantity     float64   `json:"quantity,omitempty"`
+	Price        float64   `json:"price,omitempty"`
+	Regime       string    `json:"regime"`
+	LongExposure float64   `json:"long_exposure"`
+	Reason       string    `json:"reason,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// HedgingStrategy opens and unwinds short hedges according to configured rules
+type HedgingStrategy struct {
+	config HedgingConfig
+	audit  []HedgeAuditEntry
+	last   StrategyContext // Context of the latest evaluation, used for result audits
+	mutex  sync.RWMutex
+}
+
+// NewHedgingStrategy creates a new hedging strategy
+func NewHedgingStrategy(config HedgingConfig) *HedgingStrategy {
+	return &HedgingStrategy{
+		config: config,
+		audit:  make([]HedgeAuditEntry, 0),
+	}
+}
+
+// Name returns the strategy name
+func (hs *HedgingStrategy) Name() string {
+	return "HEDGE"
+}
+
+// Symbols returns the hedge instruments
+func (hs *HedgingStrategy) Symbols() []string {
+	symbols := make([]string, 0, len(hs.config.Rules))
+	for _, rule := range hs.config.Rules {
+		symbols = append(symbols, rule.HedgeSymbol)
+	}
+	return symbols
+}
+
+// Evaluate sizes each rule's hedge against current long exposure and regime.
+// A triggered rule tops its hedge up to HedgeRatio of long exposure; a cleared
+// rule covers its hedge entirely.
+func (hs *HedgingStrategy) Evaluate(ctx StrategyContext) ([]OrderIntent, error) {
+	hs.mutex.Lock()
+	hs.last = ctx
+	hs.mutex.Unlock()
+
+	intents := make([]OrderIntent, 0)
+	for _, rule := range hs.config.Rules {
+		price := ctx.Prices[rule.HedgeSymbol]
+		if price <= 0 {
+			return nil, fmt.Errorf("no price for %s", rule.HedgeSymbol)
+		}
+		hedged := ctx.Hedges[rule.HedgeSymbol]
+
+		// Hedges don't count toward the long exposure they offset
+		triggered := ctx.Exposure.Long > rule.MaxLongExposure &&
+			(rule.Regime == "" || rule.Regime == ctx.Regime)
+
+		if triggered {
+			target := rule.HedgeRatio * ctx.Exposure.Long / price
+			if target-hedged <= 1e-12 {
+				continue
+			}
+			intent := OrderIntent{
+				Symbol:   rule.HedgeSymbol,
+				Side:     "SHORT",
+				Quantity: target - hedged,
+				Price:    price,
+				Reason: fmt.Sprintf("%s: long exposure %.2f > %.2f in %s regime, hedging %.0f%%",
+					rule.Name, ctx.Exposure.Long, rule.MaxLongExposure, ctx.Regime, rule.HedgeRatio*100),
+			}
+			hs.record(rule, "TRIGGER", intent, ctx, nil)
+			intents = append(intents, intent)
+		} else if hedged > 0 {
+			intent := OrderIntent{
+				Symbol:   rule.HedgeSymbol,
+				Side:     "COVER",
+				Quantity: hedged,
+				Price:    price,
+				Reason: fmt.Sprintf("%s: condition cleared (long exposure %.2f, %s regime)",
+					rule.Name, ctx.Exposure.Long, ctx.Regime),
+			}
+			hs.record(rule, "RELEASE", intent, ctx, nil)
+			intents = append(intents, intent)
+		}
+	}
+	return intents, nil
+}
+
+// OnIntentResult audits the execution outcome of a hedge intent
+func (hs *HedgingStrategy) OnIntentResult(intent OrderIntent, err error) {
+	hs.mutex.RLock()
+	ctx := hs.last
+	hs.mutex.RUnlock()
+
+	for _, rule := range hs.config.Rules {
+		if rule.HedgeSymbol != intent.Symbol {
+			continue
+		}
+		action := "EXECUTED"
+		if err != nil {
+			action = "FAILED"
+		}
+		hs.record(rule, action, intent, ctx, err)
+		return
+	}
+}
+
+// GetAuditLog returns the most recent audit entries (0 = all)
+func (hs *HedgingStrategy) GetAuditLog(limit int) []HedgeAuditEntry {
+	hs.mutex.RLock()
+	defer hs.mutex.RUnlock()
+
+	start := 0
+	if limit > 0 && len(hs.audit) > limit {
+		start = len(hs.audit) - limit
+	}
+	return append([]HedgeAuditEntry(nil), hs.audit[start:]...)
+}
+
+// record appends an audit entry to memory, the log and the audit file
+func (hs *HedgingStrategy) record(rule HedgeRule, action string, intent OrderIntent, ctx StrategyContext, err error) {
+	entry := HedgeAuditEntry{
+		Time:         ctx.Now,
+		Rule:         rule.Name,
+		Action:       action,
+		Symbol:       intent.Symbol,
+		Side:         intent.Side,
+		Quantity:     intent.Quantity,
+		Price:        intent.Price,
+		Regime:       ctx.Regime,
+		LongExposure: ctx.Exposure.Long,
+		Reason:       intent.Reason,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	hs.mutex.Lock()
+	hs.audit = append(hs.audit, entry)
+	hs.mutex.Unlock()
+
+	executorLog.Info("🛡️  Hedge", "action", action, "rule", rule.Name, "side", intent.Side, "quantity", intent.Quantity, "symbol", intent.Symbol, "price", intent.Price, "reason", intent.Reason)
+	if err := hs.appendAuditFile(entry); err != nil {
+		executorLog.Warn("Failed to write hedge audit log", "error", err)
+	}
+}
+
+// appendAuditFile writes the entry as a JSON line when an audit file is configured
+func (hs *HedgingStrategy) appendAuditFile(entry HedgeAuditEntry) error {
+	if hs.config.AuditLogFile == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	file, err := os.OpenFile(hs.config.AuditLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
+
+// hedgeQuantities maps hedge symbols to open short quantity
+func hedgeQuantities(hedges []HedgePosition) map[string]float64 {
+	quantities := make(map[string]float64, len(hedges))
+	for _, hedge := range hedges {
+		quantities[hedge.Symbol] = math.Max(hedge.Quantity, 0)
+	}
+	return quantities
+}
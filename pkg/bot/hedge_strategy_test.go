@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHedgingStrategy(t *testing.T) {
+	t.Log("🛡️ Testing exposure hedging rules")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000.0)
+
+	prices := map[string]float64{"BTCUSDT": 50000, "ETHUSDT": 2500}
+	priceFn := func(symbol string) (float64, error) {
+		price, ok := prices[symbol]
+		if !ok {
+			return 0, fmt.Errorf("no price for %s", symbol)
+		}
+		return price, nil
+	}
+
+	// Build 6000 USDT of long BTC exposure
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: "MANUAL", Symbol: "BTCUSDT", Side: "BUY", Quantity: 0.12, Price: 50000}); err != nil {
+		t.Fatalf("Setup buy failed: %v", err)
+	}
+
+	auditFile := filepath.Join(t.TempDir(), "hedge_audit.jsonl")
+	hedging := NewHedgingStrategy(HedgingConfig{
+		Enabled:      true,
+		AuditLogFile: auditFile,
+		Rules: []HedgeRule{{
+			Name:            "btc-bear",
+			MaxLongExposure: 5000,
+			Regime:          "BEARISH",
+			HedgeSymbol:     "ETHUSDT",
+			HedgeRatio:      0.5,
+		}},
+	})
+
+	regime := "BULLISH"
+	manager := NewStrategyManager(executor, priceFn, time.Minute)
+	manager.SetRegimeSource(func() string { return regime })
+	manager.Register(hedging)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.RunOnce(start)
+	if len(executor.GetHedgePositions()) != 0 {
+		t.Fatalf("No hedge expected in a bullish regime")
+	}
+
+	// Regime flips bearish: hedge 50% of 6000 long exposure = 1.2 ETH short
+	regime = "BEARISH"
+	manager.RunOnce(start.Add(time.Minute))
+	hedges := executor.GetHedgePositions()
+	if len(hedges) != 1 || math.Abs(hedges[0].Quantity-1.2) > 1e-6 {
+		t.Fatalf("Expected 1.2 ETH hedge, got %+v", hedges)
+	}
+	exposure := executor.GetExposure(prices)
+	if math.Abs(exposure.Net-3000) > 1e-6 {
+		t.Errorf("Expected net exposure 3000, got %.2f", exposure.Net)
+	}
+
+	// Re-evaluating with the hedge in place does not add to it
+	manager.RunOnce(start.Add(2 * time.Minute))
+	if hedges := executor.GetHedgePositions(); math.Abs(hedges[0].Quantity-1.2) > 1e-6 {
+		t.Errorf("Hedge should not grow while already sized, got %.6f", hedges[0].Quantity)
+	}
+
+	// Regime recovers after ETH fell: hedge is covered at a profit
+	regime = "BULLISH"
+	prices["ETHUSDT"] = 2400
+	manager.RunOnce(start.Add(3 * time.Minute))
+	if len(executor.GetHedgePositions()) != 0 {
+		t.Fatalf("Expected hedge to be covered once the regime cleared")
+	}
+	if book := executor.GetStrategyBooks()["HEDGE"]; math.Abs(book.RealizedPnL-120) > 1e-6 {
+		t.Errorf("Expected hedge PnL 120, got %.2f", book.RealizedPnL)
+	}
+
+	// Every decision and result is audited in memory and on disk
+	audit := hedging.GetAuditLog(0)
+	actions := make([]string, 0, len(audit))
+	for _, entry := range audit {
+		actions = append(actions, entry.Action)
+	}
+	expected := []string{"TRIGGER", "EXECUTED", "RELEASE", "EXECUTED"}
+	if fmt.Sprint(actions) != fmt.Sprint(expected) {
+		t.Errorf("Expected audit actions %v, got %v", expected, actions)
+	}
+
+	file, err := os.Open(auditFile)
+	if err != nil {
+		t.Fatalf("Audit file not written: %v", err)
+	}
+	defer file.Close()
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	if lines != len(audit) {
+		t.Errorf("Expected %d audit file lines, got %d", len(audit), lines)
+	}
+}
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/indicator"
+)
+
+func TestHeikinAshiTransform(t *testing.T) {
+	t.Log("🕯️ Testing Heikin-Ashi candles as a per-indicator transform")
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	raw := []indicator.Candle{
+		{Timestamp: origin, Open: 100, High: 104, Low: 98, Close: 102, Volume: 5},
+		{Timestamp: origin.Add(5 * time.Minute), Open: 102, High: 106, Low: 101, Close: 105, Volume: 7},
+	}
+	ha := indicator.HeikinAshi(raw)
+	// Close = OHLC average; the first open is the candle's body midpoint, later ones the previous HA body's
+	want := []indicator.Candle{
+		{Timestamp: raw[0].Timestamp, Open: 101, High: 104, Low: 98, Close: 101, Volume: 5},
+		{Timestamp: raw[1].Timestamp, Open: 101, High: 106, Low: 101, Close: 103.5, Volume: 7},
+	}
+	for i := range want {
+		if ha[i] != want[i] {
+			t.Errorf("Heikin-Ashi candle %d: expected %+v, got %+v", i, want[i], ha[i])
+		}
+	}
+	if raw[1].Close != 105 {
+		t.Errorf("Expected the raw candles to be left untouched")
+	}
+
+	// A breakout on raw candles stays inside the Donchian channel on Heikin-Ashi candles
+	var candles []Candle
+	for i := 0; i < 25; i++ {
+		candles = append(candles, Candle{Timestamp: origin.Add(time.Duration(i) * 5 * time.Minute), Open: 100, High: 101, Low: 99, Close: 100})
+	}
+	candles = append(candles, Candle{Timestamp: origin.Add(25 * 5 * time.Minute), Open: 100, High: 102, Low: 99, Close: 101.8})
+
+	config := DefaultConfig()
+	for _, info := range Indicators() {
+		info.SetEnabled(&config, false)
+	}
+	config.DonchianChannel.Enabled = true
+	signalFor := func(config Config) IndicatorSignal {
+		signals := NewSignalAggregator(config).getTimeframeSignals(candles, FiveMinute, 101.8)
+		if len(signals) != 1 {
+			t.Fatalf("Expected one Donchian signal, got %d", len(signals))
+		}
+		return signals[0]
+	}
+	if signal := signalFor(config); signal.Signal != Buy {
+		t.Errorf("Expected a raw breakout BUY, got %+v", signal)
+	}
+	config.CandleTransforms = map[string]string{"dc": "heikin_ashi"}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected an aliased transform to be valid: %v", err)
+	}
+	if signal := signalFor(config); signal.Signal != Hold || math.Abs(signal.Value-0.85) > 1e-9 {
+		t.Errorf("Expected the Heikin-Ashi close (100.7) to hold inside the channel, got %+v", signal)
+	}
+
+	// Only the listed indicators are transformed
+	config.RSI.Enabled = true
+	sa := NewSignalAggregator(config)
+	if sa.transforms[FiveMinute][0] != nil || sa.transforms[FiveMinute][1] == nil {
+		t.Errorf("Expected only the Donchian Channel to use Heikin-Ashi candles")
+	}
+
+	config.CandleTransforms = map[string]string{"vwap": "heikin_ashi", "rsi": "renko"}
+	err := ValidateConfig(config)
+	for _, want := range []string{"candle_transforms.vwap: unknown indicator", `candle_transforms.rsi: unknown candle transform "renko"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+}
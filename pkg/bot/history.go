@@ -0,0 +1,33 @@
+package bot
+
+import "sync"
+
+// History is a bounded, concurrency-safe log that keeps the most recent items
+type History[T any] struct {
+	items    []T
+	maxItems int
+	mutex    sync.RWMutex
+}
+
+// NewHistory creates a history holding at most maxItems items
+func NewHistory[T any](maxItems int) *History[T] {
+	return &History[T]{items: make([]T, 0), maxItems: maxItems}
+}
+
+// Add appends an item, discarding the oldest once full
+func (h *History[T]) Add(item T) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.items = append(h.items, item)
+	if len(h.items) > h.maxItems {
+		h.items = h.items[len(h.items)-h.maxItems:]
+	}
+}
+
+// All returns a copy of the items, oldest first
+func (h *History[T]) All() []T {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return append([]T(nil), h.items...)
+}
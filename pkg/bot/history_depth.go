@@ -0,0 +1,82 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"trading-bot/pkg/indicator"
+)
+
+// historyShortfall is an enabled indicator that needs more candles than are loaded
+type historyShortfall struct {
+	Timeframe Timeframe
+	Indicator string // Indicator name, e.g. "Trend_1d"
+	Required  int
+	Loaded    int
+}
+
+func (s historyShortfall) String() string {
+	return fmt.Sprintf("%s needs %d %s candles, %d loaded", s.Indicator, s.Required, s.Timeframe.String(), s.Loaded)
+}
+
+// historyShortfalls checks every indicator the aggregator would run against the
+// candles loaded per timeframe, or the configured history depth when loaded is nil
+func historyShortfalls(config Config, loaded map[Timeframe]int) []historyShortfall {
+	config.Pine.Enabled = false // Studies are loaded from disk and don't report a requirement
+	sa := NewSignalAggregator(config)
+
+	var shortfalls []historyShortfall
+	for _, tf := range sa.timeframes() {
+		count := config.History.Depth(tf)
+		if loaded != nil {
+			count = loaded[tf]
+		}
+		for _, ind := range sa.indicators[tf] {
+			requirement, ok := ind.(indicator.CandleRequirement)
+			if !ok {
+				continue
+			}
+			if required := requirement.RequiredCandles(); required > count {
+				shortfalls = append(shortfalls, historyShortfall{Timeframe: tf, Indicator: ind.GetName(), Required: required, Loaded: count})
+			}
+		}
+	}
+	return shortfalls
+}
+
+// checkIndicatorHistory cross-checks the loaded candles against each enabled
+// indicator at startup. Under history.on_shortfall "disable" an indicator that
+// cannot be computed is dropped on that timeframe; otherwise startup fails.
+func (se *SignalEngine) checkIndicatorHistory() error {
+	shortfalls := historyShortfalls(se.config, se.timeframeManager.GetDataSummary())
+	if len(shortfalls) == 0 {
+		return nil
+	}
+
+	if se.config.History.OnShortfall != HistoryShortfallDisable {
+		reasons := make([]string, len(shortfalls))
+		for i, shortfall := range shortfalls {
+			reasons[i] = shortfall.String()
+		}
+		return fmt.Errorf("indicators cannot be computed: %s (raise history.candles or set history.on_shortfall to disable)", strings.Join(reasons, "; "))
+	}
+	for _, shortfall := range shortfalls {
+		engineLog.Warn("Disabling indicator", "shortfall", shortfall.String())
+	}
+	se.signalAggregator.removeIndicators(shortfalls)
+	return nil
+}
+
+// removeIndicators drops the short indicators from their timeframes
+func (sa *SignalAggregator) removeIndicators(shortfalls []historyShortfall) {
+	for _, shortfall := range shortfalls {
+		indicators, transforms := sa.indicators[shortfall.Timeframe], sa.transforms[shortfall.Timeframe]
+		for i, ind := range indicators {
+			if ind.GetName() == shortfall.Indicator {
+				sa.indicators[shortfall.Timeframe] = append(indicators[:i:i], indicators[i+1:]...)
+				sa.transforms[shortfall.Timeframe] = append(transforms[:i:i], transforms[i+1:]...)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHistoryDepth(t *testing.T) {
+	t.Log("📚 Testing configurable candle history depth per timeframe")
+
+	config := DefaultConfig()
+	if config.History.Depth(Daily) != 200 || config.History.Depth(FiveMinute) != 100 {
+		t.Errorf("Expected the default depths, got 1d=%d 5m=%d", config.History.Depth(Daily), config.History.Depth(FiveMinute))
+	}
+	if (HistoryConfig{}).Depth(EightHour) != historicalCandleCounts[EightHour] {
+		t.Errorf("Expected unlisted timeframes to use the built-in depth")
+	}
+
+	// The defaults cover every default indicator in both strategy modes
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected the default config to be valid: %v", err)
+	}
+	config.Strategy.Mode = StrategyModeMultiTimeframe
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected the default multi_timeframe config to be valid: %v", err)
+	}
+
+	// The old 30 daily candles are too few for the daily Trend and Ichimoku
+	config.History.Candles["1d"] = 30
+	err := ValidateConfig(config)
+	for _, want := range []string{"history.candles.1d: Trend_1d needs 200 candles", "Ichimoku_1d needs 52 candles"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+
+	// 5m_focus only runs the 5-minute indicators, so the daily depth no longer matters
+	config.Strategy.Mode = StrategyModeFiveMinuteFocus
+	if err := ValidateConfig(config); err != nil {
+		t.Errorf("Expected a short daily history to be fine in 5m_focus: %v", err)
+	}
+	config.EMA.TrendPeriod = 120
+	config.History.Candles["1w"] = 10
+	err = ValidateConfig(config)
+	for _, want := range []string{"history.candles.5m: EMA needs 122 candles but only 100", "history.candles.1w: unknown timeframe"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+
+	// The engine loads the configured depth and becomes ready on it
+	config = DefaultConfig()
+	config.DataProvider = "sample"
+	config.History.Candles = map[string]int{"1d": 20, "5m": 150}
+	engine := newSignalEngine(config)
+	if err := engine.initializeDataProvider(); err != nil {
+		t.Fatalf("Failed to initialize the data provider: %v", err)
+	}
+	if err := engine.loadHistoricalData(); err != nil {
+		t.Fatalf("Failed to load history: %v", err)
+	}
+	summary := engine.timeframeManager.GetDataSummary()
+	if summary[Daily] != 20 || summary[FiveMinute] != 150 || summary[EightHour] != historicalCandleCounts[EightHour] {
+		t.Errorf("Expected 20 daily, 150 5m and the default 8h candles, got %v", summary)
+	}
+	if !engine.timeframeManager.IsReady() {
+		t.Errorf("Expected 20 daily candles to be enough to become ready")
+	}
+}
+
+func TestIndicatorHistoryCheck(t *testing.T) {
+	t.Log("🧮 Testing the startup check of loaded candles against indicator requirements")
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	config.History.Candles["5m"] = 30
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "history.candles.5m: EMA needs 52 candles") {
+		t.Errorf("Expected 30 5m candles to be rejected for the EMA, got %v", err)
+	}
+
+	start := func(config Config) (*SignalEngine, error) {
+		engine := newSignalEngine(config)
+		if err := engine.initializeDataProvider(); err != nil {
+			t.Fatalf("Failed to initialize the data provider: %v", err)
+		}
+		if err := engine.loadHistoricalData(); err != nil {
+			t.Fatalf("Failed to load history: %v", err)
+		}
+		return engine, engine.checkIndicatorHistory()
+	}
+	names := func(engine *SignalEngine) map[string]bool {
+		present := make(map[string]bool)
+		for _, ind := range engine.signalAggregator.indicators[FiveMinute] {
+			present[ind.GetName()] = true
+		}
+		return present
+	}
+
+	// Fail fast, listing every indicator that cannot be computed
+	_, err := start(config)
+	for _, want := range []string{"EMA needs 52 5m candles, 30 loaded", "Ichimoku_5m needs 36 5m candles"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+
+	// Or drop them and keep the rest
+	config.History.OnShortfall = HistoryShortfallDisable
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected the disable policy to accept a short history: %v", err)
+	}
+	engine, err := start(config)
+	if err != nil {
+		t.Fatalf("Expected short indicators to be disabled, got %v", err)
+	}
+	if present := names(engine); present["EMA"] || present["Ichimoku_5m"] || !present["RSI_5m"] {
+		t.Errorf("Expected EMA and Ichimoku dropped and RSI kept, got %v", present)
+	}
+	if len(engine.signalAggregator.indicators[FiveMinute]) != len(engine.signalAggregator.transforms[FiveMinute]) {
+		t.Errorf("Expected transforms to stay parallel to indicators")
+	}
+
+	// A full history passes untouched
+	config = DefaultConfig()
+	config.DataProvider = "sample"
+	engine, err = start(config)
+	if err != nil || !names(engine)["EMA"] {
+		t.Errorf("Expected the default history to keep every indicator (err %v)", err)
+	}
+
+	config.History.OnShortfall = "warn"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "history.on_shortfall") {
+		t.Errorf("Expected an unknown policy to be rejected, got %v", err)
+	}
+}
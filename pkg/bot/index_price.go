@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// VenuePriceFunc returns a venue's latest price for a symbol
+type VenuePriceFunc func(symbol string) (float64, error)
+
+// IndexPrice is a multi-venue median price with its component quotes
+type IndexPrice struct {
+	Symbol     string             `json:"symbol"`
+	Price      float64            `json:"price"`
+	Components map[string]float64 `json:"components"`       // Price per venue that responded
+	Errors     map[string]string  `json:"errors,omitempty"` // Venues that failed
+	Timestamp  time.Time          `json:"timestamp"`
+}
+
+// IndexPriceProvider aggregates prices across venues into a median index so a
+// single exchange's anomaly can't move marks or prediction outcomes
+type IndexPriceProvider struct {
+	venues    map[string]VenuePriceFunc
+	minVenues int
+}
+
+// NewIndexPriceProvider creates an index over the given venues
+func NewIndexPriceProvider(venues map[string]VenuePriceFunc, minVenues int) *IndexPriceProvider {
+	if minVenues <= 0 {
+		minVenues = 1
+	}
+	return &IndexPriceProvider{venues: venues, minVenues: minVenues}
+}
+
+// NewIndexPriceProviderFromConfig builds an index from the configured venue names
+func NewIndexPriceProviderFromConfig(config PriceIndexConfig) *IndexPriceProvider {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	venues := make(map[string]VenuePriceFunc)
+	for _, venue := range config.Venues {
+		switch venue {
+		case "binance":
+			venues[venue] = NewBinanceFuturesDataProvider("", "").GetCurrentPrice
+		case "coinbase":
+			venues[venue] = coinbasePrice(httpClient, "https://api.exchange.coinbase.com")
+		case "kraken":
+			venues[venue] = krakenPrice(httpClient, "https://api.kraken.com")
+		}
+	}
+	return NewIndexPriceProvider(venues, config.MinVenues)
+}
+
+// GetIndexPrice queries every venue and returns the median of those that respond
+func (ip *IndexPriceProvider) GetIndexPrice(symbol string) (*IndexPrice, error) {
+	index := &IndexPrice{
+		Symbol:     symbol,
+		Components: make(map[string]float64),
+		Errors:     make(map[string]string),
+		Timestamp:  time.Now(),
+	}
+
+	prices := make([]float64, 0, len(ip.venues))
+	for name, fetch := range ip.venues {
+		price, err := fetch(symbol)
+		if err != nil {
+			index.Errors[name] = err.Error()
+			continue
+		}
+		if price <= 0 {
+			index.Errors[name] = "non-positive price"
+			continue
+		}
+		index.Components[name] = price
+		prices = append(prices, price)
+	}
+
+	if len(prices) < ip.minVenues {
+		return index, fmt.Errorf("index for %s needs %d venues, only %d responded", symbol, ip.minVenues, len(prices))
+	}
+
+	index.Price = median(prices)
+	return index, nil
+}
+
+// median returns the middle value (mean of the two middle values for even counts)
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// venuePair maps a symbol to base/quote for USD venues; USD stablecoin quotes
+// are priced against USD since Coinbase/Kraken liquidity is in fiat pairs
+func venuePair(symbol string) (string, string, error) {
+	base, quote, err := SplitSymbol(symbol)
+	if err != nil {
+		return "", "", err
+	}
+	if usdStablecoins[quote] {
+		quote = "USD"
+	}
+	return base, quote, nil
+}
+
+// coinbasePrice fetches the last trade from the Coinbase Exchange ticker
+func coinbasePrice(httpClient *http.Client, baseURL string) VenuePriceFunc {
+	return func(symbol string) (float64, error) {
+		base, quote, err := venuePair(symbol)
+		if err != nil {
+			return 0, err
+		}
+
+		var tickerResp struct {
+			Price string `json:"price"`
+		}
+		if err := getVenueJSON(httpClient, fmt.Sprintf("%s/products/%s-%s/ticker", baseURL, base, quote), &tickerResp); err != nil {
+			return 0, err
+		}
+		return strconv.ParseFloat(tickerResp.Price, 64)
+	}
+}
+
+// krakenPrice fetches the last trade from the Kraken public ticker
+func krakenPrice(httpClient *http.Client, baseURL string) VenuePriceFunc {
+	return func(symbol string) (float64, error) {
+		base, quote, err := venuePair(symbol)
+		if err != nil {
+			return 0, err
+		}
+		if base == "BTC" {
+			base = "XBT" // Kraken's BTC ticker
+		}
+
+		var tickerResp struct {
+			Error  []string `json:"error"`
+			Result map[string]struct {
+				LastTrade []string `json:"c"` // [price, lot volume]
+			} `json:"result"`
+		}
+		params := url.Values{}
+		params.Add("pair", base+quote)
+		if err := getVenueJSON(httpClient, baseURL+"/0/public/Ticker?"+params.Encode(), &tickerResp); err != nil {
+			return 0, err
+		}
+		if len(tickerResp.Error) > 0 {
+			return 0, fmt.Errorf("kraken error: %v", tickerResp.Error)
+		}
+		for _, ticker := range tickerResp.Result {
+			if len(ticker.LastTrade) > 0 {
+				return strconv.ParseFloat(ticker.LastTrade[0], 64)
+			}
+		}
+		return 0, fmt.Errorf("kraken returned no ticker for %s%s", base, quote)
+	}
+}
+
+// getVenueJSON performs a GET request and decodes the JSON response
+func getVenueJSON(httpClient *http.Client, endpoint string, out interface{}) error {
+	resp, err := httpClient.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package bot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIndexPrice(t *testing.T) {
+	t.Log("🌐 Testing multi-venue median index price")
+
+	// One venue printing an anomalous price doesn't move the median
+	index := NewIndexPriceProvider(map[string]VenuePriceFunc{
+		"binance":  func(string) (float64, error) { return 52000, nil },
+		"coinbase": func(string) (float64, error) { return 50010, nil },
+		"kraken":   func(string) (float64, error) { return 49990, nil },
+	}, 2)
+	price, err := index.GetIndexPrice("BTCUSDT")
+	if err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+	if price.Price != 50010 || len(price.Components) != 3 {
+		t.Errorf("Expected median 50010 from 3 venues, got %.2f from %d", price.Price, len(price.Components))
+	}
+
+	// A failed venue is reported; two remaining venues average
+	index = NewIndexPriceProvider(map[string]VenuePriceFunc{
+		"binance":  func(string) (float64, error) { return 0, fmt.Errorf("timeout") },
+		"coinbase": func(string) (float64, error) { return 50010, nil },
+		"kraken":   func(string) (float64, error) { return 49990, nil },
+	}, 2)
+	price, err = index.GetIndexPrice("BTCUSDT")
+	if err != nil || price.Price != 50000 || price.Errors["binance"] == "" {
+		t.Errorf("Expected 50000 with binance error, got %+v (err %v)", price, err)
+	}
+
+	// Below the venue quorum the index is unavailable
+	index = NewIndexPriceProvider(map[string]VenuePriceFunc{
+		"binance":  func(string) (float64, error) { return 0, fmt.Errorf("timeout") },
+		"coinbase": func(string) (float64, error) { return 50010, nil },
+	}, 2)
+	if _, err := index.GetIndexPrice("BTCUSDT"); err == nil {
+		t.Errorf("Expected error below min venues")
+	}
+
+	// Venue adapters map symbols to each venue's USD pair
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/products/BTC-USD/ticker":
+			w.Write([]byte(`{"price":"50001.5"}`))
+		case r.URL.Path == "/0/public/Ticker" && r.URL.Query().Get("pair") == "XBTUSD":
+			w.Write([]byte(`{"error":[],"result":{"XXBTZUSD":{"c":["49998.5","0.01"]}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	if price, err := coinbasePrice(server.Client(), server.URL)("BTCUSDT"); err != nil || price != 50001.5 {
+		t.Errorf("Coinbase adapter: got %.2f, err %v", price, err)
+	}
+	if price, err := krakenPrice(server.Client(), server.URL)("BTCUSDT"); err != nil || price != 49998.5 {
+		t.Errorf("Kraken adapter: got %.2f, err %v", price, err)
+	}
+}
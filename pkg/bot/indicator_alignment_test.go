@@ -35,12 +35,7 @@ func TestIndicatorDataAlignment(t *testing.T) {
 		currentPrice := histData.GetActualPriceAt(testTime)
 		futurePrice := histData.GetActualPriceAt(testTime.Add(5 * time.Minute))
 		priceChange := futurePrice - currentPrice
-		actualDirection := "NEUTRAL"
-		if priceChange > 3 {
-			actualDirection = "HIGHER"
-		} else if priceChange < -3 {
-			actualDirection = "LOWER"
-		}
+		actualDirection := actualDirectionFor(histData.GetCandles(FiveMinute, testTime, 100), priceChange)
 
 		t.Logf("   Price Movement: $%.2f -> $%.2f (change: $%.2f, direction: %s)",
 			currentPrice, futurePrice, priceChange, actualDirection)
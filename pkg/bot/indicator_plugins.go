@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"plugin"
+	"regexp"
+
+	"trading-bot/pkg/indicator"
+)
+
+// IndicatorPlugin is an indicator added without changing the bot. It registers
+// itself, typically from an init function in its own package or Go plugin file,
+// and is configured in the "indicators" map by name.
+type IndicatorPlugin struct {
+	Name        string   // Key in the "indicators" map and toggle name (lower_snake_case)
+	DisplayName string   // Human-readable name (default: Name)
+	Aliases     []string // Alternative toggle names
+	Weight      float64  // Vote weight of its signals (default: 3, like unknown indicators)
+
+	// Params returns a pointer to the plugin's parameter struct set to its defaults.
+	// The configured "params" are decoded into it, rejecting unknown fields. Optional.
+	Params func() interface{}
+
+	// New builds the indicator for a timeframe from the decoded parameters (nil
+	// without Params). A nil indicator skips the timeframe. Signals are weighted by
+	// name, so GetSignal should name them as GetName does.
+	New func(params interface{}, timeframe indicator.Timeframe) (indicator.TechnicalIndicator, error)
+}
+
+// pluginNamePattern is the form plugin names and aliases take
+var pluginNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// RegisterIndicator adds a plugin to the indicator registry, after the built-in
+// indicators. Names and aliases must not clash with registered ones.
+func RegisterIndicator(p IndicatorPlugin) error {
+	if p.New == nil {
+		return fmt.Errorf("indicator plugin %s has no constructor", p.Name)
+	}
+	if p.DisplayName == "" {
+		p.DisplayName = p.Name
+	}
+	if p.Weight == 0 {
+		p.Weight = 3.0
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	for _, name := range append([]string{p.Name}, p.Aliases...) {
+		if !pluginNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid indicator plugin name %q (use lower_snake_case)", name)
+		}
+		if existing, ok := lookupIndicatorLocked(name); ok {
+			return fmt.Errorf("indicator plugin name %s is already used by %s", name, existing.Name)
+		}
+	}
+	indicatorRegistry = append(indicatorRegistry, IndicatorInfo{
+		Name:        p.Name,
+		DisplayName: p.DisplayName,
+		Aliases:     p.Aliases,
+		plugin:      &p,
+	})
+	return nil
+}
+
+// unregisterIndicator removes a registered plugin
+func unregisterIndicator(name string) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	for i, info := range indicatorRegistry {
+		if info.plugin != nil && info.Name == name {
+			indicatorRegistry = append(indicatorRegistry[:i:i], indicatorRegistry[i+1:]...)
+			return
+		}
+	}
+}
+
+// newIndicator decodes the configured parameters and builds the indicator for a timeframe
+func (p *IndicatorPlugin) newIndicator(config PluginConfig, tf indicator.Timeframe) (indicator.TechnicalIndicator, error) {
+	var params interface{}
+	if p.Params != nil {
+		params = p.Params()
+		if len(config.Params) > 0 {
+			decoder := json.NewDecoder(bytes.NewReader(config.Params))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(params); err != nil {
+				return nil, fmt.Errorf("invalid %s params: %w", p.Name, err)
+			}
+		}
+	} else if len(config.Params) > 0 && string(config.Params) != "null" {
+		return nil, fmt.Errorf("%s takes no params", p.Name)
+	}
+	return p.New(params, tf)
+}
+
+// LoadIndicatorPlugins opens Go plugin files (built with -buildmode=plugin); each
+// registers its indicators from init. Opening a file twice is harmless.
+func LoadIndicatorPlugins(paths []string) error {
+	for _, path := range paths {
+		if _, err := plugin.Open(path); err != nil {
+			return fmt.Errorf("failed to load indicator plugin %s: %w", path, err)
+		}
+		engineLog.Info("🔌 Loaded indicator plugin file", "file", path)
+	}
+	return nil
+}
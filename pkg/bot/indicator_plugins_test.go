@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/indicator"
+)
+
+// momentumPlugin signals Buy when the close rose over Period candles
+type momentumPlugin struct {
+	period    int
+	timeframe indicator.Timeframe
+}
+
+type momentumParams struct {
+	Period int `json:"period"`
+}
+
+func (m *momentumPlugin) GetName() string { return "Momentum_" + m.timeframe.String() }
+
+func (m *momentumPlugin) Calculate(candles []indicator.Candle) []float64 {
+	var values []float64
+	for i := m.period; i < len(candles); i++ {
+		values = append(values, candles[i].Close-candles[i-m.period].Close)
+	}
+	return values
+}
+
+func (m *momentumPlugin) GetSignal(values []float64, currentPrice float64) indicator.IndicatorSignal {
+	signal := indicator.IndicatorSignal{Name: m.GetName(), Signal: indicator.Hold, Timestamp: time.Now(), Timeframe: m.timeframe}
+	if len(values) > 0 && values[len(values)-1] > 0 {
+		signal.Signal, signal.Strength, signal.Value = indicator.Buy, 0.8, values[len(values)-1]
+	}
+	return signal
+}
+
+func TestIndicatorPlugins(t *testing.T) {
+	t.Log("🔌 Testing self-registering indicator plugins")
+
+	err := RegisterIndicator(IndicatorPlugin{
+		Name:        "momentum",
+		DisplayName: "Momentum",
+		Aliases:     []string{"mom"},
+		Weight:      7.5,
+		Params:      func() interface{} { return &momentumParams{Period: 3} },
+		New: func(params interface{}, tf indicator.Timeframe) (indicator.TechnicalIndicator, error) {
+			p := params.(*momentumParams)
+			if p.Period < 1 {
+				return nil, fmt.Errorf("period must be positive")
+			}
+			return &momentumPlugin{period: p.Period, timeframe: tf}, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unregisterIndicator("momentum")
+
+	if err := RegisterIndicator(IndicatorPlugin{Name: "rsi_fast", Aliases: []string{"rsi"}, New: func(interface{}, indicator.Timeframe) (indicator.TechnicalIndicator, error) { return nil, nil }}); err == nil || !strings.Contains(err.Error(), "already used by rsi") {
+		t.Errorf("Expected a clashing alias to be rejected, got %v", err)
+	}
+	if info, ok := LookupIndicator("MOM"); !ok || info.Name != "momentum" || Indicators()[len(Indicators())-1].Name != "momentum" {
+		t.Errorf("Expected the plugin registered after the built-ins, got %+v", info)
+	}
+
+	// Toggled like any indicator, without sharing flags with the config it was copied from
+	config := DefaultConfig()
+	for _, info := range Indicators() {
+		info.SetEnabled(&config, false)
+	}
+	cm := NewConfigManager("")
+	cm.config = config
+	if err := cm.EnableIndicator("mom"); err != nil || !cm.GetConfig().Plugins["momentum"].Enabled || config.Plugins["momentum"].Enabled {
+		t.Fatalf("Expected the plugin enabled on the manager's copy only (err %v)", err)
+	}
+	config = cm.GetConfig()
+	config.Plugins["momentum"] = PluginConfig{Enabled: true, Params: json.RawMessage(`{"period": 2}`)}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected the plugin config to be valid: %v", err)
+	}
+
+	// Built, named and weighted by the aggregator with no plugin-specific code
+	sa := NewSignalAggregator(config)
+	if names := sa.GetActiveIndicatorNames(); len(names) != 1 || names[0] != "Momentum" || sa.GetTotalActiveIndicators() != 1 {
+		t.Errorf("Expected Momentum as the only active indicator, got %v", names)
+	}
+	candles := syntheticCandles(FiveMinute, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 10)
+	for i := range candles {
+		candles[i].Close = float64(100 + i)
+	}
+	signals := sa.getTimeframeSignals(candles, FiveMinute, 109)
+	if len(signals) != 1 || signals[0].Name != "Momentum_5m" || signals[0].Signal != Buy || signals[0].Value != 2 {
+		t.Fatalf("Expected a 2-candle momentum BUY, got %+v", signals)
+	}
+	if weight := sa.getIndicatorWeight("Momentum_5m"); weight != 7.5 {
+		t.Errorf("Expected the plugin's weight, got %.1f", weight)
+	}
+	if summary := GetConfigSummary(config); !strings.Contains(summary, "✅ Momentum: plugin") {
+		t.Errorf("Expected the plugin in the config summary:\n%s", summary)
+	}
+
+	config.Plugins["momentum"] = PluginConfig{Enabled: true, Params: json.RawMessage(`{"period": 2, "smoothing": 3}`)}
+	config.Plugins["vwap"] = PluginConfig{Enabled: true}
+	err = ValidateConfig(config)
+	for _, want := range []string{`indicators.momentum: invalid momentum params: json: unknown field "smoothing"`, "indicators.vwap: unknown indicator vwap"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+	if err := LoadIndicatorPlugins([]string{"missing.so"}); err == nil || !strings.Contains(err.Error(), "failed to load indicator plugin missing.so") {
+		t.Errorf("Expected a missing plugin file to fail, got %v", err)
+	}
+}
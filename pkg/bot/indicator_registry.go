@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"strings"
+	"sync"
+
+	"trading-bot/pkg/indicator"
+)
+
+// IndicatorInfo describes a configurable indicator, where its feature flag lives
+// and how it is built for a timeframe
+type IndicatorInfo struct {
+	Name        string   // Canonical toggle name, matching the config JSON key
+	DisplayName string   // Human-readable name used in summaries
+	Aliases     []string // Alternative toggle names
+	enabled     func(*Config) *bool
+	build       func(Config, indicator.Timeframe) indicator.TechnicalIndicator // nil result skips the timeframe
+	plugin      *IndicatorPlugin                                               // Set for registered plugins, configured under "plugins"
+}
+
+// registryMutex guards indicatorRegistry against plugins registering at runtime
+var registryMutex sync.RWMutex
+
+// indicatorRegistry lists every indicator the signal aggregator can use
+var indicatorRegistry = []IndicatorInfo{
+	{Name: "rsi", DisplayName: "RSI", enabled: func(c *Config) *bool { return &c.RSI.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewRSI(convertRSIConfig(c.RSI), tf)
+		}},
+	{Name: "macd", DisplayName: "MACD", enabled: func(c *Config) *bool { return &c.MACD.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewMACD(convertMACDConfig(c.MACD), tf)
+		}},
+	{Name: "volume", DisplayName: "Volume", enabled: func(c *Config) *bool { return &c.Volume.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewVolume(convertVolumeConfig(c.Volume), tf)
+		}},
+	{Name: "trend", DisplayName: "Trend", enabled: func(c *Config) *bool { return &c.Trend.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewTrend(convertTrendConfig(c.Trend), tf)
+		}},
+	{Name: "support_resistance", DisplayName: "Support/Resistance", Aliases: []string{"sr"}, enabled: func(c *Config) *bool { return &c.SupportResistance.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewSupportResistance(convertSupportResistanceConfig(c.SupportResistance), tf)
+		}},
+	{Name: "ichimoku", DisplayName: "Ichimoku", enabled: func(c *Config) *bool { return &c.Ichimoku.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewIchimoku(convertIchimokuConfig(c.Ichimoku), tf)
+		}},
+	{Name: "mfi", DisplayName: "Reverse-MFI", Aliases: []string{"reverse_mfi"}, enabled: func(c *Config) *bool { return &c.MFI.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewReverseMFI(convertMFIConfig(c.MFI), tf)
+		}},
+	{Name: "bollinger_bands", DisplayName: "Bollinger Bands", Aliases: []string{"bb"}, enabled: func(c *Config) *bool { return &c.BollingerBands.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewBollingerBands(convertBollingerBandsConfig(c.BollingerBands), tf)
+		}},
+	{Name: "stochastic", DisplayName: "Stochastic", Aliases: []string{"stoch"}, enabled: func(c *Config) *bool { return &c.Stochastic.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewStochastic(convertStochasticConfig(c.Stochastic), tf)
+		}},
+	{Name: "williams_r", DisplayName: "Williams %R", Aliases: []string{"williams", "wr"}, enabled: func(c *Config) *bool { return &c.WilliamsR.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewWilliamsR(convertWilliamsRConfig(c.WilliamsR), tf)
+		}},
+	{Name: "pin_bar", DisplayName: "Pin Bar", Aliases: []string{"pinbar"}, enabled: func(c *Config) *bool { return &c.PinBar.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewPinBar(convertPinBarConfig(c.PinBar), tf)
+		}},
+	{Name: "ema", DisplayName: "EMA", enabled: func(c *Config) *bool { return &c.EMA.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewEMA(convertEMAConfig(c.EMA), tf)
+		}},
+	{Name: "elliott_wave", DisplayName: "Elliott Wave", Aliases: []string{"elliott"}, enabled: func(c *Config) *bool { return &c.ElliottWave.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewElliottWave(convertElliottWaveConfig(c.ElliottWave), tf)
+		}},
+	{Name: "channel_analysis", DisplayName: "Channel Analysis", Aliases: []string{"channel"}, enabled: func(c *Config) *bool { return &c.ChannelAnalysis.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			if tf != indicator.FiveMinute {
+				return nil // Works best on 5-minute candles
+			}
+			return indicator.NewChannelAnalysis(convertChannelAnalysisConfig(c.ChannelAnalysis), tf)
+		}},
+	{Name: "keltner_channel", DisplayName: "Keltner Channel", Aliases: []string{"keltner", "kc"}, enabled: func(c *Config) *bool { return &c.KeltnerChannel.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewKeltnerChannel(convertKeltnerChannelConfig(c.KeltnerChannel), tf)
+		}},
+	{Name: "donchian_channel", DisplayName: "Donchian Channel", Aliases: []string{"donchian", "dc"}, enabled: func(c *Config) *bool { return &c.DonchianChannel.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewDonchianChannel(convertDonchianChannelConfig(c.DonchianChannel), tf)
+		}},
+	{Name: "parabolic_sar", DisplayName: "Parabolic SAR", Aliases: []string{"psar", "sar"}, enabled: func(c *Config) *bool { return &c.ParabolicSAR.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewParabolicSAR(convertParabolicSARConfig(c.ParabolicSAR), tf)
+		}},
+	{Name: "candle_patterns", DisplayName: "Candle Patterns", Aliases: []string{"patterns", "candlestick"}, enabled: func(c *Config) *bool { return &c.CandlePatterns.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewCandlePatterns(convertCandlePatternsConfig(c.CandlePatterns), tf)
+		}},
+	{Name: "atr", DisplayName: "ATR", enabled: func(c *Config) *bool { return &c.ATR.Enabled },
+		build: func(c Config, tf indicator.Timeframe) indicator.TechnicalIndicator {
+			return indicator.NewATR(convertATRConfig(c.ATR), tf)
+		}},
+}
+
+// Indicators returns every registered indicator in aggregation order
+func Indicators() []IndicatorInfo {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return append([]IndicatorInfo(nil), indicatorRegistry...)
+}
+
+// LookupIndicator finds a registered indicator by name or alias (case-insensitive)
+func LookupIndicator(name string) (IndicatorInfo, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	return lookupIndicatorLocked(name)
+}
+
+// lookupIndicatorLocked is LookupIndicator for callers holding registryMutex
+func lookupIndicatorLocked(name string) (IndicatorInfo, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, info := range indicatorRegistry {
+		if info.Name == name {
+			return info, true
+		}
+		for _, alias := range info.Aliases {
+			if alias == name {
+				return info, true
+			}
+		}
+	}
+	return IndicatorInfo{}, false
+}
+
+// IndicatorNames returns the canonical names of all registered indicators
+func IndicatorNames() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	names := make([]string, 0, len(indicatorRegistry))
+	for _, info := range indicatorRegistry {
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+// IsEnabled reports whether the indicator is enabled in config
+func (info IndicatorInfo) IsEnabled(config Config) bool {
+	if info.plugin != nil {
+		return config.Plugins[info.Name].Enabled
+	}
+	return *info.enabled(&config)
+}
+
+// SetEnabled sets the indicator's feature flag in config
+func (info IndicatorInfo) SetEnabled(config *Config, enabled bool) {
+	if info.plugin != nil {
+		// Copy the map so configs copied from this one keep their own flags
+		plugins := make(map[string]PluginConfig, len(config.Plugins)+1)
+		for name, plugin := range config.Plugins {
+			plugins[name] = plugin
+		}
+		plugin := plugins[info.Name]
+		plugin.Enabled = enabled
+		plugins[info.Name] = plugin
+		config.Plugins = plugins
+		return
+	}
+	*info.enabled(config) = enabled
+}
+
+// newIndicator builds the indicator for a timeframe from config; nil means the
+// indicator doesn't run on that timeframe
+func (info IndicatorInfo) newIndicator(config Config, tf indicator.Timeframe) (indicator.TechnicalIndicator, error) {
+	if info.plugin != nil {
+		return info.plugin.newIndicator(config.Plugins[info.Name], tf)
+	}
+	return info.build(config, tf), nil
+}
@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIndicatorRegistryToggles(t *testing.T) {
+	t.Log("🎛️ Testing indicator toggles cover every registered indicator")
+
+	// Every indicator config block (struct fields before MinConfidence) is registered under its JSON key
+	configType := reflect.TypeOf(Config{})
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		if field.Name == "MinConfidence" {
+			break
+		}
+		if field.Type.Kind() != reflect.Struct {
+			continue
+		}
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		if _, ok := LookupIndicator(key); !ok {
+			t.Errorf("Indicator config %s (%s) is not registered", field.Name, key)
+		}
+	}
+	if len(IndicatorNames()) != 19 {
+		t.Errorf("Expected 19 registered indicators, got %d", len(IndicatorNames()))
+	}
+
+	// Each name toggles exactly its own flag
+	cm := NewConfigManager("")
+	if err := cm.DisableIndicator("all"); err != nil {
+		t.Fatalf("Disable all failed: %v", err)
+	}
+	for _, info := range Indicators() {
+		if err := cm.ToggleIndicator(info.Name); err != nil {
+			t.Fatalf("Toggle %s failed: %v", info.Name, err)
+		}
+		enabled := cm.GetEnabledIndicators()
+		if len(enabled) != 1 || enabled[0] != info.DisplayName {
+			t.Errorf("Toggling %s enabled %v", info.Name, enabled)
+		}
+		if err := cm.DisableIndicator(info.Name); err != nil || len(cm.GetEnabledIndicators()) != 0 {
+			t.Errorf("Disabling %s failed: %v", info.Name, err)
+		}
+	}
+
+	// Aliases and case are accepted; unknown names list the registry
+	if err := cm.EnableIndicator("Williams"); err != nil || !cm.GetConfig().WilliamsR.Enabled {
+		t.Errorf("Expected alias to enable Williams %%R: %v", err)
+	}
+	if err := cm.EnableIndicator("all"); err != nil || len(cm.GetEnabledIndicators()) != 19 {
+		t.Errorf("Expected all 19 indicators enabled, got %v", cm.GetEnabledIndicators())
+	}
+	err := cm.ToggleIndicator("vwap")
+	if err == nil || !strings.Contains(err.Error(), "channel_analysis") {
+		t.Errorf("Expected unknown indicator error listing the registry, got %v", err)
+	}
+}
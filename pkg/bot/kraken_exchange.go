@@ -0,0 +1,278 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// krakenMaxCandles is the most recent candles Kraken keeps per interval; older
+// history isn't served
+const krakenMaxCandles = 720
+
+// KrakenExchange implements Exchange for the Kraken spot API. Real-time
+// candles are polled; 8h candles are aggregated from 4h ones.
+type KrakenExchange struct {
+	*candlePoller
+	baseURL     string
+	credentials ExchangeCredentials
+	httpClient  *http.Client
+}
+
+// NewKrakenExchange creates a Kraken client
+func NewKrakenExchange(credentials ExchangeCredentials) *KrakenExchange {
+	return &KrakenExchange{
+		candlePoller: newCandlePoller(30 * time.Second),
+		baseURL:      "https://api.kraken.com",
+		credentials:  credentials,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns the venue name
+func (k *KrakenExchange) Name() string {
+	return "kraken"
+}
+
+// pair maps a symbol to a Kraken pair (BTCUSDT -> XBTUSD)
+func (k *KrakenExchange) pair(symbol string) (string, error) {
+	base, quote, err := venuePair(symbol)
+	if err != nil {
+		return "", err
+	}
+	if base == "BTC" {
+		base = "XBT" // Kraken's BTC ticker
+	}
+	return base + quote, nil
+}
+
+// krakenAsset maps a Kraken asset code to its common name (XXBT -> BTC, ZUSD -> USD)
+func krakenAsset(asset string) string {
+	if len(asset) == 4 && (asset[0] == 'X' || asset[0] == 'Z') {
+		asset = asset[1:]
+	}
+	if asset == "XBT" {
+		return "BTC"
+	}
+	return asset
+}
+
+// interval maps a timeframe to a Kraken OHLC interval in minutes
+func (k *KrakenExchange) interval(timeframe Timeframe) venueInterval {
+	switch timeframe {
+	case FifteenMinute:
+		return venueInterval{param: "15", length: 15 * time.Minute}
+	case FortyFiveMinute:
+		return venueInterval{param: "60", length: time.Hour} // No 45m, use 1h as closest
+	case EightHour:
+		return venueInterval{param: "240", length: 4 * time.Hour, aggregate: true}
+	case Daily:
+		return venueInterval{param: "1440", length: 24 * time.Hour}
+	default:
+		return venueInterval{param: "5", length: 5 * time.Minute}
+	}
+}
+
+// getResult performs a public GET request and unwraps Kraken's result envelope
+func (k *KrakenExchange) getResult(path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, k.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	return k.doResult(req, out)
+}
+
+// doResult sends a request and decodes the result of Kraken's {error, result} envelope
+func (k *KrakenExchange) doResult(req *http.Request, out interface{}) error {
+	var envelope struct {
+		Error  []string        `json:"error"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := doExchangeRequest(k.httpClient, req, &envelope); err != nil {
+		return err
+	}
+	if len(envelope.Error) > 0 {
+		return fmt.Errorf("kraken error: %v", envelope.Error)
+	}
+	if err := json.Unmarshal(envelope.Result, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// GetHistoricalData fetches the latest count candles
+func (k *KrakenExchange) GetHistoricalData(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	return latestVenueCandles(k, k.clock, symbol, timeframe, k.interval(timeframe), count)
+}
+
+// GetHistoricalRange fetches the candles opening in [start, end) among the 720
+// most recent of the interval
+func (k *KrakenExchange) GetHistoricalRange(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	pair, err := k.pair(symbol)
+	if err != nil {
+		return nil, err
+	}
+	interval := k.interval(timeframe)
+
+	return fetchVenueRange(k.clock, interval, timeframe, start, end, krakenMaxCandles, func(from, to time.Time) ([]Candle, error) {
+		params := url.Values{}
+		params.Add("pair", pair)
+		params.Add("interval", interval.param)
+		params.Add("since", strconv.FormatInt(from.Unix()-1, 10))
+
+		// Result holds the pair's rows [time, open, high, low, close, vwap, volume, count] and "last"
+		var result map[string]json.RawMessage
+		if err := k.getResult("/0/public/OHLC", params, &result); err != nil {
+			return nil, err
+		}
+		candles := make([]Candle, 0)
+		for key, raw := range result {
+			if key == "last" {
+				continue
+			}
+			var rows [][]interface{}
+			if err := json.Unmarshal(raw, &rows); err != nil {
+				return nil, fmt.Errorf("failed to parse candles: %w", err)
+			}
+			for i, row := range rows {
+				fields := jsonStrings(row)
+				values, err := parseFloats(fields, "time", "open", "high", "low", "close", "vwap", "volume")
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert candle %d: %w", i, err)
+				}
+				timestamp := time.Unix(int64(values[0]), 0)
+				if timestamp.Before(from) || !timestamp.Before(to) {
+					continue
+				}
+				candles = append(candles, Candle{
+					Timestamp: timestamp,
+					Open:      values[1],
+					High:      values[2],
+					Low:       values[3],
+					Close:     values[4],
+					Volume:    values[6],
+				})
+			}
+		}
+		return candles, nil
+	})
+}
+
+// GetRealTimeData polls for completed candles
+func (k *KrakenExchange) GetRealTimeData(symbol string, timeframe Timeframe) (<-chan Candle, error) {
+	return k.poll(symbol, timeframe, k.GetHistoricalData), nil
+}
+
+// GetTicker fetches the last trade and top of book
+func (k *KrakenExchange) GetTicker(symbol string) (*Ticker, error) {
+	pair, err := k.pair(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("pair", pair)
+	var result map[string]struct {
+		Ask       []string `json:"a"` // [price, whole lot volume, lot volume]
+		Bid       []string `json:"b"`
+		LastTrade []string `json:"c"` // [price, lot volume]
+		Volume    []string `json:"v"` // [today, last 24 hours]
+	}
+	if err := k.getResult("/0/public/Ticker", params, &result); err != nil {
+		return nil, err
+	}
+	for _, ticker := range result {
+		if len(ticker.Ask) == 0 || len(ticker.Bid) == 0 || len(ticker.LastTrade) == 0 || len(ticker.Volume) < 2 {
+			break
+		}
+		values, err := parseFloats([]string{ticker.LastTrade[0], ticker.Bid[0], ticker.Ask[0], ticker.Volume[1]}, "price", "bid", "ask", "volume")
+		if err != nil {
+			return nil, err
+		}
+		return &Ticker{Symbol: symbol, Last: values[0], Bid: values[1], Ask: values[2], Volume: values[3], Timestamp: time.Now()}, nil
+	}
+	return nil, fmt.Errorf("kraken returned no ticker for %s", pair)
+}
+
+// GetOrderBook fetches depth levels per side of the order book
+func (k *KrakenExchange) GetOrderBook(symbol string, depth int) (*OrderBook, error) {
+	pair, err := k.pair(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("pair", pair)
+	if depth > 0 {
+		params.Add("count", strconv.Itoa(depth))
+	}
+	var result map[string]struct {
+		Asks [][]interface{} `json:"asks"` // [price, volume, timestamp]
+		Bids [][]interface{} `json:"bids"`
+	}
+	if err := k.getResult("/0/public/Depth", params, &result); err != nil {
+		return nil, err
+	}
+	for _, depthResp := range result {
+		book := &OrderBook{Symbol: symbol, Timestamp: time.Now()}
+		if book.Bids, err = parseBookLevels(depthResp.Bids, depth); err != nil {
+			return nil, err
+		}
+		if book.Asks, err = parseBookLevels(depthResp.Asks, depth); err != nil {
+			return nil, err
+		}
+		return book, nil
+	}
+	return nil, fmt.Errorf("kraken returned no order book for %s", pair)
+}
+
+// GetBalances fetches the balance of every asset
+func (k *KrakenExchange) GetBalances() (map[string]float64, error) {
+	if k.credentials.APIKey == "" || k.credentials.SecretKey == "" {
+		return nil, fmt.Errorf("API keys required for /0/private/Balance")
+	}
+	secret, err := base64.StdEncoding.DecodeString(k.credentials.SecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kraken secret: %w", err)
+	}
+
+	// API-Sign is HMAC-SHA512 of the path and SHA256(nonce + body)
+	path := "/0/private/Balance"
+	nonce := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	body := url.Values{"nonce": {nonce}}.Encode()
+	digest := sha256.Sum256([]byte(nonce + body))
+	mac := hmac.New(sha512.New, secret)
+	mac.Write(append([]byte(path), digest[:]...))
+
+	req, err := http.NewRequest(http.MethodPost, k.baseURL+path, strings.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("API-Key", k.credentials.APIKey)
+	req.Header.Set("API-Sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var result map[string]string
+	if err := k.doResult(req, &result); err != nil {
+		return nil, err
+	}
+	balances := make(map[string]float64)
+	for asset, amount := range result {
+		balance, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s balance: %w", asset, err)
+		}
+		if balance != 0 {
+			balances[krakenAsset(asset)] += balance
+		}
+	}
+	return balances, nil
+}
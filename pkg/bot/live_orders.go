@@ -0,0 +1,292 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// errOrderInFlight refuses an exchange request while another one is running
+var errOrderInFlight = errors.New("another order request is in flight")
+
+// OrderRequest is an order sent to the exchange
+type OrderRequest struct {
+	ClientID   string // Local order ID, sent as the client order ID
+	Symbol     string
+	Side       string // "BUY" or "SELL"
+	Type       string // "MARKET", "LIMIT" or "STOP_MARKET"
+	Quantity   float64
+	Price      float64 // LIMIT price; for MARKET orders the expected fill (not sent)
+	StopPrice  float64 // STOP_MARKET trigger
+	ReduceOnly bool    // Futures: only ever reduce the position
+}
+
+// OrderPlacer places and cancels exchange orders
+type OrderPlacer interface {
+	PlaceOrder(req OrderRequest) (*ExchangeOrderState, error)
+	CancelOrder(symbol, orderID string) error
+}
+
+// DryRunOrderPlacer logs orders instead of sending them. MARKET and LIMIT
+// orders fill in full at their price; STOP_MARKET orders stay working.
+type DryRunOrderPlacer struct {
+	mutex  sync.Mutex
+	nextID int64
+	clock  Clock
+}
+
+// NewDryRunOrderPlacer creates a dry-run placer; the executor it is attached
+// to hands it its clock
+func NewDryRunOrderPlacer() *DryRunOrderPlacer {
+	return &DryRunOrderPlacer{clock: SystemClock}
+}
+
+// SetClock replaces the time source stamped on simulated order states
+func (d *DryRunOrderPlacer) SetClock(clock Clock) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.clock = clock
+}
+
+// PlaceOrder logs the order and simulates its immediate outcome
+func (d *DryRunOrderPlacer) PlaceOrder(req OrderRequest) (*ExchangeOrderState, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.nextID++
+	state := &ExchangeOrderState{
+		OrderID:    "dry_" + strconv.FormatInt(d.nextID, 10),
+		Symbol:     req.Symbol,
+		Side:       req.Side,
+		Status:     "NEW",
+		Quantity:   req.Quantity,
+		UpdateTime: d.clock.Now(),
+	}
+	if req.Type != "STOP_MARKET" {
+		state.Status = "FILLED"
+		state.ExecutedQuantity = req.Quantity
+		state.AveragePrice = req.Price
+	}
+	executorLog.Info("🧪 Dry run order", "type", req.Type, "side", req.Side, "symbol", req.Symbol, "quantity", req.Quantity,
+		"price", req.Price, "stop_price", req.StopPrice, "reduce_only", req.ReduceOnly, "status", state.Status)
+	return state, nil
+}
+
+// CancelOrder logs the cancellation
+func (d *DryRunOrderPlacer) CancelOrder(symbol, orderID string) error {
+	executorLog.Info("🧪 Dry run cancel", "symbol", symbol, "order_id", orderID)
+	return nil
+}
+
+// SetOrderPlacer routes the signal strategy's orders through placer instead
+// of filling them locally; fills then come from the exchange's order states
+func (te *TradeExecutor) SetOrderPlacer(placer OrderPlacer, config LiveTradingConfig) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.orders = placer
+	te.liveConfig = config
+	if clocked, ok := placer.(interface{ SetClock(Clock) }); ok {
+		clocked.SetClock(te.clock)
+	}
+}
+
+// exchangeCall runs one exchange request with the lock released. Only one
+// request runs at a time; while it does, inFlight holds its order ID so
+// signals, exits and repairs leave the position alone (assumes lock is held)
+func (te *TradeExecutor) exchangeCall(orderID string, call func(placer OrderPlacer) error) error {
+	if te.inFlight != "" {
+		return fmt.Errorf("order %s: %w", te.inFlight, errOrderInFlight)
+	}
+	placer := te.orders
+	te.inFlight = orderID
+	te.mutex.Unlock()
+	defer func() {
+		te.mutex.Lock()
+		te.inFlight = ""
+	}()
+	return call(placer)
+}
+
+// placeOrder sends an order, tracks it and applies its immediate fills.
+// The order is tracked before it is sent, so fills streamed back while the
+// request runs find it (assumes lock is held)
+func (te *TradeExecutor) placeOrder(req OrderRequest, confidence float64, reason string) (*Order, error) {
+	order := &Order{
+		ID:          te.nextID("order"),
+		Symbol:      req.Symbol,
+		Side:        req.Side,
+		Type:        req.Type,
+		Quantity:    req.Quantity,
+		Price:       req.Price,
+		Status:      "PENDING",
+		CreatedTime: te.now(),
+		Strategy:    ATRStrategyName,
+		Confidence:  confidence,
+		Reason:      reason,
+	}
+	if req.Type == "STOP_MARKET" {
+		order.Price = req.StopPrice
+	}
+	req.ClientID = order.ID
+	te.openOrders[order.ID] = order
+
+	var state *ExchangeOrderState
+	err := te.exchangeCall(order.ID, func(placer OrderPlacer) (err error) {
+		state, err = placer.PlaceOrder(req)
+		return err
+	})
+	if err != nil {
+		if order.FilledQuantity == 0 {
+			delete(te.openOrders, order.ID)
+		}
+		return nil, fmt.Errorf("failed to place %s %s order: %w", req.Type, req.Side, err)
+	}
+	order.ExchangeID = state.OrderID
+	if _, open := te.openOrders[order.ID]; !open {
+		// Settled by the user stream while the request ran
+		return order, nil
+	}
+	executorLog.Info("📨 Order placed", "type", req.Type, "side", req.Side, "quantity", req.Quantity, "symbol", req.Symbol, "order_id", state.OrderID, "status", state.Status)
+
+	if _, err := te.applyOrderState(order.ID, *state); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// submitEntry opens a position through an entry order; the ATR stop is
+// attached once the order fills (assumes lock is held)
+func (te *TradeExecutor) submitEntry(signal *TradingSignal, side string, quantity, price, atrTrailStop float64) error {
+	orderSide := "BUY"
+	if side == "SHORT" {
+		orderSide = "SELL"
+	}
+	req := OrderRequest{
+		Symbol:   te.config.Symbol,
+		Side:     orderSide,
+		Type:     valueOrDefault(te.liveConfig.OrderType, "MARKET"),
+		Quantity: quantity,
+		Price:    te.symbolFilters.RoundPrice(price),
+	}
+	if _, err := te.placeOrder(req, signal.Confidence, ""); err != nil {
+		return err
+	}
+
+	// A working LIMIT entry gets its stop from the next trailing stop update after it fills
+	if position := te.currentPosition; position != nil && position.Side == side {
+		position.ATRTrailStop = atrTrailStop
+		position.StopLoss = atrTrailStop
+		position.Confidence = signal.Confidence
+		te.syncProtectiveStop()
+	}
+	return nil
+}
+
+// exitPosition closes the position: with a reduce-only market order when
+// orders go to the exchange, locally otherwise (assumes lock is held)
+func (te *TradeExecutor) exitPosition(reason string, exitPrice, atrTrailStop float64) error {
+	position := te.currentPosition
+	if te.orders == nil || position == nil {
+		return te.closePosition(reason, exitPrice, atrTrailStop)
+	}
+
+	for _, order := range te.openOrders {
+		if order.Reason != "" && order.ID != te.stopOrderID {
+			return fmt.Errorf("exit order %s is already working", order.ID)
+		}
+	}
+	side := "SELL"
+	if position.Side == "SHORT" {
+		side = "BUY"
+	}
+	req := OrderRequest{
+		Symbol:     position.Symbol,
+		Side:       side,
+		Type:       "MARKET",
+		Quantity:   position.Quantity,
+		Price:      exitPrice,
+		ReduceOnly: true,
+	}
+	if _, err := te.placeOrder(req, position.Confidence, reason); err != nil {
+		return err
+	}
+	te.syncProtectiveStop()
+	if te.currentPosition == position {
+		return fmt.Errorf("%s exit of %s is waiting for its fill", reason, position.ID)
+	}
+	return nil
+}
+
+// syncProtectiveStop keeps one reduce-only STOP_MARKET order at the open
+// position's ATR stop, replacing it when the stop moves and cancelling it
+// once flat (assumes lock is held)
+func (te *TradeExecutor) syncProtectiveStop() {
+	if te.orders == nil || !te.liveConfig.PlaceStops {
+		return
+	}
+	position := te.currentPosition
+	if stop, ok := te.openOrders[te.stopOrderID]; ok {
+		if position != nil && stop.Price == position.ATRTrailStop && stop.Quantity == position.Quantity {
+			return
+		}
+		if err := te.cancelOrder(stop); err != nil {
+			executorLog.Warn("Failed to cancel protective stop", "order_id", stop.ExchangeID, "error", err)
+			return
+		}
+		// The stop may have triggered while the cancel ran
+		position = te.currentPosition
+	}
+	te.stopOrderID = ""
+	if position == nil || position.ATRTrailStop <= 0 {
+		return
+	}
+
+	side := "SELL"
+	if position.Side == "SHORT" {
+		side = "BUY"
+	}
+	req := OrderRequest{
+		Symbol:     position.Symbol,
+		Side:       side,
+		Type:       "STOP_MARKET",
+		Quantity:   position.Quantity,
+		StopPrice:  position.ATRTrailStop,
+		ReduceOnly: true,
+	}
+	order, err := te.placeOrder(req, position.Confidence, "ATR_STOP")
+	if err != nil {
+		executorLog.Warn("Failed to place protective stop", "error", err)
+		return
+	}
+	if _, working := te.openOrders[order.ID]; working {
+		te.stopOrderID = order.ID
+	}
+}
+
+// cancelOrder cancels a working order on the exchange and retires it (assumes lock is held)
+func (te *TradeExecutor) cancelOrder(order *Order) error {
+	if err := te.cancelOnExchange(order); err != nil {
+		return err
+	}
+	if _, open := te.openOrders[order.ID]; !open {
+		// Filled while the cancel ran; the fill already retired it
+		return nil
+	}
+	order.Status = "CANCELLED"
+	order.FilledTime = te.now()
+	delete(te.openOrders, order.ID)
+	te.orderHistory = append(te.orderHistory, order)
+	return nil
+}
+
+// cancelOnExchange cancels an order the exchange knows about, with the lock
+// released for the request (assumes lock is held)
+func (te *TradeExecutor) cancelOnExchange(order *Order) error {
+	if te.orders == nil || order.ExchangeID == "" {
+		return nil
+	}
+	return te.exchangeCall(order.ID, func(placer OrderPlacer) error {
+		return placer.CancelOrder(order.Symbol, order.ExchangeID)
+	})
+}
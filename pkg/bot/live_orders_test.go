@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLiveOrderExecution(t *testing.T) {
+	t.Log("💸 Testing live order routing with exchange fills, protective stops and dry run")
+
+	// Fake futures order endpoint: market orders fill at the next fill price, stops rest
+	var mutex sync.Mutex
+	var requests []string
+	fills := []string{"100.05", "101.95"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		query := r.URL.Query()
+		if r.URL.Path != "/fapi/v1/order" || r.Header.Get("X-MBX-APIKEY") != "key" || query.Get("signature") == "" {
+			t.Errorf("Unexpected unsigned or misrouted request %s %s", r.Method, r.URL)
+		}
+		requests = append(requests, r.Method+" "+query.Get("type")+" "+query.Get("side")+" "+query.Get("stopPrice")+" "+query.Get("reduceOnly"))
+
+		response := map[string]interface{}{"orderId": len(requests), "symbol": query.Get("symbol"), "side": query.Get("side"),
+			"status": "NEW", "origQty": query.Get("quantity"), "executedQty": "0", "avgPrice": "0", "updateTime": 1}
+		if r.Method == http.MethodPost && query.Get("type") == "MARKET" {
+			response["status"], response["executedQty"], response["avgPrice"] = "FILLED", query.Get("quantity"), fills[0]
+			fills = fills[1:]
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	provider := NewBinanceFuturesDataProvider("key", "secret")
+	provider.baseURL = server.URL
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000)
+	executor.SetOrderPlacer(NewBinanceOrderClient(provider, MarketFutures), LiveTradingConfig{Enabled: true, Market: MarketFutures, OrderType: "MARKET", PlaceStops: true})
+
+	// The entry fills on the exchange and a protective stop rests at the ATR stop
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}, 100, 99.5); err != nil {
+		t.Fatalf("Live entry failed: %v", err)
+	}
+	position := executor.GetCurrentPosition()
+	if position == nil || position.EntryPrice != 100.05 || position.ATRTrailStop != 99.5 {
+		t.Fatalf("Expected a long filled at 100.05 with a 99.5 stop, got %+v", position)
+	}
+	if orders := executor.GetOpenOrders(); len(orders) != 1 || orders[0].Type != "STOP_MARKET" || orders[0].Price != 99.5 {
+		t.Fatalf("Expected one resting stop at 99.5, got %+v", orders)
+	}
+
+	// Trailing the stop replaces the exchange order
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Hold, Confidence: 0.9}, 101, 100.2); err != nil {
+		t.Fatalf("Trailing stop update failed: %v", err)
+	}
+	if orders := executor.GetOpenOrders(); len(orders) != 1 || orders[0].Price != 100.2 {
+		t.Errorf("Expected the stop to move to 100.2, got %+v", orders)
+	}
+
+	// The exit is a reduce-only market order; its fill closes the trade and the stop is cancelled
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Sell, Confidence: 0.9}, 102, 103); err != nil {
+		t.Fatalf("Live exit failed: %v", err)
+	}
+	trades := executor.GetTradeHistory(0)
+	if executor.GetCurrentPosition() != nil || len(trades) != 1 || trades[0].ExitPrice != 101.95 || trades[0].ExitReason != "SIGNAL_CHANGE" {
+		t.Fatalf("Expected the exit fill at 101.95 to close the trade, got %+v", trades)
+	}
+	if orders := executor.GetOpenOrders(); len(orders) != 0 {
+		t.Errorf("Expected no working orders once flat, got %+v", orders)
+	}
+	want := []string{"POST MARKET BUY  ", "POST STOP_MARKET SELL 99.5 true", "DELETE    ", "POST STOP_MARKET SELL 100.2 true", "POST MARKET SELL  true", "DELETE    "}
+	if strings.Join(requests, "|") != strings.Join(want, "|") {
+		t.Errorf("Unexpected exchange requests:\n got %q\nwant %q", requests, want)
+	}
+
+	// Dry run fills locally without touching the exchange
+	dryRun := NewTradeExecutor(config, 10000)
+	dryRun.SetOrderPlacer(NewDryRunOrderPlacer(), LiveTradingConfig{Enabled: true, DryRun: true, OrderType: "MARKET"})
+	if err := dryRun.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}, 100, 99.5); err != nil || dryRun.GetCurrentPosition() == nil {
+		t.Errorf("Expected a dry-run entry to open a position (err %v)", err)
+	}
+
+	// Sending real orders must be explicit and needs the Binance provider
+	config.LiveTrading.Enabled, config.LiveTrading.DryRun, config.DataProvider = true, false, "sample"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "live_trading.enabled") {
+		t.Errorf("Expected live trading without Binance to be rejected, got %v", err)
+	}
+}
+
+// blockingOrderPlacer holds every order request until release is closed,
+// then fills it in full at its price
+type blockingOrderPlacer struct {
+	started chan OrderRequest
+	release chan struct{}
+}
+
+func (p *blockingOrderPlacer) PlaceOrder(req OrderRequest) (*ExchangeOrderState, error) {
+	p.started <- req
+	<-p.release
+	return &ExchangeOrderState{OrderID: "1", Symbol: req.Symbol, Side: req.Side, Status: "FILLED",
+		Quantity: req.Quantity, ExecutedQuantity: req.Quantity, AveragePrice: req.Price}, nil
+}
+
+func (p *blockingOrderPlacer) CancelOrder(symbol, orderID string) error {
+	return nil
+}
+
+func TestLiveOrderRequestReleasesLock(t *testing.T) {
+	t.Log("🔓 Testing that exchange order requests run without holding the executor lock")
+
+	config := DefaultConfig()
+	placer := &blockingOrderPlacer{started: make(chan OrderRequest, 4), release: make(chan struct{})}
+	executor := NewTradeExecutor(config, 10000)
+	executor.SetOrderPlacer(placer, LiveTradingConfig{Enabled: true, Market: MarketFutures, OrderType: "MARKET"})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}, 100, 99.5)
+	}()
+	<-placer.started
+
+	// Readers, signals and repairs proceed while the entry request runs, without touching the position
+	if executor.GetCurrentPosition() != nil || len(executor.GetOpenOrders()) != 1 {
+		t.Fatalf("Expected no position and one pending order while the entry is in flight")
+	}
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}, 100, 99.5); err != nil {
+		t.Errorf("Expected a concurrent signal to be skipped, got %v", err)
+	}
+	if repairs := executor.RepairPosition(ExchangePositionState{Symbol: config.Symbol, Quantity: 1, EntryPrice: 100}, 100); len(repairs) != 0 {
+		t.Errorf("Expected no repairs while an order is in flight, got %v", repairs)
+	}
+	if len(placer.started) != 0 {
+		t.Errorf("Expected a single exchange request, got %d more", len(placer.started))
+	}
+
+	close(placer.release)
+	if err := <-done; err != nil {
+		t.Fatalf("Live entry failed: %v", err)
+	}
+	if position := executor.GetCurrentPosition(); position == nil || position.EntryPrice != 100 {
+		t.Fatalf("Expected the entry fill to open a long at 100, got %+v", position)
+	}
+	if orders := executor.GetOpenOrders(); len(orders) != 0 {
+		t.Errorf("Expected the filled entry to be retired, got %+v", orders)
+	}
+}
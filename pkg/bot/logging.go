@@ -0,0 +1,122 @@
+package bot
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// Log output formats
+const (
+	LogFormatConsole = "console" // key=value text
+	LogFormatJSON    = "json"    // One JSON object per line, for ELK/Loki
+)
+
+// LoggingConfig selects the log level and output format
+type LoggingConfig struct {
+	Level  string `json:"level"`  // debug, info, warn or error
+	Format string `json:"format"` // console or json
+}
+
+// logLevels maps the configurable level names to slog levels
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// Component loggers. Each call site picks its level explicitly and passes
+// values as attributes rather than formatting them into the message.
+var (
+	engineLog   = Logger("engine")   // Signal engines, indicators, predictions and feeds health
+	executorLog = Logger("executor") // Trade execution, orders, positions and strategies
+	providerLog = Logger("provider") // Market data providers, streams and candle storage
+	configLog   = Logger("config")   // Config loading, reloads, history and audit
+	notifyLog   = Logger("notify")   // Notifiers, Telegram, MQTT, event export and alerts
+	backtestLog = Logger("backtest") // Backtests and the nightly backtest
+)
+
+// NewLogger builds a logger writing to w at the configured level and format
+func NewLogger(config LoggingConfig, w io.Writer) *slog.Logger {
+	options := &slog.HandlerOptions{Level: logLevels[config.Level]}
+	if config.Format == LogFormatJSON {
+		return slog.New(slog.NewJSONHandler(w, options))
+	}
+	return slog.New(slog.NewTextHandler(w, options))
+}
+
+// SetupLogging makes a logger built from config the default, which every
+// component logger writes through, and routes stray lines from the log package
+// (e.g. libraries) through it at info level
+func SetupLogging(config LoggingConfig, w io.Writer) *slog.Logger {
+	logger := NewLogger(config, w)
+	slog.SetDefault(logger)
+	log.SetFlags(0)
+	log.SetOutput(logBridge{logger: logger.With("component", "log")})
+	return logger
+}
+
+// Logger returns a logger tagged with a component. It writes through the
+// default logger current at each call, so package-level loggers created
+// before SetupLogging follow its level and format.
+func Logger(component string) *slog.Logger {
+	return slog.New(defaultHandler{}).With("component", component)
+}
+
+// defaultHandler forwards records to slog.Default's handler, replaying the
+// attributes and groups added to it
+type defaultHandler struct {
+	wrap []func(slog.Handler) slog.Handler
+}
+
+func (h defaultHandler) handler() slog.Handler {
+	handler := slog.Default().Handler()
+	for _, wrap := range h.wrap {
+		handler = wrap(handler)
+	}
+	return handler
+}
+
+func (h defaultHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return slog.Default().Handler().Enabled(ctx, level)
+}
+
+func (h defaultHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.handler().Handle(ctx, record)
+}
+
+func (h defaultHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h defaultHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h defaultHandler) with(wrap func(slog.Handler) slog.Handler) defaultHandler {
+	return defaultHandler{wrap: append(append([]func(slog.Handler) slog.Handler(nil), h.wrap...), wrap)}
+}
+
+// logBridge turns lines written through the log package into info records
+type logBridge struct {
+	logger *slog.Logger
+}
+
+func (b logBridge) Write(p []byte) (int, error) {
+	b.logger.Info(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// severityLevel maps an engine error severity to a log level
+func severityLevel(severity string) slog.Level {
+	switch severity {
+	case SeverityCritical:
+		return slog.LevelError
+	case SeverityWarning:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStructuredLogging(t *testing.T) {
+	t.Log("🪵 Testing component loggers, levels, JSON output and the log bridge")
+
+	defaultLogger, flags := slog.Default(), log.Flags()
+	defer func() {
+		slog.SetDefault(defaultLogger)
+		log.SetFlags(flags)
+		log.SetOutput(os.Stderr)
+	}()
+
+	// Component loggers created before SetupLogging follow its level and format
+	var out bytes.Buffer
+	SetupLogging(LoggingConfig{Level: "info", Format: LogFormatJSON}, &out)
+	providerLog.Warn("Failed to fetch price", "symbol", "BTCUSDT")
+	engineLog.Debug("🚫 Signal skipped", "symbol", "BTCUSDT")
+	Logger("executor").Error("Order rejected", "symbol", "BTCUSDT", "order_id", "42")
+	log.Printf("⚠️  stray library line")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected the debug record to be filtered out, got %q", out.String())
+	}
+	records := make([]map[string]interface{}, len(lines))
+	for i, line := range lines {
+		if err := json.Unmarshal([]byte(line), &records[i]); err != nil {
+			t.Fatalf("Expected JSON output, got %q: %v", line, err)
+		}
+	}
+	if r := records[0]; r["level"] != "WARN" || r["msg"] != "Failed to fetch price" || r["component"] != "provider" || r["symbol"] != "BTCUSDT" {
+		t.Errorf("Unexpected provider record: %v", r)
+	}
+	if r := records[1]; r["level"] != "ERROR" || r["component"] != "executor" || r["order_id"] != "42" {
+		t.Errorf("Unexpected executor record: %v", r)
+	}
+	// Lines from the log package carry no level of their own
+	if r := records[2]; r["level"] != "INFO" || r["msg"] != "⚠️  stray library line" || r["component"] != "log" {
+		t.Errorf("Unexpected bridged record: %v", r)
+	}
+
+	// Console output at debug keeps debug records
+	out.Reset()
+	SetupLogging(LoggingConfig{Level: "debug", Format: LogFormatConsole}, &out)
+	engineLog.Debug("🚫 Signal skipped", "symbol", "BTCUSDT")
+	if !strings.Contains(out.String(), "level=DEBUG") || !strings.Contains(out.String(), `msg="🚫 Signal skipped"`) ||
+		!strings.Contains(out.String(), "component=engine") || !strings.Contains(out.String(), "symbol=BTCUSDT") {
+		t.Errorf("Unexpected console output: %q", out.String())
+	}
+
+	// Engine error severities pick the level
+	if severityLevel(SeverityCritical) != slog.LevelError || severityLevel(SeverityWarning) != slog.LevelWarn || severityLevel("INFO") != slog.LevelInfo {
+		t.Error("Unexpected severity levels")
+	}
+
+	config := DefaultConfig()
+	config.Logging = LoggingConfig{Level: "verbose", Format: "xml"}
+	err := ValidateConfig(config)
+	if err == nil || !strings.Contains(err.Error(), "logging.level") || !strings.Contains(err.Error(), "logging.format") {
+		t.Errorf("Expected logging level and format errors, got %v", err)
+	}
+}
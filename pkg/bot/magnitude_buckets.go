@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"math"
+	"time"
+)
+
+// Magnitude buckets, most bearish first
+const (
+	BucketStrongDown = "STRONG_DOWN"
+	BucketMildDown   = "MILD_DOWN"
+	BucketFlat       = "FLAT"
+	BucketMildUp     = "MILD_UP"
+	BucketStrongUp   = "STRONG_UP"
+)
+
+var magnitudeBuckets = []string{BucketStrongDown, BucketMildDown, BucketFlat, BucketMildUp, BucketStrongUp}
+
+// magnitudePriorWeight is how many resolved predictions the volatility model counts as
+// when blended with the observed bucket frequencies
+const magnitudePriorWeight = 20.0
+
+// MagnitudeBucket is the probability that price ends the horizon within a % move range
+type MagnitudeBucket struct {
+	Bucket      string   `json:"bucket" example:"MILD_UP"`
+	MinPercent  *float64 `json:"min_percent,omitempty" example:"0.1"` // Exclusive lower bound (none for STRONG_DOWN)
+	MaxPercent  *float64 `json:"max_percent,omitempty" example:"0.3"` // Inclusive upper bound (none for STRONG_UP)
+	Probability float64  `json:"probability" example:"0.32"`
+}
+
+// magnitudeBucketIndex returns the position in magnitudeBuckets of a % move
+func (p PredictionConfig) magnitudeBucketIndex(movePercent float64) int {
+	switch {
+	case movePercent < -p.StrongMovePercent:
+		return 0
+	case movePercent < -p.MildMovePercent:
+		return 1
+	case movePercent <= p.MildMovePercent:
+		return 2
+	case movePercent <= p.StrongMovePercent:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// bucketBounds returns the % move edges between buckets
+func (p PredictionConfig) bucketBounds() []float64 {
+	return []float64{-p.StrongMovePercent, -p.MildMovePercent, p.MildMovePercent, p.StrongMovePercent}
+}
+
+// MagnitudeBuckets models the move over horizon as normally distributed: the
+// spread is the 5m ATR % scaled by the square root of the horizon in 5m
+// candles, shifted towards the predicted direction by its confidence (NEUTRAL
+// predictions narrow the spread instead)
+func (p PredictionConfig) MagnitudeBuckets(direction string, confidence, volatilityPercent float64, horizon time.Duration) []MagnitudeBucket {
+	sigma := volatilityPercent * math.Sqrt(math.Max(horizon.Minutes()/FiveMinute.Duration().Minutes(), 1))
+	mean := 0.0
+	switch direction {
+	case "HIGHER":
+		mean = confidence * sigma
+	case "LOWER":
+		mean = -confidence * sigma
+	case "NEUTRAL":
+		sigma *= 1 - confidence/2
+	}
+
+	cdf := func(x float64) float64 {
+		if sigma <= 0 {
+			if x >= mean {
+				return 1
+			}
+			return 0
+		}
+		return 0.5 * (1 + math.Erf((x-mean)/(sigma*math.Sqrt2)))
+	}
+
+	bounds := p.bucketBounds()
+	buckets := make([]MagnitudeBucket, len(magnitudeBuckets))
+	lower := 0.0
+	for i, name := range magnitudeBuckets {
+		upper := 1.0
+		bucket := MagnitudeBucket{Bucket: name}
+		if i > 0 {
+			min := bounds[i-1]
+			bucket.MinPercent = &min
+		}
+		if i < len(bounds) {
+			max := bounds[i]
+			bucket.MaxPercent = &max
+			upper = cdf(max)
+		}
+		bucket.Probability = upper - lower
+		buckets[i] = bucket
+		lower = upper
+	}
+	return buckets
+}
+
+// MostLikelyBucket returns the bucket with the highest probability
+func MostLikelyBucket(buckets []MagnitudeBucket) string {
+	best := ""
+	bestProbability := -1.0
+	for _, bucket := range buckets {
+		if bucket.Probability > bestProbability {
+			best, bestProbability = bucket.Bucket, bucket.Probability
+		}
+	}
+	return best
+}
+
+// bucketCounts tallies the realized magnitude buckets of resolved predictions
+// with the same direction and confidence bucket
+func (pat *PredictionAccuracyTracker) bucketCounts(config PredictionConfig, direction string, confidence float64) ([]int, int) {
+	counts := make([]int, len(magnitudeBuckets))
+	total := 0
+	bucket := confidenceBucket(confidence)
+	for _, prediction := range pat.All() {
+		if !prediction.Resolved || prediction.Price <= 0 || prediction.Direction != direction || confidenceBucket(prediction.Confidence) != bucket {
+			continue
+		}
+		move := (prediction.ActualPrice - prediction.Price) / prediction.Price * 100
+		counts[config.magnitudeBucketIndex(move)]++
+		total++
+	}
+	return counts, total
+}
+
+// PredictMagnitude returns per-bucket probabilities for a prediction: the
+// volatility model, updated with how similar past predictions actually resolved
+func (tb *TradingBot) PredictMagnitude(direction string, confidence, price float64, horizon time.Duration) []MagnitudeBucket {
+	return tb.PredictSymbolMagnitude(tb.config.Symbol, direction, confidence, price, horizon)
+}
+
+// PredictSymbolMagnitude is PredictMagnitude with the volatility of a configured symbol
+func (tb *TradingBot) PredictSymbolMagnitude(symbol, direction string, confidence, price float64, horizon time.Duration) []MagnitudeBucket {
+	volatility := 0.0
+	if candles, err := tb.GetSymbolCandleHistory(symbol, FiveMinute, 0); err == nil && price > 0 {
+		volatility = averageTrueRange(candles, tb.config.ATR.Period) / price * 100
+	}
+	buckets := tb.config.Prediction.MagnitudeBuckets(direction, confidence, volatility, horizon)
+
+	counts, total := tb.predictionAccuracy.bucketCounts(tb.config.Prediction, direction, confidence)
+	if total > 0 {
+		for i := range buckets {
+			buckets[i].Probability = (buckets[i].Probability*magnitudePriorWeight + float64(counts[i])) / (magnitudePriorWeight + float64(total))
+		}
+	}
+	return buckets
+}
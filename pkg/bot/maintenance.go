@@ -0,0 +1,110 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaintenanceOccurrence is a single scheduled downtime
+type MaintenanceOccurrence struct {
+	Name  string    `json:"name"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// MaintenanceStatus reports current and upcoming exchange maintenance
+type MaintenanceStatus struct {
+	Active        bool                   `json:"active"`
+	Current       *MaintenanceOccurrence `json:"current,omitempty"`
+	Next          *MaintenanceOccurrence `json:"next,omitempty"`
+	EntriesPaused bool                   `json:"entries_paused"` // New entries blocked (during or just before downtime)
+	PauseReason   string                 `json:"pause_reason,omitempty"`
+}
+
+// MaintenanceCalendar answers whether the exchange is in, or about to enter, scheduled downtime.
+// A nil calendar has no windows.
+type MaintenanceCalendar struct {
+	windows    []MaintenanceWindow
+	pauseAhead time.Duration
+}
+
+// NewMaintenanceCalendar creates a calendar from config
+func NewMaintenanceCalendar(config MaintenanceConfig) *MaintenanceCalendar {
+	return &MaintenanceCalendar{
+		windows:    append([]MaintenanceWindow(nil), config.Windows...),
+		pauseAhead: time.Duration(config.PauseEntriesMinutes) * time.Minute,
+	}
+}
+
+// occurrence returns the window's occurrence that contains now or starts after it
+func (w MaintenanceWindow) occurrence(now time.Time) (MaintenanceOccurrence, bool) {
+	start, end := w.Start, w.End
+	if w.RepeatWeekly && !now.Before(end) {
+		week := 7 * 24 * time.Hour
+		shift := time.Duration(now.Sub(w.Start)/week) * week
+		start, end = start.Add(shift), end.Add(shift)
+		if !now.Before(end) {
+			start, end = start.Add(week), end.Add(week)
+		}
+	}
+	if !now.Before(end) {
+		return MaintenanceOccurrence{}, false
+	}
+	return MaintenanceOccurrence{Name: w.Name, Start: start, End: end}, true
+}
+
+// upcoming returns every current or future occurrence, earliest first
+func (mc *MaintenanceCalendar) upcoming(now time.Time) []MaintenanceOccurrence {
+	if mc == nil {
+		return nil
+	}
+	occurrences := make([]MaintenanceOccurrence, 0, len(mc.windows))
+	for _, window := range mc.windows {
+		if occ, ok := window.occurrence(now); ok {
+			occurrences = append(occurrences, occ)
+		}
+	}
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].Start.Before(occurrences[j].Start) })
+	return occurrences
+}
+
+// Active returns the maintenance window in progress at now, if any
+func (mc *MaintenanceCalendar) Active(now time.Time) (*MaintenanceOccurrence, bool) {
+	for _, occ := range mc.upcoming(now) {
+		if !now.Before(occ.Start) {
+			return &occ, true
+		}
+	}
+	return nil, false
+}
+
+// EntriesPaused reports whether new entries should be blocked at now: during a
+// window or within the configured lead time before one starts
+func (mc *MaintenanceCalendar) EntriesPaused(now time.Time) (string, bool) {
+	for _, occ := range mc.upcoming(now) {
+		if !now.Before(occ.Start) {
+			return fmt.Sprintf("exchange maintenance %s until %s", occ.Name, occ.End.UTC().Format(time.RFC3339)), true
+		}
+		if occ.Start.Sub(now) <= mc.pauseAhead {
+			return fmt.Sprintf("exchange maintenance %s starts at %s", occ.Name, occ.Start.UTC().Format(time.RFC3339)), true
+		}
+	}
+	return "", false
+}
+
+// Status returns the current and next maintenance windows
+func (mc *MaintenanceCalendar) Status(now time.Time) MaintenanceStatus {
+	status := MaintenanceStatus{}
+	for _, occ := range mc.upcoming(now) {
+		occ := occ
+		if !now.Before(occ.Start) && status.Current == nil {
+			status.Active = true
+			status.Current = &occ
+		} else if now.Before(occ.Start) && status.Next == nil {
+			status.Next = &occ
+		}
+	}
+	status.PauseReason, status.EntriesPaused = mc.EntriesPaused(now)
+	return status
+}
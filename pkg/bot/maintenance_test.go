@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceCalendar(t *testing.T) {
+	t.Log("🔧 Testing scheduled maintenance awareness")
+
+	// Weekly Tuesday 06:00-08:00 UTC window, first occurrence 2024-01-02
+	first := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	config.Maintenance = MaintenanceConfig{
+		Windows:             []MaintenanceWindow{{Name: "weekly-upgrade", Start: first, End: first.Add(2 * time.Hour), RepeatWeekly: true}},
+		PauseEntriesMinutes: 30,
+	}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Valid maintenance config rejected: %v", err)
+	}
+	calendar := NewMaintenanceCalendar(config.Maintenance)
+
+	// Three weeks later the window recurs
+	during := first.AddDate(0, 0, 21).Add(time.Hour)
+	if window, active := calendar.Active(during); !active || !window.Start.Equal(first.AddDate(0, 0, 21)) {
+		t.Fatalf("Expected recurring window active at %v, got %+v", during, window)
+	}
+	after := first.AddDate(0, 0, 21).Add(3 * time.Hour)
+	status := calendar.Status(after)
+	if status.Active || status.Next == nil || !status.Next.Start.Equal(first.AddDate(0, 0, 28)) {
+		t.Errorf("Expected next occurrence one week later, got %+v", status)
+	}
+
+	// Entries pause inside the lead time but not before it
+	if _, paused := calendar.EntriesPaused(first.Add(-45 * time.Minute)); paused {
+		t.Errorf("Entries should not pause 45 minutes before the window")
+	}
+	if reason, paused := calendar.EntriesPaused(first.Add(-20 * time.Minute)); !paused || reason == "" {
+		t.Errorf("Entries should pause 20 minutes before the window")
+	}
+
+	// Executor: entries blocked just before downtime, exits still managed
+	executor := NewTradeExecutor(config, 10000.0)
+	executor.SetMaintenanceCalendar(calendar)
+	now := first.Add(-2 * time.Hour)
+	executor.SetClock(ClockFunc(func() time.Time { return now }))
+
+	buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Timestamp: now}
+	if err := executor.ExecuteSignal(buy, 100.0, 98.0); err != nil || executor.GetCurrentPosition() == nil {
+		t.Fatalf("Entry outside the pause window should succeed: %v", err)
+	}
+	now = first.Add(-10 * time.Minute)
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: "TEST", Symbol: "ETHUSDT", Side: "BUY", Quantity: 1, Price: 100}); err == nil {
+		t.Errorf("Strategy buys should be blocked before maintenance")
+	}
+	sell := &TradingSignal{Symbol: config.Symbol, Signal: Sell, Confidence: 0.9, Timestamp: now}
+	if err := executor.ExecuteSignal(sell, 101.0, 102.0); err != nil || executor.GetCurrentPosition() != nil {
+		t.Errorf("Exits should still run before maintenance: %v", err)
+	}
+	if err := executor.ExecuteSignal(buy, 100.0, 98.0); err != nil || executor.GetCurrentPosition() != nil {
+		t.Errorf("New entry should be skipped before maintenance: %v", err)
+	}
+
+	// Failures during an active window don't count towards safe mode
+	config.SafeMode.FailureThreshold = 1
+	config.Maintenance.Windows = []MaintenanceWindow{{Name: "now", Start: time.Now().Add(-time.Minute), End: time.Now().Add(time.Hour)}}
+	tb := NewTradingBot(config)
+	tb.recordFailure("price", fmt.Errorf("503 service unavailable"))
+	if tb.GetSafeModeStatus().Active {
+		t.Errorf("Failures during maintenance should not trigger safe mode")
+	}
+
+	config.Maintenance.Windows[0].End = config.Maintenance.Windows[0].Start
+	if err := ValidateConfig(config); err == nil {
+		t.Errorf("Expected zero-length maintenance window to be rejected")
+	}
+}
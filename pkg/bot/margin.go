@@ -0,0 +1,112 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaintenanceMargin returns the margin-currency maintenance margin of quantity at price
+func (c ContractConfig) MaintenanceMargin(quantity, price, rate float64) float64 {
+	return rate * c.Notional(quantity, price)
+}
+
+// LiquidationPrice returns the price at which a cross-margined position's margin
+// balance (collateral plus unrealized PnL) falls to its maintenance margin, or 0
+// when the collateral covers any move
+func (c ContractConfig) LiquidationPrice(side string, entryPrice, quantity, collateral, rate float64) float64 {
+	if quantity <= 0 || entryPrice <= 0 {
+		return 0
+	}
+	var price float64
+	if c.Inverse() {
+		value := quantity * c.ContractSize
+		if side == "SHORT" {
+			if denominator := value/entryPrice - collateral; denominator > 0 {
+				price = value * (1 - rate) / denominator
+			}
+		} else {
+			price = value * (1 + rate) / (collateral + value/entryPrice)
+		}
+	} else {
+		if side == "SHORT" {
+			price = (collateral + quantity*entryPrice) / (quantity * (1 + rate))
+		} else {
+			price = (quantity*entryPrice - collateral) / (quantity * (1 - rate))
+		}
+	}
+	return math.Max(price, 0)
+}
+
+// marginRatio is maintenance margin over margin balance; the position is liquidated at 1
+func marginRatio(maintenance, marginBalance float64) float64 {
+	if marginBalance <= 0 {
+		return 1
+	}
+	return maintenance / marginBalance
+}
+
+// checkMargin rejects an entry whose notional exceeds the leverage cap or whose
+// margin ratio at entry would exceed MaxMarginRatio (assumes lock is held)
+func (te *TradeExecutor) checkMargin(quantity, price float64) error {
+	margin := te.config.Margin
+	if !margin.Enabled {
+		return nil
+	}
+	balance := te.balances[te.marginCurrency]
+	notional := te.config.Contract.Notional(quantity, price)
+	if balance <= 0 {
+		return fmt.Errorf("no %s margin balance", te.marginCurrency)
+	}
+	if leverage := notional / balance; leverage > margin.Leverage {
+		return fmt.Errorf("position leverage %.2fx exceeds cap %.2fx", leverage, margin.Leverage)
+	}
+	maintenance := te.config.Contract.MaintenanceMargin(quantity, price, margin.MaintenanceMarginRate)
+	if ratio := marginRatio(maintenance, balance); ratio > margin.MaxMarginRatio {
+		return fmt.Errorf("margin ratio %.1f%% would exceed cap %.1f%%", ratio*100, margin.MaxMarginRatio*100)
+	}
+	return nil
+}
+
+// updateMargin refreshes the open position's leverage, margin ratio and
+// liquidation distance at price, alerting once when the buffer drops below
+// LiquidationBufferPercent (assumes lock is held)
+func (te *TradeExecutor) updateMargin(price float64) {
+	position := te.currentPosition
+	margin := te.config.Margin
+	if position == nil || !margin.Enabled || price <= 0 {
+		return
+	}
+
+	balance := te.balances[te.marginCurrency]
+	marginBalance := balance + te.config.Contract.PnL(position.Side, position.EntryPrice, price, position.Quantity)
+	maintenance := te.config.Contract.MaintenanceMargin(position.Quantity, price, margin.MaintenanceMarginRate)
+	position.MarginRatio = marginRatio(maintenance, marginBalance)
+	if marginBalance > 0 {
+		position.Leverage = te.config.Contract.Notional(position.Quantity, price) / marginBalance
+	}
+	position.LiquidationPrice = te.config.Contract.LiquidationPrice(position.Side, position.EntryPrice, position.Quantity, balance, margin.MaintenanceMarginRate)
+	if position.LiquidationPrice == 0 {
+		position.LiquidationBufferPercent = 0
+		position.liquidationAlerted = false
+		return
+	}
+	position.LiquidationBufferPercent = math.Abs(price-position.LiquidationPrice) / price * 100
+
+	if position.LiquidationBufferPercent >= margin.LiquidationBufferPercent {
+		position.liquidationAlerted = false
+		return
+	}
+	if position.liquidationAlerted {
+		return
+	}
+	position.liquidationAlerted = true
+	err := fmt.Errorf("%s %s is %.2f%% from liquidation at $%s (margin ratio %.1f%%)",
+		position.Side, position.Symbol, position.LiquidationBufferPercent,
+		te.symbolFilters.FormatPrice(position.LiquidationPrice), position.MarginRatio*100)
+	executorLog.Error("🚨 Position near liquidation", "symbol", position.Symbol, "side", position.Side, "buffer_percent", position.LiquidationBufferPercent, "liquidation_price", te.symbolFilters.FormatPrice(position.LiquidationPrice), "margin_ratio", position.MarginRatio)
+	if te.errorReporter != nil {
+		engineErr := NewEngineError(ErrLiquidation, SeverityCritical, "margin", err)
+		engineErr.Time = te.now()
+		te.errorReporter(engineErr)
+	}
+}
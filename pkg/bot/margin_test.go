@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMarginMonitoring(t *testing.T) {
+	t.Log("⚖️ Testing leverage caps, liquidation prices and liquidation buffer alerts")
+
+	config := DefaultConfig()
+	config.Margin.Enabled = true
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected margin config to validate: %v", err)
+	}
+
+	var reported []*EngineError
+	executor := NewTradeExecutor(config, 10000)
+	executor.SetErrorReporter(func(err *EngineError) { reported = append(reported, err) })
+
+	// 2% risk with a 0.5% stop is 400 units: 4x leverage, under the 5x cap
+	buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(buy, 100, 99.5); err != nil {
+		t.Fatalf("Failed to open long position: %v", err)
+	}
+	position := executor.GetCurrentPosition()
+	if position == nil || position.Quantity != 400 {
+		t.Fatalf("Expected a 400 unit long, got %+v", position)
+	}
+	wantLiquidation := (400*100 - 10000) / (400 * (1 - 0.004))
+	if math.Abs(position.LiquidationPrice-wantLiquidation) > 1e-9 || math.Abs(position.Leverage-4) > 1e-9 {
+		t.Errorf("Expected liquidation at %.4f with 4x leverage, got %.4f at %.2fx", wantLiquidation, position.LiquidationPrice, position.Leverage)
+	}
+
+	// Widen the stop so the mark can approach liquidation
+	position.ATRTrailStop, position.StopLoss = 1, 1
+	hold := &TradingSignal{Symbol: config.Symbol, Signal: Hold, Confidence: 0.9}
+	for _, price := range []float64{90, 78, 77, 90, 78} {
+		if err := executor.ExecuteSignal(hold, price, 1); err != nil {
+			t.Fatalf("Failed to mark position at %.0f: %v", price, err)
+		}
+	}
+	alerts := 0
+	for _, err := range reported {
+		if err.Kind == ErrLiquidation && err.Severity == SeverityCritical {
+			alerts++
+		}
+	}
+	if alerts != 2 {
+		t.Errorf("Expected one alert per approach to liquidation (2), got %d", alerts)
+	}
+	if position.LiquidationBufferPercent >= config.Margin.LiquidationBufferPercent || position.MarginRatio <= 0 {
+		t.Errorf("Expected a thin buffer at 78, got %.2f%% (margin ratio %.4f)", position.LiquidationBufferPercent, position.MarginRatio)
+	}
+
+	// A tighter leverage cap blocks the same entry
+	config.Margin.Leverage = 2
+	reported = nil
+	capped := NewTradeExecutor(config, 10000)
+	capped.SetErrorReporter(func(err *EngineError) { reported = append(reported, err) })
+	if err := capped.ExecuteSignal(buy, 100, 99.5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if capped.GetCurrentPosition() != nil || len(reported) != 1 || reported[0].Kind != ErrRiskBlocked {
+		t.Errorf("Expected the 4x entry to be blocked by the 2x cap, got position %+v and errors %v", capped.GetCurrentPosition(), reported)
+	}
+
+	// Short liquidation sits above entry
+	linear := ContractConfig{Type: ContractLinear}
+	if price := linear.LiquidationPrice("SHORT", 100, 400, 10000, 0.004); price <= 100 {
+		t.Errorf("Expected short liquidation above entry, got %.4f", price)
+	}
+}
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// MQTTPublisher publishes messages to an MQTT broker
+type MQTTPublisher struct {
+	config MQTTConfig
+	client mqtt.Client
+}
+
+// NewMQTTPublisher connects to the configured broker; the client reconnects on its own afterwards
+func NewMQTTPublisher(config MQTTConfig) (*MQTTPublisher, error) {
+	options := mqtt.NewClientOptions().
+		AddBroker(config.Broker).
+		SetClientID(valueOrDefault(config.ClientID, "nexus-bot")).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetAutoReconnect(true).
+		SetConnectTimeout(10 * time.Second).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			notifyLog.Warn("MQTT connection lost", "error", err)
+		})
+
+	client := mqtt.NewClient(options)
+	token := client.Connect()
+	if !token.WaitTimeout(15 * time.Second) {
+		return nil, fmt.Errorf("timed out connecting to MQTT broker %s", config.Broker)
+	}
+	if err := token.Error(); err != nil {
+		return nil, fmt.Errorf("failed to connect to MQTT broker %s: %w", config.Broker, err)
+	}
+
+	notifyLog.Info("📡 Connected to MQTT broker", "broker", config.Broker)
+	return &MQTTPublisher{config: config, client: client}, nil
+}
+
+// Publish sends payload to topic with the configured QoS and waits for the broker to accept it
+func (mp *MQTTPublisher) Publish(topic string, payload []byte) error {
+	token := mp.client.Publish(topic, byte(mp.config.QoS), false, payload)
+	if !token.WaitTimeout(10 * time.Second) {
+		return fmt.Errorf("timed out publishing to %s", topic)
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", topic, err)
+	}
+	return nil
+}
+
+// Close disconnects from the broker
+func (mp *MQTTPublisher) Close() {
+	mp.client.Disconnect(250)
+}
+
+// StartEventPublishing publishes bus events to their configured topics until ctx is cancelled
+func (mp *MQTTPublisher) StartEventPublishing(ctx context.Context, events <-chan Event) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-events:
+				topic, payload, err := eventMessage(mp.config.Topics, event)
+				if err != nil {
+					notifyLog.Error("Failed to marshal event", "event", event.Type, "error", err)
+					continue
+				}
+				if topic == "" {
+					continue
+				}
+				if err := mp.Publish(topic, payload); err != nil {
+					notifyLog.Warn("MQTT event not published", "event", event.Type, "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// eventMessage returns the topic and JSON payload for an event; topic is empty when the event type isn't mapped
+func eventMessage(topics map[string]string, event Event) (string, []byte, error) {
+	topic := topics[string(event.Type)]
+	if topic == "" {
+		return "", nil, nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", nil, err
+	}
+	return topic, payload, nil
+}
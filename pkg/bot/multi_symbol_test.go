@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMultiSymbolEngines(t *testing.T) {
+	t.Log("🪙 Testing per-symbol engines, predictions and status sections")
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	config.Symbols = []string{"ETHUSDT", "BNBUSDT"}
+	tb := NewTradingBot(config)
+
+	if symbols := tb.Symbols(); strings.Join(symbols, ",") != "BTCUSDT,ETHUSDT,BNBUSDT" {
+		t.Fatalf("Expected the traded symbol first, got %v", symbols)
+	}
+	if tb.engines["ETHUSDT"].timeframeManager == tb.signalEngine.timeframeManager ||
+		tb.engines["ETHUSDT"].signalAggregator == tb.signalEngine.signalAggregator {
+		t.Fatal("Expected each symbol to keep its own candles and indicator state")
+	}
+
+	// Each symbol is predicted from its own candles
+	signal, err := tb.GenerateSymbolPrediction("ETHUSDT")
+	if err != nil {
+		t.Fatalf("ETHUSDT prediction failed: %v", err)
+	}
+	price, err := tb.GetSymbolPrice("ETHUSDT")
+	if signal.Symbol != "ETHUSDT" || err != nil || price < 1000 || price > 10000 {
+		t.Errorf("Expected an ETHUSDT signal near the sample's 3000 base, got %s at %.2f (err %v)", signal.Symbol, price, err)
+	}
+	if timing := tb.GetSymbolDataTiming("ETHUSDT"); timing.DataTimestamp.IsZero() || !tb.GetDataTiming().DataTimestamp.IsZero() {
+		t.Errorf("Expected fetch timing to be kept per symbol, got %+v", timing)
+	}
+	if _, err := tb.GenerateSymbolPrediction("DOGEUSDT"); err == nil {
+		t.Error("Expected an unconfigured symbol to be rejected")
+	}
+
+	// Status keeps the traded symbol at the top level and adds a section per symbol
+	status := tb.GetStatus()
+	if status.Symbol != "BTCUSDT" || len(status.Symbols) != 3 || status.Symbols["ETHUSDT"].Symbol != "ETHUSDT" {
+		t.Fatalf("Unexpected status sections: %+v", status.Symbols)
+	}
+	if eth := status.Symbols["ETHUSDT"]; eth.DataSummary[FiveMinute] == 0 || status.DataSummary[FiveMinute] != 0 {
+		t.Errorf("Expected only ETHUSDT to have loaded candles, got %v / %v", eth.DataSummary, status.DataSummary)
+	}
+
+	// Symbols are validated
+	config.Symbols = []string{"ETHUSDT", "BTCUSDT"}
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "symbols[1]") {
+		t.Errorf("Expected the traded symbol listed again to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package bot
+
+import (
+	"math"
+	"time"
+)
+
+// NeutralBand returns the price move within which an outcome over horizon
+// counts as NEUTRAL: the round-trip cost at price plus NeutralATRFraction of the
+// 5-minute ATR, scaled by the square root of the horizon in 5-minute candles
+func (p PredictionConfig) NeutralBand(price, atr float64, horizon time.Duration) float64 {
+	cost := price * p.RoundTripCostPercent / 100
+	candles := horizon.Minutes() / FiveMinute.Duration().Minutes()
+	if candles <= 0 {
+		return cost
+	}
+	return cost + p.NeutralATRFraction*atr*math.Sqrt(candles)
+}
+
+// NeutralBandFor computes NeutralBand at the last close of 5-minute candles,
+// using their average true range over atrPeriod candles
+func (p PredictionConfig) NeutralBandFor(candles []Candle, atrPeriod int, horizon time.Duration) float64 {
+	if len(candles) == 0 {
+		return 0
+	}
+	return p.NeutralBand(candles[len(candles)-1].Close, averageTrueRange(candles, atrPeriod), horizon)
+}
+
+// averageTrueRange is the mean true range of the last period candles
+func averageTrueRange(candles []Candle, period int) float64 {
+	if len(candles) < 2 {
+		return 0
+	}
+	if period < 1 || period > len(candles)-1 {
+		period = len(candles) - 1
+	}
+	sum := 0.0
+	for i := len(candles) - period; i < len(candles); i++ {
+		prevClose := candles[i-1].Close
+		sum += math.Max(candles[i].High-candles[i].Low,
+			math.Max(math.Abs(candles[i].High-prevClose), math.Abs(candles[i].Low-prevClose)))
+	}
+	return sum / float64(period)
+}
+
+// classifyPriceMove maps a price change to HIGHER, LOWER or NEUTRAL
+func classifyPriceMove(change, band float64) string {
+	switch {
+	case change > band:
+		return "HIGHER"
+	case change < -band:
+		return "LOWER"
+	default:
+		return "NEUTRAL"
+	}
+}
@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NightlyBacktestScheduler backtests the live config every night and posts the
+// summary through notifiers, continuously validating that the config still performs
+type NightlyBacktestScheduler struct {
+	config    NightlyBacktestConfig
+	run       func(days int) (*BacktestResult, error)
+	notifiers []Notifier
+}
+
+// NewNightlyBacktestScheduler creates a scheduler; run performs a backtest over the last N days
+func NewNightlyBacktestScheduler(config NightlyBacktestConfig, run func(days int) (*BacktestResult, error), notifiers []Notifier) *NightlyBacktestScheduler {
+	return &NightlyBacktestScheduler{config: config, run: run, notifiers: notifiers}
+}
+
+// Start runs the nightly backtests until ctx is cancelled
+func (ns *NightlyBacktestScheduler) Start(ctx context.Context) {
+	go func() {
+		for {
+			next := nextNightlyRun(time.Now(), ns.config.HourUTC)
+			backtestLog.Info("🌙 Next nightly backtest scheduled", "at", next.Format(time.RFC3339))
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				ns.RunOnce()
+			}
+		}
+	}()
+}
+
+// RunOnce backtests every configured window and notifies a combined summary
+func (ns *NightlyBacktestScheduler) RunOnce() {
+	level := "INFO"
+	lines := make([]string, 0, len(ns.config.WindowDays))
+
+	for _, days := range ns.config.WindowDays {
+		result, err := ns.run(days)
+		if err != nil {
+			level = "WARNING"
+			lines = append(lines, fmt.Sprintf("%dd: backtest failed: %v", days, err))
+			continue
+		}
+
+		if result.TotalReturnPercent < 0 || (ns.config.MaxDrawdownAlert > 0 && result.MaxDrawdownPercent > ns.config.MaxDrawdownAlert) {
+			level = "WARNING"
+		}
+		lines = append(lines, fmt.Sprintf("%dd: return %.2f%%, max drawdown %.2f%%, %d trades, win rate %.1f%% (report %s)",
+			days, result.TotalReturnPercent, result.MaxDrawdownPercent, len(result.Trades), result.Performance.WinRate, result.ID))
+	}
+
+	notifyAll(ns.notifiers, level, "Nightly backtest", strings.Join(lines, "\n"))
+}
+
+// nextNightlyRun returns the next occurrence of hour:00 UTC strictly after now
+func nextNightlyRun(now time.Time, hour int) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,64 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordingNotifier captures alerts for assertions
+type recordingNotifier struct {
+	levels   []string
+	messages []string
+}
+
+func (rn *recordingNotifier) Notify(level, title, message string) error {
+	rn.levels = append(rn.levels, level)
+	rn.messages = append(rn.messages, message)
+	return nil
+}
+
+func TestNightlyBacktest(t *testing.T) {
+	t.Log("🌙 Testing nightly backtest scheduling and summaries")
+
+	now := time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC)
+	if next := nextNightlyRun(now, 2); !next.Equal(time.Date(2024, 3, 10, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected run later today, got %v", next)
+	}
+	if next := nextNightlyRun(now, 1); !next.Equal(time.Date(2024, 3, 11, 1, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected run tomorrow, got %v", next)
+	}
+
+	returns := map[int]float64{7: 2.5, 30: -1.2}
+	run := func(days int) (*BacktestResult, error) {
+		ret, ok := returns[days]
+		if !ok {
+			return nil, fmt.Errorf("no data")
+		}
+		return &BacktestResult{ID: fmt.Sprintf("bt_%d", days), TotalReturnPercent: ret, MaxDrawdownPercent: 3}, nil
+	}
+
+	notifier := &recordingNotifier{}
+	config := NightlyBacktestConfig{Enabled: true, WindowDays: []int{7, 30}, MaxDrawdownAlert: 10}
+	NewNightlyBacktestScheduler(config, run, []Notifier{notifier}).RunOnce()
+
+	if len(notifier.messages) != 1 {
+		t.Fatalf("Expected one combined summary, got %d", len(notifier.messages))
+	}
+	if notifier.levels[0] != "WARNING" {
+		t.Errorf("Expected WARNING for a losing 30d window, got %s", notifier.levels[0])
+	}
+	for _, want := range []string{"7d: return 2.50%", "30d: return -1.20%", "bt_30"} {
+		if !strings.Contains(notifier.messages[0], want) {
+			t.Errorf("Summary missing %q: %s", want, notifier.messages[0])
+		}
+	}
+
+	returns[30] = 4.0
+	notifier = &recordingNotifier{}
+	NewNightlyBacktestScheduler(config, run, []Notifier{notifier}).RunOnce()
+	if notifier.levels[0] != "INFO" {
+		t.Errorf("Expected INFO when all windows perform, got %s", notifier.levels[0])
+	}
+}
@@ -0,0 +1,83 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Notifier delivers operational alerts
+type Notifier interface {
+	Notify(level, title, message string) error
+}
+
+// LogNotifier writes alerts to the process log
+type LogNotifier struct{}
+
+// Notify logs the alert
+func (LogNotifier) Notify(level, title, message string) error {
+	notifyLog.Log(context.Background(), severityLevel(level), "🚨 "+title, "severity", level, "message", message)
+	return nil
+}
+
+// WebhookNotifier POSTs alerts as JSON to a URL
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a new webhook notifier
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify sends the alert to the webhook
+func (wn *WebhookNotifier) Notify(level, title, message string) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"level":     level,
+		"title":     title,
+		"message":   message,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	resp, err := wn.httpClient.Post(wn.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NewNotifiers builds the configured notifiers; alerts are always logged
+func NewNotifiers(config NotificationsConfig) []Notifier {
+	notifiers := []Notifier{LogNotifier{}}
+	if config.WebhookURL != "" {
+		notifiers = append(notifiers, NewWebhookNotifier(config.WebhookURL))
+	}
+	if config.Telegram.Enabled {
+		notifiers = append(notifiers, NewTelegramNotifier(config.Telegram))
+	}
+	return notifiers
+}
+
+// notifyAll sends an alert to every notifier, logging delivery failures
+func notifyAll(notifiers []Notifier, level, title, message string) {
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(level, title, message); err != nil {
+			notifyLog.Warn("Failed to deliver alert", "title", title, "error", err)
+		}
+	}
+}
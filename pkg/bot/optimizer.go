@@ -0,0 +1,247 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxSweepCombinations bounds the size of a parameter grid
+const maxSweepCombinations = 100000
+
+// SweepParameter lists the candidate values of one indicator parameter
+type SweepParameter struct {
+	Parameter string    `json:"parameter"` // JSON path, e.g. "rsi.period"
+	Values    []float64 `json:"values"`
+}
+
+// SweepResult is the backtest outcome of one parameter combination
+type SweepResult struct {
+	Parameters         map[string]float64 `json:"parameters"`
+	TotalReturnPercent float64            `json:"total_return_percent"`
+	MaxDrawdownPercent float64            `json:"max_drawdown_percent"`
+	SignalAccuracy     float64            `json:"signal_accuracy"`
+	Trades             int                `json:"trades"`
+	Error              string             `json:"error,omitempty"`
+
+	index int // Position in the grid, so ordering never depends on which worker finished first
+}
+
+// SweepProgress is reported after every finished combination
+type SweepProgress struct {
+	Completed int           `json:"completed"`
+	Total     int           `json:"total"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Remaining time.Duration `json:"remaining"` // Estimate from the average time per combination
+	Best      *SweepResult  `json:"best,omitempty"`
+}
+
+// SweepReport ranks the evaluated combinations, best total return first
+type SweepReport struct {
+	Combinations int           `json:"combinations"`
+	Completed    int           `json:"completed"`
+	Cancelled    bool          `json:"cancelled"` // Stopped before every combination ran
+	Workers      int           `json:"workers"`
+	Duration     time.Duration `json:"duration"`
+	Results      []SweepResult `json:"results"`
+}
+
+// ParameterOptimizer backtests every combination of the swept parameters on a worker pool
+type ParameterOptimizer struct {
+	config         Config
+	initialBalance float64
+	workers        int
+	progress       func(SweepProgress)
+}
+
+// NewParameterOptimizer creates an optimizer running up to workers backtests at
+// once (0 uses one worker per CPU)
+func NewParameterOptimizer(config Config, initialBalance float64, workers int) *ParameterOptimizer {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return &ParameterOptimizer{config: config, initialBalance: initialBalance, workers: workers}
+}
+
+// OnProgress registers a callback invoked (from a single goroutine) after every combination
+func (po *ParameterOptimizer) OnProgress(callback func(SweepProgress)) {
+	po.progress = callback
+}
+
+// Run backtests the full grid of params. Cancelling ctx stops handing out new
+// combinations; the report then holds the combinations that finished.
+func (po *ParameterOptimizer) Run(ctx context.Context, params []SweepParameter, candles map[Timeframe][]Candle, start, end time.Time) (*SweepReport, error) {
+	total, err := po.combinations(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(candles[FiveMinute]) == 0 {
+		return nil, fmt.Errorf("no 5-minute candles to backtest")
+	}
+
+	workers := po.workers
+	if workers > total {
+		workers = total
+	}
+	report := &SweepReport{Combinations: total, Workers: workers, Results: make([]SweepResult, 0, total)}
+	now := po.config.Determinism.WallClock()
+	started := now()
+
+	jobs := make(chan int)
+	results := make(chan SweepResult)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		// Backtester.Run sorts candles in place, so every worker gets its own copy
+		workerCandles := copyCandles(candles)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				results <- po.evaluate(params, index, workerCandles, start, end)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for index := 0; index < total; index++ {
+			select {
+			case jobs <- index:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var best *SweepResult
+	for result := range results {
+		report.Results = append(report.Results, result)
+		if result.Error == "" && (best == nil || result.TotalReturnPercent > best.TotalReturnPercent ||
+			(result.TotalReturnPercent == best.TotalReturnPercent && result.index < best.index)) {
+			r := result
+			best = &r
+		}
+		if po.progress != nil {
+			elapsed := now().Sub(started)
+			completed := len(report.Results)
+			po.progress(SweepProgress{
+				Completed: completed,
+				Total:     total,
+				Elapsed:   elapsed,
+				Remaining: elapsed / time.Duration(completed) * time.Duration(total-completed),
+				Best:      best,
+			})
+		}
+	}
+
+	report.Completed = len(report.Results)
+	report.Cancelled = report.Completed < total
+	report.Duration = now().Sub(started)
+	sort.Slice(report.Results, func(i, j int) bool {
+		a, b := report.Results[i], report.Results[j]
+		if (a.Error == "") != (b.Error == "") {
+			return a.Error == ""
+		}
+		if a.TotalReturnPercent != b.TotalReturnPercent {
+			return a.TotalReturnPercent > b.TotalReturnPercent
+		}
+		if a.SignalAccuracy != b.SignalAccuracy {
+			return a.SignalAccuracy > b.SignalAccuracy
+		}
+		return a.index < b.index
+	})
+	return report, nil
+}
+
+// combinations validates the grid and returns its size
+func (po *ParameterOptimizer) combinations(params []SweepParameter) (int, error) {
+	if len(params) == 0 {
+		return 0, fmt.Errorf("no parameters to sweep")
+	}
+	seen := make(map[string]bool)
+	total := 1
+	for _, param := range params {
+		if seen[param.Parameter] {
+			return 0, fmt.Errorf("parameter %s swept twice", param.Parameter)
+		}
+		seen[param.Parameter] = true
+		if _, err := getIndicatorParameter(po.config, param.Parameter); err != nil {
+			return 0, err
+		}
+		if len(param.Values) == 0 {
+			return 0, fmt.Errorf("parameter %s has no values", param.Parameter)
+		}
+		total *= len(param.Values)
+		if total > maxSweepCombinations {
+			return 0, fmt.Errorf("sweep has more than %d combinations", maxSweepCombinations)
+		}
+	}
+	return total, nil
+}
+
+// evaluate backtests combination index, decoding it as a mixed-radix number over the value lists
+func (po *ParameterOptimizer) evaluate(params []SweepParameter, index int, candles map[Timeframe][]Candle, start, end time.Time) SweepResult {
+	config := po.config
+	result := SweepResult{Parameters: make(map[string]float64, len(params)), index: index}
+	for i := len(params) - 1; i >= 0; i-- {
+		values := params[i].Values
+		value := values[index%len(values)]
+		index /= len(values)
+		setIndicatorParameter(&config, params[i].Parameter, value)
+		result.Parameters[params[i].Parameter] = value
+	}
+
+	if err := ValidateConfig(config); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	backtest, err := NewBacktester(config, po.initialBalance).Run(candles, start, end)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.TotalReturnPercent = backtest.TotalReturnPercent
+	result.MaxDrawdownPercent = backtest.MaxDrawdownPercent
+	result.SignalAccuracy = backtest.SignalAccuracy
+	result.Trades = len(backtest.Trades)
+	return result
+}
+
+// copyCandles copies every timeframe's candle slice
+func copyCandles(candles map[Timeframe][]Candle) map[Timeframe][]Candle {
+	copied := make(map[Timeframe][]Candle, len(candles))
+	for tf, series := range candles {
+		copied[tf] = append([]Candle(nil), series...)
+	}
+	return copied
+}
+
+// WriteProfile saves config with params applied as a config profile at path,
+// e.g. "profiles/optimized.json". The result is validated first and the exchange
+// credentials are left out, so a profile can be shared or committed.
+func WriteProfile(config Config, params map[string]float64, path string) error {
+	profile, err := ApplyParameters(config, params)
+	if err != nil {
+		return err
+	}
+	if err := ValidateConfig(profile); err != nil {
+		return fmt.Errorf("optimized profile is invalid: %w", err)
+	}
+	KeepCredentials(&profile, Config{})
+
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create profile directory: %w", err)
+		}
+	}
+	return SaveConfig(profile, path)
+}
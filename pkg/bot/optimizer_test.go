@@ -0,0 +1,105 @@
+package bot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParameterOptimizer(t *testing.T) {
+	t.Log("🧮 Testing parallel parameter sweeps with progress and cancellation")
+
+	// Keep the run small: only RSI and EMA stay enabled
+	config := DefaultConfig()
+	for _, param := range IndicatorParameters(config) {
+		section := strings.SplitN(param, ".", 2)[0]
+		if section != "rsi" && section != "ema" {
+			field, _ := indicatorParameterField(&config, section+".enabled")
+			field.SetBool(false)
+		}
+	}
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := map[Timeframe][]Candle{
+		Daily:           syntheticCandles(Daily, origin.AddDate(0, 0, -40), 41),
+		EightHour:       syntheticCandles(EightHour, origin.AddDate(0, 0, -20), 62),
+		FortyFiveMinute: syntheticCandles(FortyFiveMinute, origin.AddDate(0, 0, -3), 110),
+		FifteenMinute:   syntheticCandles(FifteenMinute, origin.AddDate(0, 0, -2), 220),
+		FiveMinute:      syntheticCandles(FiveMinute, origin.AddDate(0, 0, -1), 288+72),
+	}
+	start, end := origin, origin.Add(4*time.Hour)
+
+	params := []SweepParameter{
+		{Parameter: "rsi.period", Values: []float64{7, 14, 0}},
+		{Parameter: "ema.fast_period", Values: []float64{5, 9}},
+	}
+
+	optimizer := NewParameterOptimizer(config, 10000.0, 3)
+	progress := 0
+	optimizer.OnProgress(func(p SweepProgress) {
+		progress++
+		if p.Completed != progress || p.Total != 6 {
+			t.Errorf("Expected progress %d/6, got %d/%d", progress, p.Completed, p.Total)
+		}
+	})
+	report, err := optimizer.Run(context.Background(), params, candles, start, end)
+	if err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+	if report.Combinations != 6 || report.Completed != 6 || report.Cancelled || progress != 6 {
+		t.Fatalf("Expected 6 completed combinations, got %+v (progress %d)", report, progress)
+	}
+
+	// Every combination ran once; the invalid period sorts last with its error
+	seen := make(map[[2]float64]bool)
+	for i, result := range report.Results {
+		seen[[2]float64{result.Parameters["rsi.period"], result.Parameters["ema.fast_period"]}] = true
+		if result.Parameters["rsi.period"] == 0 {
+			if result.Error == "" || i < 4 {
+				t.Errorf("Expected rsi.period=0 to fail validation and rank last, got %+v at %d", result, i)
+			}
+			continue
+		}
+		if result.Error != "" {
+			t.Errorf("Unexpected error for %v: %s", result.Parameters, result.Error)
+		}
+		if i > 0 && report.Results[i-1].Error == "" && report.Results[i-1].TotalReturnPercent < result.TotalReturnPercent {
+			t.Errorf("Results not sorted by return at %d", i)
+		}
+	}
+	if len(seen) != 6 {
+		t.Errorf("Expected 6 distinct combinations, got %d", len(seen))
+	}
+
+	// A parallel run matches a single-worker run
+	serial, _ := NewParameterOptimizer(config, 10000.0, 1).Run(context.Background(), params, candles, start, end)
+	for i := range serial.Results {
+		if serial.Results[i].TotalReturnPercent != report.Results[i].TotalReturnPercent {
+			t.Errorf("Result %d differs between 1 and 3 workers: %.4f vs %.4f", i, serial.Results[i].TotalReturnPercent, report.Results[i].TotalReturnPercent)
+		}
+	}
+
+	// Cancelling stops handing out combinations and keeps the finished ones
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelling := NewParameterOptimizer(config, 10000.0, 1)
+	cancelling.OnProgress(func(p SweepProgress) { cancel() })
+	partial, err := cancelling.Run(ctx, params, candles, start, end)
+	if err != nil {
+		t.Fatalf("Cancelled sweep failed: %v", err)
+	}
+	if !partial.Cancelled || partial.Completed == 0 || partial.Completed > 2 {
+		t.Errorf("Expected a cancelled sweep with 1-2 results, got %d (cancelled %v)", partial.Completed, partial.Cancelled)
+	}
+
+	for _, bad := range [][]SweepParameter{
+		nil,
+		{{Parameter: "rsi.nope", Values: []float64{1}}},
+		{{Parameter: "rsi.period", Values: nil}},
+		{{Parameter: "rsi.period", Values: []float64{7}}, {Parameter: "rsi.period", Values: []float64{9}}},
+	} {
+		if _, err := optimizer.Run(context.Background(), bad, candles, start, end); err == nil {
+			t.Errorf("Expected sweep %v to be rejected", bad)
+		}
+	}
+}
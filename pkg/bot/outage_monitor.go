@@ -0,0 +1,169 @@
+package bot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SafeModeStatus reports outage detection state
+type SafeModeStatus struct {
+	Enabled         bool       `json:"enabled"`
+	Active          bool       `json:"active"`
+	Reason          string     `json:"reason,omitempty"`
+	EnteredAt       *time.Time `json:"entered_at,omitempty"`
+	ExitedAt        *time.Time `json:"exited_at,omitempty"`
+	RecentFailures  int        `json:"recent_failures"` // Failures inside the detection window
+	LastFailure     string     `json:"last_failure,omitempty"`
+	LastFailureAt   *time.Time `json:"last_failure_at,omitempty"`
+	CancelledOrders int        `json:"cancelled_orders"` // Working orders cancelled on entry
+	Flattened       bool       `json:"flattened"`        // Whether positions were closed on entry
+}
+
+// outageFailure is a single failed exchange/data operation
+type outageFailure struct {
+	time   time.Time
+	source string
+	err    string
+}
+
+// OutageMonitor counts order/data failures in a sliding window and switches
+// the bot into safe mode when they exceed the configured threshold
+type OutageMonitor struct {
+	config    SafeModeConfig
+	failures  []outageFailure
+	successes int // Consecutive successes while in safe mode
+	status    SafeModeStatus
+	onEnter   func(reason string) (cancelled int, flattened bool)
+	onExit    func(reason string)
+	mutex     sync.Mutex
+}
+
+// NewOutageMonitor creates a new outage monitor
+func NewOutageMonitor(config SafeModeConfig) *OutageMonitor {
+	return &OutageMonitor{
+		config:   config,
+		failures: make([]outageFailure, 0),
+		status:   SafeModeStatus{Enabled: config.Enabled},
+	}
+}
+
+// SetHandlers sets the actions run when safe mode is entered or exited
+func (om *OutageMonitor) SetHandlers(onEnter func(reason string) (int, bool), onExit func(reason string)) {
+	om.mutex.Lock()
+	defer om.mutex.Unlock()
+	om.onEnter = onEnter
+	om.onExit = onExit
+}
+
+// RecordFailure registers a failed operation and enters safe mode once the threshold is hit
+func (om *OutageMonitor) RecordFailure(source string, err error) {
+	if !om.config.Enabled {
+		return
+	}
+
+	om.mutex.Lock()
+	now := time.Now()
+	om.failures = append(om.failures, outageFailure{time: now, source: source, err: err.Error()})
+	om.pruneFailures(now)
+	om.successes = 0
+	om.status.RecentFailures = len(om.failures)
+	om.status.LastFailure = fmt.Sprintf("%s: %v", source, err)
+	om.status.LastFailureAt = &now
+
+	if om.status.Active || len(om.failures) < om.config.FailureThreshold {
+		om.mutex.Unlock()
+		return
+	}
+
+	reason := fmt.Sprintf("%d failures in %ds, last: %s", len(om.failures), om.config.WindowSeconds, om.status.LastFailure)
+	om.status.Active = true
+	om.status.Reason = reason
+	om.status.EnteredAt = &now
+	om.status.ExitedAt = nil
+	onEnter := om.onEnter
+	om.mutex.Unlock()
+
+	engineLog.Warn("🛟 Entering safe mode", "reason", reason)
+	if onEnter != nil {
+		cancelled, flattened := onEnter(reason)
+		om.mutex.Lock()
+		om.status.CancelledOrders = cancelled
+		om.status.Flattened = flattened
+		om.mutex.Unlock()
+	}
+}
+
+// RecordSuccess registers a successful operation and exits safe mode after enough in a row
+func (om *OutageMonitor) RecordSuccess(source string) {
+	if !om.config.Enabled {
+		return
+	}
+
+	om.mutex.Lock()
+	om.pruneFailures(time.Now())
+	om.status.RecentFailures = len(om.failures)
+	if !om.status.Active || om.config.RecoverySuccesses == 0 {
+		om.mutex.Unlock()
+		return
+	}
+
+	om.successes++
+	if om.successes < om.config.RecoverySuccesses {
+		om.mutex.Unlock()
+		return
+	}
+	om.mutex.Unlock()
+
+	om.Exit(fmt.Sprintf("recovered after %d consecutive successful %s operations", om.config.RecoverySuccesses, source))
+}
+
+// Exit leaves safe mode (no-op when inactive)
+func (om *OutageMonitor) Exit(reason string) {
+	om.mutex.Lock()
+	if !om.status.Active {
+		om.mutex.Unlock()
+		return
+	}
+
+	now := time.Now()
+	om.status.Active = false
+	om.status.Reason = reason
+	om.status.ExitedAt = &now
+	om.failures = om.failures[:0]
+	om.status.RecentFailures = 0
+	om.successes = 0
+	onExit := om.onExit
+	om.mutex.Unlock()
+
+	engineLog.Info("✅ Exiting safe mode", "reason", reason)
+	if onExit != nil {
+		onExit(reason)
+	}
+}
+
+// IsActive reports whether safe mode is active
+func (om *OutageMonitor) IsActive() bool {
+	om.mutex.Lock()
+	defer om.mutex.Unlock()
+	return om.status.Active
+}
+
+// GetStatus returns a snapshot of the outage state
+func (om *OutageMonitor) GetStatus() SafeModeStatus {
+	om.mutex.Lock()
+	defer om.mutex.Unlock()
+	return om.status
+}
+
+// pruneFailures drops failures older than the window; callers must hold the mutex
+func (om *OutageMonitor) pruneFailures(now time.Time) {
+	cutoff := now.Add(-time.Duration(om.config.WindowSeconds) * time.Second)
+	kept := om.failures[:0]
+	for _, failure := range om.failures {
+		if failure.time.After(cutoff) {
+			kept = append(kept, failure)
+		}
+	}
+	om.failures = kept
+}
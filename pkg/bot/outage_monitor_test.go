@@ -0,0 +1,76 @@
+package bot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestOutageSafeMode(t *testing.T) {
+	t.Log("🛟 Testing outage detection and safe mode")
+
+	config := DefaultConfig()
+	config.SafeMode = SafeModeConfig{
+		Enabled:           true,
+		FailureThreshold:  3,
+		WindowSeconds:     60,
+		FlattenPositions:  true,
+		RecoverySuccesses: 2,
+	}
+	tb := NewTradingBot(config)
+	executor := tb.tradeExecutor
+
+	buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Timestamp: time.Now()}
+	if err := executor.ExecuteSignal(buy, 100.0, 98.0); err != nil {
+		t.Fatalf("Entry failed: %v", err)
+	}
+
+	// Failures below the threshold don't trigger safe mode
+	tb.outage.RecordFailure("price", fmt.Errorf("connection refused"))
+	tb.outage.RecordFailure("data", fmt.Errorf("connection refused"))
+	if tb.GetSafeModeStatus().Active {
+		t.Fatalf("Safe mode should not trigger below the failure threshold")
+	}
+
+	tb.outage.RecordFailure("order", fmt.Errorf("502 bad gateway"))
+	status := tb.GetSafeModeStatus()
+	if !status.Active || !status.Flattened {
+		t.Fatalf("Expected active, flattened safe mode, got %+v", status)
+	}
+	if executor.GetCurrentPosition() != nil {
+		t.Errorf("Expected position to be flattened")
+	}
+	if history := executor.GetTradeHistory(1); len(history) != 1 || history[0].ExitReason != "SAFE_MODE" {
+		t.Errorf("Expected SAFE_MODE exit in trade history")
+	}
+
+	// New entries are blocked in safe mode
+	if err := executor.ExecuteSignal(buy, 100.0, 98.0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if executor.GetCurrentPosition() != nil {
+		t.Errorf("Safe mode should block new entries")
+	}
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: "TEST", Symbol: "ETHUSDT", Side: "BUY", Quantity: 1, Price: 100}); err == nil {
+		t.Errorf("Safe mode should block strategy buys")
+	}
+
+	// Consecutive successes end safe mode; a failure in between resets the count
+	tb.outage.RecordSuccess("price")
+	tb.outage.RecordFailure("price", fmt.Errorf("timeout"))
+	tb.outage.RecordSuccess("price")
+	if !tb.GetSafeModeStatus().Active {
+		t.Fatalf("Interleaved failure should reset recovery count")
+	}
+	tb.outage.RecordSuccess("price")
+	if tb.GetSafeModeStatus().Active {
+		t.Fatalf("Expected safe mode to end after 2 consecutive successes")
+	}
+
+	if err := executor.ExecuteSignal(buy, 100.0, 98.0); err != nil {
+		t.Fatalf("Entry after recovery failed: %v", err)
+	}
+	if executor.GetCurrentPosition() == nil {
+		t.Errorf("Entries should resume after safe mode ends")
+	}
+}
@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/indicator"
+)
+
+func TestParabolicSAR(t *testing.T) {
+	t.Log("🪂 Testing the Parabolic SAR and SAR trailing stops")
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var candles []indicator.Candle
+	add := func(close float64) {
+		i := len(candles)
+		candles = append(candles, indicator.Candle{Timestamp: origin.Add(time.Duration(i) * 5 * time.Minute), Open: close, High: close + 1, Low: close - 1, Close: close})
+	}
+	for i := 0; i < 10; i++ {
+		add(100 + float64(i))
+	}
+
+	// An uptrend trails below the lows and accelerates with each new high
+	psar := indicator.NewParabolicSAR(indicator.ParabolicSARConfig{Enabled: true, Step: 0.02, MaxStep: 0.2}, indicator.FiveMinute)
+	values := psar.CalculateAll(candles)
+	for i := 2; i < len(candles); i++ {
+		if !values.Long[i] || values.SAR[i] <= values.SAR[i-1] || values.SAR[i] > candles[i].Low {
+			t.Fatalf("Expected a rising SAR below the lows at %d, got %.4f", i, values.SAR[i])
+		}
+	}
+	// The first stop is the lowest low (99); the next new high (103) makes it 99 + 0.04 × (103 - 99)
+	if math.Abs(values.SAR[1]-99) > 1e-9 || math.Abs(values.SAR[2]-99.16) > 1e-9 {
+		t.Errorf("Expected SAR 99 then 99.16, got %.4f and %.4f", values.SAR[1], values.SAR[2])
+	}
+	if len(psar.Calculate(candles)) != len(candles)-1 {
+		t.Errorf("Expected a SAR from the second candle on")
+	}
+
+	// A drop through the stop flips to a downtrend starting at the extreme high (110)
+	add(95)
+	level, long, ok := psar.CurrentSAR(candles)
+	if !ok || long || level < 110 {
+		t.Errorf("Expected a flip to a downtrend with the SAR at or above 110, got %.4f long=%v", level, long)
+	}
+	if signal := psar.GetSignal(psar.Calculate(candles), 95); signal.Signal != indicator.Sell || signal.Value != level || signal.Strength != 1 {
+		t.Errorf("Expected a full-strength SELL below the SAR, got %+v", signal)
+	}
+	if _, _, ok := psar.CurrentSAR(candles[:1]); ok {
+		t.Errorf("Expected no SAR from a single candle")
+	}
+
+	// psar trailing stops need the indicator
+	config := DefaultConfig()
+	config.TrailingStop.Method = TrailingStopPSAR
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "trailing_stop.method") {
+		t.Errorf("Expected psar without parabolic_sar to be rejected, got %v", err)
+	}
+	config.ParabolicSAR.Enabled = true
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected psar trailing stops to be valid: %v", err)
+	}
+	if !slices.Contains(NewSignalAggregator(config).GetActiveIndicatorNames(), "Parabolic SAR") {
+		t.Errorf("Expected the Parabolic SAR to be active")
+	}
+
+	withSAR := func(side SignalType, sar float64) *TradingSignal {
+		return &TradingSignal{Symbol: config.Symbol, Signal: side, Confidence: 0.9,
+			IndicatorSignals: []IndicatorSignal{{Name: "ParabolicSAR_5m", Value: sar}}}
+	}
+
+	// An entry with the SAR above price keeps the ATR stop
+	executor := NewTradeExecutor(config, 10000)
+	if err := executor.ExecuteSignal(withSAR(Buy, 101), 100, 99); err != nil || executor.GetCurrentPosition().ATRTrailStop != 99 {
+		t.Fatalf("Expected the ATR stop on a long entry below the SAR (err %v)", err)
+	}
+
+	// Otherwise the position trails the SAR and a flip stops it out
+	executor = NewTradeExecutor(config, 10000)
+	if err := executor.ExecuteSignal(withSAR(Buy, 98.5), 100, 99); err != nil || executor.GetCurrentPosition().ATRTrailStop != 98.5 {
+		t.Fatalf("Expected the SAR stop on a long entry (err %v)", err)
+	}
+	if err := executor.ExecuteSignal(withSAR(Hold, 99.2), 100.5, 99.8); err != nil || executor.GetCurrentPosition().ATRTrailStop != 99.2 {
+		t.Fatalf("Expected the stop to follow the SAR up (err %v)", err)
+	}
+	if err := executor.ExecuteSignal(withSAR(Hold, 101), 100.2, 99.8); err != nil || executor.GetCurrentPosition() != nil {
+		t.Fatalf("Expected the SAR flip to close the position (err %v)", err)
+	}
+	if trades := executor.GetTradeHistory(1); len(trades) != 1 || trades[0].ExitReason != "ATR_STOP" {
+		t.Errorf("Expected a trailing stop exit, got %+v", trades)
+	}
+}
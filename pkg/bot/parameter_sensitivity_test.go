@@ -0,0 +1,75 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParameterSensitivity(t *testing.T) {
+	t.Log("🔬 Testing indicator parameter sensitivity analysis")
+
+	config := DefaultConfig()
+	params := IndicatorParameters(config)
+	found := map[string]bool{}
+	for _, param := range params {
+		found[param] = true
+	}
+	if !found["rsi.period"] || !found["ema.fast_period"] {
+		t.Fatalf("Expected rsi.period and ema.fast_period in %v", params)
+	}
+	if found["rebalance.threshold_band"] || found["hedging.hedge_ratio"] {
+		t.Errorf("Non-indicator sections should not be perturbed: %v", params)
+	}
+
+	// Integer parameters move by at least one step even for small perturbations
+	analyzer := NewSensitivityAnalyzer(config, 10000.0, 0.01, 2)
+	low, lowValue := analyzer.perturb("rsi.period", -1)
+	if lowValue != float64(config.RSI.Period-1) || low.RSI.Period != config.RSI.Period-1 {
+		t.Errorf("Expected rsi.period %d, got %.0f (config %d)", config.RSI.Period-1, lowValue, low.RSI.Period)
+	}
+	if config.RSI.Period == low.RSI.Period {
+		t.Errorf("Perturbation must not modify the base config")
+	}
+	if _, highValue := analyzer.perturb("rsi.overbought", 1); highValue != config.RSI.Overbought*1.01 {
+		t.Errorf("Expected rsi.overbought %.3f, got %.3f", config.RSI.Overbought*1.01, highValue)
+	}
+
+	// Keep the run small: only RSI and EMA stay enabled
+	for _, param := range params {
+		section := strings.SplitN(param, ".", 2)[0]
+		if section != "rsi" && section != "ema" {
+			field, _ := indicatorParameterField(&config, section+".enabled")
+			field.SetBool(false)
+		}
+	}
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := map[Timeframe][]Candle{
+		Daily:           syntheticCandles(Daily, origin.AddDate(0, 0, -40), 41),
+		EightHour:       syntheticCandles(EightHour, origin.AddDate(0, 0, -20), 62),
+		FortyFiveMinute: syntheticCandles(FortyFiveMinute, origin.AddDate(0, 0, -3), 110),
+		FifteenMinute:   syntheticCandles(FifteenMinute, origin.AddDate(0, 0, -2), 220),
+		FiveMinute:      syntheticCandles(FiveMinute, origin.AddDate(0, 0, -1), 288+72),
+	}
+
+	report, err := NewSensitivityAnalyzer(config, 10000.0, 0.2, 0).Run(candles, origin, origin.Add(6*time.Hour))
+	if err != nil {
+		t.Fatalf("Sensitivity run failed: %v", err)
+	}
+	if want := len(IndicatorParameters(config)); len(report.Parameters) != want {
+		t.Fatalf("Expected %d parameters, got %d", want, len(report.Parameters))
+	}
+	for i, p := range report.Parameters {
+		if p.PerturbationErr != "" {
+			t.Errorf("Parameter %s failed: %s", p.Parameter, p.PerturbationErr)
+		}
+		if i > 0 && p.ReturnImpact > report.Parameters[i-1].ReturnImpact {
+			t.Errorf("Parameters not sorted by return impact at %s", p.Parameter)
+		}
+		if p.ReturnImpact > 0 && !p.Fragile {
+			t.Errorf("Parameter %s moved return %.2fpp but was not flagged with a 0pp threshold", p.Parameter, p.ReturnImpact)
+		}
+	}
+	t.Logf("   Baseline return %.2f%%, accuracy %.1f%%, %d parameters", report.BaselineReturn, report.BaselineAccuracy, len(report.Parameters))
+}
@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/indicator"
+)
+
+// pineTestCandles falls for fall bars then rises, so a fast EMA crosses above a slow one on the last bar
+func pineTestCandles(fall, rise int) []indicator.Candle {
+	candles := make([]indicator.Candle, 0, fall+rise)
+	price := 100.0
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < fall+rise; i++ {
+		if i < fall {
+			price -= 1
+		} else {
+			price += 3
+		}
+		candles = append(candles, indicator.Candle{
+			Timestamp: start.Add(time.Duration(i) * 5 * time.Minute),
+			Open:      price, High: price + 1, Low: price - 1, Close: price, Volume: 1000,
+		})
+	}
+	return candles
+}
+
+func TestPineStudy(t *testing.T) {
+	t.Log("🌲 Testing the Pine Script subset interpreter")
+
+	source := `//@version=5
+indicator("EMA Cross", overlay=true)
+fastLen = input.int(3, "Fast", minval=1)
+slowLen = input(8)
+fast = ta.ema(close, fastLen)
+slow = ta.ema(close, slowLen)
+atrBand = ta.atr(5) * 2
+buy = ta.crossover(fast, slow) and close > close[1]
+sell = ta.crossunder(fast, slow) or
+    close < slow - atrBand
+plot(fast, color=color.green)
+plotshape(buy, style=shape.triangleup, color=#00FF00)`
+
+	study, err := indicator.NewPineStudy(source, "ema_cross", indicator.FiveMinute, 0.7)
+	if err != nil {
+		t.Fatalf("Failed to compile study: %v", err)
+	}
+	if study.GetName() != "Pine[EMA Cross]_5m" {
+		t.Errorf("Expected indicator() title in the name, got %s", study.GetName())
+	}
+
+	// Find the bar where the fast EMA first crosses above the slow one
+	var crossed []indicator.Candle
+	for rise := 1; rise < 10; rise++ {
+		candles := pineTestCandles(20, rise)
+		signal := study.GetSignal(study.Calculate(candles), candles[len(candles)-1].Close)
+		if signal.Signal == indicator.Buy {
+			crossed = candles
+			break
+		}
+	}
+	if crossed == nil {
+		t.Fatal("Expected a BUY on the crossover bar")
+	}
+
+	// The plotted series is the fast EMA: seeded with an SMA, then alpha = 2/(n+1)
+	values := study.Calculate(crossed)
+	expected := 0.0
+	for i := 0; i < 3; i++ {
+		expected += crossed[i].Close / 3
+	}
+	for i := 3; i < len(crossed); i++ {
+		expected = 0.5*crossed[i].Close + 0.5*expected
+	}
+	if math.Abs(values[len(values)-1]-expected) > 1e-9 {
+		t.Errorf("Expected fast EMA %.6f, got %.6f", expected, values[len(values)-1])
+	}
+	if !math.IsNaN(values[1]) {
+		t.Errorf("Expected na before the EMA has %d bars, got %v", 3, values[1])
+	}
+
+	// One bar later the cross is no longer fresh
+	later := pineTestCandles(20, len(crossed)-20+1)
+	if signal := study.GetSignal(study.Calculate(later), 0); signal.Signal != indicator.Hold {
+		t.Errorf("Expected HOLD after the crossover bar, got %s", signal.Signal)
+	}
+
+	cases := map[string]string{
+		"strategy(\"x\")\nbuy = close > open": "strategy()",
+		"x = ta.vwma(close, 5)\nbuy = x > 0":  "unsupported function",
+		"buy = close > foo":                   "unknown variable",
+		"buy = ta.ema(close)":                 "takes 2",
+		"x = close\nx := open\nbuy = x > 0":   "not supported",
+		"if close > open\n    buy = true":     "if blocks",
+		"fast = ta.ema(close, 3)":             "buy or sell",
+		"buy = close > close[n]":              "constant integers",
+	}
+	for source, want := range cases {
+		if _, err := indicator.NewPineStudy(source, "bad", indicator.FiveMinute, 0.7); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("NewPineStudy(%q) = %v, want error containing %q", source, err, want)
+		}
+	}
+}
+
+func TestPineStudiesInAggregator(t *testing.T) {
+	t.Log("🌲 Testing Pine studies load as extra indicators")
+
+	dir := t.TempDir()
+	good := filepath.Join(dir, "rsi_dip.pine")
+	if err := os.WriteFile(good, []byte("buy = ta.rsi(close, 14) < 30\nsell = ta.rsi(close, 14) > 70"), 0644); err != nil {
+		t.Fatalf("Failed to write study: %v", err)
+	}
+
+	config := DefaultConfig()
+	config.Pine.Enabled = true
+	config.Pine.Scripts = []string{good, filepath.Join(dir, "missing.pine")}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected Pine config to validate: %v", err)
+	}
+
+	aggregator := NewSignalAggregator(config)
+	found := 0
+	for _, ind := range aggregator.indicators[FiveMinute] {
+		if ind.GetName() == "Pine[rsi_dip]_5m" {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("Expected the readable study to be loaded once and the missing one skipped, found %d", found)
+	}
+
+	config.Pine.Strength = 0
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "pine.strength") {
+		t.Errorf("Expected pine.strength validation error, got %v", err)
+	}
+}
@@ -0,0 +1,503 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// predictionOutcomes are the classes a prediction's confidence is spread over
+var predictionOutcomes = []string{"HIGHER", "LOWER", "NEUTRAL"}
+
+// logLossEpsilon keeps log loss finite when a confident prediction is wrong
+const logLossEpsilon = 1e-15
+
+// predictionAccuracySize is how many served predictions the accuracy tracker keeps
+const predictionAccuracySize = 2000
+
+// rollingAccuracyWindow is how many of the latest resolved predictions the rolling accuracy covers
+const rollingAccuracyWindow = 100
+
+// TrackedPrediction is a served price direction prediction and, once its target
+// time has passed, its outcome
+type TrackedPrediction struct {
+	ID                string    `json:"id"`
+	Symbol            string    `json:"symbol,omitempty"`
+	Direction         string    `json:"direction"` // HIGHER, LOWER or NEUTRAL
+	Confidence        float64   `json:"confidence"`
+	Price             float64   `json:"price"`
+	MadeAt            time.Time `json:"made_at"`
+	TargetTime        time.Time `json:"target_time"`
+	Regime            string    `json:"regime"`             // Market regime when the prediction was made
+	VolatilityPercent float64   `json:"volatility_percent"` // Average 5m true range as % of price
+	NeutralBand       float64   `json:"neutral_band"`       // Moves within ± this price change resolve NEUTRAL
+
+	Indicators map[string]IndicatorVote `json:"indicators,omitempty"` // What each indicator pointed to, scored with the prediction
+
+	Resolved        bool      `json:"resolved"`
+	ActualPrice     float64   `json:"actual_price,omitempty"`
+	ActualDirection string    `json:"actual_direction,omitempty"`
+	Correct         bool      `json:"correct"`
+	BrierScore      float64   `json:"brier_score,omitempty"` // 0 (perfect) to 2
+	LogLoss         float64   `json:"log_loss,omitempty"`
+	ResolvedAt      time.Time `json:"resolved_at,omitempty"`
+}
+
+// IndicatorVote is the direction one indicator pointed to when a prediction was made
+type IndicatorVote struct {
+	Direction string  `json:"direction"` // HIGHER, LOWER or NEUTRAL
+	Strength  float64 `json:"strength"`  // Scored as the vote's confidence
+}
+
+// indicatorVotes converts indicator signals into the directions they point to
+func indicatorVotes(signals []IndicatorSignal) map[string]IndicatorVote {
+	if len(signals) == 0 {
+		return nil
+	}
+	votes := make(map[string]IndicatorVote, len(signals))
+	for _, signal := range signals {
+		direction := "NEUTRAL"
+		switch signal.Signal {
+		case Buy:
+			direction = "HIGHER"
+		case Sell:
+			direction = "LOWER"
+		}
+		votes[signal.Name] = IndicatorVote{Direction: direction, Strength: signal.Strength}
+	}
+	return votes
+}
+
+// AccuracySegment is the hit rate of the resolved predictions in one segment
+type AccuracySegment struct {
+	Segment     string  `json:"segment"`
+	Predictions int     `json:"predictions"`
+	Correct     int     `json:"correct"`
+	HitRate     float64 `json:"hit_rate"`    // % correct
+	BrierScore  float64 `json:"brier_score"` // Mean multi-class Brier score, lower is better
+	LogLoss     float64 `json:"log_loss"`    // Mean log loss, lower is better
+
+	brierSum, logLossSum float64
+}
+
+// AccuracyBreakdown segments prediction hit rate so users learn when the bot is trustworthy
+type AccuracyBreakdown struct {
+	Overall    AccuracySegment   `json:"overall"`
+	Rolling    AccuracySegment   `json:"rolling"`    // The latest resolved predictions only, to follow recent accuracy
+	Pending    int               `json:"pending"`    // Predictions whose target time hasn't been evaluated yet
+	Directions []AccuracySegment `json:"directions"` // Predicted direction: HIGHER, LOWER, NEUTRAL
+	Indicators []AccuracySegment `json:"indicators"` // Each indicator's own vote scored against the outcome
+	Regimes    []AccuracySegment `json:"regimes"`    // TRENDING, RANGING, VOLATILE
+	Volatility []AccuracySegment `json:"volatility"` // Terciles of the resolved predictions' volatility
+	Hours      []AccuracySegment `json:"hours"`      // Session-local hour the prediction was made
+	Confidence []AccuracySegment `json:"confidence"` // 10-point confidence buckets
+	Days       []AccuracySegment `json:"days"`       // Trading day the prediction was made, to follow calibration over time
+}
+
+// AccuracySummary is the prediction accuracy dashboard: overall and rolling hit
+// rate, breakdowns by direction, indicator and confidence, and the latest outcomes
+type AccuracySummary struct {
+	Overall    AccuracySegment     `json:"overall"`
+	Rolling    AccuracySegment     `json:"rolling"`
+	Pending    int                 `json:"pending"`
+	Directions []AccuracySegment   `json:"directions"`
+	Indicators []AccuracySegment   `json:"indicators"`
+	Confidence []AccuracySegment   `json:"confidence"`
+	Recent     []TrackedPrediction `json:"recent"` // Latest resolved predictions, newest first
+}
+
+// PredictionAccuracyTracker records served predictions and scores them against
+// the price at their target time
+type PredictionAccuracyTracker struct {
+	predictions []*TrackedPrediction
+	maxItems    int
+	nextID      int
+	session     SessionConfig // Trading day the hour and day segments follow
+	historyFile string        // JSON file predictions are persisted to; empty disables
+	mutex       sync.RWMutex
+}
+
+// NewPredictionAccuracyTracker creates a tracker keeping at most maxItems predictions
+func NewPredictionAccuracyTracker(maxItems int) *PredictionAccuracyTracker {
+	return &PredictionAccuracyTracker{predictions: make([]*TrackedPrediction, 0), maxItems: maxItems}
+}
+
+// SetSession sets the trading day used for the hour and day segments
+func (pat *PredictionAccuracyTracker) SetSession(session SessionConfig) {
+	pat.mutex.Lock()
+	defer pat.mutex.Unlock()
+	pat.session = session
+}
+
+// SetHistoryFile persists predictions to filename, first restoring those saved
+// there. Restored predictions still ahead of their target time are returned so
+// they can be resolved; those whose target passed while stopped can't be scored
+// and are dropped.
+func (pat *PredictionAccuracyTracker) SetHistoryFile(filename string) ([]TrackedPrediction, error) {
+	pat.mutex.Lock()
+	defer pat.mutex.Unlock()
+	pat.historyFile = filename
+
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prediction history: %w", err)
+	}
+	var predictions []*TrackedPrediction
+	if err := json.Unmarshal(data, &predictions); err != nil {
+		return nil, fmt.Errorf("failed to parse prediction history: %w", err)
+	}
+
+	now := time.Now()
+	restored := make([]*TrackedPrediction, 0, len(predictions))
+	pending := make([]TrackedPrediction, 0)
+	for _, prediction := range predictions {
+		var id int
+		if _, err := fmt.Sscanf(prediction.ID, "pred_%d", &id); err == nil && id > pat.nextID {
+			pat.nextID = id
+		}
+		if !prediction.Resolved {
+			if !prediction.TargetTime.After(now) {
+				continue
+			}
+			pending = append(pending, *prediction)
+		}
+		restored = append(restored, prediction)
+	}
+	if dropped := len(predictions) - len(restored); dropped > 0 {
+		engineLog.Warn("Dropped restored predictions whose target time passed while stopped", "count", dropped)
+	}
+	pat.predictions = append(restored, pat.predictions...)
+	if len(pat.predictions) > pat.maxItems {
+		pat.predictions = pat.predictions[len(pat.predictions)-pat.maxItems:]
+	}
+	return pending, nil
+}
+
+// save writes the tracked predictions to the history file (assumes lock is held)
+func (pat *PredictionAccuracyTracker) save() {
+	if pat.historyFile == "" {
+		return
+	}
+	if err := writeJSONFile(pat.historyFile, pat.predictions); err != nil {
+		engineLog.Error("Failed to save prediction history", "error", err)
+	}
+}
+
+// Record stores a prediction awaiting its outcome and returns its ID
+func (pat *PredictionAccuracyTracker) Record(prediction TrackedPrediction) string {
+	pat.mutex.Lock()
+	defer pat.mutex.Unlock()
+
+	pat.nextID++
+	prediction.ID = fmt.Sprintf("pred_%d", pat.nextID)
+	prediction.Resolved = false
+	pat.predictions = append(pat.predictions, &prediction)
+	if len(pat.predictions) > pat.maxItems {
+		pat.predictions = pat.predictions[len(pat.predictions)-pat.maxItems:]
+	}
+	pat.save()
+	return prediction.ID
+}
+
+// Resolve scores a prediction against the price at its target time
+func (pat *PredictionAccuracyTracker) Resolve(id string, actualPrice float64, at time.Time) error {
+	pat.mutex.Lock()
+	defer pat.mutex.Unlock()
+
+	for _, prediction := range pat.predictions {
+		if prediction.ID != id {
+			continue
+		}
+		if prediction.Resolved {
+			return fmt.Errorf("prediction %s already resolved", id)
+		}
+		prediction.Resolved = true
+		prediction.ActualPrice = actualPrice
+		prediction.ActualDirection = classifyPriceMove(actualPrice-prediction.Price, prediction.NeutralBand)
+		prediction.Correct = prediction.ActualDirection == prediction.Direction
+		prediction.BrierScore, prediction.LogLoss = scorePrediction(prediction.Direction, prediction.Confidence, prediction.ActualDirection)
+		prediction.ResolvedAt = at
+		pat.save()
+		return nil
+	}
+	return fmt.Errorf("prediction %s not found", id)
+}
+
+// scorePrediction computes the Brier score and log loss of a prediction, treating
+// its confidence as the probability of its direction and splitting the rest
+// evenly over the other two outcomes
+func scorePrediction(direction string, confidence float64, actual string) (brier, logLoss float64) {
+	confidence = math.Max(0, math.Min(1, confidence))
+	for _, outcome := range predictionOutcomes {
+		probability := (1 - confidence) / float64(len(predictionOutcomes)-1)
+		if outcome == direction {
+			probability = confidence
+		}
+		observed := 0.0
+		if outcome == actual {
+			observed = 1
+			logLoss = -math.Log(math.Max(probability, logLossEpsilon))
+		}
+		brier += (probability - observed) * (probability - observed)
+	}
+	return brier, logLoss
+}
+
+// All returns copies of the tracked predictions, oldest first
+func (pat *PredictionAccuracyTracker) All() []TrackedPrediction {
+	pat.mutex.RLock()
+	defer pat.mutex.RUnlock()
+
+	predictions := make([]TrackedPrediction, len(pat.predictions))
+	for i, prediction := range pat.predictions {
+		predictions[i] = *prediction
+	}
+	return predictions
+}
+
+// Breakdown segments the hit rate and scores of resolved predictions by
+// direction, indicator, regime, volatility tercile, hour of day, confidence
+// bucket and day
+func (pat *PredictionAccuracyTracker) Breakdown() AccuracyBreakdown {
+	breakdown := AccuracyBreakdown{
+		Overall:    AccuracySegment{Segment: "ALL"},
+		Rolling:    AccuracySegment{Segment: fmt.Sprintf("LAST_%d", rollingAccuracyWindow)},
+		Directions: make([]AccuracySegment, 0),
+		Indicators: make([]AccuracySegment, 0),
+		Regimes:    make([]AccuracySegment, 0),
+		Volatility: make([]AccuracySegment, 0),
+		Hours:      make([]AccuracySegment, 0),
+		Confidence: make([]AccuracySegment, 0),
+		Days:       make([]AccuracySegment, 0),
+	}
+
+	resolved := make([]TrackedPrediction, 0)
+	for _, prediction := range pat.All() {
+		if prediction.Resolved {
+			resolved = append(resolved, prediction)
+		} else {
+			breakdown.Pending++
+		}
+	}
+	for _, prediction := range resolved {
+		breakdown.Overall.add(prediction)
+	}
+	if len(resolved) == 0 {
+		return breakdown
+	}
+	for _, prediction := range resolved[max(0, len(resolved)-rollingAccuracyWindow):] {
+		breakdown.Rolling.add(prediction)
+	}
+
+	// Directions in a fixed order; indicators are scored as if each had made the call
+	directions := make(map[string]*AccuracySegment)
+	indicators := make(map[string]*AccuracySegment)
+	for _, prediction := range resolved {
+		segmentFor(directions, prediction.Direction).add(prediction)
+		for name, vote := range prediction.Indicators {
+			scored := prediction
+			scored.Direction, scored.Confidence = vote.Direction, vote.Strength
+			scored.Correct = vote.Direction == prediction.ActualDirection
+			scored.BrierScore, scored.LogLoss = scorePrediction(vote.Direction, vote.Strength, prediction.ActualDirection)
+			segmentFor(indicators, name).add(scored)
+		}
+	}
+	for _, direction := range predictionOutcomes {
+		if segment, ok := directions[direction]; ok {
+			breakdown.Directions = append(breakdown.Directions, *segment)
+		}
+	}
+	breakdown.Indicators = sortedSegments(indicators)
+
+	// Regimes in a fixed order, unclassified predictions last
+	regimes := make(map[string]*AccuracySegment)
+	for _, prediction := range resolved {
+		regime := prediction.Regime
+		if regime == "" {
+			regime = "UNKNOWN"
+		}
+		segmentFor(regimes, regime).add(prediction)
+	}
+	for _, regime := range []string{RegimeTrending, RegimeRanging, RegimeVolatile, "UNKNOWN"} {
+		if segment, ok := regimes[regime]; ok {
+			breakdown.Regimes = append(breakdown.Regimes, *segment)
+		}
+	}
+
+	// Volatility terciles are cut at the 1/3 and 2/3 quantiles of what was seen
+	sorted := append([]TrackedPrediction(nil), resolved...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].VolatilityPercent < sorted[j].VolatilityPercent })
+	for tercile, name := range []string{"LOW", "MEDIUM", "HIGH"} {
+		from, to := tercile*len(sorted)/3, (tercile+1)*len(sorted)/3
+		if from == to {
+			continue
+		}
+		segment := AccuracySegment{Segment: fmt.Sprintf("%s (%.3f-%.3f%%)", name, sorted[from].VolatilityPercent, sorted[to-1].VolatilityPercent)}
+		for _, prediction := range sorted[from:to] {
+			segment.add(prediction)
+		}
+		breakdown.Volatility = append(breakdown.Volatility, segment)
+	}
+
+	pat.mutex.RLock()
+	session := pat.session
+	pat.mutex.RUnlock()
+
+	hours := make(map[string]*AccuracySegment)
+	buckets := make(map[string]*AccuracySegment)
+	days := make(map[string]*AccuracySegment)
+	for _, prediction := range resolved {
+		segmentFor(hours, fmt.Sprintf("%02d:00", prediction.MadeAt.In(session.Location()).Hour())).add(prediction)
+		segmentFor(buckets, confidenceBucket(prediction.Confidence)).add(prediction)
+		segmentFor(days, session.TradingDay(prediction.MadeAt).Format("2006-01-02")).add(prediction)
+	}
+	breakdown.Hours = sortedSegments(hours)
+	breakdown.Confidence = sortedSegments(buckets)
+	breakdown.Days = sortedSegments(days)
+	return breakdown
+}
+
+// Summary returns the accuracy dashboard with up to limit recent outcomes
+func (pat *PredictionAccuracyTracker) Summary(limit int) AccuracySummary {
+	breakdown := pat.Breakdown()
+	summary := AccuracySummary{
+		Overall:    breakdown.Overall,
+		Rolling:    breakdown.Rolling,
+		Pending:    breakdown.Pending,
+		Directions: breakdown.Directions,
+		Indicators: breakdown.Indicators,
+		Confidence: breakdown.Confidence,
+		Recent:     make([]TrackedPrediction, 0, limit),
+	}
+	predictions := pat.All()
+	for i := len(predictions) - 1; i >= 0 && len(summary.Recent) < limit; i-- {
+		if predictions[i].Resolved {
+			summary.Recent = append(summary.Recent, predictions[i])
+		}
+	}
+	return summary
+}
+
+// confidenceBucket labels a 0-1 confidence with its 10-point bucket, e.g. "70-80%"
+func confidenceBucket(confidence float64) string {
+	if confidence < 0.5 {
+		return "00-50%"
+	}
+	lower := int(confidence * 10)
+	if lower >= 10 {
+		lower = 9
+	}
+	return fmt.Sprintf("%d-%d%%", lower*10, lower*10+10)
+}
+
+// add counts one resolved prediction
+func (s *AccuracySegment) add(prediction TrackedPrediction) {
+	s.Predictions++
+	if prediction.Correct {
+		s.Correct++
+	}
+	s.brierSum += prediction.BrierScore
+	s.logLossSum += prediction.LogLoss
+	s.HitRate = float64(s.Correct) / float64(s.Predictions) * 100
+	s.BrierScore = s.brierSum / float64(s.Predictions)
+	s.LogLoss = s.logLossSum / float64(s.Predictions)
+}
+
+// segmentFor returns the named segment, creating it on first use
+func segmentFor(segments map[string]*AccuracySegment, name string) *AccuracySegment {
+	segment, ok := segments[name]
+	if !ok {
+		segment = &AccuracySegment{Segment: name}
+		segments[name] = segment
+	}
+	return segment
+}
+
+// sortedSegments returns the segments ordered by name
+func sortedSegments(segments map[string]*AccuracySegment) []AccuracySegment {
+	sorted := make([]AccuracySegment, 0, len(segments))
+	for _, name := range sortedKeys(segments) {
+		sorted = append(sorted, *segments[name])
+	}
+	return sorted
+}
+
+// TrackPrediction records a served prediction with the current regime,
+// volatility and NEUTRAL band, and scores it against the live price once
+// targetTime passes
+func (tb *TradingBot) TrackPrediction(direction string, confidence, price float64, targetTime time.Time) string {
+	return tb.TrackSymbolPrediction(tb.config.Symbol, direction, confidence, price, targetTime, nil)
+}
+
+// TrackSymbolPrediction is TrackPrediction for any configured symbol, also
+// scoring the indicator signals the prediction was built from
+func (tb *TradingBot) TrackSymbolPrediction(symbol, direction string, confidence, price float64, targetTime time.Time, indicators []IndicatorSignal) string {
+	now := time.Now()
+	prediction := TrackedPrediction{
+		Symbol:      symbol,
+		Direction:   direction,
+		Confidence:  confidence,
+		Price:       price,
+		MadeAt:      now,
+		TargetTime:  targetTime,
+		NeutralBand: tb.config.Prediction.NeutralBand(price, 0, targetTime.Sub(now)),
+		Indicators:  indicatorVotes(indicators),
+	}
+	if candles, err := tb.GetSymbolCandleHistory(symbol, FiveMinute, 0); err == nil && len(candles) > 0 {
+		atr := averageTrueRange(candles, tb.config.ATR.Period)
+		prediction.NeutralBand = tb.config.Prediction.NeutralBand(price, atr, targetTime.Sub(now))
+		if reading, ok := DetectRegime(candles, tb.config.RegimeSwitching); ok {
+			prediction.Regime = reading.Regime
+			prediction.VolatilityPercent = reading.VolatilityPercent
+		}
+	}
+	id := tb.predictionAccuracy.Record(prediction)
+	tb.scheduleResolution(id, symbol, targetTime)
+	return id
+}
+
+// scheduleResolution scores a prediction against the live price at its target time
+func (tb *TradingBot) scheduleResolution(id, symbol string, targetTime time.Time) {
+	time.AfterFunc(time.Until(targetTime), func() {
+		if tb.ctx.Err() != nil {
+			return
+		}
+		actual, err := tb.GetSymbolPrice(valueOrDefault(symbol, tb.config.Symbol))
+		if err != nil {
+			engineLog.Warn("Could not resolve prediction", "prediction_id", id, "error", err)
+			return
+		}
+		if err := tb.predictionAccuracy.Resolve(id, actual, time.Now()); err != nil {
+			engineLog.Warn("Could not resolve prediction", "prediction_id", id, "error", err)
+		}
+	})
+}
+
+// restorePredictions loads persisted predictions and reschedules the pending ones
+func (tb *TradingBot) restorePredictions() {
+	pending, err := tb.predictionAccuracy.SetHistoryFile(tb.config.Prediction.HistoryFile)
+	if err != nil {
+		engineLog.Error("Failed to restore prediction history", "error", err)
+		return
+	}
+	for _, prediction := range pending {
+		tb.scheduleResolution(prediction.ID, prediction.Symbol, prediction.TargetTime)
+	}
+}
+
+// GetPredictionAccuracy returns the accuracy dashboard with up to limit recent outcomes
+func (tb *TradingBot) GetPredictionAccuracy(limit int) AccuracySummary {
+	return tb.predictionAccuracy.Summary(limit)
+}
+
+// GetPredictionAccuracyBreakdown returns prediction hit rate by direction, indicator, regime, volatility, hour and confidence
+func (tb *TradingBot) GetPredictionAccuracyBreakdown() AccuracyBreakdown {
+	return tb.predictionAccuracy.Breakdown()
+}
@@ -0,0 +1,314 @@
+package bot
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPredictionAccuracyBreakdown(t *testing.T) {
+	t.Log("🎯 Testing prediction hit rate segmentation by regime, volatility, hour and confidence")
+
+	tracker := NewPredictionAccuracyTracker(100)
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	// Six predictions: trending ones are right, ranging ones wrong
+	cases := []struct {
+		regime     string
+		volatility float64
+		confidence float64
+		hour       int
+		direction  string
+		move       float64
+	}{
+		{RegimeTrending, 0.10, 0.82, 9, "HIGHER", 25},
+		{RegimeTrending, 0.20, 0.75, 9, "LOWER", -12},
+		{RegimeTrending, 0.30, 0.95, 14, "NEUTRAL", 2},
+		{RegimeRanging, 0.40, 0.55, 14, "HIGHER", -30},
+		{RegimeRanging, 0.50, 0.30, 14, "LOWER", 3},
+		{"", 0.60, 1.00, 22, "HIGHER", 8},
+	}
+	for _, c := range cases {
+		made := base.Add(time.Duration(c.hour-9) * time.Hour)
+		id := tracker.Record(TrackedPrediction{
+			Direction: c.direction, Confidence: c.confidence, Price: 50000, MadeAt: made,
+			TargetTime: made.Add(5 * time.Minute), Regime: c.regime, VolatilityPercent: c.volatility, NeutralBand: 4,
+		})
+		if err := tracker.Resolve(id, 50000+c.move, made.Add(5*time.Minute)); err != nil {
+			t.Fatalf("Resolve failed: %v", err)
+		}
+	}
+	pending := tracker.Record(TrackedPrediction{Direction: "HIGHER", Confidence: 0.7, Price: 50000, MadeAt: base})
+
+	breakdown := tracker.Breakdown()
+	if breakdown.Overall.Predictions != 6 || breakdown.Overall.Correct != 4 || breakdown.Pending != 1 {
+		t.Fatalf("Expected 4/6 correct with 1 pending, got %+v (pending %d)", breakdown.Overall, breakdown.Pending)
+	}
+
+	want := []AccuracySegment{
+		{Segment: RegimeTrending, Predictions: 3, Correct: 3, HitRate: 100},
+		{Segment: RegimeRanging, Predictions: 2, Correct: 0, HitRate: 0},
+		{Segment: "UNKNOWN", Predictions: 1, Correct: 1, HitRate: 100},
+	}
+	if len(breakdown.Regimes) != len(want) {
+		t.Fatalf("Expected %d regime segments, got %+v", len(want), breakdown.Regimes)
+	}
+	for i := range want {
+		got := breakdown.Regimes[i]
+		if got.Segment != want[i].Segment || got.Predictions != want[i].Predictions || got.Correct != want[i].Correct || got.HitRate != want[i].HitRate {
+			t.Errorf("Regime segment %d: expected %+v, got %+v", i, want[i], breakdown.Regimes[i])
+		}
+	}
+
+	if len(breakdown.Volatility) != 3 || breakdown.Volatility[0].Segment != "LOW (0.100-0.200%)" ||
+		breakdown.Volatility[0].HitRate != 100 || breakdown.Volatility[1].HitRate != 50 || breakdown.Volatility[2].HitRate != 50 {
+		t.Errorf("Unexpected volatility terciles %+v", breakdown.Volatility)
+	}
+
+	hours := map[string]int{}
+	for _, segment := range breakdown.Hours {
+		hours[segment.Segment] = segment.Predictions
+	}
+	if hours["09:00"] != 2 || hours["14:00"] != 3 || hours["22:00"] != 1 || breakdown.Hours[0].Segment != "09:00" {
+		t.Errorf("Unexpected hour segments %+v", breakdown.Hours)
+	}
+
+	buckets := map[string]int{}
+	for _, segment := range breakdown.Confidence {
+		buckets[segment.Segment] = segment.Predictions
+	}
+	for bucket, count := range map[string]int{"00-50%": 1, "50-60%": 1, "70-80%": 1, "80-90%": 1, "90-100%": 2} {
+		if buckets[bucket] != count {
+			t.Errorf("Expected %d predictions in bucket %s, got %+v", count, bucket, breakdown.Confidence)
+		}
+	}
+
+	if len(breakdown.Days) != 1 || breakdown.Days[0].Segment != "2024-03-01" || breakdown.Days[0].Predictions != 6 {
+		t.Errorf("Unexpected day segments %+v", breakdown.Days)
+	}
+
+	if err := tracker.Resolve(pending, 50010, base); err != nil {
+		t.Errorf("Expected the pending prediction to resolve: %v", err)
+	}
+	if err := tracker.Resolve(pending, 50010, base); err == nil {
+		t.Error("Expected a second resolution to be rejected")
+	}
+	if err := tracker.Resolve("pred_missing", 1, base); err == nil {
+		t.Error("Expected an unknown prediction to be rejected")
+	}
+}
+
+func TestPredictionScoring(t *testing.T) {
+	t.Log("📐 Testing Brier score and log loss of resolved predictions")
+
+	tests := []struct {
+		direction  string
+		confidence float64
+		actual     string
+		brier      float64
+		logLoss    float64
+	}{
+		{"HIGHER", 1.0, "HIGHER", 0, 0},
+		{"HIGHER", 0.8, "HIGHER", 0.04 + 0.01 + 0.01, -math.Log(0.8)},
+		{"HIGHER", 0.8, "LOWER", 0.64 + 0.81 + 0.01, -math.Log(0.1)},
+		{"NEUTRAL", 0.5, "NEUTRAL", 0.25 + 0.0625 + 0.0625, -math.Log(0.5)},
+		{"LOWER", 1.0, "HIGHER", 2, -math.Log(logLossEpsilon)},
+	}
+	for _, test := range tests {
+		brier, logLoss := scorePrediction(test.direction, test.confidence, test.actual)
+		if math.Abs(brier-test.brier) > 1e-9 || math.Abs(logLoss-test.logLoss) > 1e-9 {
+			t.Errorf("%s @ %.2f vs %s: expected brier %.4f / log loss %.4f, got %.4f / %.4f",
+				test.direction, test.confidence, test.actual, test.brier, test.logLoss, brier, logLoss)
+		}
+	}
+
+	// Better calibrated confidence scores better even at the same hit rate
+	score := func(confidence float64) AccuracySegment {
+		tracker := NewPredictionAccuracyTracker(10)
+		for i, move := range []float64{10, 10, 10, -10} {
+			id := tracker.Record(TrackedPrediction{Direction: "HIGHER", Confidence: confidence, Price: 100, MadeAt: time.Unix(int64(i), 0)})
+			tracker.Resolve(id, 100+move, time.Unix(int64(i), 0))
+		}
+		return tracker.Breakdown().Overall
+	}
+	calibrated, overconfident := score(0.75), score(0.99)
+	if calibrated.HitRate != 75 || overconfident.HitRate != 75 {
+		t.Fatalf("Expected 75%% hit rate for both, got %.1f and %.1f", calibrated.HitRate, overconfident.HitRate)
+	}
+	if calibrated.BrierScore >= overconfident.BrierScore || calibrated.LogLoss >= overconfident.LogLoss {
+		t.Errorf("Expected calibrated confidence to score better: brier %.4f vs %.4f, log loss %.4f vs %.4f",
+			calibrated.BrierScore, overconfident.BrierScore, calibrated.LogLoss, overconfident.LogLoss)
+	}
+}
+
+func TestNeutralBand(t *testing.T) {
+	t.Log("🎚️ Testing the NEUTRAL band scales with cost, ATR and horizon")
+
+	config := DefaultConfig().Prediction
+	config.RoundTripCostPercent = 0.1
+	config.NeutralATRFraction = 0.5
+
+	// $50 of cost at 50k plus half a $40 ATR, doubled for a 4-candle horizon
+	if band := config.NeutralBand(50000, 40, 20*time.Minute); math.Abs(band-90) > 1e-9 {
+		t.Errorf("Expected a $90 band, got %.4f", band)
+	}
+	if short, long := config.NeutralBand(50000, 40, 5*time.Minute), config.NeutralBand(50000, 40, 30*time.Minute); short >= long {
+		t.Errorf("Expected a longer horizon to widen the band: %.2f vs %.2f", short, long)
+	}
+	if band := config.NeutralBand(50000, 40, 0); band != 50 {
+		t.Errorf("Expected only the cost without a horizon, got %.2f", band)
+	}
+
+	// ATR comes from the true ranges of the latest candles
+	candles := []Candle{
+		{Close: 100, High: 101, Low: 99},
+		{Close: 102, High: 103, Low: 100},
+		{Close: 101, High: 104, Low: 98},
+	}
+	if atr := averageTrueRange(candles, 14); atr != 4.5 {
+		t.Errorf("Expected ATR 4.5, got %.4f", atr)
+	}
+	band := config.NeutralBandFor(candles, 14, 5*time.Minute)
+	if math.Abs(band-(0.101+2.25)) > 1e-9 {
+		t.Errorf("Expected band %.4f, got %.4f", 0.101+2.25, band)
+	}
+	for change, want := range map[float64]string{3: "HIGHER", -3: "LOWER", 2: "NEUTRAL", -2: "NEUTRAL"} {
+		if got := classifyPriceMove(change, band); got != want {
+			t.Errorf("Expected %+.0f to classify as %s, got %s", change, want, got)
+		}
+	}
+
+	bad := DefaultConfig()
+	bad.Prediction.RoundTripCostPercent = -0.1
+	if err := ValidateConfig(bad); err == nil || !strings.Contains(err.Error(), "prediction.round_trip_cost_percent") {
+		t.Errorf("Expected prediction.round_trip_cost_percent validation error, got %v", err)
+	}
+}
+
+func TestMagnitudeBuckets(t *testing.T) {
+	t.Log("📶 Testing per-bucket move probabilities")
+
+	config := DefaultConfig().Prediction
+	sum := func(buckets []MagnitudeBucket) float64 {
+		total := 0.0
+		for _, bucket := range buckets {
+			total += bucket.Probability
+		}
+		return total
+	}
+	up := func(buckets []MagnitudeBucket) float64 { return buckets[3].Probability + buckets[4].Probability }
+
+	higher := config.MagnitudeBuckets("HIGHER", 0.8, 0.15, 5*time.Minute)
+	lower := config.MagnitudeBuckets("LOWER", 0.8, 0.15, 5*time.Minute)
+	neutral := config.MagnitudeBuckets("NEUTRAL", 0.8, 0.15, 5*time.Minute)
+	for _, buckets := range [][]MagnitudeBucket{higher, lower, neutral} {
+		if len(buckets) != 5 || math.Abs(sum(buckets)-1) > 1e-9 {
+			t.Fatalf("Expected 5 buckets summing to 1, got %+v", buckets)
+		}
+	}
+	if higher[0].Bucket != BucketStrongDown || higher[4].Bucket != BucketStrongUp || higher[0].MinPercent != nil || *higher[4].MinPercent != 0.3 {
+		t.Errorf("Unexpected bucket layout %+v", higher)
+	}
+	if up(higher) <= 0.5 || up(lower) >= up(neutral) || neutral[2].Probability <= higher[2].Probability {
+		t.Errorf("Expected HIGHER to favour up moves and NEUTRAL to favour FLAT: %+v / %+v / %+v", higher, lower, neutral)
+	}
+	if MostLikelyBucket(neutral) != BucketFlat {
+		t.Errorf("Expected FLAT to be the most likely NEUTRAL bucket, got %s", MostLikelyBucket(neutral))
+	}
+	if wide := config.MagnitudeBuckets("HIGHER", 0.8, 0.15, 30*time.Minute); wide[4].Probability <= higher[4].Probability {
+		t.Error("Expected a longer horizon to make strong moves more likely")
+	}
+
+	// Observed outcomes of similar predictions pull the model towards what happened
+	tb := NewTradingBot(DefaultConfig())
+	before := tb.PredictMagnitude("HIGHER", 0.8, 100, 5*time.Minute)
+	for i := 0; i < 40; i++ {
+		id := tb.predictionAccuracy.Record(TrackedPrediction{Direction: "HIGHER", Confidence: 0.85, Price: 100})
+		tb.predictionAccuracy.Resolve(id, 99.5, time.Now()) // -0.5%: STRONG_DOWN
+	}
+	after := tb.PredictMagnitude("HIGHER", 0.8, 100, 5*time.Minute)
+	if math.Abs(sum(after)-1) > 1e-9 || after[0].Probability < 0.6 || after[0].Probability <= before[0].Probability {
+		t.Errorf("Expected 40 strong down outcomes to dominate, got %+v", after)
+	}
+
+	bad := DefaultConfig()
+	bad.Prediction.StrongMovePercent = 0.05
+	if err := ValidateConfig(bad); err == nil || !strings.Contains(err.Error(), "prediction.strong_move_percent") {
+		t.Errorf("Expected prediction.strong_move_percent validation error, got %v", err)
+	}
+}
+
+func TestPredictionOutcomeTracking(t *testing.T) {
+	t.Log("📒 Testing per-direction and per-indicator accuracy, the summary and persisted predictions")
+
+	path := filepath.Join(t.TempDir(), "predictions.json")
+	tracker := NewPredictionAccuracyTracker(100)
+	if pending, err := tracker.SetHistoryFile(path); err != nil || len(pending) != 0 {
+		t.Fatalf("Expected a missing history file to start empty, got %d (err %v)", len(pending), err)
+	}
+
+	// RSI agrees with the calls and MACD opposes them; one LOWER call is wrong
+	base := time.Now().Add(-time.Hour)
+	signals := []IndicatorSignal{{Name: "RSI_5m", Signal: Buy, Strength: 0.8}, {Name: "MACD_5m", Signal: Sell, Strength: 0.6}}
+	cases := []struct {
+		direction string
+		move      float64
+		rsi, macd SignalType
+	}{
+		{"HIGHER", 20, Buy, Sell},
+		{"HIGHER", 15, Buy, Sell},
+		{"LOWER", -10, Sell, Buy},
+		{"LOWER", 30, Sell, Buy},
+	}
+	for i, c := range cases {
+		signals[0].Signal, signals[1].Signal = c.rsi, c.macd
+		made := base.Add(time.Duration(i) * time.Minute)
+		id := tracker.Record(TrackedPrediction{Direction: c.direction, Confidence: 0.7, Price: 100, MadeAt: made,
+			TargetTime: made.Add(5 * time.Minute), NeutralBand: 1, Indicators: indicatorVotes(signals)})
+		if err := tracker.Resolve(id, 100+c.move, made.Add(5*time.Minute)); err != nil {
+			t.Fatalf("Resolve failed: %v", err)
+		}
+	}
+	stale := tracker.Record(TrackedPrediction{Direction: "HIGHER", Price: 100, MadeAt: base, TargetTime: base.Add(time.Minute)})
+	ahead := tracker.Record(TrackedPrediction{Direction: "LOWER", Price: 100, MadeAt: time.Now(), TargetTime: time.Now().Add(time.Hour)})
+
+	breakdown := tracker.Breakdown()
+	directions := map[string]int{}
+	for _, segment := range breakdown.Directions {
+		directions[segment.Segment] = segment.Correct*10 + segment.Predictions
+	}
+	if len(breakdown.Directions) != 2 || breakdown.Directions[0].Segment != "HIGHER" || directions["HIGHER"] != 22 || directions["LOWER"] != 12 {
+		t.Errorf("Expected HIGHER 2/2 and LOWER 1/2, got %+v", breakdown.Directions)
+	}
+	if len(breakdown.Indicators) != 2 || breakdown.Indicators[0].Segment != "MACD_5m" || breakdown.Indicators[0].Correct != 1 || breakdown.Indicators[1].Correct != 3 {
+		t.Errorf("Expected MACD right once and RSI three times, got %+v", breakdown.Indicators)
+	}
+	if breakdown.Rolling.Predictions != 4 || breakdown.Rolling.Correct != 3 || breakdown.Pending != 2 {
+		t.Errorf("Expected a rolling 3/4 with 2 pending, got %+v (pending %d)", breakdown.Rolling, breakdown.Pending)
+	}
+
+	summary := tracker.Summary(2)
+	if len(summary.Recent) != 2 || summary.Recent[0].ActualPrice != 130 || len(summary.Confidence) != 1 || summary.Overall.Correct != 3 {
+		t.Errorf("Expected the two newest outcomes first and one confidence bucket, got %+v", summary)
+	}
+
+	// Reloading keeps outcomes and the pending prediction still ahead, but not the stale one
+	restored := NewPredictionAccuracyTracker(100)
+	pending, err := restored.SetHistoryFile(path)
+	if err != nil || len(pending) != 1 || pending[0].ID != ahead {
+		t.Fatalf("Expected only %s to be pending after reload, got %+v (err %v)", ahead, pending, err)
+	}
+	for _, prediction := range restored.All() {
+		if prediction.ID == stale {
+			t.Errorf("Expected the stale prediction %s to be dropped", stale)
+		}
+	}
+	if reloaded := restored.Breakdown(); reloaded.Overall.Correct != 3 || len(reloaded.Indicators) != 2 || reloaded.Pending != 1 {
+		t.Errorf("Expected outcomes and indicator votes to survive a reload, got %+v", reloaded)
+	}
+	if id := restored.Record(TrackedPrediction{Direction: "HIGHER", Price: 100}); id != "pred_7" {
+		t.Errorf("Expected IDs to continue after the restored ones, got %s", id)
+	}
+}
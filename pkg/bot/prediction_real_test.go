@@ -137,14 +137,7 @@ func testRealPredictionAtTime(t *testing.T, aggregator *SignalAggregator, dataPr
 
 	// Calculate actual direction
 	priceChange := actualPrice - currentPrice
-	actualDirection := "NEUTRAL"
-	threshold := 15.0 // Slightly higher threshold for real data noise
-
-	if priceChange > threshold {
-		actualDirection = "HIGHER"
-	} else if priceChange < -threshold {
-		actualDirection = "LOWER"
-	}
+	actualDirection := actualDirectionFor(fiveMinCandles, priceChange)
 
 	// Determine accuracy
 	wasCorrect := prediction.Direction == actualDirection
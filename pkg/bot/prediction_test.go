@@ -268,14 +268,9 @@ func runSinglePredictionTest(aggregator *SignalAggregator, histData *HistoricalD
 	targetTime := testTime.Add(5 * time.Minute)
 	actualPrice := histData.GetActualPriceAt(targetTime)
 
-	// Calculate actual direction with optimized threshold
+	// Calculate actual direction with the NEUTRAL band live predictions are scored against
 	priceChange := actualPrice - currentPrice
-	actualDirection := "NEUTRAL"
-	if priceChange > 4 { // Increased from $3 to $4 threshold for more decisive classification
-		actualDirection = "HIGHER"
-	} else if priceChange < -4 {
-		actualDirection = "LOWER"
-	}
+	actualDirection := actualDirectionFor(fiveMinCandles, priceChange)
 
 	// Determine if prediction was correct
 	wasCorrect := prediction.Direction == actualDirection
@@ -631,3 +626,11 @@ func getTestConfig() Config {
 		DataProvider:  "sample",
 	}
 }
+
+// actualDirectionFor classifies a 5-minute price change with the configured NEUTRAL
+// band (round-trip cost plus ATR noise), the same definition live evaluation uses
+func actualDirectionFor(fiveMinCandles []Candle, priceChange float64) string {
+	config := DefaultConfig()
+	band := config.Prediction.NeutralBandFor(fiveMinCandles, config.ATR.Period, 5*time.Minute)
+	return classifyPriceMove(priceChange, band)
+}
@@ -0,0 +1,80 @@
+package bot
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/indicator"
+)
+
+func TestPriceChannels(t *testing.T) {
+	t.Log("📏 Testing Keltner and Donchian channels in breakout and mean-reversion modes")
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candle := func(i int, close, high, low float64) indicator.Candle {
+		return indicator.Candle{Timestamp: origin.Add(time.Duration(i) * 5 * time.Minute), Open: close, High: high, Low: low, Close: close}
+	}
+	var flat []indicator.Candle
+	for i := 0; i < 30; i++ {
+		flat = append(flat, candle(i, 100, 101, 99))
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	// Keltner: a 100 EMA with an ATR of 2 puts the bands at 96 and 104
+	keltner := indicator.KeltnerChannelConfig{Enabled: true, EMAPeriod: 20, ATRPeriod: 10, Multiplier: 2, Mode: ChannelModeBreakout}
+	values := indicator.NewKeltnerChannel(keltner, indicator.FiveMinute).CalculateAll(flat)
+	if !near(values.MiddleBand[29], 100) || !near(values.UpperBand[29], 104) || !near(values.LowerBand[29], 96) {
+		t.Errorf("Expected bands 96/100/104, got %.4f/%.4f/%.4f", values.LowerBand[29], values.MiddleBand[29], values.UpperBand[29])
+	}
+
+	// A close at 90 falls below the widened lower band
+	drop := append(append([]indicator.Candle(nil), flat...), candle(30, 90, 91, 89))
+	for mode, want := range map[string]indicator.SignalType{ChannelModeBreakout: indicator.Sell, ChannelModeMeanReversion: indicator.Buy} {
+		keltner.Mode = mode
+		kc := indicator.NewKeltnerChannel(keltner, indicator.FiveMinute)
+		positions := kc.Calculate(drop)
+		signal := kc.GetSignal(positions, 90)
+		if len(positions) != len(drop)-19 || positions[len(positions)-1] >= 0 || signal.Signal != want {
+			t.Errorf("Expected Keltner %s to signal %v below the band, got %+v", mode, want, signal)
+		}
+	}
+
+	// Donchian: the channel spans the previous candles, so a new high breaks out
+	donchian := indicator.DonchianChannelConfig{Enabled: true, Period: 20, Mode: ChannelModeBreakout}
+	rally := append(append([]indicator.Candle(nil), flat...), candle(30, 103, 103.5, 100))
+	dc := indicator.NewDonchianChannel(donchian, indicator.FiveMinute)
+	bands := dc.CalculateAll(rally)
+	if !near(bands.UpperBand[30], 101) || !near(bands.LowerBand[30], 99) || !near(bands.Position[30], 2) {
+		t.Errorf("Expected a 99-101 channel with the close at 2, got %+v", bands.Position[30])
+	}
+	if signal := dc.GetSignal(dc.Calculate(rally), 103); signal.Signal != indicator.Buy || signal.Strength != 1 {
+		t.Errorf("Expected a full-strength breakout BUY, got %+v", signal)
+	}
+	donchian.Mode = ChannelModeMeanReversion
+	dc = indicator.NewDonchianChannel(donchian, indicator.FiveMinute)
+	if signal := dc.GetSignal(dc.Calculate(rally), 103); signal.Signal != indicator.Sell {
+		t.Errorf("Expected mean reversion to fade the new high, got %+v", signal)
+	}
+	if signal := dc.GetSignal(dc.Calculate(flat), 100); signal.Signal != indicator.Hold {
+		t.Errorf("Expected mid-channel to hold, got %+v", signal)
+	}
+
+	// Both are registered, validated and run by the aggregator
+	config := DefaultConfig()
+	config.KeltnerChannel.Enabled = true
+	config.DonchianChannel.Enabled = true
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected the default channels to be valid: %v", err)
+	}
+	names := NewSignalAggregator(config).GetActiveIndicatorNames()
+	if !slices.Contains(names, "Keltner Channel") || !slices.Contains(names, "Donchian Channel") {
+		t.Errorf("Expected both channels to be active, got %v", names)
+	}
+	config.DonchianChannel.Mode = "reversal"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "donchian_channel.mode") {
+		t.Errorf("Expected an unknown mode to be rejected, got %v", err)
+	}
+}
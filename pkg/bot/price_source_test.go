@@ -0,0 +1,58 @@
+package bot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPriceSources(t *testing.T) {
+	t.Log("📏 Testing last/mark/mid price sources")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fapi/v1/ticker/price":
+			w.Write([]byte(`{"symbol":"BTCUSDT","price":"50500.00"}`)) // Single print far from the book
+		case "/fapi/v1/premiumIndex":
+			w.Write([]byte(`{"symbol":"BTCUSDT","markPrice":"50010.50"}`))
+		case "/fapi/v1/ticker/bookTicker":
+			w.Write([]byte(`{"symbol":"BTCUSDT","bidPrice":"49999.00","askPrice":"50001.00"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewBinanceFuturesDataProvider("", "")
+	provider.baseURL = server.URL
+
+	expected := map[string]float64{
+		PriceSourceLast: 50500.00,
+		PriceSourceMark: 50010.50,
+		PriceSourceMid:  50000.00,
+	}
+	for source, want := range expected {
+		price, err := provider.GetPrice("BTCUSDT", source)
+		if err != nil {
+			t.Fatalf("%s price failed: %v", source, err)
+		}
+		if price != want {
+			t.Errorf("Expected %s price %.2f, got %.2f", source, want, price)
+		}
+	}
+
+	config := DefaultConfig()
+	config.PriceSource = PriceSourceMark
+	config.PriceSources = map[string]string{"ETHUSDT": PriceSourceMid}
+	if source := config.PriceSourceFor("BTCUSDT"); source != PriceSourceMark {
+		t.Errorf("Expected default source mark, got %s", source)
+	}
+	if source := config.PriceSourceFor("ETHUSDT"); source != PriceSourceMid {
+		t.Errorf("Expected per-symbol source mid, got %s", source)
+	}
+
+	config.PriceSources["ETHUSDT"] = "vwap"
+	if err := ValidateConfig(config); err == nil {
+		t.Errorf("Expected unknown price source to fail validation")
+	}
+}
@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// RebalancingStrategy keeps holdings close to target weights using threshold bands
+type RebalancingStrategy struct {
+	config  RebalanceConfig
+	lastRun time.Time
+}
+
+// NewRebalancingStrategy creates a new rebalancing strategy
+func NewRebalancingStrategy(config RebalanceConfig) *RebalancingStrategy {
+	return &RebalancingStrategy{config: config}
+}
+
+// Name returns the strategy name
+func (rs *RebalancingStrategy) Name() string {
+	return "REBALANCE"
+}
+
+// Symbols returns the symbols with target weights
+func (rs *RebalancingStrategy) Symbols() []string {
+	symbols := make([]string, 0, len(rs.config.TargetWeights))
+	for symbol := range rs.config.TargetWeights {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Evaluate computes current weights and emits intents for symbols outside their band.
+// Sells are emitted before buys so freed cash can fund purchases.
+func (rs *RebalancingStrategy) Evaluate(ctx StrategyContext) ([]OrderIntent, error) {
+	interval := time.Duration(rs.config.IntervalMinutes) * time.Minute
+	if !rs.lastRun.IsZero() && ctx.Now.Sub(rs.lastRun) < interval {
+		return nil, nil
+	}
+	rs.lastRun = ctx.Now
+
+	// Total portfolio value = cash + marked holdings
+	totalValue := ctx.Cash
+	for symbol, quantity := range ctx.Holdings {
+		totalValue += quantity * ctx.Prices[symbol]
+	}
+	if totalValue <= 0 {
+		return nil, fmt.Errorf("portfolio value is zero")
+	}
+
+	sells := make([]OrderIntent, 0)
+	buys := make([]OrderIntent, 0)
+	for _, symbol := range rs.Symbols() {
+		target := rs.config.TargetWeights[symbol]
+		price := ctx.Prices[symbol]
+		if price <= 0 {
+			return nil, fmt.Errorf("no price for %s", symbol)
+		}
+
+		currentValue := ctx.Holdings[symbol] * price
+		weight := currentValue / totalValue
+		drift := weight - target
+		if math.Abs(drift) <= rs.config.ThresholdBand {
+			continue
+		}
+
+		adjustValue := target*totalValue - currentValue
+		if math.Abs(adjustValue) < rs.config.MinTradeValue {
+			continue
+		}
+
+		intent := OrderIntent{
+			Symbol:   symbol,
+			Quantity: math.Abs(adjustValue) / price,
+			Price:    price,
+			Reason:   fmt.Sprintf("weight %.1f%% vs target %.1f%% (band ±%.1f%%)", weight*100, target*100, rs.config.ThresholdBand*100),
+		}
+		if adjustValue < 0 {
+			intent.Side = "SELL"
+			sells = append(sells, intent)
+		} else {
+			intent.Side = "BUY"
+			buys = append(buys, intent)
+		}
+	}
+
+	return append(sells, buys...), nil
+}
@@ -0,0 +1,68 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRebalancingStrategy(t *testing.T) {
+	t.Log("⚖️ Testing portfolio rebalancing through the strategy layer")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000.0)
+
+	prices := map[string]float64{"BTCUSDT": 50000, "ETHUSDT": 2500}
+	priceFn := func(symbol string) (float64, error) {
+		price, ok := prices[symbol]
+		if !ok {
+			return 0, fmt.Errorf("no price for %s", symbol)
+		}
+		return price, nil
+	}
+
+	manager := NewStrategyManager(executor, priceFn, time.Minute)
+	manager.Register(NewRebalancingStrategy(RebalanceConfig{
+		Enabled:         true,
+		TargetWeights:   map[string]float64{"BTCUSDT": 0.6, "ETHUSDT": 0.3},
+		ThresholdBand:   0.05,
+		IntervalMinutes: 60,
+		MinTradeValue:   10,
+	}))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	manager.RunOnce(start)
+
+	holdings := executor.GetHoldings()
+	btcValue := holdings["BTCUSDT"] * prices["BTCUSDT"]
+	ethValue := holdings["ETHUSDT"] * prices["ETHUSDT"]
+	if math.Abs(btcValue-6000) > 1 || math.Abs(ethValue-3000) > 1 {
+		t.Fatalf("Expected ~6000 BTC / ~3000 ETH allocation, got %.2f / %.2f", btcValue, ethValue)
+	}
+	if cash := executor.GetBalances()["USDT"]; math.Abs(cash-1000) > 1 {
+		t.Errorf("Expected ~1000 USDT cash left, got %.2f", cash)
+	}
+
+	// BTC rallies 50%: BTC weight drifts to ~69% which is outside the 5% band
+	prices["BTCUSDT"] = 75000
+	manager.RunOnce(start.Add(30 * time.Minute)) // Before interval: no action
+	if executor.GetHoldings()["BTCUSDT"] != holdings["BTCUSDT"] {
+		t.Errorf("Rebalance should not run before the interval elapses")
+	}
+
+	manager.RunOnce(start.Add(61 * time.Minute))
+	after := executor.GetHoldings()
+	total := executor.GetBalances()["USDT"] + after["BTCUSDT"]*prices["BTCUSDT"] + after["ETHUSDT"]*prices["ETHUSDT"]
+	btcWeight := after["BTCUSDT"] * prices["BTCUSDT"] / total
+	if math.Abs(btcWeight-0.6) > 0.01 {
+		t.Errorf("Expected BTC weight back near 60%%, got %.2f%%", btcWeight*100)
+	}
+	t.Logf("   Portfolio value %.2f, BTC weight %.1f%%, fills %d", total, btcWeight*100, len(executor.GetOrderHistory(0)))
+
+	// Disabled executor rejects strategy intents just like signal trades
+	executor.Disable()
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: "TEST", Symbol: "BTCUSDT", Side: "BUY", Quantity: 0.01, Price: 75000}); err == nil {
+		t.Errorf("Expected disabled executor to reject intents")
+	}
+}
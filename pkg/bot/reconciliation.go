@@ -0,0 +1,286 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ExchangeOrderState is an order as the exchange reports it
+type ExchangeOrderState struct {
+	OrderID          string    `json:"order_id"`
+	Symbol           string    `json:"symbol"`
+	Side             string    `json:"side"`   // "BUY" or "SELL"
+	Status           string    `json:"status"` // NEW, PARTIALLY_FILLED, FILLED, CANCELED, EXPIRED, REJECTED
+	Quantity         float64   `json:"quantity"`
+	ExecutedQuantity float64   `json:"executed_quantity"` // Cumulative filled quantity
+	AveragePrice     float64   `json:"average_price"`     // Average price of the executed quantity
+	UpdateTime       time.Time `json:"update_time"`
+}
+
+// ExchangePositionState is the exchange's view of the position in a symbol
+type ExchangePositionState struct {
+	Symbol     string  `json:"symbol"`
+	Quantity   float64 `json:"quantity"` // Positive long, negative short, 0 flat
+	EntryPrice float64 `json:"entry_price"`
+}
+
+// AccountStateProvider reads order and position state from the exchange
+type AccountStateProvider interface {
+	GetOrderState(symbol, orderID string) (*ExchangeOrderState, error)
+	GetPositionState(symbol string) (*ExchangePositionState, error)
+}
+
+// ReconcileReport summarizes one reconciliation pass
+type ReconcileReport struct {
+	Time          time.Time `json:"time"`
+	OrdersChecked int       `json:"orders_checked"`
+	Fills         int       `json:"fills"`   // Orders whose executed quantity grew
+	Repairs       []string  `json:"repairs"` // Local position changes made to match the exchange
+}
+
+// reconcileTolerance is the relative difference below which quantities and prices match
+const reconcileTolerance = 1e-6
+
+// TrackOrder registers a working exchange order whose fills update the position
+func (te *TradeExecutor) TrackOrder(order *Order) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	if order.Status == "" {
+		order.Status = "PENDING"
+	}
+	te.openOrders[order.ID] = order
+}
+
+// GetOpenOrders returns copies of the working orders
+func (te *TradeExecutor) GetOpenOrders() []Order {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	orders := make([]Order, 0, len(te.openOrders))
+	for _, id := range sortedKeys(te.openOrders) {
+		orders = append(orders, *te.openOrders[id])
+	}
+	return orders
+}
+
+// ApplyOrderState applies an exchange order update: new fills adjust the
+// position quantity and average entry, terminal statuses close the order.
+// It returns whether the update contained new fills
+func (te *TradeExecutor) ApplyOrderState(id string, state ExchangeOrderState) (bool, error) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	return te.applyOrderState(id, state)
+}
+
+// applyOrderState applies an exchange order update (assumes lock is held)
+func (te *TradeExecutor) applyOrderState(id string, state ExchangeOrderState) (bool, error) {
+	order, ok := te.openOrders[id]
+	if !ok {
+		return false, fmt.Errorf("order %s is not open", id)
+	}
+
+	filled := false
+	if delta := state.ExecutedQuantity - order.FilledQuantity; delta > 0 {
+		// Price of just the new fills, from the change in cumulative cost
+		price := (state.ExecutedQuantity*state.AveragePrice - order.FilledQuantity*order.AveragePrice) / delta
+		if price <= 0 {
+			price = state.AveragePrice
+		}
+		order.FilledQuantity = state.ExecutedQuantity
+		order.AveragePrice = state.AveragePrice
+		order.Status = "PARTIALLY_FILLED"
+		te.applyFill(order, delta, price)
+		intended := order.Price
+		if intended <= 0 {
+			intended = price
+		}
+		te.recordExecution(order.ID, order.Side, "FILL", delta, intended, price, order.CreatedTime)
+		filled = true
+	}
+
+	switch state.Status {
+	case "FILLED":
+		order.Status = "FILLED"
+	case "CANCELED", "EXPIRED", "REJECTED":
+		order.Status = "CANCELLED"
+	}
+	if order.Status == "FILLED" || order.Status == "CANCELLED" {
+		order.FilledTime = te.now()
+		delete(te.openOrders, id)
+		te.orderHistory = append(te.orderHistory, order)
+	}
+	return filled, nil
+}
+
+// applyFill adds or removes filled quantity from the position (assumes lock is held)
+func (te *TradeExecutor) applyFill(order *Order, quantity, price float64) {
+	side := "LONG"
+	if order.Side == "SELL" {
+		side = "SHORT"
+	}
+	position := te.currentPosition
+
+	switch {
+	case position == nil:
+		te.currentPosition = te.newPosition(side, price, quantity)
+		te.currentPosition.Strategy = valueOrDefault(order.Strategy, ATRStrategyName)
+		te.currentPosition.Confidence = order.Confidence
+		executorLog.Info("🧾 Fill opened", "side", side, "symbol", te.config.Symbol, "quantity", quantity, "price", te.symbolFilters.FormatPrice(price))
+	case position.Side == side:
+		position.EntryPrice = te.config.Contract.AverageEntry(position.Quantity, position.EntryPrice, quantity, price)
+		position.Quantity += quantity
+		executorLog.Info("🧾 Fill added", "side", side, "quantity", quantity, "price", te.symbolFilters.FormatPrice(price),
+			"position_quantity", position.Quantity, "entry_price", te.symbolFilters.FormatPrice(position.EntryPrice))
+	case quantity >= position.Quantity*(1-reconcileTolerance):
+		te.closePosition(valueOrDefault(order.Reason, "FILL"), price, position.ATRTrailStop)
+	default:
+		// Partial exit: realize the PnL of the filled slice
+		pnl := te.config.Contract.PnL(position.Side, position.EntryPrice, price, quantity)
+		te.balances[te.marginCurrency] += pnl
+		te.bookFor(position.Strategy).recordPnL(pnl)
+		position.Quantity -= quantity
+		executorLog.Info("🧾 Fill reduced", "side", position.Side, "quantity", quantity, "price", te.symbolFilters.FormatPrice(price),
+			"pnl", FormatCurrencyAmount(pnl, te.marginCurrency), "position_quantity", position.Quantity)
+	}
+}
+
+// newPosition creates a position opened by exchange fills rather than a signal (assumes lock is held)
+func (te *TradeExecutor) newPosition(side string, entryPrice, quantity float64) *Position {
+	return &Position{
+		ID:             te.nextID("pos"),
+		Symbol:         te.config.Symbol,
+		BaseCurrency:   te.baseCurrency,
+		QuoteCurrency:  te.quoteCurrency,
+		ContractType:   te.contractType(),
+		MarginCurrency: te.marginCurrency,
+		Side:           side,
+		EntryPrice:     entryPrice,
+		Quantity:       quantity,
+		CurrentPrice:   entryPrice,
+		OpenTime:       te.now(),
+		Strategy:       ATRStrategyName,
+	}
+}
+
+// RepairPosition makes the local position match the exchange's, closing,
+// adopting or resizing it; price marks positions closed on the exchange.
+// It returns a description of each change made
+func (te *TradeExecutor) RepairPosition(state ExchangePositionState, price float64) []string {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+
+	repairs := make([]string, 0)
+	if te.inFlight != "" {
+		// The running order request may still move the position; repair on the next pass
+		return repairs
+	}
+	position := te.currentPosition
+	quantity := math.Abs(state.Quantity)
+	side := "LONG"
+	if state.Quantity < 0 {
+		side = "SHORT"
+	}
+	flat := quantity <= te.symbolFilters.StepSize/2
+
+	// Closed (or flipped) on the exchange, e.g. a stop or liquidation we missed
+	if position != nil && (flat || position.Side != side) {
+		repairs = append(repairs, fmt.Sprintf("closed local %s %.8f missing on exchange", position.Side, position.Quantity))
+		te.closePosition("RECONCILED", price, position.ATRTrailStop)
+		position = nil
+	}
+	if flat {
+		return repairs
+	}
+
+	if position == nil {
+		// Spot holdings carry no entry price; adopt them at the current one
+		entry := state.EntryPrice
+		if entry <= 0 {
+			entry = price
+		}
+		te.currentPosition = te.newPosition(side, entry, quantity)
+		te.markPosition(price)
+		return append(repairs, fmt.Sprintf("adopted exchange %s %.8f @ %s", side, quantity, te.symbolFilters.FormatPrice(entry)))
+	}
+
+	if math.Abs(position.Quantity-quantity) > quantity*reconcileTolerance {
+		repairs = append(repairs, fmt.Sprintf("quantity %.8f -> %.8f", position.Quantity, quantity))
+		position.Quantity = quantity
+	}
+	if state.EntryPrice > 0 && math.Abs(position.EntryPrice-state.EntryPrice) > state.EntryPrice*reconcileTolerance {
+		repairs = append(repairs, fmt.Sprintf("entry %s -> %s", te.symbolFilters.FormatPrice(position.EntryPrice), te.symbolFilters.FormatPrice(state.EntryPrice)))
+		position.EntryPrice = state.EntryPrice
+	}
+	if len(repairs) > 0 && price > 0 {
+		te.markPosition(price)
+	}
+	return repairs
+}
+
+// Reconcile polls the exchange for every working order and the position,
+// applies fills that were missed and repairs the local position
+func Reconcile(executor *TradeExecutor, account AccountStateProvider, symbol string, price float64) (ReconcileReport, error) {
+	report := ReconcileReport{Time: executor.now(), Repairs: make([]string, 0)}
+
+	for _, order := range executor.GetOpenOrders() {
+		if order.ExchangeID == "" {
+			continue
+		}
+		state, err := account.GetOrderState(order.Symbol, order.ExchangeID)
+		if err != nil {
+			return report, fmt.Errorf("failed to fetch order %s: %w", order.ExchangeID, err)
+		}
+		report.OrdersChecked++
+		filled, err := executor.ApplyOrderState(order.ID, *state)
+		if err != nil {
+			return report, err
+		}
+		if filled {
+			report.Fills++
+		}
+	}
+
+	state, err := account.GetPositionState(symbol)
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch position: %w", err)
+	}
+	report.Repairs = append(report.Repairs, executor.RepairPosition(*state, price)...)
+	return report, nil
+}
+
+// startReconciliation polls the exchange account every interval and repairs local state
+func (tb *TradingBot) startReconciliation(ctx context.Context, account AccountStateProvider) {
+	interval := time.Duration(tb.config.Reconciliation.IntervalSeconds) * time.Second
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				tb.reconcile(account)
+			}
+		}
+	}()
+	executorLog.Info("🔄 Reconciling orders and position with the exchange", "interval", interval.String())
+}
+
+// reconcile runs one reconciliation pass and records the outcome
+func (tb *TradingBot) reconcile(account AccountStateProvider) {
+	price, err := tb.GetCurrentPrice()
+	if err != nil {
+		executorLog.Warn("Reconciliation skipped", "error", err)
+		return
+	}
+	report, err := Reconcile(tb.tradeExecutor, account, tb.config.Symbol, price)
+	if err != nil {
+		executorLog.Error("Reconciliation failed", "error", err)
+		return
+	}
+	if report.Fills > 0 || len(report.Repairs) > 0 {
+		executorLog.Info("🔄 Reconciled orders", "orders", report.OrdersChecked, "fills", report.Fills, "repairs", report.Repairs)
+	}
+}
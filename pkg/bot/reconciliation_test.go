@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"math"
+	"testing"
+)
+
+// fakeAccount serves canned exchange order and position state
+type fakeAccount struct {
+	orders   map[string]*ExchangeOrderState
+	position ExchangePositionState
+}
+
+func (fa *fakeAccount) GetOrderState(symbol, orderID string) (*ExchangeOrderState, error) {
+	state := *fa.orders[orderID]
+	return &state, nil
+}
+
+func (fa *fakeAccount) GetPositionState(symbol string) (*ExchangePositionState, error) {
+	state := fa.position
+	return &state, nil
+}
+
+func TestReconciliation(t *testing.T) {
+	t.Log("🔄 Testing partial fills and position repair from polled exchange state")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000)
+	executor.TrackOrder(&Order{ID: "entry", ExchangeID: "42", Symbol: config.Symbol, Side: "BUY", Type: "LIMIT", Quantity: 1})
+	account := &fakeAccount{orders: map[string]*ExchangeOrderState{
+		"42": {OrderID: "42", Status: "PARTIALLY_FILLED", Quantity: 1, ExecutedQuantity: 0.4, AveragePrice: 100},
+	}}
+	account.position = ExchangePositionState{Symbol: config.Symbol, Quantity: 0.4, EntryPrice: 100}
+
+	report, err := Reconcile(executor, account, config.Symbol, 100)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	position := executor.GetCurrentPosition()
+	if report.Fills != 1 || position == nil || position.Quantity != 0.4 || position.EntryPrice != 100 {
+		t.Fatalf("Expected a 0.4 @ 100 long from the partial fill, got %+v (report %+v)", position, report)
+	}
+	if orders := executor.GetOpenOrders(); len(orders) != 1 || orders[0].Status != "PARTIALLY_FILLED" {
+		t.Errorf("Expected the order to stay open as PARTIALLY_FILLED, got %+v", orders)
+	}
+
+	// The rest fills at 105 (average 103): entry averages in and the order closes
+	account.orders["42"] = &ExchangeOrderState{OrderID: "42", Status: "FILLED", Quantity: 1, ExecutedQuantity: 1, AveragePrice: 103}
+	account.position = ExchangePositionState{Symbol: config.Symbol, Quantity: 1, EntryPrice: 103}
+	if report, err = Reconcile(executor, account, config.Symbol, 104); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	position = executor.GetCurrentPosition()
+	if math.Abs(position.Quantity-1) > 1e-9 || math.Abs(position.EntryPrice-103) > 1e-9 || len(report.Repairs) != 0 {
+		t.Errorf("Expected 1 @ 103 with nothing to repair, got %.4f @ %.4f (repairs %v)", position.Quantity, position.EntryPrice, report.Repairs)
+	}
+	if len(executor.GetOpenOrders()) != 0 {
+		t.Error("Expected the filled order to leave the open orders")
+	}
+
+	// A missed WebSocket update: the exchange shows a smaller position
+	account.position = ExchangePositionState{Symbol: config.Symbol, Quantity: 0.7, EntryPrice: 103}
+	if report, _ = Reconcile(executor, account, config.Symbol, 104); len(report.Repairs) != 1 || executor.GetCurrentPosition().Quantity != 0.7 {
+		t.Errorf("Expected the quantity repaired to 0.7, got %.4f (repairs %v)", executor.GetCurrentPosition().Quantity, report.Repairs)
+	}
+
+	// Closed on the exchange: the local position is closed at the current price
+	account.position = ExchangePositionState{Symbol: config.Symbol}
+	if _, err := Reconcile(executor, account, config.Symbol, 106); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	trades := executor.GetTradeHistory(1)
+	if executor.GetCurrentPosition() != nil || len(trades) != 1 || trades[0].ExitReason != "RECONCILED" {
+		t.Fatalf("Expected the position closed as RECONCILED, got %+v", trades)
+	}
+	if math.Abs(trades[0].PnL-0.7*3) > 1e-9 {
+		t.Errorf("Expected PnL %.2f, got %.4f", 0.7*3, trades[0].PnL)
+	}
+
+	// A position opened outside the bot is adopted
+	account.position = ExchangePositionState{Symbol: config.Symbol, Quantity: -2, EntryPrice: 110}
+	Reconcile(executor, account, config.Symbol, 108)
+	if position := executor.GetCurrentPosition(); position == nil || position.Side != "SHORT" || position.Quantity != 2 || position.PnL != 4 {
+		t.Errorf("Expected an adopted 2 unit short with $4 PnL, got %+v", position)
+	}
+
+	inverse := ContractConfig{Type: ContractInverse, ContractSize: 100}
+	if entry := inverse.AverageEntry(100, 40000, 100, 60000); math.Abs(entry-48000) > 1e-9 {
+		t.Errorf("Expected a harmonic inverse average entry of 48000, got %.4f", entry)
+	}
+}
@@ -0,0 +1,77 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Market regimes used to switch indicator weight/threshold profiles
+const (
+	RegimeTrending = "TRENDING"
+	RegimeRanging  = "RANGING"
+	RegimeVolatile = "VOLATILE"
+)
+
+// RegimeReading is the regime detector's classification of recent candles
+type RegimeReading struct {
+	Regime            string  `json:"regime"`
+	EfficiencyRatio   float64 `json:"efficiency_ratio"`   // |net move| / sum of |moves| (0 = choppy, 1 = straight line)
+	VolatilityPercent float64 `json:"volatility_percent"` // Average true range as % of price
+}
+
+// RegimeStatus reports the active regime profile
+type RegimeStatus struct {
+	RegimeReading
+	Profile    RegimeProfile `json:"profile"`
+	ActiveFrom time.Time     `json:"active_from"` // When the engine last switched profiles
+}
+
+// DetectRegime classifies the last Lookback candles: VOLATILE when the average
+// true range exceeds the volatility threshold, TRENDING when price moves
+// efficiently in one direction, RANGING otherwise
+func DetectRegime(candles []Candle, config RegimeSwitchingConfig) (RegimeReading, bool) {
+	if len(candles) < 2 {
+		return RegimeReading{}, false
+	}
+	if config.Lookback > 1 && len(candles) > config.Lookback {
+		candles = candles[len(candles)-config.Lookback:]
+	}
+
+	var path, trueRange float64
+	for i := 1; i < len(candles); i++ {
+		prevClose := candles[i-1].Close
+		path += math.Abs(candles[i].Close - prevClose)
+		trueRange += math.Max(candles[i].High-candles[i].Low,
+			math.Max(math.Abs(candles[i].High-prevClose), math.Abs(candles[i].Low-prevClose)))
+	}
+
+	reading := RegimeReading{Regime: RegimeRanging}
+	if path > 0 {
+		reading.EfficiencyRatio = math.Abs(candles[len(candles)-1].Close-candles[0].Close) / path
+	}
+	if last := candles[len(candles)-1].Close; last > 0 {
+		reading.VolatilityPercent = trueRange / float64(len(candles)-1) / last * 100
+	}
+
+	switch {
+	case reading.VolatilityPercent > config.VolatilityThreshold:
+		reading.Regime = RegimeVolatile
+	case reading.EfficiencyRatio >= config.TrendThreshold:
+		reading.Regime = RegimeTrending
+	}
+	return reading, true
+}
+
+// weightFor returns the profile weight for an indicator (1 when not listed).
+// Keys match indicator names by substring, e.g. "RSI" matches "RSI_5m";
+// the longest matching key wins.
+func (p RegimeProfile) weightFor(indicatorName string) float64 {
+	weight, matched := 1.0, ""
+	for key, value := range p.Weights {
+		if strings.Contains(indicatorName, key) && len(key) > len(matched) {
+			weight, matched = value, key
+		}
+	}
+	return weight
+}
@@ -0,0 +1,94 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+// regimeCandles builds candles from a close path with a fixed high/low range
+func regimeCandles(closes []float64, rangePercent float64) []Candle {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]Candle, len(closes))
+	for i, c := range closes {
+		candles[i] = Candle{
+			Timestamp: start.Add(time.Duration(i) * 5 * time.Minute),
+			Open:      c,
+			High:      c * (1 + rangePercent/200),
+			Low:       c * (1 - rangePercent/200),
+			Close:     c,
+			Volume:    1000,
+		}
+	}
+	return candles
+}
+
+func TestRegimeSwitching(t *testing.T) {
+	t.Log("🔀 Testing regime-conditional indicator profiles")
+
+	config := DefaultConfig()
+	config.RegimeSwitching.Enabled = true
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Default regime profiles should validate: %v", err)
+	}
+
+	trending := make([]float64, 60)
+	ranging := make([]float64, 60)
+	for i := range trending {
+		trending[i] = 100 + float64(i)*0.1
+		ranging[i] = 100 + float64(i%2)*0.1
+	}
+
+	cases := []struct {
+		name    string
+		candles []Candle
+		want    string
+	}{
+		{"steady climb", regimeCandles(trending, 0.1), RegimeTrending},
+		{"chop", regimeCandles(ranging, 0.1), RegimeRanging},
+		{"wide ranges", regimeCandles(trending, 2.0), RegimeVolatile},
+	}
+	for _, tc := range cases {
+		reading, ok := DetectRegime(tc.candles, config.RegimeSwitching)
+		if !ok || reading.Regime != tc.want {
+			t.Errorf("%s: expected %s, got %s (efficiency %.2f, volatility %.2f%%)",
+				tc.name, tc.want, reading.Regime, reading.EfficiencyRatio, reading.VolatilityPercent)
+		}
+	}
+
+	// Profile weights can flip a 2-vs-1 vote
+	signals := []IndicatorSignal{
+		{Name: "RSI_5m", Signal: Sell, Strength: 0.5},
+		{Name: "Stochastic", Signal: Sell, Strength: 0.5},
+		{Name: "Trend_5m", Signal: Buy, Strength: 0.5},
+	}
+	aggregator := NewSignalAggregator(config)
+	if result := aggregator.applyFocused5MinuteLogic(signals, 100, RegimeProfile{}, 0); result.Signal != Sell {
+		t.Errorf("Equal weights should follow the 2-1 majority, got %s", result.Signal)
+	}
+	profile := RegimeProfile{Weights: map[string]float64{"Trend": 3, "RSI": 0.5}}
+	if result := aggregator.applyFocused5MinuteLogic(signals, 100, profile, 0); result.Signal != Buy {
+		t.Errorf("Trend-weighted profile should produce BUY, got %s", result.Signal)
+	}
+	profile.MinConfidence = 0.99
+	if result := aggregator.applyFocused5MinuteLogic(signals, 100, profile, 0); result.Signal != Hold {
+		t.Errorf("Profile min confidence should force HOLD, got %s", result.Signal)
+	}
+
+	// The engine tracks the active profile for /status
+	if aggregator.GetRegimeStatus() != nil {
+		t.Fatalf("No regime should be active before the first signal")
+	}
+	profile, regime := aggregator.selectRegimeProfile(regimeCandles(trending, 0.1))
+	status := aggregator.GetRegimeStatus()
+	if regime != RegimeTrending || status == nil || status.Regime != RegimeTrending {
+		t.Fatalf("Expected TRENDING profile to be active, got %q / %+v", regime, status)
+	}
+	if profile.weightFor("EMA") != config.RegimeSwitching.Profiles[RegimeTrending].Weights["EMA"] {
+		t.Errorf("Selected profile does not match the TRENDING config")
+	}
+
+	config.RegimeSwitching.Profiles["SIDEWAYS"] = RegimeProfile{}
+	if err := ValidateConfig(config); err == nil {
+		t.Errorf("Expected unknown regime profile to be rejected")
+	}
+}
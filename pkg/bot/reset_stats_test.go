@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResetStats(t *testing.T) {
+	t.Log("🧹 Testing performance stats and daily loss reset")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000.0)
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Timestamp: time.Now()}
+
+	// A large loss exhausts the 5% daily budget and blocks new entries
+	if err := executor.ExecuteSignal(signal, 100.0, 99.0); err != nil {
+		t.Fatalf("Entry failed: %v", err)
+	}
+	if err := executor.ForceClosePosition(50.0); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+	if err := executor.ExecuteSignal(signal, 100.0, 99.0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if executor.GetCurrentPosition() != nil {
+		t.Fatalf("Expected entries to be blocked by the daily loss limit")
+	}
+
+	stats := executor.ResetStats()
+	if stats.TotalTrades != 0 || stats.TotalPnL != 0 {
+		t.Errorf("Expected cleared stats, got %d trades / %.2f PnL", stats.TotalTrades, stats.TotalPnL)
+	}
+	status := executor.GetStatus()
+	if status.RiskManagement.DailyLossUsed != 0 || status.Strategies[ATRStrategyName].DailyLossUsed != 0 {
+		t.Errorf("Expected daily loss counters reset, got %.4f / %.4f",
+			status.RiskManagement.DailyLossUsed, status.Strategies[ATRStrategyName].DailyLossUsed)
+	}
+	if len(executor.GetTradeHistory(0)) != 1 {
+		t.Errorf("Expected trade history to be kept")
+	}
+
+	// Trading resumes after the reset
+	if err := executor.ExecuteSignal(signal, 100.0, 99.0); err != nil {
+		t.Fatalf("Entry after reset failed: %v", err)
+	}
+	if executor.GetCurrentPosition() == nil {
+		t.Errorf("Expected entry to be allowed after reset")
+	}
+}
@@ -0,0 +1,161 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultScenarioShocks are the price moves evaluated when a scenario request lists none
+var DefaultScenarioShocks = []float64{-0.05, -0.10, -0.20, 0.05, 0.10}
+
+// maxScenarioShocks bounds how many shocks one request evaluates
+const maxScenarioShocks = 20
+
+// ScenarioLine is one position, holding or hedge under a price shock
+type ScenarioLine struct {
+	Symbol        string  `json:"symbol"`
+	Kind          string  `json:"kind"` // "position" (signal strategy), "holding" (spot) or "hedge"
+	Side          string  `json:"side"` // LONG or SHORT
+	Quantity      float64 `json:"quantity"`
+	Price         float64 `json:"price"`
+	ShockedPrice  float64 `json:"shocked_price"`
+	PnL           float64 `json:"pnl"`                      // Quote-currency PnL of the move
+	StopLoss      float64 `json:"stop_loss,omitempty"`      // Signal position's stop
+	StopTriggered bool    `json:"stop_triggered,omitempty"` // The move gaps through the stop, filling at the shocked price
+}
+
+// ScenarioResult is the account under one instantaneous price shock
+type ScenarioResult struct {
+	Shock          float64        `json:"shock"` // Benchmark move, e.g. -0.1 for -10%
+	PnL            float64        `json:"pnl"`   // Quote-currency PnL across every line
+	PnLPercent     float64        `json:"pnl_percent"`
+	EquityAfter    float64        `json:"equity_after"`
+	MarginRatio    float64        `json:"margin_ratio,omitempty"` // Signal position's maintenance margin / margin balance after the move
+	Liquidated     bool           `json:"liquidated,omitempty"`   // Margin ratio reached 1
+	StopsTriggered []string       `json:"stops_triggered,omitempty"`
+	Lines          []ScenarioLine `json:"lines"`
+}
+
+// ScenarioReport is a what-if of instantaneous price shocks on the open book
+type ScenarioReport struct {
+	Benchmark  string           `json:"benchmark"`
+	BetaScaled bool             `json:"beta_scaled"` // Each symbol moved by shock x its beta to the benchmark
+	Equity     float64          `json:"equity"`      // Quote-currency equity before the shocks
+	Results    []ScenarioResult `json:"results"`
+}
+
+// ValidateScenarioShocks checks shocks are price moves above -100%
+func ValidateScenarioShocks(shocks []float64) error {
+	if len(shocks) > maxScenarioShocks {
+		return fmt.Errorf("at most %d shocks per scenario, got %d", maxScenarioShocks, len(shocks))
+	}
+	for _, shock := range shocks {
+		if shock <= -1 || shock > 10 {
+			return fmt.Errorf("shock %v is out of range: use a fraction above -1, e.g. -0.1 for -10%%", shock)
+		}
+	}
+	return nil
+}
+
+// priceFor returns the symbol's price from prices, falling back to its latest
+// sampled price and then to fallback (assumes lock is held)
+func (te *TradeExecutor) priceFor(prices map[string]float64, symbol string, fallback float64) float64 {
+	if price, ok := prices[symbol]; ok {
+		return price
+	}
+	if price, ok := te.correlations.Price(symbol); ok {
+		return price
+	}
+	return fallback
+}
+
+// RunScenarios applies each shock instantaneously to every open line and reports
+// the resulting PnL, stop triggers and signal position margin. With betaScaled,
+// each symbol moves by the shock times its rolling beta to the benchmark.
+func (te *TradeExecutor) RunScenarios(prices map[string]float64, shocks []float64, benchmark string, betaScaled bool) ScenarioReport {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	type line struct {
+		ScenarioLine
+		beta float64
+	}
+	var lines []line
+	add := func(l ScenarioLine) {
+		beta := 1.0
+		if estimated, ok := te.correlations.Beta(l.Symbol, benchmark); betaScaled && ok {
+			beta = estimated
+		}
+		lines = append(lines, line{ScenarioLine: l, beta: beta})
+	}
+	for _, symbol := range sortedKeys(te.holdings) {
+		add(ScenarioLine{Symbol: symbol, Kind: "holding", Side: "LONG", Quantity: te.holdings[symbol], Price: te.priceFor(prices, symbol, 0)})
+	}
+	for _, symbol := range sortedKeys(te.hedges) {
+		hedge := te.hedges[symbol]
+		add(ScenarioLine{Symbol: symbol, Kind: "hedge", Side: "SHORT", Quantity: hedge.Quantity, Price: te.priceFor(prices, symbol, hedge.EntryPrice)})
+	}
+	position := te.currentPosition
+	if position != nil {
+		add(ScenarioLine{
+			Symbol: position.Symbol, Kind: "position", Side: position.Side, Quantity: position.Quantity,
+			Price: te.priceFor(prices, position.Symbol, position.CurrentPrice), StopLoss: position.StopLoss,
+		})
+	}
+
+	// Equity in the quote currency: cash, holdings and the position's margin and unrealized PnL
+	equity := te.balances[te.quoteCurrency]
+	for _, l := range lines {
+		switch {
+		case l.Kind == "holding":
+			equity += l.Quantity * l.Price
+		case l.Kind == "position" && te.marginCurrency != te.quoteCurrency:
+			equity += (te.balances[te.marginCurrency] + te.config.Contract.PnL(position.Side, position.EntryPrice, l.Price, position.Quantity)) * l.Price
+		case l.Kind == "position":
+			equity += te.config.Contract.PnL(position.Side, position.EntryPrice, l.Price, position.Quantity)
+		}
+	}
+
+	report := ScenarioReport{Benchmark: benchmark, BetaScaled: betaScaled, Equity: equity, Results: make([]ScenarioResult, 0, len(shocks))}
+	for _, shock := range shocks {
+		result := ScenarioResult{Shock: shock, Lines: make([]ScenarioLine, 0, len(lines))}
+		for _, l := range lines {
+			shocked := l.ScenarioLine
+			shocked.ShockedPrice = l.Price * (1 + shock*l.beta)
+			if shocked.ShockedPrice < 0 {
+				shocked.ShockedPrice = 0
+			}
+			switch l.Kind {
+			case "holding":
+				shocked.PnL = l.Quantity * (shocked.ShockedPrice - l.Price)
+			case "hedge":
+				shocked.PnL = l.Quantity * (l.Price - shocked.ShockedPrice)
+			case "position":
+				shocked.PnL = te.config.Contract.PnL(l.Side, l.Price, shocked.ShockedPrice, l.Quantity)
+				if te.marginCurrency != te.quoteCurrency {
+					shocked.PnL *= shocked.ShockedPrice
+				}
+				shocked.StopTriggered = l.StopLoss > 0 &&
+					((l.Side == "LONG" && shocked.ShockedPrice <= l.StopLoss) || (l.Side == "SHORT" && shocked.ShockedPrice >= l.StopLoss))
+				if shocked.StopTriggered {
+					result.StopsTriggered = append(result.StopsTriggered, l.Symbol)
+				}
+				if margin := te.config.Margin; margin.Enabled {
+					marginBalance := te.balances[te.marginCurrency] + te.config.Contract.PnL(position.Side, position.EntryPrice, shocked.ShockedPrice, position.Quantity)
+					maintenance := te.config.Contract.MaintenanceMargin(position.Quantity, shocked.ShockedPrice, margin.MaintenanceMarginRate)
+					result.MarginRatio = marginRatio(maintenance, marginBalance)
+					result.Liquidated = result.MarginRatio >= 1
+				}
+			}
+			result.PnL += shocked.PnL
+			result.Lines = append(result.Lines, shocked)
+		}
+		result.EquityAfter = equity + result.PnL
+		if equity > 0 {
+			result.PnLPercent = result.PnL / equity * 100
+		}
+		sort.Strings(result.StopsTriggered)
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
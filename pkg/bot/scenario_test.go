@@ -0,0 +1,70 @@
+package bot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRiskScenarios(t *testing.T) {
+	t.Log("🌪️ Testing price shock scenarios on the signal position, holdings and margin")
+
+	config := DefaultConfig()
+	config.Margin.Enabled = true
+	executor := NewTradeExecutor(config, 10000)
+
+	// A 400 unit long at 100 with its stop at 99.5, plus 10 ETH bought for 1000
+	if err := executor.ExecuteSignal(&TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}, 100, 99.5); err != nil {
+		t.Fatalf("Failed to open long position: %v", err)
+	}
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: "TEST", Symbol: "ETHUSDT", Side: "BUY", Quantity: 10, Price: 100}); err != nil {
+		t.Fatalf("Failed to buy ETH: %v", err)
+	}
+
+	prices := map[string]float64{config.Symbol: 100, "ETHUSDT": 100}
+	report := executor.RunScenarios(prices, []float64{-0.05, -0.25, 0.05}, "BTCUSDT", false)
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+	if !near(report.Equity, 10000) || len(report.Results) != 3 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+
+	down := report.Results[0]
+	wantRatio := config.Margin.MaintenanceMarginRate * 400 * 95 / 7000
+	if !near(down.PnL, -2050) || !near(down.PnLPercent, -20.5) || !near(down.EquityAfter, 7950) {
+		t.Errorf("Expected -2050 at -5%%, got %+v", down)
+	}
+	if strings.Join(down.StopsTriggered, ",") != config.Symbol || !near(down.MarginRatio, wantRatio) || down.Liquidated {
+		t.Errorf("Expected the stop to trigger at margin ratio %.4f, got %+v", wantRatio, down)
+	}
+	if len(down.Lines) != 2 || down.Lines[0].Kind != "holding" || !near(down.Lines[0].ShockedPrice, 95) || !near(down.Lines[1].PnL, -2000) {
+		t.Errorf("Unexpected lines %+v", down.Lines)
+	}
+	if crash := report.Results[1]; !crash.Liquidated || crash.MarginRatio != 1 {
+		t.Errorf("Expected a 25%% crash to liquidate the 4x long, got %+v", crash)
+	}
+	if up := report.Results[2]; !near(up.PnL, 2050) || len(up.StopsTriggered) != 0 {
+		t.Errorf("Expected +2050 without stops at +5%%, got %+v", up)
+	}
+
+	// Beta scaling moves ETH twice as far as BTC
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	btc, eth := 100.0, 100.0
+	for i := 0; i < 20; i++ {
+		move := 0.01 * math.Sin(float64(i))
+		btc, eth = btc*(1+move), eth*(1+2*move)
+		executor.RecordPrice("BTCUSDT", start.Add(time.Duration(i)*5*time.Minute), btc)
+		executor.RecordPrice("ETHUSDT", start.Add(time.Duration(i)*5*time.Minute), eth)
+	}
+	scaled := executor.RunScenarios(prices, []float64{-0.05}, "BTCUSDT", true).Results[0]
+	if !near(scaled.Lines[0].ShockedPrice, 90) || !near(scaled.PnL, -2100) {
+		t.Errorf("Expected ETH to fall 10%% on a beta-scaled -5%% shock, got %+v", scaled)
+	}
+
+	if err := ValidateScenarioShocks([]float64{-0.1, -1}); err == nil {
+		t.Error("Expected a -100% shock to be rejected")
+	}
+	if err := ValidateScenarioShocks(make([]float64, maxScenarioShocks+1)); err == nil {
+		t.Error("Expected too many shocks to be rejected")
+	}
+}
@@ -0,0 +1,602 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Script limits; scripts have no loops, calls or I/O, so these bound both
+// parse time and evaluation cost
+const (
+	maxScriptBytes = 64 << 10 // Largest accepted script file
+	maxScriptNodes = 4096     // Expression nodes across all statements
+	maxScriptDepth = 64       // Nesting depth of a single expression
+)
+
+// scriptOperators are the operator tokens the tokenizer accepts
+var scriptOperators = map[string]bool{
+	"==": true, "!=": true, "<=": true, ">=": true, "<": true, ">": true, "=": true,
+	"+": true, "-": true, "*": true, "/": true, "(": true, ")": true,
+}
+
+// scriptFields are the per-indicator values a script can read, e.g. ema.bullish
+var scriptFields = map[string]bool{"bullish": true, "bearish": true, "neutral": true, "strength": true, "value": true}
+
+// scriptGlobals are the built-in variables a script can read
+var scriptGlobals = map[string]bool{
+	"signal":     true, // Aggregated signal: "BUY", "SELL" or "HOLD"
+	"confidence": true, // Aggregated signal confidence (0-1)
+	"regime":     true, // Regime profile from regime switching: "TRENDING", "RANGING", "VOLATILE" or ""
+	"trend":      true, // Daily trend: "BULLISH", "BEARISH", "SIDEWAYS" or "UNKNOWN"
+	"price":      true, // Latest price of the traded symbol
+	"position":   true, // Quantity held by the scripted strategy
+	"cash":       true, // Cash available to the scripted strategy
+}
+
+// Script is a compiled rule script: ordered assignments, where "enter" and
+// "exit" decide entries and exits and any other name defines a helper variable
+type Script struct {
+	statements []scriptStatement
+	nodes      int
+}
+
+// ScriptDecision is the outcome of running a script against one snapshot
+type ScriptDecision struct {
+	Enter bool
+	Exit  bool
+	Steps int // Evaluation steps used
+}
+
+type scriptStatement struct {
+	line int
+	name string
+	expr scriptNode
+}
+
+// scriptNode is a parsed expression
+type scriptNode struct {
+	op       string // "lit", "var", "not", "neg", or a binary operator
+	value    interface{}
+	name     string
+	children []scriptNode
+}
+
+type scriptToken struct {
+	kind  string // "ident", "number", "string", "op", "newline", "eof"
+	text  string
+	line  int
+	value interface{}
+}
+
+// CompileScript parses a rule script, checking names and limits up front so a
+// bad edit is rejected before it replaces a working script
+func CompileScript(source string) (*Script, error) {
+	if len(source) > maxScriptBytes {
+		return nil, fmt.Errorf("script is %d bytes, limit is %d", len(source), maxScriptBytes)
+	}
+	tokens, err := tokenizeScript(source)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &scriptParser{tokens: tokens, defined: make(map[string]bool)}
+	script := &Script{}
+	for {
+		parser.skipNewlines()
+		if parser.peek().kind == "eof" {
+			break
+		}
+		statement, err := parser.statement()
+		if err != nil {
+			return nil, err
+		}
+		script.statements = append(script.statements, statement)
+	}
+	script.nodes = parser.nodes
+
+	if !parser.defined["enter"] && !parser.defined["exit"] {
+		return nil, fmt.Errorf("script must define an enter or exit rule")
+	}
+	return script, nil
+}
+
+// Run evaluates every statement in order against env, failing once more than
+// maxSteps expression nodes have been evaluated
+func (s *Script) Run(env map[string]interface{}, maxSteps int) (ScriptDecision, error) {
+	evaluator := &scriptEvaluator{env: env, vars: make(map[string]interface{}), budget: maxSteps}
+	for _, statement := range s.statements {
+		value, err := evaluator.eval(statement.expr)
+		if err != nil {
+			return ScriptDecision{Steps: evaluator.steps}, fmt.Errorf("line %d: %w", statement.line, err)
+		}
+		evaluator.vars[statement.name] = value
+	}
+
+	decision := ScriptDecision{Steps: evaluator.steps}
+	for name, target := range map[string]*bool{"enter": &decision.Enter, "exit": &decision.Exit} {
+		value, ok := evaluator.vars[name]
+		if !ok {
+			continue
+		}
+		result, isBool := value.(bool)
+		if !isBool {
+			return decision, fmt.Errorf("%s rule must be true or false, got %v", name, value)
+		}
+		*target = result
+	}
+	return decision, nil
+}
+
+// tokenizeScript splits source into tokens; newlines end statements except inside parentheses
+func tokenizeScript(source string) ([]scriptToken, error) {
+	tokens := make([]scriptToken, 0)
+	line, depth := 1, 0
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			if depth == 0 {
+				tokens = append(tokens, scriptToken{kind: "newline", line: line})
+			}
+			line++
+			i++
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, scriptToken{kind: "ident", text: string(runes[start:i]), line: line})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			value, err := strconv.ParseFloat(string(runes[start:i]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid number %q", line, string(runes[start:i]))
+			}
+			tokens = append(tokens, scriptToken{kind: "number", text: string(runes[start:i]), line: line, value: value})
+		case r == '"':
+			start := i + 1
+			i++
+			for i < len(runes) && runes[i] != '"' && runes[i] != '\n' {
+				i++
+			}
+			if i >= len(runes) || runes[i] != '"' {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			text := string(runes[start:i])
+			tokens = append(tokens, scriptToken{kind: "string", text: text, line: line, value: text})
+			i++
+		default:
+			op := string(r)
+			if i+1 < len(runes) {
+				if two := string(runes[i : i+2]); two == "==" || two == "!=" || two == "<=" || two == ">=" {
+					op = two
+				}
+			}
+			if !scriptOperators[op] {
+				return nil, fmt.Errorf("line %d: unexpected character %q", line, r)
+			}
+			switch op {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			}
+			tokens = append(tokens, scriptToken{kind: "op", text: op, line: line})
+			i += len(op)
+		}
+	}
+	return append(tokens, scriptToken{kind: "eof", line: line}), nil
+}
+
+// scriptParser is a recursive-descent parser over script tokens
+type scriptParser struct {
+	tokens  []scriptToken
+	pos     int
+	depth   int
+	nodes   int
+	defined map[string]bool
+}
+
+func (p *scriptParser) peek() scriptToken {
+	return p.tokens[p.pos]
+}
+
+func (p *scriptParser) next() scriptToken {
+	token := p.tokens[p.pos]
+	if token.kind != "eof" {
+		p.pos++
+	}
+	return token
+}
+
+func (p *scriptParser) skipNewlines() {
+	for p.peek().kind == "newline" {
+		p.pos++
+	}
+}
+
+// statement parses "name = expr" followed by a newline or end of script
+func (p *scriptParser) statement() (scriptStatement, error) {
+	name := p.next()
+	if name.kind != "ident" || strings.Contains(name.text, ".") || isScriptKeyword(name.text) {
+		return scriptStatement{}, fmt.Errorf("line %d: expected a rule name, got %q", name.line, name.text)
+	}
+	if scriptGlobals[name.text] {
+		return scriptStatement{}, fmt.Errorf("line %d: %s is a built-in variable", name.line, name.text)
+	}
+	if p.defined[name.text] {
+		return scriptStatement{}, fmt.Errorf("line %d: %s is already defined", name.line, name.text)
+	}
+	if eq := p.next(); eq.text != "=" {
+		return scriptStatement{}, fmt.Errorf("line %d: expected = after %s", name.line, name.text)
+	}
+
+	expr, err := p.or()
+	if err != nil {
+		return scriptStatement{}, err
+	}
+	if end := p.next(); end.kind != "newline" && end.kind != "eof" {
+		return scriptStatement{}, fmt.Errorf("line %d: unexpected %q", end.line, end.text)
+	}
+	p.defined[name.text] = true
+	return scriptStatement{line: name.line, name: name.text, expr: expr}, nil
+}
+
+// node counts a new expression node against the script limits
+func (p *scriptParser) node(n scriptNode) (scriptNode, error) {
+	p.nodes++
+	if p.nodes > maxScriptNodes {
+		return scriptNode{}, fmt.Errorf("script exceeds %d expression nodes", maxScriptNodes)
+	}
+	return n, nil
+}
+
+// binary parses a left-associative chain of operators over operand
+func (p *scriptParser) binary(ops []string, operand func() (scriptNode, error)) (scriptNode, error) {
+	left, err := operand()
+	if err != nil {
+		return scriptNode{}, err
+	}
+	for {
+		token := p.peek()
+		matched := false
+		for _, op := range ops {
+			if (token.kind == "op" || token.kind == "ident") && token.text == op {
+				matched = true
+			}
+		}
+		if !matched {
+			return left, nil
+		}
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return scriptNode{}, err
+		}
+		if left, err = p.node(scriptNode{op: token.text, children: []scriptNode{left, right}}); err != nil {
+			return scriptNode{}, err
+		}
+	}
+}
+
+// descend counts one more level of parser recursion against maxScriptDepth;
+// the caller restores p.depth once its nested expression is parsed
+func (p *scriptParser) descend() error {
+	p.depth++
+	if p.depth > maxScriptDepth {
+		return fmt.Errorf("line %d: expression nested deeper than %d", p.peek().line, maxScriptDepth)
+	}
+	return nil
+}
+
+func (p *scriptParser) or() (scriptNode, error) {
+	defer func() { p.depth-- }()
+	if err := p.descend(); err != nil {
+		return scriptNode{}, err
+	}
+	return p.binary([]string{"or"}, p.and)
+}
+
+func (p *scriptParser) and() (scriptNode, error) {
+	return p.binary([]string{"and"}, p.not)
+}
+
+func (p *scriptParser) not() (scriptNode, error) {
+	if token := p.peek(); token.kind == "ident" && token.text == "not" {
+		p.next()
+		defer func() { p.depth-- }()
+		if err := p.descend(); err != nil {
+			return scriptNode{}, err
+		}
+		operand, err := p.not()
+		if err != nil {
+			return scriptNode{}, err
+		}
+		return p.node(scriptNode{op: "not", children: []scriptNode{operand}})
+	}
+	return p.comparison()
+}
+
+func (p *scriptParser) comparison() (scriptNode, error) {
+	left, err := p.sum()
+	if err != nil {
+		return scriptNode{}, err
+	}
+	token := p.peek()
+	switch token.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		if token.kind != "op" {
+			return left, nil
+		}
+		p.next()
+		right, err := p.sum()
+		if err != nil {
+			return scriptNode{}, err
+		}
+		return p.node(scriptNode{op: token.text, children: []scriptNode{left, right}})
+	}
+	return left, nil
+}
+
+func (p *scriptParser) sum() (scriptNode, error) {
+	return p.binary([]string{"+", "-"}, p.product)
+}
+
+func (p *scriptParser) product() (scriptNode, error) {
+	return p.binary([]string{"*", "/"}, p.unary)
+}
+
+func (p *scriptParser) unary() (scriptNode, error) {
+	if token := p.peek(); token.kind == "op" && token.text == "-" {
+		p.next()
+		defer func() { p.depth-- }()
+		if err := p.descend(); err != nil {
+			return scriptNode{}, err
+		}
+		operand, err := p.unary()
+		if err != nil {
+			return scriptNode{}, err
+		}
+		return p.node(scriptNode{op: "neg", children: []scriptNode{operand}})
+	}
+	return p.primary()
+}
+
+func (p *scriptParser) primary() (scriptNode, error) {
+	token := p.next()
+	switch token.kind {
+	case "number", "string":
+		return p.node(scriptNode{op: "lit", value: token.value})
+	case "ident":
+		switch token.text {
+		case "true", "false":
+			return p.node(scriptNode{op: "lit", value: token.text == "true"})
+		}
+		if isScriptKeyword(token.text) {
+			return scriptNode{}, fmt.Errorf("line %d: unexpected %q", token.line, token.text)
+		}
+		name, err := p.checkName(token)
+		if err != nil {
+			return scriptNode{}, err
+		}
+		return p.node(scriptNode{op: "var", name: name})
+	case "op":
+		if token.text == "(" {
+			expr, err := p.or()
+			if err != nil {
+				return scriptNode{}, err
+			}
+			if closing := p.next(); closing.text != ")" {
+				return scriptNode{}, fmt.Errorf("line %d: expected )", closing.line)
+			}
+			return expr, nil
+		}
+	}
+	if token.kind == "newline" || token.kind == "eof" {
+		return scriptNode{}, fmt.Errorf("line %d: expression is incomplete", token.line)
+	}
+	return scriptNode{}, fmt.Errorf("line %d: unexpected %q", token.line, token.text)
+}
+
+// checkName rejects unknown variables and indicator fields at compile time and
+// returns the name to look up, with indicator aliases resolved (sr.bullish -> support_resistance.bullish)
+func (p *scriptParser) checkName(token scriptToken) (string, error) {
+	if scriptGlobals[token.text] || p.defined[token.text] {
+		return token.text, nil
+	}
+	indicator, field, dotted := strings.Cut(token.text, ".")
+	if !dotted {
+		return "", fmt.Errorf("line %d: unknown variable %s", token.line, token.text)
+	}
+	if !scriptFields[field] {
+		return "", fmt.Errorf("line %d: unknown field %s (use bullish, bearish, neutral, strength or value)", token.line, field)
+	}
+	name, timeframe := splitScriptTimeframe(indicator)
+	info, ok := LookupIndicator(name)
+	if !ok {
+		return "", fmt.Errorf("line %d: unknown indicator %s", token.line, indicator)
+	}
+	if timeframe != "" {
+		return info.Name + "_" + timeframe + "." + field, nil
+	}
+	return info.Name + "." + field, nil
+}
+
+// splitScriptTimeframe splits "rsi_5m" into "rsi" and "5m"
+func splitScriptTimeframe(name string) (string, string) {
+	if i := strings.LastIndex(name, "_"); i > 0 {
+		if timeframe := name[i+1:]; isScriptTimeframe(timeframe) {
+			return name[:i], timeframe
+		}
+	}
+	return name, ""
+}
+
+// isScriptTimeframe reports whether suffix names one of the engine's timeframes
+func isScriptTimeframe(suffix string) bool {
+	for _, timeframe := range []Timeframe{FiveMinute, FifteenMinute, FortyFiveMinute, EightHour, Daily} {
+		if timeframe.String() == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+func isScriptKeyword(word string) bool {
+	switch word {
+	case "and", "or", "not", "true", "false":
+		return true
+	}
+	return false
+}
+
+// scriptEvaluator walks compiled expressions under a step budget
+type scriptEvaluator struct {
+	env    map[string]interface{}
+	vars   map[string]interface{}
+	steps  int
+	budget int
+}
+
+func (e *scriptEvaluator) eval(n scriptNode) (interface{}, error) {
+	e.steps++
+	if e.steps > e.budget {
+		return nil, fmt.Errorf("step budget of %d exceeded", e.budget)
+	}
+
+	switch n.op {
+	case "lit":
+		return n.value, nil
+	case "var":
+		if value, ok := e.vars[n.name]; ok {
+			return value, nil
+		}
+		if value, ok := e.env[n.name]; ok {
+			return value, nil
+		}
+		return scriptMissingValue(n.name), nil
+	case "not":
+		value, err := e.evalBool(n.children[0])
+		return !value, err
+	case "neg":
+		value, err := e.evalNumber(n.children[0])
+		return -value, err
+	case "and", "or":
+		left, err := e.evalBool(n.children[0])
+		if err != nil || (n.op == "and" && !left) || (n.op == "or" && left) {
+			return left, err
+		}
+		return e.evalBool(n.children[1])
+	case "==", "!=":
+		left, err := e.eval(n.children[0])
+		if err != nil {
+			return nil, err
+		}
+		right, err := e.eval(n.children[1])
+		if err != nil {
+			return nil, err
+		}
+		equal, err := scriptEqual(left, right)
+		return equal == (n.op == "=="), err
+	}
+
+	left, err := e.evalNumber(n.children[0])
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.evalNumber(n.children[1])
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "<":
+		return left < right, nil
+	case "<=":
+		return left <= right, nil
+	case ">":
+		return left > right, nil
+	case ">=":
+		return left >= right, nil
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/":
+		if right == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+func (e *scriptEvaluator) evalBool(n scriptNode) (bool, error) {
+	value, err := e.eval(n)
+	if err != nil {
+		return false, err
+	}
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected true or false, got %v", value)
+	}
+	return result, nil
+}
+
+func (e *scriptEvaluator) evalNumber(n scriptNode) (float64, error) {
+	value, err := e.eval(n)
+	if err != nil {
+		return 0, err
+	}
+	result, ok := value.(float64)
+	if !ok {
+		return 0, fmt.Errorf("expected a number, got %v", value)
+	}
+	return result, nil
+}
+
+// scriptEqual compares values of the same type; strings compare case-insensitively
+func scriptEqual(left, right interface{}) (bool, error) {
+	switch l := left.(type) {
+	case string:
+		if r, ok := right.(string); ok {
+			return strings.EqualFold(l, r), nil
+		}
+	case float64:
+		if r, ok := right.(float64); ok {
+			return l == r, nil
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			return l == r, nil
+		}
+	}
+	return false, fmt.Errorf("cannot compare %v with %v", left, right)
+}
+
+// scriptMissingValue is what an indicator field reads when that indicator produced
+// no signal this run: not bullish or bearish, neutral, zero strength and value
+func scriptMissingValue(name string) interface{} {
+	_, field, _ := strings.Cut(name, ".")
+	switch field {
+	case "bullish", "bearish":
+		return false
+	case "neutral":
+		return true
+	}
+	return 0.0
+}
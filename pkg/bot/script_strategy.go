@@ -0,0 +1,207 @@
+package bot
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// ScriptedStrategy trades the main symbol from a user rule script. The script is
+// recompiled whenever its file changes; a script that fails to compile is
+// rejected and the previous one keeps running.
+type ScriptedStrategy struct {
+	config  ScriptingConfig
+	symbol  string
+	script  *Script
+	modTime time.Time
+	lastErr error
+	mutex   sync.Mutex
+}
+
+// NewScriptedStrategy loads and compiles the configured rule script
+func NewScriptedStrategy(config ScriptingConfig, symbol string) (*ScriptedStrategy, error) {
+	ss := &ScriptedStrategy{config: config, symbol: symbol}
+	if err := ss.reload(); err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+// Name returns the strategy name
+func (ss *ScriptedStrategy) Name() string {
+	return "SCRIPTED"
+}
+
+// Symbols returns the traded symbol
+func (ss *ScriptedStrategy) Symbols() []string {
+	return []string{ss.symbol}
+}
+
+// LastError returns the most recent reload or evaluation error (nil when healthy)
+func (ss *ScriptedStrategy) LastError() error {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	return ss.lastErr
+}
+
+// reload recompiles the script when its file has changed since the last load
+func (ss *ScriptedStrategy) reload() error {
+	info, err := os.Stat(ss.config.File)
+	if err != nil {
+		return fmt.Errorf("failed to read rule script: %w", err)
+	}
+	if ss.script != nil && info.ModTime().Equal(ss.modTime) {
+		return nil
+	}
+	if info.Size() > maxScriptBytes {
+		return fmt.Errorf("rule script is %d bytes, limit is %d", info.Size(), maxScriptBytes)
+	}
+
+	source, err := ioutil.ReadFile(ss.config.File)
+	if err != nil {
+		return fmt.Errorf("failed to read rule script: %w", err)
+	}
+	script, err := CompileScript(string(source))
+	if err != nil {
+		return fmt.Errorf("invalid rule script %s: %w", ss.config.File, err)
+	}
+
+	if ss.script != nil {
+		executorLog.Info("📜 Rule script reloaded", "file", ss.config.File)
+	}
+	ss.script = script
+	ss.modTime = info.ModTime()
+	return nil
+}
+
+// Evaluate reloads the script if needed, runs it and turns enter/exit into intents.
+// Entries spend PositionSize of the strategy's cash; exits sell the whole holding.
+func (ss *ScriptedStrategy) Evaluate(ctx StrategyContext) ([]OrderIntent, error) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	if err := ss.reload(); err != nil {
+		// Keep trading on the last good script
+		if ss.lastErr == nil || ss.lastErr.Error() != err.Error() {
+			executorLog.Warn("Keeping the previous rule script", "file", ss.config.File, "error", err)
+		}
+		ss.lastErr = err
+	} else {
+		ss.lastErr = nil
+	}
+
+	decision, err := ss.script.Run(scriptEnv(ctx, ss.symbol), ss.config.MaxSteps)
+	if err != nil {
+		ss.lastErr = err
+		return nil, fmt.Errorf("rule script failed: %w", err)
+	}
+
+	price := ctx.Prices[ss.symbol]
+	if price <= 0 {
+		return nil, fmt.Errorf("no price for %s", ss.symbol)
+	}
+
+	held := ctx.Holdings[ss.symbol]
+	switch {
+	case held > 0 && decision.Exit:
+		return []OrderIntent{{Symbol: ss.symbol, Side: "SELL", Quantity: held, Price: price, Reason: "script exit rule"}}, nil
+	case held == 0 && decision.Enter && !decision.Exit:
+		quantity := ctx.Cash * ss.config.PositionSize / price
+		if quantity <= 0 {
+			return nil, nil
+		}
+		return []OrderIntent{{Symbol: ss.symbol, Side: "BUY", Quantity: quantity, Price: price, Reason: "script enter rule"}}, nil
+	}
+	return nil, nil
+}
+
+// scriptVote accumulates one indicator's signals across timeframes
+type scriptVote struct {
+	buy, sell, strength, value float64
+}
+
+// scriptEnv exposes the strategy snapshot and the latest indicator signals to a script
+func scriptEnv(ctx StrategyContext, symbol string) map[string]interface{} {
+	env := map[string]interface{}{
+		"signal":     Hold.String(),
+		"confidence": 0.0,
+		"regime":     "",
+		"trend":      ctx.Regime,
+		"price":      ctx.Prices[symbol],
+		"position":   ctx.Holdings[symbol],
+		"cash":       ctx.Cash,
+	}
+	if ctx.LastSignal == nil {
+		return env
+	}
+	env["signal"] = ctx.LastSignal.Signal.String()
+	env["confidence"] = ctx.LastSignal.Confidence
+	env["regime"] = ctx.LastSignal.Regime
+
+	votes := make(map[string]*scriptVote)
+	for _, signal := range ctx.LastSignal.IndicatorSignals {
+		info, ok := indicatorForSignal(signal.Name)
+		if !ok {
+			continue
+		}
+		for _, key := range []string{info.Name, info.Name + "_" + signal.Timeframe.String()} {
+			vote, exists := votes[key]
+			if !exists {
+				vote = &scriptVote{}
+				votes[key] = vote
+			}
+			switch signal.Signal {
+			case Buy:
+				vote.buy += signal.Strength
+			case Sell:
+				vote.sell += signal.Strength
+			}
+			if signal.Strength > vote.strength {
+				vote.strength = signal.Strength
+			}
+			vote.value = signal.Value
+		}
+	}
+
+	// Net direction across timeframes: bullish when buy strength outweighs sell
+	for key, vote := range votes {
+		env[key+".bullish"] = vote.buy > vote.sell
+		env[key+".bearish"] = vote.sell > vote.buy
+		env[key+".neutral"] = vote.buy == vote.sell
+		env[key+".strength"] = vote.strength
+		env[key+".value"] = vote.value
+	}
+	return env
+}
+
+// indicatorForSignal maps an indicator signal name such as "RSI_5m", "S&R_1d" or
+// "Williams %R" to its registry entry
+func indicatorForSignal(name string) (IndicatorInfo, bool) {
+	if i := strings.LastIndex(name, "_"); i > 0 && isScriptTimeframe(name[i+1:]) {
+		name = name[:i]
+	}
+	key := alphanumeric(name)
+	for _, info := range Indicators() {
+		candidates := append([]string{info.Name, info.DisplayName}, info.Aliases...)
+		for _, candidate := range candidates {
+			if alphanumeric(candidate) == key {
+				return info, true
+			}
+		}
+	}
+	return IndicatorInfo{}, false
+}
+
+// alphanumeric lowercases s and drops everything but letters and digits
+func alphanumeric(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompileScript(t *testing.T) {
+	t.Log("📜 Testing rule script compilation and limits")
+
+	valid := `
+# Enter on EMA + Bollinger agreement in a trending regime
+trending = regime == "trending"
+enter = ema.bullish and bb.bullish and trending
+exit = rsi_5m.bearish or (confidence < 0.4
+    and not trending)
+`
+	if _, err := CompileScript(valid); err != nil {
+		t.Fatalf("Valid script rejected: %v", err)
+	}
+
+	cases := map[string]string{
+		"enter = emma.bullish":        "unknown indicator",
+		"enter = ema.bullsh":          "unknown field",
+		"enter = foo and true":        "unknown variable",
+		"enter = ema.bullish and":     "incomplete",
+		"price = 1\nenter = true":     "built-in",
+		"enter = true\nenter = false": "already defined",
+		"helper = true":               "enter or exit",
+		"enter = 1 ; 2":               "unexpected character",
+		"enter = \"open":              "unterminated",
+		"enter = " + strings.Repeat("(", 100) + "true" + strings.Repeat(")", 100): "nested deeper",
+		"enter = " + strings.Repeat("not ", 100) + "true":                         "nested deeper",
+		"enter = " + strings.Repeat("- ", 100) + "1 > 0":                          "nested deeper",
+	}
+	for source, want := range cases {
+		if _, err := CompileScript(source); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("CompileScript(%q) = %v, want error containing %q", source, err, want)
+		}
+	}
+
+	if _, err := CompileScript("enter = true\n" + strings.Repeat("#", maxScriptBytes)); err == nil {
+		t.Error("Expected oversized script to be rejected")
+	}
+
+	// The step budget bounds evaluation
+	script, _ := CompileScript("enter = 1 + 1 + 1 + 1 > 2")
+	if _, err := script.Run(nil, 3); err == nil || !strings.Contains(err.Error(), "step budget") {
+		t.Errorf("Expected step budget error, got %v", err)
+	}
+	if decision, err := script.Run(nil, 100); err != nil || !decision.Enter {
+		t.Errorf("Expected enter with enough budget, got %+v (err %v)", decision, err)
+	}
+
+	// Type errors surface at run time with the line number
+	script, _ = CompileScript("enter = true\nexit = regime > 1")
+	if _, err := script.Run(map[string]interface{}{"regime": "TRENDING"}, 100); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected line 2 type error, got %v", err)
+	}
+}
+
+func TestScriptedStrategy(t *testing.T) {
+	t.Log("🧩 Testing scripted strategy intents and hot reload")
+
+	file := filepath.Join(t.TempDir(), "strategy.rules")
+	write := func(source string, modTime time.Time) {
+		if err := os.WriteFile(file, []byte(source), 0644); err != nil {
+			t.Fatalf("Failed to write script: %v", err)
+		}
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatalf("Failed to set script time: %v", err)
+		}
+	}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	write(`enter = ema.bullish and regime == "trending"
+exit = ema.bearish`, start)
+
+	config := DefaultConfig().Scripting
+	config.Enabled = true
+	config.File = file
+	strategy, err := NewScriptedStrategy(config, "BTCUSDT")
+	if err != nil {
+		t.Fatalf("Failed to create scripted strategy: %v", err)
+	}
+
+	signal := &TradingSignal{
+		Signal: Buy,
+		Regime: RegimeTrending,
+		IndicatorSignals: []IndicatorSignal{
+			{Name: "EMA", Signal: Buy, Strength: 0.8, Timeframe: FiveMinute},
+			{Name: "EMA", Signal: Sell, Strength: 0.3, Timeframe: Daily},
+		},
+	}
+	ctx := StrategyContext{
+		Now:        start,
+		Cash:       10000,
+		Holdings:   map[string]float64{},
+		Prices:     map[string]float64{"BTCUSDT": 50000},
+		LastSignal: signal,
+	}
+
+	intents, err := strategy.Evaluate(ctx)
+	if err != nil || len(intents) != 1 || intents[0].Side != "BUY" || intents[0].Quantity != 0.02 {
+		t.Fatalf("Expected a 10%% BUY entry, got %+v (err %v)", intents, err)
+	}
+
+	// Holding and no exit condition: nothing to do
+	ctx.Holdings["BTCUSDT"] = 0.02
+	if intents, _ := strategy.Evaluate(ctx); len(intents) != 0 {
+		t.Errorf("Expected no intents while holding, got %+v", intents)
+	}
+
+	// Editing the file swaps the rules without restarting
+	write("exit = ema_5m.bullish", start.Add(time.Minute))
+	intents, err = strategy.Evaluate(ctx)
+	if err != nil || len(intents) != 1 || intents[0].Side != "SELL" || intents[0].Quantity != 0.02 {
+		t.Fatalf("Expected reloaded exit rule to SELL, got %+v (err %v)", intents, err)
+	}
+
+	// A broken edit is rejected and the previous script keeps running
+	write("exit = ema.bullish and", start.Add(2*time.Minute))
+	intents, err = strategy.Evaluate(ctx)
+	if err != nil || len(intents) != 1 || intents[0].Side != "SELL" {
+		t.Errorf("Expected previous script to keep running, got %+v (err %v)", intents, err)
+	}
+	if strategy.LastError() == nil {
+		t.Error("Expected the rejected reload to be reported")
+	}
+}
+
+func TestIndicatorForSignal(t *testing.T) {
+	t.Log("🔎 Testing indicator signal name mapping")
+
+	for name, want := range map[string]string{
+		"RSI_5m":             "rsi",
+		"BollingerBands_15m": "bollinger_bands",
+		"S&R_1d":             "support_resistance",
+		"ReverseMFI_8h":      "mfi",
+		"Williams %R":        "williams_r",
+		"ElliottWave":        "elliott_wave",
+		"Channel_45m":        "channel_analysis",
+	} {
+		if info, ok := indicatorForSignal(name); !ok || info.Name != want {
+			t.Errorf("indicatorForSignal(%q) = %q, want %q", name, info.Name, want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// SeasonalityBucket aggregates candle returns for one hour-of-day or day-of-week
+type SeasonalityBucket struct {
+	Key               int     `json:"key"` // Session-local hour 0-23 or trading-day weekday 0-6 (Sunday = 0)
+	Label             string  `json:"label"`
+	Samples           int     `json:"samples"`
+	UpRatio           float64 `json:"up_ratio"`           // Fraction of candles closing above their open
+	Bias              float64 `json:"bias"`               // Directional bias in [-1, 1]: 2*UpRatio - 1
+	AvgReturnPercent  float64 `json:"avg_return_percent"` // Mean candle return
+	VolatilityPercent float64 `json:"volatility_percent"` // Standard deviation of candle returns
+}
+
+// SeasonalityStats holds hour-of-day and day-of-week statistics for a symbol
+type SeasonalityStats struct {
+	Symbol     string              `json:"symbol"`
+	Timeframe  string              `json:"timeframe"`
+	From       time.Time           `json:"from"`
+	To         time.Time           `json:"to"`
+	Candles    int                 `json:"candles"`
+	Hours      []SeasonalityBucket `json:"hours"`    // 24 buckets in the session time zone
+	Weekdays   []SeasonalityBucket `json:"weekdays"` // 7 buckets by trading day, Sunday first
+	Session    SessionConfig       `json:"session"`  // Trading day the buckets were cut by
+	ComputedAt time.Time           `json:"computed_at"`
+}
+
+// ComputeSeasonality buckets candle returns by the session-local hour and the
+// trading-day weekday of their open time
+func ComputeSeasonality(symbol string, timeframe Timeframe, candles []Candle, session SessionConfig) (*SeasonalityStats, error) {
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("no candles to compute seasonality")
+	}
+
+	hourReturns := make([][]float64, 24)
+	weekdayReturns := make([][]float64, 7)
+	for _, candle := range candles {
+		if candle.Open <= 0 {
+			continue
+		}
+		ret := (candle.Close - candle.Open) / candle.Open * 100
+		hour, weekday := session.seasonalKeys(candle.Timestamp)
+		hourReturns[hour] = append(hourReturns[hour], ret)
+		weekdayReturns[weekday] = append(weekdayReturns[weekday], ret)
+	}
+
+	stats := &SeasonalityStats{
+		Symbol:     symbol,
+		Timeframe:  timeframe.String(),
+		From:       candles[0].Timestamp,
+		To:         candles[len(candles)-1].Timestamp,
+		Candles:    len(candles),
+		Hours:      make([]SeasonalityBucket, 24),
+		Weekdays:   make([]SeasonalityBucket, 7),
+		Session:    session,
+		ComputedAt: time.Now(),
+	}
+	for hour, returns := range hourReturns {
+		stats.Hours[hour] = newSeasonalityBucket(hour, fmt.Sprintf("%02d:00", hour), returns)
+	}
+	for day, returns := range weekdayReturns {
+		stats.Weekdays[day] = newSeasonalityBucket(day, time.Weekday(day).String(), returns)
+	}
+	return stats, nil
+}
+
+// seasonalKeys returns the hour and weekday buckets t falls into
+func (s SessionConfig) seasonalKeys(t time.Time) (hour int, weekday time.Weekday) {
+	return t.In(s.Location()).Hour(), s.TradingDay(t).Weekday()
+}
+
+// newSeasonalityBucket summarizes the returns falling into one bucket
+func newSeasonalityBucket(key int, label string, returns []float64) SeasonalityBucket {
+	bucket := SeasonalityBucket{Key: key, Label: label, Samples: len(returns)}
+	if len(returns) == 0 {
+		return bucket
+	}
+
+	up, sum := 0, 0.0
+	for _, ret := range returns {
+		if ret > 0 {
+			up++
+		}
+		sum += ret
+	}
+	mean := sum / float64(len(returns))
+
+	variance := 0.0
+	for _, ret := range returns {
+		variance += (ret - mean) * (ret - mean)
+	}
+
+	bucket.UpRatio = float64(up) / float64(len(returns))
+	bucket.Bias = 2*bucket.UpRatio - 1
+	bucket.AvgReturnPercent = mean
+	bucket.VolatilityPercent = math.Sqrt(variance / float64(len(returns)))
+	return bucket
+}
+
+// Prior returns the average directional bias of the hour and weekday containing t,
+// ignoring buckets with fewer than minSamples candles
+func (s *SeasonalityStats) Prior(t time.Time, minSamples int) float64 {
+	hour, weekday := s.Session.seasonalKeys(t)
+	total, count := 0.0, 0
+	for _, bucket := range []SeasonalityBucket{s.Hours[hour], s.Weekdays[weekday]} {
+		if bucket.Samples > 0 && bucket.Samples >= minSamples {
+			total += bucket.Bias
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+// RefreshSeasonality recomputes seasonality from the configured lookback of 15m candles
+func (tb *TradingBot) RefreshSeasonality() (*SeasonalityStats, error) {
+	if tb.signalEngine.dataProvider.primary == nil {
+		if err := tb.signalEngine.initializeDataProvider(); err != nil {
+			return nil, fmt.Errorf("failed to initialize data provider: %w", err)
+		}
+	}
+
+	end := time.Now().Truncate(FifteenMinute.Duration())
+	start := end.AddDate(0, 0, -tb.config.Seasonality.LookbackDays)
+	candles, err := tb.signalEngine.dataProvider.GetHistoricalRange(tb.config.Symbol, FifteenMinute, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load seasonality history: %w", err)
+	}
+
+	stats, err := ComputeSeasonality(tb.config.Symbol, FifteenMinute, candles, tb.config.Session)
+	if err != nil {
+		return nil, err
+	}
+
+	tb.seasonalityMutex.Lock()
+	tb.seasonality = stats
+	tb.seasonalityMutex.Unlock()
+
+	if tb.config.Seasonality.PriorEnabled {
+		tb.signalEngine.aggregator().SetSeasonality(stats)
+	}
+	engineLog.Info("📅 Seasonality refreshed", "candles", stats.Candles, "lookback_days", tb.config.Seasonality.LookbackDays)
+	return stats, nil
+}
+
+// GetSeasonality returns cached seasonality, recomputing it when missing or stale
+func (tb *TradingBot) GetSeasonality() (*SeasonalityStats, error) {
+	tb.seasonalityMutex.RLock()
+	stats := tb.seasonality
+	tb.seasonalityMutex.RUnlock()
+
+	maxAge := time.Duration(tb.config.Seasonality.RefreshHours) * time.Hour
+	if stats != nil && time.Since(stats.ComputedAt) < maxAge {
+		return stats, nil
+	}
+	return tb.RefreshSeasonality()
+}
+
+// startSeasonalityRefresh keeps the aggregator's seasonal prior up to date
+func (tb *TradingBot) startSeasonalityRefresh(ctx context.Context) {
+	go func() {
+		if _, err := tb.RefreshSeasonality(); err != nil {
+			engineLog.Warn("Seasonality refresh failed", "error", err)
+		}
+
+		ticker := time.NewTicker(time.Duration(tb.config.Seasonality.RefreshHours) * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := tb.RefreshSeasonality(); err != nil {
+					engineLog.Warn("Seasonality refresh failed", "error", err)
+				}
+			}
+		}
+	}()
+}
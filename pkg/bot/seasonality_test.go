@@ -0,0 +1,74 @@
+package bot
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSeasonality(t *testing.T) {
+	t.Log("📅 Testing hour-of-day and day-of-week seasonality")
+
+	// Two weeks of hourly candles: 14:00 UTC always rallies 1%, 02:00 always drops 1%
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Monday
+	candles := make([]Candle, 0, 14*24)
+	for i := 0; i < 14*24; i++ {
+		open := start.Add(time.Duration(i) * time.Hour)
+		closePrice := 100.0
+		switch open.Hour() {
+		case 14:
+			closePrice = 101
+		case 2:
+			closePrice = 99
+		}
+		candles = append(candles, Candle{Timestamp: open, Open: 100, High: 101, Low: 99, Close: closePrice})
+	}
+
+	stats, err := ComputeSeasonality("BTCUSDT", FifteenMinute, candles, SessionConfig{})
+	if err != nil {
+		t.Fatalf("Seasonality failed: %v", err)
+	}
+	if len(stats.Hours) != 24 || len(stats.Weekdays) != 7 {
+		t.Fatalf("Expected 24 hour and 7 weekday buckets, got %d/%d", len(stats.Hours), len(stats.Weekdays))
+	}
+	if h := stats.Hours[14]; h.Samples != 14 || h.Bias != 1 || math.Abs(h.AvgReturnPercent-1) > 1e-9 {
+		t.Errorf("Expected 14:00 bias 1 with 1%% mean return, got %+v", h)
+	}
+	if h := stats.Hours[2]; h.Bias != -1 {
+		t.Errorf("Expected 02:00 bias -1, got %.2f", h.Bias)
+	}
+	if h := stats.Hours[8]; h.Bias != -1 || h.VolatilityPercent != 0 {
+		t.Errorf("Flat candles count as not-up with zero volatility, got %+v", h)
+	}
+	if d := stats.Weekdays[time.Monday]; d.Samples != 48 || d.Label != "Monday" || d.VolatilityPercent <= 0 {
+		t.Errorf("Unexpected Monday bucket: %+v", d)
+	}
+
+	// Prior averages the hour and weekday bias, skipping thin buckets
+	at := time.Date(2024, 1, 15, 14, 5, 0, 0, time.UTC)
+	expected := (stats.Hours[14].Bias + stats.Weekdays[time.Monday].Bias) / 2
+	if prior := stats.Prior(at, 10); math.Abs(prior-expected) > 1e-9 {
+		t.Errorf("Expected prior %.3f, got %.3f", expected, prior)
+	}
+	if prior := stats.Prior(at, 20); prior != stats.Weekdays[time.Monday].Bias {
+		t.Errorf("Hour bucket below min samples should be ignored, got prior %.3f", prior)
+	}
+
+	// The prior breaks a tied vote only when enabled
+	config := DefaultConfig()
+	aggregator := NewSignalAggregator(config)
+	aggregator.SetSeasonality(stats)
+	tied := []IndicatorSignal{{Name: "RSI_5m", Signal: Buy, Strength: 0.5}, {Name: "EMA", Signal: Sell, Strength: 0.5}}
+	if prior := aggregator.seasonalPrior(at); prior != 0 {
+		t.Errorf("Prior should be 0 while disabled, got %.3f", prior)
+	}
+
+	config.Seasonality.PriorEnabled = true
+	config.Seasonality.MinSamples = 10
+	aggregator = NewSignalAggregator(config)
+	aggregator.SetSeasonality(stats)
+	prior := aggregator.seasonalPrior(at)
+	if result := aggregator.applyFocused5MinuteLogic(tied, 100, RegimeProfile{}, prior); prior <= 0 || result.Signal != Buy {
+		t.Errorf("Bullish 14:00 prior %.3f should tip a tied vote to BUY, got %s", prior, result.Signal)
+	}
+}
@@ -0,0 +1,265 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ParameterSensitivity is the backtest impact of nudging one indicator parameter
+type ParameterSensitivity struct {
+	Parameter       string  `json:"parameter"` // JSON path, e.g. "rsi.period"
+	BaseValue       float64 `json:"base_value"`
+	LowValue        float64 `json:"low_value"`
+	HighValue       float64 `json:"high_value"`
+	LowReturn       float64 `json:"low_return"`  // Total return % with the parameter lowered
+	HighReturn      float64 `json:"high_return"` // Total return % with the parameter raised
+	LowAccuracy     float64 `json:"low_accuracy"`
+	HighAccuracy    float64 `json:"high_accuracy"`
+	ReturnImpact    float64 `json:"return_impact"`   // Largest |Δ return| vs baseline (percentage points)
+	AccuracyImpact  float64 `json:"accuracy_impact"` // Largest |Δ accuracy| vs baseline (percentage points)
+	Fragile         bool    `json:"fragile"`
+	FragileReason   string  `json:"fragile_reason,omitempty"`
+	PerturbationErr string  `json:"error,omitempty"`
+}
+
+// SensitivityReport ranks indicator parameters by how much small changes move results
+type SensitivityReport struct {
+	Perturbation     float64                `json:"perturbation"` // Relative change applied, e.g. 0.1 = ±10%
+	BaselineReturn   float64                `json:"baseline_return"`
+	BaselineAccuracy float64                `json:"baseline_accuracy"`
+	Parameters       []ParameterSensitivity `json:"parameters"` // Most sensitive first
+}
+
+// SensitivityAnalyzer perturbs each enabled indicator parameter and re-runs the backtest
+type SensitivityAnalyzer struct {
+	config         Config
+	initialBalance float64
+	perturbation   float64
+	fragileImpact  float64 // Return impact (pp) above which a parameter is flagged
+}
+
+// NewSensitivityAnalyzer creates an analyzer perturbing parameters by ±perturbation
+// and flagging parameters whose return impact exceeds fragileImpact percentage points
+func NewSensitivityAnalyzer(config Config, initialBalance, perturbation, fragileImpact float64) *SensitivityAnalyzer {
+	return &SensitivityAnalyzer{
+		config:         config,
+		initialBalance: initialBalance,
+		perturbation:   perturbation,
+		fragileImpact:  fragileImpact,
+	}
+}
+
+// Run backtests the baseline then both perturbations of every parameter
+func (sa *SensitivityAnalyzer) Run(candles map[Timeframe][]Candle, start, end time.Time) (*SensitivityReport, error) {
+	baseline, err := NewBacktester(sa.config, sa.initialBalance).Run(candles, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("baseline backtest failed: %w", err)
+	}
+
+	report := &SensitivityReport{
+		Perturbation:     sa.perturbation,
+		BaselineReturn:   baseline.TotalReturnPercent,
+		BaselineAccuracy: baseline.SignalAccuracy,
+		Parameters:       make([]ParameterSensitivity, 0),
+	}
+
+	for _, param := range IndicatorParameters(sa.config) {
+		result := ParameterSensitivity{Parameter: param}
+		base, _ := getIndicatorParameter(sa.config, param)
+		result.BaseValue = base
+
+		low, lowValue := sa.perturb(param, -1)
+		high, highValue := sa.perturb(param, 1)
+		result.LowValue, result.HighValue = lowValue, highValue
+
+		lowResult, lowErr := NewBacktester(low, sa.initialBalance).Run(candles, start, end)
+		highResult, highErr := NewBacktester(high, sa.initialBalance).Run(candles, start, end)
+		if lowErr != nil || highErr != nil {
+			result.PerturbationErr = fmt.Sprintf("low: %v, high: %v", lowErr, highErr)
+			report.Parameters = append(report.Parameters, result)
+			continue
+		}
+
+		result.LowReturn, result.HighReturn = lowResult.TotalReturnPercent, highResult.TotalReturnPercent
+		result.LowAccuracy, result.HighAccuracy = lowResult.SignalAccuracy, highResult.SignalAccuracy
+		result.ReturnImpact = math.Max(math.Abs(result.LowReturn-baseline.TotalReturnPercent), math.Abs(result.HighReturn-baseline.TotalReturnPercent))
+		result.AccuracyImpact = math.Max(math.Abs(result.LowAccuracy-baseline.SignalAccuracy), math.Abs(result.HighAccuracy-baseline.SignalAccuracy))
+
+		switch {
+		case baseline.TotalReturnPercent > 0 && (result.LowReturn < 0 || result.HighReturn < 0):
+			result.Fragile = true
+			result.FragileReason = "return turns negative"
+		case result.ReturnImpact > sa.fragileImpact:
+			result.Fragile = true
+			result.FragileReason = fmt.Sprintf("return moves %.2fpp", result.ReturnImpact)
+		}
+		report.Parameters = append(report.Parameters, result)
+	}
+
+	sort.SliceStable(report.Parameters, func(i, j int) bool {
+		return report.Parameters[i].ReturnImpact > report.Parameters[j].ReturnImpact
+	})
+	return report, nil
+}
+
+// perturb returns a copy of the config with param scaled by 1 ± perturbation.
+// Integer parameters move by at least 1 and never drop below 1.
+func (sa *SensitivityAnalyzer) perturb(param string, direction float64) (Config, float64) {
+	config := sa.config
+	base, _ := getIndicatorParameter(config, param)
+	value := base * (1 + direction*sa.perturbation)
+
+	if field, _ := indicatorParameterField(&config, param); field.Kind() == reflect.Int {
+		rounded := math.Round(value)
+		if rounded == base {
+			rounded = base + direction
+		}
+		value = math.Max(rounded, 1)
+	}
+
+	setIndicatorParameter(&config, param, value)
+	return config, value
+}
+
+// IndicatorParameters lists the numeric parameters of enabled indicators as JSON paths
+func IndicatorParameters(config Config) []string {
+	params := make([]string, 0)
+	configValue := reflect.ValueOf(config)
+	configType := configValue.Type()
+
+	for i := 0; i < configType.NumField(); i++ {
+		section := configValue.Field(i)
+		if section.Kind() != reflect.Struct {
+			continue
+		}
+		sectionName := jsonName(configType.Field(i))
+		enabled := section.FieldByName("Enabled")
+		if !indicatorSections[sectionName] || !enabled.IsValid() || !enabled.Bool() {
+			continue
+		}
+
+		for j := 0; j < section.NumField(); j++ {
+			switch section.Field(j).Kind() {
+			case reflect.Int, reflect.Float64:
+				params = append(params, sectionName+"."+jsonName(section.Type().Field(j)))
+			}
+		}
+	}
+	return params
+}
+
+// indicatorSections are the Config sections (by JSON key) that configure indicators
+var indicatorSections = map[string]bool{
+	"rsi": true, "macd": true, "volume": true, "trend": true, "support_resistance": true,
+	"ichimoku": true, "mfi": true, "bollinger_bands": true, "stochastic": true, "williams_r": true,
+	"pin_bar": true, "ema": true, "elliott_wave": true, "channel_analysis": true, "atr": true,
+	"keltner_channel": true, "donchian_channel": true, "parabolic_sar": true, "candle_patterns": true,
+}
+
+// jsonName returns a struct field's JSON key
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// indicatorParameterField resolves "section.param" to a settable field
+func indicatorParameterField(config *Config, param string) (reflect.Value, error) {
+	parts := strings.SplitN(param, ".", 2)
+	if len(parts) != 2 {
+		return reflect.Value{}, fmt.Errorf("invalid parameter path: %s", param)
+	}
+
+	configValue := reflect.ValueOf(config).Elem()
+	for i := 0; i < configValue.NumField(); i++ {
+		if jsonName(configValue.Type().Field(i)) != parts[0] {
+			continue
+		}
+		section := configValue.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			if jsonName(section.Type().Field(j)) == parts[1] {
+				return section.Field(j), nil
+			}
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("unknown parameter: %s", param)
+}
+
+// weightParameter resolves "section.map.key" to a map[string]float64 field, e.g.
+// "strategy.timeframe_weights.5m"
+func weightParameter(config *Config, param string) (reflect.Value, string, bool) {
+	parts := strings.SplitN(param, ".", 3)
+	if len(parts) != 3 {
+		return reflect.Value{}, "", false
+	}
+	field, err := indicatorParameterField(config, parts[0]+"."+parts[1])
+	if err != nil || field.Type() != reflect.TypeOf(map[string]float64(nil)) {
+		return reflect.Value{}, "", false
+	}
+	return field, parts[2], true
+}
+
+// getIndicatorParameter reads a numeric parameter by JSON path
+func getIndicatorParameter(config Config, param string) (float64, error) {
+	if weights, key, ok := weightParameter(&config, param); ok {
+		if value := weights.MapIndex(reflect.ValueOf(key)); value.IsValid() {
+			return value.Float(), nil
+		}
+		return 0, nil
+	}
+	field, err := indicatorParameterField(&config, param)
+	if err != nil {
+		return 0, err
+	}
+	switch field.Kind() {
+	case reflect.Int:
+		return float64(field.Int()), nil
+	case reflect.Float64:
+		return field.Float(), nil
+	}
+	return 0, fmt.Errorf("parameter %s is not numeric", param)
+}
+
+// setIndicatorParameter writes a numeric parameter by JSON path
+func setIndicatorParameter(config *Config, param string, value float64) error {
+	if weights, key, ok := weightParameter(config, param); ok {
+		// Copy the map so configs sharing it (e.g. a sweep's base config) keep their weights
+		copied := make(map[string]float64, weights.Len()+1)
+		for _, name := range weights.MapKeys() {
+			copied[name.String()] = weights.MapIndex(name).Float()
+		}
+		copied[key] = value
+		weights.Set(reflect.ValueOf(copied))
+		return nil
+	}
+	field, err := indicatorParameterField(config, param)
+	if err != nil {
+		return err
+	}
+	switch field.Kind() {
+	case reflect.Int:
+		field.SetInt(int64(math.Round(value)))
+	case reflect.Float64:
+		field.SetFloat(value)
+	default:
+		return fmt.Errorf("parameter %s is not numeric", param)
+	}
+	return nil
+}
+
+// ApplyParameters returns a copy of config with numeric parameters set by JSON
+// path, e.g. "rsi.period", "atr.multiplier" or "strategy.timeframe_weights.5m"
+func ApplyParameters(config Config, params map[string]float64) (Config, error) {
+	for _, param := range sortedKeys(params) {
+		if err := setIndicatorParameter(&config, param, params[param]); err != nil {
+			return config, err
+		}
+	}
+	return config, nil
+}
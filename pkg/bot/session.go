@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	_ "time/tzdata" // Sessions resolve IANA zones even on hosts without a zoneinfo database
+)
+
+// sessionLocations caches loaded time zones by name
+var sessionLocations sync.Map
+
+// Location returns the session's time zone, UTC when unset or unknown
+func (s SessionConfig) Location() *time.Location {
+	name := valueOrDefault(s.Timezone, "UTC")
+	if loc, ok := sessionLocations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	sessionLocations.Store(name, loc)
+	return loc
+}
+
+// startClock parses StartTime ("HH:MM", midnight when unset)
+func (s SessionConfig) startClock() (hour, minute int, err error) {
+	if s.StartTime == "" {
+		return 0, 0, nil
+	}
+	start, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid session start time %q (want HH:MM): %w", s.StartTime, err)
+	}
+	return start.Hour(), start.Minute(), nil
+}
+
+// SessionStart returns the start of the trading session containing now: the
+// latest StartTime in the session's time zone at or before now. Each start is
+// resolved from the local calendar date, so it follows DST changes.
+func (s SessionConfig) SessionStart(now time.Time) time.Time {
+	hour, minute, _ := s.startClock()
+	local := now.In(s.Location())
+	start := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, local.Location())
+	if start.After(now) {
+		start = time.Date(local.Year(), local.Month(), local.Day()-1, hour, minute, 0, 0, local.Location())
+	}
+	return start
+}
+
+// TradingDay returns the date of the session containing t: the local calendar
+// date holding most of it, so a 17:00 New York session counts toward the next day
+func (s SessionConfig) TradingDay(t time.Time) time.Time {
+	midday := s.SessionStart(t).Add(12 * time.Hour)
+	return time.Date(midday.Year(), midday.Month(), midday.Day(), 0, 0, 0, 0, midday.Location())
+}
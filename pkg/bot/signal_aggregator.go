@@ -3,7 +3,10 @@ package bot
 import (
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"trading-bot/pkg/indicator"
@@ -13,6 +16,80 @@ import (
 type SignalAggregator struct {
 	config     Config
 	indicators map[Timeframe][]indicator.TechnicalIndicator
+	transforms map[Timeframe][]indicator.CandleTransform // Parallel to indicators; nil calculates on raw candles
+	weights    map[string]float64                        // Plugin signal weights keyed by indicator name
+
+	regime      *RegimeStatus // Active regime profile (nil until regime switching first runs)
+	regimeMutex sync.RWMutex
+
+	seasonality      *SeasonalityStats // Source of the optional seasonal prior
+	seasonalityMutex sync.RWMutex
+
+	filters      *SymbolFilters // Tick size targets and stops are rounded to
+	filtersMutex sync.RWMutex
+
+	clock Clock // Stamps signals and regime switches
+}
+
+// SetSymbolFilters replaces the tick size used to round targets and stops
+func (sa *SignalAggregator) SetSymbolFilters(filters *SymbolFilters) {
+	sa.filtersMutex.Lock()
+	defer sa.filtersMutex.Unlock()
+	sa.filters = filters
+}
+
+// SetClock replaces the time source stamping signals; call before generating any
+func (sa *SignalAggregator) SetClock(clock Clock) {
+	sa.clock = clock
+}
+
+// SymbolFilters returns the tick/lot rules prices are rounded with
+func (sa *SignalAggregator) SymbolFilters() *SymbolFilters {
+	sa.filtersMutex.RLock()
+	defer sa.filtersMutex.RUnlock()
+	return sa.filters
+}
+
+// roundPrice rounds a target or stop to the symbol's tick (0 stays unset)
+func (sa *SignalAggregator) roundPrice(price float64) float64 {
+	if price == 0 {
+		return 0
+	}
+	sa.filtersMutex.RLock()
+	defer sa.filtersMutex.RUnlock()
+	return sa.filters.RoundPrice(price)
+}
+
+// SetSeasonality provides the statistics used for the seasonal prior
+func (sa *SignalAggregator) SetSeasonality(stats *SeasonalityStats) {
+	sa.seasonalityMutex.Lock()
+	defer sa.seasonalityMutex.Unlock()
+	sa.seasonality = stats
+}
+
+// seasonalPrior returns the weighted seasonal bias for t (0 when disabled)
+func (sa *SignalAggregator) seasonalPrior(t time.Time) float64 {
+	if !sa.config.Seasonality.PriorEnabled {
+		return 0
+	}
+	sa.seasonalityMutex.RLock()
+	defer sa.seasonalityMutex.RUnlock()
+	if sa.seasonality == nil {
+		return 0
+	}
+	return sa.seasonality.Prior(t, sa.config.Seasonality.MinSamples) * sa.config.Seasonality.PriorWeight
+}
+
+// SetVectorized switches the indicators that support it to their vectorized
+// calculation path (used for large backtests)
+func (sa *SignalAggregator) SetVectorized(enabled bool) {
+	for _, indicators := range sa.indicators {
+		for _, ind := range indicators {
+			if v, ok := ind.(indicator.VectorizedIndicator); ok {
+				v.SetVectorized(enabled)
+			}
+		}
+	}
 }
 
 // NewSignalAggregator creates a new signal aggregator
@@ -20,6 +97,10 @@ func NewSignalAggregator(config Config) *SignalAggregator {
 	sa := &SignalAggregator{
 		config:     config,
 		indicators: make(map[Timeframe][]indicator.TechnicalIndicator),
+		transforms: make(map[Timeframe][]indicator.CandleTransform),
+		weights:    make(map[string]float64),
+		filters:    SymbolFiltersFor(config, config.Symbol),
+		clock:      SystemClock,
 	}
 
 	// Initialize indicators for each timeframe
@@ -34,195 +115,103 @@ func (sa *SignalAggregator) GetActiveIndicatorCount(timeframe Timeframe) int {
 
 // GetTotalActiveIndicators returns the total number of active indicators across all timeframes
 func (sa *SignalAggregator) GetTotalActiveIndicators() int {
-	enabledIndicators := 0
-
-	if sa.config.RSI.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.MACD.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.Volume.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.Trend.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.SupportResistance.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.Ichimoku.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.MFI.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.BollingerBands.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.Stochastic.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.WilliamsR.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.PinBar.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.EMA.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.ElliottWave.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.ChannelAnalysis.Enabled {
-		enabledIndicators++
-	}
-	if sa.config.ATR.Enabled {
-		enabledIndicators++
-	}
-
-	return enabledIndicators
+	return len(sa.GetActiveIndicatorNames())
 }
 
 // GetActiveIndicatorNames returns the names of active indicators
 func (sa *SignalAggregator) GetActiveIndicatorNames() []string {
 	var names []string
-
-	if sa.config.RSI.Enabled {
-		names = append(names, "RSI")
-	}
-	if sa.config.MACD.Enabled {
-		names = append(names, "MACD")
-	}
-	if sa.config.Volume.Enabled {
-		names = append(names, "Volume")
-	}
-	if sa.config.Trend.Enabled {
-		names = append(names, "Trend")
-	}
-	if sa.config.SupportResistance.Enabled {
-		names = append(names, "Support/Resistance")
-	}
-	if sa.config.Ichimoku.Enabled {
-		names = append(names, "Ichimoku")
-	}
-	if sa.config.MFI.Enabled {
-		names = append(names, "Reverse-MFI")
-	}
-	if sa.config.BollingerBands.Enabled {
-		names = append(names, "Bollinger Bands")
-	}
-	if sa.config.Stochastic.Enabled {
-		names = append(names, "Stochastic")
-	}
-	if sa.config.WilliamsR.Enabled {
-		names = append(names, "Williams %R")
-	}
-	if sa.config.PinBar.Enabled {
-		names = append(names, "Pin Bar")
-	}
-	if sa.config.EMA.Enabled {
-		names = append(names, "EMA")
-	}
-	if sa.config.ElliottWave.Enabled {
-		names = append(names, "Elliott Wave")
-	}
-	if sa.config.ChannelAnalysis.Enabled {
-		names = append(names, "Channel Analysis")
-	}
-	if sa.config.ATR.Enabled {
-		names = append(names, "ATR")
+	for _, info := range Indicators() {
+		if info.IsEnabled(sa.config) {
+			names = append(names, info.DisplayName)
+		}
 	}
-
 	return names
 }
 
-// initializeIndicators sets up all indicators for each timeframe
-func (sa *SignalAggregator) initializeIndicators() {
-	// FOCUSED: Only initialize 5-minute timeframe for ultra-fast trading
-	timeframes := []Timeframe{FiveMinute}
-
-	for _, tf := range timeframes {
-		var indicators []indicator.TechnicalIndicator
-
-		// Add RSI (if enabled)
-		if sa.config.RSI.Enabled {
-			indicators = append(indicators, indicator.NewRSI(convertRSIConfig(sa.config.RSI), convertTimeframe(tf)))
-		}
-
-		// Add MACD (if enabled)
-		if sa.config.MACD.Enabled {
-			indicators = append(indicators, indicator.NewMACD(convertMACDConfig(sa.config.MACD), convertTimeframe(tf)))
-		}
-
-		// Add Volume (if enabled)
-		if sa.config.Volume.Enabled {
-			indicators = append(indicators, indicator.NewVolume(convertVolumeConfig(sa.config.Volume), convertTimeframe(tf)))
-		}
-
-		// Add Trend (if enabled)
-		if sa.config.Trend.Enabled {
-			indicators = append(indicators, indicator.NewTrend(convertTrendConfig(sa.config.Trend), convertTimeframe(tf)))
-		}
-
-		// Add Support/Resistance (if enabled)
-		if sa.config.SupportResistance.Enabled {
-			indicators = append(indicators, indicator.NewSupportResistance(convertSupportResistanceConfig(sa.config.SupportResistance), convertTimeframe(tf)))
-		}
-
-		// Add Ichimoku (if enabled)
-		if sa.config.Ichimoku.Enabled {
-			indicators = append(indicators, indicator.NewIchimoku(convertIchimokuConfig(sa.config.Ichimoku), convertTimeframe(tf)))
-		}
-
-		// Add Reverse-MFI (if enabled)
-		if sa.config.MFI.Enabled {
-			indicators = append(indicators, indicator.NewReverseMFI(convertMFIConfig(sa.config.MFI), convertTimeframe(tf)))
-		}
-
-		// Add Bollinger Bands (if enabled)
-		if sa.config.BollingerBands.Enabled {
-			indicators = append(indicators, indicator.NewBollingerBands(convertBollingerBandsConfig(sa.config.BollingerBands), convertTimeframe(tf)))
-		}
-
-		// Add Stochastic (if enabled)
-		if sa.config.Stochastic.Enabled {
-			indicators = append(indicators, indicator.NewStochastic(convertStochasticConfig(sa.config.Stochastic), convertTimeframe(tf)))
-		}
+// multiTimeframes are the timeframes the multi_timeframe mode analyzes, highest first
+var multiTimeframes = []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute}
 
-		// Add Williams %R (if enabled)
-		if sa.config.WilliamsR.Enabled {
-			indicators = append(indicators, indicator.NewWilliamsR(convertWilliamsRConfig(sa.config.WilliamsR), convertTimeframe(tf)))
-		}
+// timeframes returns the timeframes the configured strategy mode analyzes
+func (sa *SignalAggregator) timeframes() []Timeframe {
+	if sa.config.Strategy.Mode == StrategyModeMultiTimeframe {
+		return multiTimeframes
+	}
+	// FOCUSED: Only the 5-minute timeframe for ultra-fast trading
+	return []Timeframe{FiveMinute}
+}
 
-		// Add Pin Bar (if enabled)
-		if sa.config.PinBar.Enabled {
-			indicators = append(indicators, indicator.NewPinBar(convertPinBarConfig(sa.config.PinBar), convertTimeframe(tf)))
-		}
+// timeframeWeight returns a timeframe's multi_timeframe weight (0 leaves it out)
+func (sa *SignalAggregator) timeframeWeight(tf Timeframe) float64 {
+	return sa.config.Strategy.TimeframeWeights[tf.String()]
+}
 
-		// Add EMA (if enabled)
-		if sa.config.EMA.Enabled {
-			indicators = append(indicators, indicator.NewEMA(convertEMAConfig(sa.config.EMA), convertTimeframe(tf)))
+// candleTransform returns the transform candle_transforms assigns to a registered
+// indicator, or nil for raw candles
+func (sa *SignalAggregator) candleTransform(name string) indicator.CandleTransform {
+	for key, transformName := range sa.config.CandleTransforms {
+		if info, ok := LookupIndicator(key); ok && info.Name == name {
+			transform, _ := indicator.LookupCandleTransform(transformName)
+			return transform
 		}
+	}
+	return nil
+}
 
-		// Add Elliott Wave (if enabled)
-		if sa.config.ElliottWave.Enabled {
-			indicators = append(indicators, indicator.NewElliottWave(convertElliottWaveConfig(sa.config.ElliottWave), convertTimeframe(tf)))
+// initializeIndicators sets up all indicators for each timeframe
+func (sa *SignalAggregator) initializeIndicators() {
+	for _, tf := range sa.timeframes() {
+		var indicators []indicator.TechnicalIndicator
+		var transforms []indicator.CandleTransform
+		add := func(name string, ind indicator.TechnicalIndicator) {
+			indicators = append(indicators, ind)
+			transforms = append(transforms, sa.candleTransform(name))
 		}
 
-		// Add Channel Analysis (if enabled) - Works best on 5min and 15min timeframes
-		if sa.config.ChannelAnalysis.Enabled && (tf == FiveMinute) {
-			indicators = append(indicators, indicator.NewChannelAnalysis(convertChannelAnalysisConfig(sa.config.ChannelAnalysis), convertTimeframe(tf)))
+		// Registered indicators, built-ins first; a plugin that fails to build is skipped
+		for _, info := range Indicators() {
+			if !info.IsEnabled(sa.config) {
+				continue
+			}
+			ind, err := info.newIndicator(sa.config, convertTimeframe(tf))
+			if err != nil {
+				engineLog.Warn("Indicator skipped", "indicator", info.Name, "error", err)
+				continue
+			}
+			if ind == nil {
+				continue
+			}
+			if info.plugin != nil {
+				sa.weights[ind.GetName()] = info.plugin.Weight
+			}
+			add(info.Name, ind)
 		}
 
-		// Add ATR (if enabled)
-		if sa.config.ATR.Enabled {
-			indicators = append(indicators, indicator.NewATR(convertATRConfig(sa.config.ATR), convertTimeframe(tf)))
+		// Add Pine Script studies (if enabled); a study that fails to load is skipped
+		if sa.config.Pine.Enabled {
+			for _, path := range sa.config.Pine.Scripts {
+				study, err := loadPineStudy(path, sa.config.Pine.Strength, tf)
+				if err != nil {
+					engineLog.Warn("Pine study skipped", "file", path, "error", err)
+					continue
+				}
+				add("", study)
+			}
 		}
 
 		sa.indicators[tf] = indicators
+		sa.transforms[tf] = transforms
+	}
+}
+
+// loadPineStudy reads and compiles a Pine Script study file
+func loadPineStudy(path string, strength float64, tf Timeframe) (*indicator.PineStudy, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read study: %w", err)
 	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return indicator.NewPineStudy(string(source), name, convertTimeframe(tf), strength)
 }
 
 // Helper functions to convert between bot and indicator package types
@@ -387,6 +376,44 @@ func convertChannelAnalysisConfig(config ChannelAnalysisConfig) indicator.Channe
 	}
 }
 
+// convertKeltnerChannelConfig converts bot config to indicator config
+func convertKeltnerChannelConfig(config KeltnerChannelConfig) indicator.KeltnerChannelConfig {
+	return indicator.KeltnerChannelConfig{
+		Enabled:    config.Enabled,
+		EMAPeriod:  config.EMAPeriod,
+		ATRPeriod:  config.ATRPeriod,
+		Multiplier: config.Multiplier,
+		Mode:       config.Mode,
+	}
+}
+
+// convertDonchianChannelConfig converts bot config to indicator config
+func convertDonchianChannelConfig(config DonchianChannelConfig) indicator.DonchianChannelConfig {
+	return indicator.DonchianChannelConfig{
+		Enabled: config.Enabled,
+		Period:  config.Period,
+		Mode:    config.Mode,
+	}
+}
+
+// convertParabolicSARConfig converts bot config to indicator config
+func convertParabolicSARConfig(config ParabolicSARConfig) indicator.ParabolicSARConfig {
+	return indicator.ParabolicSARConfig{
+		Enabled: config.Enabled,
+		Step:    config.Step,
+		MaxStep: config.MaxStep,
+	}
+}
+
+// convertCandlePatternsConfig converts bot config to indicator config
+func convertCandlePatternsConfig(config CandlePatternsConfig) indicator.CandlePatternsConfig {
+	return indicator.CandlePatternsConfig{
+		Enabled:          config.Enabled,
+		Patterns:         config.Patterns,
+		TweezerTolerance: config.TweezerTolerance,
+	}
+}
+
 // convertATRConfig converts bot config to indicator config
 func convertATRConfig(config ATRConfig) indicator.ATRConfig {
 	return indicator.ATRConfig{
@@ -452,22 +479,54 @@ func (sa *SignalAggregator) GenerateSignal(ctx *MultiTimeframeContext) (*Trading
 		return nil, fmt.Errorf("invalid current price")
 	}
 
-	// FOCUSED: Only get 5-minute signals for ultra-fast response
-	fiveMinSignals := sa.getTimeframeSignals(ctx.FiveMinCandles, FiveMinute, currentPrice)
+	// Pick the weight/threshold profile for the current regime
+	profile, regime := sa.selectRegimeProfile(ctx.FiveMinCandles)
+
+	var finalSignal MultiTimeframeResult
+	var indicatorSignals []IndicatorSignal
+	if sa.config.Strategy.Mode == StrategyModeMultiTimeframe {
+		candles := map[Timeframe][]Candle{
+			Daily:           ctx.DailyCandles,
+			EightHour:       ctx.EightHourCandles,
+			FortyFiveMinute: ctx.FortyFiveMinCandles,
+			FifteenMinute:   ctx.FifteenMinCandles,
+			FiveMinute:      ctx.FiveMinCandles,
+		}
+		signals := make(map[Timeframe][]IndicatorSignal, len(candles))
+		for _, tf := range multiTimeframes {
+			signals[tf] = sa.getTimeframeSignals(candles[tf], tf, currentPrice)
+			indicatorSignals = append(indicatorSignals, signals[tf]...)
+		}
+		finalSignal = sa.applyMultiTimeframeLogic(signals, currentPrice)
+	} else {
+		// FOCUSED: Only get 5-minute signals for ultra-fast response
+		indicatorSignals = sa.getTimeframeSignals(ctx.FiveMinCandles, FiveMinute, currentPrice)
+
+		// Seasonal prior for the upcoming candle
+		prior := 0.0
+		if len(ctx.FiveMinCandles) > 0 {
+			latest := ctx.FiveMinCandles[len(ctx.FiveMinCandles)-1].Timestamp
+			prior = sa.seasonalPrior(latest.Add(FiveMinute.Duration()))
+		}
 
-	// Apply focused 5-minute logic
-	finalSignal := sa.applyFocused5MinuteLogic(fiveMinSignals, currentPrice)
+		// Apply focused 5-minute logic
+		finalSignal = sa.applyFocused5MinuteLogic(indicatorSignals, currentPrice, profile, prior)
+	}
 
-	return &TradingSignal{
+	signal := &TradingSignal{
+		Regime:           regime,
 		Symbol:           ctx.Symbol,
 		Signal:           finalSignal.Signal,
 		Confidence:       finalSignal.Confidence,
-		Timestamp:        time.Now(),
-		IndicatorSignals: fiveMinSignals,
+		Timestamp:        sa.clock.Now(),
+		IndicatorSignals: indicatorSignals,
 		Reasoning:        finalSignal.Reasoning,
-		TargetPrice:      finalSignal.TargetPrice,
-		StopLoss:         finalSignal.StopLoss,
-	}, nil
+		TargetPrice:      sa.roundPrice(finalSignal.TargetPrice),
+		StopLoss:         sa.roundPrice(finalSignal.StopLoss),
+		Price:            currentPrice,
+	}
+	sa.applyDataQuality(signal, ctx.Quality)
+	return signal, nil
 }
 
 // getTimeframeSignals calculates signals for a specific timeframe
@@ -476,35 +535,44 @@ func (sa *SignalAggregator) getTimeframeSignals(candles []Candle, timeframe Time
 
 	indicators := sa.indicators[timeframe]
 
-	for _, ind := range indicators {
-		var signal indicator.IndicatorSignal
+	for i, ind := range indicators {
+		var signal, divergence indicator.IndicatorSignal
+		var diverged bool
+		input := convertCandles(candles)
+		if transform := sa.transforms[timeframe][i]; transform != nil {
+			input = transform(input)
+		}
 
 		// Enhanced 5-minute Ichimoku signal processing
 		if timeframe == FiveMinute && strings.Contains(ind.GetName(), "Ichimoku") {
 			// Use enhanced 5-minute signal for Ichimoku on 5-minute timeframe
 			if ichimokuIndicator, ok := ind.(*indicator.Ichimoku); ok {
-				signal = ichimokuIndicator.GetEnhanced5MinuteSignal(convertCandles(candles), currentPrice)
+				signal = ichimokuIndicator.GetEnhanced5MinuteSignal(input, currentPrice)
 			} else {
 				// Fallback to standard calculation
-				values := ind.Calculate(convertCandles(candles))
+				values := ind.Calculate(input)
 				signal = ind.GetSignal(values, currentPrice)
 			}
 		} else if timeframe == FiveMinute && strings.Contains(ind.GetName(), "BollingerBands") {
 			// Use enhanced 5-minute signal for Bollinger Bands on 5-minute timeframe
 			if bollingerIndicator, ok := ind.(*indicator.BollingerBands); ok {
-				signal = bollingerIndicator.GetEnhanced5MinuteSignal(convertCandles(candles), currentPrice)
+				signal = bollingerIndicator.GetEnhanced5MinuteSignal(input, currentPrice)
 			} else {
 				// Fallback to standard calculation
-				values := ind.Calculate(convertCandles(candles))
+				values := ind.Calculate(input)
 				signal = ind.GetSignal(values, currentPrice)
 			}
 		} else {
 			// Standard signal calculation for all other cases
-			values := ind.Calculate(convertCandles(candles))
+			values := ind.Calculate(input)
 			signal = ind.GetSignal(values, currentPrice)
+			divergence, diverged = sa.divergenceSignal(ind, timeframe, input, values)
 		}
 
 		signals = append(signals, convertIndicatorSignal(signal))
+		if diverged {
+			signals = append(signals, convertIndicatorSignal(divergence))
+		}
 	}
 
 	return signals
@@ -519,77 +587,72 @@ type MultiTimeframeResult struct {
 	StopLoss    float64
 }
 
-// applyMultiTimeframeLogic combines signals using multi-timeframe analysis
-func (sa *SignalAggregator) applyMultiTimeframeLogic(dailySignals, eightHourSignals, fortyFiveMinSignals, fifteenMinSignals, fiveMinSignals []IndicatorSignal, currentPrice float64) MultiTimeframeResult {
-	// Rebalanced weights prioritizing 5-minute timeframe for short-term predictions
-	// while maintaining higher timeframe context
-	dailyContext := sa.analyzeTimeframeContext(dailySignals, 0.25)               // 25% weight for daily (reduced from 35%)
-	eightHourContext := sa.analyzeTimeframeContext(eightHourSignals, 0.20)       // 20% weight for 8H (reduced from 25%)
-	fortyFiveMinContext := sa.analyzeTimeframeContext(fortyFiveMinSignals, 0.20) // 20% weight for 45min (same)
-	fifteenMinContext := sa.analyzeTimeframeContext(fifteenMinSignals, 0.20)     // 20% weight for 15min (increased from 15%)
-	fiveMinContext := sa.analyzeTimeframeContext(fiveMinSignals, 0.15)           // 15% weight for 5min (increased from 5%) - MOST IMPORTANT for accuracy
+// applyMultiTimeframeLogic combines each timeframe's weighted consensus into one
+// decision, boosting confidence when the daily/8H bias agrees and cutting it when not
+func (sa *SignalAggregator) applyMultiTimeframeLogic(signals map[Timeframe][]IndicatorSignal, currentPrice float64) MultiTimeframeResult {
+	contexts := make(map[Timeframe]TimeframeContext, len(signals))
+	var bullish, bearish, totalWeight float64
+	var summary []string
+	for _, tf := range multiTimeframes {
+		weight := sa.timeframeWeight(tf)
+		if weight <= 0 || len(signals[tf]) == 0 {
+			continue
+		}
+		ctx := sa.analyzeTimeframeContext(signals[tf], 1.0)
+		contexts[tf] = ctx
+		totalWeight += weight
+		switch ctx.Signal {
+		case Buy:
+			bullish += weight * ctx.Confidence
+		case Sell:
+			bearish += weight * ctx.Confidence
+		}
+		summary = append(summary, fmt.Sprintf("%s %s %.0f%%", tf, ctx.Signal, ctx.Confidence*100))
+	}
+	if totalWeight == 0 {
+		return MultiTimeframeResult{Signal: Hold, Confidence: 0.2, Reasoning: "HOLD: No weighted timeframe has signals"}
+	}
+	bullish /= totalWeight
+	bearish /= totalWeight
 
 	// Higher timeframe bias (Daily + 8H)
-	higherTimeframeBias := sa.calculateTimeframeBias(dailyContext, eightHourContext)
+	higherTimeframeBias := sa.calculateTimeframeBias(contexts[Daily], contexts[EightHour])
 
-	// Final signal logic with 5-timeframe analysis
 	var finalSignal SignalType
 	var confidence float64
 	var reasoning strings.Builder
-
-	// Enhanced multi-timeframe confirmation logic
-	totalBullishSignals := 0
-	totalBearishSignals := 0
-	totalConfidence := 0.0
-
-	// Count signals from all timeframes
-	contexts := []TimeframeContext{dailyContext, eightHourContext, fortyFiveMinContext, fifteenMinContext, fiveMinContext}
-	for _, ctx := range contexts {
-		totalConfidence += ctx.Confidence
-		if ctx.Signal == Buy {
-			totalBullishSignals++
-		} else if ctx.Signal == Sell {
-			totalBearishSignals++
-		}
-	}
-
-	// Determine overall signal based on majority and higher timeframe bias
-	if totalBullishSignals > totalBearishSignals && higherTimeframeBias.Signal == Buy {
-		// Strong bullish confluence
+	switch {
+	case bullish > bearish && higherTimeframeBias.Signal == Buy:
 		finalSignal = Buy
-		confidence = math.Min(1.0, totalConfidence/5.0*1.2) // Boost for alignment
+		confidence = math.Min(1.0, bullish*1.2) // Boost for alignment
 		reasoning.WriteString("BULLISH: Multi-timeframe bullish confluence")
-	} else if totalBearishSignals > totalBullishSignals && higherTimeframeBias.Signal == Sell {
-		// Strong bearish confluence
+	case bearish > bullish && higherTimeframeBias.Signal == Sell:
 		finalSignal = Sell
-		confidence = math.Min(1.0, totalConfidence/5.0*1.2) // Boost for alignment
+		confidence = math.Min(1.0, bearish*1.2) // Boost for alignment
 		reasoning.WriteString("BEARISH: Multi-timeframe bearish confluence")
-	} else if totalBullishSignals > totalBearishSignals {
-		// Bullish majority but higher timeframes neutral/bearish
+	case bullish > bearish:
 		finalSignal = Buy
-		confidence = math.Min(1.0, totalConfidence/5.0*0.8) // Reduce for conflict
-		reasoning.WriteString("CAUTIOUS BULLISH: Lower timeframes bullish")
-	} else if totalBearishSignals > totalBullishSignals {
-		// Bearish majority but higher timeframes neutral/bullish
+		confidence = bullish * 0.8 // Reduce for conflict
+		reasoning.WriteString("CAUTIOUS BULLISH: Higher timeframes not confirming")
+	case bearish > bullish:
 		finalSignal = Sell
-		confidence = math.Min(1.0, totalConfidence/5.0*0.8) // Reduce for conflict
-		reasoning.WriteString("CAUTIOUS BEARISH: Lower timeframes bearish")
-	} else {
-		// No clear majority or conflicting signals
+		confidence = bearish * 0.8 // Reduce for conflict
+		reasoning.WriteString("CAUTIOUS BEARISH: Higher timeframes not confirming")
+	default:
 		finalSignal = Hold
 		confidence = 0.3
 		reasoning.WriteString("HOLD: Mixed signals across timeframes")
 	}
+	reasoning.WriteString(" (" + strings.Join(summary, ", ") + ")")
 
 	// Apply minimum confidence threshold
-	if confidence < sa.config.MinConfidence {
+	if finalSignal != Hold && confidence < sa.config.MinConfidence {
 		finalSignal = Hold
-		confidence = 0.2
 		reasoning.WriteString(" - Below minimum confidence threshold")
 	}
 
 	// Calculate target price and stop loss using higher timeframes
-	targetPrice, stopLoss := sa.calculateTargetAndStopLoss(finalSignal, currentPrice, dailySignals, eightHourSignals, fortyFiveMinSignals)
+	targetPrice, stopLoss := sa.calculateTargetAndStopLoss(finalSignal, currentPrice, signals[Daily], signals[EightHour], signals[FortyFiveMinute])
 
 	return MultiTimeframeResult{
 		Signal:      finalSignal,
@@ -600,12 +663,55 @@ func (sa *SignalAggregator) applyMultiTimeframeLogic(dailySignals, eightHourSign
 	}
 }
 
-// applyFocused5MinuteLogic applies focused 5-minute trading logic for ultra-fast response
-func (sa *SignalAggregator) applyFocused5MinuteLogic(fiveMinSignals []IndicatorSignal, currentPrice float64) MultiTimeframeResult {
+// selectRegimeProfile detects the regime and returns its profile, logging profile switches
+func (sa *SignalAggregator) selectRegimeProfile(candles []Candle) (RegimeProfile, string) {
+	if !sa.config.RegimeSwitching.Enabled {
+		return RegimeProfile{}, ""
+	}
+
+	reading, ok := DetectRegime(candles, sa.config.RegimeSwitching)
+	if !ok {
+		return RegimeProfile{}, ""
+	}
+	profile := sa.config.RegimeSwitching.Profiles[reading.Regime]
+
+	sa.regimeMutex.Lock()
+	defer sa.regimeMutex.Unlock()
+	if sa.regime == nil || sa.regime.Regime != reading.Regime {
+		previous := "NONE"
+		if sa.regime != nil {
+			previous = sa.regime.Regime
+		}
+		engineLog.Info("🔀 Regime switch", "from", previous, "to", reading.Regime,
+			"efficiency", reading.EfficiencyRatio, "volatility_percent", reading.VolatilityPercent)
+		sa.regime = &RegimeStatus{ActiveFrom: sa.clock.Now()}
+	}
+	sa.regime.RegimeReading = reading
+	sa.regime.Profile = profile
+	return profile, reading.Regime
+}
+
+// GetRegimeStatus returns the active regime profile (nil when regime switching is off)
+func (sa *SignalAggregator) GetRegimeStatus() *RegimeStatus {
+	sa.regimeMutex.RLock()
+	defer sa.regimeMutex.RUnlock()
+
+	if sa.regime == nil {
+		return nil
+	}
+	status := *sa.regime
+	return &status
+}
+
+// applyFocused5MinuteLogic applies focused 5-minute trading logic for ultra-fast response.
+// Votes are weighted by the regime profile (equal weights when none is active) and
+// prior adds a seasonal vote (positive = bullish).
+func (sa *SignalAggregator) applyFocused5MinuteLogic(fiveMinSignals []IndicatorSignal, currentPrice float64, profile RegimeProfile, prior float64) MultiTimeframeResult {
 	buyCount := 0
 	sellCount := 0
 	holdCount := 0
 	totalStrength := 0.0
+	buyScore, sellScore := 0.0, 0.0
 
 	// Analyze 5-minute signals with focused weighting
 	for _, signal := range fiveMinSignals {
@@ -613,13 +719,22 @@ func (sa *SignalAggregator) applyFocused5MinuteLogic(fiveMinSignals []IndicatorS
 		switch signal.Signal {
 		case Buy:
 			buyCount++
+			buyScore += profile.weightFor(signal.Name)
 		case Sell:
 			sellCount++
+			sellScore += profile.weightFor(signal.Name)
 		case Hold:
 			holdCount++
 		}
 	}
 
+	// Seasonal prior nudges the vote towards the historical bias
+	if prior > 0 {
+		buyScore += prior
+	} else {
+		sellScore -= prior
+	}
+
 	// Calculate focused confidence
 	avgStrength := totalStrength / float64(len(fiveMinSignals))
 	var confidence float64
@@ -627,12 +742,12 @@ func (sa *SignalAggregator) applyFocused5MinuteLogic(fiveMinSignals []IndicatorS
 	var reasoning string
 
 	// Determine signal based on 5-minute consensus
-	if buyCount > sellCount {
+	if buyScore > sellScore {
 		finalSignal = Buy
 		confidence = math.Min(0.95, 0.75+(avgStrength*0.2)) // High base confidence
 		reasoning = fmt.Sprintf("5-minute BULLISH consensus: %d buy vs %d sell signals (avg strength: %.1f%%)",
 			buyCount, sellCount, avgStrength*100)
-	} else if sellCount > buyCount {
+	} else if sellScore > buyScore {
 		finalSignal = Sell
 		confidence = math.Min(0.95, 0.75+(avgStrength*0.2)) // High base confidence
 		reasoning = fmt.Sprintf("5-minute BEARISH consensus: %d sell vs %d buy signals (avg strength: %.1f%%)",
@@ -644,9 +759,15 @@ func (sa *SignalAggregator) applyFocused5MinuteLogic(fiveMinSignals []IndicatorS
 			avgStrength*100)
 	}
 
+	// Regime profiles can demand more conviction before acting
+	if finalSignal != Hold && profile.MinConfidence > 0 && confidence < profile.MinConfidence {
+		finalSignal = Hold
+		reasoning += fmt.Sprintf(" - Below regime minimum confidence %.0f%%", profile.MinConfidence*100)
+	}
+
 	// Calculate target price based on 5-minute momentum
 	var targetPrice, stopLoss float64
-	priceChange := currentPrice * 0.001 * float64(buyCount-sellCount) // 0.1% per signal difference
+	priceChange := currentPrice * 0.001 * (buyScore - sellScore) // 0.1% per (weighted) signal difference
 
 	if finalSignal == Buy {
 		targetPrice = currentPrice + math.Abs(priceChange)
@@ -677,6 +798,9 @@ type TimeframeContext struct {
 
 // getIndicatorWeight returns the performance-based weight for each indicator
 func (sa *SignalAggregator) getIndicatorWeight(indicatorName string) float64 {
+	if weight, ok := sa.weights[indicatorName]; ok {
+		return weight
+	}
 	switch {
 	// TIER 1: Elite performers (>80% accuracy) - HIGHEST WEIGHTS
 	case strings.Contains(indicatorName, "ElliottWave"):
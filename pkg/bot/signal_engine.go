@@ -3,7 +3,7 @@ package bot
 import (
 	"context"
 	"fmt"
-	"log"
+	"strings"
 	"sync"
 	"time"
 )
@@ -20,11 +20,52 @@ type SignalEngine struct {
 	running          bool
 	mutex            sync.RWMutex
 	lastSignal       *TradingSignal
+	candleStore      CandleStore // Optional candle persistence
+	sharedStore      bool        // candleStore is owned (and closed) by another engine
+	dataTiming       DataTiming  // Timing of the latest on-demand data fetch
+	timingMutex      sync.RWMutex
+
+	lastContext *ContextSnapshot // Context of the latest signal or on-demand prediction (guarded by mutex)
+
+	clock Clock // Drives signal generation, readiness timeouts and staleness checks
 }
 
 // NewSignalEngine creates a new signal engine
 func NewSignalEngine(config Config) *SignalEngine {
-	return &SignalEngine{
+	se := newSignalEngine(config)
+
+	// Persist candles unless they're synthetic
+	if config.CandleStore.Enabled && config.DataProvider != "sample" {
+		store, err := NewCandleStore(config.CandleStore)
+		if err != nil {
+			engineLog.Warn("Candle store unavailable, keeping candles in memory only", "error", err)
+		} else {
+			se.candleStore = store
+			se.timeframeManager.SetStore(store)
+		}
+	}
+	return se
+}
+
+// forSymbol creates an engine for another symbol with the same settings and its
+// own candles and indicator state, sharing this engine's candle store
+func (se *SignalEngine) forSymbol(symbol string) *SignalEngine {
+	config := se.config
+	config.Symbol = symbol
+	config.Continuous.Enabled = false // Contracts belong to the traded symbol
+	engine := newSignalEngine(config)
+	engine.clock = se.clock
+	if se.candleStore != nil {
+		engine.candleStore = se.candleStore
+		engine.sharedStore = true
+		engine.timeframeManager.SetStore(se.candleStore)
+	}
+	return engine
+}
+
+// newSignalEngine creates an engine without candle persistence
+func newSignalEngine(config Config) *SignalEngine {
+	se := &SignalEngine{
 		config:           config,
 		timeframeManager: NewTimeframeManager(config.Symbol),
 		dataProvider:     NewDataProviderManager(),
@@ -33,7 +74,14 @@ func NewSignalEngine(config Config) *SignalEngine {
 		errorChan:        make(chan error, 10),
 		stopChan:         make(chan struct{}),
 		running:          false,
+		clock:            SystemClock,
 	}
+	for _, timeframe := range multiTimeframes {
+		depth := config.History.Depth(timeframe)
+		se.dataProvider.SetHistoryDepth(timeframe, depth)
+		se.timeframeManager.SetHistoryDepth(timeframe, depth)
+	}
+	return se
 }
 
 // Start initializes and starts the signal engine
@@ -49,11 +97,17 @@ func (se *SignalEngine) Start(ctx context.Context) error {
 	if err := se.initializeDataProvider(); err != nil {
 		return fmt.Errorf("failed to initialize data provider: %w", err)
 	}
+	if err := se.validateSymbol(); err != nil {
+		return fmt.Errorf("invalid symbol: %w", err)
+	}
 
 	// Load historical data
 	if err := se.loadHistoricalData(); err != nil {
 		return fmt.Errorf("failed to load historical data: %w", err)
 	}
+	if err := se.checkIndicatorHistory(); err != nil {
+		return fmt.Errorf("insufficient history: %w", err)
+	}
 
 	// Wait for sufficient data
 	if err := se.waitForDataReady(ctx); err != nil {
@@ -64,12 +118,13 @@ func (se *SignalEngine) Start(ctx context.Context) error {
 	if err := se.startRealTimeFeeds(); err != nil {
 		return fmt.Errorf("failed to start real-time feeds: %w", err)
 	}
+	se.startFeedWatchdog()
 
 	// Start signal generation
 	se.startSignalGeneration(ctx)
 
 	se.running = true
-	log.Printf("Signal engine started for symbol: %s", se.config.Symbol)
+	engineLog.Info("Signal engine started", "symbol", se.config.Symbol)
 	return nil
 }
 
@@ -89,11 +144,25 @@ func (se *SignalEngine) Stop() error {
 	if err := se.dataProvider.Close(); err != nil {
 		return fmt.Errorf("failed to close data provider: %w", err)
 	}
+	if se.candleStore != nil && !se.sharedStore {
+		if err := se.candleStore.Close(); err != nil {
+			return fmt.Errorf("failed to close candle store: %w", err)
+		}
+	}
 
-	log.Printf("Signal engine stopped for symbol: %s", se.config.Symbol)
+	engineLog.Info("Signal engine stopped", "symbol", se.config.Symbol)
 	return nil
 }
 
+// SetClock replaces the engine's time source; call before Start
+func (se *SignalEngine) SetClock(clock Clock) {
+	se.mutex.Lock()
+	defer se.mutex.Unlock()
+	se.clock = clock
+	se.signalAggregator.SetClock(clock)
+	se.timeframeManager.SetClock(clock)
+}
+
 // GetSignalChannel returns the channel for receiving trading signals
 func (se *SignalEngine) GetSignalChannel() <-chan *TradingSignal {
 	return se.signalChan
@@ -122,8 +191,31 @@ func (se *SignalEngine) GetStatus() SignalEngineStatus {
 		DataSummary: se.timeframeManager.GetDataSummary(),
 		ReadyStatus: se.timeframeManager.GetReadyStatus(),
 		LastSignal:  se.lastSignal,
-		LastUpdate:  time.Now(),
+		LastUpdate:  se.clock.Now(),
+		Regime:      se.signalAggregator.GetRegimeStatus(),
+		Stream:      se.streamStatus(),
+
+		DegradedFeeds: se.degradedFeeds(),
+	}
+}
+
+// degradedFeeds names the timeframes whose feeds the watchdog found silent
+func (se *SignalEngine) degradedFeeds() []string {
+	var names []string
+	for _, timeframe := range se.timeframeManager.DegradedTimeframes() {
+		names = append(names, timeframe.String())
+	}
+	return names
+}
+
+// streamStatus returns the kline stream's health, or nil when not streaming
+func (se *SignalEngine) streamStatus() *StreamStatus {
+	stream := se.dataProvider.Stream()
+	if stream == nil {
+		return nil
 	}
+	status := stream.Status()
+	return &status
 }
 
 // initializeDataProvider sets up the data provider
@@ -143,49 +235,145 @@ func (se *SignalEngine) initializeDataProvider() error {
 	}
 
 	sampleProvider := NewSampleDataProvider([]string{se.config.Symbol}, basePrice)
+	sampleProvider.SetDeterminism(se.config.Determinism)
 	se.dataProvider.AddProvider("sample", sampleProvider)
 
-	// Add Binance data provider if configured
-	if se.config.DataProvider == "binance" {
-		binanceProvider := NewBinanceFuturesDataProvider(se.config.Binance.APIKey, se.config.Binance.SecretKey)
-		se.dataProvider.AddProvider("binance", binanceProvider)
+	// Add the configured exchange as primary
+	if IsExchange(se.config.DataProvider) {
+		exchange, err := NewExchange(se.config.DataProvider, se.config)
+		if err != nil {
+			return err
+		}
+		if clocked, ok := exchange.(interface{ SetClock(Clock) }); ok {
+			clocked.SetClock(se.clock)
+		}
+		se.dataProvider.AddProvider(exchange.Name(), exchange)
+
+		providerLog.Info("Using exchange API for data provider", "exchange", exchange.Name())
+		if err := se.dataProvider.SetPrimary(exchange.Name()); err != nil {
+			return err
+		}
+	} else {
+		// Default to sample provider
+		providerLog.Info("Using sample data provider for testing")
+		if err := se.dataProvider.SetPrimary("sample"); err != nil {
+			return err
+		}
+	}
+
+	if se.config.Continuous.Enabled {
+		if err := se.configureContinuousContracts(); err != nil {
+			return err
+		}
+	}
+	return se.configureTimeframeProviders()
+}
+
+// configureContinuousContracts routes every timeframe through the back-adjusted
+// series of the configured delivery contracts; providers entries still override it
+func (se *SignalEngine) configureContinuousContracts() error {
+	continuous, err := NewContinuousContractProvider(se.dataProvider.primary, se.config.Symbol, se.config.Continuous)
+	if err != nil {
+		return fmt.Errorf("invalid continuous contracts: %w", err)
+	}
+	se.dataProvider.AddProvider("continuous", continuous)
+	for _, timeframe := range []Timeframe{FiveMinute, FifteenMinute, FortyFiveMinute, EightHour, Daily} {
+		if err := se.dataProvider.SetTimeframeProviders(timeframe, "continuous", "continuous"); err != nil {
+			return err
+		}
+	}
+	providerLog.Info("🔗 Following delivery contracts", "symbol", se.config.Symbol, "contracts", len(continuous.contracts), "adjustment", continuous.adjustment)
+	return nil
+}
+
+// configureTimeframeProviders applies per-timeframe provider overrides from config
+func (se *SignalEngine) configureTimeframeProviders() error {
+	for tfName, route := range se.config.Providers {
+		timeframe, err := ParseTimeframe(tfName)
+		if err != nil {
+			return fmt.Errorf("invalid providers entry: %w", err)
+		}
+
+		for _, name := range []string{route.Historical, route.RealTime} {
+			if name == "" || se.dataProvider.HasProvider(name) {
+				continue
+			}
+			provider, err := se.newDataProvider(name)
+			if err != nil {
+				return err
+			}
+			se.dataProvider.AddProvider(name, provider)
+		}
+
+		if err := se.dataProvider.SetTimeframeProviders(timeframe, route.Historical, route.RealTime); err != nil {
+			return err
+		}
+		providerLog.Info("Using routed data providers", "timeframe", timeframe.String(),
+			"historical", valueOrDefault(route.Historical, se.config.DataProvider), "realtime", valueOrDefault(route.RealTime, se.config.DataProvider))
+	}
+
+	return nil
+}
 
-		// Set Binance as primary if configured
-		log.Printf("Using Binance Futures API for data provider")
-		return se.dataProvider.SetPrimary("binance")
+// newDataProvider creates a data provider by name
+func (se *SignalEngine) newDataProvider(name string) (DataProvider, error) {
+	switch name {
+	case "binance", "coinbase", "kraken", "bybit":
+		return NewExchange(name, se.config)
+	case "binance_rest":
+		return NewBinanceRESTPollingProvider(se.config.Binance.APIKey, se.config.Binance.SecretKey, time.Minute), nil
+	case "file":
+		if se.config.DataDir == "" {
+			return nil, fmt.Errorf("file provider requires data_dir to be set")
+		}
+		return NewFileDataProvider(se.config.DataDir), nil
+	case "store":
+		if se.candleStore == nil {
+			return nil, fmt.Errorf("store provider requires the candle store to be enabled")
+		}
+		return NewCandleStoreProvider(se.candleStore), nil
+	default:
+		return nil, fmt.Errorf("unknown data provider: %s", name)
 	}
+}
 
-	// Default to sample provider
-	log.Printf("Using sample data provider for testing")
-	return se.dataProvider.SetPrimary("sample")
+// valueOrDefault returns value, or fallback when value is empty
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
 }
 
 // loadHistoricalData loads historical market data for all timeframes
 func (se *SignalEngine) loadHistoricalData() error {
-	log.Printf("Loading historical data for %s...", se.config.Symbol)
+	providerLog.Info("Loading historical data", "symbol", se.config.Symbol)
 
+	// Resume from stored candles, fetching only the gap since the last run
+	if se.candleStore != nil {
+		return se.dataProvider.LoadHistoricalDataThroughStore(se.config.Symbol, se.timeframeManager, se.candleStore)
+	}
 	return se.dataProvider.LoadHistoricalDataForAllTimeframes(se.config.Symbol, se.timeframeManager)
 }
 
 // waitForDataReady waits until sufficient data is available
 func (se *SignalEngine) waitForDataReady(ctx context.Context) error {
-	log.Printf("Waiting for sufficient data...")
+	engineLog.Info("Waiting for sufficient data", "symbol", se.config.Symbol)
 
-	timeout := time.NewTimer(30 * time.Second)
-	defer timeout.Stop()
+	timeout := se.clock.After(30 * time.Second)
 
-	ticker := time.NewTicker(1 * time.Second)
+	ticker := se.clock.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-timeout.C:
+		case <-timeout:
 			return fmt.Errorf("timeout waiting for data")
-		case <-ticker.C:
+		case <-ticker.Chan():
 			if se.timeframeManager.IsReady() {
-				log.Printf("Data ready for all timeframes")
+				engineLog.Info("Data ready for all timeframes", "symbol", se.config.Symbol)
 				return nil
 			}
 		}
@@ -194,15 +382,39 @@ func (se *SignalEngine) waitForDataReady(ctx context.Context) error {
 
 // startRealTimeFeeds starts real-time data feeds
 func (se *SignalEngine) startRealTimeFeeds() error {
-	log.Printf("Starting real-time data feeds for %s...", se.config.Symbol)
+	providerLog.Info("Starting real-time data feeds", "symbol", se.config.Symbol)
 
+	// One WebSocket for every Binance timeframe; other venues keep per-timeframe feeds.
+	// The stream follows the perpetual, so continuous contracts keep their own feeds.
+	if se.config.Streaming.Enabled && se.config.DataProvider == "binance" && !se.config.Continuous.Enabled {
+		if err := se.dataProvider.StartKlineStream(se.config.Symbol, se.timeframeManager, se.config.Streaming); err != nil {
+			providerLog.Warn("Kline stream unavailable, using per-timeframe feeds", "symbol", se.config.Symbol, "error", err)
+		}
+	}
 	return se.dataProvider.StartRealTimeDataFeeds(se.config.Symbol, se.timeframeManager)
 }
 
+// startFeedWatchdog reconnects feeds that go silent and flags their timeframes
+// as degraded until data flows again
+func (se *SignalEngine) startFeedWatchdog() {
+	if se.config.Streaming.WatchdogSeconds <= 0 {
+		return
+	}
+	var streamed func(Timeframe) bool
+	if stream := se.dataProvider.Stream(); stream != nil {
+		streamed = stream.Covers
+	}
+	limits := FeedLimits(se.config.Streaming, streamed)
+	interval := time.Duration(se.config.Streaming.WatchdogSeconds) * time.Second
+	go se.timeframeManager.RunWatchdog(se.stopChan, interval, limits, func(timeframe Timeframe) error {
+		return se.dataProvider.ReconnectFeed(se.config.Symbol, timeframe, se.timeframeManager)
+	})
+}
+
 // startSignalGeneration starts the signal generation process
 func (se *SignalEngine) startSignalGeneration(ctx context.Context) {
 	go func() {
-		ticker := time.NewTicker(1 * time.Minute) // Generate signals every minute
+		ticker := se.clock.NewTicker(1 * time.Minute) // Generate signals every minute
 		defer ticker.Stop()
 
 		for {
@@ -211,7 +423,7 @@ func (se *SignalEngine) startSignalGeneration(ctx context.Context) {
 				return
 			case <-se.stopChan:
 				return
-			case <-ticker.C:
+			case <-ticker.Chan():
 				se.generateSignal()
 			}
 		}
@@ -223,14 +435,30 @@ func (se *SignalEngine) generateSignal() {
 	// Get multi-timeframe context
 	ctx, err := se.timeframeManager.GetMultiTimeframeContext()
 	if err != nil {
-		se.errorChan <- fmt.Errorf("failed to get multi-timeframe context: %w", err)
+		se.reportError(NewEngineError(ErrProviderDown, SeverityWarning, "timeframes",
+			fmt.Errorf("failed to get multi-timeframe context: %w", err)))
 		return
 	}
 
+	// Don't trade on candles the feed stopped updating
+	if len(ctx.FiveMinCandles) > 0 {
+		latest := ctx.FiveMinCandles[len(ctx.FiveMinCandles)-1]
+		if age := se.clock.Now().Sub(latest.Timestamp.Add(FiveMinute.Duration())); age > 2*FiveMinute.Duration() {
+			severity := SeverityWarning
+			if age > 10*FiveMinute.Duration() {
+				severity = SeverityCritical
+			}
+			se.reportError(NewEngineError(ErrDataStale, severity, "timeframes",
+				fmt.Errorf("latest 5m candle closed %s ago", age.Round(time.Second))))
+			return
+		}
+	}
+
 	// Generate signal
-	signal, err := se.signalAggregator.GenerateSignal(ctx)
+	signal, err := se.aggregator().GenerateSignal(ctx)
 	if err != nil {
-		se.errorChan <- fmt.Errorf("failed to generate signal: %w", err)
+		se.reportError(NewEngineError(ErrIndicatorError, SeverityWarning, "aggregator",
+			fmt.Errorf("failed to generate signal: %w", err)))
 		return
 	}
 
@@ -238,15 +466,25 @@ func (se *SignalEngine) generateSignal() {
 	se.mutex.Lock()
 	se.lastSignal = signal
 	se.mutex.Unlock()
+	se.recordContext(ctx, signal, "engine")
 
 	// Send signal to channel
 	select {
 	case se.signalChan <- signal:
-		log.Printf("Generated signal: %s (%.2f confidence) - %s",
-			signal.Signal.String(), signal.Confidence, signal.Reasoning)
+		engineLog.Info("Generated signal", "symbol", signal.Symbol, "signal", signal.Signal.String(),
+			"confidence", signal.Confidence, "reasoning", signal.Reasoning)
 	default:
 		// Channel is full, skip this signal
-		log.Printf("Signal channel full, skipping signal")
+		engineLog.Warn("Signal channel full, skipping signal", "symbol", se.config.Symbol)
+	}
+}
+
+// reportError sends a classified error to the error channel, dropping it if the consumer is behind
+func (se *SignalEngine) reportError(err *EngineError) {
+	select {
+	case se.errorChan <- err:
+	default:
+		engineLog.Warn("Error channel full, dropping error", "symbol", se.config.Symbol, "error", err)
 	}
 }
 
@@ -258,44 +496,222 @@ type SignalEngineStatus struct {
 	ReadyStatus map[Timeframe]bool `json:"ready_status"`
 	LastSignal  *TradingSignal     `json:"last_signal"`
 	LastUpdate  time.Time          `json:"last_update"`
+	Regime      *RegimeStatus      `json:"regime,omitempty"`      // Active regime profile
+	Stream      *StreamStatus      `json:"stream,omitempty"`      // Kline stream health when streaming
+	UserStream  *UserStreamStatus  `json:"user_stream,omitempty"` // Account event stream health when trading live
+
+	DegradedFeeds []string `json:"degraded_feeds,omitempty"` // Timeframes whose feeds went silent; their signals carry less confidence
+
+	Symbols map[string]SignalEngineStatus `json:"symbols,omitempty"` // Every configured symbol's engine, keyed by symbol
 }
 
 // TradingBot is the main trading bot that uses the signal engine
 type TradingBot struct {
-	config        Config
-	signalEngine  *SignalEngine
-	tradeExecutor *TradeExecutor // Pine Script ATR strategy trading engine
-	ctx           context.Context
-	cancel        context.CancelFunc
-	wg            sync.WaitGroup
+	config             Config
+	signalEngine       *SignalEngine
+	tradeExecutor      *TradeExecutor // Pine Script ATR strategy trading engine
+	tradeReplays       *TradeReplayRecorder
+	signalHistory      *History[*TradingSignal]   // Recent signals for /signals/history
+	predictionAccuracy *PredictionAccuracyTracker // Served predictions scored at their target time
+	strategies         *StrategyManager           // Strategy layer (rebalancing etc.) sharing tradeExecutor
+	hedging            *HedgingStrategy           // Nil unless hedging is enabled
+	outage             *OutageMonitor             // Exchange outage detection / safe mode
+	maintenance        *MaintenanceCalendar
+	priceIndex         *IndexPriceProvider
+	backtests          *BacktestStore
+	engines            map[string]*SignalEngine // Engine per configured symbol, signalEngine included
+	symbols            []string                 // Configured symbols, the traded one first
+	selected           map[string]bool          // Symbols added by the symbol selector
+	symbolsMutex       sync.RWMutex             // Guards engines, symbols and selected
+	errorLog           *ErrorLog                // Recent classified engine errors
+	auditLog           *AuditLog                // State-changing operations and who made them
+	configHistory      *ConfigHistory           // Every applied config
+	heartbeat          *HeartbeatMonitor
+	mqtt               *MQTTPublisher // Nil unless an MQTT broker is configured and reachable
+	events             *EventBus      // Signals, trades, predictions and errors for external publishers
+	exporter           *EventExporter // Nil unless event export is enabled and connected
+	seasonality        *SeasonalityStats
+	seasonalityMutex   sync.RWMutex
+	userStream         *BinanceUserStream
+	notifiers          []Notifier
+	alerts             *AlertManager // User price and indicator alerts
+	ctx                context.Context
+	cancel             context.CancelFunc
+	wg                 sync.WaitGroup
+
+	reloadMutex sync.Mutex // Serializes ApplyConfig
+	hotConfig   *Config    // Config with the hot-reloaded settings applied, nil until the first reload
 }
 
+// signalHistorySize is how many recent signals are kept for the history endpoint
+const signalHistorySize = 2000
+
 // NewTradingBot creates a new trading bot
 func NewTradingBot(config Config) *TradingBot {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Create trade executor with initial balance (default: $10,000 for testing)
-	initialBalance := 10000.0 // $10,000 demo balance
-	tradeExecutor := NewTradeExecutor(config, initialBalance)
+	tradeExecutor := NewTradeExecutor(config, config.Account.InitialBalance)
+
+	signalEngine := NewSignalEngine(config)
+	tb := &TradingBot{
+		config:             config,
+		signalEngine:       signalEngine,
+		engines:            map[string]*SignalEngine{config.Symbol: signalEngine},
+		symbols:            config.AllSymbols(),
+		tradeExecutor:      tradeExecutor,
+		tradeReplays:       NewTradeReplayRecorder(tradeReplayFile(config.TradeHistoryFile), tradeExecutor.GetTradeHistory(0)),
+		signalHistory:      NewHistory[*TradingSignal](signalHistorySize),
+		predictionAccuracy: NewPredictionAccuracyTracker(predictionAccuracySize),
+		ctx:                ctx,
+		cancel:             cancel,
+	}
+
+	for _, symbol := range tb.symbols[1:] {
+		tb.engines[symbol] = signalEngine.forSymbol(symbol)
+	}
+
+	tb.predictionAccuracy.SetSession(config.Session)
+	if config.Prediction.HistoryFile != "" {
+		tb.restorePredictions()
+	}
+	tb.strategies = NewStrategyManager(tradeExecutor, tb.GetSymbolPrice, time.Minute)
+	tb.strategies.SetSignalSource(tb.GetLastSignal)
+	if config.Rebalance.Enabled {
+		tb.strategies.Register(NewRebalancingStrategy(config.Rebalance))
+	}
+	tb.strategies.SetRegimeSource(tb.GetMarketRegime)
+	if config.Hedging.Enabled {
+		tb.hedging = NewHedgingStrategy(config.Hedging)
+		tb.strategies.Register(tb.hedging)
+	}
+	if config.Scripting.Enabled {
+		if scripted, err := NewScriptedStrategy(config.Scripting, config.Symbol); err != nil {
+			executorLog.Warn("Scripted strategy disabled", "error", err)
+		} else {
+			tb.strategies.Register(scripted)
+		}
+	}
 
-	return &TradingBot{
-		config:        config,
-		signalEngine:  NewSignalEngine(config),
-		tradeExecutor: tradeExecutor,
-		ctx:           ctx,
-		cancel:        cancel,
+	tb.priceIndex = NewIndexPriceProviderFromConfig(config.PriceIndex)
+	tb.backtests = NewBacktestStore(valueOrDefault(config.BacktestDir, "backtests"))
+	tb.notifiers = NewNotifiers(config.Notifications)
+	tb.alerts = NewAlertManager(tb.notifiers)
+	if config.AlertsFile != "" {
+		if err := tb.alerts.SetFile(config.AlertsFile); err != nil {
+			notifyLog.Warn("Failed to restore alerts", "error", err)
+		}
+	}
+	tb.outage = NewOutageMonitor(config.SafeMode)
+	tb.outage.SetHandlers(tb.enterSafeMode, tb.exitSafeMode)
+	tb.maintenance = NewMaintenanceCalendar(config.Maintenance)
+	tb.errorLog = NewErrorLog(200)
+	auditLog, err := NewAuditLog(config.AuditFile)
+	if err != nil {
+		configLog.Warn("Failed to restore audit log", "error", err)
+	}
+	tb.auditLog = auditLog
+	configHistory, err := NewConfigHistory(config.ConfigHistoryFile)
+	if err != nil {
+		configLog.Warn("Failed to restore config history", "error", err)
 	}
+	tb.configHistory = configHistory
+	tb.heartbeat = NewHeartbeatMonitor(config.Heartbeat, tb.checkHeartbeat)
+	tb.events = NewEventBus()
+	tradeExecutor.SetTradeObserver(func(trade *Trade) {
+		tb.events.Publish(EventTrade, config.Symbol, trade)
+		tb.tradeReplays.Capture(trade, tb.replayCandles())
+	})
+	tradeExecutor.SetErrorReporter(tb.recordEngineError)
+	tradeExecutor.SetMaintenanceCalendar(tb.maintenance)
+
+	return tb
 }
 
 // Start starts the trading bot
 func (tb *TradingBot) Start() error {
-	log.Printf("Starting trading bot for symbol: %s", tb.config.Symbol)
+	engineLog.Info("Starting trading bot", "symbol", tb.config.Symbol)
+	tb.RecordConfigVersion(tb.config, APIUser{Name: "startup"})
 
 	// Start signal engine
 	if err := tb.signalEngine.Start(tb.ctx); err != nil {
 		return fmt.Errorf("failed to start signal engine: %w", err)
 	}
 
+	// Add the exchange's top pairs before the other symbols start
+	if tb.config.SymbolSelector.Enabled {
+		tb.refreshSymbolSelection()
+	}
+
+	// Other symbols get their own engines; one failing to start doesn't stop the rest
+	for _, engine := range tb.symbolEngines()[1:] {
+		tb.wg.Add(1)
+		go tb.runSymbolEngine(engine)
+	}
+	if tb.config.SymbolSelector.Enabled {
+		tb.startSymbolSelection(tb.ctx)
+	}
+
+	// Load exchange lot/tick/notional rules and conversion rates
+	tb.loadExchangeMetadata()
+	tb.startLiveTrading()
+
+	// Start strategy layer
+	if len(tb.strategies.GetStrategies()) > 0 {
+		tb.strategies.Start(tb.ctx)
+	}
+
+	// Keep the seasonal prior fresh
+	if tb.config.Seasonality.PriorEnabled {
+		tb.startSeasonalityRefresh(tb.ctx)
+	}
+
+	// Connect to the MQTT broker
+	if tb.config.MQTT.Broker != "" {
+		publisher, err := NewMQTTPublisher(tb.config.MQTT)
+		if err != nil {
+			notifyLog.Warn("MQTT disabled", "error", err)
+		} else {
+			tb.mqtt = publisher
+			tb.mqtt.StartEventPublishing(tb.ctx, tb.events.Subscribe("mqtt", 100))
+		}
+	}
+
+	// Stream events to Kafka/NATS
+	if tb.config.EventExport.Enabled {
+		sink, err := NewEventSink(tb.config.EventExport)
+		if err != nil {
+			notifyLog.Warn("Event export disabled", "error", err)
+		} else {
+			tb.exporter = NewEventExporter(tb.config.EventExport, sink)
+			tb.exporter.Start(tb.ctx, tb.events.Subscribe("export", 1000))
+			notifyLog.Info("📤 Exporting events", "backend", tb.config.EventExport.Backend, "brokers", strings.Join(tb.config.EventExport.Brokers, ", "))
+		}
+	}
+
+	// Post signals and trades to Telegram and answer its commands
+	if tb.config.Notifications.Telegram.Enabled {
+		NewTelegramBot(tb.config.Notifications.Telegram, tb).Start(tb.ctx, tb.events.Subscribe("telegram", 100))
+		notifyLog.Info("💬 Telegram notifications enabled", "chat_id", tb.config.Notifications.Telegram.ChatID)
+	}
+
+	// Dead-man's switch: pings stop when feeds or signals stall
+	if tb.config.Heartbeat.Enabled {
+		if tb.config.Heartbeat.URL != "" {
+			tb.heartbeat.AddSender("http", HTTPHeartbeatSender(tb.config.Heartbeat.URL))
+		}
+		if tb.config.Heartbeat.MQTTTopic != "" && tb.mqtt != nil {
+			tb.heartbeat.AddSender("mqtt", MQTTHeartbeatSender(tb.mqtt, tb.config.Heartbeat.MQTTTopic))
+		}
+		tb.heartbeat.Start(tb.ctx)
+	}
+
+	// Schedule nightly validation backtests
+	if tb.config.NightlyBacktest.Enabled {
+		runBacktest := func(days int) (*BacktestResult, error) { return tb.RunBacktest(days, nil, nil) }
+		NewNightlyBacktestScheduler(tb.config.NightlyBacktest, runBacktest, tb.notifiers).Start(tb.ctx)
+	}
+
 	// Start signal handler
 	tb.wg.Add(1)
 	go tb.handleSignals()
@@ -307,13 +723,115 @@ func (tb *TradingBot) Start() error {
 	return nil
 }
 
+// loadExchangeMetadata fetches exchange symbol filters, wires ticker-based
+// currency conversion and starts account reconciliation when trading against Binance
+func (tb *TradingBot) loadExchangeMetadata() {
+	binanceProvider, ok := tb.signalEngine.dataProvider.primary.(*BinanceFuturesDataProvider)
+	if !ok {
+		// Warns once if reporting PnL needs a rate no provider can give
+		tb.tradeExecutor.refreshRates()
+		return
+	}
+
+	tb.tradeExecutor.SetRateProvider(BinanceRateProvider(binanceProvider))
+	tb.startRateRefresh(tb.ctx)
+	if tb.config.Funding.Enabled {
+		tb.startFundingRefresh(tb.ctx, binanceProvider)
+	}
+
+	// Live trading reconciles through its order client (see startLiveTrading)
+	if tb.config.Reconciliation.Enabled && (!tb.config.LiveTrading.Enabled || tb.config.LiveTrading.DryRun) {
+		if apiKey, _ := binanceProvider.Credentials(); apiKey == "" {
+			executorLog.Warn("Reconciliation disabled: Binance API keys not configured")
+		} else {
+			tb.startReconciliation(tb.ctx, binanceProvider)
+		}
+	}
+
+	for _, engine := range tb.symbolEngines() {
+		tb.loadSymbolFilters(binanceProvider, engine)
+	}
+}
+
+// loadSymbolFilters applies a symbol's exchange filters to its engine, and to the
+// executor for the traded symbol
+func (tb *TradingBot) loadSymbolFilters(binanceProvider *BinanceFuturesDataProvider, engine *SignalEngine) {
+	symbol := engine.config.Symbol
+	filters, err := binanceProvider.GetSymbolFilters(symbol)
+	if err != nil {
+		executorLog.Warn("Failed to load symbol filters, using defaults", "symbol", symbol, "error", err)
+		return
+	}
+	if symbol == tb.config.Symbol {
+		tb.tradeExecutor.SetSymbolFilters(filters)
+	}
+	engine.aggregator().SetSymbolFilters(filters)
+}
+
+// startLiveTrading routes the executor's orders to Binance, or logs them in
+// dry-run mode. Live orders are reconciled so working orders pick up fills.
+func (tb *TradingBot) startLiveTrading() {
+	live := tb.config.LiveTrading
+	if !live.Enabled {
+		return
+	}
+	if live.DryRun {
+		tb.tradeExecutor.SetOrderPlacer(NewDryRunOrderPlacer(), live)
+		executorLog.Info("🧪 Live trading dry run: orders are logged and filled locally", "order_type", live.OrderType)
+		return
+	}
+
+	binanceProvider, ok := tb.signalEngine.dataProvider.primary.(*BinanceFuturesDataProvider)
+	if !ok {
+		executorLog.Warn("Live trading disabled: requires the Binance data provider")
+		return
+	}
+	if apiKey, _ := binanceProvider.Credentials(); apiKey == "" {
+		executorLog.Warn("Live trading disabled: Binance API keys not configured")
+		return
+	}
+	client := NewBinanceOrderClient(binanceProvider, live.Market)
+	tb.tradeExecutor.SetOrderPlacer(client, live)
+	tb.startReconciliation(tb.ctx, client)
+	if live.UserStream {
+		tb.userStream = NewBinanceUserStream(client, tb.tradeExecutor)
+		tb.userStream.OnReconnect(func() { tb.reconcile(client) })
+		tb.userStream.Start()
+	}
+	executorLog.Warn("💸 LIVE TRADING ENABLED: orders are sent to Binance", "order_type", live.OrderType, "symbol", tb.config.Symbol, "market", live.Market)
+}
+
+// SetClock drives every engine (signals, feed updates and the watchdog), the
+// trade executor, its risk manager and the strategy loop from clock, e.g. a
+// SimClock in tests; call before Start
+func (tb *TradingBot) SetClock(clock Clock) {
+	tb.symbolsMutex.RLock()
+	for _, engine := range tb.engines {
+		engine.SetClock(clock)
+	}
+	tb.symbolsMutex.RUnlock()
+	tb.signalEngine.SetClock(clock)
+	tb.tradeExecutor.SetClock(clock)
+}
+
 // Stop stops the trading bot
 func (tb *TradingBot) Stop() error {
-	log.Printf("Stopping trading bot...")
+	engineLog.Info("Stopping trading bot")
 
 	// Cancel context
 	tb.cancel()
 
+	if tb.userStream != nil {
+		tb.userStream.Stop()
+	}
+
+	// Stop the other symbols' engines before the one owning the candle store
+	for _, engine := range tb.symbolEngines()[1:] {
+		if err := engine.Stop(); err != nil {
+			engineLog.Warn("Failed to stop signal engine", "symbol", engine.config.Symbol, "error", err)
+		}
+	}
+
 	// Stop signal engine
 	if err := tb.signalEngine.Stop(); err != nil {
 		return fmt.Errorf("failed to stop signal engine: %w", err)
@@ -322,13 +840,70 @@ func (tb *TradingBot) Stop() error {
 	// Wait for goroutines to finish
 	tb.wg.Wait()
 
-	log.Printf("Trading bot stopped")
+	if tb.mqtt != nil {
+		tb.mqtt.Close()
+	}
+
+	engineLog.Info("Trading bot stopped")
 	return nil
 }
 
-// GetStatus returns the current status
+// GetStatus returns the traded symbol's status with a section per configured symbol
 func (tb *TradingBot) GetStatus() SignalEngineStatus {
-	return tb.signalEngine.GetStatus()
+	status := tb.signalEngine.GetStatus()
+	if tb.userStream != nil {
+		userStream := tb.userStream.Status()
+		status.UserStream = &userStream
+	}
+	engines := tb.symbolEngines()
+	status.Symbols = make(map[string]SignalEngineStatus, len(engines))
+	for _, engine := range engines {
+		if engine == tb.signalEngine {
+			primary := status
+			primary.Symbols = nil
+			status.Symbols[tb.config.Symbol] = primary
+			continue
+		}
+		status.Symbols[engine.config.Symbol] = engine.GetStatus()
+	}
+	return status
+}
+
+// Symbols returns the configured symbols, the traded one first
+func (tb *TradingBot) Symbols() []string {
+	tb.symbolsMutex.RLock()
+	defer tb.symbolsMutex.RUnlock()
+	return append([]string(nil), tb.symbols...)
+}
+
+// symbolEngines returns the engine of each configured symbol, the traded one first
+func (tb *TradingBot) symbolEngines() []*SignalEngine {
+	tb.symbolsMutex.RLock()
+	defer tb.symbolsMutex.RUnlock()
+	engines := make([]*SignalEngine, len(tb.symbols))
+	for i, symbol := range tb.symbols {
+		engines[i] = tb.engines[symbol]
+	}
+	return engines
+}
+
+// lookupEngine returns the engine of a configured symbol
+func (tb *TradingBot) lookupEngine(symbol string) (*SignalEngine, bool) {
+	tb.symbolsMutex.RLock()
+	defer tb.symbolsMutex.RUnlock()
+	engine, ok := tb.engines[symbol]
+	return engine, ok
+}
+
+// engineFor returns the signal engine of a configured symbol ("" is the traded one)
+func (tb *TradingBot) engineFor(symbol string) (*SignalEngine, error) {
+	if symbol == "" {
+		return tb.signalEngine, nil
+	}
+	if engine, ok := tb.lookupEngine(symbol); ok {
+		return engine, nil
+	}
+	return nil, fmt.Errorf("symbol %s is not configured", symbol)
 }
 
 // GetLastSignal returns the most recent trading signal
@@ -341,21 +916,274 @@ func (tb *TradingBot) GetCurrentPrice() (float64, error) {
 	if tb.signalEngine == nil {
 		return 0, fmt.Errorf("signal engine not initialized")
 	}
+	return tb.enginePrice(tb.signalEngine)
+}
+
+// enginePrice returns the real-time price of an engine's symbol
+func (tb *TradingBot) enginePrice(se *SignalEngine) (float64, error) {
+	symbol := se.config.Symbol
+
+	// Multi-venue median index
+	if tb.config.PriceSourceFor(symbol) == PriceSourceIndex {
+		index, err := tb.priceIndex.GetIndexPrice(symbol)
+		if err == nil {
+			return index.Price, nil
+		}
+		providerLog.Warn("Failed to get index price, falling back to last price", "error", err)
+	}
+
+	// A healthy kline stream already carries the last price
+	if stream := se.dataProvider.Stream(); stream != nil && tb.config.PriceSourceFor(symbol) == PriceSourceLast {
+		if price, ok := stream.LastPrice(); ok {
+			return price, nil
+		}
+	}
 
-	// Try to get real-time price from Binance provider
-	if tb.config.DataProvider == "binance" && tb.signalEngine.dataProvider.primary != nil {
-		if binanceProvider, ok := tb.signalEngine.dataProvider.primary.(*BinanceFuturesDataProvider); ok {
-			if price, err := binanceProvider.GetCurrentPrice(tb.config.Symbol); err == nil {
+	// Try to get real-time price (last, mark or mid per config) from Binance provider
+	if tb.config.DataProvider == "binance" && se.dataProvider.primary != nil {
+		if binanceProvider, ok := se.dataProvider.primary.(*BinanceFuturesDataProvider); ok {
+			source := tb.config.PriceSourceFor(symbol)
+			if price, err := binanceProvider.GetPrice(symbol, source); err == nil {
 				return price, nil
+			} else if source != PriceSourceLast {
+				providerLog.Warn("Failed to get price, falling back to candles", "source", source, "error", err)
 			}
 		}
+	} else if exchange, ok := se.dataProvider.primary.(Exchange); ok {
+		// Other venues serve the last trade from their ticker
+		if ticker, err := exchange.GetTicker(symbol); err == nil {
+			return ticker.Last, nil
+		}
 	}
 
 	// Fallback to latest candle data if real-time price unavailable
-	if tb.signalEngine.timeframeManager == nil {
+	if se.timeframeManager == nil {
 		return 0, fmt.Errorf("timeframe manager not initialized")
 	}
-	return tb.signalEngine.timeframeManager.GetCurrentPrice()
+	return se.timeframeManager.GetCurrentPrice()
+}
+
+// GetSymbolFilters returns the tick/lot rules of the configured symbol
+func (tb *TradingBot) GetSymbolFilters() *SymbolFilters {
+	return tb.tradeExecutor.GetSymbolFilters()
+}
+
+// GetSymbolFiltersFor returns the tick/lot rules of any configured symbol
+func (tb *TradingBot) GetSymbolFiltersFor(symbol string) *SymbolFilters {
+	if symbol == tb.config.Symbol {
+		return tb.GetSymbolFilters()
+	}
+	if engine, ok := tb.lookupEngine(symbol); ok {
+		return engine.aggregator().SymbolFilters()
+	}
+	return SymbolFiltersFor(tb.config, symbol)
+}
+
+// GetSymbolPrice returns the latest price for any symbol; symbols without an
+// engine require the Binance data provider
+func (tb *TradingBot) GetSymbolPrice(symbol string) (float64, error) {
+	if symbol == tb.config.Symbol {
+		return tb.GetCurrentPrice()
+	}
+	if engine, ok := tb.lookupEngine(symbol); ok {
+		return tb.enginePrice(engine)
+	}
+	if tb.config.PriceSourceFor(symbol) == PriceSourceIndex {
+		index, err := tb.priceIndex.GetIndexPrice(symbol)
+		if err != nil {
+			return 0, err
+		}
+		return index.Price, nil
+	}
+
+	if binanceProvider, ok := tb.signalEngine.dataProvider.primary.(*BinanceFuturesDataProvider); ok {
+		return binanceProvider.GetPrice(symbol, tb.config.PriceSourceFor(symbol))
+	}
+	return 0, fmt.Errorf("no price source for %s", symbol)
+}
+
+// GetIndexPrice returns the multi-venue index price with its components
+func (tb *TradingBot) GetIndexPrice(symbol string) (*IndexPrice, error) {
+	return tb.priceIndex.GetIndexPrice(symbol)
+}
+
+// RunBacktest replays the current config over the last days of historical data
+// and saves the result and HTML report. Nil costs keep the configured ones.
+func (tb *TradingBot) RunBacktest(days int, feePercent, slippageBps *float64) (*BacktestResult, error) {
+	candles, start, end, err := tb.LoadBacktestCandles(days)
+	if err != nil {
+		return nil, err
+	}
+
+	config := tb.config
+	if feePercent != nil {
+		config.Backtest.FeePercent = *feePercent
+		config.Fees.Enabled = false // An explicit fee overrides the account's tier
+	}
+	if slippageBps != nil {
+		config.Backtest.SlippageBps = *slippageBps
+	}
+	result, err := NewBacktester(config, config.Account.InitialBalance).Run(candles, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if err := tb.backtests.Save(result); err != nil {
+		return nil, fmt.Errorf("failed to save backtest: %w", err)
+	}
+	return result, nil
+}
+
+// LoadBacktestCandles fetches the last N days of history plus each timeframe's lookback
+func (tb *TradingBot) LoadBacktestCandles(days int) (map[Timeframe][]Candle, time.Time, time.Time, error) {
+	if days <= 0 {
+		return nil, time.Time{}, time.Time{}, fmt.Errorf("days must be positive")
+	}
+
+	if tb.signalEngine.dataProvider.primary == nil {
+		if err := tb.signalEngine.initializeDataProvider(); err != nil {
+			return nil, time.Time{}, time.Time{}, fmt.Errorf("failed to initialize data provider: %w", err)
+		}
+	}
+
+	end := tb.config.Determinism.WallClock()().Truncate(FiveMinute.Duration())
+	start := end.Add(-time.Duration(days) * 24 * time.Hour)
+
+	// Each timeframe also needs its analysis lookback before the window starts.
+	// A fixed order keeps seeded sample data reproducible.
+	candles := make(map[Timeframe][]Candle)
+	for _, timeframe := range []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute} {
+		lookback := backtestLookbacks[timeframe]
+		from := start.Add(-time.Duration(lookback) * timeframe.Duration())
+		fetch := func(from, end time.Time) ([]Candle, error) {
+			return tb.signalEngine.dataProvider.GetHistoricalRange(tb.config.Symbol, timeframe, from, end)
+		}
+		var data []Candle
+		var err error
+		if store := tb.signalEngine.candleStore; store != nil {
+			data, err = loadRangeThroughStore(store, tb.config.Symbol, timeframe, from, end, fetch)
+		} else {
+			data, err = fetch(from, end)
+		}
+		if err != nil {
+			return nil, time.Time{}, time.Time{}, fmt.Errorf("failed to load %s data: %w", timeframe.String(), err)
+		}
+		candles[timeframe] = data
+	}
+	return candles, start, end, nil
+}
+
+// GetBacktest returns a saved backtest result
+func (tb *TradingBot) GetBacktest(id string) (*BacktestResult, error) {
+	return tb.backtests.Load(id)
+}
+
+// GetBacktestReportPath returns the HTML report path for a saved backtest
+func (tb *TradingBot) GetBacktestReportPath(id string) (string, error) {
+	return tb.backtests.ReportPath(id)
+}
+
+// GetStrategyManager returns the strategy layer
+func (tb *TradingBot) GetStrategyManager() *StrategyManager {
+	return tb.strategies
+}
+
+// GetMarketRegime returns the daily trend used as the market regime for strategies
+func (tb *TradingBot) GetMarketRegime() string {
+	ctx, err := tb.signalEngine.timeframeManager.GetMultiTimeframeContext()
+	if err != nil {
+		return "UNKNOWN"
+	}
+	return ctx.GetDailyTrend()
+}
+
+// HedgeStatus reports open hedges and recent hedging rule decisions
+type HedgeStatus struct {
+	Enabled bool              `json:"enabled"`
+	Regime  string            `json:"regime"`
+	Hedges  []HedgePosition   `json:"hedges"`
+	Audit   []HedgeAuditEntry `json:"audit,omitempty"`
+}
+
+// GetHedgeStatus returns open hedges, current exposure and recent hedge audit entries
+func (tb *TradingBot) GetHedgeStatus(limit int) HedgeStatus {
+	status := HedgeStatus{
+		Enabled: tb.hedging != nil,
+		Regime:  tb.GetMarketRegime(),
+		Hedges:  tb.tradeExecutor.GetHedgePositions(),
+	}
+	if tb.hedging != nil {
+		status.Audit = tb.hedging.GetAuditLog(limit)
+	}
+	return status
+}
+
+// GetRiskReport returns the account's exposure, raw and in beta terms against the
+// configured benchmark. Symbols that can't be priced fall back to their last known price.
+func (tb *TradingBot) GetRiskReport() RiskReport {
+	return tb.tradeExecutor.GetRiskReport(tb.exposurePrices(), tb.config.Correlation.BetaBenchmark)
+}
+
+// RunScenarios applies instantaneous price shocks to the open book (nil shocks use
+// DefaultScenarioShocks), optionally scaling each symbol's move by its beta to the benchmark
+func (tb *TradingBot) RunScenarios(shocks []float64, betaScaled bool) (ScenarioReport, error) {
+	if len(shocks) == 0 {
+		shocks = DefaultScenarioShocks
+	}
+	if err := ValidateScenarioShocks(shocks); err != nil {
+		return ScenarioReport{}, err
+	}
+	return tb.tradeExecutor.RunScenarios(tb.exposurePrices(), shocks, tb.config.Correlation.BetaBenchmark, betaScaled), nil
+}
+
+// exposurePrices prices every symbol the account is exposed to; symbols that
+// can't be priced are left out for the executor to fall back on
+func (tb *TradingBot) exposurePrices() map[string]float64 {
+	prices := make(map[string]float64)
+	for _, symbol := range tb.tradeExecutor.GetExposureSymbols() {
+		if price, err := tb.GetSymbolPrice(symbol); err == nil {
+			prices[symbol] = price
+		}
+	}
+	return prices
+}
+
+// enterSafeMode cancels working orders, optionally flattens, blocks new entries and alerts
+func (tb *TradingBot) enterSafeMode(reason string) (int, bool) {
+	tb.tradeExecutor.SetSafeMode(true)
+	cancelled := tb.tradeExecutor.CancelOpenOrders()
+
+	flattened := false
+	if tb.config.SafeMode.FlattenPositions {
+		if err := tb.tradeExecutor.FlattenPosition("SAFE_MODE"); err != nil {
+			executorLog.Error("Failed to flatten position in safe mode", "error", err)
+		} else {
+			flattened = true
+		}
+	}
+
+	notifyAll(tb.notifiers, "CRITICAL", "Safe mode entered",
+		fmt.Sprintf("%s: %s (cancelled %d orders, flattened: %t)", tb.config.Symbol, reason, cancelled, flattened))
+	return cancelled, flattened
+}
+
+// exitSafeMode re-allows new entries and alerts
+func (tb *TradingBot) exitSafeMode(reason string) {
+	tb.tradeExecutor.SetSafeMode(false)
+	notifyAll(tb.notifiers, "INFO", "Safe mode exited", fmt.Sprintf("%s: %s", tb.config.Symbol, reason))
+}
+
+// GetSafeModeStatus returns outage detection state
+func (tb *TradingBot) GetSafeModeStatus() SafeModeStatus {
+	return tb.outage.GetStatus()
+}
+
+// ExitSafeMode manually resumes new entries after an outage
+func (tb *TradingBot) ExitSafeMode() error {
+	if !tb.outage.IsActive() {
+		return fmt.Errorf("safe mode is not active")
+	}
+	tb.outage.Exit("manual exit")
+	return nil
 }
 
 // EnsureDataAvailable ensures all required timeframes have sufficient data, fetching on-demand if needed
@@ -377,7 +1205,7 @@ func (tb *TradingBot) EnsureDataAvailable() error {
 	}
 
 	// Load historical data for all timeframes
-	log.Printf("Fetching historical data on-demand for %s...", tb.config.Symbol)
+	providerLog.Info("Fetching historical data on demand", "symbol", tb.config.Symbol)
 	if err := tb.signalEngine.dataProvider.LoadHistoricalDataForAllTimeframes(tb.config.Symbol, tb.signalEngine.timeframeManager); err != nil {
 		return fmt.Errorf("failed to load historical data: %w", err)
 	}
@@ -390,54 +1218,131 @@ func (tb *TradingBot) ForceFreshDataUpdate() error {
 	if tb.signalEngine == nil {
 		return fmt.Errorf("signal engine not initialized")
 	}
+	return tb.signalEngine.forceFreshData()
+}
 
+// forceFreshData refetches every timeframe of the engine's symbol
+func (se *SignalEngine) forceFreshData() error {
 	// Initialize data provider if not already done
-	if tb.signalEngine.dataProvider.primary == nil {
-		if err := tb.signalEngine.initializeDataProvider(); err != nil {
+	if se.dataProvider.primary == nil {
+		if err := se.initializeDataProvider(); err != nil {
 			return fmt.Errorf("failed to initialize data provider: %w", err)
 		}
 	}
 
 	// FORCE fresh data fetch from Binance (bypass cache)
-	log.Printf("🔄 FORCING fresh Binance data update for %s...", tb.config.Symbol)
-	if err := tb.signalEngine.dataProvider.LoadHistoricalDataForAllTimeframes(tb.config.Symbol, tb.signalEngine.timeframeManager); err != nil {
+	providerLog.Info("🔄 Forcing fresh Binance data update", "symbol", se.config.Symbol)
+	fetchStarted := time.Now()
+	if err := se.dataProvider.LoadHistoricalDataForAllTimeframes(se.config.Symbol, se.timeframeManager); err != nil {
 		return fmt.Errorf("failed to fetch fresh Binance data: %w", err)
 	}
+	se.recordDataTiming(fetchStarted, time.Now())
 
 	// Validate we have sufficient data after update
-	if !tb.signalEngine.timeframeManager.IsReady() {
+	if !se.timeframeManager.IsReady() {
 		return fmt.Errorf("insufficient data after fresh fetch")
 	}
 
-	log.Printf("✅ Fresh Binance data loaded successfully")
+	providerLog.Info("✅ Fresh Binance data loaded", "symbol", se.config.Symbol)
 	return nil
 }
 
+// RefreshData re-fetches every timeframe and replaces the stored candles, returning candle counts per timeframe
+func (tb *TradingBot) RefreshData() (map[string]int, error) {
+	if tb.signalEngine == nil {
+		return nil, fmt.Errorf("signal engine not initialized")
+	}
+
+	if tb.signalEngine.dataProvider.primary == nil {
+		if err := tb.signalEngine.initializeDataProvider(); err != nil {
+			return nil, fmt.Errorf("failed to initialize data provider: %w", err)
+		}
+	}
+
+	providerLog.Info("🔄 Refreshing historical data (admin request)", "symbol", tb.config.Symbol)
+	fetchStarted := time.Now()
+	counts, err := tb.signalEngine.dataProvider.RefreshHistoricalDataForAllTimeframes(tb.config.Symbol, tb.signalEngine.timeframeManager)
+	if err != nil {
+		return nil, err
+	}
+	tb.signalEngine.recordDataTiming(fetchStarted, time.Now())
+
+	summary := make(map[string]int, len(counts))
+	for timeframe, count := range counts {
+		summary[timeframe.String()] = count
+	}
+	providerLog.Info("✅ Historical data refreshed", "symbol", tb.config.Symbol, "candles", summary)
+	return summary, nil
+}
+
+// recordDataTiming stores fetch latency and the latest 5-minute candle times
+func (se *SignalEngine) recordDataTiming(fetchStarted, fetchCompleted time.Time) {
+	var latest Candle
+	if candles, err := se.timeframeManager.GetLatestCandles(FiveMinute, 1); err == nil && len(candles) > 0 {
+		latest = candles[0]
+	}
+
+	timing := NewDataTiming(fetchStarted, fetchCompleted, latest, FiveMinute)
+	se.timingMutex.Lock()
+	se.dataTiming = timing
+	se.timingMutex.Unlock()
+
+	providerLog.Debug("⏱️  Data fetch finished", "latency", timing.FetchLatency.Round(time.Millisecond),
+		"candle_open", timing.CandleOpenTime, "data_as_of", timing.DataTimestamp)
+}
+
+// GetDataTiming returns timing of the latest on-demand data fetch
+func (tb *TradingBot) GetDataTiming() DataTiming {
+	return tb.GetSymbolDataTiming(tb.config.Symbol)
+}
+
+// GetSymbolDataTiming returns timing of the latest on-demand data fetch for a configured symbol
+func (tb *TradingBot) GetSymbolDataTiming(symbol string) DataTiming {
+	engine, err := tb.engineFor(symbol)
+	if err != nil {
+		return DataTiming{}
+	}
+	engine.timingMutex.RLock()
+	defer engine.timingMutex.RUnlock()
+	return engine.dataTiming
+}
+
 // GenerateImmediatePrediction generates a trading signal immediately using available or freshly fetched data
 func (tb *TradingBot) GenerateImmediatePrediction() (*TradingSignal, error) {
 	if tb.signalEngine == nil {
 		return nil, fmt.Errorf("signal engine not initialized")
 	}
+	return tb.GenerateSymbolPrediction(tb.config.Symbol)
+}
+
+// GenerateSymbolPrediction generates a signal for any configured symbol from its own engine
+func (tb *TradingBot) GenerateSymbolPrediction(symbol string) (*TradingSignal, error) {
+	engine, err := tb.engineFor(symbol)
+	if err != nil {
+		return nil, err
+	}
 
-	// 🔥 FORCE fresh Binance data update for each prediction call
-	if err := tb.ForceFreshDataUpdate(); err != nil {
+	// A healthy kline stream keeps candles current; otherwise refetch over REST
+	if stream := engine.dataProvider.Stream(); stream != nil && stream.Healthy() && engine.timeframeManager.IsReady() {
+		providerLog.Info("📡 Using streamed candles", "symbol", engine.config.Symbol)
+	} else if err := engine.forceFreshData(); err != nil {
 		return nil, fmt.Errorf("failed to fetch fresh Binance data: %w", err)
 	}
 
 	// Generate signal SYNCHRONOUSLY for API (not the async version used by real-time engine)
-	ctx, err := tb.signalEngine.timeframeManager.GetMultiTimeframeContext()
+	ctx, err := engine.timeframeManager.GetMultiTimeframeContext()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get multi-timeframe context: %w", err)
 	}
 
 	// Generate fresh signal directly using signal aggregator with fresh data
-	signal, err := tb.signalEngine.signalAggregator.GenerateSignal(ctx)
+	signal, err := engine.aggregator().GenerateSignal(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate fresh signal: %w", err)
 	}
+	engine.recordContext(ctx, signal, "prediction")
 
-	log.Printf("🎯 Generated fresh prediction with latest Binance data - Signal: %s, Confidence: %.1f%%",
-		signal.Signal.String(), signal.Confidence*100)
+	engineLog.Info("🎯 Generated fresh prediction", "symbol", engine.config.Symbol, "signal", signal.Signal.String(), "confidence", signal.Confidence)
 
 	return signal, nil
 }
@@ -465,90 +1370,205 @@ func (tb *TradingBot) handleErrors() {
 		case <-tb.ctx.Done():
 			return
 		case err := <-tb.signalEngine.GetErrorChannel():
-			log.Printf("Signal engine error: %v", err)
+			tb.recordEngineError(ClassifyError(err))
+		}
+	}
+}
+
+// runSymbolEngine starts the engine of a symbol that is analyzed but not traded
+// and records its signals and errors. Its feed failures are logged without
+// feeding outage detection, which guards the traded symbol.
+func (tb *TradingBot) runSymbolEngine(engine *SignalEngine) {
+	defer tb.wg.Done()
+
+	symbol := engine.config.Symbol
+	if err := engine.Start(tb.ctx); err != nil {
+		if tb.ctx.Err() == nil {
+			engineLog.Error("Failed to start signal engine", "symbol", symbol, "error", err)
+			tb.errorLog.Record(NewEngineError(ErrProviderDown, SeverityWarning, "engine", fmt.Errorf("%s: %w", symbol, err)))
+		}
+		return
+	}
+
+	for {
+		select {
+		case <-tb.ctx.Done():
+			return
+		case <-engine.stopChan: // Dropped by the symbol selector
+			return
+		case signal := <-engine.GetSignalChannel():
+			tb.signalHistory.Add(signal)
+			tb.events.Publish(EventSignal, signal.Symbol, signal)
+			tb.checkAlerts(signal)
+			tb.tradeExecutor.RecordPrice(signal.Symbol, signal.Timestamp, signal.Price)
+			engineLog.Info("📊 Signal (not traded)", "symbol", signal.Symbol, "signal", signal.Signal.String(), "confidence", signal.Confidence)
+		case err := <-engine.GetErrorChannel():
+			classified := ClassifyError(err)
+			record := tb.errorLog.Record(classified)
+			tb.events.Publish(EventError, symbol, record)
+			engineLog.Log(tb.ctx, severityLevel(classified.Severity), "Engine error", "symbol", symbol, "kind", classified.Kind, "error", classified.Err)
 		}
 	}
 }
 
+// recordFailure feeds outage detection, except during scheduled maintenance
+// when exchange errors are expected
+func (tb *TradingBot) recordFailure(source string, err error) {
+	if window, active := tb.maintenance.Active(tb.signalEngine.clock.Now()); active {
+		engineLog.Info("🔧 Ignoring failure during maintenance", "source", source, "maintenance", window.Name, "error", err)
+		return
+	}
+	tb.outage.RecordFailure(source, err)
+}
+
+// recordEngineError logs a classified error, feeds provider/data failures to outage
+// detection and notifies on critical errors (at most once per kind every 15 minutes)
+func (tb *TradingBot) recordEngineError(err *EngineError) {
+	record := tb.errorLog.Record(err)
+	tb.events.Publish(EventError, tb.config.Symbol, record)
+	engineLog.Log(tb.ctx, severityLevel(err.Severity), "Engine error", "symbol", tb.config.Symbol, "kind", err.Kind, "error", err.Err)
+
+	switch err.Kind {
+	case ErrProviderDown, ErrDataStale:
+		tb.recordFailure("data", err)
+	}
+
+	if err.Severity == SeverityCritical && tb.errorLog.ShouldAlert(err.Kind, err.Time, 15*time.Minute) {
+		notifyAll(tb.notifiers, SeverityCritical, string(err.Kind), fmt.Sprintf("%s: %v", tb.config.Symbol, err.Err))
+	}
+}
+
+// GetRecentErrors returns recent engine errors, newest first, optionally filtered
+func (tb *TradingBot) GetRecentErrors(limit int, kind ErrorKind, severity string) []ErrorRecord {
+	return tb.errorLog.Recent(limit, kind, severity)
+}
+
+// GetErrorStats returns engine error counts since startup
+func (tb *TradingBot) GetErrorStats() ErrorStats {
+	return tb.errorLog.Stats()
+}
+
+// GetMaintenanceStatus returns current and upcoming exchange maintenance
+func (tb *TradingBot) GetMaintenanceStatus() MaintenanceStatus {
+	return tb.maintenance.Status(tb.signalEngine.clock.Now())
+}
+
+// GetEventExportStatus returns Kafka/NATS exporter progress
+func (tb *TradingBot) GetEventExportStatus() EventExportStatus {
+	if tb.exporter == nil {
+		return EventExportStatus{Enabled: false}
+	}
+	return tb.exporter.GetStatus()
+}
+
+// SubscribeEvents returns a channel of bus events; release it with UnsubscribeEvents
+func (tb *TradingBot) SubscribeEvents(name string, buffer int) <-chan Event {
+	return tb.events.Subscribe(name, buffer)
+}
+
+// UnsubscribeEvents releases a channel returned by SubscribeEvents
+func (tb *TradingBot) UnsubscribeEvents(events <-chan Event) {
+	tb.events.Unsubscribe(events)
+}
+
+// PublishEvent puts an event on the bot's event bus (e.g. predictions served by the API)
+func (tb *TradingBot) PublishEvent(eventType EventType, data interface{}) {
+	tb.events.Publish(eventType, tb.config.Symbol, data)
+}
+
 // processSignal handles a trading signal and executes trades
 func (tb *TradingBot) processSignal(signal *TradingSignal) {
-	// Log the signal
-	log.Printf("📊 SIGNAL: %s %s", signal.Symbol, signal.Signal.String())
-	log.Printf("   Confidence: %.2f%%", signal.Confidence*100)
-	log.Printf("   Reasoning: %s", signal.Reasoning)
+	tb.signalHistory.Add(signal)
+	tb.events.Publish(EventSignal, signal.Symbol, signal)
+	tb.checkAlerts(signal)
+	tb.tradeExecutor.RecordPrice(signal.Symbol, signal.Timestamp, signal.Price)
 
+	// Log the signal
+	filters := tb.tradeExecutor.GetSymbolFilters()
+	attrs := []any{"symbol", signal.Symbol, "signal", signal.Signal.String(), "confidence", signal.Confidence, "reasoning", signal.Reasoning}
 	if signal.TargetPrice > 0 {
-		log.Printf("   Target: %.2f", signal.TargetPrice)
+		attrs = append(attrs, "target", filters.FormatPrice(signal.TargetPrice))
 	}
 	if signal.StopLoss > 0 {
-		log.Printf("   Stop Loss: %.2f", signal.StopLoss)
+		attrs = append(attrs, "stop_loss", filters.FormatPrice(signal.StopLoss))
 	}
+	engineLog.Info("📊 Signal", attrs...)
 
-	// Print individual indicator signals
-	log.Printf("   Indicators:")
+	// Log individual indicator signals
 	for _, indSig := range signal.IndicatorSignals {
-		log.Printf("     %s: %s (%.2f)", indSig.Name, indSig.Signal.String(), indSig.Strength)
+		engineLog.Debug("Indicator signal", "symbol", signal.Symbol, "indicator", indSig.Name, "signal", indSig.Signal.String(), "strength", indSig.Strength)
 	}
 
 	// Get current price for trade execution
 	currentPrice, err := tb.GetCurrentPrice()
 	if err != nil {
-		log.Printf("❌ Failed to get current price: %v", err)
+		providerLog.Error("Failed to get current price", "symbol", signal.Symbol, "error", err)
+		tb.errorLog.Record(NewEngineError(ErrProviderDown, SeverityWarning, "price", err))
+		tb.recordFailure("price", err)
 		return
 	}
+	tb.outage.RecordSuccess("price")
 
-	// Get ATR trailing stop value
-	var atrTrailStop float64 = 0
-
-	// Get ATR indicator from timeframe manager to get trailing stop value
-	if tb.signalEngine.signalAggregator != nil {
-		// Look for ATR indicator in the signal
-		for _, indSig := range signal.IndicatorSignals {
-			if indSig.Name == "ATR_5m" {
-				atrTrailStop = indSig.Value // Use ATR indicator value as trailing stop
-				break
-			}
-		}
+	// Feed the latest 5-minute candle into MFE/MAE tracking for the open position
+	if latest, err := tb.signalEngine.timeframeManager.GetLatestCandles(FiveMinute, 1); err == nil && len(latest) > 0 {
+		tb.tradeExecutor.UpdateExcursion(latest[0])
 	}
 
-	// If no ATR trailing stop found, calculate basic stop based on current price
-	if atrTrailStop == 0 {
-		// Default stop loss calculation: current price ± (ATR multiplier × estimated volatility)
-		estimatedVolatility := currentPrice * 0.02 // 2% estimated volatility
-		if signal.Signal == Buy {
-			atrTrailStop = currentPrice - (tb.config.ATR.Multiplier * estimatedVolatility)
-		} else if signal.Signal == Sell {
-			atrTrailStop = currentPrice + (tb.config.ATR.Multiplier * estimatedVolatility)
-		}
-	}
+	// Get ATR trailing stop value
+	atrTrailStop := atrTrailStopFor(signal, currentPrice, tb.config.ATR.Multiplier)
 
 	// Execute trade via Pine Script ATR strategy
+	tb.tradeReplays.RecordSignal(signal)
 	if err := tb.tradeExecutor.ExecuteSignal(signal, currentPrice, atrTrailStop); err != nil {
-		log.Printf("❌ Trade execution failed: %v", err)
+		executorLog.Error("Trade execution failed", "symbol", signal.Symbol, "error", err)
+		tb.recordFailure("order", err)
 	}
+	tb.tradeReplays.Update(tb.replayCandles())
 
 	// Log current trading status
 	position := tb.tradeExecutor.GetCurrentPosition()
 	if position != nil {
-		log.Printf("📍 Current Position: %s %.6f @ $%.2f (PnL: $%.2f)",
-			position.Side, position.Quantity, position.EntryPrice, position.PnL)
-		log.Printf("🛡️  ATR Trailing Stop: $%.2f", position.ATRTrailStop)
+		snapshot := *position // The executor keeps marking its copy
+		tb.events.Publish(EventPosition, signal.Symbol, &snapshot)
+		executorLog.Info("📍 Current position", "symbol", position.Symbol, "side", position.Side, "quantity", position.Quantity,
+			"entry_price", filters.FormatPrice(position.EntryPrice), "pnl", position.PnL, "atr_trail_stop", filters.FormatPrice(position.ATRTrailStop))
 	} else {
-		log.Printf("📍 No open position")
+		executorLog.Info("📍 No open position", "symbol", signal.Symbol)
+	}
+}
+
+// atrTrailStopFor returns the ATR_5m trailing stop from a signal, falling back to
+// current price ± (ATR multiplier × 2% estimated volatility)
+func atrTrailStopFor(signal *TradingSignal, currentPrice, multiplier float64) float64 {
+	for _, indSig := range signal.IndicatorSignals {
+		if indSig.Name == "ATR_5m" && indSig.Value != 0 {
+			return indSig.Value // Use ATR indicator value as trailing stop
+		}
+	}
+
+	estimatedVolatility := currentPrice * 0.02 // 2% estimated volatility
+	switch signal.Signal {
+	case Buy:
+		return currentPrice - (multiplier * estimatedVolatility)
+	case Sell:
+		return currentPrice + (multiplier * estimatedVolatility)
 	}
+	return 0
 }
 
 // GetTradingStatus returns current trading status
-func (tb *TradingBot) GetTradingStatus() interface{} {
+func (tb *TradingBot) GetTradingStatus() TradingStatus {
 	if tb.tradeExecutor == nil {
-		return map[string]interface{}{
-			"enabled": false,
-			"error":   "Trade executor not initialized",
-		}
+		return TradingStatus{Error: "Trade executor not initialized"}
 	}
 	return tb.tradeExecutor.GetStatus()
 }
 
+// GetExecutionQuality returns slippage and fill latency stats with the newest recent fills
+func (tb *TradingBot) GetExecutionQuality(recent int) ExecutionQuality {
+	return tb.tradeExecutor.GetExecutionQuality(recent)
+}
+
 // GetCurrentTradingPosition returns current trading position
 func (tb *TradingBot) GetCurrentTradingPosition() *Position {
 	if tb.tradeExecutor == nil {
@@ -565,6 +1585,24 @@ func (tb *TradingBot) GetTradeHistory(limit int) []*Trade {
 	return tb.tradeExecutor.GetTradeHistory(limit)
 }
 
+// GetPositionView returns the API view of the open position, or nil when flat
+func (tb *TradingBot) GetPositionView() *PositionView {
+	return NewPositionView(tb.GetCurrentTradingPosition())
+}
+
+// GetTradeViews returns the API views of the latest limit closed trades
+func (tb *TradingBot) GetTradeViews(limit int) []TradeView {
+	return NewTradeViews(tb.GetTradeHistory(limit))
+}
+
+// GenerateTaxReport builds closed tax lots from the trade history using FIFO or LIFO
+func (tb *TradingBot) GenerateTaxReport(method TaxLotMethod, from, to time.Time) []TaxLotRecord {
+	if tb.tradeExecutor == nil {
+		return []TaxLotRecord{}
+	}
+	return GenerateTaxLots(tb.tradeExecutor.GetTradeHistory(0), method, from, to)
+}
+
 // EnableTrading enables trade execution
 func (tb *TradingBot) EnableTrading() {
 	if tb.tradeExecutor != nil {
@@ -572,6 +1610,14 @@ func (tb *TradingBot) EnableTrading() {
 	}
 }
 
+// ResetStats clears performance statistics and daily loss counters
+func (tb *TradingBot) ResetStats() (*PerformanceStats, error) {
+	if tb.tradeExecutor == nil {
+		return nil, fmt.Errorf("trade executor not initialized")
+	}
+	return tb.tradeExecutor.ResetStats(), nil
+}
+
 // DisableTrading disables trade execution
 func (tb *TradingBot) DisableTrading() {
 	if tb.tradeExecutor != nil {
@@ -593,3 +1639,46 @@ func (tb *TradingBot) ForceClosePosition() error {
 
 	return tb.tradeExecutor.ForceClosePosition(currentPrice)
 }
+
+// replayCandles returns the 5-minute candles trade replays are cut from
+func (tb *TradingBot) replayCandles() []Candle {
+	candles, err := tb.signalEngine.timeframeManager.GetCandles(FiveMinute)
+	if err != nil {
+		return []Candle{}
+	}
+	return candles
+}
+
+// GetSignalHistory returns recent signals, oldest first
+func (tb *TradingBot) GetSignalHistory() []*TradingSignal {
+	return tb.signalHistory.All()
+}
+
+// GetCandleHistory returns a copy of the latest limit candles for a timeframe (0 = all)
+func (tb *TradingBot) GetCandleHistory(timeframe Timeframe, limit int) ([]Candle, error) {
+	return tb.GetSymbolCandleHistory(tb.config.Symbol, timeframe, limit)
+}
+
+// GetSymbolCandleHistory returns a copy of the latest limit candles of a configured symbol
+func (tb *TradingBot) GetSymbolCandleHistory(symbol string, timeframe Timeframe, limit int) ([]Candle, error) {
+	engine, err := tb.engineFor(symbol)
+	if err != nil {
+		return nil, err
+	}
+	candles, err := engine.timeframeManager.GetCandles(timeframe)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(candles) > limit {
+		candles = candles[len(candles)-limit:]
+	}
+	return append([]Candle(nil), candles...), nil
+}
+
+// GetTradeReplay returns the candles and signals spanning a closed trade
+func (tb *TradingBot) GetTradeReplay(tradeID string) (*TradeReplay, error) {
+	if tb.tradeReplays == nil {
+		return nil, fmt.Errorf("trade replay not initialized")
+	}
+	return tb.tradeReplays.GetReplay(tradeID)
+}
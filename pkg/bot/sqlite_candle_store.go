@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteCandleStore persists candles in a SQLite database file
+type SQLiteCandleStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteCandleStore opens (creating if needed) the database at path
+func NewSQLiteCandleStore(path string) (*SQLiteCandleStore, error) {
+	if path == "" {
+		return nil, fmt.Errorf("candle store path is empty")
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create candle store directory: %w", err)
+		}
+	}
+
+	// WAL lets readers (backtests, the API) run alongside the write-through
+	db, err := sql.Open("sqlite3", "file:"+path+"?_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open candle store: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS candles (
+		symbol    TEXT    NOT NULL,
+		timeframe TEXT    NOT NULL,
+		open_time INTEGER NOT NULL, -- unix milliseconds
+		open      REAL    NOT NULL,
+		high      REAL    NOT NULL,
+		low       REAL    NOT NULL,
+		close     REAL    NOT NULL,
+		volume    REAL    NOT NULL,
+		PRIMARY KEY (symbol, timeframe, open_time)
+	) WITHOUT ROWID`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create candle table: %w", err)
+	}
+	return &SQLiteCandleStore{db: db}, nil
+}
+
+// SaveCandles inserts or replaces candles by open time in one transaction
+func (s *SQLiteCandleStore) SaveCandles(symbol string, timeframe Timeframe, candles []Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO candles (symbol, timeframe, open_time, open, high, low, close, volume)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, candle := range candles {
+		if _, err := stmt.Exec(symbol, timeframe.String(), candle.Timestamp.UnixMilli(),
+			candle.Open, candle.High, candle.Low, candle.Close, candle.Volume); err != nil {
+			return fmt.Errorf("failed to save candle %s: %w", candle.Timestamp.Format(time.RFC3339), err)
+		}
+	}
+	return tx.Commit()
+}
+
+// LoadCandles returns the candles opening in [start, end)
+func (s *SQLiteCandleStore) LoadCandles(symbol string, timeframe Timeframe, start, end time.Time) ([]Candle, error) {
+	return s.query(`SELECT open_time, open, high, low, close, volume FROM candles
+		WHERE symbol = ? AND timeframe = ? AND open_time >= ? AND open_time < ?
+		ORDER BY open_time`, symbol, timeframe.String(), start.UnixMilli(), end.UnixMilli())
+}
+
+// LatestCandles returns the last count candles
+func (s *SQLiteCandleStore) LatestCandles(symbol string, timeframe Timeframe, count int) ([]Candle, error) {
+	candles, err := s.query(`SELECT open_time, open, high, low, close, volume FROM candles
+		WHERE symbol = ? AND timeframe = ?
+		ORDER BY open_time DESC LIMIT ?`, symbol, timeframe.String(), count)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(candles)-1; i < j; i, j = i+1, j-1 {
+		candles[i], candles[j] = candles[j], candles[i]
+	}
+	return candles, nil
+}
+
+// query scans candle rows
+func (s *SQLiteCandleStore) query(query string, args ...interface{}) ([]Candle, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query candles: %w", err)
+	}
+	defer rows.Close()
+
+	candles := make([]Candle, 0)
+	for rows.Next() {
+		var openTime int64
+		var candle Candle
+		if err := rows.Scan(&openTime, &candle.Open, &candle.High, &candle.Low, &candle.Close, &candle.Volume); err != nil {
+			return nil, fmt.Errorf("failed to read candle: %w", err)
+		}
+		candle.Timestamp = time.UnixMilli(openTime)
+		candles = append(candles, candle)
+	}
+	return candles, rows.Err()
+}
+
+// Close closes the database
+func (s *SQLiteCandleStore) Close() error {
+	return s.db.Close()
+}
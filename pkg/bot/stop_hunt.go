@@ -0,0 +1,97 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StopHuntResult is the backtest outcome with adversarial wicks of one size
+type StopHuntResult struct {
+	WickPercent        float64 `json:"wick_percent"` // Extra wick beyond each candle's extreme, % of its close
+	ReturnPercent      float64 `json:"return_percent"`
+	Degradation        float64 `json:"degradation"` // Return lost vs the real wicks (percentage points)
+	MaxDrawdownPercent float64 `json:"max_drawdown_percent"`
+	Trades             int     `json:"trades"`
+	StopHunts          int     `json:"stop_hunts"` // Exits filled at the stop by a wick
+	WinRate            float64 `json:"win_rate"`
+}
+
+// StopHuntReport shows how the ATR strategy degrades as stop-hunting wicks grow
+type StopHuntReport struct {
+	CloseOnlyReturn float64          `json:"close_only_return"` // Regular backtest: stops only checked at candle closes
+	Results         []StopHuntResult `json:"results"`           // Real wicks (0%) first, then each wick size
+}
+
+// SetStopHunt makes stops trigger inside candles: each candle's adverse wick is
+// extended by wickPercent of its close (0 keeps the real wicks), and a stop the
+// wick reaches fills at the stop level
+func (bt *Backtester) SetStopHunt(wickPercent float64) {
+	bt.stopHunt = true
+	bt.stopHuntWick = wickPercent
+}
+
+// HuntStop stops out the open position at its stop level when the candle's
+// adverse wick, extended by wickPercent of the close, reaches it
+func (te *TradeExecutor) HuntStop(candle Candle, wickPercent float64) (bool, error) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+
+	position := te.currentPosition
+	if position == nil || position.ATRTrailStop <= 0 {
+		return false, nil
+	}
+	wick := candle.Close * wickPercent / 100
+	if position.Side == "LONG" && candle.Low-wick > position.ATRTrailStop {
+		return false, nil
+	}
+	if position.Side == "SHORT" && candle.High+wick < position.ATRTrailStop {
+		return false, nil
+	}
+	return true, te.closePosition("STOP_HUNT", position.ATRTrailStop, position.ATRTrailStop)
+}
+
+// RunStopHuntTest backtests with real wicks and with each extra wick size,
+// reporting the return lost to stop hunts
+func RunStopHuntTest(config Config, initialBalance float64, candles map[Timeframe][]Candle, start, end time.Time, wickPercents []float64) (*StopHuntReport, error) {
+	closeOnly, err := NewBacktester(config, initialBalance).Run(candles, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("baseline backtest failed: %w", err)
+	}
+	report := &StopHuntReport{CloseOnlyReturn: closeOnly.TotalReturnPercent, Results: make([]StopHuntResult, 0)}
+
+	wicks := []float64{0}
+	for _, wick := range wickPercents {
+		if wick > 0 {
+			wicks = append(wicks, wick)
+		}
+	}
+	sort.Float64s(wicks[1:])
+
+	for _, wick := range wicks {
+		backtester := NewBacktester(config, initialBalance)
+		backtester.SetStopHunt(wick)
+		result, err := backtester.Run(candles, start, end)
+		if err != nil {
+			return nil, fmt.Errorf("stop hunt backtest with %.2f%% wicks failed: %w", wick, err)
+		}
+
+		hunted := StopHuntResult{
+			WickPercent:        wick,
+			ReturnPercent:      result.TotalReturnPercent,
+			MaxDrawdownPercent: result.MaxDrawdownPercent,
+			Trades:             len(result.Trades),
+			WinRate:            result.Performance.WinRate,
+		}
+		for _, trade := range result.Trades {
+			if trade.ExitReason == "STOP_HUNT" {
+				hunted.StopHunts++
+			}
+		}
+		if len(report.Results) > 0 {
+			hunted.Degradation = report.Results[0].ReturnPercent - hunted.ReturnPercent
+		}
+		report.Results = append(report.Results, hunted)
+	}
+	return report, nil
+}
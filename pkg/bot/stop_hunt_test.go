@@ -0,0 +1,53 @@
+package bot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStopHunt(t *testing.T) {
+	t.Log("🎣 Testing adversarial wicks stop out positions and degrade backtest PnL")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000)
+	buy := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Timestamp: time.Now()}
+	if err := executor.ExecuteSignal(buy, 100, 99); err != nil {
+		t.Fatalf("Failed to open position: %v", err)
+	}
+
+	// Low of 99.5 with a 0.4% wick reaches 99.1, short of the stop
+	candle := Candle{Open: 100, High: 100.5, Low: 99.5, Close: 100, Timestamp: time.Now()}
+	if hit, err := executor.HuntStop(candle, 0.4); err != nil || hit {
+		t.Fatalf("Expected the stop to survive a 0.4%% wick (hit %v, err %v)", hit, err)
+	}
+	// A 0.6% wick reaches 98.9 and fills at the stop
+	if hit, err := executor.HuntStop(candle, 0.6); err != nil || !hit {
+		t.Fatalf("Expected a 0.6%% wick to hunt the stop (hit %v, err %v)", hit, err)
+	}
+	trades := executor.GetTradeHistory(0)
+	if executor.GetCurrentPosition() != nil || len(trades) == 0 || trades[0].ExitReason != "STOP_HUNT" || trades[0].ExitPrice != 99 {
+		t.Fatalf("Expected the position closed at the 99 stop by STOP_HUNT, got %+v", trades)
+	}
+
+	// Backtests lose more as wicks grow
+	config.DataProvider = "sample"
+	candles, start, end, err := NewTradingBot(config).LoadBacktestCandles(3)
+	if err != nil {
+		t.Fatalf("Failed to load candles: %v", err)
+	}
+	report, err := RunStopHuntTest(config, 10000, candles, start, end, []float64{2, 0.5})
+	if err != nil {
+		t.Fatalf("Stop hunt test failed: %v", err)
+	}
+	if len(report.Results) != 3 || report.Results[0].WickPercent != 0 || report.Results[1].WickPercent != 0.5 || report.Results[2].WickPercent != 2 {
+		t.Fatalf("Expected real wicks then 0.5%% and 2%%, got %+v", report.Results)
+	}
+	if report.Results[0].Degradation != 0 {
+		t.Errorf("Expected no degradation for the real wicks, got %.2f", report.Results[0].Degradation)
+	}
+	widest := report.Results[2]
+	if widest.Trades > 0 && widest.StopHunts == 0 {
+		t.Errorf("Expected 2%% wicks to hunt stops over %d trades", widest.Trades)
+	}
+	t.Logf("Close-only %.2f%%, degradation %.2fpp at 0.5%% and %.2fpp at 2%%", report.CloseOnlyReturn, report.Results[1].Degradation, widest.Degradation)
+}
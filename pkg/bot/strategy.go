@@ -0,0 +1,198 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Strategy produces order intents from the current portfolio and market state.
+// Strategies never place orders themselves; intents are executed by the
+// TradeExecutor so every strategy shares the same risk framework.
+type Strategy interface {
+	Name() string
+	Symbols() []string // Symbols the strategy needs prices for
+	Evaluate(ctx StrategyContext) ([]OrderIntent, error)
+}
+
+// IntentObserver is implemented by strategies that want the outcome of their intents
+type IntentObserver interface {
+	OnIntentResult(intent OrderIntent, err error)
+}
+
+// StrategyContext is the portfolio/market snapshot handed to a strategy
+type StrategyContext struct {
+	Now           time.Time
+	QuoteCurrency string
+	Cash          float64            // Available quote-currency balance
+	Holdings      map[string]float64 // Base quantity held per symbol
+	Prices        map[string]float64 // Latest price per symbol
+	LastSignal    *TradingSignal     // Most recent signal from the signal engine (may be nil)
+	Regime        string             // Market regime, e.g. "BULLISH", "BEARISH", "UNKNOWN"
+	Exposure      ExposureSnapshot   // Account-wide exposure across all strategies
+	Hedges        map[string]float64 // Open hedge quantity per symbol
+}
+
+// OrderIntent is a strategy's request to buy or sell a quantity of a symbol
+type OrderIntent struct {
+	Strategy string  `json:"strategy"`
+	Symbol   string  `json:"symbol"`
+	Side     string  `json:"side"` // "BUY", "SELL", or "SHORT"/"COVER" for hedges
+	Quantity float64 `json:"quantity"`
+	Price    float64 `json:"price"` // Reference price used for sizing and notional checks
+	Reason   string  `json:"reason"`
+}
+
+// PriceFunc returns the latest price for a symbol
+type PriceFunc func(symbol string) (float64, error)
+
+// StrategyManager runs registered strategies on an interval and routes their
+// intents through the TradeExecutor
+type StrategyManager struct {
+	executor   *TradeExecutor
+	priceFn    PriceFunc
+	signalFn   func() *TradingSignal
+	regimeFn   func() string
+	strategies []Strategy
+	interval   time.Duration
+	mutex      sync.RWMutex
+}
+
+// NewStrategyManager creates a new strategy manager
+func NewStrategyManager(executor *TradeExecutor, priceFn PriceFunc, interval time.Duration) *StrategyManager {
+	return &StrategyManager{
+		executor:   executor,
+		priceFn:    priceFn,
+		strategies: make([]Strategy, 0),
+		interval:   interval,
+	}
+}
+
+// SetSignalSource provides the latest engine signal to strategies
+func (sm *StrategyManager) SetSignalSource(signalFn func() *TradingSignal) {
+	sm.signalFn = signalFn
+}
+
+// SetRegimeSource provides the current market regime to strategies
+func (sm *StrategyManager) SetRegimeSource(regimeFn func() string) {
+	sm.regimeFn = regimeFn
+}
+
+// Register adds a strategy to the manager
+func (sm *StrategyManager) Register(strategy Strategy) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	sm.strategies = append(sm.strategies, strategy)
+	executorLog.Info("🧩 Strategy registered", "strategy", strategy.Name())
+}
+
+// GetStrategies returns the registered strategy names
+func (sm *StrategyManager) GetStrategies() []string {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	names := make([]string, 0, len(sm.strategies))
+	for _, strategy := range sm.strategies {
+		names = append(names, strategy.Name())
+	}
+	return names
+}
+
+// Start runs strategies every interval until ctx is cancelled
+func (sm *StrategyManager) Start(ctx context.Context) {
+	go func() {
+		ticker := sm.executor.clock.NewTicker(sm.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.Chan():
+				sm.RunOnce(now)
+			}
+		}
+	}()
+}
+
+// RunOnce evaluates every strategy once and executes the resulting intents
+func (sm *StrategyManager) RunOnce(now time.Time) {
+	sm.mutex.RLock()
+	strategies := append([]Strategy(nil), sm.strategies...)
+	sm.mutex.RUnlock()
+
+	for _, strategy := range strategies {
+		ctx, err := sm.buildContext(now, strategy)
+		if err != nil {
+			executorLog.Warn("Strategy skipped", "strategy", strategy.Name(), "error", err)
+			continue
+		}
+
+		intents, err := strategy.Evaluate(ctx)
+		if err != nil {
+			executorLog.Error("Strategy evaluation failed", "strategy", strategy.Name(), "error", err)
+			continue
+		}
+
+		for _, intent := range intents {
+			intent.Strategy = strategy.Name()
+			err := sm.executor.ExecuteIntent(intent)
+			if err != nil {
+				executorLog.Error("Strategy intent failed", "strategy", strategy.Name(), "side", intent.Side, "symbol", intent.Symbol, "error", err)
+			}
+			if observer, ok := strategy.(IntentObserver); ok {
+				observer.OnIntentResult(intent, err)
+			}
+		}
+	}
+}
+
+// buildContext snapshots the strategy's cash, holdings and prices for evaluation
+func (sm *StrategyManager) buildContext(now time.Time, strategy Strategy) (StrategyContext, error) {
+	cash, holdings := sm.executor.GetStrategyPortfolio(strategy.Name())
+
+	// Price everything the account is exposed to, not just this strategy's book
+	needed := make(map[string]bool)
+	for _, symbol := range sm.executor.GetExposureSymbols() {
+		needed[symbol] = true
+	}
+	for symbol := range holdings {
+		needed[symbol] = true
+	}
+	for _, symbol := range strategy.Symbols() {
+		needed[symbol] = true
+	}
+
+	prices := make(map[string]float64)
+	for symbol := range needed {
+		price, err := sm.priceFn(symbol)
+		if err != nil {
+			return StrategyContext{}, fmt.Errorf("failed to get price for %s: %w", symbol, err)
+		}
+		prices[symbol] = price
+		sm.executor.RecordPrice(symbol, now, price)
+	}
+
+	var lastSignal *TradingSignal
+	if sm.signalFn != nil {
+		lastSignal = sm.signalFn()
+	}
+
+	regime := "UNKNOWN"
+	if sm.regimeFn != nil {
+		regime = sm.regimeFn()
+	}
+
+	return StrategyContext{
+		Now:           now,
+		QuoteCurrency: sm.executor.QuoteCurrency(),
+		Cash:          cash,
+		Holdings:      holdings,
+		Prices:        prices,
+		LastSignal:    lastSignal,
+		Regime:        regime,
+		Exposure:      sm.executor.GetExposure(prices),
+		Hedges:        hedgeQuantities(sm.executor.GetHedgePositions()),
+	}, nil
+}
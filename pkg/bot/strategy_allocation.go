@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"fmt"
+	"time"
+)
+
+// ATRStrategyName identifies trades from the signal-driven Pine Script ATR strategy
+const ATRStrategyName = "ATR_PINE_SCRIPT"
+
+// StrategyBook tracks the capital, PnL and risk budget of a single strategy
+type StrategyBook struct {
+	Strategy         string             `json:"strategy"`
+	CapitalFraction  float64            `json:"capital_fraction"`  // 0 = unallocated (shares the whole account)
+	AllocatedCapital float64            `json:"allocated_capital"` // Initial capital assigned to the strategy
+	RealizedPnL      float64            `json:"realized_pnl"`
+	MaxDailyLoss     float64            `json:"max_daily_loss"`    // Fraction of equity the strategy may lose per day
+	MaxPositionSize  float64            `json:"max_position_size"` // Risk fraction per signal trade
+	DailyLossUsed    float64            `json:"daily_loss_used"`
+	LastResetTime    time.Time          `json:"last_reset_time"`
+	TotalTrades      int                `json:"total_trades"`
+	WinningTrades    int                `json:"winning_trades"`
+	Holdings         map[string]float64 `json:"holdings"`   // Base quantity per symbol from strategy-layer orders
+	CostBasis        map[string]float64 `json:"cost_basis"` // Quote cost of Holdings per symbol
+}
+
+// newStrategyBook creates a book for a strategy from its allocation
+func newStrategyBook(name string, allocation StrategyAllocation, accountBalance float64, defaults *RiskManager) *StrategyBook {
+	book := &StrategyBook{
+		Strategy:         name,
+		CapitalFraction:  allocation.CapitalFraction,
+		AllocatedCapital: accountBalance * allocation.CapitalFraction,
+		MaxDailyLoss:     allocation.MaxDailyLoss,
+		MaxPositionSize:  allocation.MaxPositionSize,
+		LastResetTime:    defaults.clock.Now(),
+		Holdings:         make(map[string]float64),
+		CostBasis:        make(map[string]float64),
+	}
+	if book.CapitalFraction == 0 {
+		book.AllocatedCapital = accountBalance
+	}
+	if book.MaxDailyLoss == 0 {
+		book.MaxDailyLoss = defaults.MaxDailyLoss
+	}
+	if book.MaxPositionSize == 0 {
+		book.MaxPositionSize = defaults.MaxPositionSize
+	}
+	return book
+}
+
+// Allocated reports whether the strategy has a dedicated capital slice
+func (b *StrategyBook) Allocated() bool {
+	return b.CapitalFraction > 0
+}
+
+// Equity returns allocated capital plus realized PnL
+func (b *StrategyBook) Equity() float64 {
+	return b.AllocatedCapital + b.RealizedPnL
+}
+
+// AvailableCash returns equity not tied up in strategy-layer holdings
+func (b *StrategyBook) AvailableCash() float64 {
+	invested := 0.0
+	for _, cost := range b.CostBasis {
+		invested += cost
+	}
+	return b.Equity() - invested
+}
+
+// checkDailyLoss resets the daily budget once a new session has started and
+// errors once it is exhausted
+func (b *StrategyBook) checkDailyLoss(sessionStart time.Time) error {
+	if sessionStart.After(b.LastResetTime) {
+		b.DailyLossUsed = 0
+		b.LastResetTime = sessionStart
+	}
+	if b.DailyLossUsed >= b.MaxDailyLoss {
+		return fmt.Errorf("strategy %s daily loss budget exhausted: %.2f%% >= %.2f%%",
+			b.Strategy, b.DailyLossUsed*100, b.MaxDailyLoss*100)
+	}
+	return nil
+}
+
+// recordPnL books a realized result against the strategy
+func (b *StrategyBook) recordPnL(pnl float64) {
+	equity := b.Equity()
+	b.RealizedPnL += pnl
+	b.TotalTrades++
+	if pnl > 0 {
+		b.WinningTrades++
+	} else if equity > 0 {
+		b.DailyLossUsed += -pnl / equity
+	}
+}
+
+// copy returns a deep copy safe to hand out of the executor lock
+func (b *StrategyBook) copy() *StrategyBook {
+	c := *b
+	c.Holdings = make(map[string]float64, len(b.Holdings))
+	for k, v := range b.Holdings {
+		c.Holdings[k] = v
+	}
+	c.CostBasis = make(map[string]float64, len(b.CostBasis))
+	for k, v := range b.CostBasis {
+		c.CostBasis[k] = v
+	}
+	return &c
+}
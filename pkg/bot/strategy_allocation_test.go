@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestStrategyCapitalAllocation(t *testing.T) {
+	t.Log("💼 Testing per-strategy capital allocation")
+
+	config := DefaultConfig()
+	config.StrategyAllocations = map[string]StrategyAllocation{
+		ATRStrategyName: {CapitalFraction: 0.25, MaxDailyLoss: 0.02},
+		"REBALANCE":     {CapitalFraction: 0.5},
+	}
+	executor := NewTradeExecutor(config, 10000.0)
+
+	books := executor.GetStrategyBooks()
+	if books[ATRStrategyName].AllocatedCapital != 2500 || books["REBALANCE"].AllocatedCapital != 5000 {
+		t.Fatalf("Unexpected allocations: ATR %.2f, REBALANCE %.2f",
+			books[ATRStrategyName].AllocatedCapital, books["REBALANCE"].AllocatedCapital)
+	}
+
+	// Strategy-layer buys are capped at the strategy's slice
+	err := executor.ExecuteIntent(OrderIntent{Strategy: "REBALANCE", Symbol: "ETHUSDT", Side: "BUY", Quantity: 10, Price: 1000})
+	if err != nil {
+		t.Fatalf("Rebalance buy failed: %v", err)
+	}
+	if held := executor.GetHoldings()["ETHUSDT"]; math.Abs(held-5) > 1e-9 {
+		t.Errorf("Expected buy capped at 5 ETH (5000 allocation), got %.4f", held)
+	}
+
+	// Another strategy can't sell holdings it doesn't own when allocated
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: ATRStrategyName, Symbol: "ETHUSDT", Side: "SELL", Quantity: 1, Price: 1000}); err == nil {
+		t.Errorf("Expected ATR strategy sell of REBALANCE holdings to be rejected")
+	}
+
+	// Selling at a profit realizes PnL only in the owning book
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: "REBALANCE", Symbol: "ETHUSDT", Side: "SELL", Quantity: 5, Price: 1100}); err != nil {
+		t.Fatalf("Rebalance sell failed: %v", err)
+	}
+	books = executor.GetStrategyBooks()
+	if math.Abs(books["REBALANCE"].RealizedPnL-500) > 1e-6 || books[ATRStrategyName].RealizedPnL != 0 {
+		t.Errorf("Expected REBALANCE PnL 500 and ATR PnL 0, got %.2f / %.2f",
+			books["REBALANCE"].RealizedPnL, books[ATRStrategyName].RealizedPnL)
+	}
+
+	// ATR signal trades size off the strategy's equity and are capped at it
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9, Timestamp: time.Now()}
+	if err := executor.ExecuteSignal(signal, 100.0, 99.0); err != nil {
+		t.Fatalf("ATR entry failed: %v", err)
+	}
+	position := executor.GetCurrentPosition()
+	if notional := position.Quantity * position.EntryPrice; notional > 2500+1e-6 {
+		t.Errorf("ATR position notional %.2f exceeds its 2500 allocation", notional)
+	}
+
+	// A 3% loss on the ATR slice exhausts its 2% daily budget without touching REBALANCE
+	if err := executor.ForceClosePosition(97.0); err != nil {
+		t.Fatalf("Failed to close ATR position: %v", err)
+	}
+	if err := executor.ExecuteSignal(signal, 100.0, 99.0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if executor.GetCurrentPosition() != nil {
+		t.Errorf("Expected ATR strategy to be blocked by its daily loss budget")
+	}
+	if err := executor.ExecuteIntent(OrderIntent{Strategy: "REBALANCE", Symbol: "ETHUSDT", Side: "BUY", Quantity: 1, Price: 1000}); err != nil {
+		t.Errorf("REBALANCE should still trade after ATR budget is exhausted: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStrategyMode(t *testing.T) {
+	t.Log("🧭 Testing the 5m_focus and multi_timeframe strategy modes")
+
+	config := DefaultConfig()
+	focused := NewSignalAggregator(config)
+	if len(focused.indicators) != 1 || len(focused.indicators[FiveMinute]) == 0 {
+		t.Fatalf("Expected 5m_focus to build 5-minute indicators only, got %d timeframes", len(focused.indicators))
+	}
+
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	ctx := &MultiTimeframeContext{
+		Symbol:              config.Symbol,
+		DailyCandles:        syntheticCandles(Daily, now.AddDate(0, 0, -120), 120),
+		EightHourCandles:    syntheticCandles(EightHour, now.AddDate(0, 0, -60), 180),
+		FortyFiveMinCandles: syntheticCandles(FortyFiveMinute, now.AddDate(0, 0, -10), 320),
+		FifteenMinCandles:   syntheticCandles(FifteenMinute, now.AddDate(0, 0, -3), 288),
+		FiveMinCandles:      syntheticCandles(FiveMinute, now.AddDate(0, 0, -1), 288),
+	}
+
+	config.Strategy.Mode = StrategyModeMultiTimeframe
+	multi := NewSignalAggregator(config)
+	for _, tf := range multiTimeframes {
+		if len(multi.indicators[tf]) == 0 {
+			t.Errorf("Expected multi_timeframe to build %s indicators", tf)
+		}
+	}
+	signal, err := multi.GenerateSignal(ctx)
+	if err != nil {
+		t.Fatalf("GenerateSignal failed: %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, s := range signal.IndicatorSignals {
+		seen[s.Name[strings.LastIndex(s.Name, "_")+1:]] = true
+	}
+	for _, tf := range multiTimeframes {
+		if !seen[tf.String()] {
+			t.Errorf("Expected %s indicator signals, got %v", tf, seen)
+		}
+	}
+	for _, tf := range multiTimeframes {
+		if !strings.Contains(signal.Reasoning, tf.String()+" ") {
+			t.Errorf("Expected the reasoning to summarize %s: %s", tf, signal.Reasoning)
+		}
+	}
+
+	// A zero weight leaves a timeframe out of the decision
+	config.Strategy.TimeframeWeights = map[string]float64{"1d": 1, "8h": 0, "45m": 0, "15m": 0, "5m": 0}
+	signal, err = NewSignalAggregator(config).GenerateSignal(ctx)
+	if err != nil {
+		t.Fatalf("GenerateSignal failed: %v", err)
+	}
+	if !strings.Contains(signal.Reasoning, "(1d ") || strings.Contains(signal.Reasoning, "5m ") {
+		t.Errorf("Expected only the daily timeframe to be weighed: %s", signal.Reasoning)
+	}
+
+	// Validation rejects unknown modes, unknown timeframes and negative weights
+	config = DefaultConfig()
+	config.Strategy.Mode = "scalp"
+	config.Strategy.TimeframeWeights = map[string]float64{"1w": 0.5, "5m": -1}
+	err = ValidateConfig(config)
+	if err == nil {
+		t.Fatal("Expected the strategy to be rejected")
+	}
+	for _, want := range []string{"strategy.mode", "strategy.timeframe_weights.1w", "strategy.timeframe_weights.5m"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected an error for %s, got %v", want, err)
+		}
+	}
+	config.Strategy = StrategyConfig{Mode: StrategyModeMultiTimeframe, TimeframeWeights: map[string]float64{"1d": 0}}
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "strategy.timeframe_weights") {
+		t.Errorf("Expected multi_timeframe without a positive weight to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// SymbolFilters holds exchange trading rules for a symbol (lot size, tick size, min notional)
+type SymbolFilters struct {
+	Symbol      string  `json:"symbol"`
+	TickSize    float64 `json:"tick_size"`    // Minimum price increment
+	StepSize    float64 `json:"step_size"`    // Minimum quantity increment
+	MinQty      float64 `json:"min_qty"`      // Minimum order quantity
+	MaxQty      float64 `json:"max_qty"`      // Maximum order quantity (0 = unlimited)
+	MinNotional float64 `json:"min_notional"` // Minimum order value in quote currency
+}
+
+// DefaultSymbolFilters returns conservative filters used until exchange metadata is loaded
+func DefaultSymbolFilters(symbol string) *SymbolFilters {
+	return &SymbolFilters{
+		Symbol:      symbol,
+		TickSize:    0.01,
+		StepSize:    0.00001,
+		MinQty:      0.00001, // Matches the historical minimum viable crypto quantity
+		MaxQty:      0,
+		MinNotional: 0,
+	}
+}
+
+// SymbolFiltersFor returns the default filters with the configured tick and
+// step sizes of symbol applied
+func SymbolFiltersFor(config Config, symbol string) *SymbolFilters {
+	filters := DefaultSymbolFilters(symbol)
+	if precision, ok := config.SymbolPrecision[symbol]; ok {
+		if precision.TickSize > 0 {
+			filters.TickSize = precision.TickSize
+		}
+		if precision.StepSize > 0 {
+			filters.StepSize = precision.StepSize
+			filters.MinQty = precision.StepSize
+		}
+	}
+	return filters
+}
+
+// PriceDecimals returns the number of decimals a tick-rounded price needs
+func (sf *SymbolFilters) PriceDecimals() int {
+	if sf.TickSize <= 0 {
+		return 2
+	}
+	return stepDecimals(sf.TickSize)
+}
+
+// FormatPrice rounds a price to the tick and prints it with the tick's decimals
+func (sf *SymbolFilters) FormatPrice(price float64) string {
+	return strconv.FormatFloat(sf.RoundPrice(price), 'f', sf.PriceDecimals(), 64)
+}
+
+// RoundQuantity rounds a quantity down to the lot step size and caps it at MaxQty
+func (sf *SymbolFilters) RoundQuantity(quantity float64) float64 {
+	if sf.MaxQty > 0 && quantity > sf.MaxQty {
+		quantity = sf.MaxQty
+	}
+	return floorToStep(quantity, sf.StepSize)
+}
+
+// RoundPrice rounds a price to the nearest tick
+func (sf *SymbolFilters) RoundPrice(price float64) float64 {
+	if sf.TickSize <= 0 {
+		return price
+	}
+	decimals := stepDecimals(sf.TickSize)
+	rounded := math.Round(price/sf.TickSize) * sf.TickSize
+	return roundToDecimals(rounded, decimals)
+}
+
+// ValidateOrder checks an order against the minimum quantity and notional filters
+func (sf *SymbolFilters) ValidateOrder(quantity, price float64) error {
+	if quantity < sf.MinQty || quantity <= 0 {
+		return fmt.Errorf("quantity %.8f below minimum lot size %.8f for %s", quantity, sf.MinQty, sf.Symbol)
+	}
+	if notional := quantity * price; notional < sf.MinNotional {
+		return fmt.Errorf("order value $%.2f below minimum notional $%.2f for %s", notional, sf.MinNotional, sf.Symbol)
+	}
+	return nil
+}
+
+// floorToStep rounds value down to a multiple of step, avoiding float drift
+func floorToStep(value, step float64) float64 {
+	if step <= 0 {
+		return value
+	}
+	decimals := stepDecimals(step)
+	// Small epsilon so 0.3/0.1 style divisions don't floor to 2.999...
+	steps := math.Floor(value/step + 1e-9)
+	return roundToDecimals(steps*step, decimals)
+}
+
+// stepDecimals returns the number of decimals needed to represent a step (0.001 -> 3)
+func stepDecimals(step float64) int {
+	decimals := 0
+	for step < 1 && decimals < 12 {
+		step *= 10
+		decimals++
+		if math.Abs(step-math.Round(step)) < 1e-9 {
+			break
+		}
+	}
+	return decimals
+}
+
+// roundToDecimals rounds value to the given number of decimal places
+func roundToDecimals(value float64, decimals int) float64 {
+	factor := math.Pow(10, float64(decimals))
+	return math.Round(value*factor) / factor
+}
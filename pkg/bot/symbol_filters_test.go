@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSymbolFiltersRounding(t *testing.T) {
+	filters := &SymbolFilters{Symbol: "BTCUSDT", TickSize: 0.1, StepSize: 0.001, MinQty: 0.001, MaxQty: 100, MinNotional: 5}
+
+	tests := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"quantity floors to step", filters.RoundQuantity(0.12345), 0.123},
+		{"exact step survives float drift", filters.RoundQuantity(0.3), 0.3},
+		{"quantity capped at max", filters.RoundQuantity(250), 100},
+		{"price rounds to tick", filters.RoundPrice(50123.456), 50123.5},
+		{"price rounds down to tick", filters.RoundPrice(50123.44), 50123.4},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.got)
+		}
+	}
+
+	if err := filters.ValidateOrder(0.0001, 50000); err == nil {
+		t.Errorf("Expected min quantity rejection")
+	}
+	if err := filters.ValidateOrder(0.001, 1000); err == nil || !strings.Contains(err.Error(), "minimum notional") {
+		t.Errorf("Expected min notional rejection, got: %v", err)
+	}
+	if err := filters.ValidateOrder(0.001, 50000); err != nil {
+		t.Errorf("Expected valid order, got: %v", err)
+	}
+}
+
+func TestExecutorRejectsBelowMinNotional(t *testing.T) {
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 100.0) // Tiny balance: $2 risk budget
+	executor.SetSymbolFilters(&SymbolFilters{Symbol: "BTCUSDT", TickSize: 0.1, StepSize: 0.001, MinQty: 0.001, MinNotional: 100})
+
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	err := executor.ExecuteSignal(signal, 50000.0, 48000.0)
+	if err == nil {
+		t.Fatalf("Expected order below min notional to be rejected")
+	}
+	t.Logf("   Rejected as expected: %v", err)
+
+	if executor.GetCurrentPosition() != nil {
+		t.Errorf("No position should be opened for a rejected order")
+	}
+
+	// Large enough balance passes and quantity respects the lot step
+	executor = NewTradeExecutor(config, 1000000.0)
+	executor.SetSymbolFilters(&SymbolFilters{Symbol: "BTCUSDT", TickSize: 0.1, StepSize: 0.001, MinQty: 0.001, MinNotional: 100})
+	if err := executor.ExecuteSignal(signal, 50000.0, 48000.05); err != nil {
+		t.Fatalf("Expected order to be accepted: %v", err)
+	}
+	position := executor.GetCurrentPosition()
+	if position.Quantity != 10.0 {
+		t.Errorf("Expected quantity 10.000 (20000 risk / 2000 stop distance), got %.6f", position.Quantity)
+	}
+	if position.ATRTrailStop != 48000.1 {
+		t.Errorf("Expected stop rounded to tick 48000.1, got %.4f", position.ATRTrailStop)
+	}
+}
+
+func TestSymbolPrecisionFormatting(t *testing.T) {
+	t.Log("🔢 Testing tick-size-aware rounding and formatting for low-priced symbols")
+
+	config := DefaultConfig()
+	config.Symbol = "DOGEUSDT"
+	config.SymbolPrecision = map[string]SymbolPrecision{"DOGEUSDT": {TickSize: 0.00001, StepSize: 1}}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected precision overrides to validate: %v", err)
+	}
+
+	doge := SymbolFiltersFor(config, "DOGEUSDT")
+	if doge.TickSize != 0.00001 || doge.StepSize != 1 || doge.MinQty != 1 {
+		t.Errorf("Expected configured DOGE precision, got %+v", doge)
+	}
+	tests := []struct {
+		filters *SymbolFilters
+		price   float64
+		want    string
+	}{
+		{doge, 0.123456789, "0.12346"},
+		{doge, 0.1, "0.10000"},
+		{SymbolFiltersFor(config, "BTCUSDT"), 50123.456, "50123.46"},
+		{&SymbolFilters{TickSize: 0.5}, 101.3, "101.5"},
+		{&SymbolFilters{TickSize: 1}, 42.4, "42"},
+	}
+	for _, tt := range tests {
+		if got := tt.filters.FormatPrice(tt.price); got != tt.want {
+			t.Errorf("FormatPrice(%v) with tick %v: expected %s, got %s", tt.price, tt.filters.TickSize, tt.want, got)
+		}
+	}
+
+	// Targets and stops come out of the aggregator on the symbol's tick grid
+	aggregator := NewSignalAggregator(config)
+	if got := aggregator.roundPrice(0.0812349); got != 0.08123 {
+		t.Errorf("Expected the aggregator to round to 0.08123, got %v", got)
+	}
+	if got := aggregator.roundPrice(0); got != 0 {
+		t.Errorf("Expected an unset stop to stay 0, got %v", got)
+	}
+
+	// Entries on a sub-dollar symbol keep a usable stop instead of rounding it to cents
+	executor := NewTradeExecutor(config, 10000.0)
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(signal, 0.12345, 0.1183456); err != nil {
+		t.Fatalf("Expected DOGE entry to be accepted: %v", err)
+	}
+	if position := executor.GetCurrentPosition(); position.ATRTrailStop != 0.11835 || position.Quantity != float64(int(position.Quantity)) {
+		t.Errorf("Expected stop 0.11835 and a whole-coin quantity, got %+v", position)
+	}
+
+	config.SymbolPrecision["DOGEUSDT"] = SymbolPrecision{TickSize: 0}
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "symbol_precision.DOGEUSDT.tick_size") {
+		t.Errorf("Expected symbol_precision.DOGEUSDT.tick_size validation error, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// VolumeLister is implemented by venues that report 24h volume for every symbol
+type VolumeLister interface {
+	SymbolLister
+	QuoteVolumes() (map[string]float64, error) // 24h volume in each symbol's quote currency
+}
+
+// isKnownQuote reports whether quote is a quote currency SplitSymbol recognizes
+func isKnownQuote(quote string) bool {
+	for _, q := range knownQuoteCurrencies {
+		if strings.EqualFold(q, quote) {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectSymbols ranks the listed symbols of each configured quote currency by
+// 24h quote volume and keeps the top ones, in quote order then volume order
+func SelectSymbols(volumes map[string]float64, listed []string, config SymbolSelectorConfig) []string {
+	excluded := make(map[string]bool, len(config.Exclude))
+	for _, symbol := range config.Exclude {
+		excluded[NormalizeSymbol(symbol)] = true
+	}
+
+	byQuote := make(map[string][]string)
+	for _, symbol := range listed {
+		if excluded[symbol] {
+			continue
+		}
+		if _, ok := volumes[symbol]; !ok {
+			continue
+		}
+		if _, quote, err := SplitSymbol(symbol); err == nil {
+			byQuote[quote] = append(byQuote[quote], symbol)
+		}
+	}
+
+	var selected []string
+	for _, quote := range config.Quotes {
+		symbols := byQuote[strings.ToUpper(quote)]
+		sort.Slice(symbols, func(i, j int) bool {
+			if volumes[symbols[i]] != volumes[symbols[j]] {
+				return volumes[symbols[i]] > volumes[symbols[j]]
+			}
+			return symbols[i] < symbols[j]
+		})
+		selected = append(selected, symbols[:min(config.Top, len(symbols))]...)
+	}
+	return selected
+}
+
+// resolveSymbolSelection fetches the venue's tradable symbols and volumes and selects from them
+func resolveSymbolSelection(venue VolumeLister, config SymbolSelectorConfig) ([]string, error) {
+	listed, err := venue.ListSymbols()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s symbols: %w", venue.Name(), err)
+	}
+	volumes, err := venue.QuoteVolumes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s volumes: %w", venue.Name(), err)
+	}
+	return SelectSymbols(volumes, listed, config), nil
+}
+
+// refreshSymbolSelection resolves the selector again, adding an engine for each
+// newly selected symbol and stopping those of selected symbols that dropped out.
+// Configured symbols are never removed. Returns the engines added, not yet started.
+func (tb *TradingBot) refreshSymbolSelection() []*SignalEngine {
+	venue, ok := tb.signalEngine.dataProvider.primary.(VolumeLister)
+	if !ok {
+		engineLog.Warn("Symbol selector disabled: the provider does not report volumes", "provider", tb.config.DataProvider)
+		return nil
+	}
+	selection, err := resolveSymbolSelection(venue, tb.config.SymbolSelector)
+	if err != nil {
+		engineLog.Warn("Symbol selection failed, keeping the current symbols", "error", err)
+		return nil
+	}
+
+	tb.symbolsMutex.Lock()
+	if tb.selected == nil {
+		tb.selected = make(map[string]bool)
+	}
+	wanted := make(map[string]bool, len(selection))
+	var added, dropped []*SignalEngine
+	for _, symbol := range selection {
+		wanted[symbol] = true
+		if _, ok := tb.engines[symbol]; ok {
+			continue
+		}
+		engine := tb.signalEngine.forSymbol(symbol)
+		tb.engines[symbol] = engine
+		tb.symbols = append(tb.symbols, symbol)
+		tb.selected[symbol] = true
+		added = append(added, engine)
+	}
+	symbols := tb.symbols[:0]
+	for _, symbol := range tb.symbols {
+		if tb.selected[symbol] && !wanted[symbol] {
+			dropped = append(dropped, tb.engines[symbol])
+			delete(tb.engines, symbol)
+			delete(tb.selected, symbol)
+			continue
+		}
+		symbols = append(symbols, symbol)
+	}
+	tb.symbols = symbols
+	tb.symbolsMutex.Unlock()
+
+	for _, engine := range dropped {
+		if err := engine.Stop(); err != nil {
+			engineLog.Error("Failed to stop signal engine", "symbol", engine.config.Symbol, "error", err)
+		}
+	}
+	engineLog.Info("🔎 Symbol selector picked pairs", "venue", venue.Name(), "symbols", selection,
+		"added", len(added), "dropped", len(dropped))
+	return added
+}
+
+// startSymbolSelection resolves the selection again every RefreshHours, starting
+// engines for newly selected symbols
+func (tb *TradingBot) startSymbolSelection(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Duration(tb.config.SymbolSelector.RefreshHours) * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				binanceProvider, isBinance := tb.signalEngine.dataProvider.primary.(*BinanceFuturesDataProvider)
+				for _, engine := range tb.refreshSymbolSelection() {
+					if isBinance {
+						tb.loadSymbolFilters(binanceProvider, engine)
+					}
+					tb.wg.Add(1)
+					go tb.runSymbolEngine(engine)
+				}
+			}
+		}
+	}()
+}
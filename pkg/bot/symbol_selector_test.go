@@ -0,0 +1,99 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSymbolSelector(t *testing.T) {
+	t.Log("🔎 Testing exchange-resolved symbol selection by 24h volume per quote currency")
+
+	volumes := map[string]string{
+		"BTCUSDT": "9000000000", "ETHUSDT": "5000000000", "SOLUSDT": "1200000000", "DOGEUSDT": "1200000000",
+		"USDCUSDT": "8000000000", "BTCUSDC": "700000000", "ETHUSDC": "300000000", "LUNAUSDT": "9999999999",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch r.URL.Path {
+		case "/fapi/v1/exchangeInfo":
+			var symbols []map[string]string
+			for _, symbol := range []string{"BTCUSDT", "ETHUSDT", "SOLUSDT", "DOGEUSDT", "USDCUSDT", "BTCUSDC", "ETHUSDC"} {
+				symbols = append(symbols, map[string]string{"symbol": symbol, "status": "TRADING"})
+			}
+			body = map[string]interface{}{"symbols": append(symbols, map[string]string{"symbol": "LUNAUSDT", "status": "SETTLING"})}
+		case "/fapi/v1/ticker/24hr":
+			var stats []map[string]string
+			for symbol, volume := range volumes {
+				stats = append(stats, map[string]string{"symbol": symbol, "quoteVolume": volume})
+			}
+			body = stats
+		case "/v5/market/tickers":
+			body = map[string]interface{}{"retCode": 0, "result": map[string]interface{}{"list": []map[string]string{
+				{"symbol": "BTCUSDT", "turnover24h": "123.5"}, {"symbol": "ETHUSDT", "turnover24h": "bad"},
+			}}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	binance := NewBinanceFuturesDataProvider("", "")
+	binance.baseURL = server.URL
+	bybit := NewBybitExchange(DefaultConfig().Bybit)
+	bybit.baseURL = server.URL
+	if bybitVolumes, err := bybit.QuoteVolumes(); err != nil || len(bybitVolumes) != 1 || bybitVolumes["BTCUSDT"] != 123.5 {
+		t.Errorf("Expected Bybit turnover for BTCUSDT only, got %v (err %v)", bybitVolumes, err)
+	}
+
+	// Top N per quote, busiest first with ties by name; excluded and non-trading pairs skipped
+	selector := SymbolSelectorConfig{Enabled: true, Quotes: []string{"USDT", "usdc"}, Top: 3, Exclude: []string{"usdc-usdt"}, RefreshHours: 24}
+	selection, err := resolveSymbolSelection(binance, selector)
+	if want := "BTCUSDT,ETHUSDT,DOGEUSDT,BTCUSDC,ETHUSDC"; err != nil || strings.Join(selection, ",") != want {
+		t.Fatalf("Expected %s, got %v (err %v)", want, selection, err)
+	}
+
+	// The bot adds engines for selected pairs and drops them when they fall out
+	config := DefaultConfig()
+	config.Symbols = []string{"SOLUSDT"}
+	config.SymbolSelector = selector
+	config.SymbolSelector.Quotes = []string{"USDT"}
+	tb := NewTradingBot(config)
+	tb.signalEngine.dataProvider.primary = binance
+	if added := tb.refreshSymbolSelection(); len(added) != 2 {
+		t.Errorf("Expected engines for ETHUSDT and DOGEUSDT, got %d", len(added))
+	}
+	if got := strings.Join(tb.Symbols(), ","); got != "BTCUSDT,SOLUSDT,ETHUSDT,DOGEUSDT" {
+		t.Fatalf("Expected the selected pairs after the configured ones, got %s", got)
+	}
+	if _, err := tb.engineFor("DOGEUSDT"); err != nil {
+		t.Errorf("Expected a DOGEUSDT engine: %v", err)
+	}
+
+	// SOLUSDT overtakes DOGEUSDT; it stays configured either way while DOGEUSDT is dropped
+	volumes["SOLUSDT"] = "2000000000"
+	if added := tb.refreshSymbolSelection(); len(added) != 0 {
+		t.Errorf("Expected no new engines, got %d", len(added))
+	}
+	if got := strings.Join(tb.Symbols(), ","); got != "BTCUSDT,SOLUSDT,ETHUSDT" {
+		t.Errorf("Expected DOGEUSDT dropped and SOLUSDT kept, got %s", got)
+	}
+	volumes["SOLUSDT"] = "1"
+	tb.refreshSymbolSelection()
+	if got := strings.Join(tb.Symbols(), ","); got != "BTCUSDT,SOLUSDT,ETHUSDT,DOGEUSDT" {
+		t.Errorf("Expected DOGEUSDT back and configured SOLUSDT kept, got %s", got)
+	}
+
+	config.SymbolSelector.Quotes = []string{"USDT", "XYZ"}
+	config.SymbolSelector.Top = 0
+	err = ValidateConfig(config)
+	for _, want := range []string{`symbol_selector.quotes[1]: unknown quote currency "XYZ"`, "symbol_selector.top"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+}
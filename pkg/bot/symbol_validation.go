@@ -0,0 +1,161 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxSymbolSuggestions caps the close matches listed for an unknown symbol
+const maxSymbolSuggestions = 5
+
+// SymbolLister is implemented by venues that can list the symbols they trade
+type SymbolLister interface {
+	Name() string
+	ListSymbols() ([]string, error) // Tradable symbols in the venue's format
+}
+
+// NormalizeSymbol upper-cases a symbol and strips pair separators (btc-usdt, BTC/USDT -> BTCUSDT)
+func NormalizeSymbol(symbol string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '/', '_', ' ':
+			return -1
+		}
+		return unicode.ToUpper(r)
+	}, strings.TrimSpace(symbol))
+}
+
+// validSymbolFormat reports whether a normalized symbol holds only letters and digits
+func validSymbolFormat(symbol string) bool {
+	for _, r := range symbol {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return symbol != ""
+}
+
+// ResolveSymbol finds a symbol in a venue's symbol list, accepting the USD -> USDT
+// perpetual mapping the venues apply (BTCUSD trades as BTCUSDT), and returns the
+// listed symbol. An unknown symbol's error names the closest listed ones.
+func ResolveSymbol(symbol string, listed []string) (string, error) {
+	normalized := NormalizeSymbol(symbol)
+	candidates := []string{normalized}
+	if strings.HasSuffix(normalized, "USD") {
+		candidates = append(candidates, normalized+"T")
+	}
+
+	known := make(map[string]bool, len(listed))
+	for _, s := range listed {
+		known[s] = true
+	}
+	for _, candidate := range candidates {
+		if known[candidate] {
+			return candidate, nil
+		}
+	}
+
+	matches := closeSymbols(normalized, listed)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("symbol %s is not listed", normalized)
+	}
+	return "", fmt.Errorf("symbol %s is not listed (close matches: %s)", normalized, strings.Join(matches, ", "))
+}
+
+// closeSymbols returns the listed symbols within two edits of target or sharing
+// its base asset, nearest first
+func closeSymbols(target string, listed []string) []string {
+	base, _, _ := SplitSymbol(target)
+
+	type match struct {
+		symbol   string
+		distance int
+	}
+	var matches []match
+	for _, symbol := range listed {
+		distance := editDistance(target, symbol)
+		if listedBase, _, _ := SplitSymbol(symbol); distance <= 2 || (base != "" && listedBase == base) {
+			matches = append(matches, match{symbol, distance})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].symbol < matches[j].symbol
+	})
+
+	var symbols []string
+	for i := 0; i < len(matches) && i < maxSymbolSuggestions; i++ {
+		symbols = append(symbols, matches[i].symbol)
+	}
+	return symbols
+}
+
+// editDistance is the Levenshtein distance between two symbols
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// validateSymbol checks the engine's symbol against its primary venue's symbol
+// list. Venues that can't list symbols, or fail to, are not checked.
+func (se *SignalEngine) validateSymbol() error {
+	lister, ok := se.dataProvider.primary.(SymbolLister)
+	if !ok {
+		return nil
+	}
+	listed, err := lister.ListSymbols()
+	if err != nil {
+		engineLog.Warn("Could not list venue symbols, skipping the symbol check", "venue", lister.Name(), "symbol", se.config.Symbol, "error", err)
+		return nil
+	}
+
+	venueSymbol, err := ResolveSymbol(se.config.Symbol, listed)
+	if err != nil {
+		return fmt.Errorf("%w on %s", err, lister.Name())
+	}
+	if venueSymbol != se.config.Symbol {
+		engineLog.Info("🔤 Symbol mapped to venue symbol", "symbol", se.config.Symbol, "venue_symbol", venueSymbol, "venue", lister.Name())
+	}
+	return nil
+}
+
+// ValidateSymbol checks a symbol against the exchange the bot trades on before
+// switching to it, returning the exchange's symbol
+func (tb *TradingBot) ValidateSymbol(symbol string) (string, error) {
+	symbol = NormalizeSymbol(symbol)
+	if !validSymbolFormat(symbol) {
+		return "", fmt.Errorf("symbol %q is not a valid trading pair", symbol)
+	}
+	lister, ok := tb.signalEngine.dataProvider.primary.(SymbolLister)
+	if !ok {
+		return symbol, nil
+	}
+	listed, err := lister.ListSymbols()
+	if err != nil {
+		return "", fmt.Errorf("failed to list %s symbols: %w", lister.Name(), err)
+	}
+	venueSymbol, err := ResolveSymbol(symbol, listed)
+	if err != nil {
+		return "", fmt.Errorf("%w on %s", err, lister.Name())
+	}
+	return venueSymbol, nil
+}
@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSymbolValidation(t *testing.T) {
+	t.Log("🔤 Testing symbol normalization and validation against the exchange symbol list")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch r.URL.Path {
+		case "/fapi/v1/exchangeInfo":
+			body = map[string]interface{}{"symbols": []map[string]string{
+				{"symbol": "BTCUSDT", "status": "TRADING"},
+				{"symbol": "BTCUSDC", "status": "TRADING"},
+				{"symbol": "ETHUSDT", "status": "TRADING"},
+				{"symbol": "LUNAUSDT", "status": "SETTLING"},
+			}}
+		case "/v5/market/instruments-info":
+			// Two pages joined by the cursor
+			list, next := []map[string]string{{"symbol": "BTCUSDT", "status": "Trading"}}, "page2"
+			if r.URL.Query().Get("cursor") == "page2" {
+				list, next = []map[string]string{{"symbol": "SOLUSDT", "status": "Trading"}, {"symbol": "OLDUSDT", "status": "Closed"}}, ""
+			}
+			body = map[string]interface{}{"retCode": 0, "result": map[string]interface{}{"list": list, "nextPageCursor": next}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	for raw, want := range map[string]string{"btc-usdt": "BTCUSDT", " eth/usdt ": "ETHUSDT", "sol_usdt": "SOLUSDT", "BTCUSD": "BTCUSD"} {
+		if got := NormalizeSymbol(raw); got != want {
+			t.Errorf("NormalizeSymbol(%q): expected %s, got %s", raw, want, got)
+		}
+	}
+
+	binance := NewBinanceFuturesDataProvider("", "")
+	binance.baseURL = server.URL
+	listed, err := binance.ListSymbols()
+	if err != nil || strings.Join(listed, ",") != "BTCUSDT,BTCUSDC,ETHUSDT" {
+		t.Fatalf("Expected the trading Binance symbols, got %v (err %v)", listed, err)
+	}
+	bybit := NewBybitExchange(DefaultConfig().Bybit)
+	bybit.baseURL = server.URL
+	if bybitListed, err := bybit.ListSymbols(); err != nil || strings.Join(bybitListed, ",") != "BTCUSDT,SOLUSDT" {
+		t.Errorf("Expected both Bybit pages of trading symbols, got %v (err %v)", bybitListed, err)
+	}
+
+	// BTCUSD trades as the USDT perpetual; separators and case don't matter
+	for _, symbol := range []string{"btcusd", "BTC/USDT", "eth-usdt"} {
+		if _, err := ResolveSymbol(symbol, listed); err != nil {
+			t.Errorf("Expected %s to resolve: %v", symbol, err)
+		}
+	}
+	if venueSymbol, _ := ResolveSymbol("btcusd", listed); venueSymbol != "BTCUSDT" {
+		t.Errorf("Expected BTCUSD to trade as BTCUSDT, got %s", venueSymbol)
+	}
+
+	// Unknown symbols list typo-close and same-base symbols, nearest first
+	_, err = ResolveSymbol("BTCUDST", listed)
+	if err == nil || !strings.Contains(err.Error(), "symbol BTCUDST is not listed (close matches: BTCUSDT)") {
+		t.Errorf("Expected close matches for a typo, got %v", err)
+	}
+	if _, err := ResolveSymbol("BTCEUR", listed); err == nil || !strings.Contains(err.Error(), "close matches: BTCUSDC, BTCUSDT") {
+		t.Errorf("Expected the other BTC pairs as close matches, got %v", err)
+	}
+	if _, err := ResolveSymbol("LUNAUSDT", listed); err == nil || strings.Contains(err.Error(), "close matches") {
+		t.Errorf("Expected a settling symbol to be unknown without matches, got %v", err)
+	}
+
+	// The engine refuses to start on an unlisted symbol
+	config := DefaultConfig()
+	config.Symbol = "DOGEUSDT"
+	engine := newSignalEngine(config)
+	engine.dataProvider.primary = binance
+	if err := engine.validateSymbol(); err == nil || !strings.Contains(err.Error(), "DOGEUSDT is not listed on binance") {
+		t.Errorf("Expected an unlisted symbol to be rejected, got %v", err)
+	}
+	config.Symbol = "BTCUSD"
+	engine = newSignalEngine(config)
+	engine.dataProvider.primary = binance
+	if err := engine.validateSymbol(); err != nil {
+		t.Errorf("Expected BTCUSD to pass the check: %v", err)
+	}
+
+	// Loaded and updated symbols are normalized; malformed ones are rejected
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"symbol": "eth-usdt", "symbols": ["btc/usdt"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadConfig(path)
+	if err != nil || loaded.Symbol != "ETHUSDT" || loaded.Symbols[0] != "BTCUSDT" {
+		t.Fatalf("Expected normalized symbols, got %s %v (err %v)", loaded.Symbol, loaded.Symbols, err)
+	}
+	cm := NewConfigManager("")
+	if err := cm.UpdateSymbol("sol-usdt"); err != nil || cm.GetConfig().Symbol != "SOLUSDT" {
+		t.Errorf("Expected SOLUSDT, got %s (err %v)", cm.GetConfig().Symbol, err)
+	}
+	if err := cm.UpdateSymbol("BTC$USDT"); err == nil {
+		t.Errorf("Expected a malformed symbol to be rejected")
+	}
+	config.Symbol = "BTC.USDT"
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "not a valid trading pair") {
+		t.Errorf("Expected a malformed symbol to fail validation, got %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package bot
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaxLotMethod selects which open lot is relieved first on disposal
+type TaxLotMethod string
+
+const (
+	FIFO TaxLotMethod = "FIFO"
+	LIFO TaxLotMethod = "LIFO"
+)
+
+// ParseTaxLotMethod parses "fifo"/"lifo" (case-insensitive)
+func ParseTaxLotMethod(s string) (TaxLotMethod, error) {
+	switch TaxLotMethod(strings.ToUpper(s)) {
+	case FIFO:
+		return FIFO, nil
+	case LIFO:
+		return LIFO, nil
+	default:
+		return "", fmt.Errorf("unknown tax lot method: %s (expected FIFO or LIFO)", s)
+	}
+}
+
+// TaxLotRecord is a single closed lot with its cost basis and realized gain
+type TaxLotRecord struct {
+	Symbol       string    `json:"symbol"`
+	Side         string    `json:"side"` // "LONG" or "SHORT"
+	Quantity     float64   `json:"quantity"`
+	DateAcquired time.Time `json:"date_acquired"`
+	DateSold     time.Time `json:"date_sold"`
+	CostBasis    float64   `json:"cost_basis"`
+	Proceeds     float64   `json:"proceeds"`
+	GainLoss     float64   `json:"gain_loss"`
+	Term         string    `json:"term"` // "SHORT" or "LONG" (held more than one year)
+	Currency     string    `json:"currency"`
+	OpenTradeID  string    `json:"open_trade_id"`
+	CloseTradeID string    `json:"close_trade_id"`
+	Fees         float64   `json:"fees"` // The lot's share of its trades' entry and exit fees
+}
+
+// taxLot is an open lot waiting to be relieved
+type taxLot struct {
+	tradeID  string
+	time     time.Time
+	price    float64
+	quantity float64
+	unitFee  float64 // Entry fee per unit
+}
+
+// taxEvent is an acquisition or disposal derived from a trade
+type taxEvent struct {
+	trade   *Trade
+	time    time.Time
+	price   float64
+	opening bool
+}
+
+// GenerateTaxLots matches position openings and closings into closed lots per symbol
+// and side, relieving open lots in FIFO or LIFO order. Only lots closed within
+// [from, to) are returned; a zero bound is unbounded.
+func GenerateTaxLots(trades []*Trade, method TaxLotMethod, from, to time.Time) []TaxLotRecord {
+	// Group opening/closing events by symbol and side
+	events := make(map[string][]taxEvent)
+	for _, trade := range trades {
+		key := trade.Symbol + "|" + trade.Side
+		events[key] = append(events[key],
+			taxEvent{trade: trade, time: trade.EntryTime, price: trade.EntryPrice, opening: true},
+			taxEvent{trade: trade, time: trade.ExitTime, price: trade.ExitPrice, opening: false},
+		)
+	}
+
+	keys := make([]string, 0, len(events))
+	for key := range events {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	records := make([]TaxLotRecord, 0)
+	for _, key := range keys {
+		stream := events[key]
+		sort.SliceStable(stream, func(i, j int) bool {
+			if stream[i].time.Equal(stream[j].time) {
+				return stream[i].opening && !stream[j].opening
+			}
+			return stream[i].time.Before(stream[j].time)
+		})
+
+		openLots := make([]*taxLot, 0)
+		for _, event := range stream {
+			if event.opening {
+				openLots = append(openLots, &taxLot{
+					tradeID:  event.trade.ID,
+					time:     event.time,
+					price:    event.price,
+					quantity: event.trade.Quantity,
+					unitFee:  event.trade.EntryFee / event.trade.Quantity,
+				})
+				continue
+			}
+
+			remaining := event.trade.Quantity
+			for remaining > 1e-12 && len(openLots) > 0 {
+				idx := 0
+				if method == LIFO {
+					idx = len(openLots) - 1
+				}
+				lot := openLots[idx]
+
+				qty := lot.quantity
+				if remaining < qty {
+					qty = remaining
+				}
+
+				record := buildTaxLotRecord(event.trade, lot, event, qty)
+				if inTaxPeriod(record.DateSold, from, to) {
+					records = append(records, record)
+				}
+
+				lot.quantity -= qty
+				remaining -= qty
+				if lot.quantity <= 1e-12 {
+					openLots = append(openLots[:idx], openLots[idx+1:]...)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].DateSold.Before(records[j].DateSold)
+	})
+	return records
+}
+
+// buildTaxLotRecord computes basis and proceeds for qty units of a lot relieved by a closing event
+func buildTaxLotRecord(closing *Trade, lot *taxLot, event taxEvent, qty float64) TaxLotRecord {
+	record := TaxLotRecord{
+		Symbol:       closing.Symbol,
+		Side:         closing.Side,
+		Quantity:     qty,
+		Currency:     closing.QuoteCurrency,
+		OpenTradeID:  lot.tradeID,
+		CloseTradeID: closing.ID,
+		Term:         "SHORT",
+	}
+
+	if closing.Side == "SHORT" {
+		// Short sale: proceeds at open, basis when covered; always short-term
+		record.DateAcquired = event.time
+		record.DateSold = event.time
+		record.Proceeds = lot.price * qty
+		record.CostBasis = event.price * qty
+	} else {
+		record.DateAcquired = lot.time
+		record.DateSold = event.time
+		record.CostBasis = lot.price * qty
+		record.Proceeds = event.price * qty
+		if event.time.Sub(lot.time) > 365*24*time.Hour {
+			record.Term = "LONG"
+		}
+	}
+
+	record.GainLoss = record.Proceeds - record.CostBasis
+	record.Fees = lot.unitFee*qty + closing.ExitFee*qty/closing.Quantity
+	return record
+}
+
+// inTaxPeriod reports whether t falls within [from, to), treating zero bounds as open
+func inTaxPeriod(t, from, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !t.Before(to) {
+		return false
+	}
+	return true
+}
+
+// WriteTaxLotsCSV exports tax lot records as CSV
+func WriteTaxLotsCSV(w io.Writer, records []TaxLotRecord) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"symbol", "side", "quantity", "date_acquired", "date_sold",
+		"cost_basis", "proceeds", "gain_loss", "term", "currency", "open_trade_id", "close_trade_id", "fees"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, r := range records {
+		row := []string{
+			r.Symbol,
+			r.Side,
+			strconv.FormatFloat(r.Quantity, 'f', -1, 64),
+			r.DateAcquired.UTC().Format(time.RFC3339),
+			r.DateSold.UTC().Format(time.RFC3339),
+			strconv.FormatFloat(r.CostBasis, 'f', 8, 64),
+			strconv.FormatFloat(r.Proceeds, 'f', 8, 64),
+			strconv.FormatFloat(r.GainLoss, 'f', 8, 64),
+			r.Term,
+			r.Currency,
+			r.OpenTradeID,
+			r.CloseTradeID,
+			strconv.FormatFloat(r.Fees, 'f', 8, 64),
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
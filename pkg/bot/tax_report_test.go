@@ -0,0 +1,92 @@
+package bot
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// overlappingLongTrades builds two long lots where the second is opened before the first closes
+func overlappingLongTrades() []*Trade {
+	t0 := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	return []*Trade{
+		{ID: "t1", Symbol: "BTCUSDT", Side: "LONG", Quantity: 1, EntryPrice: 100, ExitPrice: 130,
+			EntryTime: t0, ExitTime: t0.Add(48 * time.Hour), QuoteCurrency: "USDT"},
+		{ID: "t2", Symbol: "BTCUSDT", Side: "LONG", Quantity: 1, EntryPrice: 120, ExitPrice: 110,
+			EntryTime: t0.Add(24 * time.Hour), ExitTime: t0.Add(72 * time.Hour), QuoteCurrency: "USDT"},
+	}
+}
+
+func TestTaxLotsFIFOAndLIFO(t *testing.T) {
+	t.Log("🧾 Testing FIFO/LIFO tax lot matching")
+
+	trades := overlappingLongTrades()
+
+	fifo := GenerateTaxLots(trades, FIFO, time.Time{}, time.Time{})
+	lifo := GenerateTaxLots(trades, LIFO, time.Time{}, time.Time{})
+	if len(fifo) != 2 || len(lifo) != 2 {
+		t.Fatalf("Expected 2 lots for each method, got FIFO %d, LIFO %d", len(fifo), len(lifo))
+	}
+
+	// FIFO: first disposal (130) relieves the 100 lot
+	if fifo[0].OpenTradeID != "t1" || math.Abs(fifo[0].GainLoss-30) > 1e-9 {
+		t.Errorf("FIFO first lot: expected t1 gain 30, got %s gain %.2f", fifo[0].OpenTradeID, fifo[0].GainLoss)
+	}
+	// LIFO: first disposal (130) relieves the newer 120 lot
+	if lifo[0].OpenTradeID != "t2" || math.Abs(lifo[0].GainLoss-10) > 1e-9 {
+		t.Errorf("LIFO first lot: expected t2 gain 10, got %s gain %.2f", lifo[0].OpenTradeID, lifo[0].GainLoss)
+	}
+
+	// Total realized gain is identical regardless of method
+	total := func(records []TaxLotRecord) float64 {
+		sum := 0.0
+		for _, r := range records {
+			sum += r.GainLoss
+		}
+		return sum
+	}
+	if math.Abs(total(fifo)-total(lifo)) > 1e-9 || math.Abs(total(fifo)-20) > 1e-9 {
+		t.Errorf("Expected total gain 20 for both methods, got FIFO %.2f, LIFO %.2f", total(fifo), total(lifo))
+	}
+
+	// Year filter excludes lots closed outside the period
+	if lots := GenerateTaxLots(trades, FIFO, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}); len(lots) != 0 {
+		t.Errorf("Expected no lots closed in 2025, got %d", len(lots))
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTaxLotsCSV(&buf, fifo); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "symbol,side,quantity") {
+		t.Errorf("Unexpected CSV output:\n%s", buf.String())
+	}
+}
+
+func TestTradeHistoryPersistence(t *testing.T) {
+	config := DefaultConfig()
+	config.TradeHistoryFile = filepath.Join(t.TempDir(), "trades.json")
+
+	executor := NewTradeExecutor(config, 10000.0)
+	signal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(signal, 100.0, 95.0); err != nil {
+		t.Fatalf("Failed to open position: %v", err)
+	}
+	if err := executor.ForceClosePosition(110.0); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+
+	// A fresh executor picks up the persisted history and stats
+	restored := NewTradeExecutor(config, 10000.0)
+	history := restored.GetTradeHistory(0)
+	if len(history) != 1 || history[0].ExitPrice != 110.0 {
+		t.Fatalf("Expected 1 restored trade exiting at 110, got %d trades", len(history))
+	}
+	if restored.performanceStats.TotalTrades != 1 || restored.performanceStats.WinningTrades != 1 {
+		t.Errorf("Expected performance stats rebuilt from history, got %+v", restored.performanceStats)
+	}
+}
@@ -0,0 +1,312 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultTelegramAPIURL is the Bot API used when TelegramConfig.APIURL is empty
+const defaultTelegramAPIURL = "https://api.telegram.org"
+
+// telegramPollSeconds is how long a getUpdates call waits for new messages
+const telegramPollSeconds = 25
+
+// telegramRiskInterval spaces out risk-block messages, which repeat on every signal while a limit holds
+const telegramRiskInterval = 15 * time.Minute
+
+// telegramUser is the audit identity of commands sent from the configured chat
+var telegramUser = APIUser{Name: "telegram", Role: RoleTrader}
+
+// TelegramNotifier sends messages to a Telegram chat through the Bot API
+type TelegramNotifier struct {
+	config     TelegramConfig
+	httpClient *http.Client
+}
+
+// NewTelegramNotifier creates a notifier for the configured bot and chat
+func NewTelegramNotifier(config TelegramConfig) *TelegramNotifier {
+	if config.APIURL == "" {
+		config.APIURL = defaultTelegramAPIURL
+	}
+	return &TelegramNotifier{
+		config:     config,
+		httpClient: &http.Client{Timeout: (telegramPollSeconds + 10) * time.Second},
+	}
+}
+
+// Notify sends an operational alert to the chat
+func (tn *TelegramNotifier) Notify(level, title, message string) error {
+	icon := "ℹ️"
+	if level == SeverityCritical || level == SeverityWarning {
+		icon = "🚨"
+	}
+	return tn.Send(fmt.Sprintf("%s %s\n%s", icon, title, message))
+}
+
+// Send posts a plain-text message to the chat
+func (tn *TelegramNotifier) Send(text string) error {
+	payload, err := json.Marshal(map[string]interface{}{"chat_id": tn.config.ChatID, "text": text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal telegram message: %w", err)
+	}
+	resp, err := tn.httpClient.Post(tn.method("sendMessage"), "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+	defer resp.Body.Close()
+	return decodeTelegramResponse(resp, nil)
+}
+
+// telegramUpdate is an incoming message from getUpdates
+type telegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Text string `json:"text"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
+
+// updates long-polls for messages after offset
+func (tn *TelegramNotifier) updates(ctx context.Context, offset int64) ([]telegramUpdate, error) {
+	query := url.Values{"offset": {fmt.Sprint(offset)}, "timeout": {fmt.Sprint(telegramPollSeconds)}}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, tn.method("getUpdates")+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := tn.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll telegram updates: %w", err)
+	}
+	defer resp.Body.Close()
+	var updates []telegramUpdate
+	if err := decodeTelegramResponse(resp, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// method returns the URL of a Bot API method
+func (tn *TelegramNotifier) method(name string) string {
+	return fmt.Sprintf("%s/bot%s/%s", strings.TrimSuffix(tn.config.APIURL, "/"), tn.config.Token, name)
+}
+
+// decodeTelegramResponse checks the Bot API's ok flag and decodes its result into v
+func decodeTelegramResponse(resp *http.Response, v interface{}) error {
+	var body struct {
+		OK          bool            `json:"ok"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("telegram returned status %d: %w", resp.StatusCode, err)
+	}
+	if !body.OK {
+		return fmt.Errorf("telegram returned status %d: %s", resp.StatusCode, body.Description)
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(body.Result, v)
+}
+
+// TelegramBot posts signals, positions, trades, risk blocks and daily
+// summaries to a chat and answers its commands
+type TelegramBot struct {
+	notifier   *TelegramNotifier
+	tb         *TradingBot
+	offset     int64     // Next update to fetch
+	positionID string    // Last position announced as opened
+	riskSent   time.Time // Time of the last risk block sent
+}
+
+// NewTelegramBot creates a Telegram bot for tb
+func NewTelegramBot(config TelegramConfig, tb *TradingBot) *TelegramBot {
+	return &TelegramBot{notifier: NewTelegramNotifier(config), tb: tb}
+}
+
+// Start forwards bus events and, when configured, answers commands and sends
+// daily summaries until ctx is cancelled
+func (tg *TelegramBot) Start(ctx context.Context, events <-chan Event) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				tg.send(tg.eventMessage(event))
+			}
+		}
+	}()
+
+	if tg.notifier.config.Commands {
+		go func() {
+			for ctx.Err() == nil {
+				if err := tg.poll(ctx); err != nil && ctx.Err() == nil {
+					notifyLog.Warn("Telegram commands failed", "error", err)
+					select {
+					case <-ctx.Done():
+					case <-tg.tb.signalEngine.clock.After(5 * time.Second):
+					}
+				}
+			}
+		}()
+	}
+
+	if tg.notifier.config.DailySummary {
+		// A session start 36h after this one's is in the next session, whatever DST does
+		session, clock := tg.tb.config.Session, tg.tb.signalEngine.clock
+		start := session.SessionStart(clock.Now())
+		end := session.SessionStart(start.Add(36 * time.Hour))
+		timer := clock.After(end.Sub(clock.Now()))
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-timer:
+					tg.send(tg.dailySummary(start, end))
+					start, end = end, session.SessionStart(end.Add(36*time.Hour))
+					timer = clock.After(end.Sub(clock.Now()))
+				}
+			}
+		}()
+	}
+}
+
+// send delivers a message, logging failures; empty messages are skipped
+func (tg *TelegramBot) send(text string) {
+	if text == "" {
+		return
+	}
+	if err := tg.notifier.Send(text); err != nil {
+		notifyLog.Warn("Failed to deliver Telegram message", "error", err)
+	}
+}
+
+// eventMessage formats the events worth a message: BUY/SELL signals, newly
+// opened positions, closed trades and risk blocks. Critical errors reach the
+// chat through the notifiers instead.
+func (tg *TelegramBot) eventMessage(event Event) string {
+	switch data := event.Data.(type) {
+	case *TradingSignal:
+		if data.Signal != Buy && data.Signal != Sell {
+			return ""
+		}
+		icon := "🟢"
+		if data.Signal == Sell {
+			icon = "🔴"
+		}
+		return fmt.Sprintf("%s %s %s @ %s (confidence %.0f%%)", icon, data.Signal, data.Symbol, tg.price(data.Symbol, data.Price), data.Confidence*100)
+	case *Position:
+		if data.ID == tg.positionID {
+			return ""
+		}
+		tg.positionID = data.ID
+		return fmt.Sprintf("📈 Opened %s %s: %g @ %s, stop %s", data.Side, data.Symbol, data.Quantity, tg.price(data.Symbol, data.EntryPrice), tg.price(data.Symbol, data.StopLoss))
+	case *Trade:
+		icon := "✅"
+		if data.PnL < 0 {
+			icon = "❌"
+		}
+		return fmt.Sprintf("%s Closed %s %s by %s: %s → %s, PnL %+.2f (%+.2f%%)", icon, data.Side, data.Symbol, data.ExitReason,
+			tg.price(data.Symbol, data.EntryPrice), tg.price(data.Symbol, data.ExitPrice), data.PnL, data.PnLPercent)
+	case ErrorRecord:
+		if data.Kind == ErrRiskBlocked && data.Time.Sub(tg.riskSent) >= telegramRiskInterval {
+			tg.riskSent = data.Time
+			return fmt.Sprintf("⛔ Risk limit on %s: %s", event.Symbol, data.Message)
+		}
+	}
+	return ""
+}
+
+// poll fetches one batch of updates and answers the commands from the configured chat
+func (tg *TelegramBot) poll(ctx context.Context) error {
+	updates, err := tg.notifier.updates(ctx, tg.offset)
+	if err != nil {
+		return err
+	}
+	for _, update := range updates {
+		tg.offset = update.UpdateID + 1
+		if update.Message == nil || update.Message.Chat.ID != tg.notifier.config.ChatID {
+			continue // Other chats can't control the bot
+		}
+		tg.send(tg.command(update.Message.Text))
+	}
+	return nil
+}
+
+// command answers /status, /position and /disable
+func (tg *TelegramBot) command(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+	name, _, _ := strings.Cut(fields[0], "@") // "/status@my_bot" in group chats
+	switch name {
+	case "/status":
+		status := tg.tb.GetTradingStatus()
+		lines := []string{fmt.Sprintf("📊 %s", tg.tb.config.Symbol)}
+		if price, err := tg.tb.GetCurrentPrice(); err == nil {
+			lines = append(lines, "Price: "+tg.price(tg.tb.config.Symbol, price))
+		}
+		if signal := tg.tb.GetLastSignal(); signal != nil {
+			lines = append(lines, fmt.Sprintf("Last signal: %s (%.0f%%) at %s", signal.Signal, signal.Confidence*100, signal.Timestamp.UTC().Format("15:04 MST")))
+		}
+		lines = append(lines,
+			fmt.Sprintf("Trading: %s", map[bool]string{true: "enabled", false: "disabled"}[status.Enabled]),
+			fmt.Sprintf("Balance: %.2f %s", status.Balance, status.MarginCurrency),
+			fmt.Sprintf("Trades: %d, win rate %.1f%%, PnL %+.2f", status.Performance.TotalTrades, status.Performance.WinRate, status.Performance.TotalPnL))
+		if status.SafeMode {
+			lines = append(lines, "⚠️ Safe mode: new entries blocked")
+		}
+		return strings.Join(lines, "\n")
+	case "/position":
+		position := tg.tb.GetCurrentTradingPosition()
+		if position == nil {
+			return "📍 No open position"
+		}
+		return fmt.Sprintf("📍 %s %s: %g @ %s\nPrice: %s, PnL %+.2f (%+.2f%%)\nStop: %s", position.Side, position.Symbol, position.Quantity,
+			tg.price(position.Symbol, position.EntryPrice), tg.price(position.Symbol, position.CurrentPrice), position.PnL, position.PnLPercent,
+			tg.price(position.Symbol, position.StopLoss))
+	case "/disable":
+		previous := tg.tb.GetTradingStatus().Enabled
+		tg.tb.DisableTrading()
+		tg.tb.Audit(telegramUser, AuditTradingDisable, "", previous, false)
+		return "🔴 Trading disabled. Open positions are kept; re-enable through the API."
+	}
+	return "Commands: /status, /position, /disable"
+}
+
+// dailySummary reports the trades closed in [from, to)
+func (tg *TelegramBot) dailySummary(from, to time.Time) string {
+	trades, wins, pnl := 0, 0, 0.0
+	for _, trade := range tg.tb.GetTradeHistory(0) {
+		if trade.ExitTime.Before(from) || !trade.ExitTime.Before(to) {
+			continue
+		}
+		trades++
+		pnl += trade.PnL
+		if trade.PnL > 0 {
+			wins++
+		}
+	}
+	status := tg.tb.GetTradingStatus()
+	return fmt.Sprintf("🗓️ %s session of %s: %d trades, %d wins, PnL %+.2f\nBalance: %.2f %s",
+		tg.tb.config.Symbol, tg.tb.config.Session.TradingDay(from).Format("2006-01-02"), trades, wins, pnl, status.Balance, status.MarginCurrency)
+}
+
+// price formats a price with the symbol's tick size
+func (tg *TelegramBot) price(symbol string, price float64) string {
+	return tg.tb.GetSymbolFiltersFor(symbol).FormatPrice(price)
+}
@@ -0,0 +1,145 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTelegramBot(t *testing.T) {
+	t.Log("💬 Testing Telegram messages, commands and daily summaries")
+
+	// A fake Bot API recording sent messages and serving queued updates
+	var mutex sync.Mutex
+	var sent []string
+	updates := `[{"update_id": 7, "message": {"text": "/disable", "chat": {"id": 999}}},
+		{"update_id": 8, "message": {"text": "/position@nexus_bot", "chat": {"id": 42}}},
+		{"update_id": 9, "message": {"text": "/disable", "chat": {"id": 42}}}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bottest-token/sendMessage":
+			var message struct {
+				ChatID int64  `json:"chat_id"`
+				Text   string `json:"text"`
+			}
+			json.NewDecoder(r.Body).Decode(&message)
+			if message.ChatID != 42 {
+				t.Errorf("Message sent to chat %d", message.ChatID)
+			}
+			mutex.Lock()
+			sent = append(sent, message.Text)
+			mutex.Unlock()
+			w.Write([]byte(`{"ok": true, "result": {}}`))
+		case "/bottest-token/getUpdates":
+			if r.URL.Query().Get("offset") != "0" {
+				t.Errorf("Unexpected offset %s", r.URL.Query().Get("offset"))
+			}
+			w.Write([]byte(`{"ok": true, "result": ` + updates + `}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"ok": false, "description": "Not Found"}`))
+		}
+	}))
+	defer server.Close()
+	last := func() string {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if len(sent) == 0 {
+			return ""
+		}
+		return sent[len(sent)-1]
+	}
+
+	config := DefaultConfig()
+	config.DataProvider = "sample"
+	config.Notifications.Telegram = TelegramConfig{Enabled: true, Token: "test-token", ChatID: 42, Commands: true, DailySummary: true, APIURL: server.URL}
+	if err := ValidateConfig(config); err != nil {
+		t.Fatalf("Expected a valid Telegram config, got %v", err)
+	}
+	tb := NewTradingBot(config)
+	tg := NewTelegramBot(config.Notifications.Telegram, tb)
+
+	// BUY/SELL signals, new positions, closed trades and risk blocks are posted; the rest is not
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	position := &Position{ID: "pos_1", Symbol: "BTCUSDT", Side: "LONG", Quantity: 0.5, EntryPrice: 42000, StopLoss: 41000}
+	messages := []struct {
+		event Event
+		want  string
+	}{
+		{Event{Data: &TradingSignal{Symbol: "BTCUSDT", Signal: Buy, Confidence: 0.72, Price: 42000}}, "🟢 BUY BTCUSDT @ 42000.00 (confidence 72%)"},
+		{Event{Data: &TradingSignal{Symbol: "BTCUSDT", Signal: Hold}}, ""},
+		{Event{Data: position}, "📈 Opened LONG BTCUSDT: 0.5 @ 42000.00, stop 41000.00"},
+		{Event{Data: position}, ""},
+		{Event{Data: &Trade{Symbol: "BTCUSDT", Side: "LONG", ExitReason: "ATR_STOP", EntryPrice: 42000, ExitPrice: 41000, PnL: -500, PnLPercent: -2.38}}, "❌ Closed LONG BTCUSDT by ATR_STOP: 42000.00 → 41000.00, PnL -500.00 (-2.38%)"},
+		{Event{Symbol: "BTCUSDT", Data: ErrorRecord{Kind: ErrRiskBlocked, Message: "daily loss limit reached", Time: day}}, "⛔ Risk limit on BTCUSDT: daily loss limit reached"},
+		{Event{Symbol: "BTCUSDT", Data: ErrorRecord{Kind: ErrRiskBlocked, Message: "daily loss limit reached", Time: day.Add(time.Minute)}}, ""},
+		{Event{Data: ErrorRecord{Kind: ErrDataStale}}, ""},
+	}
+	for _, m := range messages {
+		if got := tg.eventMessage(m.event); got != m.want {
+			t.Errorf("Expected %q, got %q", m.want, got)
+		}
+	}
+
+	// Commands are only taken from the configured chat
+	if err := tg.poll(context.Background()); err != nil {
+		t.Fatalf("poll failed: %v", err)
+	}
+	mutex.Lock()
+	if len(sent) != 2 || sent[0] != "📍 No open position" || !strings.HasPrefix(sent[1], "🔴 Trading disabled") {
+		t.Errorf("Unexpected replies: %q", sent)
+	}
+	mutex.Unlock()
+	if tb.GetTradingStatus().Enabled || tg.offset != 10 {
+		t.Errorf("Expected trading disabled and offset 10, got %v and %d", tb.GetTradingStatus().Enabled, tg.offset)
+	}
+	if audit := tb.GetAuditLog(); len(audit) != 1 || audit[0].User != "telegram" || audit[0].Action != AuditTradingDisable {
+		t.Errorf("Expected the disable to be audited as telegram, got %+v", audit)
+	}
+	if reply := tg.command("/status"); !strings.Contains(reply, "Trading: disabled") || !strings.Contains(reply, "Balance: 10000.00") {
+		t.Errorf("Unexpected status reply: %q", reply)
+	}
+	if reply := tg.command("/help"); reply != "Commands: /status, /position, /disable" {
+		t.Errorf("Unexpected help reply: %q", reply)
+	}
+
+	// The summary covers the trades closed in the session
+	tb.tradeExecutor.tradeHistory = append(tb.tradeExecutor.tradeHistory,
+		&Trade{PnL: 30, ExitTime: day.Add(2 * time.Hour)}, &Trade{PnL: -10, ExitTime: day.Add(20 * time.Hour)}, &Trade{PnL: 99, ExitTime: day.Add(-time.Hour)})
+	if summary := tg.dailySummary(day, day.AddDate(0, 0, 1)); !strings.HasPrefix(summary, "🗓️ BTCUSDT session of 2024-01-15: 2 trades, 1 wins, PnL +20.00") {
+		t.Errorf("Unexpected summary: %q", summary)
+	}
+
+	// Sessions end on the bot's clock, so a simulated day sends the summary
+	clock := NewSimClock(day.Add(23 * time.Hour))
+	tb.SetClock(clock)
+	config.Notifications.Telegram.Commands = false
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	NewTelegramBot(config.Notifications.Telegram, tb).Start(ctx, make(chan Event))
+	for i := 0; i < 100 && !strings.HasPrefix(last(), "🗓️"); i++ {
+		clock.Advance(time.Hour)
+		time.Sleep(2 * time.Millisecond)
+	}
+	if !strings.HasPrefix(last(), "🗓️ BTCUSDT session of 2024-01-15: 2 trades") {
+		t.Errorf("Expected the 2024-01-15 summary at the session end, got %q", last())
+	}
+
+	// Alerts go to the chat too, and a failed call reports Telegram's description
+	if err := NewTelegramNotifier(config.Notifications.Telegram).Notify(SeverityCritical, "LIQUIDATION_RISK", "BTCUSDT: 2% from liquidation"); err != nil || last() != "🚨 LIQUIDATION_RISK\nBTCUSDT: 2% from liquidation" {
+		t.Errorf("Unexpected alert %q (err %v)", last(), err)
+	}
+	config.Notifications.Telegram.Token = "wrong"
+	if err := NewTelegramNotifier(config.Notifications.Telegram).Send("hi"); err == nil || !strings.Contains(err.Error(), "Not Found") {
+		t.Errorf("Expected Telegram's error description, got %v", err)
+	}
+	config.Notifications.Telegram.ChatID = 0
+	if err := ValidateConfig(config); err == nil || !strings.Contains(err.Error(), "notifications.telegram.chat_id") {
+		t.Errorf("Expected a missing chat id error, got %v", err)
+	}
+}
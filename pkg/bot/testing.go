@@ -3,13 +3,20 @@ package bot
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"os"
 	"time"
 
 	"trading-bot/pkg/indicator"
 )
 
+// testDeterminism seeds generated data and freezes the suite's clock when
+// TRADING_BOT_SEED is set, so two runs print identical reports
+var (
+	testDeterminism = DeterminismFromEnv()
+	testClock       = testDeterminism.WallClock()
+	testRand        = testDeterminism.Rand()
+)
+
 // TestSuite represents a collection of tests
 type TestSuite struct {
 	name    string
@@ -56,9 +63,9 @@ func (ts *TestSuite) Run() {
 	for _, test := range ts.tests {
 		fmt.Printf("Testing: %s... ", test.name)
 
-		start := time.Now()
+		start := testClock()
 		err := test.function()
-		duration := time.Since(start)
+		duration := testClock().Sub(start)
 
 		result := TestResult{
 			name:     test.name,
@@ -315,6 +322,7 @@ func testIchimokuCloud() error {
 // Data Provider Tests
 func testSampleDataGeneration() error {
 	provider := NewSampleDataProvider([]string{"BTCUSD"}, 100.0)
+	provider.SetDeterminism(testDeterminism)
 
 	candles, err := provider.GetHistoricalData("BTCUSD", FiveMinute, 10)
 	if err != nil {
@@ -331,6 +339,7 @@ func testSampleDataGeneration() error {
 func testHistoricalData() error {
 	manager := NewDataProviderManager()
 	provider := NewSampleDataProvider([]string{"BTCUSD"}, 100.0)
+	provider.SetDeterminism(testDeterminism)
 	manager.AddProvider("sample", provider)
 
 	candles, err := manager.GetHistoricalData("BTCUSD", FiveMinute, 20)
@@ -348,6 +357,7 @@ func testHistoricalData() error {
 func testRealTimeData() error {
 	manager := NewDataProviderManager()
 	provider := NewSampleDataProvider([]string{"BTCUSD"}, 100.0)
+	provider.SetDeterminism(testDeterminism)
 	manager.AddProvider("sample", provider)
 
 	candleChan, err := manager.GetRealTimeData("BTCUSD", FiveMinute)
@@ -475,7 +485,7 @@ func testSignalGeneration() error {
 		FortyFiveMinCandles: generateTestCandles(60, 100.0),
 		FifteenMinCandles:   generateTestCandles(80, 100.0),
 		FiveMinCandles:      generateTestCandles(100, 100.0),
-		LastUpdate:          time.Now(),
+		LastUpdate:          testClock(),
 	}
 
 	signal, err := aggregator.GenerateSignal(ctx)
@@ -502,7 +512,7 @@ func testMultiTimeframeLogic() error {
 		FortyFiveMinCandles: generateTrendingCandles(60, 100.0, 0.003),  // 0.3% uptrend
 		FifteenMinCandles:   generateTrendingCandles(80, 100.0, 0.002),  // 0.2% uptrend
 		FiveMinCandles:      generateTrendingCandles(100, 100.0, 0.001), // 0.1% uptrend
-		LastUpdate:          time.Now(),
+		LastUpdate:          testClock(),
 	}
 
 	signal, err := aggregator.GenerateSignal(ctx)
@@ -531,7 +541,7 @@ func testSignalConfidence() error {
 		FortyFiveMinCandles: generateTestCandles(60, 100.0),
 		FifteenMinCandles:   generateTestCandles(80, 100.0),
 		FiveMinCandles:      generateTestCandles(100, 100.0),
-		LastUpdate:          time.Now(),
+		LastUpdate:          testClock(),
 	}
 
 	signal, err := aggregator.GenerateSignal(ctx)
@@ -554,19 +564,19 @@ func generateTestCandles(count int, basePrice float64) []Candle {
 
 	for i := 0; i < count; i++ {
 		// Generate random price movement
-		change := (rand.Float64() - 0.5) * 0.02 // 2% max change
+		change := (testRand.Float64() - 0.5) * 0.02 // 2% max change
 		newPrice := currentPrice * (1 + change)
 
 		high := math.Max(currentPrice, newPrice) * 1.005
 		low := math.Min(currentPrice, newPrice) * 0.995
 
 		candles[i] = Candle{
-			Timestamp: time.Now().Add(time.Duration(i) * time.Minute),
+			Timestamp: testClock().Add(time.Duration(i) * time.Minute),
 			Open:      currentPrice,
 			High:      high,
 			Low:       low,
 			Close:     newPrice,
-			Volume:    10000 + rand.Float64()*5000,
+			Volume:    10000 + testRand.Float64()*5000,
 		}
 
 		currentPrice = newPrice
@@ -582,19 +592,19 @@ func generateTrendingCandles(count int, basePrice float64, trendStrength float64
 	for i := 0; i < count; i++ {
 		// Add trend component
 		trend := trendStrength * float64(i)
-		change := (rand.Float64() - 0.5) * 0.01 // 1% max random change
+		change := (testRand.Float64() - 0.5) * 0.01 // 1% max random change
 		newPrice := currentPrice * (1 + trend + change)
 
 		high := math.Max(currentPrice, newPrice) * 1.005
 		low := math.Min(currentPrice, newPrice) * 0.995
 
 		candles[i] = Candle{
-			Timestamp: time.Now().Add(time.Duration(i) * time.Minute),
+			Timestamp: testClock().Add(time.Duration(i) * time.Minute),
 			Open:      currentPrice,
 			High:      high,
 			Low:       low,
 			Close:     newPrice,
-			Volume:    10000 + rand.Float64()*5000,
+			Volume:    10000 + testRand.Float64()*5000,
 		}
 
 		currentPrice = newPrice
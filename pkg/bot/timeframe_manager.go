@@ -12,6 +12,12 @@ type TimeframeManager struct {
 	mutex      sync.RWMutex
 	lastUpdate map[Timeframe]time.Time
 	minCandles map[Timeframe]int
+	store      CandleStore // Optional; added candles are written through to it
+
+	degraded map[Timeframe]bool // Feeds the watchdog found silent past their limit
+	fallback map[Timeframe]bool // Timeframes last refreshed over the REST fallback
+
+	clock Clock // Stamps updates and drives the feed watchdog
 }
 
 // NewTimeframeManager creates a new timeframe manager
@@ -22,6 +28,9 @@ func NewTimeframeManager(symbol string) *TimeframeManager {
 			Timeframes: make(map[Timeframe][]Candle),
 		},
 		lastUpdate: make(map[Timeframe]time.Time),
+		degraded:   make(map[Timeframe]bool),
+		fallback:   make(map[Timeframe]bool),
+		clock:      SystemClock,
 		minCandles: map[Timeframe]int{
 			FiveMinute:      100, // Need enough 5-min candles for indicators
 			FifteenMinute:   80,  // Need enough 15-min candles for short-term analysis
@@ -32,9 +41,44 @@ func NewTimeframeManager(symbol string) *TimeframeManager {
 	}
 }
 
+// SetHistoryDepth caps a timeframe's readiness minimum at the candles loaded for it,
+// so a shallower configured history doesn't keep the manager from becoming ready
+func (tm *TimeframeManager) SetHistoryDepth(timeframe Timeframe, count int) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	if minCount, ok := tm.minCandles[timeframe]; ok && count < minCount {
+		tm.minCandles[timeframe] = count
+	}
+}
+
+// SetStore writes every candle added from now on through to store
+func (tm *TimeframeManager) SetStore(store CandleStore) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm.store = store
+}
+
+// SetClock replaces the time source stamping updates and driving the watchdog
+func (tm *TimeframeManager) SetClock(clock Clock) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	tm.clock = clock
+}
+
+// persist writes candles through to the store, if any (called without the lock held)
+func (tm *TimeframeManager) persist(store CandleStore, timeframe Timeframe, candles []Candle) {
+	if store == nil || len(candles) == 0 {
+		return
+	}
+	if err := store.SaveCandles(tm.marketData.Symbol, timeframe, candles); err != nil {
+		providerLog.Error("Failed to store candles", "timeframe", timeframe.String(), "error", err)
+	}
+}
+
 // AddCandle adds a new candle to the specified timeframe
 func (tm *TimeframeManager) AddCandle(timeframe Timeframe, candle Candle) {
 	tm.mutex.Lock()
+	defer tm.persist(tm.store, timeframe, []Candle{candle}) // Runs after unlocking
 	defer tm.mutex.Unlock()
 
 	// Initialize timeframe if it doesn't exist
@@ -47,10 +91,12 @@ func (tm *TimeframeManager) AddCandle(timeframe Timeframe, candle Candle) {
 	if len(candles) > 0 {
 		lastCandle := candles[len(candles)-1]
 
-		// If the timestamp matches, update the last candle
+		// If the timestamp matches, update the last candle; copied so slices
+		// already handed out by GetCandles don't change under their readers
 		if lastCandle.Timestamp.Equal(candle.Timestamp) {
-			candles[len(candles)-1] = candle
-			tm.marketData.Timeframes[timeframe] = candles
+			updated := append([]Candle(nil), candles...)
+			updated[len(updated)-1] = candle
+			tm.marketData.Timeframes[timeframe] = updated
 		} else if candle.Timestamp.After(lastCandle.Timestamp) {
 			// New candle, append it
 			tm.marketData.Timeframes[timeframe] = append(candles, candle)
@@ -60,7 +106,18 @@ func (tm *TimeframeManager) AddCandle(timeframe Timeframe, candle Candle) {
 		tm.marketData.Timeframes[timeframe] = append(candles, candle)
 	}
 
-	tm.lastUpdate[timeframe] = time.Now()
+	tm.lastUpdate[timeframe] = tm.clock.Now()
+}
+
+// ReplaceCandles swaps a timeframe's candles for a freshly fetched series
+func (tm *TimeframeManager) ReplaceCandles(timeframe Timeframe, candles []Candle) {
+	tm.mutex.Lock()
+	defer tm.persist(tm.store, timeframe, candles) // Runs after unlocking
+	defer tm.mutex.Unlock()
+
+	tm.marketData.Timeframes[timeframe] = append([]Candle(nil), candles...)
+	tm.lastUpdate[timeframe] = tm.clock.Now()
+	delete(tm.degraded, timeframe) // A full refetch brings the timeframe current
 }
 
 // GetCandles returns candles for a specific timeframe
@@ -186,7 +243,14 @@ func (tm *TimeframeManager) GetMultiTimeframeContext() (*MultiTimeframeContext,
 		FortyFiveMinCandles: fortyFiveMinCandles,
 		FifteenMinCandles:   fifteenMinCandles,
 		FiveMinCandles:      fiveMinCandles,
-		LastUpdate:          time.Now(),
+		LastUpdate:          tm.clock.Now(),
+		Quality: tm.dataQuality(map[Timeframe][]Candle{
+			Daily:           dailyCandles,
+			EightHour:       eightHourCandles,
+			FortyFiveMinute: fortyFiveMinCandles,
+			FifteenMinute:   fifteenMinCandles,
+			FiveMinute:      fiveMinCandles,
+		}),
 	}, nil
 }
 
@@ -213,6 +277,8 @@ type MultiTimeframeContext struct {
 	FifteenMinCandles   []Candle  `json:"fifteen_min_candles"`
 	FiveMinCandles      []Candle  `json:"five_min_candles"`
 	LastUpdate          time.Time `json:"last_update"`
+
+	Quality *DataQuality `json:"quality,omitempty"` // Stale, gapped and fallback timeframes (live contexts only)
 }
 
 // GetCurrentPrice returns the latest price from 5-minute data
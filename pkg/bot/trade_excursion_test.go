@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTradeExcursionTracking(t *testing.T) {
+	t.Log("📐 Testing MFE/MAE tracking for Pine Script ATR positions")
+
+	config := DefaultConfig()
+	executor := NewTradeExecutor(config, 10000.0)
+
+	buySignal := &TradingSignal{Symbol: config.Symbol, Signal: Buy, Confidence: 0.9}
+	if err := executor.ExecuteSignal(buySignal, 100.0, 95.0); err != nil {
+		t.Fatalf("Failed to open long position: %v", err)
+	}
+
+	position := executor.GetCurrentPosition()
+	if position == nil {
+		t.Fatalf("Expected an open long position")
+	}
+
+	// Candle runs 8% in our favor and 3% against us
+	executor.UpdateExcursion(Candle{Timestamp: time.Now(), Open: 100, High: 108, Low: 97, Close: 104})
+	// Smaller range must not shrink the recorded extremes
+	executor.UpdateExcursion(Candle{Timestamp: time.Now(), Open: 104, High: 105, Low: 99, Close: 102})
+
+	if math.Abs(position.MFEPercent-8.0) > 1e-9 {
+		t.Errorf("Expected MFE 8%%, got %.4f%%", position.MFEPercent)
+	}
+	if math.Abs(position.MAEPercent-3.0) > 1e-9 {
+		t.Errorf("Expected MAE 3%%, got %.4f%%", position.MAEPercent)
+	}
+	if math.Abs(position.MFE-8.0*position.Quantity) > 1e-6 {
+		t.Errorf("Expected MFE $%.4f, got $%.4f", 8.0*position.Quantity, position.MFE)
+	}
+
+	if err := executor.ForceClosePosition(102.0); err != nil {
+		t.Fatalf("Failed to close position: %v", err)
+	}
+
+	trades := executor.GetTradeHistory(1)
+	if len(trades) != 1 {
+		t.Fatalf("Expected 1 trade, got %d", len(trades))
+	}
+
+	trade := trades[0]
+	t.Logf("   Trade: PnL %.2f%% | MFE %.2f%% | MAE %.2f%%", trade.PnLPercent, trade.MFEPercent, trade.MAEPercent)
+	if trade.MFEPercent != 8.0 || trade.MAEPercent != 3.0 {
+		t.Errorf("Trade record lost excursion data: MFE %.2f%%, MAE %.2f%%", trade.MFEPercent, trade.MAEPercent)
+	}
+
+	stats := executor.performanceStats
+	if stats.AverageMFEPercent != 8.0 || stats.AverageWinnerMAEPercent != 3.0 {
+		t.Errorf("Performance stats not updated: avg MFE %.2f%%, winner MAE %.2f%%",
+			stats.AverageMFEPercent, stats.AverageWinnerMAEPercent)
+	}
+}
+
+func TestShortExcursionTracking(t *testing.T) {
+	config := DefaultConfig()
+	config.ATR.UseShorts = true
+	executor := NewTradeExecutor(config, 10000.0)
+
+	sellSignal := &TradingSignal{Symbol: config.Symbol, Signal: Sell, Confidence: 0.9}
+	if err := executor.ExecuteSignal(sellSignal, 100.0, 105.0); err != nil {
+		t.Fatalf("Failed to open short position: %v", err)
+	}
+
+	executor.UpdateExcursion(Candle{Timestamp: time.Now(), Open: 100, High: 102, Low: 94, Close: 96})
+
+	position := executor.GetCurrentPosition()
+	if math.Abs(position.MFEPercent-6.0) > 1e-9 || math.Abs(position.MAEPercent-2.0) > 1e-9 {
+		t.Errorf("Short excursion wrong: MFE %.2f%% (want 6), MAE %.2f%% (want 2)", position.MFEPercent, position.MAEPercent)
+	}
+}
@@ -2,8 +2,8 @@ package bot
 
 import (
 	"fmt"
-	"log"
 	"math"
+	"strings"
 	"sync"
 	"time"
 )
@@ -12,6 +12,11 @@ import (
 type TradeExecutor struct {
 	config           Config
 	enabled          bool
+	safeMode         bool                 // Exchange outage: exits only, no new entries
+	maintenance      *MaintenanceCalendar // Scheduled downtime: exits only during/just before windows
+	errorReporter    func(*EngineError)   // Receives RISK_BLOCKED and LIQUIDATION_RISK errors (optional)
+	tradeObserver    func(*Trade)         // Notified of every closed trade (optional)
+	clock            Clock                // Time source (simulated during backtests)
 	currentPosition  *Position
 	openOrders       map[string]*Order
 	tradeHistory     []*Trade
@@ -19,24 +24,89 @@ type TradeExecutor struct {
 	mutex            sync.RWMutex
 	riskManager      *RiskManager
 	performanceStats *PerformanceStats
+	symbolFilters    *SymbolFilters // Exchange lot/tick/notional rules
+	tradeStore       *TradeStore    // Optional persistence for closed trades
+
+	// Quote/base accounting: balance is held in marginCurrency (the quote for
+	// linear contracts, the base coin for inverse ones), PnL is also reported in
+	// reportingCurrency via converter
+	baseCurrency      string
+	quoteCurrency     string
+	marginCurrency    string
+	reportingCurrency string
+	balances          map[string]float64
+	converter         *CurrencyConverter
+
+	// Spot holdings and fills for orders placed through the strategy layer
+	holdings     map[string]float64
+	orderHistory []*Order
+
+	// Per-strategy capital, PnL and risk budgets
+	books map[string]*StrategyBook
+
+	// Short perpetual hedges opened through the strategy layer
+	hedges map[string]*HedgePosition
+
+	fundingRates map[string]float64 // Latest exchange funding rate per symbol (see SetFundingRate)
+
+	correlations *CorrelationTracker // Rolling returns for the correlation limit and beta reporting
+
+	executions *History[ExecutionRecord] // Fills scored for slippage and latency
+	decision   *executionDecision        // Signal being executed, the reference for its fills
+
+	skippedEntries *History[SkippedEntry] // Entry signals not acted on, for the activity timeline
+
+	// Costs charged on the signal strategy's fills (see SetSimulatedCosts and FeeConfig)
+	fees         FeeSchedule
+	slippageRate float64 // Fraction of price per fill
+
+	// Exchange order routing (see SetOrderPlacer); nil fills signals locally
+	orders      OrderPlacer
+	liveConfig  LiveTradingConfig
+	stopOrderID string // Working protective STOP_MARKET order
+
+	inFlight string // Order whose exchange request is running with the lock released
+
+	sequence int64 // Keeps IDs unique when the clock stands still (see nextID)
 }
 
 // Position represents an open trading position
 type Position struct {
-	ID           string    `json:"id"`
-	Symbol       string    `json:"symbol"`
-	Side         string    `json:"side"` // "LONG" or "SHORT"
-	EntryPrice   float64   `json:"entry_price"`
-	Quantity     float64   `json:"quantity"`
-	CurrentPrice float64   `json:"current_price"`
-	PnL          float64   `json:"pnl"`
-	PnLPercent   float64   `json:"pnl_percent"`
-	StopLoss     float64   `json:"stop_loss"`
-	TakeProfit   float64   `json:"take_profit"`
-	ATRTrailStop float64   `json:"atr_trail_stop"` // Pine Script ATR trailing stop
-	OpenTime     time.Time `json:"open_time"`
-	Strategy     string    `json:"strategy"` // "ATR_PINE_SCRIPT"
-	Confidence   float64   `json:"confidence"`
+	ID             string    `json:"id"`
+	Symbol         string    `json:"symbol"`
+	BaseCurrency   string    `json:"base_currency"`
+	QuoteCurrency  string    `json:"quote_currency"`  // Currency EntryPrice, stops etc. are denominated in
+	ContractType   string    `json:"contract_type"`   // "linear" or "inverse"
+	MarginCurrency string    `json:"margin_currency"` // Currency PnL is denominated in
+	Side           string    `json:"side"`            // "LONG" or "SHORT"
+	EntryPrice     float64   `json:"entry_price"`
+	Quantity       float64   `json:"quantity"`
+	CurrentPrice   float64   `json:"current_price"`
+	PnL            float64   `json:"pnl"`
+	PnLPercent     float64   `json:"pnl_percent"`
+	StopLoss       float64   `json:"stop_loss"`
+	TakeProfit     float64   `json:"take_profit"`
+	ATRTrailStop   float64   `json:"atr_trail_stop"` // Pine Script ATR trailing stop
+	OpenTime       time.Time `json:"open_time"`
+	Strategy       string    `json:"strategy"` // "ATR_PINE_SCRIPT"
+	Confidence     float64   `json:"confidence"`
+
+	// Maximum favorable/adverse excursion since entry (MFE/MAE), updated on each candle
+	MFE        float64 `json:"mfe"`         // Best unrealized PnL seen ($)
+	MFEPercent float64 `json:"mfe_percent"` // Best unrealized move seen (%)
+	MAE        float64 `json:"mae"`         // Worst unrealized loss seen ($, positive)
+	MAEPercent float64 `json:"mae_percent"` // Worst unrealized move against the position (%, positive)
+
+	// Futures margin, updated on each mark when margin monitoring is enabled
+	Leverage                 float64 `json:"leverage,omitempty"`                   // Notional / margin balance
+	MarginRatio              float64 `json:"margin_ratio,omitempty"`               // Maintenance margin / margin balance (liquidation at 1)
+	LiquidationPrice         float64 `json:"liquidation_price,omitempty"`          // 0 when the balance covers any move
+	LiquidationBufferPercent float64 `json:"liquidation_buffer_percent,omitempty"` // Distance from price to liquidation (%)
+	liquidationAlerted       bool    // Buffer alert sent; cleared once the buffer recovers
+
+	// Funding payments and borrow interest in the margin currency (negative = paid), included in PnL
+	Funding     float64   `json:"funding"`
+	lastAccrual time.Time // Funding is accrued up to here
 }
 
 // Order represents a trading order
@@ -47,11 +117,16 @@ type Order struct {
 	Type        string    `json:"type"` // "MARKET", "LIMIT", "STOP"
 	Quantity    float64   `json:"quantity"`
 	Price       float64   `json:"price"`
-	Status      string    `json:"status"` // "PENDING", "FILLED", "CANCELLED"
+	Status      string    `json:"status"` // "PENDING", "PARTIALLY_FILLED", "FILLED", "CANCELLED"
 	CreatedTime time.Time `json:"created_time"`
 	FilledTime  time.Time `json:"filled_time"`
 	Strategy    string    `json:"strategy"`
 	Confidence  float64   `json:"confidence"`
+
+	ExchangeID     string  `json:"exchange_id,omitempty"` // Exchange order ID polled by reconciliation
+	FilledQuantity float64 `json:"filled_quantity"`       // Cumulative executed quantity
+	AveragePrice   float64 `json:"average_price"`         // Average price of the executed quantity
+	Reason         string  `json:"reason,omitempty"`      // Exit reason recorded when the order closes the position
 }
 
 // Trade represents a completed trade
@@ -68,8 +143,25 @@ type Trade struct {
 	ExitTime   time.Time `json:"exit_time"`
 	Duration   string    `json:"duration"`
 	Strategy   string    `json:"strategy"`
-	ExitReason string    `json:"exit_reason"` // "ATR_STOP", "TAKE_PROFIT", "MANUAL", "SIGNAL_CHANGE"
+	ExitReason string    `json:"exit_reason"` // "ATR_STOP", "TAKE_PROFIT", "MANUAL", "SIGNAL_CHANGE", "SAFE_MODE", "FILL", "RECONCILED", "STOP_HUNT"
 	Confidence float64   `json:"confidence"`
+	MFE        float64   `json:"mfe"`         // Maximum favorable excursion ($)
+	MFEPercent float64   `json:"mfe_percent"` // Maximum favorable excursion (%)
+	MAE        float64   `json:"mae"`         // Maximum adverse excursion ($, positive)
+	MAEPercent float64   `json:"mae_percent"` // Maximum adverse excursion (%, positive)
+	Fees       float64   `json:"fees"`        // Simulated entry and exit fees, already deducted from PnL
+	Funding    float64   `json:"funding"`     // Funding and borrow interest, already included in PnL
+
+	// Fees itemized per fill (Fees is their sum)
+	EntryFee    float64 `json:"entry_fee"`
+	ExitFee     float64 `json:"exit_fee"`
+	FeeTier     string  `json:"fee_tier,omitempty"` // Fee tier charged; empty for a flat backtest fee
+	FeeDiscount float64 `json:"fee_discount"`       // Fees waived for paying in BNB
+
+	QuoteCurrency     string  `json:"quote_currency"`             // Currency PnL is denominated in
+	ReportingCurrency string  `json:"reporting_currency"`         // Currency PnLReporting is denominated in
+	PnLReporting      float64 `json:"pnl_reporting"`              // PnL converted at exit
+	ConversionError   string  `json:"conversion_error,omitempty"` // Set when PnLReporting could not be computed
 }
 
 // RiskManager handles position sizing and risk controls
@@ -79,8 +171,47 @@ type RiskManager struct {
 	MaxDrawdown       float64   `json:"max_drawdown"`        // Max portfolio drawdown %
 	ATRStopMultiplier float64   `json:"atr_stop_multiplier"` // ATR multiplier for stops
 	MinConfidence     float64   `json:"min_confidence"`      // Min signal confidence to trade
-	DailyLossUsed     float64   `json:"daily_loss_used"`     // Current daily loss
-	LastResetTime     time.Time `json:"last_reset_time"`
+	DailyLossUsed     float64   `json:"daily_loss_used"`     // Realized losses this session (fraction of balance)
+	LastResetTime     time.Time `json:"last_reset_time"`     // Start of the current session
+
+	DailyUnrealizedLoss float64 `json:"daily_unrealized_loss"` // Open position's unrealized loss at the last mark (fraction of balance)
+
+	clock Clock // Decides when a new session starts; shared with the executor
+}
+
+// rollSession resets the daily loss once a new session has started and returns
+// the current session's start
+func (rm *RiskManager) rollSession(session SessionConfig) time.Time {
+	sessionStart := session.SessionStart(rm.clock.Now())
+	if sessionStart.After(rm.LastResetTime) {
+		rm.DailyLossUsed = 0
+		rm.LastResetTime = sessionStart
+	}
+	return sessionStart
+}
+
+// TradingStatus is a snapshot of the executor's state, balances and risk usage
+type TradingStatus struct {
+	Enabled           bool                      `json:"enabled"`
+	SafeMode          bool                      `json:"safe_mode"`
+	MaintenancePaused bool                      `json:"maintenance_paused"`
+	Balance           float64                   `json:"balance"`
+	Balances          map[string]float64        `json:"balances"`
+	Holdings          map[string]float64        `json:"holdings"`
+	Strategies        map[string]*StrategyBook  `json:"strategies"`
+	Hedges            map[string]*HedgePosition `json:"hedges"`
+	BaseCurrency      string                    `json:"base_currency"`
+	QuoteCurrency     string                    `json:"quote_currency"`
+	MarginCurrency    string                    `json:"margin_currency"` // Currency Balance and PnL are held in
+	ReportingCurrency string                    `json:"reporting_currency"`
+	CurrentPosition   *Position                 `json:"current_position"`
+	OpenOrdersCount   int                       `json:"open_orders_count"`
+	TotalTrades       int                       `json:"total_trades"`
+	Performance       PerformanceStats          `json:"performance"`
+	RiskManagement    RiskManager               `json:"risk_management"`
+	Strategy          string                    `json:"strategy"`
+	ATRConfig         ATRConfig                 `json:"atr_config"`
+	Error             string                    `json:"error,omitempty"` // Set when the executor isn't available
 }
 
 // PerformanceStats tracks trading performance
@@ -97,20 +228,54 @@ type PerformanceStats struct {
 	AverageLoss     float64   `json:"average_loss"`
 	ProfitFactor    float64   `json:"profit_factor"`
 	SharpeRatio     float64   `json:"sharpe_ratio"`
-	MaxDrawdown     float64   `json:"max_drawdown"`
-	ATRTradeCount   int       `json:"atr_trade_count"` // Pine Script ATR trades
+	MaxDrawdown     float64   `json:"max_drawdown"`     // Largest peak-to-trough equity decline (fraction of the peak)
+	CurrentDrawdown float64   `json:"current_drawdown"` // Decline from PeakEquity at the latest mark (fraction)
+	PeakEquity      float64   `json:"peak_equity"`      // Equity high-water mark, unrealized PnL included
+	ATRTradeCount   int       `json:"atr_trade_count"`  // Pine Script ATR trades
 	LastUpdated     time.Time `json:"last_updated"`
+
+	TotalPnLReporting float64 `json:"total_pnl_reporting"` // Total PnL in the reporting currency
+
+	// Excursion statistics for stop/target optimization
+	AverageMFEPercent       float64 `json:"average_mfe_percent"`        // Avg best move across all trades
+	AverageMAEPercent       float64 `json:"average_mae_percent"`        // Avg worst move across all trades
+	AverageWinnerMAEPercent float64 `json:"average_winner_mae_percent"` // How far winners went against us (stop tuning)
+	AverageLoserMFEPercent  float64 `json:"average_loser_mfe_percent"`  // How far losers went in our favor (target tuning)
 }
 
 // NewTradeExecutor creates a new trade executor
 func NewTradeExecutor(config Config, initialBalance float64) *TradeExecutor {
-	return &TradeExecutor{
-		config:          config,
-		enabled:         true, // Enable by default for Pine Script ATR strategy
-		currentPosition: nil,
-		openOrders:      make(map[string]*Order),
-		tradeHistory:    make([]*Trade, 0),
-		balance:         initialBalance,
+	baseCurrency, quoteCurrency := resolveCurrencies(config)
+	marginCurrency := config.Contract.MarginCurrency(baseCurrency, quoteCurrency)
+	reportingCurrency := config.ReportingCurrency
+	if reportingCurrency == "" {
+		reportingCurrency = "USDT"
+	}
+	clock := SystemClock
+
+	te := &TradeExecutor{
+		config:            config,
+		enabled:           true, // Enable by default for Pine Script ATR strategy
+		currentPosition:   nil,
+		openOrders:        make(map[string]*Order),
+		tradeHistory:      make([]*Trade, 0),
+		balance:           initialBalance,
+		symbolFilters:     SymbolFiltersFor(config, config.Symbol),
+		baseCurrency:      baseCurrency,
+		quoteCurrency:     quoteCurrency,
+		marginCurrency:    marginCurrency,
+		reportingCurrency: strings.ToUpper(reportingCurrency),
+		balances:          map[string]float64{marginCurrency: initialBalance},
+		converter:         NewCurrencyConverter(nil),
+		holdings:          make(map[string]float64),
+		orderHistory:      make([]*Order, 0),
+		books:             make(map[string]*StrategyBook),
+		hedges:            make(map[string]*HedgePosition),
+		fundingRates:      make(map[string]float64),
+		executions:        NewHistory[ExecutionRecord](executionHistorySize),
+		skippedEntries:    NewHistory[SkippedEntry](skippedEntryHistorySize),
+		correlations:      NewCorrelationTracker(time.Duration(config.Correlation.IntervalMinutes)*time.Minute, config.Correlation.Window),
+		clock:             clock,
 		riskManager: &RiskManager{
 			MaxPositionSize:   0.02,                  // 2% of balance per trade (conservative)
 			MaxDailyLoss:      0.05,                  // 5% max daily loss
@@ -118,12 +283,64 @@ func NewTradeExecutor(config Config, initialBalance float64) *TradeExecutor {
 			ATRStopMultiplier: config.ATR.Multiplier, // Use Pine Script ATR multiplier
 			MinConfidence:     config.MinConfidence,
 			DailyLossUsed:     0,
-			LastResetTime:     time.Now(),
+			LastResetTime:     config.Session.SessionStart(clock.Now()),
+			clock:             clock,
 		},
 		performanceStats: &PerformanceStats{
-			LastUpdated: time.Now(),
+			PeakEquity:  initialBalance,
+			LastUpdated: clock.Now(),
 		},
 	}
+
+	if config.Fees.Enabled {
+		te.fees = config.Fees.Schedule()
+	}
+
+	// Create books up front so allocated strategies are reported before their first trade
+	for name := range config.StrategyAllocations {
+		te.bookFor(name)
+	}
+
+	// Restore persisted trade history if configured
+	if config.TradeHistoryFile != "" {
+		te.tradeStore = NewTradeStore(config.TradeHistoryFile)
+		te.restoreTradeHistory()
+	}
+
+	return te
+}
+
+// restoreTradeHistory loads persisted trades and rebuilds performance stats
+func (te *TradeExecutor) restoreTradeHistory() {
+	trades, err := te.tradeStore.Load()
+	if err != nil {
+		executorLog.Warn("Failed to restore trade history", "error", err)
+		return
+	}
+
+	for _, trade := range trades {
+		te.tradeHistory = append(te.tradeHistory, trade)
+		te.updatePerformanceStats(trade)
+		te.bookFor(trade.Strategy).recordPnL(trade.PnL)
+		if trade.QuoteCurrency != "" {
+			te.balances[trade.QuoteCurrency] += trade.PnL
+		}
+		te.markEquity()
+	}
+	// Only losses closed in the current session count against today's limit
+	te.riskManager.DailyLossUsed = 0
+	for _, trade := range trades {
+		if trade.PnL < 0 && !trade.ExitTime.Before(te.riskManager.LastResetTime) {
+			te.riskManager.DailyLossUsed += -trade.PnL / te.balance
+		}
+	}
+	for _, book := range te.books {
+		book.DailyLossUsed = 0
+	}
+
+	if len(trades) > 0 {
+		executorLog.Info("📂 Restored trade history", "trades", len(trades), "file", te.config.TradeHistoryFile)
+	}
 }
 
 // ExecuteSignal processes a trading signal from Pine Script ATR strategy
@@ -132,13 +349,50 @@ func (te *TradeExecutor) ExecuteSignal(signal *TradingSignal, currentPrice float
 	defer te.mutex.Unlock()
 
 	if !te.enabled {
-		log.Printf("🚫 Trade execution disabled - skipping signal: %s", signal.Signal.String())
+		executorLog.Debug("Trade execution disabled, skipping signal", "symbol", signal.Symbol, "signal", signal.Signal.String())
+		if signal.Signal != Hold {
+			te.recordSkippedEntry(signal, "Trade execution disabled")
+		}
+		return nil
+	}
+	if te.inFlight != "" {
+		executorLog.Info("Order request in flight, skipping signal", "symbol", signal.Symbol, "signal", signal.Signal.String(), "order_id", te.inFlight)
+		return nil
+	}
+
+	// Fills are measured against the price and time the signal was computed at
+	te.decision = &executionDecision{price: signal.Price, at: signal.Timestamp}
+	if te.decision.price <= 0 {
+		te.decision.price = currentPrice
+	}
+	if te.decision.at.IsZero() || te.decision.at.After(te.now()) {
+		te.decision.at = te.now()
+	}
+	defer func() { te.decision = nil }()
+	atrTrailStop = te.trailStop(signal, currentPrice, atrTrailStop)
+
+	// Safe mode / maintenance: keep managing exits but never open new positions
+	pauseReason, paused := te.maintenance.EntriesPaused(te.now())
+	if te.safeMode {
+		pauseReason, paused = "Safe mode active", true
+	}
+	if paused {
+		position := te.currentPosition
+		switch {
+		case signal.Signal == Hold:
+			return te.updateTrailingStops(currentPrice, atrTrailStop)
+		case position != nil && signal.Signal == Buy && position.Side == "SHORT",
+			position != nil && signal.Signal == Sell && position.Side == "LONG":
+			return te.exitPosition("SIGNAL_CHANGE", currentPrice, atrTrailStop)
+		}
+		executorLog.Info("🛟 Skipping entry", "symbol", signal.Symbol, "signal", signal.Signal.String(), "reason", pauseReason)
+		te.recordSkippedEntry(signal, pauseReason)
 		return nil
 	}
 
 	// Check risk management
 	if !te.checkRiskManagement(signal) {
-		log.Printf("🛑 Risk management blocked trade: %s", signal.Signal.String())
+		executorLog.Info("🛑 Risk management blocked trade", "symbol", signal.Symbol, "signal", signal.Signal.String())
 		return nil
 	}
 
@@ -161,7 +415,7 @@ func (te *TradeExecutor) ExecuteSignal(signal *TradingSignal, currentPrice float
 		} else {
 			// Close long position if open (spot trading)
 			if te.currentPosition != nil && te.currentPosition.Side == "LONG" {
-				return te.closePosition("SIGNAL_CHANGE", currentPrice, atrTrailStop)
+				return te.exitPosition("SIGNAL_CHANGE", currentPrice, atrTrailStop)
 			}
 		}
 	case Hold:
@@ -176,9 +430,12 @@ func (te *TradeExecutor) ExecuteSignal(signal *TradingSignal, currentPrice float
 func (te *TradeExecutor) executeLongEntry(signal *TradingSignal, currentPrice, atrTrailStop, atrStrength float64) error {
 	// Close any short position first
 	if te.currentPosition != nil && te.currentPosition.Side == "SHORT" {
-		if err := te.closePosition("SIGNAL_CHANGE", currentPrice, atrTrailStop); err != nil {
+		if err := te.exitPosition("SIGNAL_CHANGE", currentPrice, atrTrailStop); err != nil {
 			return err
 		}
+		if !te.checkReversal() {
+			return nil
+		}
 	}
 
 	// Don't open new long if already long
@@ -188,38 +445,62 @@ func (te *TradeExecutor) executeLongEntry(signal *TradingSignal, currentPrice, a
 	}
 
 	// Calculate position size based on risk management
+	currentPrice = te.simulatedFill(currentPrice, "BUY")
+	atrTrailStop = te.symbolFilters.RoundPrice(atrTrailStop)
 	quantity := te.calculatePositionSize(currentPrice, atrTrailStop)
 	if quantity == 0 {
 		return fmt.Errorf("position size calculation resulted in 0 quantity")
 	}
+	if err := te.symbolFilters.ValidateOrder(quantity, te.config.Contract.OrderValue(1, currentPrice)); err != nil {
+		return fmt.Errorf("order rejected: %w", err)
+	}
+	if err := te.checkMargin(quantity, currentPrice); err != nil {
+		te.reportRiskBlock(err)
+		return nil
+	}
+	quantity, err := te.limitCorrelation(te.config.Symbol, quantity, te.config.Contract.OrderValue(1, currentPrice))
+	if err == nil && te.symbolFilters.RoundQuantity(quantity) < te.symbolFilters.MinQty {
+		err = fmt.Errorf("correlation limit: %s entry downsized below the minimum quantity", te.config.Symbol)
+	}
+	if err != nil {
+		te.reportRiskBlock(err)
+		return nil
+	}
+	quantity = te.symbolFilters.RoundQuantity(quantity)
+	if te.orders != nil {
+		return te.submitEntry(signal, "LONG", quantity, currentPrice, atrTrailStop)
+	}
 
 	// Create new long position
 	position := &Position{
-		ID:           fmt.Sprintf("pos_%d", time.Now().UnixNano()),
-		Symbol:       te.config.Symbol,
-		Side:         "LONG",
-		EntryPrice:   currentPrice,
-		Quantity:     quantity,
-		CurrentPrice: currentPrice,
-		PnL:          0,
-		PnLPercent:   0,
-		StopLoss:     atrTrailStop,
-		TakeProfit:   0, // No fixed take profit for ATR strategy
-		ATRTrailStop: atrTrailStop,
-		OpenTime:     time.Now(),
-		Strategy:     "ATR_PINE_SCRIPT",
-		Confidence:   signal.Confidence,
+		ID:             te.nextID("pos"),
+		Symbol:         te.config.Symbol,
+		BaseCurrency:   te.baseCurrency,
+		QuoteCurrency:  te.quoteCurrency,
+		ContractType:   te.contractType(),
+		MarginCurrency: te.marginCurrency,
+		Side:           "LONG",
+		EntryPrice:     currentPrice,
+		Quantity:       quantity,
+		CurrentPrice:   currentPrice,
+		PnL:            0,
+		PnLPercent:     0,
+		StopLoss:       atrTrailStop,
+		TakeProfit:     0, // No fixed take profit for ATR strategy
+		ATRTrailStop:   atrTrailStop,
+		OpenTime:       te.now(),
+		Strategy:       ATRStrategyName,
+		Confidence:     signal.Confidence,
 	}
 
 	te.currentPosition = position
+	te.updateMargin(currentPrice)
+	te.recordExecution(position.ID, "BUY", "ENTRY", quantity, te.decision.price, currentPrice, te.decision.at)
 
 	// Log the trade
-	log.Printf("🟢 LONG ENTRY: %s at $%.2f", te.config.Symbol, currentPrice)
-	log.Printf("   📊 Quantity: %.6f", quantity)
-	log.Printf("   🛡️ ATR Stop: $%.2f", atrTrailStop)
-	log.Printf("   📈 Confidence: %.1f%%", signal.Confidence*100)
-	log.Printf("   ⚡ ATR Strength: %.3f", atrStrength)
-	log.Printf("   🎯 Strategy: Pine Script ATR (Length=%d, Mult=%.1f)", te.config.ATR.Period, te.config.ATR.Multiplier)
+	executorLog.Info("🟢 Long entry", "symbol", te.config.Symbol, "side", "LONG", "price", te.symbolFilters.FormatPrice(currentPrice),
+		"quantity", quantity, "atr_stop", te.symbolFilters.FormatPrice(atrTrailStop), "confidence", signal.Confidence,
+		"atr_strength", atrStrength, "atr_period", te.config.ATR.Period, "atr_multiplier", te.config.ATR.Multiplier)
 
 	return nil
 }
@@ -228,9 +509,12 @@ func (te *TradeExecutor) executeLongEntry(signal *TradingSignal, currentPrice, a
 func (te *TradeExecutor) executeShortEntry(signal *TradingSignal, currentPrice, atrTrailStop, atrStrength float64) error {
 	// Close any long position first
 	if te.currentPosition != nil && te.currentPosition.Side == "LONG" {
-		if err := te.closePosition("SIGNAL_CHANGE", currentPrice, atrTrailStop); err != nil {
+		if err := te.exitPosition("SIGNAL_CHANGE", currentPrice, atrTrailStop); err != nil {
 			return err
 		}
+		if !te.checkReversal() {
+			return nil
+		}
 	}
 
 	// Don't open new short if already short
@@ -240,42 +524,77 @@ func (te *TradeExecutor) executeShortEntry(signal *TradingSignal, currentPrice,
 	}
 
 	// Calculate position size based on risk management
+	currentPrice = te.simulatedFill(currentPrice, "SELL")
+	atrTrailStop = te.symbolFilters.RoundPrice(atrTrailStop)
 	quantity := te.calculatePositionSize(currentPrice, atrTrailStop)
 	if quantity == 0 {
 		return fmt.Errorf("position size calculation resulted in 0 quantity")
 	}
+	if err := te.symbolFilters.ValidateOrder(quantity, te.config.Contract.OrderValue(1, currentPrice)); err != nil {
+		return fmt.Errorf("order rejected: %w", err)
+	}
+	if err := te.checkMargin(quantity, currentPrice); err != nil {
+		te.reportRiskBlock(err)
+		return nil
+	}
+	if te.orders != nil {
+		return te.submitEntry(signal, "SHORT", quantity, currentPrice, atrTrailStop)
+	}
 
 	// Create new short position
 	position := &Position{
-		ID:           fmt.Sprintf("pos_%d", time.Now().UnixNano()),
-		Symbol:       te.config.Symbol,
-		Side:         "SHORT",
-		EntryPrice:   currentPrice,
-		Quantity:     quantity,
-		CurrentPrice: currentPrice,
-		PnL:          0,
-		PnLPercent:   0,
-		StopLoss:     atrTrailStop,
-		TakeProfit:   0, // No fixed take profit for ATR strategy
-		ATRTrailStop: atrTrailStop,
-		OpenTime:     time.Now(),
-		Strategy:     "ATR_PINE_SCRIPT",
-		Confidence:   signal.Confidence,
+		ID:             te.nextID("pos"),
+		Symbol:         te.config.Symbol,
+		BaseCurrency:   te.baseCurrency,
+		QuoteCurrency:  te.quoteCurrency,
+		ContractType:   te.contractType(),
+		MarginCurrency: te.marginCurrency,
+		Side:           "SHORT",
+		EntryPrice:     currentPrice,
+		Quantity:       quantity,
+		CurrentPrice:   currentPrice,
+		PnL:            0,
+		PnLPercent:     0,
+		StopLoss:       atrTrailStop,
+		TakeProfit:     0, // No fixed take profit for ATR strategy
+		ATRTrailStop:   atrTrailStop,
+		OpenTime:       te.now(),
+		Strategy:       ATRStrategyName,
+		Confidence:     signal.Confidence,
 	}
 
 	te.currentPosition = position
+	te.updateMargin(currentPrice)
+	te.recordExecution(position.ID, "SELL", "ENTRY", quantity, te.decision.price, currentPrice, te.decision.at)
 
 	// Log the trade
-	log.Printf("🔴 SHORT ENTRY: %s at $%.2f", te.config.Symbol, currentPrice)
-	log.Printf("   📊 Quantity: %.6f", quantity)
-	log.Printf("   🛡️ ATR Stop: $%.2f", atrTrailStop)
-	log.Printf("   📈 Confidence: %.1f%%", signal.Confidence*100)
-	log.Printf("   ⚡ ATR Strength: %.3f", atrStrength)
-	log.Printf("   🎯 Strategy: Pine Script ATR (Length=%d, Mult=%.1f)", te.config.ATR.Period, te.config.ATR.Multiplier)
+	executorLog.Info("🔴 Short entry", "symbol", te.config.Symbol, "side", "SHORT", "price", te.symbolFilters.FormatPrice(currentPrice),
+		"quantity", quantity, "atr_stop", te.symbolFilters.FormatPrice(atrTrailStop), "confidence", signal.Confidence,
+		"atr_strength", atrStrength, "atr_period", te.config.ATR.Period, "atr_multiplier", te.config.ATR.Multiplier)
 
 	return nil
 }
 
+// trailStop returns the ParabolicSAR_5m level in place of the ATR trail stop when
+// trailing_stop.method is psar. An entry keeps the ATR stop while the SAR is on the
+// wrong side of price; an open position follows the SAR even through a flip, which
+// stops it out (assumes lock is held).
+func (te *TradeExecutor) trailStop(signal *TradingSignal, currentPrice, atrTrailStop float64) float64 {
+	if te.config.TrailingStop.Method != TrailingStopPSAR {
+		return atrTrailStop
+	}
+	for _, indSig := range signal.IndicatorSignals {
+		if indSig.Name != "ParabolicSAR_5m" || indSig.Value == 0 {
+			continue
+		}
+		if (signal.Signal == Buy && indSig.Value >= currentPrice) || (signal.Signal == Sell && indSig.Value <= currentPrice) {
+			return atrTrailStop
+		}
+		return indSig.Value
+	}
+	return atrTrailStop
+}
+
 // updateTrailingStops updates ATR trailing stops for open positions
 func (te *TradeExecutor) updateTrailingStops(currentPrice, newATRTrailStop float64) error {
 	if te.currentPosition == nil {
@@ -284,23 +603,24 @@ func (te *TradeExecutor) updateTrailingStops(currentPrice, newATRTrailStop float
 
 	// Update current price and PnL
 	te.currentPosition.CurrentPrice = currentPrice
+	newATRTrailStop = te.symbolFilters.RoundPrice(newATRTrailStop)
+	te.trackExcursion(currentPrice, currentPrice)
 
 	if te.currentPosition.Side == "LONG" {
 		// Long position: trailing stop can only move up
 		if newATRTrailStop > te.currentPosition.ATRTrailStop {
 			te.currentPosition.ATRTrailStop = newATRTrailStop
 			te.currentPosition.StopLoss = newATRTrailStop
-			log.Printf("📈 ATR Trailing Stop Updated: $%.2f -> $%.2f (LONG)", te.currentPosition.StopLoss, newATRTrailStop)
+			executorLog.Debug("📈 ATR trailing stop raised", "symbol", te.currentPosition.Symbol, "side", "LONG", "stop", te.symbolFilters.FormatPrice(newATRTrailStop))
+			te.syncProtectiveStop()
 		}
 
-		// Calculate PnL
-		te.currentPosition.PnL = (currentPrice - te.currentPosition.EntryPrice) * te.currentPosition.Quantity
-		te.currentPosition.PnLPercent = (currentPrice - te.currentPosition.EntryPrice) / te.currentPosition.EntryPrice * 100
+		te.markPosition(currentPrice)
 
 		// Check if stop loss hit
 		if currentPrice <= te.currentPosition.ATRTrailStop {
-			log.Printf("🛑 ATR STOP TRIGGERED: Price $%.2f <= Stop $%.2f", currentPrice, te.currentPosition.ATRTrailStop)
-			return te.closePosition("ATR_STOP", currentPrice, newATRTrailStop)
+			executorLog.Info("🛑 ATR stop triggered", "symbol", te.currentPosition.Symbol, "side", "LONG", "price", te.symbolFilters.FormatPrice(currentPrice), "stop", te.symbolFilters.FormatPrice(te.currentPosition.ATRTrailStop))
+			return te.exitPosition("ATR_STOP", currentPrice, newATRTrailStop)
 		}
 
 	} else if te.currentPosition.Side == "SHORT" {
@@ -308,17 +628,16 @@ func (te *TradeExecutor) updateTrailingStops(currentPrice, newATRTrailStop float
 		if newATRTrailStop < te.currentPosition.ATRTrailStop || te.currentPosition.ATRTrailStop == 0 {
 			te.currentPosition.ATRTrailStop = newATRTrailStop
 			te.currentPosition.StopLoss = newATRTrailStop
-			log.Printf("📉 ATR Trailing Stop Updated: $%.2f -> $%.2f (SHORT)", te.currentPosition.StopLoss, newATRTrailStop)
+			executorLog.Debug("📉 ATR trailing stop lowered", "symbol", te.currentPosition.Symbol, "side", "SHORT", "stop", te.symbolFilters.FormatPrice(newATRTrailStop))
+			te.syncProtectiveStop()
 		}
 
-		// Calculate PnL
-		te.currentPosition.PnL = (te.currentPosition.EntryPrice - currentPrice) * te.currentPosition.Quantity
-		te.currentPosition.PnLPercent = (te.currentPosition.EntryPrice - currentPrice) / te.currentPosition.EntryPrice * 100
+		te.markPosition(currentPrice)
 
 		// Check if stop loss hit
 		if currentPrice >= te.currentPosition.ATRTrailStop {
-			log.Printf("🛑 ATR STOP TRIGGERED: Price $%.2f >= Stop $%.2f", currentPrice, te.currentPosition.ATRTrailStop)
-			return te.closePosition("ATR_STOP", currentPrice, newATRTrailStop)
+			executorLog.Info("🛑 ATR stop triggered", "symbol", te.currentPosition.Symbol, "side", "SHORT", "price", te.symbolFilters.FormatPrice(currentPrice), "stop", te.symbolFilters.FormatPrice(te.currentPosition.ATRTrailStop))
+			return te.exitPosition("ATR_STOP", currentPrice, newATRTrailStop)
 		}
 	}
 
@@ -332,22 +651,37 @@ func (te *TradeExecutor) closePosition(reason string, exitPrice, atrTrailStop fl
 	}
 
 	position := te.currentPosition
-	exitTime := time.Now()
+	exitTime := te.now()
 	duration := exitTime.Sub(position.OpenTime)
-
-	// Calculate final PnL
-	var finalPnL, finalPnLPercent float64
-	if position.Side == "LONG" {
-		finalPnL = (exitPrice - position.EntryPrice) * position.Quantity
-		finalPnLPercent = (exitPrice - position.EntryPrice) / position.EntryPrice * 100
-	} else {
-		finalPnL = (position.EntryPrice - exitPrice) * position.Quantity
-		finalPnLPercent = (position.EntryPrice - exitPrice) / position.EntryPrice * 100
+	exitSide := "SELL"
+	if position.Side == "SHORT" {
+		exitSide = "BUY"
+	}
+	exitPrice = te.simulatedFill(exitPrice, exitSide)
+
+	// Make sure the exit print itself is reflected in the excursion stats
+	te.trackExcursion(exitPrice, exitPrice)
+	te.recordExit(position, reason, exitPrice)
+
+	// Calculate final PnL in the margin currency
+	finalPnL := te.config.Contract.PnL(position.Side, position.EntryPrice, exitPrice, position.Quantity)
+	finalPnLPercent := te.config.Contract.PnLPercent(position.Side, position.EntryPrice, exitPrice)
+	entryFee, entryDiscount := te.fees.charge(te.config.Contract.Notional(position.Quantity, position.EntryPrice), te.entryIsMaker())
+	exitFee, exitDiscount := te.fees.charge(te.config.Contract.Notional(position.Quantity, exitPrice), false)
+	fees := entryFee + exitFee
+	if fees > 0 {
+		finalPnL -= fees
+		finalPnLPercent -= fees / te.config.Contract.Notional(position.Quantity, position.EntryPrice) * 100
+	}
+	te.accrueFunding(exitPrice)
+	if position.Funding != 0 {
+		finalPnL += position.Funding
+		finalPnLPercent += position.Funding / te.config.Contract.Notional(position.Quantity, position.EntryPrice) * 100
 	}
 
 	// Create trade record
 	trade := &Trade{
-		ID:         fmt.Sprintf("trade_%d", time.Now().UnixNano()),
+		ID:         te.nextID("trade"),
 		Symbol:     position.Symbol,
 		Side:       position.Side,
 		EntryPrice: position.EntryPrice,
@@ -361,57 +695,186 @@ func (te *TradeExecutor) closePosition(reason string, exitPrice, atrTrailStop fl
 		Strategy:   position.Strategy,
 		ExitReason: reason,
 		Confidence: position.Confidence,
+		MFE:        position.MFE,
+		MFEPercent: position.MFEPercent,
+		MAE:        position.MAE,
+		MAEPercent: position.MAEPercent,
+		Fees:       fees,
+		Funding:    position.Funding,
+
+		EntryFee:    entryFee,
+		ExitFee:     exitFee,
+		FeeTier:     te.fees.Tier,
+		FeeDiscount: entryDiscount + exitDiscount,
+
+		QuoteCurrency:     te.marginCurrency,
+		ReportingCurrency: te.reportingCurrency,
 	}
 
+	// Convert for reporting with the cached rate: fetching one here would hold
+	// the executor lock across a network call. Without a rate the trade still
+	// closes and records why its reporting PnL is missing.
+	if converted, err := te.converter.ConvertCached(finalPnL, te.marginCurrency, te.reportingCurrency); err != nil {
+		trade.ConversionError = err.Error()
+		executorLog.Warn("PnL conversion failed", "from", te.marginCurrency, "to", te.reportingCurrency, "error", err)
+	} else {
+		trade.PnLReporting = converted
+	}
+
+	// Settle realized PnL into the quote balance
+	te.balances[te.marginCurrency] += finalPnL
+
 	te.tradeHistory = append(te.tradeHistory, trade)
 	te.updatePerformanceStats(trade)
+	te.bookFor(trade.Strategy).recordPnL(finalPnL)
+	if te.tradeObserver != nil {
+		te.tradeObserver(trade)
+	}
+
+	if te.tradeStore != nil {
+		if err := te.tradeStore.Save(te.tradeHistory); err != nil {
+			executorLog.Warn("Failed to persist trade history", "error", err)
+		}
+	}
 
 	// Log the trade
-	pnlSign := "🟢"
+	message := "🟢 Position closed"
 	if finalPnL < 0 {
-		pnlSign = "🔴"
+		message = "🔴 Position closed"
 	}
+	attrs := []any{
+		"symbol", te.config.Symbol, "side", position.Side, "reason", reason,
+		"entry_price", te.symbolFilters.FormatPrice(position.EntryPrice), "exit_price", te.symbolFilters.FormatPrice(exitPrice),
+		"pnl", FormatCurrencyAmount(finalPnL, te.marginCurrency), "pnl_percent", finalPnLPercent,
+	}
+	if te.reportingCurrency != te.marginCurrency && trade.ConversionError == "" {
+		attrs = append(attrs, "pnl_reporting", FormatCurrencyAmount(trade.PnLReporting, te.reportingCurrency))
+	}
+	attrs = append(attrs, "duration", duration, "mfe_percent", position.MFEPercent, "mae_percent", position.MAEPercent,
+		"win_rate", te.performanceStats.WinRate, "total_trades", te.performanceStats.TotalTrades)
+	executorLog.Info(message, attrs...)
 
-	log.Printf("%s POSITION CLOSED: %s %s", pnlSign, position.Side, te.config.Symbol)
-	log.Printf("   💰 Entry: $%.2f -> Exit: $%.2f", position.EntryPrice, exitPrice)
-	log.Printf("   📊 PnL: $%.2f (%.2f%%)", finalPnL, finalPnLPercent)
-	log.Printf("   ⏱️ Duration: %s", duration.String())
-	log.Printf("   🎯 Reason: %s", reason)
-	log.Printf("   📈 Win Rate: %.1f%% (%d/%d trades)", te.performanceStats.WinRate, te.performanceStats.WinningTrades, te.performanceStats.TotalTrades)
-
-	// Clear current position
+	// Clear current position; its loss is now realized
 	te.currentPosition = nil
+	te.riskManager.DailyUnrealizedLoss = 0
+	te.markEquity()
 
 	return nil
 }
 
+// UpdateExcursion updates MFE/MAE of the open position using a candle's high/low range
+func (te *TradeExecutor) UpdateExcursion(candle Candle) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+
+	if te.currentPosition == nil {
+		return
+	}
+
+	// Ignore candles that closed before the position was opened
+	if candle.Timestamp.Add(FiveMinute.Duration()).Before(te.currentPosition.OpenTime) {
+		return
+	}
+
+	te.trackExcursion(candle.High, candle.Low)
+}
+
+// trackExcursion records the best and worst prices seen for the open position (assumes lock is held)
+func (te *TradeExecutor) trackExcursion(high, low float64) {
+	position := te.currentPosition
+	if position == nil || position.EntryPrice == 0 {
+		return
+	}
+
+	best, worst := high, low
+	if position.Side == "SHORT" {
+		best, worst = low, high
+	}
+
+	if favorable := te.config.Contract.PnL(position.Side, position.EntryPrice, best, position.Quantity); favorable > 0 && favorable > position.MFE {
+		position.MFE = favorable
+		position.MFEPercent = math.Abs(best-position.EntryPrice) / position.EntryPrice * 100
+	}
+	if adverse := -te.config.Contract.PnL(position.Side, position.EntryPrice, worst, position.Quantity); adverse > 0 && adverse > position.MAE {
+		position.MAE = adverse
+		position.MAEPercent = math.Abs(worst-position.EntryPrice) / position.EntryPrice * 100
+	}
+}
+
+// markPosition updates the open position's unrealized PnL at price (assumes lock is held)
+func (te *TradeExecutor) markPosition(price float64) {
+	position := te.currentPosition
+	te.accrueFunding(price)
+	position.PnL = te.config.Contract.PnL(position.Side, position.EntryPrice, price, position.Quantity) + position.Funding
+	position.PnLPercent = te.config.Contract.PnLPercent(position.Side, position.EntryPrice, price) +
+		position.Funding/te.config.Contract.Notional(position.Quantity, position.EntryPrice)*100
+	te.updateMargin(price)
+	te.markEquity()
+	te.riskManager.DailyUnrealizedLoss = math.Max(0, -position.PnL) / te.balance
+}
+
+// markEquity updates the equity high-water mark and drawdown from the margin
+// balance plus unrealized PnL (assumes lock is held)
+func (te *TradeExecutor) markEquity() {
+	stats := te.performanceStats
+	equity := te.balances[te.marginCurrency]
+	if te.currentPosition != nil {
+		equity += te.currentPosition.PnL
+	}
+
+	if equity > stats.PeakEquity {
+		stats.PeakEquity = equity
+	}
+	stats.CurrentDrawdown = 0
+	if stats.PeakEquity > 0 {
+		stats.CurrentDrawdown = math.Max(0, (stats.PeakEquity-equity)/stats.PeakEquity)
+	}
+	if stats.CurrentDrawdown > stats.MaxDrawdown {
+		stats.MaxDrawdown = stats.CurrentDrawdown
+	}
+}
+
+// contractType returns the configured settlement type, defaulting to linear
+func (te *TradeExecutor) contractType() string {
+	if te.config.Contract.Inverse() {
+		return ContractInverse
+	}
+	return ContractLinear
+}
+
 // calculatePositionSize calculates position size based on risk management
 func (te *TradeExecutor) calculatePositionSize(entryPrice, stopLoss float64) float64 {
 	if stopLoss == 0 {
 		return 0
 	}
 
-	// Calculate risk per share
-	var riskPerShare float64
-	if stopLoss < entryPrice {
-		// Long position or short with stop above entry
-		riskPerShare = math.Abs(entryPrice - stopLoss)
-	} else {
-		// Short position with stop below entry
-		riskPerShare = math.Abs(stopLoss - entryPrice)
-	}
-
+	// Calculate risk per unit (base unit, or contract for inverse contracts) in the margin currency
+	riskPerShare := te.config.Contract.RiskPerUnit(entryPrice, stopLoss)
 	if riskPerShare == 0 {
 		return 0
 	}
 
-	// Calculate position size based on max position risk
-	maxRiskAmount := te.balance * te.riskManager.MaxPositionSize
+	// Calculate position size based on max position risk, within the strategy's capital slice;
+	// compounding sizes from the realized balance rather than the initial capital
+	book := te.bookFor(ATRStrategyName)
+	capital := te.balance
+	if te.config.Account.Compounding {
+		capital = te.balances[te.marginCurrency]
+	}
+	if book.Allocated() {
+		capital = book.Equity()
+	}
+	maxRiskAmount := capital * book.MaxPositionSize
 	quantity := maxRiskAmount / riskPerShare
 
-	// Ensure minimum viable quantity (for crypto, typically > 0.00001)
-	minQuantity := 0.00001
-	if quantity < minQuantity {
+	// An allocated strategy can't hold more notional than its equity
+	if unitValue := te.config.Contract.Notional(1, entryPrice); book.Allocated() && quantity*unitValue > capital {
+		quantity = capital / unitValue
+	}
+
+	// Round down to the exchange lot size; anything below the minimum lot is not tradeable
+	quantity = te.symbolFilters.RoundQuantity(quantity)
+	if quantity < te.symbolFilters.MinQty {
 		return 0
 	}
 
@@ -422,38 +885,72 @@ func (te *TradeExecutor) calculatePositionSize(entryPrice, stopLoss float64) flo
 func (te *TradeExecutor) checkRiskManagement(signal *TradingSignal) bool {
 	// Check confidence threshold
 	if signal.Confidence < te.riskManager.MinConfidence {
-		log.Printf("🚫 Signal confidence %.3f below minimum %.3f", signal.Confidence, te.riskManager.MinConfidence)
+		executorLog.Debug("🚫 Signal confidence below minimum", "symbol", signal.Symbol, "confidence", signal.Confidence, "min_confidence", te.riskManager.MinConfidence)
 		return false
 	}
 
-	// Check daily loss limit
-	now := time.Now()
-	if now.Sub(te.riskManager.LastResetTime) >= 24*time.Hour {
-		// Reset daily loss tracking
-		te.riskManager.DailyLossUsed = 0
-		te.riskManager.LastResetTime = now
+	// Loss limits only gate entries. While a position is open every signal either
+	// manages it or closes it (Sell while long, Buy while short), so a losing
+	// position keeps trailing and exiting; a close that reverses into a new
+	// position is checked again once flat (see checkReversal)
+	exitOnly := signal.Signal == Hold || te.currentPosition != nil || (signal.Signal == Sell && !te.config.ATR.UseShorts)
+	if err := te.checkLossLimits(); !exitOnly && err != nil {
+		te.reportRiskBlock(err)
+		return false
 	}
+	return true
+}
 
-	if te.riskManager.DailyLossUsed >= te.riskManager.MaxDailyLoss {
-		log.Printf("🚫 Daily loss limit reached: %.2f%% >= %.2f%%", te.riskManager.DailyLossUsed*100, te.riskManager.MaxDailyLoss*100)
-		return false
+// checkLossLimits returns the loss limit a new entry would breach, if any
+// (assumes lock is held)
+func (te *TradeExecutor) checkLossLimits() error {
+	// Check daily loss limit (realized plus the open position's unrealized loss)
+	sessionStart := te.rollSession()
+	if dailyLoss := te.dailyLoss(); dailyLoss >= te.riskManager.MaxDailyLoss {
+		return fmt.Errorf("daily loss limit reached: %.2f%% >= %.2f%%", dailyLoss*100, te.riskManager.MaxDailyLoss*100)
+	}
+
+	// Check the ATR strategy's own daily loss budget
+	if err := te.bookFor(ATRStrategyName).checkDailyLoss(sessionStart); err != nil {
+		return err
 	}
 
 	// Check max drawdown
 	if te.performanceStats.MaxDrawdown >= te.riskManager.MaxDrawdown {
-		log.Printf("🚫 Max drawdown limit reached: %.2f%% >= %.2f%%", te.performanceStats.MaxDrawdown*100, te.riskManager.MaxDrawdown*100)
-		return false
+		return fmt.Errorf("max drawdown limit reached: %.2f%% >= %.2f%%", te.performanceStats.MaxDrawdown*100, te.riskManager.MaxDrawdown*100)
 	}
+	return nil
+}
 
+// checkReversal gates the entry that follows closing the opposite side: it is a
+// new position, so the loss limits apply (assumes lock is held)
+func (te *TradeExecutor) checkReversal() bool {
+	if err := te.checkLossLimits(); err != nil {
+		te.reportRiskBlock(err)
+		return false
+	}
 	return true
 }
 
+// rollSession resets the daily loss once a new session has started and returns
+// the current session's start (assumes lock is held)
+func (te *TradeExecutor) rollSession() time.Time {
+	return te.riskManager.rollSession(te.config.Session)
+}
+
+// dailyLoss is the session's realized loss plus the open position's unrealized
+// loss, as a fraction of balance (assumes lock is held)
+func (te *TradeExecutor) dailyLoss() float64 {
+	return te.riskManager.DailyLossUsed + te.riskManager.DailyUnrealizedLoss
+}
+
 // updatePerformanceStats updates performance statistics
 func (te *TradeExecutor) updatePerformanceStats(trade *Trade) {
 	stats := te.performanceStats
 
 	stats.TotalTrades++
 	stats.TotalPnL += trade.PnL
+	stats.TotalPnLReporting += trade.PnLReporting
 	stats.TotalPnLPercent += trade.PnLPercent
 
 	if trade.PnL > 0 {
@@ -470,10 +967,23 @@ func (te *TradeExecutor) updatePerformanceStats(trade *Trade) {
 		stats.AverageLoss = (stats.AverageLoss*float64(stats.LosingTrades-1) + trade.PnL) / float64(stats.LosingTrades)
 
 		// Update daily loss
+		te.rollSession()
 		dailyLossPercent := math.Abs(trade.PnL) / te.balance
 		te.riskManager.DailyLossUsed += dailyLossPercent
 	}
 
+	// Update excursion averages (winner MAE drives stop placement, loser MFE drives targets)
+	n := float64(stats.TotalTrades)
+	stats.AverageMFEPercent = (stats.AverageMFEPercent*(n-1) + trade.MFEPercent) / n
+	stats.AverageMAEPercent = (stats.AverageMAEPercent*(n-1) + trade.MAEPercent) / n
+	if trade.PnL > 0 {
+		wins := float64(stats.WinningTrades)
+		stats.AverageWinnerMAEPercent = (stats.AverageWinnerMAEPercent*(wins-1) + trade.MAEPercent) / wins
+	} else {
+		losses := float64(stats.LosingTrades)
+		stats.AverageLoserMFEPercent = (stats.AverageLoserMFEPercent*(losses-1) + trade.MFEPercent) / losses
+	}
+
 	// Calculate win rate
 	stats.WinRate = float64(stats.WinningTrades) / float64(stats.TotalTrades) * 100
 
@@ -483,32 +993,80 @@ func (te *TradeExecutor) updatePerformanceStats(trade *Trade) {
 	}
 
 	// Track ATR trades specifically
-	if trade.Strategy == "ATR_PINE_SCRIPT" {
+	if trade.Strategy == ATRStrategyName {
 		stats.ATRTradeCount++
 	}
 
-	stats.LastUpdated = time.Now()
+	stats.LastUpdated = te.now()
+}
+
+// ResetStats clears performance statistics and the account and strategy daily loss
+// counters. Trade history, balances and open positions are kept.
+func (te *TradeExecutor) ResetStats() *PerformanceStats {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+
+	now := te.now()
+	te.performanceStats = &PerformanceStats{LastUpdated: now}
+	te.markEquity() // The high-water mark restarts from current equity
+	te.riskManager.DailyLossUsed = 0
+	te.riskManager.LastResetTime = now
+	for _, book := range te.books {
+		book.DailyLossUsed = 0
+		book.LastResetTime = now
+	}
+
+	executorLog.Info("🧹 Performance stats and daily loss counters reset")
+	stats := *te.performanceStats
+	return &stats
 }
 
 // GetStatus returns current trading status
-func (te *TradeExecutor) GetStatus() interface{} {
+func (te *TradeExecutor) GetStatus() TradingStatus {
 	te.mutex.RLock()
 	defer te.mutex.RUnlock()
 
-	return map[string]interface{}{
-		"enabled":           te.enabled,
-		"balance":           te.balance,
-		"current_position":  te.currentPosition,
-		"open_orders_count": len(te.openOrders),
-		"total_trades":      len(te.tradeHistory),
-		"performance":       te.performanceStats,
-		"risk_management":   te.riskManager,
-		"strategy":          "Pine Script ATR Trailing Stops",
-		"atr_config": map[string]interface{}{
-			"period":     te.config.ATR.Period,
-			"multiplier": te.config.ATR.Multiplier,
-			"use_shorts": te.config.ATR.UseShorts,
-		},
+	_, maintenancePaused := te.maintenance.EntriesPaused(te.now())
+
+	balances := make(map[string]float64, len(te.balances))
+	for currency, amount := range te.balances {
+		balances[currency] = amount
+	}
+	holdings := make(map[string]float64, len(te.holdings))
+	for symbol, quantity := range te.holdings {
+		holdings[symbol] = quantity
+	}
+	hedges := make(map[string]*HedgePosition, len(te.hedges))
+	for symbol, hedge := range te.hedges {
+		h := *hedge
+		hedges[symbol] = &h
+	}
+	var position *Position
+	if te.currentPosition != nil {
+		p := *te.currentPosition
+		position = &p
+	}
+
+	return TradingStatus{
+		Enabled:           te.enabled,
+		SafeMode:          te.safeMode,
+		MaintenancePaused: maintenancePaused,
+		Balance:           te.balance,
+		Balances:          balances,
+		Holdings:          holdings,
+		Strategies:        te.copyStrategyBooks(),
+		Hedges:            hedges,
+		BaseCurrency:      te.baseCurrency,
+		QuoteCurrency:     te.quoteCurrency,
+		MarginCurrency:    te.marginCurrency,
+		ReportingCurrency: te.reportingCurrency,
+		CurrentPosition:   position,
+		OpenOrdersCount:   len(te.openOrders),
+		TotalTrades:       len(te.tradeHistory),
+		Performance:       *te.performanceStats,
+		RiskManagement:    *te.riskManager,
+		Strategy:          "Pine Script ATR Trailing Stops",
+		ATRConfig:         te.config.ATR,
 	}
 }
 
@@ -532,12 +1090,308 @@ func (te *TradeExecutor) GetTradeHistory(limit int) []*Trade {
 	return te.tradeHistory[startIdx:]
 }
 
+// ExecuteIntent fills a strategy-layer order intent against the spot holdings ledger
+// (BUY/SELL) or the perpetual hedge ledger (SHORT/COVER), applying the same enable
+// switch, loss limits and exchange filters as signal trades
+func (te *TradeExecutor) ExecuteIntent(intent OrderIntent) error {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+
+	if !te.enabled {
+		return fmt.Errorf("trade execution disabled")
+	}
+	if te.safeMode && (intent.Side == "BUY" || intent.Side == "SHORT") {
+		return fmt.Errorf("safe mode active: %s %s blocked", intent.Side, intent.Symbol)
+	}
+	if reason, paused := te.maintenance.EntriesPaused(te.now()); paused && (intent.Side == "BUY" || intent.Side == "SHORT") {
+		return fmt.Errorf("%s: %s %s blocked", reason, intent.Side, intent.Symbol)
+	}
+	sessionStart := te.rollSession()
+	if dailyLoss := te.dailyLoss(); dailyLoss >= te.riskManager.MaxDailyLoss {
+		return fmt.Errorf("daily loss limit reached: %.2f%% >= %.2f%%", dailyLoss*100, te.riskManager.MaxDailyLoss*100)
+	}
+	book := te.bookFor(intent.Strategy)
+	if err := book.checkDailyLoss(sessionStart); err != nil {
+		return err
+	}
+	if intent.Quantity <= 0 || intent.Price <= 0 {
+		return fmt.Errorf("invalid intent: quantity %.8f at price %.8f", intent.Quantity, intent.Price)
+	}
+
+	_, quote, err := SplitSymbol(intent.Symbol)
+	if err != nil {
+		return err
+	}
+	if quote != te.quoteCurrency {
+		return fmt.Errorf("%s is quoted in %s, executor accounts in %s", intent.Symbol, quote, te.quoteCurrency)
+	}
+
+	filters := SymbolFiltersFor(te.config, intent.Symbol)
+	if intent.Symbol == te.config.Symbol {
+		filters = te.symbolFilters
+	}
+
+	// Allocated strategies can only commit their own slice of capital
+	available := te.balances[quote]
+	if book.Allocated() {
+		available = math.Min(available, book.AvailableCash())
+	}
+
+	quantity := intent.Quantity
+	switch intent.Side {
+	case "BUY", "SHORT":
+		if existing, exists := te.hedges[intent.Symbol]; intent.Side == "SHORT" && exists && existing.Strategy != intent.Strategy {
+			return fmt.Errorf("hedge on %s is owned by %s", intent.Symbol, existing.Strategy)
+		}
+		// Hedges are 1x margined: total hedge notional can't exceed available cash
+		if intent.Side == "SHORT" {
+			for _, hedge := range te.hedges {
+				available -= hedge.Quantity * hedge.EntryPrice
+			}
+		}
+		if cost := quantity * intent.Price; cost > available {
+			quantity = available / intent.Price
+		}
+		if intent.Side == "BUY" {
+			if quantity, err = te.limitCorrelation(intent.Symbol, quantity, intent.Price); err != nil {
+				return err
+			}
+		}
+	case "SELL":
+		// Spot only: never sell more than the strategy holds
+		held := te.holdings[intent.Symbol]
+		if book.Allocated() {
+			held = math.Min(held, book.Holdings[intent.Symbol])
+		}
+		if quantity > held {
+			quantity = held
+		}
+	case "COVER":
+		hedge, exists := te.hedges[intent.Symbol]
+		if !exists || hedge.Strategy != intent.Strategy {
+			return fmt.Errorf("no %s hedge on %s to cover", intent.Strategy, intent.Symbol)
+		}
+		if quantity > hedge.Quantity {
+			quantity = hedge.Quantity
+		}
+	default:
+		return fmt.Errorf("invalid intent side: %s", intent.Side)
+	}
+
+	quantity = filters.RoundQuantity(quantity)
+	if err := filters.ValidateOrder(quantity, intent.Price); err != nil {
+		return fmt.Errorf("order rejected: %w", err)
+	}
+
+	notional := quantity * intent.Price
+	switch intent.Side {
+	case "BUY":
+		te.balances[quote] -= notional
+		te.holdings[intent.Symbol] += quantity
+		book.Holdings[intent.Symbol] += quantity
+		book.CostBasis[intent.Symbol] += notional
+	case "SELL":
+		te.balances[quote] += notional
+		te.holdings[intent.Symbol] -= quantity
+		if te.holdings[intent.Symbol] <= 0 {
+			delete(te.holdings, intent.Symbol)
+		}
+
+		// Realize PnL against the strategy's average cost
+		if held := book.Holdings[intent.Symbol]; held > 0 {
+			relieved := book.CostBasis[intent.Symbol] * math.Min(quantity/held, 1)
+			book.CostBasis[intent.Symbol] -= relieved
+			book.Holdings[intent.Symbol] -= quantity
+			if book.Holdings[intent.Symbol] <= 1e-12 {
+				delete(book.Holdings, intent.Symbol)
+				delete(book.CostBasis, intent.Symbol)
+			}
+			book.recordPnL(notional - relieved)
+		}
+	case "SHORT":
+		te.openHedge(intent, quantity)
+	case "COVER":
+		pnl := te.coverHedge(intent.Symbol, quantity, intent.Price)
+		te.balances[quote] += pnl
+		book.recordPnL(pnl)
+	}
+
+	now := te.now()
+	te.orderHistory = append(te.orderHistory, &Order{
+		ID:          te.nextID("order"),
+		Symbol:      intent.Symbol,
+		Side:        intent.Side,
+		Type:        "MARKET",
+		Quantity:    quantity,
+		Price:       intent.Price,
+		Status:      "FILLED",
+		CreatedTime: now,
+		FilledTime:  now,
+		Strategy:    intent.Strategy,
+	})
+
+	executorLog.Info("🧩 Strategy intent executed", "strategy", intent.Strategy, "side", intent.Side, "quantity", quantity,
+		"symbol", intent.Symbol, "price", intent.Price, "notional", FormatCurrencyAmount(notional, quote), "reason", intent.Reason)
+	return nil
+}
+
+// bookFor returns the strategy's book, creating it from the configured allocation
+func (te *TradeExecutor) bookFor(strategy string) *StrategyBook {
+	book, exists := te.books[strategy]
+	if !exists {
+		book = newStrategyBook(strategy, te.config.StrategyAllocations[strategy], te.balance, te.riskManager)
+		book.LastResetTime = te.now()
+		te.books[strategy] = book
+	}
+	return book
+}
+
+// GetStrategyPortfolio returns the cash and holdings a strategy may trade with:
+// its own slice when allocated, otherwise the shared account
+func (te *TradeExecutor) GetStrategyPortfolio(strategy string) (float64, map[string]float64) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+
+	book := te.bookFor(strategy)
+	source := te.holdings
+	cash := te.balances[te.quoteCurrency]
+	if book.Allocated() {
+		source = book.Holdings
+		cash = math.Min(cash, book.AvailableCash())
+	}
+
+	holdings := make(map[string]float64, len(source))
+	for symbol, quantity := range source {
+		holdings[symbol] = quantity
+	}
+	return cash, holdings
+}
+
+// GetStrategyBooks returns capital, PnL and risk budget per strategy
+func (te *TradeExecutor) GetStrategyBooks() map[string]*StrategyBook {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+	return te.copyStrategyBooks()
+}
+
+// copyStrategyBooks deep-copies all books; callers must hold the mutex
+func (te *TradeExecutor) copyStrategyBooks() map[string]*StrategyBook {
+	books := make(map[string]*StrategyBook, len(te.books))
+	for name, book := range te.books {
+		books[name] = book.copy()
+	}
+	return books
+}
+
+// GetHoldings returns spot holdings (base quantity per symbol) from strategy-layer orders
+func (te *TradeExecutor) GetHoldings() map[string]float64 {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	holdings := make(map[string]float64, len(te.holdings))
+	for symbol, quantity := range te.holdings {
+		holdings[symbol] = quantity
+	}
+	return holdings
+}
+
+// GetOrderHistory returns recent strategy-layer fills
+func (te *TradeExecutor) GetOrderHistory(limit int) []*Order {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	if limit <= 0 || limit > len(te.orderHistory) {
+		return te.orderHistory
+	}
+	return te.orderHistory[len(te.orderHistory)-limit:]
+}
+
+// QuoteCurrency returns the currency prices are quoted in
+func (te *TradeExecutor) QuoteCurrency() string {
+	return te.quoteCurrency
+}
+
+// MarginCurrency returns the currency balances and PnL are accounted in
+func (te *TradeExecutor) MarginCurrency() string {
+	return te.marginCurrency
+}
+
+// SetRateProvider sets the source of exchange rates used for PnL reporting conversion
+func (te *TradeExecutor) SetRateProvider(rates RateProvider) {
+	te.mutex.Lock()
+	te.converter = NewCurrencyConverter(rates)
+	te.mutex.Unlock()
+	te.refreshRates()
+}
+
+// refreshRates caches the margin -> reporting currency rate used when trades
+// close; startRateRefresh repeats it every rateRefreshInterval. Call it
+// without holding te.mutex: the rate provider may hit the exchange.
+func (te *TradeExecutor) refreshRates() {
+	te.mutex.RLock()
+	converter, from, to := te.converter, te.marginCurrency, te.reportingCurrency
+	te.mutex.RUnlock()
+
+	if err := converter.Refresh(from, to); err != nil {
+		executorLog.Warn("Failed to refresh exchange rate", "from", from, "to", to, "error", err)
+	}
+}
+
+// GetBalances returns realized balances per currency
+func (te *TradeExecutor) GetBalances() map[string]float64 {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+
+	balances := make(map[string]float64, len(te.balances))
+	for currency, amount := range te.balances {
+		balances[currency] = amount
+	}
+	return balances
+}
+
+// resolveCurrencies determines base/quote currencies from config, defaulting to USDT quote
+func resolveCurrencies(config Config) (base, quote string) {
+	base, quote, err := SplitSymbol(config.Symbol)
+	if err != nil {
+		executorLog.Warn("Unknown quote currency, assuming USDT", "symbol", config.Symbol, "error", err)
+		base, quote = strings.ToUpper(config.Symbol), "USDT"
+	}
+	if config.QuoteCurrency != "" {
+		quote = strings.ToUpper(config.QuoteCurrency)
+		base = strings.TrimSuffix(strings.ToUpper(config.Symbol), quote)
+	}
+	return base, quote
+}
+
+// SetSymbolFilters applies exchange trading rules used for order rounding and validation
+func (te *TradeExecutor) SetSymbolFilters(filters *SymbolFilters) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.symbolFilters = filters
+	executorLog.Info("📏 Symbol filters loaded", "symbol", filters.Symbol, "tick_size", filters.TickSize, "step_size", filters.StepSize,
+		"min_qty", filters.MinQty, "min_notional", filters.MinNotional)
+}
+
+// SetMinConfidence changes the confidence a signal needs to open a trade
+func (te *TradeExecutor) SetMinConfidence(confidence float64) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.riskManager.MinConfidence = confidence
+}
+
+// GetSymbolFilters returns the active exchange trading rules
+func (te *TradeExecutor) GetSymbolFilters() *SymbolFilters {
+	te.mutex.RLock()
+	defer te.mutex.RUnlock()
+	return te.symbolFilters
+}
+
 // Enable enables trade execution
 func (te *TradeExecutor) Enable() {
 	te.mutex.Lock()
 	defer te.mutex.Unlock()
 	te.enabled = true
-	log.Printf("🟢 Trade execution ENABLED - Pine Script ATR strategy active")
+	executorLog.Info("🟢 Trade execution enabled")
 }
 
 // Disable disables trade execution
@@ -545,7 +1399,137 @@ func (te *TradeExecutor) Disable() {
 	te.mutex.Lock()
 	defer te.mutex.Unlock()
 	te.enabled = false
-	log.Printf("🔴 Trade execution DISABLED - Pine Script ATR strategy paused")
+	executorLog.Info("🔴 Trade execution disabled")
+}
+
+// SetClock replaces the executor's and risk manager's time source, e.g. with a
+// SimClock following candle time
+func (te *TradeExecutor) SetClock(clock Clock) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.clock = clock
+	te.riskManager.clock = clock
+	te.riskManager.LastResetTime = te.config.Session.SessionStart(clock.Now())
+	if clocked, ok := te.orders.(interface{ SetClock(Clock) }); ok {
+		clocked.SetClock(clock)
+	}
+	te.performanceStats.LastUpdated = clock.Now()
+	for _, book := range te.books {
+		book.LastResetTime = clock.Now()
+	}
+}
+
+// SetSimulatedCosts charges a fee (% of notional) and an adverse price move
+// (basis points) on every fill of the signal strategy; used by backtests. An
+// enabled fee tier takes precedence over feePercent.
+func (te *TradeExecutor) SetSimulatedCosts(feePercent, slippageBps float64) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	if !te.config.Fees.Enabled {
+		te.fees = flatFeeSchedule(feePercent)
+	}
+	te.slippageRate = slippageBps / 10000
+}
+
+// simulatedFill moves price against a BUY or SELL by the simulated slippage
+func (te *TradeExecutor) simulatedFill(price float64, side string) float64 {
+	if side == "BUY" {
+		return price * (1 + te.slippageRate)
+	}
+	return price * (1 - te.slippageRate)
+}
+
+// now returns the current time from the executor's clock
+func (te *TradeExecutor) now() time.Time {
+	return te.clock.Now()
+}
+
+// nextID returns a unique position, trade or order ID; the sequence keeps IDs
+// apart under a simulated or frozen clock (assumes lock is held)
+func (te *TradeExecutor) nextID(prefix string) string {
+	te.sequence++
+	return fmt.Sprintf("%s_%d_%d", prefix, te.now().UnixNano(), te.sequence)
+}
+
+// SetSafeMode blocks (or re-allows) new entries while the exchange is unhealthy
+func (te *TradeExecutor) SetSafeMode(active bool) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.safeMode = active
+}
+
+// SetErrorReporter receives a RISK_BLOCKED error whenever a risk limit blocks a
+// trade and a LIQUIDATION_RISK error when a position nears liquidation
+func (te *TradeExecutor) SetErrorReporter(reporter func(*EngineError)) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.errorReporter = reporter
+}
+
+// SetTradeObserver registers a callback for closed trades (called with the executor locked)
+func (te *TradeExecutor) SetTradeObserver(observer func(*Trade)) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.tradeObserver = observer
+}
+
+// reportRiskBlock logs a risk limit rejection and forwards it to the error reporter
+func (te *TradeExecutor) reportRiskBlock(err error) {
+	executorLog.Warn("🚫 Risk limit blocked entry", "error", err)
+	if te.errorReporter != nil {
+		engineErr := NewEngineError(ErrRiskBlocked, SeverityWarning, "risk", err)
+		engineErr.Time = te.now()
+		te.errorReporter(engineErr)
+	}
+}
+
+// SetMaintenanceCalendar pauses new entries around scheduled exchange downtime
+func (te *TradeExecutor) SetMaintenanceCalendar(calendar *MaintenanceCalendar) {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+	te.maintenance = calendar
+}
+
+// CancelOpenOrders cancels all working orders and returns how many were cancelled
+func (te *TradeExecutor) CancelOpenOrders() int {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+
+	cancelled := 0
+	for _, id := range sortedKeys(te.openOrders) {
+		order, ok := te.openOrders[id]
+		if !ok || id == te.inFlight {
+			continue
+		}
+		if err := te.cancelOnExchange(order); err != nil {
+			executorLog.Warn("Failed to cancel order", "order_id", order.ExchangeID, "error", err)
+			continue
+		}
+		if _, open := te.openOrders[id]; !open {
+			continue
+		}
+		order.Status = "CANCELLED"
+		delete(te.openOrders, id)
+		cancelled++
+	}
+	return cancelled
+}
+
+// FlattenPosition closes the open position at its last marked price, for use
+// when no fresh price is available
+func (te *TradeExecutor) FlattenPosition(reason string) error {
+	te.mutex.Lock()
+	defer te.mutex.Unlock()
+
+	if te.currentPosition == nil {
+		return nil
+	}
+
+	price := te.currentPosition.CurrentPrice
+	if price <= 0 {
+		price = te.currentPosition.EntryPrice
+	}
+	return te.exitPosition(reason, price, te.currentPosition.ATRTrailStop)
 }
 
 // ForceClosePosition manually closes current position
@@ -557,5 +1541,5 @@ func (te *TradeExecutor) ForceClosePosition(currentPrice float64) error {
 		return fmt.Errorf("no open position to close")
 	}
 
-	return te.closePosition("MANUAL", currentPrice, te.currentPosition.ATRTrailStop)
+	return te.exitPosition("MANUAL", currentPrice, te.currentPosition.ATRTrailStop)
 }
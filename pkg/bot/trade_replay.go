@@ -0,0 +1,222 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	replayContextCandles = 20  // 5m candles kept before entry and after exit
+	replaySignalBuffer   = 500 // Recent signals kept while waiting for trades to close
+)
+
+// TradeReplay is everything the bot saw between entry and exit of a closed trade
+type TradeReplay struct {
+	TradeID    string           `json:"trade_id"`
+	Trade      *Trade           `json:"trade"`
+	Timeframe  string           `json:"timeframe"`
+	Candles    []Candle         `json:"candles"`
+	Signals    []*TradingSignal `json:"signals"`
+	EntryIndex int              `json:"entry_index"` // Index into Candles of the entry candle (-1 if not found)
+	ExitIndex  int              `json:"exit_index"`  // Index into Candles of the exit candle (-1 if not found)
+	CapturedAt time.Time        `json:"captured_at"`
+	Pending    bool             `json:"pending,omitempty"` // Still collecting the candles after the exit
+}
+
+// TradeReplayRecorder keeps the candles and signals spanning each closed trade
+type TradeReplayRecorder struct {
+	signals  []*TradingSignal
+	replays  map[string]*TradeReplay
+	restored map[string]bool // Trades closed before this process started; their candles and signals are gone
+	filename string          // JSON file replays are persisted to (empty disables)
+	mutex    sync.RWMutex
+}
+
+// NewTradeReplayRecorder creates a replay recorder persisting to filename
+// (empty disables) and loads the replays saved there. restored are the trades
+// already closed at startup: replays are never built for them.
+func NewTradeReplayRecorder(filename string, restored []*Trade) *TradeReplayRecorder {
+	rr := &TradeReplayRecorder{
+		signals:  make([]*TradingSignal, 0),
+		replays:  make(map[string]*TradeReplay),
+		restored: make(map[string]bool, len(restored)),
+		filename: filename,
+	}
+	for _, trade := range restored {
+		rr.restored[trade.ID] = true
+	}
+
+	if filename != "" {
+		if err := rr.load(); err != nil {
+			executorLog.Error("Failed to restore trade replays", "error", err)
+		} else if len(rr.replays) > 0 {
+			executorLog.Info("📂 Restored trade replays", "count", len(rr.replays), "file", filename)
+		}
+	}
+	return rr
+}
+
+// tradeReplayFile is where replays are stored next to a trade history file,
+// e.g. trades.json → trades.replays.json
+func tradeReplayFile(historyFile string) string {
+	if historyFile == "" {
+		return ""
+	}
+	ext := filepath.Ext(historyFile)
+	return strings.TrimSuffix(historyFile, ext) + ".replays" + ext
+}
+
+// load reads persisted replays; a missing file yields none
+func (rr *TradeReplayRecorder) load() error {
+	data, err := os.ReadFile(rr.filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read trade replays: %w", err)
+	}
+	if err := json.Unmarshal(data, &rr.replays); err != nil {
+		return fmt.Errorf("failed to parse trade replays: %w", err)
+	}
+	return nil
+}
+
+// RecordSignal buffers a generated signal so it can be attached to later replays
+func (rr *TradeReplayRecorder) RecordSignal(signal *TradingSignal) {
+	if signal == nil {
+		return
+	}
+
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	rr.signals = append(rr.signals, signal)
+	if len(rr.signals) > replaySignalBuffer {
+		rr.signals = rr.signals[len(rr.signals)-replaySignalBuffer:]
+	}
+}
+
+// Capture starts the replay of a trade as it closes. The candles after the
+// exit haven't happened yet, so the replay stays pending until Update has
+// added them.
+func (rr *TradeReplayRecorder) Capture(trade *Trade, candles []Candle) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	if _, exists := rr.replays[trade.ID]; exists || rr.restored[trade.ID] {
+		return
+	}
+	rr.replays[trade.ID] = rr.buildReplay(trade, candles)
+	rr.save()
+}
+
+// Update adds newly arrived candles and signals to the pending replays
+func (rr *TradeReplayRecorder) Update(candles []Candle) {
+	rr.mutex.Lock()
+	defer rr.mutex.Unlock()
+
+	updated := 0
+	for _, replay := range rr.replays {
+		if !replay.Pending {
+			continue
+		}
+		rr.extend(replay, candles)
+		updated++
+	}
+	if updated > 0 {
+		rr.save()
+	}
+}
+
+// save persists the replays (assumes lock is held)
+func (rr *TradeReplayRecorder) save() {
+	if rr.filename == "" {
+		return
+	}
+	if err := writeJSONFile(rr.filename, rr.replays); err != nil {
+		executorLog.Error("Failed to save trade replays", "error", err)
+	}
+}
+
+// replayWindow is the span of a trade's replay: its candles plus the context on either side
+func replayWindow(trade *Trade) (time.Time, time.Time) {
+	padding := time.Duration(replayContextCandles) * FiveMinute.Duration()
+	return trade.EntryTime.Truncate(FiveMinute.Duration()).Add(-padding), trade.ExitTime.Add(padding)
+}
+
+// buildReplay slices candles and signals to the window around a trade
+func (rr *TradeReplayRecorder) buildReplay(trade *Trade, candles []Candle) *TradeReplay {
+	replay := &TradeReplay{
+		TradeID:    trade.ID,
+		Trade:      trade,
+		Timeframe:  FiveMinute.String(),
+		Candles:    make([]Candle, 0),
+		Signals:    make([]*TradingSignal, 0),
+		EntryIndex: -1,
+		ExitIndex:  -1,
+		CapturedAt: trade.ExitTime,
+	}
+	rr.extend(replay, candles)
+	return replay
+}
+
+// extend appends the window's candles and signals newer than the replay's
+// last ones, and keeps the replay pending until the context after the exit is in
+func (rr *TradeReplayRecorder) extend(replay *TradeReplay, candles []Candle) {
+	trade := replay.Trade
+	windowStart, windowEnd := replayWindow(trade)
+
+	afterExit := 0
+	for _, candle := range candles {
+		if candle.Timestamp.Before(windowStart) || candle.Timestamp.After(windowEnd) {
+			continue
+		}
+		if n := len(replay.Candles); n > 0 && !candle.Timestamp.After(replay.Candles[n-1].Timestamp) {
+			continue
+		}
+		replay.Candles = append(replay.Candles, candle)
+
+		// Mark the candles that contain the entry and exit times
+		candleEnd := candle.Timestamp.Add(FiveMinute.Duration())
+		idx := len(replay.Candles) - 1
+		if !trade.EntryTime.Before(candle.Timestamp) && trade.EntryTime.Before(candleEnd) {
+			replay.EntryIndex = idx
+		}
+		if !trade.ExitTime.Before(candle.Timestamp) && trade.ExitTime.Before(candleEnd) {
+			replay.ExitIndex = idx
+		}
+	}
+	for _, candle := range replay.Candles {
+		if candle.Timestamp.After(trade.ExitTime) {
+			afterExit++
+		}
+	}
+	replay.Pending = afterExit < replayContextCandles
+
+	for _, signal := range rr.signals {
+		if signal.Timestamp.Before(windowStart) || signal.Timestamp.After(windowEnd) {
+			continue
+		}
+		if n := len(replay.Signals); n > 0 && !signal.Timestamp.After(replay.Signals[n-1].Timestamp) {
+			continue
+		}
+		replay.Signals = append(replay.Signals, signal)
+	}
+}
+
+// GetReplay returns the replay for a trade ID
+func (rr *TradeReplayRecorder) GetReplay(tradeID string) (*TradeReplay, error) {
+	rr.mutex.RLock()
+	defer rr.mutex.RUnlock()
+
+	replay, exists := rr.replays[tradeID]
+	if !exists {
+		return nil, fmt.Errorf("no replay recorded for trade %s", tradeID)
+	}
+	return replay, nil
+}
@@ -0,0 +1,108 @@
+package bot
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTradeReplayCapture(t *testing.T) {
+	t.Log("🎬 Testing trade replay capture")
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]Candle, 0, 100)
+	for i := 0; i < 100; i++ {
+		price := 100.0 + float64(i)
+		candles = append(candles, Candle{
+			Timestamp: start.Add(time.Duration(i) * 5 * time.Minute),
+			Open:      price, High: price + 1, Low: price - 1, Close: price,
+		})
+	}
+
+	trade := &Trade{
+		ID:        "trade_1",
+		EntryTime: start.Add(50*5*time.Minute + time.Minute), // inside candle 50
+		ExitTime:  start.Add(60*5*time.Minute + time.Minute), // inside candle 60
+	}
+
+	recorder := NewTradeReplayRecorder("", nil)
+	recorder.RecordSignal(&TradingSignal{Signal: Buy, Timestamp: trade.EntryTime})
+	recorder.RecordSignal(&TradingSignal{Signal: Sell, Timestamp: trade.ExitTime})
+	recorder.RecordSignal(&TradingSignal{Signal: Hold, Timestamp: start}) // outside window
+
+	// At the close only the candles up to the exit exist; the rest arrive later
+	recorder.Capture(trade, candles[:61])
+	replay, err := recorder.GetReplay("trade_1")
+	if err != nil {
+		t.Fatalf("Expected replay for trade_1: %v", err)
+	}
+	if !replay.Pending || len(replay.Candles) != 31 {
+		t.Fatalf("Expected a pending replay of 31 candles at the close, got %d (pending %v)", len(replay.Candles), replay.Pending)
+	}
+	recorder.RecordSignal(&TradingSignal{Signal: Buy, Timestamp: trade.ExitTime.Add(time.Hour)})
+	recorder.Update(candles[:75])
+	if !replay.Pending {
+		t.Errorf("Expected the replay to wait for %d candles after the exit", replayContextCandles)
+	}
+	recorder.Update(candles)
+	if replay.Pending {
+		t.Errorf("Expected the replay to complete once the candles after the exit arrived")
+	}
+
+	// 20 candles of context on each side of the 11 candles spanning the trade
+	if len(replay.Candles) != 51 {
+		t.Errorf("Expected 51 candles in replay, got %d", len(replay.Candles))
+	}
+	if replay.EntryIndex < 0 || !replay.Candles[replay.EntryIndex].Timestamp.Equal(candles[50].Timestamp) {
+		t.Errorf("Entry candle not marked correctly (index %d)", replay.EntryIndex)
+	}
+	if replay.ExitIndex < 0 || !replay.Candles[replay.ExitIndex].Timestamp.Equal(candles[60].Timestamp) {
+		t.Errorf("Exit candle not marked correctly (index %d)", replay.ExitIndex)
+	}
+	if len(replay.Signals) != 3 {
+		t.Errorf("Expected 3 signals within the replay window, got %d", len(replay.Signals))
+	}
+
+	if _, err := recorder.GetReplay("missing"); err == nil {
+		t.Errorf("Expected error for unknown trade ID")
+	}
+}
+
+func TestTradeReplayPersistence(t *testing.T) {
+	t.Log("🎬 Testing trade replay persistence across restarts")
+
+	filename := tradeReplayFile(filepath.Join(t.TempDir(), "trades.json"))
+	if filepath.Base(filename) != "trades.replays.json" {
+		t.Fatalf("Expected replays next to trades.json, got %s", filename)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := []Candle{{Timestamp: start, Open: 100, High: 101, Low: 99, Close: 100}}
+	old := &Trade{ID: "old", EntryTime: start, ExitTime: start.Add(time.Minute)}
+	recorder := NewTradeReplayRecorder(filename, nil)
+	recorder.Capture(old, candles)
+
+	// After a restart the replay is served from disk...
+	recorder = NewTradeReplayRecorder(filename, []*Trade{old})
+	replay, err := recorder.GetReplay("old")
+	if err != nil {
+		t.Fatalf("Expected persisted replay for old: %v", err)
+	}
+	if len(replay.Candles) != 1 {
+		t.Errorf("Expected 1 persisted candle, got %d", len(replay.Candles))
+	}
+
+	// ...while trades closed before startup without one aren't made up from current data
+	lost := &Trade{ID: "lost", EntryTime: start, ExitTime: start.Add(time.Minute)}
+	recorder = NewTradeReplayRecorder(filename, []*Trade{old, lost})
+	fresh := &Trade{ID: "fresh", EntryTime: start, ExitTime: start.Add(time.Minute)}
+	for _, trade := range []*Trade{old, lost, fresh} {
+		recorder.Capture(trade, candles)
+	}
+	if _, err := recorder.GetReplay("lost"); err == nil {
+		t.Errorf("Expected no replay for a trade closed before startup")
+	}
+	if _, err := recorder.GetReplay("fresh"); err != nil {
+		t.Errorf("Expected replay for a trade closed after startup: %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package bot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TradeStore persists closed trades to a JSON file so history survives restarts
+type TradeStore struct {
+	filename string
+	mutex    sync.Mutex
+}
+
+// NewTradeStore creates a trade store backed by filename
+func NewTradeStore(filename string) *TradeStore {
+	return &TradeStore{filename: filename}
+}
+
+// Load reads persisted trades; a missing file yields an empty history
+func (ts *TradeStore) Load() ([]*Trade, error) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	data, err := os.ReadFile(ts.filename)
+	if os.IsNotExist(err) {
+		return []*Trade{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trade history: %w", err)
+	}
+
+	var trades []*Trade
+	if err := json.Unmarshal(data, &trades); err != nil {
+		return nil, fmt.Errorf("failed to parse trade history: %w", err)
+	}
+	return trades, nil
+}
+
+// Save writes the full trade history, replacing the file atomically
+func (ts *TradeStore) Save(trades []*Trade) error {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+
+	if err := writeJSONFile(ts.filename, trades); err != nil {
+		return fmt.Errorf("failed to save trade history: %w", err)
+	}
+	return nil
+}
+
+// writeJSONFile writes value as indented JSON, replacing filename atomically
+func writeJSONFile(filename string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+"_*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+	return nil
+}
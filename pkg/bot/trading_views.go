@@ -0,0 +1,105 @@
+package bot
+
+import "time"
+
+// PositionView is the API view of an open position. It is a copy, so callers
+// can't change the executor's state through it.
+type PositionView struct {
+	ID           string    `json:"id"`
+	Symbol       string    `json:"symbol"`
+	Side         string    `json:"side"` // "LONG" or "SHORT"
+	EntryPrice   float64   `json:"entry_price"`
+	Quantity     float64   `json:"quantity"`
+	CurrentPrice float64   `json:"current_price"`
+	PnL          float64   `json:"pnl"`
+	PnLPercent   float64   `json:"pnl_percent"`
+	StopLoss     float64   `json:"stop_loss"`
+	TakeProfit   float64   `json:"take_profit"`
+	ATRTrailStop float64   `json:"atr_trail_stop"`
+	OpenTime     time.Time `json:"open_time"`
+	Strategy     string    `json:"strategy"`
+	Confidence   float64   `json:"confidence"`
+}
+
+// NewPositionView returns the view of position, or nil when flat
+func NewPositionView(position *Position) *PositionView {
+	if position == nil {
+		return nil
+	}
+	return &PositionView{
+		ID:           position.ID,
+		Symbol:       position.Symbol,
+		Side:         position.Side,
+		EntryPrice:   position.EntryPrice,
+		Quantity:     position.Quantity,
+		CurrentPrice: position.CurrentPrice,
+		PnL:          position.PnL,
+		PnLPercent:   position.PnLPercent,
+		StopLoss:     position.StopLoss,
+		TakeProfit:   position.TakeProfit,
+		ATRTrailStop: position.ATRTrailStop,
+		OpenTime:     position.OpenTime,
+		Strategy:     position.Strategy,
+		Confidence:   position.Confidence,
+	}
+}
+
+// IsLong reports whether the position is long
+func (pv *PositionView) IsLong() bool {
+	return pv.Side == "LONG"
+}
+
+// InProfit reports whether the position's unrealized PnL is positive
+func (pv *PositionView) InProfit() bool {
+	return pv.PnL > 0
+}
+
+// TradeView is the API view of a closed trade
+type TradeView struct {
+	ID         string    `json:"id"`
+	Symbol     string    `json:"symbol"`
+	Side       string    `json:"side"`
+	EntryPrice float64   `json:"entry_price"`
+	ExitPrice  float64   `json:"exit_price"`
+	Quantity   float64   `json:"quantity"`
+	PnL        float64   `json:"pnl"`
+	PnLPercent float64   `json:"pnl_percent"`
+	EntryTime  time.Time `json:"entry_time"`
+	ExitTime   time.Time `json:"exit_time"`
+	Duration   string    `json:"duration"`
+	Strategy   string    `json:"strategy"`
+	ExitReason string    `json:"exit_reason"`
+}
+
+// NewTradeView returns the view of trade
+func NewTradeView(trade *Trade) TradeView {
+	return TradeView{
+		ID:         trade.ID,
+		Symbol:     trade.Symbol,
+		Side:       trade.Side,
+		EntryPrice: trade.EntryPrice,
+		ExitPrice:  trade.ExitPrice,
+		Quantity:   trade.Quantity,
+		PnL:        trade.PnL,
+		PnLPercent: trade.PnLPercent,
+		EntryTime:  trade.EntryTime,
+		ExitTime:   trade.ExitTime,
+		Duration:   trade.Duration,
+		Strategy:   trade.Strategy,
+		ExitReason: trade.ExitReason,
+	}
+}
+
+// NewTradeViews returns the views of trades, in the same order
+func NewTradeViews(trades []*Trade) []TradeView {
+	views := make([]TradeView, 0, len(trades))
+	for _, trade := range trades {
+		views = append(views, NewTradeView(trade))
+	}
+	return views
+}
+
+// Won reports whether the trade closed with a positive PnL
+func (tv TradeView) Won() bool {
+	return tv.PnL > 0
+}
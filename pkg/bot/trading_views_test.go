@@ -0,0 +1,54 @@
+package bot
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTradingViews(t *testing.T) {
+	t.Log("🪟 Testing position and trade API views")
+
+	if NewPositionView(nil) != nil {
+		t.Errorf("Expected nil view when flat")
+	}
+
+	executor := NewTradeExecutor(DefaultConfig(), 10000.0)
+	signal := &TradingSignal{Symbol: "BTCUSDT", Signal: Buy, Confidence: 0.9, Timestamp: time.Now()}
+	if err := executor.ExecuteSignal(signal, 100.0, 99.0); err != nil {
+		t.Fatalf("Entry failed: %v", err)
+	}
+
+	position := executor.GetCurrentPosition()
+	view := NewPositionView(position)
+	if view.ID != position.ID || !view.IsLong() || view.EntryPrice != position.EntryPrice || view.ATRTrailStop != position.ATRTrailStop {
+		t.Errorf("Position view doesn't match position: %+v", view)
+	}
+	view.StopLoss = 1
+	if executor.GetCurrentPosition().StopLoss == 1 {
+		t.Errorf("Changing the view must not change the position")
+	}
+
+	if err := executor.ForceClosePosition(101.0); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	trades := NewTradeViews(executor.GetTradeHistory(5))
+	if len(trades) != 1 || trades[0].ExitReason != "MANUAL" || trades[0].Won() != (trades[0].PnL > 0) {
+		t.Errorf("Unexpected trade views: %+v", trades)
+	}
+
+	// Views keep the field names of the full types
+	data, err := json.Marshal(trades[0])
+	if err != nil {
+		t.Fatalf("Failed to marshal trade view: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Failed to unmarshal trade view: %v", err)
+	}
+	for _, name := range []string{"id", "entry_price", "exit_price", "pnl", "exit_time", "exit_reason"} {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("Trade view JSON lacks %s", name)
+		}
+	}
+}
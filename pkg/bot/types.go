@@ -1,6 +1,8 @@
 package bot
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -49,6 +51,16 @@ func (t Timeframe) Duration() time.Duration {
 	}
 }
 
+// ParseTimeframe converts a timeframe string ("5m", "15m", "45m", "8h", "1d") to a Timeframe
+func ParseTimeframe(s string) (Timeframe, error) {
+	for _, tf := range []Timeframe{FiveMinute, FifteenMinute, FortyFiveMinute, EightHour, Daily} {
+		if tf.String() == s {
+			return tf, nil
+		}
+	}
+	return FiveMinute, fmt.Errorf("unknown timeframe: %s", s)
+}
+
 // Candle represents OHLCV data for a specific time period
 type Candle struct {
 	Timestamp time.Time `json:"timestamp"`
@@ -107,6 +119,8 @@ type TradingSignal struct {
 	Reasoning        string            `json:"reasoning"`
 	TargetPrice      float64           `json:"target_price,omitempty"`
 	StopLoss         float64           `json:"stop_loss,omitempty"`
+	Price            float64           `json:"price,omitempty"`  // Price the signal was computed at
+	Regime           string            `json:"regime,omitempty"` // Regime profile used (when regime switching is enabled)
 }
 
 // RSIConfig holds RSI parameters
@@ -239,6 +253,53 @@ type ChannelAnalysisConfig struct {
 	SignalBoost      float64 `json:"signal_boost"`      // Boost factor for channel signals (default: 1.4)
 }
 
+// Channel signal modes for the Keltner and Donchian channels
+const (
+	ChannelModeBreakout      = "breakout"       // Follow closes outside the channel
+	ChannelModeMeanReversion = "mean_reversion" // Fade moves into the outer bands
+)
+
+// KeltnerChannelConfig holds Keltner Channel (EMA +/- ATR) parameters
+type KeltnerChannelConfig struct {
+	Enabled    bool    `json:"enabled"`    // Feature flag to enable/disable the Keltner Channel
+	EMAPeriod  int     `json:"ema_period"` // Middle line EMA period (default: 20)
+	ATRPeriod  int     `json:"atr_period"` // ATR period for the band width (default: 10)
+	Multiplier float64 `json:"multiplier"` // Band distance from the middle in ATRs (default: 2)
+	Mode       string  `json:"mode"`       // "breakout" or "mean_reversion" (default)
+}
+
+// DonchianChannelConfig holds Donchian Channel (highest high / lowest low) parameters
+type DonchianChannelConfig struct {
+	Enabled bool   `json:"enabled"` // Feature flag to enable/disable the Donchian Channel
+	Period  int    `json:"period"`  // Previous candles the channel spans (default: 20)
+	Mode    string `json:"mode"`    // "breakout" (default) or "mean_reversion"
+}
+
+// ParabolicSARConfig holds Parabolic SAR (stop and reverse) parameters
+type ParabolicSARConfig struct {
+	Enabled bool    `json:"enabled"`  // Feature flag to enable/disable the Parabolic SAR
+	Step    float64 `json:"step"`     // Acceleration factor start and increment (default: 0.02)
+	MaxStep float64 `json:"max_step"` // Acceleration factor cap (default: 0.2)
+}
+
+// CandlePatternsConfig holds multi-candle pattern detection parameters
+type CandlePatternsConfig struct {
+	Enabled          bool     `json:"enabled"`           // Feature flag to enable/disable candlestick patterns
+	Patterns         []string `json:"patterns"`          // Patterns detected (default: all, see indicator.CandlePatternNames)
+	TweezerTolerance float64  `json:"tweezer_tolerance"` // Max gap between tweezer extremes as a fraction of the average range (default: 0.05)
+}
+
+// Trailing stop methods
+const (
+	TrailingStopATR  = "atr"  // ATR_5m trailing stop (default)
+	TrailingStopPSAR = "psar" // ParabolicSAR_5m level
+)
+
+// TrailingStopConfig selects where open positions' trailing stop comes from
+type TrailingStopConfig struct {
+	Method string `json:"method"` // "atr" (default) or "psar"; psar needs parabolic_sar enabled
+}
+
 // ATRConfig holds Average True Range parameters
 type ATRConfig struct {
 	Enabled    bool    `json:"enabled"`    // Feature flag to enable/disable ATR
@@ -247,6 +308,169 @@ type ATRConfig struct {
 	UseShorts  bool    `json:"use_shorts"` // Allow short signals (default: false for spot trading)
 }
 
+// RebalanceConfig holds passive portfolio rebalancing parameters
+type RebalanceConfig struct {
+	Enabled         bool               `json:"enabled"`          // Feature flag
+	TargetWeights   map[string]float64 `json:"target_weights"`   // Symbol -> target fraction of portfolio (remainder stays in cash)
+	ThresholdBand   float64            `json:"threshold_band"`   // Rebalance a symbol when |weight - target| exceeds this (default: 0.05)
+	IntervalMinutes int                `json:"interval_minutes"` // How often to check weights (default: 60)
+	MinTradeValue   float64            `json:"min_trade_value"`  // Skip adjustments smaller than this quote value (default: 10)
+}
+
+// HedgingConfig holds exposure hedging rules run on the strategy layer
+type HedgingConfig struct {
+	Enabled      bool        `json:"enabled"`        // Feature flag
+	Rules        []HedgeRule `json:"rules"`          // Evaluated in order; each rule manages its own hedge
+	AuditLogFile string      `json:"audit_log_file"` // JSON-lines audit trail of hedge decisions (empty = log only)
+}
+
+// Correlation limit actions for entries that would breach max_concentration
+const (
+	CorrelationActionBlock    = "block"
+	CorrelationActionDownsize = "downsize"
+)
+
+// CorrelationConfig limits how much equity sits in held symbols whose rolling
+// returns move together with a new long entry
+type CorrelationConfig struct {
+	Enabled          bool    `json:"enabled"`           // Feature flag
+	IntervalMinutes  int     `json:"interval_minutes"`  // Return sampling interval (default: 5)
+	Window           int     `json:"window"`            // Returns in the rolling window (default: 96, i.e. 8 hours of 5m returns)
+	Threshold        float64 `json:"threshold"`         // Held symbols correlated at or above this join the entry's cluster (default: 0.7)
+	MaxConcentration float64 `json:"max_concentration"` // Max fraction of equity in one correlated cluster (default: 0.5)
+	Action           string  `json:"action"`            // "block" the entry or "downsize" it to fit (default)
+	BetaBenchmark    string  `json:"beta_benchmark"`    // Symbol /risk scales exposure against (default: BTCUSDT)
+}
+
+// HedgeRule opens a short hedge on a correlated perpetual when long exposure is
+// too large during an adverse regime, and unwinds it when the condition clears
+type HedgeRule struct {
+	Name            string  `json:"name"`
+	MaxLongExposure float64 `json:"max_long_exposure"` // Aggregate long exposure (quote value) that triggers the hedge
+	Regime          string  `json:"regime"`            // Regime that must be active, e.g. "BEARISH" (empty = any)
+	HedgeSymbol     string  `json:"hedge_symbol"`      // Correlated perpetual to short, e.g. "ETHUSDT"
+	HedgeRatio      float64 `json:"hedge_ratio"`       // Fraction of long exposure to hedge (0-1)
+}
+
+// PineConfig lists Pine Script studies run alongside the built-in indicators
+type PineConfig struct {
+	Enabled  bool     `json:"enabled"`  // Feature flag
+	Scripts  []string `json:"scripts"`  // Study files defining buy/sell conditions (supported subset: inputs, ta.*, math.*, crossover)
+	Strength float64  `json:"strength"` // Signal strength reported when a study's buy or sell fires (default: 0.7)
+}
+
+// PluginConfig enables a registered indicator plugin and holds its parameters
+type PluginConfig struct {
+	Enabled bool            `json:"enabled"`
+	Params  json.RawMessage `json:"params,omitempty"` // Decoded into the plugin's parameter struct
+}
+
+// DeterminismConfig makes backtests and optimizer sweeps reproducible byte for byte
+type DeterminismConfig struct {
+	Enabled bool   `json:"enabled"` // Feature flag
+	Seed    int64  `json:"seed"`    // Seed for every random source (sample data, generated test candles)
+	Clock   string `json:"clock"`   // RFC3339 time the frozen wall clock reports (default: 2024-01-01T00:00:00Z)
+}
+
+// SymbolPrecision is a symbol's minimum price and quantity increments
+type SymbolPrecision struct {
+	TickSize float64 `json:"tick_size"`
+	StepSize float64 `json:"step_size,omitempty"` // 0 keeps the default lot step
+}
+
+// PredictionConfig defines the NEUTRAL band predictions are scored against: a
+// move smaller than the round-trip cost plus horizon-scaled noise isn't
+// profitably tradeable
+type PredictionConfig struct {
+	RoundTripCostPercent float64 `json:"round_trip_cost_percent"` // Entry + exit fees and slippage, % of price
+	NeutralATRFraction   float64 `json:"neutral_atr_fraction"`    // Share of the 5m ATR (scaled by sqrt of the horizon in 5m candles) added to the band
+	MildMovePercent      float64 `json:"mild_move_percent"`       // Magnitude buckets: moves within ± this are FLAT
+	StrongMovePercent    float64 `json:"strong_move_percent"`     // Moves beyond ± this are STRONG_UP/STRONG_DOWN
+	HistoryFile          string  `json:"history_file,omitempty"`  // JSON file served predictions and outcomes are persisted to (empty disables)
+}
+
+// ContractConfig describes how the traded contract settles. Linear (USDT-margined)
+// contracts are sized in base units with PnL in the quote currency; inverse
+// (coin-margined) contracts are sized in contracts worth ContractSize quote units
+// each, with margin and PnL in the base coin
+type ContractConfig struct {
+	Type         string  `json:"type"`          // "linear" or "inverse"
+	ContractSize float64 `json:"contract_size"` // Quote value of one inverse contract, e.g. 100 (USD) for BTCUSD perpetuals
+}
+
+// Continuous contract back-adjustment methods
+const (
+	AdjustDifference = "difference" // Shift earlier contracts by the price gap at each roll
+	AdjustRatio      = "ratio"      // Scale earlier contracts by the price ratio at each roll
+)
+
+// ContinuousConfig stitches delivery futures into one gap-free series for Symbol,
+// back-adjusting older contracts so the live contract keeps its real prices
+type ContinuousConfig struct {
+	Enabled    bool     `json:"enabled"`
+	Contracts  []string `json:"contracts"`  // Delivery contracts of Symbol, e.g. BTCUSDT_240329 (Binance) or BTCUSDT-29MAR24 (Bybit)
+	RollDays   int      `json:"roll_days"`  // Days before expiry the series moves to the next contract (default: 3)
+	Adjustment string   `json:"adjustment"` // "difference" (default) or "ratio"
+}
+
+// MarginConfig enables futures margin monitoring for the signal strategy's
+// position, assuming cross margin on the whole margin-currency balance
+type MarginConfig struct {
+	Enabled                  bool    `json:"enabled"`                    // Feature flag (futures mode)
+	Leverage                 float64 `json:"leverage"`                   // Max position notional as a multiple of the balance
+	MaintenanceMarginRate    float64 `json:"maintenance_margin_rate"`    // Maintenance margin as a fraction of notional
+	MaxMarginRatio           float64 `json:"max_margin_ratio"`           // Entries that would push maintenance margin / margin balance above this are blocked
+	LiquidationBufferPercent float64 `json:"liquidation_buffer_percent"` // Alert when price is within this % of the liquidation price
+}
+
+// FundingConfig accrues perpetual funding payments and margin borrow interest
+// into the open position's PnL and its trade record
+type FundingConfig struct {
+	Enabled       bool               `json:"enabled"`         // Feature flag
+	IntervalHours int                `json:"interval_hours"`  // Hours between funding settlements, counted from 00:00 UTC (default: 8)
+	Rate          float64            `json:"rate"`            // Funding rate per settlement when no live rate is known (default: 0.0001); longs pay positive rates
+	Rates         map[string]float64 `json:"rates,omitempty"` // Per-symbol rate overrides
+	BorrowRate    float64            `json:"borrow_rate"`     // Annual interest on the position notional for borrowed margin (default: 0)
+}
+
+// FeeConfig is the account's exchange fee tier. When enabled, the signal strategy's
+// fills are charged its fees, live and paper, and backtests use it in place of
+// backtest.fee_percent.
+type FeeConfig struct {
+	Enabled            bool    `json:"enabled"`              // Feature flag
+	Tier               string  `json:"tier"`                 // Binance USDⓈ-M futures VIP tier, VIP0-VIP9 (default: VIP0)
+	MakerPercent       float64 `json:"maker_percent"`        // Overrides the tier's maker fee, % of notional (0 keeps the tier's)
+	TakerPercent       float64 `json:"taker_percent"`        // Overrides the tier's taker fee, % of notional (0 keeps the tier's)
+	BNBDiscount        bool    `json:"bnb_discount"`         // Fees are paid in BNB at a discount
+	BNBDiscountPercent float64 `json:"bnb_discount_percent"` // Discount for paying in BNB (default: 10, the futures rate; spot is 25)
+}
+
+// ReconciliationConfig polls the exchange account to catch fills and position
+// changes missed by the WebSocket feed
+type ReconciliationConfig struct {
+	Enabled         bool `json:"enabled"`          // Feature flag (requires API keys)
+	IntervalSeconds int  `json:"interval_seconds"` // Time between polls
+}
+
+// LiveTradingConfig sends the signal strategy's orders to Binance instead of
+// filling them in memory. Dry run logs the orders and fills them locally.
+type LiveTradingConfig struct {
+	Enabled    bool   `json:"enabled"`     // Feature flag; must be set explicitly
+	DryRun     bool   `json:"dry_run"`     // Log orders instead of sending them
+	Market     string `json:"market"`      // "futures" (USDT-M) or "spot"
+	OrderType  string `json:"order_type"`  // Entry order type: "MARKET" or "LIMIT" at the signal price
+	PlaceStops bool   `json:"place_stops"` // Keep a reduce-only STOP_MARKET at the ATR stop (futures only)
+	UserStream bool   `json:"user_stream"` // Apply fills from the user data stream as they happen
+}
+
+// ScriptingConfig holds a user rule script run on the strategy layer
+type ScriptingConfig struct {
+	Enabled      bool    `json:"enabled"`       // Feature flag
+	File         string  `json:"file"`          // Rule script; reloaded when the file changes
+	PositionSize float64 `json:"position_size"` // Fraction of the strategy's cash spent per entry (default: 0.1)
+	MaxSteps     int     `json:"max_steps"`     // Evaluation step budget per run (default: 10000)
+}
+
 // BinanceConfig holds Binance API configuration
 type BinanceConfig struct {
 	APIKey     string `json:"api_key"`
@@ -254,8 +478,115 @@ type BinanceConfig struct {
 	UseTestnet bool   `json:"use_testnet"`
 }
 
+// SessionConfig sets the trading day used for daily loss resets, daily
+// accuracy stats and seasonality buckets
+type SessionConfig struct {
+	Timezone  string `json:"timezone"`   // IANA zone, e.g. "America/New_York" (default UTC)
+	StartTime string `json:"start_time"` // Local "HH:MM" the session starts (default 00:00, Binance's day in UTC)
+}
+
+// AccountConfig sets the starting capital and what positions are sized from
+type AccountConfig struct {
+	InitialBalance float64 `json:"initial_balance"`    // Starting balance for paper trading and backtests
+	Currency       string  `json:"currency,omitempty"` // Currency of InitialBalance; must be the margin currency (default: the margin currency)
+	Compounding    bool    `json:"compounding"`        // Size positions from the current balance instead of InitialBalance
+}
+
+// CandleStoreConfig persists candles so they survive restarts and backtests can run offline
+type CandleStoreConfig struct {
+	Enabled bool   `json:"enabled"`
+	Driver  string `json:"driver"` // "sqlite" (default) or "memory"
+	Path    string `json:"path"`   // SQLite database file
+}
+
+// DivergenceConfig adds a divergence signal per timeframe for each listed
+// oscillator whose last two price swings and oscillator values disagree
+type DivergenceConfig struct {
+	Enabled    bool     `json:"enabled"`    // Feature flag
+	Indicators []string `json:"indicators"` // Oscillators checked: rsi, macd, mfi, stochastic (names or aliases)
+	PivotBars  int      `json:"pivot_bars"` // Candles on each side that confirm a swing high/low (default: 3)
+	Lookback   int      `json:"lookback"`   // Candles searched for the last two swings (default: 60)
+	Hidden     bool     `json:"hidden"`     // Also signal hidden (trend continuation) divergences
+}
+
+// HistoryConfig sets how many candles are loaded per timeframe at startup and refresh
+type HistoryConfig struct {
+	// Candles keyed by timeframe ("5m", "1d", ...); timeframes not listed use
+	// the built-in depth
+	Candles map[string]int `json:"candles"`
+
+	// What happens when an enabled indicator needs more candles than are configured
+	// or loaded: "fail" (default) rejects the config and stops startup, "disable"
+	// warns and drops the indicator on that timeframe
+	OnShortfall string `json:"on_shortfall"`
+}
+
+// History shortfall policies
+const (
+	HistoryShortfallFail    = "fail"
+	HistoryShortfallDisable = "disable"
+)
+
+// Depth returns how many candles are loaded for a timeframe
+func (h HistoryConfig) Depth(timeframe Timeframe) int {
+	if count, ok := h.Candles[timeframe.String()]; ok && count > 0 {
+		return count
+	}
+	return historicalCandleCounts[timeframe]
+}
+
+// StreamingConfig controls the Binance WebSocket kline/ticker stream
+type StreamingConfig struct {
+	Enabled           bool `json:"enabled"`             // Stream klines instead of one feed per timeframe (Binance only)
+	StaleSeconds      int  `json:"stale_seconds"`       // Silence after which the stream reconnects and predictions refetch over REST
+	MaxBackoffSeconds int  `json:"max_backoff_seconds"` // Cap on the exponential reconnect delay
+
+	// Feed watchdog: feeds silent past their limit (stale_seconds when streamed, one
+	// interval plus stale_seconds for closed-candle feeds) are flagged degraded and reconnected
+	WatchdogSeconds    int     `json:"watchdog_seconds"`    // Check interval (0 disables the watchdog)
+	DegradedConfidence float64 `json:"degraded_confidence"` // Confidence multiplier while any feed is degraded
+}
+
+// DataQualityConfig scales live signal confidence down when the candles behind
+// it are gapped or came from the REST fallback; stale feeds use
+// streaming.degraded_confidence (1 disables a penalty)
+type DataQualityConfig struct {
+	GapConfidence      float64 `json:"gap_confidence"`      // Multiplier when candles are missing between the ones analyzed
+	FallbackConfidence float64 `json:"fallback_confidence"` // Multiplier while candles come from the REST fallback
+}
+
+// SymbolSelectorConfig picks the most traded pairs per quote currency from the
+// exchange, e.g. the top 10 USDT pairs by 24h volume
+type SymbolSelectorConfig struct {
+	Enabled      bool     `json:"enabled"`
+	Quotes       []string `json:"quotes"`        // Quote currencies, each ranked separately (default: USDT)
+	Top          int      `json:"top"`           // Pairs kept per quote currency (default: 10)
+	Exclude      []string `json:"exclude"`       // Pairs never selected, e.g. stablecoin pairs
+	RefreshHours int      `json:"refresh_hours"` // How often the selection is resolved again (default: 24)
+}
+
+// Signal aggregation modes
+const (
+	StrategyModeFiveMinuteFocus = "5m_focus"        // 5-minute indicators only
+	StrategyModeMultiTimeframe  = "multi_timeframe" // Every timeframe, weighted per timeframe
+)
+
+// StrategyConfig selects how indicator signals are combined into a trading signal
+type StrategyConfig struct {
+	Mode             string             `json:"mode"`              // "5m_focus" (default) or "multi_timeframe"
+	TimeframeWeights map[string]float64 `json:"timeframe_weights"` // multi_timeframe weight per timeframe ("5m", "15m", "45m", "8h", "1d"); 0 leaves it out
+}
+
+// ExchangeCredentials are the API keys of a non-Binance venue
+type ExchangeCredentials struct {
+	APIKey     string `json:"api_key"`
+	SecretKey  string `json:"secret_key"`
+	Passphrase string `json:"passphrase,omitempty"` // Coinbase only
+}
+
 // Config represents the main configuration structure
 type Config struct {
+	Version           int                     `json:"version"` // Schema version; older files are migrated on load
 	RSI               RSIConfig               `json:"rsi"`
 	MACD              MACDConfig              `json:"macd"`
 	Volume            VolumeConfig            `json:"volume"`
@@ -270,9 +601,278 @@ type Config struct {
 	EMA               EMAConfig               `json:"ema"`
 	ElliottWave       ElliottWaveConfig       `json:"elliott_wave"`
 	ChannelAnalysis   ChannelAnalysisConfig   `json:"channel_analysis"`
+	KeltnerChannel    KeltnerChannelConfig    `json:"keltner_channel"`
+	DonchianChannel   DonchianChannelConfig   `json:"donchian_channel"`
+	ParabolicSAR      ParabolicSARConfig      `json:"parabolic_sar"`
+	CandlePatterns    CandlePatternsConfig    `json:"candle_patterns"`
 	ATR               ATRConfig               `json:"atr"`
 	MinConfidence     float64                 `json:"min_confidence"`
+	Strategy          StrategyConfig          `json:"strategy"` // How indicator signals are combined
 	Symbol            string                  `json:"symbol"`
+	Symbols           []string                `json:"symbols,omitempty"` // More markets analyzed alongside Symbol, each with its own engine
 	Binance           BinanceConfig           `json:"binance"`
-	DataProvider      string                  `json:"data_provider"`
+	Coinbase          ExchangeCredentials     `json:"coinbase"`
+	Kraken            ExchangeCredentials     `json:"kraken"`
+	Bybit             ExchangeCredentials     `json:"bybit"`
+	DataProvider      string                  `json:"data_provider"` // "sample" or an exchange: binance, coinbase, kraken, bybit
+	Streaming         StreamingConfig         `json:"streaming"`     // WebSocket klines with REST fallback
+
+	SymbolSelector SymbolSelectorConfig `json:"symbol_selector"` // Symbols picked from the exchange by volume, alongside Symbols
+	DataQuality    DataQualityConfig    `json:"data_quality"`    // Confidence penalties for gapped or fallback candles
+
+	// Candle transform applied before an indicator calculates, keyed by indicator
+	// name or alias, e.g. {"trend": "heikin_ashi"}; unlisted indicators use raw OHLC
+	CandleTransforms map[string]string `json:"candle_transforms,omitempty"`
+
+	Divergence DivergenceConfig `json:"divergence"` // Price/oscillator divergence signals
+
+	// Per-timeframe provider overrides keyed by timeframe ("5m", "1d", ...);
+	// timeframes not listed use DataProvider
+	Providers map[string]TimeframeProviderConfig `json:"providers,omitempty"`
+	DataDir   string                             `json:"data_dir,omitempty"` // Directory for the "file" provider
+
+	CandleStore CandleStoreConfig `json:"candle_store"` // Candle persistence; also selectable per timeframe as the "store" provider
+	History     HistoryConfig     `json:"history"`      // Candle history loaded per timeframe
+
+	QuoteCurrency     string `json:"quote_currency,omitempty"`     // Quote asset of Symbol (derived from Symbol when empty)
+	ReportingCurrency string `json:"reporting_currency,omitempty"` // Currency PnL is reported in (default: USDT)
+
+	Account  AccountConfig  `json:"account"`  // Starting balance and compounding
+	Contract ContractConfig `json:"contract"` // Linear or inverse (coin-margined) settlement
+	Margin   MarginConfig   `json:"margin"`   // Futures leverage caps and liquidation alerts
+	Funding  FundingConfig  `json:"funding"`  // Funding and borrow costs accrued into position PnL
+	Fees     FeeConfig      `json:"fees"`     // Exchange fee tier and BNB discount
+	Session  SessionConfig  `json:"session"`  // Trading-day boundary for daily loss limits
+
+	Continuous ContinuousConfig `json:"continuous"` // Back-adjusted series across delivery futures rolls
+
+	Reconciliation ReconciliationConfig `json:"reconciliation"` // Exchange order/position state polling
+	LiveTrading    LiveTradingConfig    `json:"live_trading"`   // Real order placement on Binance (off by default)
+	TrailingStop   TrailingStopConfig   `json:"trailing_stop"`  // ATR or Parabolic SAR trailing stops
+
+	TradeHistoryFile string         `json:"trade_history_file,omitempty"` // JSON file closed trades are persisted to (empty disables)
+	AlertsFile       string         `json:"alerts_file,omitempty"`        // JSON file price and indicator alerts are persisted to (empty disables)
+	AuditFile        string         `json:"audit_file,omitempty"`         // JSON-lines file the audit log is appended to (empty keeps it in memory)
+	BacktestDir      string         `json:"backtest_dir,omitempty"`       // Directory backtest results and HTML reports are written to
+	Backtest         BacktestConfig `json:"backtest"`                     // Simulated trading costs
+
+	ConfigHistoryFile string `json:"config_history_file,omitempty"` // JSON file applied configs are kept in (empty keeps them in memory)
+
+	NightlyBacktest NightlyBacktestConfig `json:"nightly_backtest"` // Scheduled validation of the live config
+
+	Rebalance RebalanceConfig `json:"rebalance"` // Passive allocation strategy
+
+	// Capital fraction and risk budget per strategy name (e.g. "ATR_PINE_SCRIPT", "REBALANCE");
+	// strategies not listed share the whole account
+	StrategyAllocations map[string]StrategyAllocation `json:"strategy_allocations,omitempty"`
+
+	Hedging HedgingConfig `json:"hedging"` // Exposure hedging rules
+
+	Correlation CorrelationConfig `json:"correlation"` // Cap on equity held in correlated symbols
+
+	Scripting ScriptingConfig `json:"scripting"` // User entry/exit rules loaded from a script file
+	Pine      PineConfig      `json:"pine"`      // TradingView studies run as extra indicators
+
+	// Registered indicator plugins keyed by name (stored in the "indicators" map),
+	// and Go plugin files (.so) loaded before the config is validated so their indicators can register
+	Plugins     map[string]PluginConfig `json:"-"`
+	PluginFiles []string                `json:"plugin_files,omitempty"`
+
+	Determinism DeterminismConfig `json:"determinism"` // Fixed seeds and a frozen clock for reproducible reports
+	Prediction  PredictionConfig  `json:"prediction"`  // How served predictions are scored
+
+	// Price used for predictions and PnL marking: "last" trade, exchange "mark"
+	// price, order book "mid" or multi-venue "index"; PriceSources overrides it per symbol
+	PriceSource  string            `json:"price_source"`
+	PriceSources map[string]string `json:"price_sources,omitempty"`
+	PriceIndex   PriceIndexConfig  `json:"price_index"`
+
+	// Tick/step sizes per symbol, used until (or instead of) exchange metadata,
+	// e.g. {"DOGEUSDT": {"tick_size": 0.00001, "step_size": 1}}
+	SymbolPrecision map[string]SymbolPrecision `json:"symbol_precision,omitempty"`
+
+	RegimeSwitching RegimeSwitchingConfig `json:"regime_switching"` // Per-regime indicator weights/thresholds
+	Seasonality     SeasonalityConfig     `json:"seasonality"`      // Hour-of-day / day-of-week statistics
+
+	SafeMode      SafeModeConfig      `json:"safe_mode"`     // Exchange outage detection
+	Maintenance   MaintenanceConfig   `json:"maintenance"`   // Scheduled exchange downtime
+	Notifications NotificationsConfig `json:"notifications"` // Alert delivery
+	Heartbeat     HeartbeatConfig     `json:"heartbeat"`     // Dead-man's switch pings
+	MQTT          MQTTConfig          `json:"mqtt"`          // MQTT broker connection
+	EventExport   EventExportConfig   `json:"event_export"`  // Kafka/NATS event streaming
+	Admin         AdminConfig         `json:"admin"`         // Operator endpoints
+
+	Logging LoggingConfig `json:"logging"` // Log level and output format
+}
+
+// Price sources for predictions and PnL marking
+const (
+	PriceSourceLast  = "last"  // Last trade price
+	PriceSourceMark  = "mark"  // Exchange mark price
+	PriceSourceMid   = "mid"   // Best bid/ask midpoint
+	PriceSourceIndex = "index" // Median across venues
+)
+
+// PriceIndexConfig configures the multi-venue median index price
+type PriceIndexConfig struct {
+	Venues    []string `json:"venues"`     // "binance", "coinbase", "kraken"
+	MinVenues int      `json:"min_venues"` // Venues that must respond for a valid index
+}
+
+// PriceSourceFor returns the price source configured for a symbol
+func (c Config) PriceSourceFor(symbol string) string {
+	if source, ok := c.PriceSources[symbol]; ok && source != "" {
+		return source
+	}
+	if c.PriceSource == "" {
+		return PriceSourceLast
+	}
+	return c.PriceSource
+}
+
+// AllSymbols returns Symbol followed by the additional Symbols
+func (c Config) AllSymbols() []string {
+	symbols := []string{c.Symbol}
+	for _, symbol := range c.Symbols {
+		if symbol != "" && symbol != c.Symbol {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// RegimeSwitchingConfig selects an indicator weight/threshold profile from the detected regime
+type RegimeSwitchingConfig struct {
+	Enabled             bool                     `json:"enabled"`              // Feature flag
+	Lookback            int                      `json:"lookback"`             // 5m candles the regime detector looks at
+	TrendThreshold      float64                  `json:"trend_threshold"`      // Efficiency ratio (0-1) at or above which the market is TRENDING
+	VolatilityThreshold float64                  `json:"volatility_threshold"` // Average true range % of price above which the market is VOLATILE
+	Profiles            map[string]RegimeProfile `json:"profiles"`             // Keyed by "TRENDING", "RANGING" or "VOLATILE"
+}
+
+// RegimeProfile is the indicator weight/threshold set used while a regime is active
+type RegimeProfile struct {
+	Weights       map[string]float64 `json:"weights"`        // Vote weight per indicator name, e.g. {"RSI": 1.5} (unlisted = 1)
+	MinConfidence float64            `json:"min_confidence"` // Signals below this confidence become HOLD (0 = no extra threshold)
+}
+
+// SeasonalityConfig controls seasonality statistics and the optional seasonal prior
+type SeasonalityConfig struct {
+	LookbackDays int     `json:"lookback_days"` // History used for the statistics
+	RefreshHours int     `json:"refresh_hours"` // How long computed statistics stay fresh
+	PriorEnabled bool    `json:"prior_enabled"` // Feed the seasonal bias into the aggregator
+	PriorWeight  float64 `json:"prior_weight"`  // Vote weight of a full (±1) seasonal bias
+	MinSamples   int     `json:"min_samples"`   // Buckets with fewer candles contribute no prior
+}
+
+// BacktestConfig sets the trading costs backtests charge on every simulated fill
+type BacktestConfig struct {
+	FeePercent  float64 `json:"fee_percent"`  // Fee per fill, % of notional
+	SlippageBps float64 `json:"slippage_bps"` // Adverse fill price move per fill, basis points
+}
+
+// NightlyBacktestConfig schedules a daily backtest of the live config
+type NightlyBacktestConfig struct {
+	Enabled          bool    `json:"enabled"`            // Feature flag
+	HourUTC          int     `json:"hour_utc"`           // Hour of day (UTC) to run
+	WindowDays       []int   `json:"window_days"`        // Lookback windows to backtest, e.g. [7, 30]
+	MaxDrawdownAlert float64 `json:"max_drawdown_alert"` // Raise a warning above this drawdown % (0 = only on losses)
+}
+
+// SafeModeConfig controls outage detection and the safe mode entered on repeated failures
+type SafeModeConfig struct {
+	Enabled           bool `json:"enabled"`            // Feature flag
+	FailureThreshold  int  `json:"failure_threshold"`  // Failures within the window that trigger safe mode
+	WindowSeconds     int  `json:"window_seconds"`     // Sliding window for counting failures
+	FlattenPositions  bool `json:"flatten_positions"`  // Close open positions on entry (at last known price)
+	RecoverySuccesses int  `json:"recovery_successes"` // Consecutive successes that end safe mode (0 = manual exit only)
+}
+
+// MaintenanceConfig lists scheduled exchange downtime
+type MaintenanceConfig struct {
+	Windows             []MaintenanceWindow `json:"windows"`
+	PauseEntriesMinutes int                 `json:"pause_entries_minutes"` // Block new entries this long before a window starts
+}
+
+// MaintenanceWindow is a scheduled exchange downtime (RFC3339 times)
+type MaintenanceWindow struct {
+	Name         string    `json:"name"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	RepeatWeekly bool      `json:"repeat_weekly"` // Recur every 7 days from Start
+}
+
+// HeartbeatConfig sends dead-man's switch pings only while feeds are fresh and signals flow
+type HeartbeatConfig struct {
+	Enabled             bool   `json:"enabled"`                // Feature flag
+	URL                 string `json:"url,omitempty"`          // GET target, e.g. https://hc-ping.com/<uuid>
+	MQTTTopic           string `json:"mqtt_topic,omitempty"`   // Publish heartbeats to this topic (requires mqtt.broker)
+	IntervalSeconds     int    `json:"interval_seconds"`       // Time between heartbeats
+	MaxSignalAgeSeconds int    `json:"max_signal_age_seconds"` // Withhold heartbeats when the last signal is older than this
+}
+
+// MQTTConfig connects to an MQTT broker
+type MQTTConfig struct {
+	Broker   string `json:"broker,omitempty"` // e.g. tcp://localhost:1883 (empty disables MQTT)
+	ClientID string `json:"client_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	QoS      int    `json:"qos"` // 0, 1 or 2
+
+	// Topic per event type ("signal", "trade", "prediction", "error"); unlisted events aren't published
+	Topics map[string]string `json:"topics,omitempty"`
+}
+
+// EventExportConfig streams bus events to Kafka or NATS JetStream with at-least-once delivery
+type EventExportConfig struct {
+	Enabled        bool              `json:"enabled"`
+	Backend        string            `json:"backend"`          // "kafka" or "nats"
+	Brokers        []string          `json:"brokers"`          // Kafka host:port list or NATS URLs
+	Topics         map[string]string `json:"topics"`           // Topic/subject per event type; unlisted events aren't exported
+	MaxPending     int               `json:"max_pending"`      // Undelivered events kept while the broker is down (0 = unbounded)
+	RetryBackoffMs int               `json:"retry_backoff_ms"` // Initial delay between delivery retries (doubles up to 30s)
+}
+
+// AdminConfig protects the operator endpoints under /api/v1/admin
+type AdminConfig struct {
+	Token string `json:"token,omitempty"` // Bearer token required by admin endpoints (empty disables them; ADMIN_TOKEN overrides)
+
+	Users []APIUser `json:"users,omitempty"` // Named tokens with a role; when set, every API route but health needs one
+}
+
+// APIUser is an API consumer identified by its bearer token
+type APIUser struct {
+	Name  string `json:"name"`
+	Token string `json:"token"`
+	Role  string `json:"role"` // "viewer", "trader" or "admin"
+}
+
+// NotificationsConfig configures where operational alerts are sent
+type NotificationsConfig struct {
+	WebhookURL string `json:"webhook_url,omitempty"` // JSON POST target for alerts (alerts are always logged)
+
+	Telegram TelegramConfig `json:"telegram"` // Signals, trades, alerts and commands over a Telegram bot
+}
+
+// TelegramConfig configures the Telegram bot
+type TelegramConfig struct {
+	Enabled      bool   `json:"enabled"`
+	Token        string `json:"token,omitempty"`   // Bot token from @BotFather (or TELEGRAM_BOT_TOKEN)
+	ChatID       int64  `json:"chat_id"`           // Chat messages go to; commands are only accepted from it
+	Commands     bool   `json:"commands"`          // Answer /status, /position and /disable
+	DailySummary bool   `json:"daily_summary"`     // Summarize each trading session when it ends
+	APIURL       string `json:"api_url,omitempty"` // Bot API base URL (default https://api.telegram.org)
+}
+
+// StrategyAllocation assigns a slice of capital and a risk budget to a strategy
+type StrategyAllocation struct {
+	CapitalFraction float64 `json:"capital_fraction"`            // Fraction of the account balance (0-1)
+	MaxDailyLoss    float64 `json:"max_daily_loss,omitempty"`    // Max daily loss as fraction of strategy equity (default: account limit)
+	MaxPositionSize float64 `json:"max_position_size,omitempty"` // Risk fraction per signal trade (default: account limit)
+}
+
+// TimeframeProviderConfig selects the data providers used for a single timeframe
+type TimeframeProviderConfig struct {
+	Historical string `json:"historical,omitempty"` // An exchange, "sample", "file" (backfills) or "store" (offline)
+	RealTime   string `json:"realtime,omitempty"`   // An exchange, "binance_rest" (polling) or "sample"
 }
@@ -0,0 +1,217 @@
+package bot
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"trading-bot/pkg/indicator"
+)
+
+// randomWalkCandles builds a reproducible random walk with a flat stretch in the middle
+func randomWalkCandles(count int) []indicator.Candle {
+	rng := rand.New(rand.NewSource(42))
+	candles := make([]indicator.Candle, count)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	price := 50000.0
+	for i := range candles {
+		open := price
+		if i < count/2 || i > count/2+40 {
+			price *= 1 + rng.NormFloat64()*0.003
+		}
+		spread := math.Abs(rng.NormFloat64()) * price * 0.001
+		candles[i] = indicator.Candle{
+			Timestamp: start.Add(time.Duration(i) * 5 * time.Minute),
+			Open:      open,
+			High:      math.Max(open, price) + spread,
+			Low:       math.Min(open, price) - spread,
+			Close:     price,
+			Volume:    1000,
+		}
+	}
+	return candles
+}
+
+// assertSeriesClose fails when two series differ in length or by more than tolerance
+func assertSeriesClose(t *testing.T, name string, got, want []float64, tolerance float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: expected %d values, got %d", name, len(want), len(got))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > tolerance*math.Max(1, math.Abs(want[i])) {
+			t.Fatalf("%s[%d]: expected %.12f, got %.12f", name, i, want[i], got[i])
+		}
+	}
+}
+
+func TestVectorizedIndicatorsMatchPerCandle(t *testing.T) {
+	t.Log("⚡ Testing vectorized Ichimoku, Bollinger and Elliott pivots against the per-candle path")
+
+	config := DefaultConfig()
+	candles := randomWalkCandles(2000)
+
+	ichimoku := indicator.NewIchimoku(convertIchimokuConfig(config.Ichimoku), indicator.FiveMinute)
+	want := ichimoku.CalculateAll(candles)
+	got := ichimoku.CalculateAllVectorized(candles)
+	assertSeriesClose(t, "TenkanSen", got.TenkanSen, want.TenkanSen, 0)
+	assertSeriesClose(t, "KijunSen", got.KijunSen, want.KijunSen, 0)
+	assertSeriesClose(t, "SenkouSpanB", got.SenkouSpanB, want.SenkouSpanB, 0)
+	assertSeriesClose(t, "CloudTop", got.CloudTop, want.CloudTop, 0)
+	assertSeriesClose(t, "ChikouSpan", got.ChikouSpan, want.ChikouSpan, 0)
+
+	bollinger := indicator.NewBollingerBands(convertBollingerBandsConfig(config.BollingerBands), indicator.FiveMinute)
+	bands := bollinger.CalculateAll(candles)
+	fast := bollinger.CalculateAllVectorized(candles)
+	assertSeriesClose(t, "MiddleBand", fast.MiddleBand, bands.MiddleBand, 1e-9)
+	assertSeriesClose(t, "UpperBand", fast.UpperBand, bands.UpperBand, 1e-9)
+	for i := range bands.Squeeze {
+		if fast.Squeeze[i] != bands.Squeeze[i] {
+			t.Fatalf("Squeeze[%d]: expected %v, got %v", i, bands.Squeeze[i], fast.Squeeze[i])
+		}
+		// Position is rounding noise once the bands collapse on flat prices
+		if bands.UpperBand[i]-bands.LowerBand[i] > 1e-6*bands.MiddleBand[i] && math.Abs(fast.Position[i]-bands.Position[i]) > 1e-6 {
+			t.Fatalf("Position[%d]: expected %.9f, got %.9f", i, bands.Position[i], fast.Position[i])
+		}
+	}
+
+	// Switching the indicator flag routes Calculate through the fast path
+	bollinger.SetVectorized(true)
+	assertSeriesClose(t, "Calculate", bollinger.Calculate(candles[:500]), fast.Position[:500], 0)
+
+	// Elliott Wave: identical values and state within the lookback buffer
+	ewConfig := convertElliottWaveConfig(config.ElliottWave)
+	window := candles[:ewConfig.MaxLookback]
+	streaming := indicator.NewElliottWave(ewConfig, indicator.FiveMinute)
+	streamValues := streaming.Calculate(window)
+	batch := indicator.NewElliottWave(ewConfig, indicator.FiveMinute)
+	assertSeriesClose(t, "ElliottWave", batch.CalculateVectorized(window), streamValues, 0)
+	if batch.GetCurrentWave() != streaming.GetCurrentWave() {
+		t.Errorf("Expected the same current wave, got %+v vs %+v", batch.GetCurrentWave(), streaming.GetCurrentWave())
+	}
+
+	// FindPivots agrees with a brute-force scan on the full series
+	highs := make([]float64, len(candles))
+	lows := make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i], lows[i] = c.High, c.Low
+	}
+	lookback := ewConfig.MinWaveLength
+	pivotHighs, pivotLows := indicator.FindPivots(highs, lows, lookback)
+	var bruteHighs, bruteLows []int
+	for c := lookback; c < len(candles)-lookback; c++ {
+		isHigh, isLow := true, true
+		for j := c - lookback; j <= c+lookback; j++ {
+			if j != c && highs[j] >= highs[c] {
+				isHigh = false
+			}
+			if j != c && lows[j] <= lows[c] {
+				isLow = false
+			}
+		}
+		if isHigh {
+			bruteHighs = append(bruteHighs, c)
+		}
+		if isLow {
+			bruteLows = append(bruteLows, c)
+		}
+	}
+	if len(pivotHighs) == 0 || len(pivotLows) == 0 {
+		t.Fatal("Expected pivots on a random walk")
+	}
+	if len(pivotHighs) != len(bruteHighs) || len(pivotLows) != len(bruteLows) {
+		t.Fatalf("Expected %d/%d pivots, got %d/%d", len(bruteHighs), len(bruteLows), len(pivotHighs), len(pivotLows))
+	}
+	for i := range bruteHighs {
+		if pivotHighs[i] != bruteHighs[i] {
+			t.Fatalf("Pivot high %d: expected index %d, got %d", i, bruteHighs[i], pivotHighs[i])
+		}
+	}
+	for i := range bruteLows {
+		if pivotLows[i] != bruteLows[i] {
+			t.Fatalf("Pivot low %d: expected index %d, got %d", i, bruteLows[i], pivotLows[i])
+		}
+	}
+}
+
+func TestBacktestVectorized(t *testing.T) {
+	t.Log("⚡ Testing a vectorized backtest runs end to end")
+
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := map[Timeframe][]Candle{
+		Daily:           syntheticCandles(Daily, origin.AddDate(0, 0, -40), 42),
+		EightHour:       syntheticCandles(EightHour, origin.AddDate(0, 0, -20), 66),
+		FortyFiveMinute: syntheticCandles(FortyFiveMinute, origin.AddDate(0, 0, -3), 200),
+		FifteenMinute:   syntheticCandles(FifteenMinute, origin.AddDate(0, 0, -2), 400),
+		FiveMinute:      syntheticCandles(FiveMinute, origin.AddDate(0, 0, -1), 288*2),
+	}
+
+	backtester := NewBacktester(DefaultConfig(), 10000.0)
+	backtester.SetVectorized(true)
+	result, err := backtester.Run(candles, origin, origin.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Vectorized backtest failed: %v", err)
+	}
+	if result.Candles != 288 {
+		t.Errorf("Expected 288 simulated candles, got %d", result.Candles)
+	}
+}
+
+// Benchmarks over a large backtest-sized series: go test ./pkg/bot -run XXX -bench 'PerCandle|Vectorized'
+
+const benchmarkCandles = 20000
+
+func BenchmarkIchimokuPerCandle(b *testing.B) {
+	candles := randomWalkCandles(benchmarkCandles)
+	ichimoku := indicator.NewIchimoku(convertIchimokuConfig(DefaultConfig().Ichimoku), indicator.FiveMinute)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ichimoku.CalculateAll(candles)
+	}
+}
+
+func BenchmarkIchimokuVectorized(b *testing.B) {
+	candles := randomWalkCandles(benchmarkCandles)
+	ichimoku := indicator.NewIchimoku(convertIchimokuConfig(DefaultConfig().Ichimoku), indicator.FiveMinute)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ichimoku.CalculateAllVectorized(candles)
+	}
+}
+
+func BenchmarkBollingerPerCandle(b *testing.B) {
+	candles := randomWalkCandles(benchmarkCandles)
+	bollinger := indicator.NewBollingerBands(convertBollingerBandsConfig(DefaultConfig().BollingerBands), indicator.FiveMinute)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bollinger.CalculateAll(candles)
+	}
+}
+
+func BenchmarkBollingerVectorized(b *testing.B) {
+	candles := randomWalkCandles(benchmarkCandles)
+	bollinger := indicator.NewBollingerBands(convertBollingerBandsConfig(DefaultConfig().BollingerBands), indicator.FiveMinute)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bollinger.CalculateAllVectorized(candles)
+	}
+}
+
+func BenchmarkElliottWavePerCandle(b *testing.B) {
+	candles := randomWalkCandles(benchmarkCandles)
+	config := convertElliottWaveConfig(DefaultConfig().ElliottWave)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		indicator.NewElliottWave(config, indicator.FiveMinute).Calculate(candles)
+	}
+}
+
+func BenchmarkElliottWaveVectorized(b *testing.B) {
+	candles := randomWalkCandles(benchmarkCandles)
+	config := convertElliottWaveConfig(DefaultConfig().ElliottWave)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		indicator.NewElliottWave(config, indicator.FiveMinute).CalculateVectorized(candles)
+	}
+}
@@ -0,0 +1,259 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Search strategies
+const (
+	SearchGrid     = "grid"     // Backtest every combination
+	SearchBayesian = "bayesian" // Backtest the combinations a Gaussian-process model rates most promising
+)
+
+// Objectives a parameter set is ranked by
+const (
+	ObjectiveReturn   = "return"   // Total return %
+	ObjectiveCalmar   = "calmar"   // Total return % per % of max drawdown (drawdown floored at 1%)
+	ObjectiveAccuracy = "accuracy" // Signal accuracy
+)
+
+// WalkForwardOptions configures a walk-forward optimization
+type WalkForwardOptions struct {
+	Params     []SweepParameter // Candidate values per parameter, e.g. rsi.period or strategy.timeframe_weights.5m
+	Search     string           // SearchGrid (default) or SearchBayesian
+	Iterations int              // Backtests per training window for bayesian search (default: 30)
+	Objective  string           // ObjectiveReturn (default), ObjectiveCalmar or ObjectiveAccuracy
+	Train      time.Duration    // In-sample window the parameters are picked on
+	Test       time.Duration    // Out-of-sample window that follows; windows roll forward by Test
+	Workers    int              // Concurrent backtests (0 = one per CPU)
+	Seed       int64            // Seeds the bayesian search's initial samples
+}
+
+// WalkForwardFold is one train/test split and the parameters picked on it
+type WalkForwardFold struct {
+	TrainStart time.Time          `json:"train_start"`
+	TrainEnd   time.Time          `json:"train_end"` // Also the test window's start
+	TestEnd    time.Time          `json:"test_end"`
+	Parameters map[string]float64 `json:"parameters"` // Best in-sample set
+	InSample   float64            `json:"in_sample"`  // Its objective on the training window
+	OutSample  float64            `json:"out_sample"` // Its objective on the test window
+	Evaluated  int                `json:"evaluated"`  // Combinations backtested in-sample
+}
+
+// WalkForwardReport lists the folds and the parameter set recommended across them
+type WalkForwardReport struct {
+	Objective string             `json:"objective"`
+	Search    string             `json:"search"`
+	Folds     []WalkForwardFold  `json:"folds"`
+	Best      map[string]float64 `json:"best"`       // WalkForwardFold winner with the best mean objective over the test windows from its own on
+	BestScore float64            `json:"best_score"` // That mean
+	Cancelled bool               `json:"cancelled"`
+}
+
+// WalkForwardOptimizer picks parameters on rolling training windows and scores
+// them on the windows that follow, so the result reflects unseen data
+type WalkForwardOptimizer struct {
+	config         Config
+	initialBalance float64
+	options        WalkForwardOptions
+}
+
+// NewWalkForwardOptimizer validates options and creates an optimizer around config
+func NewWalkForwardOptimizer(config Config, initialBalance float64, options WalkForwardOptions) (*WalkForwardOptimizer, error) {
+	if options.Search == "" {
+		options.Search = SearchGrid
+	}
+	if options.Objective == "" {
+		options.Objective = ObjectiveReturn
+	}
+	if options.Iterations <= 0 {
+		options.Iterations = 30
+	}
+	switch {
+	case options.Search != SearchGrid && options.Search != SearchBayesian:
+		return nil, fmt.Errorf("unknown search %q (want %s or %s)", options.Search, SearchGrid, SearchBayesian)
+	case options.Objective != ObjectiveReturn && options.Objective != ObjectiveCalmar && options.Objective != ObjectiveAccuracy:
+		return nil, fmt.Errorf("unknown objective %q", options.Objective)
+	case len(options.Params) == 0:
+		return nil, fmt.Errorf("no parameters to optimize")
+	case options.Train <= 0 || options.Test <= 0:
+		return nil, fmt.Errorf("train and test windows must be positive")
+	}
+	if _, err := ApplyParameters(config, firstValues(options.Params)); err != nil {
+		return nil, err
+	}
+	return &WalkForwardOptimizer{config: config, initialBalance: initialBalance, options: options}, nil
+}
+
+// Splits returns the fold windows fitting in [start, end)
+func (wf *WalkForwardOptimizer) Splits(start, end time.Time) []WalkForwardFold {
+	var folds []WalkForwardFold
+	for trainStart := start; !trainStart.Add(wf.options.Train + wf.options.Test).After(end); trainStart = trainStart.Add(wf.options.Test) {
+		trainEnd := trainStart.Add(wf.options.Train)
+		folds = append(folds, WalkForwardFold{TrainStart: trainStart, TrainEnd: trainEnd, TestEnd: trainEnd.Add(wf.options.Test)})
+	}
+	return folds
+}
+
+// Run optimizes every fold of [start, end). candles must include each
+// timeframe's backtest lookback before start. Cancelling ctx stops after the
+// backtests in flight; the report then covers the folds that finished.
+func (wf *WalkForwardOptimizer) Run(ctx context.Context, candles map[Timeframe][]Candle, start, end time.Time) (*WalkForwardReport, error) {
+	folds := wf.Splits(start, end)
+	if len(folds) == 0 {
+		return nil, fmt.Errorf("%s is too short for a %s training and %s test window", end.Sub(start), wf.options.Train, wf.options.Test)
+	}
+
+	report := &WalkForwardReport{Objective: wf.options.Objective, Search: wf.options.Search, Folds: make([]WalkForwardFold, 0, len(folds))}
+	for i, fold := range folds {
+		var best *SweepResult
+		var err error
+		if wf.options.Search == SearchBayesian {
+			best, fold.Evaluated, err = wf.bayesianSearch(ctx, candles, fold.TrainStart, fold.TrainEnd, int64(i))
+		} else {
+			best, fold.Evaluated, err = wf.gridSearch(ctx, candles, fold.TrainStart, fold.TrainEnd)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fold %d: %w", i+1, err)
+		}
+		if ctx.Err() != nil {
+			report.Cancelled = true
+			break
+		}
+		if best == nil {
+			return nil, fmt.Errorf("fold %d: every combination failed", i+1)
+		}
+		fold.Parameters = best.Parameters
+		fold.InSample = wf.score(*best)
+		if fold.OutSample, err = wf.evaluate(ctx, fold.Parameters, candles, fold.TrainEnd, fold.TestEnd); err != nil {
+			if ctx.Err() != nil {
+				report.Cancelled = true
+				break
+			}
+			return nil, fmt.Errorf("fold %d: %w", i+1, err)
+		}
+		report.Folds = append(report.Folds, fold)
+	}
+	if report.Cancelled {
+		return report, nil
+	}
+
+	var err error
+	report.Best, report.BestScore, err = recommend(report.Folds, func(params map[string]float64, start, end time.Time) (float64, error) {
+		return wf.evaluate(ctx, params, candles, start, end)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// recommend picks the fold winner that holds up best out of sample: each is
+// scored on its own test window and every later one, never on an earlier one.
+// Windows roll forward by less than the training length, so an earlier test
+// window lies inside a later winner's training range.
+func recommend(folds []WalkForwardFold, evaluate func(params map[string]float64, start, end time.Time) (float64, error)) (map[string]float64, float64, error) {
+	var best map[string]float64
+	bestScore := math.Inf(-1)
+	for _, candidate := range folds {
+		total, windows := 0.0, 0
+		for _, fold := range folds {
+			if fold.TrainEnd.Before(candidate.TrainEnd) {
+				continue
+			}
+			score := candidate.OutSample
+			if fold.TrainEnd.After(candidate.TrainEnd) {
+				var err error
+				if score, err = evaluate(candidate.Parameters, fold.TrainEnd, fold.TestEnd); err != nil {
+					return nil, 0, err
+				}
+			}
+			total += score
+			windows++
+		}
+		if mean := total / float64(windows); mean > bestScore {
+			best, bestScore = candidate.Parameters, mean
+		}
+	}
+	return best, bestScore, nil
+}
+
+// gridSearch backtests the full grid on a window and returns the best result
+func (wf *WalkForwardOptimizer) gridSearch(ctx context.Context, candles map[Timeframe][]Candle, start, end time.Time) (*SweepResult, int, error) {
+	sweep, err := NewParameterOptimizer(wf.config, wf.initialBalance, wf.options.Workers).Run(ctx, wf.options.Params, candles, start, end)
+	if err != nil {
+		return nil, 0, err
+	}
+	var best *SweepResult
+	for i := range sweep.Results {
+		result := sweep.Results[i]
+		if result.Error == "" && (best == nil || wf.score(result) > wf.score(*best)) {
+			best = &result
+		}
+	}
+	return best, sweep.Completed, nil
+}
+
+// backtest runs a single parameter set over a window
+func (wf *WalkForwardOptimizer) backtest(ctx context.Context, params map[string]float64, candles map[Timeframe][]Candle, start, end time.Time) (SweepResult, error) {
+	single := make([]SweepParameter, 0, len(params))
+	for _, name := range sortedNames(params) {
+		single = append(single, SweepParameter{Parameter: name, Values: []float64{params[name]}})
+	}
+	sweep, err := NewParameterOptimizer(wf.config, wf.initialBalance, 1).Run(ctx, single, candles, start, end)
+	if err != nil {
+		return SweepResult{}, err
+	}
+	if len(sweep.Results) == 0 {
+		return SweepResult{}, ctx.Err()
+	}
+	return sweep.Results[0], nil
+}
+
+// evaluate scores a parameter set over a window
+func (wf *WalkForwardOptimizer) evaluate(ctx context.Context, params map[string]float64, candles map[Timeframe][]Candle, start, end time.Time) (float64, error) {
+	result, err := wf.backtest(ctx, params, candles, start, end)
+	if err != nil {
+		return 0, err
+	}
+	if result.Error != "" {
+		return 0, fmt.Errorf("%s", result.Error)
+	}
+	return wf.score(result), nil
+}
+
+// score applies the objective to a backtest result
+func (wf *WalkForwardOptimizer) score(result SweepResult) float64 {
+	switch wf.options.Objective {
+	case ObjectiveCalmar:
+		return result.TotalReturnPercent / math.Max(result.MaxDrawdownPercent, 1)
+	case ObjectiveAccuracy:
+		return result.SignalAccuracy
+	}
+	return result.TotalReturnPercent
+}
+
+// firstValues picks each parameter's first candidate
+func firstValues(params []SweepParameter) map[string]float64 {
+	values := make(map[string]float64, len(params))
+	for _, param := range params {
+		if len(param.Values) > 0 {
+			values[param.Parameter] = param.Values[0]
+		}
+	}
+	return values
+}
+
+// sortedNames returns the parameter names in order
+func sortedNames(params map[string]float64) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,140 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// waveCandles builds a trending sine-wave series starting at start
+func waveCandles(timeframe Timeframe, start time.Time, count int) []Candle {
+	price := func(t time.Time) float64 {
+		hours := t.Sub(start).Hours()
+		return 50000 + 1500*math.Sin(hours/6) + 20*hours
+	}
+	candles := make([]Candle, count)
+	for i := range candles {
+		open := start.Add(time.Duration(i) * timeframe.Duration())
+		o, c := price(open), price(open.Add(timeframe.Duration()))
+		candles[i] = Candle{Timestamp: open, Open: o, High: math.Max(o, c) * 1.001, Low: math.Min(o, c) * 0.999, Close: c, Volume: 1000 + float64(i%7)*100}
+	}
+	return candles
+}
+
+func TestWalkForwardOptimizer(t *testing.T) {
+	t.Log("🚶 Testing walk-forward splits, grid and bayesian search, stored candles and profiles")
+
+	// Keep the runs small: only RSI and EMA stay enabled
+	config := DefaultConfig()
+	for _, info := range Indicators() {
+		info.SetEnabled(&config, info.Name == "rsi" || info.Name == "ema")
+	}
+
+	// Candles come from a store, with each timeframe's lookback before the window
+	origin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	start, end := origin, origin.Add(4*time.Hour)
+	store := NewMemoryCandleStore()
+	for _, timeframe := range []Timeframe{Daily, EightHour, FortyFiveMinute, FifteenMinute, FiveMinute} {
+		from := start.Add(-time.Duration(BacktestLookback(timeframe)+2) * timeframe.Duration())
+		count := int(end.Sub(from)/timeframe.Duration()) + 1
+		store.SaveCandles("BTCUSDT", timeframe, waveCandles(timeframe, from, count))
+	}
+	candles, err := LoadStoredCandles(store, "BTCUSDT", start, end)
+	if err != nil {
+		t.Fatalf("Failed to load stored candles: %v", err)
+	}
+	if _, err := LoadStoredCandles(store, "ETHUSDT", start, end); err == nil {
+		t.Error("Expected an error for a symbol without stored candles")
+	}
+
+	options := WalkForwardOptions{
+		Params: []SweepParameter{
+			{Parameter: "rsi.period", Values: []float64{7, 14}},
+			{Parameter: "strategy.timeframe_weights.5m", Values: []float64{0.3, 0.6}},
+		},
+		Train:   2 * time.Hour,
+		Test:    time.Hour,
+		Workers: 2,
+	}
+	if _, err := NewWalkForwardOptimizer(config, 10000, WalkForwardOptions{Params: options.Params, Train: time.Hour, Test: time.Hour, Search: "random"}); err == nil {
+		t.Error("Expected an unknown search to be rejected")
+	}
+	if _, err := NewWalkForwardOptimizer(config, 10000, WalkForwardOptions{Params: []SweepParameter{{Parameter: "rsi.nope", Values: []float64{1}}}, Train: time.Hour, Test: time.Hour}); err == nil {
+		t.Error("Expected an unknown parameter to be rejected")
+	}
+
+	// Windows roll forward by the test length
+	optimizer, err := NewWalkForwardOptimizer(config, 10000, options)
+	if err != nil {
+		t.Fatalf("Failed to create optimizer: %v", err)
+	}
+	folds := optimizer.Splits(start, end)
+	if len(folds) != 2 || !folds[1].TrainStart.Equal(start.Add(time.Hour)) || !folds[1].TestEnd.Equal(end) {
+		t.Fatalf("Unexpected folds: %+v", folds)
+	}
+
+	report, err := optimizer.Run(context.Background(), candles, start, end)
+	if err != nil {
+		t.Fatalf("Grid walk-forward failed: %v", err)
+	}
+	if len(report.Folds) != 2 || report.Folds[0].Evaluated != 4 || report.Cancelled || len(report.Best) != 2 {
+		t.Fatalf("Unexpected grid report: %+v", report)
+	}
+
+	// Bayesian search stays within its budget
+	options.Search, options.Iterations = SearchBayesian, 3
+	optimizer, _ = NewWalkForwardOptimizer(config, 10000, options)
+	report, err = optimizer.Run(context.Background(), candles, start, end)
+	if err != nil {
+		t.Fatalf("Bayesian walk-forward failed: %v", err)
+	}
+	if len(report.Folds) != 2 || report.Folds[1].Evaluated != 3 || len(report.Best) != 2 {
+		t.Fatalf("Unexpected bayesian report: %+v", report)
+	}
+
+	// The surrogate steers toward high scores: between the best points, away from the worst
+	params := []SweepParameter{{Parameter: "rsi.period", Values: []float64{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}}}
+	evaluated := map[int]bool{0: true, 5: true, 10: true}
+	points := [][]float64{coordinates(params, 0), coordinates(params, 5), coordinates(params, 10)}
+	if next := nextCandidate(params, 11, evaluated, points, []float64{-5, 1, 8}); next < 6 || next > 9 {
+		t.Errorf("Expected a candidate between the two best scores, got %d", next)
+	}
+
+	// WalkForwardFold winners are only scored on test windows after their training range
+	hour := func(h int) time.Time { return origin.Add(time.Duration(h) * time.Hour) }
+	overlapping := []WalkForwardFold{
+		{TrainStart: hour(0), TrainEnd: hour(2), TestEnd: hour(3), Parameters: map[string]float64{"fold": 1}, OutSample: 1},
+		{TrainStart: hour(1), TrainEnd: hour(3), TestEnd: hour(4), Parameters: map[string]float64{"fold": 2}, OutSample: 1},
+		{TrainStart: hour(2), TrainEnd: hour(4), TestEnd: hour(5), Parameters: map[string]float64{"fold": 3}, OutSample: 0},
+	}
+	best, score, err := recommend(overlapping, func(params map[string]float64, start, end time.Time) (float64, error) {
+		trainEnd := overlapping[int(params["fold"])-1].TrainEnd
+		if start.Before(trainEnd) {
+			t.Errorf("WalkForwardFold %g winner scored on the in-sample window from %v", params["fold"], start)
+			return 100, nil // WalkForwardFold 3 would win on its own training data
+		}
+		return 1, nil
+	})
+	if err != nil || best["fold"] == 3 || score != 1 {
+		t.Errorf("Expected an earlier fold's winner at 1, got %v at %.2f (err %v)", best, score, err)
+	}
+
+	// The best set is written back as a validated profile without credentials
+	config.Binance.APIKey = "secret"
+	path := filepath.Join(t.TempDir(), "profiles", "optimized.json")
+	if err := WriteProfile(config, map[string]float64{"rsi.period": 9, "strategy.timeframe_weights.5m": 0.5}, path); err != nil {
+		t.Fatalf("WriteProfile failed: %v", err)
+	}
+	profile, err := LoadConfig(path)
+	if err != nil || profile.RSI.Period != 9 || profile.Strategy.TimeframeWeights["5m"] != 0.5 || profile.Binance.APIKey != "" {
+		t.Errorf("Unexpected profile: rsi %d, 5m weight %v, key %q (err %v)", profile.RSI.Period, profile.Strategy.TimeframeWeights["5m"], profile.Binance.APIKey, err)
+	}
+	if config.Strategy.TimeframeWeights["5m"] == 0.5 {
+		t.Error("Writing a profile must not change the base config's weights")
+	}
+	if err := WriteProfile(config, map[string]float64{"rsi.period": 0}, path); err == nil {
+		t.Error("Expected an invalid profile to be rejected")
+	}
+}
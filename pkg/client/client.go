@@ -0,0 +1,721 @@
+// Package client provides typed Go bindings for the trading bot REST API.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"trading-bot/internal"
+	"trading-bot/pkg/bot"
+
+	"github.com/gorilla/websocket"
+)
+
+// Response types shared with the API server
+type (
+	APIInfo                   = internal.APIInfo
+	PredictionResponse        = internal.PredictionResponse
+	IndicatorPrediction       = internal.IndicatorPrediction
+	HealthResponse            = internal.HealthResponse
+	ErrorsResponse            = internal.ErrorsResponse
+	CandleHistoryResponse     = internal.CandleHistoryResponse
+	PositionResponse          = internal.PositionResponse
+	PageInfo                  = internal.PageInfo
+	TradeHistoryResponse      = internal.TradeHistoryResponse
+	SignalHistoryResponse     = internal.SignalHistoryResponse
+	PredictionHistoryResponse = internal.PredictionHistoryResponse
+	TaxReportResponse         = internal.TaxReportResponse
+	SafeModeResponse          = internal.SafeModeResponse
+	TradingControlResponse    = internal.TradingControlResponse
+	AdminRefreshResponse      = internal.AdminRefreshResponse
+	AdminResetResponse        = internal.AdminResetResponse
+	ConfigValidationResponse  = internal.ConfigValidationResponse
+	ReloadCredentialsRequest  = internal.ReloadCredentialsRequest
+	AdminCredentialsResponse  = internal.AdminCredentialsResponse
+	AlertListResponse         = internal.AlertListResponse
+	AlertDeleteResponse       = internal.AlertDeleteResponse
+	WebSocketRequest          = internal.WebSocketRequest
+	WebSocketReply            = internal.WebSocketReply
+)
+
+// Prediction subscription types shared with the API server
+type (
+	PredictionFilter                     = internal.PredictionFilter
+	PredictionSubscriptionSpec           = internal.PredictionSubscriptionSpec
+	PredictionSubscription               = internal.PredictionSubscription
+	PredictionWebhook                    = internal.PredictionWebhook
+	PredictionSubscriptionListResponse   = internal.PredictionSubscriptionListResponse
+	PredictionSubscriptionDeleteResponse = internal.PredictionSubscriptionDeleteResponse
+)
+
+// ScenarioRequest lists the price shocks a risk scenario evaluates
+type ScenarioRequest = internal.ScenarioRequest
+
+// APIError is returned for non-2xx responses
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+}
+
+// Client calls a trading bot API server
+type Client struct {
+	baseURL    string
+	HTTPClient *http.Client // Used for all requests except Stream
+	AdminToken string       // Sent as a Bearer token; required by the admin endpoints
+}
+
+// New creates a client for the server at baseURL, e.g. http://localhost:8080
+func New(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// ListOptions pages, sorts and filters list endpoints; zero values use the server defaults
+type ListOptions struct {
+	Limit   int
+	Offset  int
+	Sort    string            // Field name, prefixed with - for descending
+	From    time.Time         // Inclusive
+	To      time.Time         // Exclusive
+	Filters map[string]string // Endpoint-specific filters, e.g. {"side": "LONG"}
+}
+
+// values encodes the options as query parameters
+func (o ListOptions) values() url.Values {
+	query := url.Values{}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		query.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	if !o.From.IsZero() {
+		query.Set("from", o.From.UTC().Format(time.RFC3339))
+	}
+	if !o.To.IsZero() {
+		query.Set("to", o.To.UTC().Format(time.RFC3339))
+	}
+	for name, value := range o.Filters {
+		query.Set(name, value)
+	}
+	return query
+}
+
+// ErrorsQuery filters Errors; zero values use the server defaults
+type ErrorsQuery struct {
+	Limit    int
+	Kind     bot.ErrorKind
+	Severity string
+}
+
+// StreamEvent is an event received from Stream; Data holds the JSON payload for its Type
+type StreamEvent struct {
+	ID        string          `json:"id"`
+	Type      bot.EventType   `json:"type"`
+	Symbol    string          `json:"symbol"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Info returns the API description and endpoint list
+func (c *Client) Info(ctx context.Context) (*APIInfo, error) {
+	return call[APIInfo](ctx, c, http.MethodGet, "/", nil)
+}
+
+// Predict predicts the price direction seconds ahead (0 uses the server default)
+func (c *Client) Predict(ctx context.Context, seconds int) (*PredictionResponse, error) {
+	query := url.Values{}
+	if seconds > 0 {
+		query.Set("seconds", strconv.Itoa(seconds))
+	}
+	return call[PredictionResponse](ctx, c, http.MethodGet, "/api/v1/predict", query)
+}
+
+// PredictSymbol predicts the price direction of another configured symbol
+func (c *Client) PredictSymbol(ctx context.Context, symbol string, seconds int) (*PredictionResponse, error) {
+	query := url.Values{"symbol": {symbol}}
+	if seconds > 0 {
+		query.Set("seconds", strconv.Itoa(seconds))
+	}
+	return call[PredictionResponse](ctx, c, http.MethodGet, "/api/v1/predict", query)
+}
+
+// Status returns the signal engine status, with a section per configured symbol
+func (c *Client) Status(ctx context.Context) (*bot.SignalEngineStatus, error) {
+	return call[bot.SignalEngineStatus](ctx, c, http.MethodGet, "/api/v1/status", nil)
+}
+
+// Signals returns the most recent trading signal
+func (c *Client) Signals(ctx context.Context) (*bot.TradingSignal, error) {
+	return call[bot.TradingSignal](ctx, c, http.MethodGet, "/api/v1/signals", nil)
+}
+
+// SignalHistory returns a page of recent signals (filters: signal, min_confidence)
+func (c *Client) SignalHistory(ctx context.Context, options ListOptions) (*SignalHistoryResponse, error) {
+	return call[SignalHistoryResponse](ctx, c, http.MethodGet, "/api/v1/signals/history", options.values())
+}
+
+// Predictions returns a page of served predictions (filters: prediction, min_confidence)
+func (c *Client) Predictions(ctx context.Context, options ListOptions) (*PredictionHistoryResponse, error) {
+	return call[PredictionHistoryResponse](ctx, c, http.MethodGet, "/api/v1/predictions", options.values())
+}
+
+// PredictionAccuracySummary returns overall and rolling prediction accuracy by direction,
+// indicator and confidence with up to limit recent outcomes (0 uses the server default)
+func (c *Client) PredictionAccuracySummary(ctx context.Context, limit int) (*bot.AccuracySummary, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return call[bot.AccuracySummary](ctx, c, http.MethodGet, "/api/v1/predictions/accuracy", query)
+}
+
+// PredictionAccuracy returns the hit rate of resolved predictions by direction, indicator, regime, volatility, hour and confidence
+func (c *Client) PredictionAccuracy(ctx context.Context) (*bot.AccuracyBreakdown, error) {
+	return call[bot.AccuracyBreakdown](ctx, c, http.MethodGet, "/api/v1/predictions/accuracy/breakdown", nil)
+}
+
+// PredictionSubscriptions lists the webhooks receiving filtered predictions
+func (c *Client) PredictionSubscriptions(ctx context.Context) (*PredictionSubscriptionListResponse, error) {
+	return call[PredictionSubscriptionListResponse](ctx, c, http.MethodGet, "/api/v1/predictions/subscriptions", nil)
+}
+
+// CreatePredictionSubscription registers a webhook for predictions passing spec's filter
+func (c *Client) CreatePredictionSubscription(ctx context.Context, spec PredictionSubscriptionSpec) (*PredictionSubscription, error) {
+	var out PredictionSubscription
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/predictions/subscriptions", spec, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeletePredictionSubscription removes a prediction webhook
+func (c *Client) DeletePredictionSubscription(ctx context.Context, id string) (*PredictionSubscriptionDeleteResponse, error) {
+	return call[PredictionSubscriptionDeleteResponse](ctx, c, http.MethodDelete, "/api/v1/predictions/subscriptions/"+url.PathEscape(id), nil)
+}
+
+// Health returns service health
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	return call[HealthResponse](ctx, c, http.MethodGet, "/api/v1/health", nil)
+}
+
+// Maintenance returns current and upcoming exchange maintenance
+func (c *Client) Maintenance(ctx context.Context) (*bot.MaintenanceStatus, error) {
+	return call[bot.MaintenanceStatus](ctx, c, http.MethodGet, "/api/v1/maintenance", nil)
+}
+
+// Errors returns recent classified engine errors
+func (c *Client) Errors(ctx context.Context, filter ErrorsQuery) (*ErrorsResponse, error) {
+	query := url.Values{}
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.Kind != "" {
+		query.Set("kind", string(filter.Kind))
+	}
+	if filter.Severity != "" {
+		query.Set("severity", filter.Severity)
+	}
+	return call[ErrorsResponse](ctx, c, http.MethodGet, "/api/v1/errors", query)
+}
+
+// IndexPrice returns the multi-venue median price ("" uses the configured symbol)
+func (c *Client) IndexPrice(ctx context.Context, symbol string) (*bot.IndexPrice, error) {
+	query := url.Values{}
+	if symbol != "" {
+		query.Set("symbol", symbol)
+	}
+	return call[bot.IndexPrice](ctx, c, http.MethodGet, "/api/v1/price/index", query)
+}
+
+// Seasonality returns hour-of-day and day-of-week statistics, recomputing them when refresh is set
+func (c *Client) Seasonality(ctx context.Context, refresh bool) (*bot.SeasonalityStats, error) {
+	query := url.Values{}
+	if refresh {
+		query.Set("refresh", "true")
+	}
+	return call[bot.SeasonalityStats](ctx, c, http.MethodGet, "/api/v1/analytics/seasonality", query)
+}
+
+// Candles returns stored candles for a timeframe such as "5m" ("" and 0 use the server defaults)
+func (c *Client) Candles(ctx context.Context, timeframe string, limit int) (*CandleHistoryResponse, error) {
+	query := url.Values{}
+	if timeframe != "" {
+		query.Set("timeframe", timeframe)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return call[CandleHistoryResponse](ctx, c, http.MethodGet, "/api/v1/candles", query)
+}
+
+// Context returns the multi-timeframe context behind a symbol's most recent signal ("" for the configured symbol)
+func (c *Client) Context(ctx context.Context, symbol string) (*bot.ContextSnapshot, error) {
+	query := url.Values{}
+	if symbol != "" {
+		query.Set("symbol", symbol)
+	}
+	return call[bot.ContextSnapshot](ctx, c, http.MethodGet, "/api/v1/context", query)
+}
+
+// RunBacktest backtests the current config over the last days of data (0 uses the server default)
+func (c *Client) RunBacktest(ctx context.Context, days int) (*bot.BacktestResult, error) {
+	query := url.Values{}
+	if days > 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+	return call[bot.BacktestResult](ctx, c, http.MethodPost, "/api/v1/backtest", query)
+}
+
+// RunBacktestWithCosts backtests like RunBacktest, charging the given fee (% of
+// notional) and slippage (basis points) per fill instead of the configured ones
+func (c *Client) RunBacktestWithCosts(ctx context.Context, days int, feePercent, slippageBps float64) (*bot.BacktestResult, error) {
+	query := url.Values{}
+	if days > 0 {
+		query.Set("days", strconv.Itoa(days))
+	}
+	query.Set("fee_percent", strconv.FormatFloat(feePercent, 'f', -1, 64))
+	query.Set("slippage_bps", strconv.FormatFloat(slippageBps, 'f', -1, 64))
+	return call[bot.BacktestResult](ctx, c, http.MethodPost, "/api/v1/backtest", query)
+}
+
+// Backtest returns a stored backtest result
+func (c *Client) Backtest(ctx context.Context, id string) (*bot.BacktestResult, error) {
+	return call[bot.BacktestResult](ctx, c, http.MethodGet, "/api/v1/backtest/"+url.PathEscape(id), nil)
+}
+
+// BacktestReport downloads the HTML report of a backtest
+func (c *Client) BacktestReport(ctx context.Context, id string) ([]byte, error) {
+	return c.raw(ctx, "/api/v1/backtest/"+url.PathEscape(id)+"/report", nil)
+}
+
+// TradingStatus returns the trading strategy status
+func (c *Client) TradingStatus(ctx context.Context) (*bot.TradingStatus, error) {
+	return call[bot.TradingStatus](ctx, c, http.MethodGet, "/api/v1/trading/status", nil)
+}
+
+// Position returns the open position, if any
+func (c *Client) Position(ctx context.Context) (*PositionResponse, error) {
+	return call[PositionResponse](ctx, c, http.MethodGet, "/api/v1/trading/position", nil)
+}
+
+// TradeHistory returns a page of closed trades (filters: side, strategy, exit_reason)
+func (c *Client) TradeHistory(ctx context.Context, options ListOptions) (*TradeHistoryResponse, error) {
+	return call[TradeHistoryResponse](ctx, c, http.MethodGet, "/api/v1/trading/history", options.values())
+}
+
+// TradeReplay returns the candles and signals spanning a closed trade
+func (c *Client) TradeReplay(ctx context.Context, tradeID string) (*bot.TradeReplay, error) {
+	return call[bot.TradeReplay](ctx, c, http.MethodGet, "/api/v1/trading/history/"+url.PathEscape(tradeID)+"/replay", nil)
+}
+
+// TaxReport returns closed tax lots for a calendar year (0 = all years)
+func (c *Client) TaxReport(ctx context.Context, method bot.TaxLotMethod, year int) (*TaxReportResponse, error) {
+	query := url.Values{"format": {"json"}}
+	if method != "" {
+		query.Set("method", string(method))
+	}
+	if year > 0 {
+		query.Set("year", strconv.Itoa(year))
+	}
+	return call[TaxReportResponse](ctx, c, http.MethodGet, "/api/v1/trading/tax-report", query)
+}
+
+// TaxReportCSV downloads closed tax lots as CSV
+func (c *Client) TaxReportCSV(ctx context.Context, method bot.TaxLotMethod, year int) ([]byte, error) {
+	query := url.Values{"format": {"csv"}}
+	if method != "" {
+		query.Set("method", string(method))
+	}
+	if year > 0 {
+		query.Set("year", strconv.Itoa(year))
+	}
+	return c.raw(ctx, "/api/v1/trading/tax-report", query)
+}
+
+// Hedges returns open hedges and the hedging audit trail (0 uses the server default limit)
+func (c *Client) Hedges(ctx context.Context, limit int) (*bot.HedgeStatus, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return call[bot.HedgeStatus](ctx, c, http.MethodGet, "/api/v1/trading/hedges", query)
+}
+
+// Risk returns exposure per symbol and in total, raw and scaled by each symbol's beta to the benchmark
+func (c *Client) Risk(ctx context.Context) (*bot.RiskReport, error) {
+	return call[bot.RiskReport](ctx, c, http.MethodGet, "/api/v1/risk", nil)
+}
+
+// RiskScenario evaluates price shocks on the open book (nil shocks use the server defaults)
+func (c *Client) RiskScenario(ctx context.Context, shocks []float64, betaScaled bool) (*bot.ScenarioReport, error) {
+	var report bot.ScenarioReport
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/risk/scenario", ScenarioRequest{Shocks: shocks, BetaScaled: betaScaled}, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ExecutionQuality returns slippage and fill latency stats (0 uses the server default limit)
+func (c *Client) ExecutionQuality(ctx context.Context, limit int) (*bot.ExecutionQuality, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	return call[bot.ExecutionQuality](ctx, c, http.MethodGet, "/api/v1/trading/execution-quality", query)
+}
+
+// ExitSafeMode resumes new entries after an outage
+func (c *Client) ExitSafeMode(ctx context.Context) (*SafeModeResponse, error) {
+	return call[SafeModeResponse](ctx, c, http.MethodPost, "/api/v1/trading/safe-mode/exit", nil)
+}
+
+// EnableTrading enables trade execution
+func (c *Client) EnableTrading(ctx context.Context) (*TradingControlResponse, error) {
+	return call[TradingControlResponse](ctx, c, http.MethodPost, "/api/v1/trading/enable", nil)
+}
+
+// DisableTrading disables trade execution
+func (c *Client) DisableTrading(ctx context.Context) (*TradingControlResponse, error) {
+	return call[TradingControlResponse](ctx, c, http.MethodPost, "/api/v1/trading/disable", nil)
+}
+
+// ClosePosition force-closes the open position
+func (c *Client) ClosePosition(ctx context.Context) (*TradingControlResponse, error) {
+	return call[TradingControlResponse](ctx, c, http.MethodPost, "/api/v1/trading/close", nil)
+}
+
+// RefreshData forces the server to re-fetch all timeframes (requires AdminToken)
+func (c *Client) RefreshData(ctx context.Context) (*AdminRefreshResponse, error) {
+	return call[AdminRefreshResponse](ctx, c, http.MethodPost, "/api/v1/admin/refresh-data", nil)
+}
+
+// ResetStats resets performance stats and daily loss counters (requires AdminToken)
+func (c *Client) ResetStats(ctx context.Context) (*AdminResetResponse, error) {
+	return call[AdminResetResponse](ctx, c, http.MethodPost, "/api/v1/admin/reset-stats", nil)
+}
+
+// ReloadCredentials rotates the server's Binance API keys; empty keys make the server
+// re-read its environment (requires AdminToken)
+func (c *Client) ReloadCredentials(ctx context.Context, apiKey, secretKey string) (*AdminCredentialsResponse, error) {
+	var out AdminCredentialsResponse
+	request := ReloadCredentialsRequest{APIKey: apiKey, SecretKey: secretKey}
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/admin/reload-credentials", request, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ValidateConfig checks a config on the server without applying it
+func (c *Client) ValidateConfig(ctx context.Context, config bot.Config) (*ConfigValidationResponse, error) {
+	var out ConfigValidationResponse
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/config/validate", config, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Alerts lists the price and indicator alerts
+func (c *Client) Alerts(ctx context.Context) (*AlertListResponse, error) {
+	return call[AlertListResponse](ctx, c, http.MethodGet, "/api/v1/alerts", nil)
+}
+
+// CreateAlert adds an alert, e.g. AlertSpec{Condition: "RSI_5m < 25"}
+func (c *Client) CreateAlert(ctx context.Context, spec bot.AlertSpec) (*bot.Alert, error) {
+	var out bot.Alert
+	if err := c.doJSON(ctx, http.MethodPost, "/api/v1/alerts", spec, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Alert returns one alert
+func (c *Client) Alert(ctx context.Context, id string) (*bot.Alert, error) {
+	return call[bot.Alert](ctx, c, http.MethodGet, "/api/v1/alerts/"+url.PathEscape(id), nil)
+}
+
+// UpdateAlert replaces an alert's spec and re-arms it
+func (c *Client) UpdateAlert(ctx context.Context, id string, spec bot.AlertSpec) (*bot.Alert, error) {
+	var out bot.Alert
+	if err := c.doJSON(ctx, http.MethodPut, "/api/v1/alerts/"+url.PathEscape(id), spec, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteAlert removes an alert
+func (c *Client) DeleteAlert(ctx context.Context, id string) (*AlertDeleteResponse, error) {
+	return call[AlertDeleteResponse](ctx, c, http.MethodDelete, "/api/v1/alerts/"+url.PathEscape(id), nil)
+}
+
+// Stream calls handler for each server-sent event until ctx is cancelled, the server
+// closes the stream or handler returns an error. No types means all event types.
+func (c *Client) Stream(ctx context.Context, handler func(StreamEvent) error, types ...bot.EventType) error {
+	query := url.Values{}
+	if len(types) > 0 {
+		query.Set("types", joinEventTypes(types))
+	}
+
+	request, err := c.newRequest(ctx, http.MethodGet, "/api/v1/stream", query, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+
+	// Streams are long-lived, so the client timeout doesn't apply
+	streamClient := *c.HTTPClient
+	streamClient.Timeout = 0
+	response, err := streamClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("stream request failed: %w", err)
+	}
+	defer response.Body.Close()
+	if err := checkResponse(response); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case line == "" && data.Len() > 0:
+			var event StreamEvent
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				return fmt.Errorf("failed to decode stream event: %w", err)
+			}
+			data.Reset()
+			if err := handler(event); err != nil {
+				return err
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+// EventSocket is a WebSocket connection to /ws opened by Connect
+type EventSocket struct {
+	conn  *websocket.Conn
+	mutex sync.Mutex // Serializes subscription requests
+}
+
+// Connect opens a WebSocket pushing events on topics; change them later with
+// Subscribe and Unsubscribe, and read them with Next
+func (c *Client) Connect(ctx context.Context, topics ...bot.EventType) (*EventSocket, error) {
+	query := url.Values{}
+	query.Set("topics", joinEventTypes(topics))
+	target := "ws" + strings.TrimPrefix(c.baseURL, "http") + "/api/v1/ws?" + query.Encode()
+	header := http.Header{}
+	if c.AdminToken != "" {
+		header.Set("Authorization", "Bearer "+c.AdminToken)
+	}
+
+	conn, response, err := websocket.DefaultDialer.DialContext(ctx, target, header)
+	if err != nil {
+		if response != nil {
+			if apiErr := checkResponse(response); apiErr != nil {
+				return nil, apiErr
+			}
+		}
+		return nil, fmt.Errorf("websocket connection failed: %w", err)
+	}
+	return &EventSocket{conn: conn}, nil
+}
+
+// Subscribe adds topics to the connection
+func (s *EventSocket) Subscribe(topics ...bot.EventType) error {
+	return s.request("subscribe", topics, nil)
+}
+
+// SubscribePredictions adds the prediction topic, pushing only predictions that pass filter
+func (s *EventSocket) SubscribePredictions(filter PredictionFilter) error {
+	return s.request("subscribe", []bot.EventType{bot.EventPrediction}, &filter)
+}
+
+// Unsubscribe removes topics from the connection
+func (s *EventSocket) Unsubscribe(topics ...bot.EventType) error {
+	return s.request("unsubscribe", topics, nil)
+}
+
+// request sends a subscription change; the server's acknowledgement arrives through Next
+func (s *EventSocket) request(action string, topics []bot.EventType, filter *PredictionFilter) error {
+	names := make([]string, len(topics))
+	for i, topic := range topics {
+		names[i] = string(topic)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.conn.WriteJSON(WebSocketRequest{Action: action, Topics: names, Filter: filter}); err != nil {
+		return fmt.Errorf("failed to send %s request: %w", action, err)
+	}
+	return nil
+}
+
+// Next blocks until the next event, skipping subscription acknowledgements. A rejected
+// subscription request is returned as an error; the connection stays usable.
+func (s *EventSocket) Next() (StreamEvent, error) {
+	for {
+		_, message, err := s.conn.ReadMessage()
+		if err != nil {
+			return StreamEvent{}, fmt.Errorf("websocket read failed: %w", err)
+		}
+		var reply WebSocketReply
+		if err := json.Unmarshal(message, &reply); err != nil {
+			return StreamEvent{}, fmt.Errorf("failed to decode websocket message: %w", err)
+		}
+		if reply.Type == "subscription" {
+			if reply.Error != "" {
+				return StreamEvent{}, fmt.Errorf("subscription rejected: %s", reply.Error)
+			}
+			continue
+		}
+		var event StreamEvent
+		if err := json.Unmarshal(message, &event); err != nil {
+			return StreamEvent{}, fmt.Errorf("failed to decode websocket event: %w", err)
+		}
+		return event, nil
+	}
+}
+
+// Close closes the connection
+func (s *EventSocket) Close() error {
+	return s.conn.Close()
+}
+
+// joinEventTypes joins event types for a query parameter
+func joinEventTypes(types []bot.EventType) string {
+	names := make([]string, len(types))
+	for i, eventType := range types {
+		names[i] = string(eventType)
+	}
+	return strings.Join(names, ",")
+}
+
+// call sends a request and decodes the JSON response into a new T
+func call[T any](ctx context.Context, c *Client, method, path string, query url.Values) (*T, error) {
+	var out T
+	if err := c.do(ctx, method, path, query, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// do sends a request and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, out interface{}) error {
+	request, err := c.newRequest(ctx, method, path, query, nil)
+	if err != nil {
+		return err
+	}
+	return c.send(request, out)
+}
+
+// doJSON sends body as JSON and decodes the JSON response into out
+func (c *Client) doJSON(ctx context.Context, method, path string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", path, err)
+	}
+	request, err := c.newRequest(ctx, method, path, nil, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	return c.send(request, out)
+}
+
+// send performs a request and decodes the JSON response into out
+func (c *Client) send(request *http.Request, out interface{}) error {
+	method, path := request.Method, request.URL.Path
+	request.Header.Set("Accept", "application/json")
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer response.Body.Close()
+	if err := checkResponse(response); err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", path, err)
+	}
+	return nil
+}
+
+// raw performs a GET and returns the response body
+func (c *Client) raw(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	request, err := c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s failed: %w", path, err)
+	}
+	defer response.Body.Close()
+	if err := checkResponse(response); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(response.Body)
+}
+
+// newRequest builds a request against the base URL
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if c.AdminToken != "" {
+		request.Header.Set("Authorization", "Bearer "+c.AdminToken)
+	}
+	return request, nil
+}
+
+// checkResponse turns non-2xx responses into an APIError carrying the server's message
+func checkResponse(response *http.Response) error {
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+	var payload struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		message = payload.Error
+	}
+	return &APIError{StatusCode: response.StatusCode, Message: message}
+}
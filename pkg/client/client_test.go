@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"trading-bot/pkg/bot"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestClientEndpoints(t *testing.T) {
+	t.Log("🔌 Testing Go SDK request building, decoding and errors")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/predict", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(PredictionResponse{Symbol: "BTCUSDT", Prediction: "HIGHER", TimeToTarget: r.URL.Query().Get("seconds") + "s"})
+	})
+	mux.HandleFunc("/api/v1/signals", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"No signals available"}`)
+	})
+	mux.HandleFunc("/api/v1/trading/close", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST for close, got %s", r.Method)
+		}
+		fmt.Fprint(w, `{"status":"success","message":"Position closed manually"}`)
+	})
+	mux.HandleFunc("/api/v1/trading/status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"enabled":true}`)
+	})
+	mux.HandleFunc("/api/v1/stream", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("types") != "signal,trade" {
+			t.Errorf("Unexpected types filter %q", r.URL.Query().Get("types"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event:signal\ndata:{\"id\":\"evt_1\",\"type\":\"signal\",\"data\":{\"confidence\":0.8}}\n\n")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event:trade\ndata:{\"id\":\"evt_2\",\"type\":\"trade\",\"data\":{\"pnl\":12.5}}\n\n")
+	})
+	mux.HandleFunc("/api/v1/ws", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("topics") != "signal" {
+			t.Errorf("Unexpected topics %q", r.URL.Query().Get("topics"))
+		}
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteJSON(WebSocketReply{Type: "subscription", Topics: []string{"signal"}})
+		var request WebSocketRequest
+		if err := conn.ReadJSON(&request); err != nil || request.Action != "subscribe" || len(request.Topics) != 1 || request.Topics[0] != "position" {
+			t.Errorf("Unexpected subscription request %+v (err %v)", request, err)
+		}
+		conn.WriteJSON(WebSocketReply{Type: "subscription", Topics: []string{"position", "signal"}})
+		conn.WriteJSON(WebSocketReply{Type: "subscription", Topics: []string{"position", "signal"}, Error: "unknown topic candles"})
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"evt_3","type":"position","data":{"pnl":4.5}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := New(server.URL + "/")
+	ctx := context.Background()
+
+	prediction, err := client.Predict(ctx, 300)
+	if err != nil {
+		t.Fatalf("Predict failed: %v", err)
+	}
+	if prediction.Prediction != "HIGHER" || prediction.TimeToTarget != "300s" {
+		t.Errorf("Unexpected prediction: %+v", prediction)
+	}
+
+	_, err = client.Signals(ctx)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "No signals available" {
+		t.Errorf("Expected 404 APIError with server message, got %v", err)
+	}
+
+	closed, err := client.ClosePosition(ctx)
+	if err != nil || closed.Status != "success" {
+		t.Errorf("Unexpected close response %+v (err %v)", closed, err)
+	}
+
+	status, err := client.TradingStatus(ctx)
+	if err != nil || !status.Enabled {
+		t.Errorf("Unexpected trading status %v (err %v)", status, err)
+	}
+
+	var received []StreamEvent
+	err = client.Stream(ctx, func(event StreamEvent) error {
+		received = append(received, event)
+		return nil
+	}, bot.EventSignal, bot.EventTrade)
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+	if len(received) != 2 || received[0].Type != bot.EventSignal || received[1].ID != "evt_2" {
+		t.Fatalf("Unexpected stream events: %+v", received)
+	}
+	var trade struct {
+		PnL float64 `json:"pnl"`
+	}
+	if err := json.Unmarshal(received[1].Data, &trade); err != nil || trade.PnL != 12.5 {
+		t.Errorf("Expected trade payload pnl 12.5, got %+v (err %v)", trade, err)
+	}
+
+	socket, err := client.Connect(ctx, bot.EventSignal)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer socket.Close()
+	if err := socket.Subscribe(bot.EventPosition); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if _, err := socket.Next(); err == nil || err.Error() != "subscription rejected: unknown topic candles" {
+		t.Errorf("Expected the rejected request as an error, got %v", err)
+	}
+	if event, err := socket.Next(); err != nil || event.Type != bot.EventPosition || event.ID != "evt_3" {
+		t.Errorf("Expected the pushed position event after the acknowledgements, got %+v (err %v)", event, err)
+	}
+}
@@ -53,6 +53,11 @@ func (atr *ATR) GetName() string {
 	return fmt.Sprintf("ATR_%s", atr.timeframe.String())
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (atr *ATR) RequiredCandles() int {
+	return atr.config.Period + 1 // True ranges start at the second candle
+}
+
 // Update processes new candle data using Pine Script ATR Trailing Stops logic
 func (atr *ATR) Update(candle Candle) {
 	atr.candles = append(atr.candles, candle)
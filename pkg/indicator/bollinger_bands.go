@@ -7,8 +7,9 @@ import (
 
 // BollingerBands represents a Bollinger Bands indicator
 type BollingerBands struct {
-	config    BollingerBandsConfig
-	timeframe Timeframe
+	config     BollingerBandsConfig
+	timeframe  Timeframe
+	vectorized bool // Use the sliding mean/variance path
 }
 
 // BollingerBandsValues holds all calculated values
@@ -34,6 +35,11 @@ func (bb *BollingerBands) GetName() string {
 	return "BollingerBands_" + bb.timeframe.String()
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (bb *BollingerBands) RequiredCandles() int {
+	return bb.config.Period
+}
+
 // Calculate computes Bollinger Bands values
 func (bb *BollingerBands) Calculate(candles []Candle) []float64 {
 	if len(candles) < bb.config.Period {
@@ -48,6 +54,9 @@ func (bb *BollingerBands) Calculate(candles []Candle) []float64 {
 
 // CalculateAll computes all Bollinger Bands values
 func (bb *BollingerBands) CalculateAll(candles []Candle) BollingerBandsValues {
+	if bb.vectorized {
+		return bb.CalculateAllVectorized(candles)
+	}
 	length := len(candles)
 	if length < bb.config.Period {
 		return BollingerBandsValues{}
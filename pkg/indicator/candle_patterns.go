@@ -0,0 +1,327 @@
+package indicator
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// CandlePatternsConfig holds candlestick pattern detection parameters
+type CandlePatternsConfig struct {
+	Enabled          bool     `json:"enabled"`           // Feature flag
+	Patterns         []string `json:"patterns"`          // Patterns detected (default: all)
+	TweezerTolerance float64  `json:"tweezer_tolerance"` // Max gap between tweezer extremes, as a fraction of the candles' average range (default: 0.05)
+}
+
+// CandlePatternMatch is a pattern completed by a candle
+type CandlePatternMatch struct {
+	Pattern  string
+	Index    int        // Candle that completes the pattern
+	Signal   SignalType // Buy for bullish patterns, Sell for bearish ones
+	Strength float64    // 0-1, scored per pattern
+}
+
+// candlePatternDetector scores the pattern completed by candles[i], returning
+// the signed strength (positive bullish, negative bearish) or 0 for no match
+type candlePatternDetector func(candles []Candle, i int, config CandlePatternsConfig) float64
+
+// candlePatterns are the detectors selectable by name
+var candlePatterns = map[string]candlePatternDetector{
+	"morning_star":         morningStar,
+	"evening_star":         eveningStar,
+	"three_white_soldiers": threeWhiteSoldiers,
+	"three_black_crows":    threeBlackCrows,
+	"tweezers":             tweezers,
+	"inside_bar":           insideBar,
+	"outside_bar":          outsideBar,
+	"harami":               harami,
+}
+
+// CandlePatternNames returns the detectable pattern names, sorted
+func CandlePatternNames() []string {
+	names := make([]string, 0, len(candlePatterns))
+	for name := range candlePatterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsCandlePattern reports whether name is a detectable pattern
+func IsCandlePattern(name string) bool {
+	_, ok := candlePatterns[name]
+	return ok
+}
+
+// CandlePatterns detects multi-candle reversal and continuation patterns
+type CandlePatterns struct {
+	config    CandlePatternsConfig
+	timeframe Timeframe
+	patterns  []string
+}
+
+// NewCandlePatterns creates a new candlestick pattern detector
+func NewCandlePatterns(config CandlePatternsConfig, timeframe Timeframe) *CandlePatterns {
+	patterns := config.Patterns
+	if len(patterns) == 0 {
+		patterns = CandlePatternNames()
+	}
+	return &CandlePatterns{
+		config:    config,
+		timeframe: timeframe,
+		patterns:  patterns,
+	}
+}
+
+// GetName returns the indicator name
+func (cp *CandlePatterns) GetName() string {
+	return "CandlePatterns_" + cp.timeframe.String()
+}
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (cp *CandlePatterns) RequiredCandles() int {
+	return 3
+}
+
+// Detect returns the strongest enabled pattern completed by each candle
+func (cp *CandlePatterns) Detect(candles []Candle) []CandlePatternMatch {
+	var matches []CandlePatternMatch
+	for i := 1; i < len(candles); i++ {
+		best := CandlePatternMatch{Index: i, Signal: Hold}
+		for _, name := range cp.patterns {
+			detect, ok := candlePatterns[name]
+			if !ok {
+				continue
+			}
+			if score := detect(candles, i, cp.config); math.Abs(score) > best.Strength {
+				best.Pattern, best.Strength, best.Signal = name, math.Abs(score), Buy
+				if score < 0 {
+					best.Signal = Sell
+				}
+			}
+		}
+		if best.Pattern != "" {
+			matches = append(matches, best)
+		}
+	}
+	return matches
+}
+
+// Calculate returns the signed strength of the strongest pattern completed by
+// each candle from the third on (positive bullish, negative bearish, 0 none)
+func (cp *CandlePatterns) Calculate(candles []Candle) []float64 {
+	if len(candles) < cp.RequiredCandles() {
+		return []float64{}
+	}
+	values := make([]float64, len(candles))
+	for _, match := range cp.Detect(candles) {
+		values[match.Index] = match.Strength
+		if match.Signal == Sell {
+			values[match.Index] = -match.Strength
+		}
+	}
+	return values[2:]
+}
+
+// GetSignal follows the pattern completed by the latest candle
+func (cp *CandlePatterns) GetSignal(values []float64, currentPrice float64) IndicatorSignal {
+	signal := IndicatorSignal{
+		Name:      cp.GetName(),
+		Signal:    Hold,
+		Timestamp: time.Now(),
+		Timeframe: cp.timeframe,
+	}
+	if len(values) == 0 {
+		return signal
+	}
+
+	latest := values[len(values)-1]
+	switch {
+	case latest > 0:
+		signal.Signal = Buy
+	case latest < 0:
+		signal.Signal = Sell
+	}
+	signal.Strength, signal.Value = math.Abs(latest), latest
+	return signal
+}
+
+// body is a candle's absolute open-to-close size
+func body(c Candle) float64 {
+	return math.Abs(c.Close - c.Open)
+}
+
+// candleRange is a candle's high-to-low size
+func candleRange(c Candle) float64 {
+	return c.High - c.Low
+}
+
+// bullish and bearish report a candle's color
+func bullish(c Candle) bool { return c.Close > c.Open }
+func bearish(c Candle) bool { return c.Close < c.Open }
+
+// morningStar: a long bearish candle, a small-bodied star below its close and a
+// bullish candle closing past the first body's midpoint. Scored by how much of
+// the first body the third candle wins back and how small the star is.
+func morningStar(candles []Candle, i int, _ CandlePatternsConfig) float64 {
+	return star(candles, i, 1)
+}
+
+// eveningStar mirrors the morning star at a top
+func eveningStar(candles []Candle, i int, _ CandlePatternsConfig) float64 {
+	return -star(candles, i, -1)
+}
+
+// star scores a morning (direction 1) or evening (-1) star
+func star(candles []Candle, i int, direction float64) float64 {
+	if i < 2 {
+		return 0
+	}
+	first, middle, last := candles[i-2], candles[i-1], candles[i]
+	firstBody := body(first)
+	if firstBody == 0 || firstBody < candleRange(first)/2 || body(middle) > firstBody*0.3 {
+		return 0
+	}
+	// First candle against the direction, last with it, star beyond the first close
+	if direction*(first.Close-first.Open) >= 0 || direction*(last.Close-last.Open) <= 0 ||
+		direction*((middle.Open+middle.Close)/2-first.Close) > 0 {
+		return 0
+	}
+	recovered := direction * (last.Close - first.Close) / firstBody
+	if recovered < 0.5 {
+		return 0
+	}
+	return math.Min(recovered, 1) * (1 - body(middle)/firstBody)
+}
+
+// threeWhiteSoldiers: three rising bullish candles, each opening inside the
+// previous body and closing near its high. Scored by how full the bodies are.
+func threeWhiteSoldiers(candles []Candle, i int, _ CandlePatternsConfig) float64 {
+	return soldiers(candles, i, 1)
+}
+
+// threeBlackCrows mirrors three white soldiers in a decline
+func threeBlackCrows(candles []Candle, i int, _ CandlePatternsConfig) float64 {
+	return -soldiers(candles, i, -1)
+}
+
+// soldiers scores three white soldiers (direction 1) or black crows (-1)
+func soldiers(candles []Candle, i int, direction float64) float64 {
+	if i < 2 {
+		return 0
+	}
+	fullness := 0.0
+	for j := i - 2; j <= i; j++ {
+		c := candles[j]
+		if direction*(c.Close-c.Open) <= 0 || candleRange(c) == 0 {
+			return 0
+		}
+		if j > i-2 {
+			previous := candles[j-1]
+			low, high := math.Min(previous.Open, previous.Close), math.Max(previous.Open, previous.Close)
+			if c.Open < low || c.Open > high || direction*(c.Close-previous.Close) <= 0 {
+				return 0
+			}
+		}
+		fullness += body(c) / candleRange(c)
+	}
+	if fullness /= 3; fullness < 0.5 {
+		return 0 // Long wicks show the move being rejected
+	}
+	return fullness
+}
+
+// tweezers: a reversal pair with matching extremes, bearish then bullish at a
+// bottom or bullish then bearish at a top. Scored by how closely the extremes match.
+func tweezers(candles []Candle, i int, config CandlePatternsConfig) float64 {
+	if i < 1 {
+		return 0
+	}
+	previous, current := candles[i-1], candles[i]
+	tolerance := config.TweezerTolerance
+	if tolerance <= 0 {
+		tolerance = 0.05
+	}
+	allowed := tolerance * (candleRange(previous) + candleRange(current)) / 2
+	if allowed == 0 {
+		return 0
+	}
+	switch {
+	case bearish(previous) && bullish(current):
+		if gap := math.Abs(previous.Low - current.Low); gap <= allowed {
+			return 0.5 + 0.5*(1-gap/allowed)
+		}
+	case bullish(previous) && bearish(current):
+		if gap := math.Abs(previous.High - current.High); gap <= allowed {
+			return -(0.5 + 0.5*(1-gap/allowed))
+		}
+	}
+	return 0
+}
+
+// insideBar: a candle inside the previous one's range, a pause that usually
+// continues the previous candle's direction. Scored by how tight the pause is, at
+// most 0.5 as the breakout is still to come.
+func insideBar(candles []Candle, i int, _ CandlePatternsConfig) float64 {
+	if i < 1 {
+		return 0
+	}
+	mother, current := candles[i-1], candles[i]
+	if current.High >= mother.High || current.Low <= mother.Low || candleRange(mother) == 0 {
+		return 0
+	}
+	score := 0.5 * (1 - candleRange(current)/candleRange(mother))
+	switch {
+	case bullish(mother):
+		return score
+	case bearish(mother):
+		return -score
+	}
+	return 0
+}
+
+// outsideBar: a candle whose range engulfs the previous one, in the direction it
+// closes. Scored by body fullness and how far the range extends past the previous.
+func outsideBar(candles []Candle, i int, _ CandlePatternsConfig) float64 {
+	if i < 1 {
+		return 0
+	}
+	previous, current := candles[i-1], candles[i]
+	if current.High <= previous.High || current.Low >= previous.Low || candleRange(previous) == 0 {
+		return 0
+	}
+	score := body(current) / candleRange(current) * math.Min(candleRange(current)/candleRange(previous)-1, 1)
+	switch {
+	case bullish(current):
+		return score
+	case bearish(current):
+		return -score
+	}
+	return 0
+}
+
+// harami: a small opposite-colored body inside a long previous body, bullish after
+// a bearish candle and bearish after a bullish one. Scored by how small the inner
+// body is, at most 0.8 as it needs confirmation.
+func harami(candles []Candle, i int, _ CandlePatternsConfig) float64 {
+	if i < 1 {
+		return 0
+	}
+	mother, current := candles[i-1], candles[i]
+	motherBody := body(mother)
+	if motherBody == 0 || motherBody < candleRange(mother)/2 {
+		return 0
+	}
+	low, high := math.Min(mother.Open, mother.Close), math.Max(mother.Open, mother.Close)
+	if math.Min(current.Open, current.Close) <= low || math.Max(current.Open, current.Close) >= high {
+		return 0
+	}
+	score := 0.8 * (1 - body(current)/motherBody)
+	switch {
+	case bearish(mother) && bullish(current):
+		return score
+	case bullish(mother) && bearish(current):
+		return -score
+	}
+	return 0
+}
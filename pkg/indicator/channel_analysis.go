@@ -243,6 +243,11 @@ func (ca *ChannelAnalysis) GetName() string {
 	return fmt.Sprintf("Channel_%s", ca.timeframe.String())
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (ca *ChannelAnalysis) RequiredCandles() int {
+	return ca.config.LookbackPeriod + 6 // Pivot detection buffers
+}
+
 // GetChannelAnalysis returns detailed channel information
 func (ca *ChannelAnalysis) GetChannelAnalysis(values []float64) string {
 	if len(values) == 0 {
@@ -280,3 +285,40 @@ func (ca *ChannelAnalysis) GetDataStatus(candleCount int) string {
 	return fmt.Sprintf("✅ READY: %d candles, %d highs, %d lows - Channel analysis active",
 		candleCount, len(ca.highs), len(ca.lows))
 }
+
+// Band channel signal modes, shared by Keltner and Donchian channels
+const (
+	ChannelModeBreakout      = "breakout"       // Follow closes outside the channel
+	ChannelModeMeanReversion = "mean_reversion" // Fade moves into the outer bands back toward the middle
+)
+
+// channelBandSignal turns a close's position in a channel (0 = lower band, 1 = upper
+// band, beyond either when outside) into a signal for the mode
+func channelBandSignal(mode string, position float64) (SignalType, float64) {
+	if mode == ChannelModeBreakout {
+		switch {
+		case position > 1:
+			return Buy, math.Min(0.6+(position-1), 1.0)
+		case position < 0:
+			return Sell, math.Min(0.6-position, 1.0)
+		}
+		return Hold, 0.3
+	}
+
+	// Mean reversion: the outer 10% of the channel, or beyond, fades
+	switch {
+	case position <= 0.1:
+		return Buy, math.Min(0.6+(0.1-position)*2, 1.0)
+	case position >= 0.9:
+		return Sell, math.Min(0.6+(position-0.9)*2, 1.0)
+	}
+	return Hold, 0.3
+}
+
+// channelPosition locates a close between the lower and upper bands
+func channelPosition(close, lower, upper float64) float64 {
+	if upper-lower <= 0 {
+		return 0.5 // Default to middle if no range
+	}
+	return (close - lower) / (upper - lower)
+}
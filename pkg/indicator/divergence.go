@@ -0,0 +1,129 @@
+package indicator
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// DivergenceKind classifies a divergence between price and an oscillator
+type DivergenceKind string
+
+const (
+	RegularBullish DivergenceKind = "regular_bullish" // Lower price low, higher oscillator low: selling is fading
+	RegularBearish DivergenceKind = "regular_bearish" // Higher price high, lower oscillator high: buying is fading
+	HiddenBullish  DivergenceKind = "hidden_bullish"  // Higher price low, lower oscillator low: uptrend continuation
+	HiddenBearish  DivergenceKind = "hidden_bearish"  // Lower price high, higher oscillator high: downtrend continuation
+)
+
+// DivergenceConfig holds divergence detection parameters
+type DivergenceConfig struct {
+	PivotBars int  // Candles on each side that confirm a swing high/low (default: 3)
+	Lookback  int  // Candles searched for the last two swings (default: 60)
+	Hidden    bool // Also report hidden divergences
+}
+
+// Divergence is a disagreement between the last two price swings and the
+// oscillator at the same candles
+type Divergence struct {
+	Kind     DivergenceKind
+	Signal   SignalType // Buy for bullish kinds, Sell for bearish ones
+	Strength float64    // 0-1: the price and oscillator moves between the swings, relative to their ranges
+	From, To int        // Candle indexes of the earlier and later swing
+	Level    float64    // Oscillator value at the later swing
+}
+
+// DetectDivergence compares the last two confirmed swing lows and swing highs of
+// candles with the oscillator series, which is aligned to the last candles (as
+// Calculate returns it). When both sides diverge the one with the later swing wins.
+func DetectDivergence(candles []Candle, oscillator []float64, config DivergenceConfig) (Divergence, bool) {
+	n := len(candles)
+	offset := n - len(oscillator)
+	pivotBars := max(config.PivotBars, 1)
+	start := max(offset, n-config.Lookback, pivotBars)
+	end := n - pivotBars // First index without enough candles after it to confirm a swing
+	if offset < 0 || end-start < 2 {
+		return Divergence{}, false
+	}
+
+	var lows, highs []int
+	for i := start; i < end; i++ {
+		if isSwing(candles, i, pivotBars, func(c Candle) float64 { return -c.Low }) {
+			lows = append(lows, i)
+		}
+		if isSwing(candles, i, pivotBars, func(c Candle) float64 { return c.High }) {
+			highs = append(highs, i)
+		}
+	}
+
+	priceLow, priceHigh := math.Inf(1), math.Inf(-1)
+	oscLow, oscHigh := math.Inf(1), math.Inf(-1)
+	for i := start; i < n; i++ {
+		priceLow, priceHigh = math.Min(priceLow, candles[i].Low), math.Max(priceHigh, candles[i].High)
+		oscLow, oscHigh = math.Min(oscLow, oscillator[i-offset]), math.Max(oscHigh, oscillator[i-offset])
+	}
+	compare := func(swings []int, price func(Candle) float64, regular, hidden DivergenceKind, signal SignalType) (Divergence, bool) {
+		if len(swings) < 2 {
+			return Divergence{}, false
+		}
+		from, to := swings[len(swings)-2], swings[len(swings)-1]
+		priceMove := price(candles[to]) - price(candles[from])
+		oscMove := oscillator[to-offset] - oscillator[from-offset]
+
+		var kind DivergenceKind
+		switch {
+		case priceMove == 0 || oscMove == 0 || (priceMove > 0) == (oscMove > 0):
+			return Divergence{}, false
+		case (signal == Buy) == (priceMove < 0):
+			kind = regular // Price extends the swing the oscillator doesn't confirm
+		case config.Hidden:
+			kind = hidden
+		default:
+			return Divergence{}, false
+		}
+		strength := (math.Abs(priceMove)/(priceHigh-priceLow) + math.Abs(oscMove)/(oscHigh-oscLow)) / 2
+		return Divergence{Kind: kind, Signal: signal, Strength: math.Min(strength, 1), From: from, To: to, Level: oscillator[to-offset]}, true
+	}
+
+	bullish, bullishOK := compare(lows, func(c Candle) float64 { return c.Low }, RegularBullish, HiddenBullish, Buy)
+	bearish, bearishOK := compare(highs, func(c Candle) float64 { return c.High }, RegularBearish, HiddenBearish, Sell)
+	switch {
+	case bullishOK && (!bearishOK || bullish.To > bearish.To || (bullish.To == bearish.To && bullish.Strength >= bearish.Strength)):
+		return bullish, true
+	case bearishOK:
+		return bearish, true
+	}
+	return Divergence{}, false
+}
+
+// isSwing reports whether candle i is the strict extreme of value over the
+// pivotBars candles on each side (ties with earlier candles don't count)
+func isSwing(candles []Candle, i, pivotBars int, value func(Candle) float64) bool {
+	v := value(candles[i])
+	for j := i - pivotBars; j <= i+pivotBars; j++ {
+		if j == i {
+			continue
+		}
+		if other := value(candles[j]); other > v || (other == v && j < i) {
+			return false
+		}
+	}
+	return true
+}
+
+// DivergenceSignal runs DetectDivergence on an oscillator's calculated values and
+// returns the result as a signal named after it (RSI_5m -> RSIDivergence_5m)
+func DivergenceSignal(oscillator TechnicalIndicator, timeframe Timeframe, candles []Candle, values []float64, config DivergenceConfig) (IndicatorSignal, bool) {
+	divergence, ok := DetectDivergence(candles, values, config)
+	if !ok {
+		return IndicatorSignal{}, false
+	}
+	return IndicatorSignal{
+		Name:      strings.TrimSuffix(oscillator.GetName(), "_"+timeframe.String()) + "Divergence_" + timeframe.String(),
+		Signal:    divergence.Signal,
+		Strength:  divergence.Strength,
+		Value:     divergence.Level,
+		Timestamp: time.Now(),
+		Timeframe: timeframe,
+	}, true
+}
@@ -0,0 +1,107 @@
+package indicator
+
+import (
+	"time"
+)
+
+// DonchianChannelConfig holds Donchian Channel parameters
+type DonchianChannelConfig struct {
+	Enabled bool   `json:"enabled"` // Feature flag
+	Period  int    `json:"period"`  // Candles the highest high and lowest low span (default: 20)
+	Mode    string `json:"mode"`    // "breakout" (default) or "mean_reversion"
+}
+
+// DonchianChannel bands the highest high and lowest low of the preceding candles,
+// so a close beyond either band is a new extreme
+type DonchianChannel struct {
+	config    DonchianChannelConfig
+	timeframe Timeframe
+}
+
+// DonchianChannelValues holds all calculated values; entries before the warm-up are zero
+type DonchianChannelValues struct {
+	UpperBand  []float64 // Highest high of the previous Period candles
+	MiddleBand []float64
+	LowerBand  []float64 // Lowest low of the previous Period candles
+	Position   []float64 // Close within the bands (0 = lower, 1 = upper)
+}
+
+// NewDonchianChannel creates a new Donchian Channel indicator
+func NewDonchianChannel(config DonchianChannelConfig, timeframe Timeframe) *DonchianChannel {
+	return &DonchianChannel{
+		config:    config,
+		timeframe: timeframe,
+	}
+}
+
+// GetName returns the indicator name
+func (dc *DonchianChannel) GetName() string {
+	return "DonchianChannel_" + dc.timeframe.String()
+}
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (dc *DonchianChannel) RequiredCandles() int {
+	return dc.config.Period + 1
+}
+
+// Calculate returns the close's position within the previous candles' channel
+func (dc *DonchianChannel) Calculate(candles []Candle) []float64 {
+	if dc.config.Period < 1 || len(candles) <= dc.config.Period {
+		return []float64{}
+	}
+	return dc.CalculateAll(candles).Position[dc.config.Period:]
+}
+
+// CalculateAll computes the bands with the rolling extremes of the vectorized path
+func (dc *DonchianChannel) CalculateAll(candles []Candle) DonchianChannelValues {
+	length := len(candles)
+	values := DonchianChannelValues{
+		UpperBand:  make([]float64, length),
+		MiddleBand: make([]float64, length),
+		LowerBand:  make([]float64, length),
+		Position:   make([]float64, length),
+	}
+	if dc.config.Period < 1 || length <= dc.config.Period {
+		return values
+	}
+
+	highs, lows := candleColumns(candles)
+	highest, lowest := make([]float64, length), make([]float64, length)
+	queue := make([]int, length)
+	rollingMax(highs, dc.config.Period, highest, queue)
+	rollingMin(lows, dc.config.Period, lowest, queue)
+
+	for i := dc.config.Period; i < length; i++ {
+		values.UpperBand[i] = highest[i-1]
+		values.LowerBand[i] = lowest[i-1]
+		values.MiddleBand[i] = (highest[i-1] + lowest[i-1]) / 2
+		values.Position[i] = channelPosition(candles[i].Close, lowest[i-1], highest[i-1])
+	}
+	return values
+}
+
+// GetSignal follows new highs and lows in breakout mode, or fades closes near
+// the bands in mean_reversion mode
+func (dc *DonchianChannel) GetSignal(values []float64, currentPrice float64) IndicatorSignal {
+	if len(values) == 0 {
+		return IndicatorSignal{
+			Name:      dc.GetName(),
+			Signal:    Hold,
+			Strength:  0,
+			Value:     0,
+			Timestamp: time.Now(),
+			Timeframe: dc.timeframe,
+		}
+	}
+
+	position := values[len(values)-1]
+	signal, strength := channelBandSignal(dc.config.Mode, position)
+	return IndicatorSignal{
+		Name:      dc.GetName(),
+		Signal:    signal,
+		Strength:  strength,
+		Value:     position,
+		Timestamp: time.Now(),
+		Timeframe: dc.timeframe,
+	}
+}
@@ -113,6 +113,7 @@ type ElliottWave struct {
 	lastWaveCount  WaveCount
 	fibonacciLevel float64
 	initialized    bool
+	vectorized     bool // Calculate recomputes from scratch with FindPivots
 }
 
 // NewElliottWave creates a new Elliott Wave indicator
@@ -647,6 +648,9 @@ func (ew *ElliottWave) analyzeCurrentWave() (SignalType, float64) {
 
 // Calculate implements TechnicalIndicator interface
 func (ew *ElliottWave) Calculate(candles []Candle) []float64 {
+	if ew.vectorized {
+		return ew.CalculateVectorized(candles)
+	}
 	if len(candles) < ew.config.MinWaveLength*2 {
 		return []float64{}
 	}
@@ -689,6 +693,11 @@ func (ew *ElliottWave) GetName() string {
 	return "ElliottWave"
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (ew *ElliottWave) RequiredCandles() int {
+	return ew.config.MinWaveLength * 2
+}
+
 // GetLastSignal returns the last signal and strength
 func (ew *ElliottWave) GetLastSignal() (SignalType, float64) {
 	return ew.lastSignal, ew.lastStrength
@@ -372,6 +372,15 @@ func (ema *EMA) GetName() string {
 	return "EMA"
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+// (three values of the slowest EMA)
+func (ema *EMA) RequiredCandles() int {
+	if ema.config.TrendPeriod > ema.config.SlowPeriod {
+		return ema.config.TrendPeriod + 2
+	}
+	return ema.config.SlowPeriod + 2
+}
+
 // GetLastSignal returns the last signal and strength
 func (ema *EMA) GetLastSignal() (SignalType, float64) {
 	return ema.lastSignal, ema.lastStrength
@@ -0,0 +1,53 @@
+package indicator
+
+import (
+	"math"
+	"sort"
+)
+
+// CandleTransform rewrites candles before an indicator calculates on them
+type CandleTransform func(candles []Candle) []Candle
+
+// candleTransforms are the transforms selectable by name
+var candleTransforms = map[string]CandleTransform{
+	"heikin_ashi": HeikinAshi,
+}
+
+// LookupCandleTransform returns the transform registered under name
+func LookupCandleTransform(name string) (CandleTransform, bool) {
+	transform, ok := candleTransforms[name]
+	return transform, ok
+}
+
+// CandleTransformNames returns the registered transform names, sorted
+func CandleTransformNames() []string {
+	names := make([]string, 0, len(candleTransforms))
+	for name := range candleTransforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HeikinAshi returns Heikin-Ashi candles: each close averages the candle's OHLC and
+// each open is the midpoint of the previous Heikin-Ashi body, which smooths out
+// noise so trends show as runs of same-colored candles. Timestamps and volume are kept.
+func HeikinAshi(candles []Candle) []Candle {
+	transformed := make([]Candle, len(candles))
+	for i, candle := range candles {
+		close := (candle.Open + candle.High + candle.Low + candle.Close) / 4
+		open := (candle.Open + candle.Close) / 2
+		if i > 0 {
+			open = (transformed[i-1].Open + transformed[i-1].Close) / 2
+		}
+		transformed[i] = Candle{
+			Timestamp: candle.Timestamp,
+			Open:      open,
+			High:      math.Max(candle.High, math.Max(open, close)),
+			Low:       math.Min(candle.Low, math.Min(open, close)),
+			Close:     close,
+			Volume:    candle.Volume,
+		}
+	}
+	return transformed
+}
@@ -8,8 +8,9 @@ import (
 
 // Ichimoku Cloud Indicator with 5-minute optimization
 type Ichimoku struct {
-	config    IchimokuConfig
-	timeframe Timeframe
+	config     IchimokuConfig
+	timeframe  Timeframe
+	vectorized bool // Use the O(n) rolling high/low path
 }
 
 // NewIchimoku creates a new Ichimoku indicator
@@ -137,6 +138,9 @@ func (ich *Ichimoku) calculate5MinuteSignal(currentPrice, cloudTop, cloudBottom
 
 // calculateAllWithConfig computes all Ichimoku components using provided config
 func (ich *Ichimoku) calculateAllWithConfig(candles []Candle, config IchimokuConfig) IchimokuValues {
+	if ich.vectorized {
+		return ich.calculateAllVectorized(candles, config)
+	}
 	if len(candles) < config.SenkouPeriod {
 		return IchimokuValues{}
 	}
@@ -634,3 +638,8 @@ func (ich *Ichimoku) GetDetailedSignal(candles []Candle, currentPrice float64) I
 func (ich *Ichimoku) GetName() string {
 	return fmt.Sprintf("Ichimoku_%s", ich.timeframe.String())
 }
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (ich *Ichimoku) RequiredCandles() int {
+	return ich.get5MinuteOptimizedConfig().SenkouPeriod
+}
@@ -0,0 +1,137 @@
+package indicator
+
+import (
+	"math"
+	"time"
+)
+
+// KeltnerChannelConfig holds Keltner Channel parameters
+type KeltnerChannelConfig struct {
+	Enabled    bool    `json:"enabled"`    // Feature flag
+	EMAPeriod  int     `json:"ema_period"` // Middle line EMA period (default: 20)
+	ATRPeriod  int     `json:"atr_period"` // ATR period for the band width (default: 10)
+	Multiplier float64 `json:"multiplier"` // Band distance from the middle in ATRs (default: 2)
+	Mode       string  `json:"mode"`       // "breakout" or "mean_reversion" (default)
+}
+
+// KeltnerChannel places bands an ATR multiple above and below an EMA of the close
+type KeltnerChannel struct {
+	config    KeltnerChannelConfig
+	timeframe Timeframe
+}
+
+// KeltnerChannelValues holds all calculated values; entries before the warm-up are zero
+type KeltnerChannelValues struct {
+	UpperBand  []float64
+	MiddleBand []float64 // EMA of the close
+	LowerBand  []float64
+	Position   []float64 // Close within the bands (0 = lower, 1 = upper)
+}
+
+// NewKeltnerChannel creates a new Keltner Channel indicator
+func NewKeltnerChannel(config KeltnerChannelConfig, timeframe Timeframe) *KeltnerChannel {
+	return &KeltnerChannel{
+		config:    config,
+		timeframe: timeframe,
+	}
+}
+
+// GetName returns the indicator name
+func (kc *KeltnerChannel) GetName() string {
+	return "KeltnerChannel_" + kc.timeframe.String()
+}
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (kc *KeltnerChannel) RequiredCandles() int {
+	return kc.warmup() + 1
+}
+
+// warmup is the index of the first candle with both the EMA and the ATR
+func (kc *KeltnerChannel) warmup() int {
+	if kc.config.EMAPeriod > kc.config.ATRPeriod {
+		return kc.config.EMAPeriod - 1
+	}
+	return kc.config.ATRPeriod - 1
+}
+
+// Calculate returns the close's position within the bands from the first complete candle on
+func (kc *KeltnerChannel) Calculate(candles []Candle) []float64 {
+	if kc.config.EMAPeriod < 1 || kc.config.ATRPeriod < 1 || len(candles) <= kc.warmup() {
+		return []float64{}
+	}
+	return kc.CalculateAll(candles).Position[kc.warmup():]
+}
+
+// CalculateAll computes the bands with an SMA-seeded EMA and a Wilder-smoothed ATR
+func (kc *KeltnerChannel) CalculateAll(candles []Candle) KeltnerChannelValues {
+	length := len(candles)
+	values := KeltnerChannelValues{
+		UpperBand:  make([]float64, length),
+		MiddleBand: make([]float64, length),
+		LowerBand:  make([]float64, length),
+		Position:   make([]float64, length),
+	}
+	if kc.config.EMAPeriod < 1 || kc.config.ATRPeriod < 1 || length <= kc.warmup() {
+		return values
+	}
+
+	ema := make([]float64, length)
+	for i := 0; i < kc.config.EMAPeriod; i++ {
+		ema[kc.config.EMAPeriod-1] += candles[i].Close / float64(kc.config.EMAPeriod)
+	}
+	alpha := 2.0 / float64(kc.config.EMAPeriod+1)
+	for i := kc.config.EMAPeriod; i < length; i++ {
+		ema[i] = ema[i-1] + alpha*(candles[i].Close-ema[i-1])
+	}
+
+	trueRanges := make([]float64, length)
+	for i, candle := range candles {
+		trueRanges[i] = candle.High - candle.Low
+		if i > 0 {
+			previousClose := candles[i-1].Close
+			trueRanges[i] = math.Max(trueRanges[i], math.Max(math.Abs(candle.High-previousClose), math.Abs(candle.Low-previousClose)))
+		}
+	}
+	atr := make([]float64, length)
+	period := float64(kc.config.ATRPeriod)
+	for i := 0; i < kc.config.ATRPeriod; i++ {
+		atr[kc.config.ATRPeriod-1] += trueRanges[i] / period
+	}
+	for i := kc.config.ATRPeriod; i < length; i++ {
+		atr[i] = (atr[i-1]*(period-1) + trueRanges[i]) / period
+	}
+
+	for i := kc.warmup(); i < length; i++ {
+		values.MiddleBand[i] = ema[i]
+		values.UpperBand[i] = ema[i] + kc.config.Multiplier*atr[i]
+		values.LowerBand[i] = ema[i] - kc.config.Multiplier*atr[i]
+		values.Position[i] = channelPosition(candles[i].Close, values.LowerBand[i], values.UpperBand[i])
+	}
+	return values
+}
+
+// GetSignal follows closes outside the bands in breakout mode, or fades the outer
+// bands in mean_reversion mode
+func (kc *KeltnerChannel) GetSignal(values []float64, currentPrice float64) IndicatorSignal {
+	if len(values) == 0 {
+		return IndicatorSignal{
+			Name:      kc.GetName(),
+			Signal:    Hold,
+			Strength:  0,
+			Value:     0,
+			Timestamp: time.Now(),
+			Timeframe: kc.timeframe,
+		}
+	}
+
+	position := values[len(values)-1]
+	signal, strength := channelBandSignal(kc.config.Mode, position)
+	return IndicatorSignal{
+		Name:      kc.GetName(),
+		Signal:    signal,
+		Strength:  strength,
+		Value:     position,
+		Timestamp: time.Now(),
+		Timeframe: kc.timeframe,
+	}
+}
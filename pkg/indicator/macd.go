@@ -87,6 +87,11 @@ func (macd *MACD) GetName() string {
 	return fmt.Sprintf("MACD_%s", macd.timeframe.String())
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (macd *MACD) RequiredCandles() int {
+	return macd.config.SlowPeriod
+}
+
 // Helper function to calculate EMA
 func calculateEMA(candles []Candle, period int) []float64 {
 	if len(candles) < period {
@@ -0,0 +1,142 @@
+package indicator
+
+import (
+	"math"
+	"time"
+)
+
+// ParabolicSARConfig holds Parabolic SAR parameters
+type ParabolicSARConfig struct {
+	Enabled bool    `json:"enabled"`  // Feature flag
+	Step    float64 `json:"step"`     // Acceleration factor start and increment (default: 0.02)
+	MaxStep float64 `json:"max_step"` // Acceleration factor cap (default: 0.2)
+}
+
+// ParabolicSAR is Wilder's stop-and-reverse: a stop that trails the trend's extreme
+// point faster as the trend extends, flipping sides when price crosses it
+type ParabolicSAR struct {
+	config    ParabolicSARConfig
+	timeframe Timeframe
+}
+
+// ParabolicSARValues holds all calculated values; the first candle has none
+type ParabolicSARValues struct {
+	SAR  []float64 // Stop in force for the next candle, computed at each close
+	Long []bool    // Trend direction at each close
+}
+
+// NewParabolicSAR creates a new Parabolic SAR indicator
+func NewParabolicSAR(config ParabolicSARConfig, timeframe Timeframe) *ParabolicSAR {
+	return &ParabolicSAR{
+		config:    config,
+		timeframe: timeframe,
+	}
+}
+
+// GetName returns the indicator name
+func (ps *ParabolicSAR) GetName() string {
+	return "ParabolicSAR_" + ps.timeframe.String()
+}
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (ps *ParabolicSAR) RequiredCandles() int {
+	return 2
+}
+
+// Calculate returns the SAR level from the second candle on
+func (ps *ParabolicSAR) Calculate(candles []Candle) []float64 {
+	if len(candles) < ps.RequiredCandles() {
+		return []float64{}
+	}
+	return ps.CalculateAll(candles).SAR[1:]
+}
+
+// CalculateAll runs the stop-and-reverse over candles. The first trend follows
+// the second candle's close; a candle trading through the stop flips the trend
+// and restarts the stop at the previous trend's extreme point.
+func (ps *ParabolicSAR) CalculateAll(candles []Candle) ParabolicSARValues {
+	length := len(candles)
+	values := ParabolicSARValues{
+		SAR:  make([]float64, length),
+		Long: make([]bool, length),
+	}
+	if length < ps.RequiredCandles() {
+		return values
+	}
+
+	long := candles[1].Close >= candles[0].Close
+	var stop, extreme float64
+	if long {
+		stop, extreme = math.Min(candles[0].Low, candles[1].Low), math.Max(candles[0].High, candles[1].High)
+	} else {
+		stop, extreme = math.Max(candles[0].High, candles[1].High), math.Min(candles[0].Low, candles[1].Low)
+	}
+	acceleration := ps.config.Step
+	values.SAR[1], values.Long[1] = stop, long
+
+	for i := 2; i < length; i++ {
+		candle := candles[i]
+		switch {
+		case long && candle.Low <= stop:
+			long, stop, extreme, acceleration = false, extreme, candle.Low, ps.config.Step
+		case !long && candle.High >= stop:
+			long, stop, extreme, acceleration = true, extreme, candle.High, ps.config.Step
+		case long && candle.High > extreme:
+			extreme, acceleration = candle.High, math.Min(acceleration+ps.config.Step, ps.config.MaxStep)
+		case !long && candle.Low < extreme:
+			extreme, acceleration = candle.Low, math.Min(acceleration+ps.config.Step, ps.config.MaxStep)
+		}
+
+		// Project the next candle's stop, never inside the last two candles' range
+		stop += acceleration * (extreme - stop)
+		if long {
+			stop = math.Min(stop, math.Min(candle.Low, candles[i-1].Low))
+		} else {
+			stop = math.Max(stop, math.Max(candle.High, candles[i-1].High))
+		}
+		values.SAR[i], values.Long[i] = stop, long
+	}
+	return values
+}
+
+// CurrentSAR returns the stop in force for the next candle and whether the trend
+// is up, for use as a trailing stop; ok is false without enough candles
+func (ps *ParabolicSAR) CurrentSAR(candles []Candle) (level float64, long bool, ok bool) {
+	if len(candles) < ps.RequiredCandles() {
+		return 0, false, false
+	}
+	values := ps.CalculateAll(candles)
+	return values.SAR[len(candles)-1], values.Long[len(candles)-1], true
+}
+
+// GetSignal follows the side of the SAR price is on, stronger the further price
+// has pulled away from it (full strength at 2%)
+func (ps *ParabolicSAR) GetSignal(values []float64, currentPrice float64) IndicatorSignal {
+	if len(values) == 0 || currentPrice <= 0 {
+		return IndicatorSignal{
+			Name:      ps.GetName(),
+			Signal:    Hold,
+			Strength:  0,
+			Value:     0,
+			Timestamp: time.Now(),
+			Timeframe: ps.timeframe,
+		}
+	}
+
+	sar := values[len(values)-1]
+	signal := Hold
+	switch {
+	case currentPrice > sar:
+		signal = Buy
+	case currentPrice < sar:
+		signal = Sell
+	}
+	return IndicatorSignal{
+		Name:      ps.GetName(),
+		Signal:    signal,
+		Strength:  math.Min(math.Abs(currentPrice-sar)/currentPrice*50, 1),
+		Value:     sar,
+		Timestamp: time.Now(),
+		Timeframe: ps.timeframe,
+	}
+}
@@ -411,6 +411,11 @@ func (pb *PinBar) GetName() string {
 	return "PinBar"
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (pb *PinBar) RequiredCandles() int {
+	return 3
+}
+
 // GetLastSignal returns the last signal and strength
 func (pb *PinBar) GetLastSignal() (SignalType, float64) {
 	return pb.lastSignal, pb.lastStrength
@@ -0,0 +1,926 @@
+package indicator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PineStudy runs a TradingView Pine Script study written in a small subset of
+// the language: inputs, arithmetic, ternaries, history references (x[1]) and
+// the ta.*/math.* functions listed in pineFunctions. The study signals BUY when
+// its buy series is true on the last bar and SELL when its sell series is.
+type PineStudy struct {
+	title      string
+	timeframe  Timeframe
+	strength   float64
+	statements []pineStatement
+	buyVar     string
+	sellVar    string
+	lastBuy    bool
+	lastSell   bool
+}
+
+// pineBuyNames and pineSellNames are the variables a study may use for its entries
+var (
+	pineBuyNames  = []string{"buy", "long", "buySignal", "longCondition"}
+	pineSellNames = []string{"sell", "short", "sellSignal", "shortCondition"}
+)
+
+// pineFunctions maps supported functions (without ta./math. prefixes) to their positional argument counts
+var pineFunctions = map[string][2]int{
+	"sma": {2, 2}, "ema": {2, 2}, "rma": {2, 2}, "wma": {2, 2}, "rsi": {2, 2}, "atr": {1, 1}, "stdev": {2, 2},
+	"highest": {2, 2}, "lowest": {2, 2}, "crossover": {2, 2}, "crossunder": {2, 2}, "cross": {2, 2},
+	"tr": {0, 1}, "change": {1, 2}, "nz": {1, 2}, "na": {1, 1}, "iff": {3, 3},
+	"abs": {1, 1}, "max": {2, 2}, "min": {2, 2}, "sqrt": {1, 1}, "log": {1, 1}, "round": {1, 1},
+}
+
+// pineIgnoredCalls are drawing and alert calls that don't affect the signal
+var pineIgnoredCalls = map[string]bool{
+	"plotshape": true, "plotchar": true, "plotarrow": true, "hline": true, "fill": true,
+	"bgcolor": true, "barcolor": true, "alertcondition": true,
+}
+
+type pineStatement struct {
+	line   int
+	name   string // Empty for bare calls such as indicator() or plot()
+	expr   *pineNode
+	isPlot bool
+}
+
+type pineNode struct {
+	kind     string // "num", "str", "bool", "na", "var", "call", "index", "unary", "binary", "ternary"
+	op       string
+	num      float64
+	str      string
+	name     string
+	args     []*pineNode
+	named    map[string]*pineNode
+	children []*pineNode
+}
+
+type pineToken struct {
+	kind string // "ident", "num", "str", "op", "newline", "eof"
+	text string
+	line int
+}
+
+// NewPineStudy compiles a Pine Script study; name is used when the script has no indicator() title
+func NewPineStudy(source, name string, timeframe Timeframe, strength float64) (*PineStudy, error) {
+	tokens, err := tokenizePine(source)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &pineParser{tokens: tokens, defined: make(map[string]bool)}
+	study := &PineStudy{title: name, timeframe: timeframe, strength: strength}
+	for {
+		parser.skipNewlines()
+		if parser.peek().kind == "eof" {
+			break
+		}
+		statement, err := parser.statement()
+		if err != nil {
+			return nil, err
+		}
+		if statement.name == "" && statement.expr.kind == "call" {
+			switch statement.expr.name {
+			case "indicator", "study":
+				if len(statement.expr.args) > 0 && statement.expr.args[0].kind == "str" {
+					study.title = statement.expr.args[0].str
+				}
+				continue
+			case "strategy":
+				return nil, fmt.Errorf("line %d: strategy() scripts are not supported; use indicator() with buy/sell series", statement.line)
+			case "plot":
+				statement.isPlot = true
+			}
+		}
+		study.statements = append(study.statements, statement)
+	}
+
+	for _, name := range pineBuyNames {
+		if parser.defined[name] && study.buyVar == "" {
+			study.buyVar = name
+		}
+	}
+	for _, name := range pineSellNames {
+		if parser.defined[name] && study.sellVar == "" {
+			study.sellVar = name
+		}
+	}
+	if study.buyVar == "" && study.sellVar == "" {
+		return nil, fmt.Errorf("study must define a buy or sell condition (%s / %s)",
+			strings.Join(pineBuyNames, ", "), strings.Join(pineSellNames, ", "))
+	}
+	return study, nil
+}
+
+// GetName returns the indicator name
+func (ps *PineStudy) GetName() string {
+	return fmt.Sprintf("Pine[%s]_%s", ps.title, ps.timeframe.String())
+}
+
+// Calculate runs the study over candles and returns its first plot (close when it has none)
+func (ps *PineStudy) Calculate(candles []Candle) []float64 {
+	ps.lastBuy, ps.lastSell = false, false
+	n := len(candles)
+	if n == 0 {
+		return []float64{}
+	}
+
+	env := newPineEnv(candles)
+	var plotted []float64
+	for _, statement := range ps.statements {
+		value := env.eval(statement.expr)
+		if statement.name != "" {
+			env.vars[statement.name] = value
+		}
+		if statement.isPlot && plotted == nil && len(statement.expr.args) > 0 {
+			plotted = env.eval(statement.expr.args[0])
+		}
+	}
+
+	if ps.buyVar != "" {
+		ps.lastBuy = pineTrue(env.vars[ps.buyVar][n-1])
+	}
+	if ps.sellVar != "" {
+		ps.lastSell = pineTrue(env.vars[ps.sellVar][n-1])
+	}
+	if plotted == nil {
+		plotted = env.series["close"]
+	}
+	return plotted
+}
+
+// GetSignal reports the buy/sell condition of the last bar from the latest Calculate
+func (ps *PineStudy) GetSignal(values []float64, currentPrice float64) IndicatorSignal {
+	signal := IndicatorSignal{
+		Name:      ps.GetName(),
+		Signal:    Hold,
+		Timestamp: time.Now(),
+		Timeframe: ps.timeframe,
+	}
+	if len(values) > 0 {
+		signal.Value = values[len(values)-1]
+	}
+	switch {
+	case ps.lastBuy && !ps.lastSell:
+		signal.Signal, signal.Strength = Buy, ps.strength
+	case ps.lastSell && !ps.lastBuy:
+		signal.Signal, signal.Strength = Sell, ps.strength
+	}
+	return signal
+}
+
+// tokenizePine splits source into tokens. Statements end at newlines, except inside
+// brackets and before indented continuation lines.
+func tokenizePine(source string) ([]pineToken, error) {
+	tokens := make([]pineToken, 0)
+	line, depth := 1, 0
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '\n':
+			continuation := i+1 < len(runes) && (runes[i+1] == ' ' || runes[i+1] == '\t')
+			if depth == 0 && !continuation {
+				tokens = append(tokens, pineToken{kind: "newline", line: line})
+			}
+			line++
+			i++
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, pineToken{kind: "ident", text: string(runes[start:i]), line: line})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E') {
+				i++
+			}
+			tokens = append(tokens, pineToken{kind: "num", text: string(runes[start:i]), line: line})
+		case r == '"' || r == '\'':
+			quote := r
+			start := i + 1
+			i++
+			for i < len(runes) && runes[i] != quote && runes[i] != '\n' {
+				i++
+			}
+			if i >= len(runes) || runes[i] != quote {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			tokens = append(tokens, pineToken{kind: "str", text: string(runes[start:i]), line: line})
+			i++
+		case r == '#':
+			// Colour literals such as #FF0000 only appear in drawing calls
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, pineToken{kind: "str", text: string(runes[start:i]), line: line})
+		default:
+			op := string(r)
+			if i+1 < len(runes) {
+				switch two := string(runes[i : i+2]); two {
+				case "==", "!=", "<=", ">=", ":=", "=>":
+					op = two
+				}
+			}
+			if !strings.Contains("+-*/%<>=!?:()[],", string(r)) {
+				return nil, fmt.Errorf("line %d: unexpected character %q", line, r)
+			}
+			switch op {
+			case "(", "[":
+				depth++
+			case ")", "]":
+				depth--
+			}
+			tokens = append(tokens, pineToken{kind: "op", text: op, line: line})
+			i += len(op)
+		}
+	}
+	return append(tokens, pineToken{kind: "eof", line: line}), nil
+}
+
+// pineParser is a recursive-descent parser for the supported Pine subset
+type pineParser struct {
+	tokens  []pineToken
+	pos     int
+	defined map[string]bool
+}
+
+func (p *pineParser) peek() pineToken {
+	return p.tokens[p.pos]
+}
+
+func (p *pineParser) next() pineToken {
+	token := p.tokens[p.pos]
+	if token.kind != "eof" {
+		p.pos++
+	}
+	return token
+}
+
+func (p *pineParser) skipNewlines() {
+	for p.peek().kind == "newline" {
+		p.pos++
+	}
+}
+
+// statement parses "[var] name = expr" or a bare call
+func (p *pineParser) statement() (pineStatement, error) {
+	first := p.peek()
+	if first.kind == "ident" {
+		switch first.text {
+		case "if", "for", "while", "switch":
+			return pineStatement{}, fmt.Errorf("line %d: %s blocks are not supported", first.line, first.text)
+		case "var", "varip":
+			return pineStatement{}, fmt.Errorf("line %d: %s declarations are not supported", first.line, first.text)
+		}
+	}
+
+	statement := pineStatement{line: first.line}
+	if first.kind == "ident" && p.tokens[p.pos+1].kind == "op" {
+		switch p.tokens[p.pos+1].text {
+		case "=":
+			if p.defined[first.text] {
+				return pineStatement{}, fmt.Errorf("line %d: %s is already defined", first.line, first.text)
+			}
+			statement.name = first.text
+			p.pos += 2
+		case ":=":
+			return pineStatement{}, fmt.Errorf("line %d: reassignment (:=) is not supported", first.line)
+		}
+	}
+
+	expr, err := p.ternary()
+	if err != nil {
+		return pineStatement{}, err
+	}
+	if statement.name == "" && expr.kind != "call" {
+		return pineStatement{}, fmt.Errorf("line %d: expected an assignment or a function call", first.line)
+	}
+	if end := p.next(); end.kind != "newline" && end.kind != "eof" {
+		return pineStatement{}, fmt.Errorf("line %d: unexpected %q", end.line, end.text)
+	}
+	if statement.name != "" {
+		p.defined[statement.name] = true
+	}
+	statement.expr = expr
+	return statement, nil
+}
+
+func (p *pineParser) expect(op string) error {
+	if token := p.next(); token.kind != "op" || token.text != op {
+		return fmt.Errorf("line %d: expected %s, got %q", token.line, op, token.text)
+	}
+	return nil
+}
+
+func (p *pineParser) ternary() (*pineNode, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.kind != "op" || token.text != "?" {
+		return cond, nil
+	}
+	p.next()
+	whenTrue, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	whenFalse, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return &pineNode{kind: "ternary", children: []*pineNode{cond, whenTrue, whenFalse}}, nil
+}
+
+// pinePrecedence lists binary operators from loosest to tightest binding
+var pinePrecedence = [][]string{
+	{"or"},
+	{"and"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *pineParser) binary(level int) (*pineNode, error) {
+	if level == len(pinePrecedence) {
+		return p.unary()
+	}
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		token := p.peek()
+		matched := false
+		for _, op := range pinePrecedence[level] {
+			if token.text == op && (token.kind == "op" || token.kind == "ident") {
+				matched = true
+			}
+		}
+		if !matched {
+			return left, nil
+		}
+		p.next()
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &pineNode{kind: "binary", op: token.text, children: []*pineNode{left, right}}
+	}
+}
+
+func (p *pineParser) unary() (*pineNode, error) {
+	token := p.peek()
+	if (token.kind == "op" && (token.text == "-" || token.text == "+")) || (token.kind == "ident" && token.text == "not") {
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &pineNode{kind: "unary", op: token.text, children: []*pineNode{operand}}, nil
+	}
+	return p.postfix()
+}
+
+// postfix parses a primary followed by any history references: x[1][2]
+func (p *pineParser) postfix() (*pineNode, error) {
+	node, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for token := p.peek(); token.kind == "op" && token.text == "["; token = p.peek() {
+		p.next()
+		offset := p.next()
+		if offset.kind != "num" {
+			return nil, fmt.Errorf("line %d: history offsets must be constant integers", offset.line)
+		}
+		bars, err := strconv.Atoi(offset.text)
+		if err != nil || bars < 0 {
+			return nil, fmt.Errorf("line %d: invalid history offset %s", offset.line, offset.text)
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		node = &pineNode{kind: "index", num: float64(bars), children: []*pineNode{node}}
+	}
+	return node, nil
+}
+
+func (p *pineParser) primary() (*pineNode, error) {
+	token := p.next()
+	switch token.kind {
+	case "num":
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid number %s", token.line, token.text)
+		}
+		return &pineNode{kind: "num", num: value}, nil
+	case "str":
+		return &pineNode{kind: "str", str: token.text}, nil
+	case "ident":
+		switch token.text {
+		case "true", "false":
+			return &pineNode{kind: "bool", num: map[bool]float64{true: 1, false: 0}[token.text == "true"]}, nil
+		case "na":
+			if next := p.peek(); !(next.kind == "op" && next.text == "(") {
+				return &pineNode{kind: "na"}, nil
+			}
+		}
+		if next := p.peek(); next.kind == "op" && next.text == "(" {
+			return p.call(token)
+		}
+		return p.variable(token)
+	case "op":
+		if token.text == "(" {
+			expr, err := p.ternary()
+			if err != nil {
+				return nil, err
+			}
+			return expr, p.expect(")")
+		}
+	}
+	if token.kind == "newline" || token.kind == "eof" {
+		return nil, fmt.Errorf("line %d: expression is incomplete", token.line)
+	}
+	return nil, fmt.Errorf("line %d: unexpected %q", token.line, token.text)
+}
+
+// variable resolves built-in series (close, hl2, ta.tr, ...) and earlier assignments
+func (p *pineParser) variable(token pineToken) (*pineNode, error) {
+	name := token.text
+	switch name {
+	case "ta.tr", "tr":
+		return &pineNode{kind: "var", name: "tr"}, nil
+	}
+	if p.defined[name] || pineSeriesNames[name] {
+		return &pineNode{kind: "var", name: name}, nil
+	}
+	if strings.HasPrefix(name, "color.") || strings.HasPrefix(name, "shape.") || strings.HasPrefix(name, "location.") ||
+		strings.HasPrefix(name, "size.") || strings.HasPrefix(name, "plot.style") {
+		return &pineNode{kind: "str", str: name}, nil
+	}
+	return nil, fmt.Errorf("line %d: unknown variable %s", token.line, name)
+}
+
+// call parses a function call with positional and name=value arguments
+func (p *pineParser) call(token pineToken) (*pineNode, error) {
+	p.next() // (
+	node := &pineNode{kind: "call", name: strings.TrimPrefix(strings.TrimPrefix(token.text, "ta."), "math."), named: make(map[string]*pineNode)}
+	for {
+		if next := p.peek(); next.kind == "op" && next.text == ")" {
+			p.next()
+			break
+		}
+		if p.peek().kind == "ident" && p.tokens[p.pos+1].kind == "op" && p.tokens[p.pos+1].text == "=" {
+			argName := p.next().text
+			p.next()
+			value, err := p.ternary()
+			if err != nil {
+				return nil, err
+			}
+			node.named[argName] = value
+		} else {
+			value, err := p.ternary()
+			if err != nil {
+				return nil, err
+			}
+			node.args = append(node.args, value)
+		}
+		if next := p.peek(); next.kind == "op" && next.text == "," {
+			p.next()
+		} else if err := p.expect(")"); err != nil {
+			return nil, err
+		} else {
+			break
+		}
+	}
+
+	switch {
+	case node.name == "input" || strings.HasPrefix(node.name, "input."):
+		if len(node.args) == 0 && node.named["defval"] == nil {
+			return nil, fmt.Errorf("line %d: %s needs a default value", token.line, token.text)
+		}
+		return node, nil
+	case node.name == "indicator" || node.name == "study" || node.name == "strategy" || node.name == "plot" || pineIgnoredCalls[node.name]:
+		return node, nil
+	}
+
+	arity, ok := pineFunctions[node.name]
+	if !ok {
+		return nil, fmt.Errorf("line %d: unsupported function %s", token.line, token.text)
+	}
+	if len(node.args) < arity[0] || len(node.args) > arity[1] {
+		return nil, fmt.Errorf("line %d: %s takes %d argument(s), got %d", token.line, token.text, arity[0], len(node.args))
+	}
+	return node, nil
+}
+
+// pineSeriesNames are the built-in price series
+var pineSeriesNames = map[string]bool{
+	"open": true, "high": true, "low": true, "close": true, "volume": true,
+	"hl2": true, "hlc3": true, "ohlc4": true, "tr": true,
+}
+
+// pineEnv evaluates expressions bar-by-bar over whole series at once
+type pineEnv struct {
+	n      int
+	series map[string][]float64
+	vars   map[string][]float64
+}
+
+func newPineEnv(candles []Candle) *pineEnv {
+	n := len(candles)
+	env := &pineEnv{n: n, series: make(map[string][]float64), vars: make(map[string][]float64)}
+	for _, name := range []string{"open", "high", "low", "close", "volume", "hl2", "hlc3", "ohlc4", "tr"} {
+		env.series[name] = make([]float64, n)
+	}
+	for i, c := range candles {
+		env.series["open"][i] = c.Open
+		env.series["high"][i] = c.High
+		env.series["low"][i] = c.Low
+		env.series["close"][i] = c.Close
+		env.series["volume"][i] = c.Volume
+		env.series["hl2"][i] = (c.High + c.Low) / 2
+		env.series["hlc3"][i] = (c.High + c.Low + c.Close) / 3
+		env.series["ohlc4"][i] = (c.Open + c.High + c.Low + c.Close) / 4
+		env.series["tr"][i] = c.High - c.Low
+		if i > 0 {
+			prevClose := candles[i-1].Close
+			env.series["tr"][i] = math.Max(c.High-c.Low, math.Max(math.Abs(c.High-prevClose), math.Abs(c.Low-prevClose)))
+		}
+	}
+	return env
+}
+
+// constant returns a series holding value on every bar
+func (env *pineEnv) constant(value float64) []float64 {
+	series := make([]float64, env.n)
+	for i := range series {
+		series[i] = value
+	}
+	return series
+}
+
+// length reads a period argument from the last bar of its series
+func (env *pineEnv) length(node *pineNode) int {
+	series := env.eval(node)
+	value := series[env.n-1]
+	if math.IsNaN(value) || value < 1 {
+		return 1
+	}
+	return int(value)
+}
+
+func (env *pineEnv) eval(node *pineNode) []float64 {
+	switch node.kind {
+	case "num", "bool":
+		return env.constant(node.num)
+	case "na", "str":
+		return env.constant(math.NaN())
+	case "var":
+		if series, ok := env.vars[node.name]; ok {
+			return series
+		}
+		return env.series[node.name]
+	case "index":
+		source := env.eval(node.children[0])
+		bars := int(node.num)
+		out := env.constant(math.NaN())
+		for i := bars; i < env.n; i++ {
+			out[i] = source[i-bars]
+		}
+		return out
+	case "unary":
+		source := env.eval(node.children[0])
+		return env.mapSeries(source, func(x float64) float64 {
+			switch node.op {
+			case "-":
+				return -x
+			case "not":
+				return pineBool(!pineTrue(x))
+			}
+			return x
+		})
+	case "ternary":
+		cond := env.eval(node.children[0])
+		whenTrue := env.eval(node.children[1])
+		whenFalse := env.eval(node.children[2])
+		out := make([]float64, env.n)
+		for i := range out {
+			if pineTrue(cond[i]) {
+				out[i] = whenTrue[i]
+			} else {
+				out[i] = whenFalse[i]
+			}
+		}
+		return out
+	case "binary":
+		left := env.eval(node.children[0])
+		right := env.eval(node.children[1])
+		out := make([]float64, env.n)
+		for i := range out {
+			out[i] = pineBinary(node.op, left[i], right[i])
+		}
+		return out
+	case "call":
+		return env.call(node)
+	}
+	return env.constant(math.NaN())
+}
+
+func (env *pineEnv) mapSeries(source []float64, fn func(float64) float64) []float64 {
+	out := make([]float64, env.n)
+	for i, x := range source {
+		out[i] = fn(x)
+	}
+	return out
+}
+
+// pineBinary applies a binary operator; comparisons involving na are false
+func pineBinary(op string, a, b float64) float64 {
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "/":
+		if b == 0 {
+			return math.NaN()
+		}
+		return a / b
+	case "%":
+		return math.Mod(a, b)
+	case "and":
+		return pineBool(pineTrue(a) && pineTrue(b))
+	case "or":
+		return pineBool(pineTrue(a) || pineTrue(b))
+	case "==":
+		return pineBool(a == b)
+	case "!=":
+		return pineBool(!math.IsNaN(a) && !math.IsNaN(b) && a != b)
+	case "<":
+		return pineBool(a < b)
+	case "<=":
+		return pineBool(a <= b)
+	case ">":
+		return pineBool(a > b)
+	case ">=":
+		return pineBool(a >= b)
+	}
+	return math.NaN()
+}
+
+func (env *pineEnv) call(node *pineNode) []float64 {
+	arg := func(i int) []float64 { return env.eval(node.args[i]) }
+
+	switch {
+	case node.name == "input" || strings.HasPrefix(node.name, "input."):
+		if defval, ok := node.named["defval"]; ok {
+			return env.eval(defval)
+		}
+		return arg(0)
+	case node.name == "plot" || node.name == "indicator" || node.name == "study" || pineIgnoredCalls[node.name]:
+		return env.constant(math.NaN())
+	}
+
+	switch node.name {
+	case "tr":
+		return env.series["tr"]
+	case "sma":
+		return pineSMA(arg(0), env.length(node.args[1]))
+	case "ema":
+		length := env.length(node.args[1])
+		return pineSmoothed(arg(0), length, 2/float64(length+1))
+	case "rma":
+		length := env.length(node.args[1])
+		return pineSmoothed(arg(0), length, 1/float64(length))
+	case "wma":
+		return pineWMA(arg(0), env.length(node.args[1]))
+	case "atr":
+		length := env.length(node.args[0])
+		return pineSmoothed(env.series["tr"], length, 1/float64(length))
+	case "rsi":
+		return pineRSI(arg(0), env.length(node.args[1]))
+	case "stdev":
+		return pineStdev(arg(0), env.length(node.args[1]))
+	case "highest", "lowest":
+		return pineExtreme(arg(0), env.length(node.args[1]), node.name == "highest")
+	case "crossover", "crossunder", "cross":
+		a, b := arg(0), arg(1)
+		out := env.constant(0)
+		for i := 1; i < env.n; i++ {
+			up := a[i] > b[i] && a[i-1] <= b[i-1]
+			down := a[i] < b[i] && a[i-1] >= b[i-1]
+			out[i] = pineBool((node.name != "crossunder" && up) || (node.name != "crossover" && down))
+		}
+		return out
+	case "change":
+		bars := 1
+		if len(node.args) > 1 {
+			bars = env.length(node.args[1])
+		}
+		source := arg(0)
+		out := env.constant(math.NaN())
+		for i := bars; i < env.n; i++ {
+			out[i] = source[i] - source[i-bars]
+		}
+		return out
+	case "nz":
+		replacement := env.constant(0)
+		if len(node.args) > 1 {
+			replacement = arg(1)
+		}
+		source := arg(0)
+		out := make([]float64, env.n)
+		for i, x := range source {
+			out[i] = x
+			if math.IsNaN(x) {
+				out[i] = replacement[i]
+			}
+		}
+		return out
+	case "na":
+		return env.mapSeries(arg(0), func(x float64) float64 { return pineBool(math.IsNaN(x)) })
+	case "iff":
+		return env.eval(&pineNode{kind: "ternary", children: node.args})
+	case "abs":
+		return env.mapSeries(arg(0), math.Abs)
+	case "sqrt":
+		return env.mapSeries(arg(0), math.Sqrt)
+	case "log":
+		return env.mapSeries(arg(0), math.Log)
+	case "round":
+		return env.mapSeries(arg(0), math.Round)
+	case "max", "min":
+		a, b := arg(0), arg(1)
+		out := make([]float64, env.n)
+		for i := range out {
+			if node.name == "max" {
+				out[i] = math.Max(a[i], b[i])
+			} else {
+				out[i] = math.Min(a[i], b[i])
+			}
+		}
+		return out
+	}
+	return env.constant(math.NaN())
+}
+
+// pineSMA is the simple moving average; na until length valid bars are available
+func pineSMA(source []float64, length int) []float64 {
+	out := make([]float64, len(source))
+	for i := range source {
+		out[i] = math.NaN()
+		if i < length-1 {
+			continue
+		}
+		sum := 0.0
+		for j := i - length + 1; j <= i; j++ {
+			sum += source[j]
+		}
+		out[i] = sum / float64(length)
+	}
+	return out
+}
+
+// pineSmoothed is an exponential average seeded with the SMA of the first length
+// bars, as Pine does for ta.ema (alpha 2/(n+1)) and ta.rma (alpha 1/n)
+func pineSmoothed(source []float64, length int, alpha float64) []float64 {
+	out := make([]float64, len(source))
+	seed := pineSMA(source, length)
+	prev := math.NaN()
+	for i, x := range source {
+		switch {
+		case math.IsNaN(prev):
+			prev = seed[i]
+		case !math.IsNaN(x):
+			prev = alpha*x + (1-alpha)*prev
+		}
+		out[i] = prev
+	}
+	return out
+}
+
+// pineWMA is the linearly weighted moving average
+func pineWMA(source []float64, length int) []float64 {
+	out := make([]float64, len(source))
+	norm := float64(length*(length+1)) / 2
+	for i := range source {
+		out[i] = math.NaN()
+		if i < length-1 {
+			continue
+		}
+		sum := 0.0
+		for j := 0; j < length; j++ {
+			sum += source[i-j] * float64(length-j)
+		}
+		out[i] = sum / norm
+	}
+	return out
+}
+
+// pineRSI is Wilder's RSI using rma-smoothed gains and losses
+func pineRSI(source []float64, length int) []float64 {
+	gains := make([]float64, len(source))
+	losses := make([]float64, len(source))
+	for i := range source {
+		gains[i], losses[i] = math.NaN(), math.NaN()
+		if i > 0 {
+			change := source[i] - source[i-1]
+			gains[i], losses[i] = math.Max(change, 0), math.Max(-change, 0)
+		}
+	}
+	alpha := 1 / float64(length)
+	avgGain := pineSmoothed(gains[1:], length, alpha)
+	avgLoss := pineSmoothed(losses[1:], length, alpha)
+
+	out := make([]float64, len(source))
+	out[0] = math.NaN()
+	for i := range avgGain {
+		switch {
+		case avgLoss[i] == 0:
+			out[i+1] = 100
+		case avgGain[i] == 0:
+			out[i+1] = 0
+		default:
+			out[i+1] = 100 - 100/(1+avgGain[i]/avgLoss[i])
+		}
+		if math.IsNaN(avgGain[i]) || math.IsNaN(avgLoss[i]) {
+			out[i+1] = math.NaN()
+		}
+	}
+	return out
+}
+
+// pineStdev is the population standard deviation over length bars
+func pineStdev(source []float64, length int) []float64 {
+	mean := pineSMA(source, length)
+	out := make([]float64, len(source))
+	for i := range source {
+		out[i] = math.NaN()
+		if i < length-1 {
+			continue
+		}
+		sum := 0.0
+		for j := i - length + 1; j <= i; j++ {
+			sum += (source[j] - mean[i]) * (source[j] - mean[i])
+		}
+		out[i] = math.Sqrt(sum / float64(length))
+	}
+	return out
+}
+
+// pineExtreme is the highest or lowest value over length bars
+func pineExtreme(source []float64, length int, highest bool) []float64 {
+	out := make([]float64, len(source))
+	for i := range source {
+		out[i] = math.NaN()
+		if i < length-1 {
+			continue
+		}
+		extreme := source[i]
+		for j := i - length + 1; j < i; j++ {
+			if (highest && source[j] > extreme) || (!highest && source[j] < extreme) {
+				extreme = source[j]
+			}
+		}
+		out[i] = extreme
+	}
+	return out
+}
+
+// pineTrue treats na as false, as Pine does in conditions
+func pineTrue(x float64) bool {
+	return !math.IsNaN(x) && x != 0
+}
+
+func pineBool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -114,3 +114,8 @@ func (mfi *ReverseMFI) GetSignal(values []float64, currentPrice float64) Indicat
 func (mfi *ReverseMFI) GetName() string {
 	return fmt.Sprintf("ReverseMFI_%s", mfi.timeframe.String())
 }
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (mfi *ReverseMFI) RequiredCandles() int {
+	return mfi.config.Period + 1
+}
@@ -126,3 +126,8 @@ func (rsi *RSI) GetSignal(values []float64, currentPrice float64) IndicatorSigna
 func (rsi *RSI) GetName() string {
 	return fmt.Sprintf("RSI_%s", rsi.timeframe.String())
 }
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (rsi *RSI) RequiredCandles() int {
+	return rsi.config.Period + 1
+}
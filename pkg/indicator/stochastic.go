@@ -354,6 +354,11 @@ func (s *Stochastic) GetName() string {
 	return "Stochastic"
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (s *Stochastic) RequiredCandles() int {
+	return s.config.KPeriod
+}
+
 // GetLastSignal returns the last signal and strength
 func (s *Stochastic) GetLastSignal() (SignalType, float64) {
 	return s.lastSignal, s.lastStrength
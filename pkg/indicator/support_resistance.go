@@ -175,3 +175,8 @@ func (sr *SupportResistance) GetSignal(values []float64, currentPrice float64) I
 func (sr *SupportResistance) GetName() string {
 	return fmt.Sprintf("S&R_%s", sr.timeframe.String())
 }
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (sr *SupportResistance) RequiredCandles() int {
+	return sr.config.Period
+}
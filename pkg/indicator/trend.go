@@ -156,6 +156,12 @@ func (t *Trend) GetName() string {
 	return fmt.Sprintf("Trend_%s", t.timeframe.String())
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (t *Trend) RequiredCandles() int {
+	_, longPeriod := t.getAdaptiveMAPeriods()
+	return longPeriod
+}
+
 // Helper function to calculate SMA
 func calculateSMA(candles []Candle, period int) []float64 {
 	if len(candles) < period {
@@ -98,6 +98,11 @@ type TechnicalIndicator interface {
 	GetName() string
 }
 
+// CandleRequirement is implemented by indicators that know how much history they need
+type CandleRequirement interface {
+	RequiredCandles() int
+}
+
 // Configuration types for each indicator
 
 // RSIConfig holds RSI (Relative Strength Index) configuration
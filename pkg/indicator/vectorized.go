@@ -0,0 +1,302 @@
+package indicator
+
+import (
+	"math"
+)
+
+// Vectorized indicator path for large backtests. Each calculation extracts the
+// candle columns it needs into preallocated float64 slices once and then runs
+// single-pass, O(n) loops (monotonic deques for rolling extremes, a sliding
+// variance for Bollinger) instead of rescanning every window per candle.
+
+// VectorizedIndicator is implemented by indicators that can switch their
+// Calculate path to the vectorized implementation
+type VectorizedIndicator interface {
+	SetVectorized(enabled bool)
+}
+
+// candleColumns copies the high and low columns into contiguous slices
+func candleColumns(candles []Candle) (highs, lows []float64) {
+	buf := make([]float64, 2*len(candles))
+	highs, lows = buf[:len(candles):len(candles)], buf[len(candles):]
+	for i := range candles {
+		highs[i] = candles[i].High
+		lows[i] = candles[i].Low
+	}
+	return highs, lows
+}
+
+// rollingMax writes the maximum of the window values ending at each index
+// i >= window-1 into out[i]. queue is scratch space of at least len(values).
+func rollingMax(values []float64, window int, out []float64, queue []int) {
+	head, tail := 0, 0
+	for i, v := range values {
+		for tail > head && values[queue[tail-1]] <= v {
+			tail--
+		}
+		queue[tail] = i
+		tail++
+		if queue[head] <= i-window {
+			head++
+		}
+		out[i] = values[queue[head]]
+	}
+}
+
+// rollingMin is rollingMax for the minimum
+func rollingMin(values []float64, window int, out []float64, queue []int) {
+	head, tail := 0, 0
+	for i, v := range values {
+		for tail > head && values[queue[tail-1]] >= v {
+			tail--
+		}
+		queue[tail] = i
+		tail++
+		if queue[head] <= i-window {
+			head++
+		}
+		out[i] = values[queue[head]]
+	}
+}
+
+// FindPivots returns the indices of strict pivot highs and lows: candles whose
+// high (low) is above (below) every other candle within lookback bars on both
+// sides. Candles without lookback bars on either side are never pivots. This
+// matches the pivots ElliottWave.Update confirms one candle at a time.
+func FindPivots(highs, lows []float64, lookback int) (pivotHighs, pivotLows []int) {
+	n := len(highs)
+	if lookback < 1 || n < 2*lookback+1 || len(lows) != n {
+		return []int{}, []int{}
+	}
+
+	buf := make([]float64, 2*n)
+	windowMax, windowMin := buf[:n:n], buf[n:]
+	queue := make([]int, n)
+	rollingMax(highs, lookback, windowMax, queue)
+	rollingMin(lows, lookback, windowMin, queue)
+
+	pivotHighs = make([]int, 0, n/(lookback+1)+1)
+	pivotLows = make([]int, 0, n/(lookback+1)+1)
+	for c := lookback; c < n-lookback; c++ {
+		// windowMax[c-1] covers the bars before c, windowMax[c+lookback] the bars after
+		if highs[c] > windowMax[c-1] && highs[c] > windowMax[c+lookback] {
+			pivotHighs = append(pivotHighs, c)
+		}
+		if lows[c] < windowMin[c-1] && lows[c] < windowMin[c+lookback] {
+			pivotLows = append(pivotLows, c)
+		}
+	}
+	return pivotHighs, pivotLows
+}
+
+// SetVectorized switches Calculate and CalculateAll to the vectorized path
+func (ich *Ichimoku) SetVectorized(enabled bool) {
+	ich.vectorized = enabled
+}
+
+// CalculateAllVectorized returns the same values as CalculateAll using O(n)
+// rolling highs and lows
+func (ich *Ichimoku) CalculateAllVectorized(candles []Candle) IchimokuValues {
+	return ich.calculateAllVectorized(candles, ich.get5MinuteOptimizedConfig())
+}
+
+// calculateAllVectorized is calculateAllWithConfig on the vectorized path
+func (ich *Ichimoku) calculateAllVectorized(candles []Candle, config IchimokuConfig) IchimokuValues {
+	n := len(candles)
+	if n < config.SenkouPeriod {
+		return IchimokuValues{}
+	}
+
+	highs, lows := candleColumns(candles)
+	buf := make([]float64, 2*n)
+	windowMax, windowMin := buf[:n:n], buf[n:]
+	queue := make([]int, n)
+
+	midpoints := func(period int) []float64 {
+		if period < 1 || n < period {
+			return []float64{}
+		}
+		rollingMax(highs, period, windowMax, queue)
+		rollingMin(lows, period, windowMin, queue)
+		values := make([]float64, n-period+1)
+		highest, lowest := windowMax[period-1:], windowMin[period-1:]
+		for i := range values {
+			values[i] = (highest[i] + lowest[i]) / 2
+		}
+		return values
+	}
+
+	tenkanSen := midpoints(config.TenkanPeriod)
+	kijunSen := midpoints(config.KijunPeriod)
+	senkouSpanA := ich.calculateSenkouSpanA(tenkanSen, kijunSen)
+	senkouSpanB := midpoints(config.SenkouPeriod)
+	chikouSpan := ich.calculateChikouSpanWithDisplacement(candles, config.Displacement)
+	cloudTop, cloudBottom := ich.calculateCloudBoundaries(senkouSpanA, senkouSpanB)
+
+	return IchimokuValues{
+		TenkanSen:   tenkanSen,
+		KijunSen:    kijunSen,
+		SenkouSpanA: senkouSpanA,
+		SenkouSpanB: senkouSpanB,
+		ChikouSpan:  chikouSpan,
+		CloudTop:    cloudTop,
+		CloudBottom: cloudBottom,
+	}
+}
+
+// SetVectorized switches Calculate and CalculateAll to the vectorized path
+func (bb *BollingerBands) SetVectorized(enabled bool) {
+	bb.vectorized = enabled
+}
+
+// CalculateAllVectorized returns CalculateAll's values using a sliding mean and
+// variance, so each candle costs O(1) instead of O(period). Results match
+// CalculateAll to floating point rounding.
+func (bb *BollingerBands) CalculateAllVectorized(candles []Candle) BollingerBandsValues {
+	length, period := len(candles), bb.config.Period
+	if length < period || period < 1 {
+		return BollingerBandsValues{}
+	}
+
+	upperBand := make([]float64, length)
+	lowerBand := make([]float64, length)
+	middleBand := make([]float64, length)
+	position := make([]float64, length)
+	bandwidth := make([]float64, length)
+	squeeze := make([]bool, length)
+
+	// Slide the window's mean and squared deviations one candle at a time and
+	// recompute them exactly once per period so rounding cannot accumulate
+	invPeriod := 1 / float64(period)
+	mean, m2 := 0.0, 0.0
+	sinceSync := period
+	for i := period - 1; i < length; i++ {
+		if sinceSync == period {
+			sinceSync = 0
+			window := candles[i+1-period : i+1]
+			sum := 0.0
+			for j := range window {
+				sum += window[j].Close
+			}
+			mean, m2 = sum*invPeriod, 0
+			for j := range window {
+				m2 += (window[j].Close - mean) * (window[j].Close - mean)
+			}
+		} else {
+			in, out := candles[i].Close, candles[i-period].Close
+			previousMean := mean
+			mean += (in - out) * invPeriod
+			m2 += (in - out) * (in - mean + out - previousMean)
+			if m2 < 0 {
+				m2 = 0
+			}
+		}
+		sinceSync++
+
+		width := bb.config.StandardDev * math.Sqrt(m2*invPeriod)
+		middleBand[i] = mean
+		upperBand[i] = mean + width
+		lowerBand[i] = mean - width
+
+		bandRange := upperBand[i] - lowerBand[i]
+		position[i] = 0.5
+		if bandRange > 0 {
+			position[i] = (candles[i].Close - lowerBand[i]) / bandRange
+		}
+		bandwidth[i] = bandRange / mean
+
+		if i > 0 {
+			squeeze[i] = (bandwidth[i]+bandwidth[i-1])/2 < 0.1
+		}
+	}
+
+	return BollingerBandsValues{
+		UpperBand:  upperBand,
+		LowerBand:  lowerBand,
+		MiddleBand: middleBand,
+		Position:   position,
+		Bandwidth:  bandwidth,
+		Squeeze:    squeeze,
+	}
+}
+
+// SetVectorized switches Calculate to CalculateVectorized
+func (ew *ElliottWave) SetVectorized(enabled bool) {
+	ew.vectorized = enabled
+}
+
+// CalculateVectorized recomputes the indicator from scratch over candles. Pivots
+// are found in one pass with FindPivots and waves are only re-analyzed when the
+// visible pivot set changes. On input no longer than MaxLookback the values and
+// final state equal Calculate on a fresh indicator; on longer input, pivots that
+// leave the lookback window are dropped rather than pinned to its first candle.
+func (ew *ElliottWave) CalculateVectorized(candles []Candle) []float64 {
+	vectorized := ew.vectorized
+	*ew = *NewElliottWave(ew.config, ew.timeframe)
+	ew.vectorized = vectorized
+
+	lookback := ew.config.MinWaveLength
+	n := len(candles)
+	if lookback < 1 || n < lookback*2 {
+		return []float64{}
+	}
+
+	highs, lows := candleColumns(candles)
+	pivotHighs, pivotLows := FindPivots(highs, lows, lookback)
+	values := make([]float64, 0, n-2*lookback+1)
+
+	ew.candles = candles
+	var highStart, highEnd, lowStart, lowEnd int
+	for i := 2*lookback - 1; i < n; i++ {
+		// Pivot c is confirmed once lookback candles follow it
+		newHighEnd, newLowEnd := highEnd, lowEnd
+		for newHighEnd < len(pivotHighs) && pivotHighs[newHighEnd] <= i-lookback {
+			newHighEnd++
+		}
+		for newLowEnd < len(pivotLows) && pivotLows[newLowEnd] <= i-lookback {
+			newLowEnd++
+		}
+
+		// Keep the 20 most recent pivots inside the lookback window
+		first := 0
+		if ew.config.MaxLookback > 0 {
+			first = i + 1 - ew.config.MaxLookback
+		}
+		newHighStart, newLowStart := highStart, lowStart
+		for newHighStart < newHighEnd && (pivotHighs[newHighStart] < first || newHighEnd-newHighStart > 20) {
+			newHighStart++
+		}
+		for newLowStart < newLowEnd && (pivotLows[newLowStart] < first || newLowEnd-newLowStart > 20) {
+			newLowStart++
+		}
+
+		if newHighStart != highStart || newHighEnd != highEnd || newLowStart != lowStart || newLowEnd != lowEnd {
+			highStart, highEnd, lowStart, lowEnd = newHighStart, newHighEnd, newLowStart, newLowEnd
+			ew.pivotHighs = pivotHighs[highStart:highEnd]
+			ew.pivotLows = pivotLows[lowStart:lowEnd]
+			ew.analyzeWaves()
+		}
+		values = append(values, ew.currentWave.Confidence)
+	}
+
+	// Leave the rolling buffer as Update expects it so streaming can continue
+	offset := 0
+	if ew.config.MaxLookback > 0 && n > ew.config.MaxLookback {
+		offset = n - ew.config.MaxLookback
+	}
+	ew.candles = append(make([]Candle, 0, n-offset), candles[offset:]...)
+	ew.pivotHighs = shiftedIndices(pivotHighs[highStart:highEnd], offset)
+	ew.pivotLows = shiftedIndices(pivotLows[lowStart:lowEnd], offset)
+	ew.initialized = true
+
+	return values
+}
+
+// shiftedIndices copies indices moved back by offset
+func shiftedIndices(indices []int, offset int) []int {
+	shifted := make([]int, len(indices))
+	for i, index := range indices {
+		shifted[i] = index - offset
+	}
+	return shifted
+}
@@ -82,3 +82,8 @@ func (v *Volume) GetSignal(values []float64, currentPrice float64) IndicatorSign
 func (v *Volume) GetName() string {
 	return fmt.Sprintf("Volume_%s", v.timeframe.String())
 }
+
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (v *Volume) RequiredCandles() int {
+	return v.config.Period
+}
@@ -321,6 +321,11 @@ func (wr *WilliamsR) GetName() string {
 	return "Williams %R"
 }
 
+// RequiredCandles returns the fewest candles Calculate needs to return values
+func (wr *WilliamsR) RequiredCandles() int {
+	return wr.config.Period
+}
+
 // GetLastSignal returns the last signal and strength
 func (wr *WilliamsR) GetLastSignal() (SignalType, float64) {
 	return wr.lastSignal, wr.lastStrength
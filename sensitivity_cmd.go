@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"trading-bot/pkg/bot"
+)
+
+// runSensitivity perturbs each indicator parameter and prints its backtest impact
+func runSensitivity(args []string) {
+	flags := flag.NewFlagSet("sensitivity", flag.ExitOnError)
+	days := flags.Int("days", 7, "Backtest window in days")
+	perturb := flags.Float64("perturb", 10, "Parameter perturbation in percent (±N%)")
+	fragile := flags.Float64("fragile", 2, "Return impact in percentage points that flags a parameter as fragile")
+	verbose := flags.Bool("verbose", false, "Show bot logs while backtesting")
+	flags.Parse(args)
+
+	configManager := bot.NewConfigManager("config.json")
+	if err := configManager.Load(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := configManager.GetConfig()
+
+	fmt.Printf("🔬 Parameter sensitivity for %s: ±%.0f%% over %d days\n", config.Symbol, *perturb, *days)
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	candles, start, end, err := bot.NewTradingBot(config).LoadBacktestCandles(*days)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load history: %v\n", err)
+		os.Exit(1)
+	}
+
+	report, err := bot.NewSensitivityAnalyzer(config, config.Account.InitialBalance, *perturb/100, *fragile).Run(candles, start, end)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Sensitivity analysis failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("📊 Baseline: return %.2f%%, signal accuracy %.1f%%\n\n", report.BaselineReturn, report.BaselineAccuracy)
+	fmt.Printf("%-36s %10s %10s %10s %10s %10s  %s\n", "PARAMETER", "VALUE", "RET -", "RET +", "ΔRET", "ΔACC", "")
+	fragileCount := 0
+	for _, p := range report.Parameters {
+		if p.PerturbationErr != "" {
+			fmt.Printf("%-36s %10.4g  ❌ %s\n", p.Parameter, p.BaseValue, p.PerturbationErr)
+			continue
+		}
+		flag := ""
+		if p.Fragile {
+			flag = "⚠️  FRAGILE: " + p.FragileReason
+			fragileCount++
+		}
+		fmt.Printf("%-36s %10.4g %9.2f%% %9.2f%% %9.2fpp %9.1fpp  %s\n",
+			p.Parameter, p.BaseValue, p.LowReturn, p.HighReturn, p.ReturnImpact, p.AccuracyImpact, flag)
+	}
+	fmt.Printf("\n✅ %d parameters tested, %d fragile\n", len(report.Parameters), fragileCount)
+}
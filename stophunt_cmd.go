@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"trading-bot/pkg/bot"
+)
+
+// runStopHunt backtests with adversarial wicks around stop levels and prints the PnL degradation per wick size
+func runStopHunt(args []string) {
+	flags := flag.NewFlagSet("stophunt", flag.ExitOnError)
+	days := flags.Int("days", 7, "Backtest window in days")
+	wickList := flags.String("wicks", "0.1,0.25,0.5,1", "Comma-separated extra wick sizes in percent of price")
+	verbose := flags.Bool("verbose", false, "Show bot logs while backtesting")
+	flags.Parse(args)
+
+	wicks := make([]float64, 0)
+	for _, field := range strings.Split(*wickList, ",") {
+		wick, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || wick < 0 {
+			fmt.Fprintf(os.Stderr, "❌ Invalid wick size %q\n", field)
+			os.Exit(1)
+		}
+		wicks = append(wicks, wick)
+	}
+
+	configManager := bot.NewConfigManager("config.json")
+	if err := configManager.Load(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := configManager.GetConfig()
+
+	fmt.Printf("🎣 Stop hunt stress test for %s over %d days\n", config.Symbol, *days)
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	candles, start, end, err := bot.NewTradingBot(config).LoadBacktestCandles(*days)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load history: %v\n", err)
+		os.Exit(1)
+	}
+
+	report, err := bot.RunStopHuntTest(config, config.Account.InitialBalance, candles, start, end, wicks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Stop hunt test failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("📊 Close-only stops: return %.2f%%\n\n", report.CloseOnlyReturn)
+	fmt.Printf("%-8s %10s %10s %10s %8s %8s %8s\n", "WICK", "RETURN", "ΔRET", "MAX DD", "TRADES", "HUNTED", "WIN")
+	for _, r := range report.Results {
+		fmt.Printf("%7.2f%% %9.2f%% %8.2fpp %9.2f%% %8d %8d %7.1f%%\n",
+			r.WickPercent, r.ReturnPercent, -r.Degradation, r.MaxDrawdownPercent, r.Trades, r.StopHunts, r.WinRate)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"trading-bot/pkg/bot"
+)
+
+// runWalkForward picks parameters on rolling training windows, scores them on
+// the windows that follow and saves the most robust set as a config profile
+func runWalkForward(args []string) {
+	var params sweepFlags
+	flags := flag.NewFlagSet("walkforward", flag.ExitOnError)
+	flags.Var(&params, "param", "Parameter to tune, e.g. rsi.period=10:20:2, atr.multiplier=2,3,4 or strategy.timeframe_weights.5m=0.2:0.6:0.1 (repeatable)")
+	days := flags.Int("days", 30, "History to split into folds, in days")
+	trainDays := flags.Int("train-days", 14, "Training window in days")
+	testDays := flags.Int("test-days", 7, "Test window in days (folds roll forward by this much)")
+	search := flags.String("search", bot.SearchGrid, "Search strategy: grid or bayesian")
+	iterations := flags.Int("iterations", 30, "Backtests per training window for bayesian search")
+	objective := flags.String("objective", bot.ObjectiveReturn, "Ranking: return, calmar or accuracy")
+	workers := flags.Int("workers", 0, "Concurrent backtests for grid search (0 = one per CPU)")
+	profile := flags.String("profile", "profiles/optimized.json", "Config profile the best parameters are written to (empty to skip)")
+	verbose := flags.Bool("verbose", false, "Show bot logs while backtesting")
+	flags.Parse(args)
+
+	if len(params) == 0 {
+		fmt.Fprintln(os.Stderr, "❌ Nothing to tune: pass at least one -param section.param=values")
+		os.Exit(2)
+	}
+
+	configManager := bot.NewConfigManager("config.json")
+	if err := configManager.Load(); err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	config := configManager.GetConfig()
+
+	optimizer, err := bot.NewWalkForwardOptimizer(config, config.Account.InitialBalance, bot.WalkForwardOptions{
+		Params:     params,
+		Search:     *search,
+		Iterations: *iterations,
+		Objective:  *objective,
+		Train:      time.Duration(*trainDays) * 24 * time.Hour,
+		Test:       time.Duration(*testDays) * 24 * time.Hour,
+		Workers:    *workers,
+		Seed:       config.Determinism.Seed,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(2)
+	}
+
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	// Stored history when the candle store is on, otherwise the data provider
+	var candles map[bot.Timeframe][]bot.Candle
+	var start, end time.Time
+	if config.CandleStore.Enabled {
+		store, err := bot.NewCandleStore(config.CandleStore)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to open candle store: %v\n", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+		end = time.Now().Truncate(bot.FiveMinute.Duration())
+		start = end.Add(-time.Duration(*days) * 24 * time.Hour)
+		candles, err = bot.LoadStoredCandles(store, config.Symbol, start, end)
+	} else {
+		candles, start, end, err = bot.NewTradingBot(config).LoadBacktestCandles(*days)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to load history: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Ctrl-C stops after the backtests in flight and prints the finished folds
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("🚶 Walk-forward %s search for %s: %d folds of %dd train / %dd test over %d days\n",
+		*search, config.Symbol, len(optimizer.Splits(start, end)), *trainDays, *testDays, *days)
+	report, err := optimizer.Run(ctx, candles, start, end)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Walk-forward optimization failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	for i, fold := range report.Folds {
+		fmt.Printf("%3d. train %s → %s  %s  in-sample %.2f  out-of-sample %.2f  (%d backtests)\n",
+			i+1, fold.TrainStart.Format("01-02"), fold.TrainEnd.Format("01-02"), formatParameters(fold.Parameters),
+			fold.InSample, fold.OutSample, fold.Evaluated)
+	}
+	if report.Cancelled {
+		fmt.Printf("⚠️  Cancelled after %d folds; no profile written\n", len(report.Folds))
+		return
+	}
+
+	fmt.Printf("\n🏆 Best across folds: %s  mean out-of-sample %s %.2f\n", formatParameters(report.Best), report.Objective, report.BestScore)
+	if *profile != "" {
+		if err := bot.WriteProfile(config, report.Best, *profile); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to write profile: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("💾 Saved to %s\n", *profile)
+	}
+}
+
+// formatParameters prints a parameter set as name=value pairs in name order
+func formatParameters(params map[string]float64) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, fmt.Sprintf("%s=%g", name, params[name]))
+	}
+	return strings.Join(values, " ")
+}